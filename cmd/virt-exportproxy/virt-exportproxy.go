@@ -27,6 +27,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"regexp"
+	"sync"
 
 	kvtls "kubevirt.io/kubevirt/pkg/util/tls"
 
@@ -43,6 +44,8 @@ import (
 	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
 	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/service"
+	"kubevirt.io/kubevirt/pkg/storage/export/proxyauth"
+	"kubevirt.io/kubevirt/pkg/storage/export/signedurl"
 )
 
 const (
@@ -53,6 +56,8 @@ const (
 	apiVersion         = "v1alpha1"
 	exportResourceName = "virtualmachineexports"
 	gv                 = apiGroup + "/" + apiVersion
+
+	exportTokenParam = "x-kubevirt-export-token"
 )
 
 type exportProxyApp struct {
@@ -63,6 +68,12 @@ type exportProxyApp struct {
 	caManager        kvtls.ClientCAManager
 	exportInformer   cache.SharedIndexInformer
 	kubeVirtInformer cache.SharedIndexInformer
+	virtCli          kubecli.KubevirtClient
+	namespace        string
+	tokenReviewer    proxyauth.ReviewFunc
+
+	proxyKeyLock sync.Mutex
+	proxyKey     []byte
 }
 
 func NewExportProxyApp() service.Service {
@@ -135,6 +146,10 @@ func (app *exportProxyApp) proxyHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if !app.authorizeBearerToken(w, r, export) {
+		return
+	}
+
 	host := fmt.Sprintf("%s.%s.svc:443", export.Status.ServiceName, match[1])
 	targetPath := "/" + match[3]
 
@@ -165,6 +180,52 @@ func (app *exportProxyApp) proxyHandler(w http.ResponseWriter, r *http.Request)
 	p.ServeHTTP(w, r)
 }
 
+// authorizeBearerToken reviews the bearer token of requests without export token or pre-signed URL and
+// marks the authorized ones with the proxy token of the export. The bearer token is never forwarded to
+// the exporter pod. It returns false if it rejected the request.
+func (app *exportProxyApp) authorizeBearerToken(w http.ResponseWriter, r *http.Request, export *exportv1.VirtualMachineExport) bool {
+	// only the proxy sets the proxy token
+	r.Header.Del(proxyauth.Header)
+	bearer := proxyauth.BearerToken(r)
+	r.Header.Del("Authorization")
+	if bearer == "" || r.URL.Query().Has(exportTokenParam) || r.Header.Get(exportTokenParam) != "" || signedurl.IsSigned(r.URL) {
+		return true
+	}
+
+	allowed, err := app.tokenReviewer(r.Context(), bearer, export.Namespace, export.Name)
+	if err != nil {
+		log.Log.Reason(err).Error("error reviewing bearer token")
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+	if !allowed {
+		w.WriteHeader(http.StatusForbidden)
+		return false
+	}
+	key, err := app.getProxyKey(r)
+	if err != nil {
+		log.Log.Reason(err).Error("error getting the export proxy key")
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+	r.Header.Set(proxyauth.Header, proxyauth.Token(key, export.UID))
+	return true
+}
+
+// getProxyKey returns the key the proxy tokens are derived from, it never changes once virt-controller generated it
+func (app *exportProxyApp) getProxyKey(r *http.Request) ([]byte, error) {
+	app.proxyKeyLock.Lock()
+	defer app.proxyKeyLock.Unlock()
+	if app.proxyKey == nil {
+		key, err := proxyauth.GetKey(r.Context(), app.virtCli, app.namespace)
+		if err != nil {
+			return nil, err
+		}
+		app.proxyKey = key
+	}
+	return app.proxyKey, nil
+}
+
 func (app *exportProxyApp) prepareInformers(stopChan <-chan struct{}) {
 	namespace, err := clientutil.GetNamespace()
 	if err != nil {
@@ -180,6 +241,9 @@ func (app *exportProxyApp) prepareInformers(stopChan <-chan struct{}) {
 		panic(err)
 	}
 	aggregatorClient := aggregatorclient.NewForConfigOrDie(clientConfig)
+	app.virtCli = virtCli
+	app.namespace = namespace
+	app.tokenReviewer = proxyauth.NewTokenReviewer(virtCli)
 
 	kubeInformerFactory := controller.NewKubeInformerFactory(virtCli.RestClient(), virtCli, aggregatorClient, namespace)
 	caInformer := kubeInformerFactory.KubeVirtExportCAConfigMap()
@@ -21,22 +21,34 @@ package main
 
 import (
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/service"
+	"kubevirt.io/kubevirt/pkg/storage/export/proxyauth"
 
 	exportServer "kubevirt.io/kubevirt/pkg/storage/export/virt-exportserver"
 )
 
 const (
-	listenAddr = ":8443"
+	listenAddr        = ":8443"
+	metricsListenAddr = ":8444"
+
+	pushCommand        = "push"
+	terminationLogPath = "/dev/termination-log"
 )
 
 func main() {
 	log.InitializeLogging("virt-exportserver-" + os.Getenv("POD_NAME"))
+
+	if len(os.Args) > 1 && os.Args[1] == pushCommand {
+		pushContainerDisks()
+		return
+	}
+
 	log.Log.Info("Starting export server")
 
 	certFile, keyFile := getCert()
@@ -47,12 +59,55 @@ func main() {
 		ListenAddr: getListenAddr(),
 		TokenFile:  getTokenFile(),
 		Volumes:    getVolumeInfo(),
+
+		MetricsListenAddr: getMetricsListenAddr(),
+		BandwidthLimit:    getBandwidthLimit(),
+
+		ProxyTokenFile:  os.Getenv("PROXY_TOKEN_FILE"),
+		ExportName:      os.Getenv("EXPORT_NAME"),
+		ExportNamespace: os.Getenv("EXPORT_NAMESPACE"),
+		TokenReviewer:   getTokenReviewer(),
 	}
 	server := exportServer.NewExportServer(config)
 	service.Setup(server)
 	server.Run()
 }
 
+// pushContainerDisks pushes the volumes to the registry once and reports the outcome in the termination message,
+// failures are only reported per volume so the export server still starts after the push
+func pushContainerDisks() {
+	log.Log.Info("Pushing containerDisks")
+
+	result := exportServer.PushContainerDisks(exportServer.PushConfig{
+		ContainerDisks:        getContainerDiskInfo(),
+		AuthFile:              os.Getenv("REGISTRY_AUTH_FILE"),
+		InsecureSkipTLSVerify: os.Getenv("REGISTRY_INSECURE_SKIP_TLS_VERIFY") == "true",
+	})
+	data, err := exportServer.TerminationMessage(result)
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(terminationLogPath, data, 0644); err != nil {
+		log.Log.Reason(err).Error("Failed to write the termination message")
+	}
+}
+
+func getContainerDiskInfo() []exportServer.ContainerDiskInfo {
+	var result []exportServer.ContainerDiskInfo
+	for _, env := range os.Environ() {
+		kv := strings.Split(env, "=")
+		envPrefix := strings.TrimSuffix(kv[0], "_EXPORT_IMAGE")
+		if envPrefix != kv[0] {
+			result = append(result, exportServer.ContainerDiskInfo{
+				Name:  os.Getenv(envPrefix + "_EXPORT_NAME"),
+				Path:  os.Getenv(envPrefix + "_EXPORT_PATH"),
+				Image: kv[1],
+			})
+		}
+	}
+	return result
+}
+
 func getVolumeInfo() []exportServer.VolumeInfo {
 	var result []exportServer.VolumeInfo
 	for _, env := range os.Environ() {
@@ -60,6 +115,7 @@ func getVolumeInfo() []exportServer.VolumeInfo {
 		envPrefix := strings.TrimSuffix(kv[0], "_EXPORT_PATH")
 		if envPrefix != kv[0] {
 			vi := exportServer.VolumeInfo{
+				Name:       os.Getenv(envPrefix + "_EXPORT_NAME"),
 				Path:       kv[1],
 				ArchiveURI: os.Getenv(envPrefix + "_EXPORT_ARCHIVE_URI"),
 				DirURI:     os.Getenv(envPrefix + "_EXPORT_DIR_URI"),
@@ -67,6 +123,7 @@ func getVolumeInfo() []exportServer.VolumeInfo {
 				RawGzURI:   os.Getenv(envPrefix + "_EXPORT_RAW_GZIP_URI"),
 				VMURI:      os.Getenv("EXPORT_VM_DEF_URI"),
 				SecretURI:  os.Getenv("EXPORT_SECRET_DEF_URI"),
+				OvaURI:     os.Getenv("EXPORT_OVA_URI"),
 			}
 			result = append(result, vi)
 		}
@@ -74,6 +131,17 @@ func getVolumeInfo() []exportServer.VolumeInfo {
 	return result
 }
 
+// getTokenReviewer returns the reviewer of the bearer tokens of the requests reaching the export server directly,
+// bearer tokens are only accepted through virt-exportproxy if the API server is not reachable
+func getTokenReviewer() proxyauth.ReviewFunc {
+	clientForToken, err := proxyauth.InClusterClientForToken()
+	if err != nil {
+		log.Log.Reason(err).Info("Bearer token authentication disabled")
+		return nil
+	}
+	return proxyauth.NewSelfSubjectReviewer(clientForToken)
+}
+
 func getTokenFile() string {
 	tokenFile := os.Getenv("TOKEN_FILE")
 	if tokenFile == "" {
@@ -99,6 +167,26 @@ func getListenAddr() string {
 	return listenAddr
 }
 
+func getMetricsListenAddr() string {
+	addr := os.Getenv("METRICS_LISTEN_ADDR")
+	if addr != "" {
+		return addr
+	}
+	return metricsListenAddr
+}
+
+func getBandwidthLimit() int64 {
+	limit := os.Getenv("BANDWIDTH_LIMIT")
+	if limit == "" {
+		return 0
+	}
+	result, err := strconv.ParseInt(limit, 10, 64)
+	if err != nil || result < 0 {
+		panic("Invalid bandwidth limit")
+	}
+	return result
+}
+
 func getDeadline() (result time.Time) {
 	dl := os.Getenv("DEADLINE")
 	if dl != "" {
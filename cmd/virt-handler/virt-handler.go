@@ -58,6 +58,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-handler/node-labeller/api"
 
 	"kubevirt.io/kubevirt/pkg/monitoring/domainstats/downwardmetrics"
+	"kubevirt.io/kubevirt/pkg/monitoring/domainstats/resourceusage"
 
 	"kubevirt.io/kubevirt/pkg/healthz"
 
@@ -78,6 +79,7 @@ import (
 	_ "kubevirt.io/kubevirt/pkg/monitoring/reflector/prometheus" // import for prometheus metrics
 	_ "kubevirt.io/kubevirt/pkg/monitoring/workqueue/prometheus" // import for prometheus metrics
 	"kubevirt.io/kubevirt/pkg/service"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	"kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	virthandler "kubevirt.io/kubevirt/pkg/virt-handler"
@@ -316,6 +318,7 @@ func (app *virtHandlerApp) Run() {
 	// set log verbosity
 	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeLogVerbosity)
 	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeRateLimiter)
+	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeTracing)
 	app.clusterConfig.SetConfigModifiedCallback(app.shouldInstallKubevirtSeccompProfile)
 	app.clusterConfig.SetConfigModifiedCallback(app.shouldEnablePersistentReservation)
 
@@ -361,6 +364,8 @@ func (app *virtHandlerApp) Run() {
 		return
 	}
 
+	resourceUsageRecorder := resourceusage.NewRecorder(resourceusage.RefreshDuration, resourceusage.Window)
+
 	vmController, err := virthandler.NewController(
 		recorder,
 		app.virtCli,
@@ -380,6 +385,7 @@ func (app *virtHandlerApp) Run() {
 		migrationProxy,
 		capabilities,
 		hostCpuModel,
+		resourceUsageRecorder,
 	)
 	if err != nil {
 		panic(err)
@@ -398,6 +404,12 @@ func (app *virtHandlerApp) Run() {
 	if err := downwardmetrics.RunDownwardMetricsCollector(context.Background(), app.HostOverride, vmiSourceInformer, podIsolationDetector); err != nil {
 		panic(fmt.Errorf("failed to set up the downwardMetrics collector: %v", err))
 	}
+	resourceusage.RunResourceUsageCollector(context.Background(), vmiSourceInformer, resourceUsageRecorder, func(vmi *v1.VirtualMachineInstance) {
+		key, err := controller.KeyFunc(vmi)
+		if err == nil {
+			vmController.Queue.Add(key)
+		}
+	})
 
 	go app.clientcertmanager.Start()
 	go app.servercertmanager.Start()
@@ -518,6 +530,11 @@ func (app *virtHandlerApp) shouldChangeRateLimiter() {
 	log.Log.V(2).Infof("setting rate limiter to %v QPS and %v Burst", qps, burst)
 }
 
+// Update virt-handler tracing exporters on relevant config changes
+func (app *virtHandlerApp) shouldChangeTracing() {
+	tracing.Configure("virt-handler", app.clusterConfig.GetTracingConfiguration())
+}
+
 // Install the SELinux policy when the feature gate that disables it gets removed
 func (app *virtHandlerApp) shouldInstallSELinuxPolicy() {
 	app.semoduleLock.Lock()
@@ -0,0 +1,150 @@
+//nolint:lll
+package instancetype
+
+import (
+	"sort"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	apiinstancetype "kubevirt.io/api/instancetype"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+)
+
+const (
+	// RecommendationHeadroomPercent is added on top of the observed peak usage before matching instancetypes
+	RecommendationHeadroomPercent = 20
+	// MinRecommendationSamples is the amount of usage samples required before a recommendation is made
+	MinRecommendationSamples = 60
+)
+
+// Recommend maps the peak resource usage of the VMI to the smallest VirtualMachineClusterInstancetype
+// able to serve it with headroom. Nil is returned as long as not enough usage has been sampled.
+func Recommend(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, clusterInstancetypes []*instancetypev1beta1.VirtualMachineClusterInstancetype, now metav1.Time) *virtv1.VirtualMachineResourceRecommendation {
+	usage := vmi.Status.ResourceUsage
+	if usage == nil || usage.Samples < MinRecommendationSamples {
+		return nil
+	}
+
+	currentCPU, currentMemory := guestResources(vmi)
+
+	recommendation := &virtv1.VirtualMachineResourceRecommendation{
+		CPU:            requiredCPU(usage.PeakCPU),
+		PeakCPU:        usage.PeakCPU,
+		Samples:        usage.Samples,
+		LastUpdateTime: now,
+	}
+	if usage.PeakMemory != nil {
+		recommendation.PeakMemory = usage.PeakMemory
+		recommendation.Memory = requiredMemory(*usage.PeakMemory)
+	} else if currentMemory != nil {
+		// Without memory statistics from the guest the current memory is the only safe choice
+		recommendation.Memory = currentMemory
+	}
+
+	candidate := closestClusterInstancetype(vmi, clusterInstancetypes, recommendation.CPU, recommendation.Memory)
+	if candidate == nil {
+		recommendation.Reason = virtv1.VirtualMachineResourceRecommendationNoMatch
+		return recommendation
+	}
+	recommendation.Instancetype = candidate.Name
+
+	switch {
+	case usesClusterInstancetype(vm, candidate.Name):
+		recommendation.Reason = virtv1.VirtualMachineResourceRecommendationRightSized
+	case currentCPU < recommendation.CPU || (currentMemory != nil && recommendation.Memory != nil && currentMemory.Cmp(*recommendation.Memory) < 0):
+		recommendation.Reason = virtv1.VirtualMachineResourceRecommendationUndersized
+	case candidate.Spec.CPU.Guest < currentCPU || (currentMemory != nil && candidate.Spec.Memory.Guest.Cmp(*currentMemory) < 0):
+		recommendation.Reason = virtv1.VirtualMachineResourceRecommendationOversized
+	default:
+		recommendation.Reason = virtv1.VirtualMachineResourceRecommendationRightSized
+	}
+
+	return recommendation
+}
+
+func requiredCPU(peakCPU resource.Quantity) uint32 {
+	milliCPU := peakCPU.MilliValue() * (100 + RecommendationHeadroomPercent) / 100
+	cpus := uint32((milliCPU + 999) / 1000)
+	if cpus < 1 {
+		cpus = 1
+	}
+	return cpus
+}
+
+func requiredMemory(peakMemory resource.Quantity) *resource.Quantity {
+	return resource.NewQuantity(peakMemory.Value()*(100+RecommendationHeadroomPercent)/100, resource.BinarySI)
+}
+
+func guestResources(vmi *virtv1.VirtualMachineInstance) (uint32, *resource.Quantity) {
+	cpus := uint32(1)
+	if vmi.Spec.Domain.CPU != nil {
+		if vcpus := hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU); vcpus > 0 {
+			cpus = uint32(vcpus)
+		}
+	}
+
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
+		return cpus, vmi.Spec.Domain.Memory.Guest
+	}
+	if memory, exists := vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory]; exists {
+		return cpus, &memory
+	}
+	return cpus, nil
+}
+
+// closestClusterInstancetype returns the smallest instancetype providing the required resources.
+// Only instancetypes of the same class as the VMI are considered, so that a recommendation
+// never drops dedicated CPUs or hugepages, nor adds devices the VMI does not use.
+func closestClusterInstancetype(vmi *virtv1.VirtualMachineInstance, clusterInstancetypes []*instancetypev1beta1.VirtualMachineClusterInstancetype, cpu uint32, memory *resource.Quantity) *instancetypev1beta1.VirtualMachineClusterInstancetype {
+	dedicatedCPU := vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.DedicatedCPUPlacement
+	hugepages := vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Hugepages != nil
+
+	var candidates []*instancetypev1beta1.VirtualMachineClusterInstancetype
+	for _, clusterInstancetype := range clusterInstancetypes {
+		spec := clusterInstancetype.Spec
+		if spec.CPU.Guest < cpu || (memory != nil && spec.Memory.Guest.Cmp(*memory) < 0) {
+			continue
+		}
+		candidateDedicatedCPU := spec.CPU.DedicatedCPUPlacement != nil && *spec.CPU.DedicatedCPUPlacement
+		if candidateDedicatedCPU != dedicatedCPU || (spec.Memory.Hugepages != nil) != hugepages {
+			continue
+		}
+		if len(spec.GPUs) > 0 || len(spec.HostDevices) > 0 {
+			continue
+		}
+		candidates = append(candidates, clusterInstancetype)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i].Spec, candidates[j].Spec
+		if cmp := a.Memory.Guest.Cmp(b.Memory.Guest); cmp != 0 {
+			return cmp < 0
+		}
+		if a.CPU.Guest != b.CPU.Guest {
+			return a.CPU.Guest < b.CPU.Guest
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates[0]
+}
+
+func usesClusterInstancetype(vm *virtv1.VirtualMachine, name string) bool {
+	if vm.Spec.Instancetype == nil || vm.Spec.Instancetype.Name != name {
+		return false
+	}
+	switch strings.ToLower(vm.Spec.Instancetype.Kind) {
+	case apiinstancetype.ClusterSingularResourceName, apiinstancetype.ClusterPluralResourceName, "":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,143 @@
+package instancetype_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	apiinstancetype "kubevirt.io/api/instancetype"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+var _ = Describe("Instancetype recommendation", func() {
+	var (
+		vm                   *v1.VirtualMachine
+		vmi                  *v1.VirtualMachineInstance
+		clusterInstancetypes []*instancetypev1beta1.VirtualMachineClusterInstancetype
+		now                  metav1.Time
+	)
+
+	newClusterInstancetype := func(name string, cpu uint32, memory string) *instancetypev1beta1.VirtualMachineClusterInstancetype {
+		return &instancetypev1beta1.VirtualMachineClusterInstancetype{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: instancetypev1beta1.VirtualMachineInstancetypeSpec{
+				CPU:    instancetypev1beta1.CPUInstancetype{Guest: cpu},
+				Memory: instancetypev1beta1.MemoryInstancetype{Guest: resource.MustParse(memory)},
+			},
+		}
+	}
+
+	withUsage := func(peakCPU, peakMemory string) {
+		usage := &v1.VirtualMachineInstanceResourceUsage{
+			PeakCPU: resource.MustParse(peakCPU),
+			Samples: instancetype.MinRecommendationSamples,
+		}
+		if peakMemory != "" {
+			usage.PeakMemory = pointer.P(resource.MustParse(peakMemory))
+		}
+		vmi.Status.ResourceUsage = usage
+	}
+
+	BeforeEach(func() {
+		vm = &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				Instancetype: &v1.InstancetypeMatcher{
+					Kind: apiinstancetype.ClusterSingularResourceName,
+					Name: "u1.xlarge",
+				},
+			},
+		}
+		vmi = &v1.VirtualMachineInstance{
+			Spec: v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{
+					CPU:    &v1.CPU{Sockets: 4, Cores: 1, Threads: 1},
+					Memory: &v1.Memory{Guest: pointer.P(resource.MustParse("16Gi"))},
+				},
+			},
+		}
+		clusterInstancetypes = []*instancetypev1beta1.VirtualMachineClusterInstancetype{
+			newClusterInstancetype("u1.xlarge", 4, "16Gi"),
+			newClusterInstancetype("u1.medium", 1, "4Gi"),
+			newClusterInstancetype("u1.large", 2, "8Gi"),
+			newClusterInstancetype("cx1.medium", 1, "2Gi"),
+		}
+		clusterInstancetypes[3].Spec.CPU.DedicatedCPUPlacement = pointer.P(true)
+		now = metav1.Now()
+	})
+
+	It("should not recommend anything without enough samples", func() {
+		Expect(instancetype.Recommend(vm, vmi, clusterInstancetypes, now)).To(BeNil())
+
+		withUsage("500m", "1Gi")
+		vmi.Status.ResourceUsage.Samples = instancetype.MinRecommendationSamples - 1
+		Expect(instancetype.Recommend(vm, vmi, clusterInstancetypes, now)).To(BeNil())
+	})
+
+	It("should recommend the smallest instancetype of the same class serving the peak usage with headroom", func() {
+		withUsage("500m", "3Gi")
+
+		recommendation := instancetype.Recommend(vm, vmi, clusterInstancetypes, now)
+		Expect(recommendation).To(Equal(&v1.VirtualMachineResourceRecommendation{
+			Instancetype:   "u1.medium",
+			Reason:         v1.VirtualMachineResourceRecommendationOversized,
+			CPU:            1,
+			Memory:         resource.NewQuantity(3*1024*1024*1024*120/100, resource.BinarySI),
+			PeakCPU:        resource.MustParse("500m"),
+			PeakMemory:     pointer.P(resource.MustParse("3Gi")),
+			Samples:        instancetype.MinRecommendationSamples,
+			LastUpdateTime: now,
+		}))
+	})
+
+	It("should keep a VM using the recommended instancetype right sized", func() {
+		withUsage("3", "12Gi")
+
+		recommendation := instancetype.Recommend(vm, vmi, clusterInstancetypes, now)
+		Expect(recommendation.Instancetype).To(Equal("u1.xlarge"))
+		Expect(recommendation.Reason).To(Equal(v1.VirtualMachineResourceRecommendationRightSized))
+	})
+
+	It("should flag a VM which needs more resources than it has", func() {
+		vm.Spec.Instancetype = nil
+		vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 1, Cores: 1, Threads: 1}
+		vmi.Spec.Domain.Memory.Guest = pointer.P(resource.MustParse("2Gi"))
+		withUsage("1500m", "3Gi")
+
+		recommendation := instancetype.Recommend(vm, vmi, clusterInstancetypes, now)
+		Expect(recommendation.CPU).To(Equal(uint32(2)))
+		Expect(recommendation.Instancetype).To(Equal("u1.large"))
+		Expect(recommendation.Reason).To(Equal(v1.VirtualMachineResourceRecommendationUndersized))
+	})
+
+	It("should not shrink the memory when the guest does not report memory statistics", func() {
+		withUsage("500m", "")
+
+		recommendation := instancetype.Recommend(vm, vmi, clusterInstancetypes, now)
+		Expect(recommendation.Memory).To(Equal(pointer.P(resource.MustParse("16Gi"))))
+		Expect(recommendation.Instancetype).To(Equal("u1.xlarge"))
+		Expect(recommendation.Reason).To(Equal(v1.VirtualMachineResourceRecommendationRightSized))
+	})
+
+	It("should only consider instancetypes with dedicated CPUs for a VM with dedicated CPUs", func() {
+		vmi.Spec.Domain.CPU.DedicatedCPUPlacement = true
+		withUsage("500m", "1Gi")
+
+		recommendation := instancetype.Recommend(vm, vmi, clusterInstancetypes, now)
+		Expect(recommendation.Instancetype).To(Equal("cx1.medium"))
+		Expect(recommendation.Reason).To(Equal(v1.VirtualMachineResourceRecommendationOversized))
+	})
+
+	It("should report when no instancetype serves the peak usage", func() {
+		withUsage("8", "1Gi")
+
+		recommendation := instancetype.Recommend(vm, vmi, clusterInstancetypes, now)
+		Expect(recommendation.Instancetype).To(BeEmpty())
+		Expect(recommendation.CPU).To(Equal(uint32(10)))
+		Expect(recommendation.Reason).To(Equal(v1.VirtualMachineResourceRecommendationNoMatch))
+	})
+})
@@ -0,0 +1,287 @@
+//nolint:lll
+package instancetype
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	virtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+)
+
+// Diff compares the instancetype and preference ControllerRevisions referenced by the VirtualMachine
+// with the current instancetype and preference objects the VirtualMachine is matched against
+func Diff(methods Methods, vm *virtv1.VirtualMachine) (*virtv1.VirtualMachineInstancetypeDiff, error) {
+	diff := &virtv1.VirtualMachineInstancetypeDiff{}
+
+	currentVM := withoutRevisionNames(vm)
+
+	if vm.Spec.Instancetype != nil {
+		stored, err := methods.FindInstancetypeSpec(vm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the stored instancetype: %w", err)
+		}
+		current, err := methods.FindInstancetypeSpec(currentVM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the current instancetype: %w", err)
+		}
+		diff.Instancetype, err = newRevisionDiff(vm.Spec.Instancetype.Kind, vm.Spec.Instancetype.Name, vm.Spec.Instancetype.RevisionName, stored, current)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if vm.Spec.Preference != nil {
+		stored, err := methods.FindPreferenceSpec(vm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the stored preference: %w", err)
+		}
+		current, err := methods.FindPreferenceSpec(currentVM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the current preference: %w", err)
+		}
+		diff.Preference, err = newRevisionDiff(vm.Spec.Preference.Kind, vm.Spec.Preference.Name, vm.Spec.Preference.RevisionName, stored, current)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return diff, nil
+}
+
+// IsUpToDate returns true when neither the instancetype nor the preference of the VirtualMachine changed
+// since their ControllerRevisions were stored
+func IsUpToDate(diff *virtv1.VirtualMachineInstancetypeDiff) bool {
+	return (diff.Instancetype == nil || diff.Instancetype.UpToDate) &&
+		(diff.Preference == nil || diff.Preference.UpToDate)
+}
+
+// GenerateUpgradePatch returns a patch removing the revision names of the outdated matchers of the VirtualMachine.
+// The VirtualMachine controller then stores ControllerRevisions of the current objects, which are applied
+// with the next start of the VirtualMachine.
+func GenerateUpgradePatch(diff *virtv1.VirtualMachineInstancetypeDiff) ([]byte, error) {
+	var patches []patch.PatchOperation
+
+	if diff.Instancetype != nil && !diff.Instancetype.UpToDate && diff.Instancetype.RevisionName != "" {
+		patches = append(patches,
+			patch.PatchOperation{
+				Op:    patch.PatchTestOp,
+				Path:  "/spec/instancetype/revisionName",
+				Value: diff.Instancetype.RevisionName,
+			},
+			patch.PatchOperation{
+				Op:   patch.PatchRemoveOp,
+				Path: "/spec/instancetype/revisionName",
+			},
+		)
+	}
+
+	if diff.Preference != nil && !diff.Preference.UpToDate && diff.Preference.RevisionName != "" {
+		patches = append(patches,
+			patch.PatchOperation{
+				Op:    patch.PatchTestOp,
+				Path:  "/spec/preference/revisionName",
+				Value: diff.Preference.RevisionName,
+			},
+			patch.PatchOperation{
+				Op:   patch.PatchRemoveOp,
+				Path: "/spec/preference/revisionName",
+			},
+		)
+	}
+
+	if len(patches) == 0 {
+		return nil, nil
+	}
+
+	payload, err := patch.GeneratePatchPayload(patches...)
+	if err != nil {
+		// This is a programmer's error and should not happen
+		return nil, fmt.Errorf("failed to generate patch payload: %w", err)
+	}
+
+	return payload, nil
+}
+
+// CurrentCPUTopology returns the CPU topology the VirtualMachine gets from the current instancetype and preference objects
+func CurrentCPUTopology(methods Methods, vm *virtv1.VirtualMachine) (*virtv1.CPU, error) {
+	currentVM := withoutRevisionNames(vm)
+
+	instancetypeSpec, err := methods.FindInstancetypeSpec(currentVM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the current instancetype: %w", err)
+	}
+	preferenceSpec, err := methods.FindPreferenceSpec(currentVM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the current preference: %w", err)
+	}
+
+	vmiSpec := &virtv1.VirtualMachineInstanceSpec{}
+	if currentVM.Spec.Template != nil {
+		vmiSpec = currentVM.Spec.Template.Spec.DeepCopy()
+	}
+	if conflicts := methods.ApplyToVmi(k8sfield.NewPath("spec"), instancetypeSpec, preferenceSpec, vmiSpec); len(conflicts) > 0 {
+		return nil, fmt.Errorf("VM conflicts with instancetype spec in fields: [%s]", conflicts.String())
+	}
+
+	return vmiSpec.Domain.CPU, nil
+}
+
+// CanHotplugCPU returns true when the running VirtualMachineInstance can reach the CPU topology by hotplugging sockets.
+// Changes of the cores or threads and sockets beyond the maximum of the VirtualMachineInstance need a restart.
+func CanHotplugCPU(vmi *virtv1.VirtualMachineInstance, cpu *virtv1.CPU) bool {
+	vmiCPU := vmi.Spec.Domain.CPU
+	if vmiCPU == nil || cpu == nil || vmiCPU.MaxSockets == 0 {
+		return false
+	}
+
+	return cpu.Cores == vmiCPU.Cores && cpu.Threads == vmiCPU.Threads &&
+		cpu.Sockets != vmiCPU.Sockets && cpu.Sockets <= vmiCPU.MaxSockets
+}
+
+// GenerateCPUHotplugPatch returns a patch setting the sockets of the VirtualMachineInstance to those of the CPU topology
+func GenerateCPUHotplugPatch(vmi *virtv1.VirtualMachineInstance, cpu *virtv1.CPU) ([]byte, error) {
+	payload, err := patch.GeneratePatchPayload(
+		patch.PatchOperation{
+			Op:    patch.PatchTestOp,
+			Path:  "/spec/domain/cpu/sockets",
+			Value: vmi.Spec.Domain.CPU.Sockets,
+		},
+		patch.PatchOperation{
+			Op:    patch.PatchReplaceOp,
+			Path:  "/spec/domain/cpu/sockets",
+			Value: cpu.Sockets,
+		},
+	)
+	if err != nil {
+		// This is a programmer's error and should not happen
+		return nil, fmt.Errorf("failed to generate patch payload: %w", err)
+	}
+
+	return payload, nil
+}
+
+// withoutRevisionNames returns a copy of the VirtualMachine the methods resolve the current objects for
+func withoutRevisionNames(vm *virtv1.VirtualMachine) *virtv1.VirtualMachine {
+	currentVM := vm.DeepCopy()
+	if currentVM.Spec.Instancetype != nil {
+		currentVM.Spec.Instancetype.RevisionName = ""
+	}
+	if currentVM.Spec.Preference != nil {
+		currentVM.Spec.Preference.RevisionName = ""
+	}
+	return currentVM
+}
+
+func newRevisionDiff(kind, name, revisionName string, stored, current interface{}) (*virtv1.InstancetypeRevisionDiff, error) {
+	changes, err := diffSpecs(stored, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return &virtv1.InstancetypeRevisionDiff{
+		Kind:         kind,
+		Name:         name,
+		RevisionName: revisionName,
+		UpToDate:     len(changes) == 0,
+		Changes:      changes,
+	}, nil
+}
+
+func diffSpecs(stored, current interface{}) ([]virtv1.InstancetypeSpecChange, error) {
+	storedFields, err := flattenSpec(stored)
+	if err != nil {
+		return nil, err
+	}
+	currentFields, err := flattenSpec(current)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := map[string]struct{}{}
+	for path := range storedFields {
+		paths[path] = struct{}{}
+	}
+	for path := range currentFields {
+		paths[path] = struct{}{}
+	}
+
+	var changes []virtv1.InstancetypeSpecChange
+	for path := range paths {
+		storedValue, hasStored := storedFields[path]
+		currentValue, hasCurrent := currentFields[path]
+		if hasStored == hasCurrent && reflect.DeepEqual(storedValue, currentValue) {
+			continue
+		}
+
+		change := virtv1.InstancetypeSpecChange{Path: path}
+		if hasStored {
+			if change.Stored, err = encodeValue(storedValue); err != nil {
+				return nil, err
+			}
+		}
+		if hasCurrent {
+			if change.Current, err = encodeValue(currentValue); err != nil {
+				return nil, err
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes, nil
+}
+
+// flattenSpec maps the dotted path of every leaf within the JSON representation of spec to its value,
+// lists are treated as a single value
+func flattenSpec(spec interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if spec == nil || reflect.ValueOf(spec).IsNil() {
+		return fields, nil
+	}
+
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec: %w", err)
+	}
+
+	var specMap map[string]interface{}
+	if err := json.Unmarshal(specJSON, &specMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal spec: %w", err)
+	}
+
+	flattenInto(fields, "", specMap)
+	return fields, nil
+}
+
+func flattenInto(fields map[string]interface{}, prefix string, value interface{}) {
+	object, isObject := value.(map[string]interface{})
+	if !isObject {
+		fields[prefix] = value
+		return
+	}
+
+	for key, child := range object {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		flattenInto(fields, path, child)
+	}
+}
+
+func encodeValue(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal value: %w", err)
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,203 @@
+package instancetype_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/api/core/v1"
+	apiinstancetype "kubevirt.io/api/instancetype"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Instancetype upgrade", func() {
+	const (
+		instancetypeName     = "instancetype"
+		instancetypeRevision = "vm-instancetype-1"
+		preferenceName       = "preference"
+		preferenceRevision   = "vm-preference-1"
+	)
+
+	var (
+		vm                  *v1.VirtualMachine
+		instancetypeMethods *testutils.MockInstancetypeMethods
+		storedInstancetype  *instancetypev1beta1.VirtualMachineInstancetypeSpec
+		currentInstancetype *instancetypev1beta1.VirtualMachineInstancetypeSpec
+		storedPreference    *instancetypev1beta1.VirtualMachinePreferenceSpec
+		currentPreference   *instancetypev1beta1.VirtualMachinePreferenceSpec
+	)
+
+	BeforeEach(func() {
+		vm = &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				Instancetype: &v1.InstancetypeMatcher{
+					Kind:         apiinstancetype.ClusterSingularResourceName,
+					Name:         instancetypeName,
+					RevisionName: instancetypeRevision,
+				},
+				Preference: &v1.PreferenceMatcher{
+					Kind:         apiinstancetype.ClusterSingularPreferenceResourceName,
+					Name:         preferenceName,
+					RevisionName: preferenceRevision,
+				},
+			},
+		}
+
+		storedInstancetype = &instancetypev1beta1.VirtualMachineInstancetypeSpec{
+			CPU: instancetypev1beta1.CPUInstancetype{
+				Guest: 2,
+			},
+			Memory: instancetypev1beta1.MemoryInstancetype{
+				Guest: resource.MustParse("1Gi"),
+			},
+		}
+		currentInstancetype = storedInstancetype.DeepCopy()
+
+		storedPreference = &instancetypev1beta1.VirtualMachinePreferenceSpec{
+			CPU: &instancetypev1beta1.CPUPreferences{
+				PreferredCPUTopology: pointer.P(instancetypev1beta1.PreferSockets),
+			},
+		}
+		currentPreference = storedPreference.DeepCopy()
+
+		instancetypeMethods = testutils.NewMockInstancetypeMethods()
+		instancetypeMethods.FindInstancetypeSpecFunc = func(vm *v1.VirtualMachine) (*instancetypev1beta1.VirtualMachineInstancetypeSpec, error) {
+			if vm.Spec.Instancetype.RevisionName != "" {
+				return storedInstancetype, nil
+			}
+			return currentInstancetype, nil
+		}
+		instancetypeMethods.FindPreferenceSpecFunc = func(vm *v1.VirtualMachine) (*instancetypev1beta1.VirtualMachinePreferenceSpec, error) {
+			if vm.Spec.Preference.RevisionName != "" {
+				return storedPreference, nil
+			}
+			return currentPreference, nil
+		}
+	})
+
+	Context("Diff", func() {
+		It("should report the VM as up to date when nothing changed", func() {
+			diff, err := instancetype.Diff(instancetypeMethods, vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.Instancetype.UpToDate).To(BeTrue())
+			Expect(diff.Instancetype.Changes).To(BeEmpty())
+			Expect(diff.Instancetype.RevisionName).To(Equal(instancetypeRevision))
+			Expect(diff.Preference.UpToDate).To(BeTrue())
+			Expect(instancetype.IsUpToDate(diff)).To(BeTrue())
+		})
+
+		It("should report the changed fields of the instancetype", func() {
+			currentInstancetype.CPU.Guest = 4
+			currentInstancetype.Memory.Guest = resource.MustParse("2Gi")
+
+			diff, err := instancetype.Diff(instancetypeMethods, vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.Instancetype.UpToDate).To(BeFalse())
+			Expect(diff.Instancetype.Changes).To(Equal([]v1.InstancetypeSpecChange{
+				{Path: "cpu.guest", Stored: "2", Current: "4"},
+				{Path: "memory.guest", Stored: `"1Gi"`, Current: `"2Gi"`},
+			}))
+			Expect(diff.Preference.UpToDate).To(BeTrue())
+			Expect(instancetype.IsUpToDate(diff)).To(BeFalse())
+		})
+
+		It("should report added and removed fields of the preference", func() {
+			currentPreference.CPU = nil
+			currentPreference.Machine = &instancetypev1beta1.MachinePreferences{
+				PreferredMachineType: "q35",
+			}
+
+			diff, err := instancetype.Diff(instancetypeMethods, vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.Preference.UpToDate).To(BeFalse())
+			Expect(diff.Preference.Changes).To(Equal([]v1.InstancetypeSpecChange{
+				{Path: "cpu.preferredCPUTopology", Stored: `"preferSockets"`},
+				{Path: "machine.preferredMachineType", Current: `"q35"`},
+			}))
+		})
+
+		It("should only report matchers present on the VM", func() {
+			vm.Spec.Preference = nil
+
+			diff, err := instancetype.Diff(instancetypeMethods, vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.Instancetype).ToNot(BeNil())
+			Expect(diff.Preference).To(BeNil())
+		})
+	})
+
+	Context("GenerateUpgradePatch", func() {
+		It("should not generate a patch when the VM is up to date", func() {
+			diff, err := instancetype.Diff(instancetypeMethods, vm)
+			Expect(err).ToNot(HaveOccurred())
+
+			payload, err := instancetype.GenerateUpgradePatch(diff)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload).To(BeNil())
+		})
+
+		It("should only remove the revision names of outdated matchers", func() {
+			currentInstancetype.CPU.Guest = 4
+
+			diff, err := instancetype.Diff(instancetypeMethods, vm)
+			Expect(err).ToNot(HaveOccurred())
+
+			payload, err := instancetype.GenerateUpgradePatch(diff)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(payload)).To(Equal(`[{"op":"test","path":"/spec/instancetype/revisionName","value":"vm-instancetype-1"},{"op":"remove","path":"/spec/instancetype/revisionName","value":null}]`))
+		})
+	})
+
+	Context("CPU hotplug", func() {
+		It("should resolve the CPU topology of the current objects", func() {
+			currentInstancetype.CPU.Guest = 4
+			instancetypeMethods.ApplyToVmiFunc = func(_ *k8sfield.Path, instancetypeSpec *instancetypev1beta1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec, vmiSpec *v1.VirtualMachineInstanceSpec) instancetype.Conflicts {
+				Expect(instancetypeSpec).To(BeIdenticalTo(currentInstancetype))
+				Expect(preferenceSpec).To(BeIdenticalTo(currentPreference))
+				vmiSpec.Domain.CPU = &v1.CPU{Sockets: instancetypeSpec.CPU.Guest, Cores: 1, Threads: 1}
+				return nil
+			}
+
+			cpu, err := instancetype.CurrentCPUTopology(instancetypeMethods, vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cpu).To(Equal(&v1.CPU{Sockets: 4, Cores: 1, Threads: 1}))
+			Expect(vm.Spec.Instancetype.RevisionName).To(Equal(instancetypeRevision))
+		})
+
+		DescribeTable("should hotplug only", func(cpu *v1.CPU, expected bool) {
+			vmi := &v1.VirtualMachineInstance{
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU: &v1.CPU{Sockets: 2, Cores: 2, Threads: 1, MaxSockets: 8},
+					},
+				},
+			}
+			Expect(instancetype.CanHotplugCPU(vmi, cpu)).To(Equal(expected))
+		},
+			Entry("changed sockets", &v1.CPU{Sockets: 4, Cores: 2, Threads: 1}, true),
+			Entry("but not unchanged sockets", &v1.CPU{Sockets: 2, Cores: 2, Threads: 1}, false),
+			Entry("but not changed cores", &v1.CPU{Sockets: 2, Cores: 4, Threads: 1}, false),
+			Entry("but not changed threads", &v1.CPU{Sockets: 2, Cores: 2, Threads: 2}, false),
+			Entry("but not sockets beyond the maximum", &v1.CPU{Sockets: 16, Cores: 2, Threads: 1}, false),
+		)
+
+		It("should replace the sockets of the VMI", func() {
+			vmi := &v1.VirtualMachineInstance{
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU: &v1.CPU{Sockets: 2, MaxSockets: 8},
+					},
+				},
+			}
+
+			payload, err := instancetype.GenerateCPUHotplugPatch(vmi, &v1.CPU{Sockets: 4})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(payload)).To(Equal(`[{"op":"test","path":"/spec/domain/cpu/sockets","value":2},{"op":"replace","path":"/spec/domain/cpu/sockets","value":4}]`))
+		})
+	})
+})
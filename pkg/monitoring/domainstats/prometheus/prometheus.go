@@ -22,6 +22,7 @@ package prometheus
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -48,6 +49,10 @@ const (
 	MigrateVmiDirtyMemoryRateMetricName    = "kubevirt_migrate_vmi_dirty_memory_rate_bytes"
 	MigrateVmiMemoryTransferRateMetricName = "kubevirt_migrate_vmi_memory_transfer_rate_bytes"
 	MigrateVmiDiskTransferRateMetricName   = "kubevirt_migrate_vmi_disk_transfer_rate_bytes"
+	VmiDirtyRateMetricName                 = "kubevirt_vmi_dirty_rate_bytes_per_second"
+
+	// the guest kernel always accounts block device I/O in 512 byte sectors
+	guestSectorSize = 512
 )
 
 var (
@@ -122,6 +127,21 @@ func (metrics *vmiMetrics) updateMigrateInfo(jobInfo *stats.DomainJobInfo) {
 	}
 }
 
+func (metrics *vmiMetrics) updateDirtyRate(dirtyRate *stats.DomainStatsDirtyRate) {
+	if dirtyRate == nil || !dirtyRate.CalcStatusSet || dirtyRate.CalcStatus != stats.DirtyRateMeasured {
+		return
+	}
+
+	if dirtyRate.MegabytesPerSecondSet {
+		metrics.pushCommonMetric(
+			VmiDirtyRateMetricName,
+			"Guest memory dirty rate in bytes per second, as measured by the last periodic dirty-rate sample.",
+			prometheus.GaugeValue,
+			float64(dirtyRate.MegabytesPerSecond)*1024*1024,
+		)
+	}
+}
+
 func (metrics *vmiMetrics) updateMemory(mem *stats.DomainStatsMemory) {
 	if mem.RSSSet {
 		metrics.pushCommonMetric(
@@ -552,6 +572,152 @@ func (metrics *vmiMetrics) updateFilesystem(vmFSStats k6tv1.VirtualMachineInstan
 	}
 }
 
+func (metrics *vmiMetrics) updateGuestMetrics(guestMetrics *k6tv1.VirtualMachineInstanceGuestMetrics) {
+	if guestMetrics == nil {
+		return
+	}
+
+	if guestMetrics.Load != nil {
+		metrics.pushCommonMetric(
+			"kubevirt_vmi_guest_load_1m",
+			"Guest system load average over 1 minute as reported by the guest agent.",
+			prometheus.GaugeValue,
+			guestMetrics.Load.Load1,
+		)
+		metrics.pushCommonMetric(
+			"kubevirt_vmi_guest_load_5m",
+			"Guest system load average over 5 minutes as reported by the guest agent.",
+			prometheus.GaugeValue,
+			guestMetrics.Load.Load5,
+		)
+		metrics.pushCommonMetric(
+			"kubevirt_vmi_guest_load_15m",
+			"Guest system load average over 15 minutes as reported by the guest agent.",
+			prometheus.GaugeValue,
+			guestMetrics.Load.Load15,
+		)
+	}
+
+	metrics.updateGuestCPUStats(guestMetrics.CPUStats)
+	metrics.updateGuestDiskStats(guestMetrics.DiskStats)
+}
+
+func (metrics *vmiMetrics) updateGuestCPUStats(cpuStats []k6tv1.VirtualMachineInstanceGuestCPUStats) {
+	cpuLabels := []string{"cpu", "mode"}
+
+	for _, cpu := range cpuStats {
+		modes := []struct {
+			name         string
+			milliseconds uint64
+		}{
+			{"user", cpu.User},
+			{"nice", cpu.Nice},
+			{"system", cpu.System},
+			{"idle", cpu.Idle},
+			{"iowait", cpu.IOWait},
+			{"irq", cpu.IRQ},
+			{"softirq", cpu.SoftIRQ},
+			{"steal", cpu.Steal},
+		}
+		var totalMilliseconds uint64
+		for _, mode := range modes {
+			totalMilliseconds += mode.milliseconds
+			metrics.pushCustomMetric(
+				"kubevirt_vmi_guest_cpu_seconds_total",
+				"Total time each guest cpu spent in the different modes, as perceived by the guest.",
+				prometheus.CounterValue,
+				float64(mode.milliseconds)/1000,
+				cpuLabels,
+				[]string{strconv.Itoa(cpu.CPU), mode.name},
+			)
+		}
+
+		// The guest agent has no uptime command, every cpu which is online since boot
+		// accounts for each tick in exactly one mode though.
+		if cpu.CPU == 0 {
+			metrics.pushCommonMetric(
+				"kubevirt_vmi_guest_uptime_seconds",
+				"Time since the guest OS booted, derived from the cpu time accounting of the first guest cpu.",
+				prometheus.GaugeValue,
+				float64(totalMilliseconds)/1000,
+			)
+		}
+	}
+}
+
+func (metrics *vmiMetrics) updateGuestDiskStats(diskStats []k6tv1.VirtualMachineInstanceGuestDiskStats) {
+	diskLabels := []string{"disk_name"}
+
+	for _, disk := range diskStats {
+		diskLabelValues := []string{disk.Name}
+
+		metrics.pushCustomMetric(
+			"kubevirt_vmi_guest_disk_reads_total",
+			"Total number of read requests completed by a whole guest disk, as perceived by the guest.",
+			prometheus.CounterValue,
+			float64(disk.ReadIOs),
+			diskLabels,
+			diskLabelValues,
+		)
+		metrics.pushCustomMetric(
+			"kubevirt_vmi_guest_disk_read_bytes_total",
+			"Total number of bytes read by a whole guest disk, as perceived by the guest.",
+			prometheus.CounterValue,
+			float64(disk.ReadSectors*guestSectorSize),
+			diskLabels,
+			diskLabelValues,
+		)
+		metrics.pushCustomMetric(
+			"kubevirt_vmi_guest_disk_read_time_seconds_total",
+			"Total time spent on read requests by a whole guest disk, as perceived by the guest.",
+			prometheus.CounterValue,
+			float64(disk.ReadTicks)/1000,
+			diskLabels,
+			diskLabelValues,
+		)
+		metrics.pushCustomMetric(
+			"kubevirt_vmi_guest_disk_writes_total",
+			"Total number of write requests completed by a whole guest disk, as perceived by the guest.",
+			prometheus.CounterValue,
+			float64(disk.WriteIOs),
+			diskLabels,
+			diskLabelValues,
+		)
+		metrics.pushCustomMetric(
+			"kubevirt_vmi_guest_disk_written_bytes_total",
+			"Total number of bytes written by a whole guest disk, as perceived by the guest.",
+			prometheus.CounterValue,
+			float64(disk.WriteSectors*guestSectorSize),
+			diskLabels,
+			diskLabelValues,
+		)
+		metrics.pushCustomMetric(
+			"kubevirt_vmi_guest_disk_write_time_seconds_total",
+			"Total time spent on write requests by a whole guest disk, as perceived by the guest.",
+			prometheus.CounterValue,
+			float64(disk.WriteTicks)/1000,
+			diskLabels,
+			diskLabelValues,
+		)
+		metrics.pushCustomMetric(
+			"kubevirt_vmi_guest_disk_io_time_seconds_total",
+			"Total time a whole guest disk spent doing I/Os, as perceived by the guest.",
+			prometheus.CounterValue,
+			float64(disk.TotalTicks)/1000,
+			diskLabels,
+			diskLabelValues,
+		)
+		metrics.pushCustomMetric(
+			"kubevirt_vmi_guest_disk_io_in_progress",
+			"Number of I/Os currently in progress on a whole guest disk, as perceived by the guest.",
+			prometheus.GaugeValue,
+			float64(disk.InFlightIOs),
+			diskLabels,
+			diskLabelValues,
+		)
+	}
+}
+
 func updateVersion(ch chan<- prometheus.Metric) {
 	verinfo := version.Get()
 	ch <- prometheus.MustNewConstMetric(
@@ -616,8 +782,9 @@ type prometheusScraper struct {
 }
 
 type VirtualMachineInstanceStats struct {
-	DomainStats *stats.DomainStats
-	FsStats     k6tv1.VirtualMachineInstanceFileSystemList
+	DomainStats  *stats.DomainStats
+	FsStats      k6tv1.VirtualMachineInstanceFileSystemList
+	GuestMetrics *k6tv1.VirtualMachineInstanceGuestMetrics
 }
 
 func (ps *prometheusScraper) Scrape(socketFile string, vmi *k6tv1.VirtualMachineInstance) {
@@ -652,6 +819,14 @@ func (ps *prometheusScraper) Scrape(socketFile string, vmi *k6tv1.VirtualMachine
 		return
 	}
 
+	guestInfo, err := cli.GetGuestInfo()
+	if err != nil {
+		// the guest metrics are optional, don't drop the hypervisor side metrics
+		log.Log.Reason(err).Warningf("failed to update guest metrics from socket %s", socketFile)
+	} else {
+		vmStats.GuestMetrics = guestInfo.Metrics
+	}
+
 	// GetDomainStats() may hang for a long time.
 	// If it wakes up past the timeout, there is no point in send back any metric.
 	// In the best case the information is stale, in the worst case the information is stale *and*
@@ -708,12 +883,14 @@ func (metrics *vmiMetrics) updateMetrics(vmi *k6tv1.VirtualMachineInstance, vmSt
 	metrics.updateVcpu(vmStats.DomainStats.Vcpu)
 	metrics.updateBlock(vmStats.DomainStats.Block)
 	metrics.updateNetwork(vmStats.DomainStats.Net)
+	metrics.updateDirtyRate(vmStats.DomainStats.DirtyRate)
 
 	if vmStats.DomainStats.CPUMapSet {
 		metrics.updateCPUAffinity(vmStats.DomainStats.CPUMap)
 	}
 	metrics.updateMigrateInfo(vmStats.DomainStats.MigrateDomainJobInfo)
 	metrics.updateFilesystem(vmStats.FsStats)
+	metrics.updateGuestMetrics(vmStats.GuestMetrics)
 }
 
 func (metrics *vmiMetrics) newPrometheusDesc(name string, help string, customLabels []string) *prometheus.Desc {
@@ -21,6 +21,7 @@ package prometheus
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	io_prometheus_client "github.com/prometheus/client_model/go"
@@ -404,6 +405,56 @@ var _ = Describe("Prometheus", func() {
 				}),
 		)
 
+		It("should handle dirty rate metrics", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			domainStats := &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{},
+				DirtyRate: &stats.DomainStatsDirtyRate{
+					CalcStatusSet:         true,
+					CalcStatus:            stats.DirtyRateMeasured,
+					MegabytesPerSecondSet: true,
+					MegabytesPerSecond:    2,
+				},
+			}
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, newVmStats(domainStats, nil))
+
+			result := <-ch
+			dto := &io_prometheus_client.Metric{}
+			result.Write(dto)
+
+			Expect(result).ToNot(BeNil())
+			Expect(result.Desc().String()).To(ContainSubstring(VmiDirtyRateMetricName))
+			Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(float64(2 * 1024 * 1024)))
+		})
+
+		It("should not report dirty rate metrics while the calculation is in progress", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			domainStats := &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{},
+				DirtyRate: &stats.DomainStatsDirtyRate{
+					CalcStatusSet:         true,
+					CalcStatus:            1,
+					MegabytesPerSecondSet: true,
+					MegabytesPerSecond:    2,
+				},
+			}
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, newVmStats(domainStats, nil))
+
+			Expect(ch).To(BeEmpty())
+		})
+
 		It("should handle vcpu metrics", func() {
 			ch := make(chan prometheus.Metric, 1)
 			defer close(ch)
@@ -1213,6 +1264,61 @@ var _ = Describe("Prometheus", func() {
 			Expect(ch).To(BeEmpty())
 		})
 
+		It("should expose guest metrics", func() {
+			ch := make(chan prometheus.Metric, 100)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			domainStats := &stats.DomainStats{
+				Cpu:                  &stats.DomainStatsCPU{},
+				Memory:               &stats.DomainStatsMemory{},
+				Net:                  []stats.DomainStatsNet{},
+				MigrateDomainJobInfo: &stats.DomainJobInfo{},
+			}
+
+			vmStats := newVmStats(domainStats, nil)
+			vmStats.GuestMetrics = &k6tv1.VirtualMachineInstanceGuestMetrics{
+				Load:      &k6tv1.VirtualMachineInstanceGuestLoad{Load1: 1.5, Load5: 1, Load15: 0.5},
+				CPUStats:  []k6tv1.VirtualMachineInstanceGuestCPUStats{{CPU: 0, User: 2000, Idle: 8000}},
+				DiskStats: []k6tv1.VirtualMachineInstanceGuestDiskStats{{Name: "vda", ReadIOs: 10, ReadSectors: 4, ReadTicks: 1500}},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			values := map[string]float64{}
+			for len(ch) > 0 {
+				result := <-ch
+				dto := &io_prometheus_client.Metric{}
+				Expect(result.Write(dto)).To(Succeed())
+				labels := []string{}
+				for _, label := range dto.GetLabel() {
+					if label.GetName() == "mode" || label.GetName() == "disk_name" {
+						labels = append(labels, label.GetValue())
+					}
+				}
+				name := strings.Split(strings.Split(result.Desc().String(), "fqName: \"")[1], "\"")[0]
+				if len(labels) > 0 {
+					name += "/" + strings.Join(labels, "/")
+				}
+				if dto.GetCounter() != nil {
+					values[name] = dto.GetCounter().GetValue()
+				} else {
+					values[name] = dto.GetGauge().GetValue()
+				}
+			}
+
+			Expect(values).To(HaveKeyWithValue("kubevirt_vmi_guest_load_1m", 1.5))
+			Expect(values).To(HaveKeyWithValue("kubevirt_vmi_guest_load_15m", 0.5))
+			Expect(values).To(HaveKeyWithValue("kubevirt_vmi_guest_cpu_seconds_total/user", 2.0))
+			Expect(values).To(HaveKeyWithValue("kubevirt_vmi_guest_cpu_seconds_total/idle", 8.0))
+			Expect(values).To(HaveKeyWithValue("kubevirt_vmi_guest_uptime_seconds", 10.0))
+			Expect(values).To(HaveKeyWithValue("kubevirt_vmi_guest_disk_reads_total/vda", 10.0))
+			Expect(values).To(HaveKeyWithValue("kubevirt_vmi_guest_disk_read_bytes_total/vda", 2048.0))
+			Expect(values).To(HaveKeyWithValue("kubevirt_vmi_guest_disk_read_time_seconds_total/vda", 1.5))
+		})
+
 		DescribeTable("CPU metrics", func(metricName string, MetricValue int, cpuStats *stats.DomainStatsCPU) {
 			ch := make(chan prometheus.Metric, 1)
 			defer close(ch)
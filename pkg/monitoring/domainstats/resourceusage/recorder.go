@@ -0,0 +1,176 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package resourceusage
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+// publishEvery is the amount of samples after which the peak usage is published again.
+// Every publication ends up in a VMI status update, so the peaks are not published on each sample.
+const publishEvery = 15
+
+type sample struct {
+	timestamp     time.Time
+	cpuMillicores int64
+	memoryBytes   int64
+}
+
+type vmiUsage struct {
+	samples        []sample
+	lastCPUTime    uint64
+	lastSampleTime time.Time
+	unpublished    int
+	published      *k6tv1.VirtualMachineInstanceResourceUsage
+}
+
+// Recorder keeps the peak CPU and memory usage of the VMIs over a sliding window,
+// computed from the same domain stats the VMI metrics are reported from.
+type Recorder struct {
+	lock       sync.Mutex
+	usage      map[types.UID]*vmiUsage
+	maxSamples int
+}
+
+func NewRecorder(interval, window time.Duration) *Recorder {
+	maxSamples := int(window / interval)
+	if maxSamples < 1 {
+		maxSamples = 1
+	}
+	return &Recorder{
+		usage:      map[types.UID]*vmiUsage{},
+		maxSamples: maxSamples,
+	}
+}
+
+// Report records a sample of the domain stats of the VMI. It returns true when the peak
+// usage of the VMI was published, so it should be written to the VMI status.
+func (r *Recorder) Report(vmi *k6tv1.VirtualMachineInstance, vmStats *stats.DomainStats, now time.Time) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	usage, exists := r.usage[vmi.UID]
+	if !exists {
+		usage = &vmiUsage{}
+		r.usage[vmi.UID] = usage
+	}
+
+	current, measured := usage.measure(vmStats, now)
+	if !measured {
+		return false
+	}
+
+	usage.samples = append(usage.samples, current)
+	if len(usage.samples) > r.maxSamples {
+		usage.samples = usage.samples[len(usage.samples)-r.maxSamples:]
+	}
+
+	usage.unpublished++
+	if usage.published != nil && usage.unpublished < publishEvery {
+		return false
+	}
+	usage.unpublished = 0
+	usage.published = usage.peakUsage()
+	return true
+}
+
+// PeakUsage returns the last published peak usage of the VMI, nil if none was published yet
+func (r *Recorder) PeakUsage(uid types.UID) *k6tv1.VirtualMachineInstanceResourceUsage {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	usage, exists := r.usage[uid]
+	if !exists || usage.published == nil {
+		return nil
+	}
+	return usage.published.DeepCopy()
+}
+
+// Retain drops the samples of all VMIs not in the given list
+func (r *Recorder) Retain(vmis []*k6tv1.VirtualMachineInstance) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	known := map[types.UID]struct{}{}
+	for _, vmi := range vmis {
+		known[vmi.UID] = struct{}{}
+	}
+	for uid := range r.usage {
+		if _, exists := known[uid]; !exists {
+			delete(r.usage, uid)
+		}
+	}
+}
+
+func (u *vmiUsage) measure(vmStats *stats.DomainStats, now time.Time) (sample, bool) {
+	current := sample{timestamp: now}
+	measured := false
+
+	if vmStats.Cpu != nil && vmStats.Cpu.TimeSet {
+		if !u.lastSampleTime.IsZero() && vmStats.Cpu.Time >= u.lastCPUTime {
+			if elapsed := now.Sub(u.lastSampleTime); elapsed > 0 {
+				current.cpuMillicores = int64(float64(vmStats.Cpu.Time-u.lastCPUTime) / float64(elapsed.Nanoseconds()) * 1000)
+				measured = true
+			}
+		}
+		u.lastCPUTime = vmStats.Cpu.Time
+		u.lastSampleTime = now
+	}
+
+	// the memory usage requires the guest to report its memory statistics through the balloon driver
+	if memory := vmStats.Memory; memory != nil && memory.AvailableSet && memory.UsableSet && memory.Available >= memory.Usable {
+		current.memoryBytes = int64(memory.Available-memory.Usable) * 1024
+		measured = true
+	}
+
+	return current, measured
+}
+
+func (u *vmiUsage) peakUsage() *k6tv1.VirtualMachineInstanceResourceUsage {
+	var peakCPU, peakMemory int64
+	for _, current := range u.samples {
+		if current.cpuMillicores > peakCPU {
+			peakCPU = current.cpuMillicores
+		}
+		if current.memoryBytes > peakMemory {
+			peakMemory = current.memoryBytes
+		}
+	}
+
+	windowStart := metav1.NewTime(u.samples[0].timestamp)
+	usage := &k6tv1.VirtualMachineInstanceResourceUsage{
+		PeakCPU:     *resource.NewMilliQuantity(peakCPU, resource.DecimalSI),
+		Samples:     len(u.samples),
+		WindowStart: &windowStart,
+	}
+	if peakMemory > 0 {
+		usage.PeakMemory = resource.NewQuantity(peakMemory, resource.BinarySI)
+	}
+	return usage
+}
@@ -0,0 +1,111 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package resourceusage
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+var _ = Describe("Resource usage recorder", func() {
+	var (
+		recorder *Recorder
+		vmi      *k6tv1.VirtualMachineInstance
+		start    time.Time
+	)
+
+	domainStats := func(cpuTime time.Duration, usedMemoryKiB uint64) *stats.DomainStats {
+		return &stats.DomainStats{
+			Cpu: &stats.DomainStatsCPU{TimeSet: true, Time: uint64(cpuTime)},
+			Memory: &stats.DomainStatsMemory{
+				AvailableSet: true,
+				Available:    4 * 1024 * 1024,
+				UsableSet:    true,
+				Usable:       4*1024*1024 - usedMemoryKiB,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		recorder = NewRecorder(time.Minute, 10*time.Minute)
+		vmi = &k6tv1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{UID: "vmi-uid"}}
+		start = time.Now()
+	})
+
+	It("should publish the peak usage with the first sample", func() {
+		Expect(recorder.Report(vmi, domainStats(0, 1024*1024), start)).To(BeTrue())
+		Expect(recorder.Report(vmi, domainStats(2*time.Minute, 512*1024), start.Add(time.Minute))).To(BeFalse())
+
+		usage := recorder.PeakUsage(vmi.UID)
+		Expect(usage).ToNot(BeNil())
+		Expect(usage.Samples).To(Equal(1))
+		Expect(usage.PeakCPU.IsZero()).To(BeTrue())
+		Expect(usage.PeakMemory.Equal(resource.MustParse("1Gi"))).To(BeTrue())
+	})
+
+	It("should publish the peaks of the window every few samples", func() {
+		now := start
+		cpuTime := time.Duration(0)
+		recorder.Report(vmi, domainStats(cpuTime, 1024*1024), now)
+		for i := 1; i < publishEvery; i++ {
+			now = now.Add(time.Minute)
+			// one sample uses two CPUs, the others half a CPU
+			if i == publishEvery-3 {
+				cpuTime += 2 * time.Minute
+			} else {
+				cpuTime += 30 * time.Second
+			}
+			Expect(recorder.Report(vmi, domainStats(cpuTime, 512*1024), now)).To(BeFalse())
+		}
+		now = now.Add(time.Minute)
+		cpuTime += 30 * time.Second
+		Expect(recorder.Report(vmi, domainStats(cpuTime, 512*1024), now)).To(BeTrue())
+
+		usage := recorder.PeakUsage(vmi.UID)
+		Expect(usage.Samples).To(Equal(10))
+		Expect(usage.PeakCPU.Equal(resource.MustParse("2"))).To(BeTrue())
+		Expect(usage.PeakMemory.Equal(resource.MustParse("512Mi"))).To(BeTrue())
+		Expect(usage.WindowStart.Time).To(BeTemporally("==", now.Add(-9*time.Minute)))
+	})
+
+	It("should not account a sample without CPU or memory stats", func() {
+		Expect(recorder.Report(vmi, &stats.DomainStats{}, start)).To(BeFalse())
+		Expect(recorder.PeakUsage(vmi.UID)).To(BeNil())
+	})
+
+	It("should drop the samples of VMIs which are gone", func() {
+		Expect(recorder.Report(vmi, domainStats(0, 1024*1024), start)).To(BeTrue())
+
+		recorder.Retain([]*k6tv1.VirtualMachineInstance{vmi})
+		Expect(recorder.PeakUsage(vmi.UID)).ToNot(BeNil())
+
+		recorder.Retain(nil)
+		Expect(recorder.PeakUsage(vmi.UID)).To(BeNil())
+	})
+})
@@ -0,0 +1,11 @@
+package resourceusage
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestResourceUsage(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,118 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package resourceusage
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+
+	k6tv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	vms "kubevirt.io/kubevirt/pkg/monitoring/domainstats"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+)
+
+const (
+	// RefreshDuration is how often the CPU and memory usage of the VMIs is sampled
+	RefreshDuration = time.Minute
+	// Window is the period over which the peak usage is reported
+	Window            = 24 * time.Hour
+	CollectionTimeout = vms.CollectionTimeout
+)
+
+type Scraper struct {
+	recorder *Recorder
+	// published is called with the VMIs whose peak usage was published
+	published func(vmi *k6tv1.VirtualMachineInstance)
+}
+
+func (s *Scraper) Scrape(socketFile string, vmi *k6tv1.VirtualMachineInstance) {
+	if !vmi.IsRunning() {
+		return
+	}
+
+	ts := time.Now()
+	cli, err := cmdclient.NewClient(socketFile)
+	if err != nil {
+		// Ignore failure to connect to client.
+		// These are all local connections via unix socket.
+		// A failure to connect means there's nothing on the other
+		// end listening.
+		log.Log.Reason(err).Error("failed to connect to cmd client socket")
+		return
+	}
+	defer cli.Close()
+
+	vmStats, exists, err := cli.GetDomainStats()
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to update stats from socket %s", socketFile)
+		return
+	}
+	if !exists || vmStats.Name == "" {
+		log.Log.V(2).Infof("disappearing VM on %s, ignored", socketFile) // VM may be shutting down
+		return
+	}
+
+	// GetDomainStats() may hang for a long time, a late sample would skew the CPU usage
+	elapsed := time.Now().Sub(ts)
+	if elapsed > vms.StatsMaxAge {
+		log.Log.Infof("took too long (%v) to collect stats from %s: ignored", elapsed, socketFile)
+		return
+	}
+
+	if s.recorder.Report(vmi, vmStats, time.Now()) {
+		s.published(vmi)
+	}
+}
+
+// RunResourceUsageCollector periodically samples the domain stats of the VMIs into the recorder,
+// published is called for every VMI whose peak usage should be written to its status.
+func RunResourceUsageCollector(context context.Context, vmiInformer cache.SharedIndexInformer, recorder *Recorder, published func(vmi *k6tv1.VirtualMachineInstance)) {
+	scraper := &Scraper{
+		recorder:  recorder,
+		published: published,
+	}
+	collector := vms.NewConcurrentCollector(1)
+
+	go func() {
+		ticker := time.NewTicker(RefreshDuration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				vmis := []*k6tv1.VirtualMachineInstance{}
+				for _, obj := range vmiInformer.GetIndexer().List() {
+					vmis = append(vmis, obj.(*k6tv1.VirtualMachineInstance))
+				}
+				recorder.Retain(vmis)
+				if len(vmis) == 0 {
+					log.Log.V(4).Infof("No VMIs detected")
+					continue
+				}
+				collector.Collect(vmis, scraper, CollectionTimeout)
+			case <-context.Done():
+				return
+			}
+		}
+	}()
+}
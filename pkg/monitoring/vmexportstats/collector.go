@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vmexportstats
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/cache"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	"kubevirt.io/client-go/log"
+
+	virtexportserver "kubevirt.io/kubevirt/pkg/storage/export/virt-exportserver"
+)
+
+// The exporter pods only serve their internal kubevirt_exportserver_* metrics to virt-controller, which keeps the
+// progress of every volume in the VirtualMachineExport status. They are reported from there under their own names,
+// labeled with the export they belong to.
+var (
+	labels       = []string{"namespace", "name"}
+	volumeLabels = []string{"namespace", "name", virtexportserver.VolumeLabel}
+
+	bytesServedDesc = prometheus.NewDesc(
+		"kubevirt_vmexport_bytes_served_total",
+		"The total number of bytes of the volume served by the export server.",
+		volumeLabels,
+		nil,
+	)
+	activeConnectionsDesc = prometheus.NewDesc(
+		"kubevirt_vmexport_active_connections",
+		"The number of downloads of the volume in progress.",
+		volumeLabels,
+		nil,
+	)
+	bandwidthLimitDesc = prometheus.NewDesc(
+		"kubevirt_vmexport_bandwidth_limit_bytes",
+		"The bytes per second limit of the downloads served by the export server, 0 when they are not throttled.",
+		labels,
+		nil,
+	)
+)
+
+type VMExportCollector struct {
+	vmExportInformer cache.SharedIndexInformer
+}
+
+func (co *VMExportCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- bytesServedDesc
+	ch <- activeConnectionsDesc
+	ch <- bandwidthLimitDesc
+}
+
+func SetupVMExportCollector(vmExportInformer cache.SharedIndexInformer) *VMExportCollector {
+	log.Log.Infof("Starting vmexport collector")
+	co := &VMExportCollector{
+		vmExportInformer: vmExportInformer,
+	}
+
+	prometheus.MustRegister(co)
+	return co
+}
+
+func (co *VMExportCollector) Collect(ch chan<- prometheus.Metric) {
+	cachedObjs := co.vmExportInformer.GetIndexer().List()
+	if len(cachedObjs) == 0 {
+		return
+	}
+
+	vmExports := make([]*exportv1.VirtualMachineExport, len(cachedObjs))
+	for i, obj := range cachedObjs {
+		vmExports[i] = obj.(*exportv1.VirtualMachineExport)
+	}
+
+	scraper := NewPrometheusScraper(ch)
+	scraper.Report(vmExports)
+}
+
+func NewPrometheusScraper(ch chan<- prometheus.Metric) *prometheusScraper {
+	return &prometheusScraper{ch: ch}
+}
+
+type prometheusScraper struct {
+	ch chan<- prometheus.Metric
+}
+
+func (ps *prometheusScraper) Report(vmExports []*exportv1.VirtualMachineExport) {
+	for _, vmExport := range vmExports {
+		ps.updateVMExportMetrics(vmExport)
+	}
+}
+
+func (ps *prometheusScraper) updateVMExportMetrics(vmExport *exportv1.VirtualMachineExport) {
+	if vmExport.Status == nil || vmExport.Status.Phase != exportv1.Ready {
+		return
+	}
+
+	var bandwidthLimit int64
+	if vmExport.Spec.BandwidthLimit != nil {
+		bandwidthLimit = vmExport.Spec.BandwidthLimit.Value()
+	}
+	ps.pushMetric(bandwidthLimitDesc, prometheus.GaugeValue, float64(bandwidthLimit), vmExport.Namespace, vmExport.Name)
+
+	for _, volume := range vmExport.Status.Volumes {
+		ps.pushMetric(bytesServedDesc, prometheus.CounterValue, float64(volume.BytesServed), vmExport.Namespace, vmExport.Name, volume.Name)
+		ps.pushMetric(activeConnectionsDesc, prometheus.GaugeValue, float64(volume.ActiveConnections), vmExport.Namespace, vmExport.Name, volume.Name)
+	}
+}
+
+func (ps *prometheusScraper) pushMetric(desc *prometheus.Desc, valueType prometheus.ValueType, value float64, labelValues ...string) {
+	mv, err := prometheus.NewConstMetric(desc, valueType, value, labelValues...)
+	if err != nil {
+		log.Log.Warningf("Error creating the new const metric for %s: %s", desc, err)
+		return
+	}
+	ps.ch <- mv
+}
@@ -0,0 +1,125 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vmexportstats
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+
+	virtexportserver "kubevirt.io/kubevirt/pkg/storage/export/virt-exportserver"
+)
+
+var _ = Describe("VMExport Stats Collector", func() {
+	var ch chan prometheus.Metric
+	var scraper *prometheusScraper
+
+	createVMExport := func(phase exportv1.VirtualMachineExportPhase) *exportv1.VirtualMachineExport {
+		return &exportv1.VirtualMachineExport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-export"},
+			Status: &exportv1.VirtualMachineExportStatus{
+				Phase: phase,
+				Volumes: []exportv1.VirtualMachineExportVolumeProgress{
+					{Name: "disk1", BytesServed: 1024, ActiveConnections: 2},
+					{Name: "disk2"},
+				},
+			},
+		}
+	}
+
+	collect := func() map[string][]*io_prometheus_client.Metric {
+		close(ch)
+		result := make(map[string][]*io_prometheus_client.Metric)
+		for m := range ch {
+			dto := &io_prometheus_client.Metric{}
+			Expect(m.Write(dto)).To(Succeed())
+			result[m.Desc().String()] = append(result[m.Desc().String()], dto)
+		}
+		return result
+	}
+
+	labelsOf := func(m *io_prometheus_client.Metric) map[string]string {
+		labels := make(map[string]string)
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		return labels
+	}
+
+	BeforeEach(func() {
+		ch = make(chan prometheus.Metric, 10)
+		scraper = &prometheusScraper{ch: ch}
+	})
+
+	It("should report the progress of every volume labeled with the export", func() {
+		vmExport := createVMExport(exportv1.Ready)
+		limit := resource.MustParse("10Mi")
+		vmExport.Spec.BandwidthLimit = &limit
+		scraper.Report([]*exportv1.VirtualMachineExport{vmExport})
+
+		metrics := collect()
+		Expect(metrics[bytesServedDesc.String()]).To(HaveLen(2))
+		Expect(metrics[activeConnectionsDesc.String()]).To(HaveLen(2))
+		Expect(metrics[bandwidthLimitDesc.String()]).To(HaveLen(1))
+
+		bytesServed := metrics[bytesServedDesc.String()][0]
+		Expect(labelsOf(bytesServed)).To(Equal(map[string]string{"namespace": "test-ns", "name": "test-export", "volume": "disk1"}))
+		Expect(bytesServed.GetCounter().GetValue()).To(BeEquivalentTo(1024))
+		activeConnections := metrics[activeConnectionsDesc.String()][0]
+		Expect(labelsOf(activeConnections)).To(Equal(map[string]string{"namespace": "test-ns", "name": "test-export", "volume": "disk1"}))
+		Expect(activeConnections.GetGauge().GetValue()).To(BeEquivalentTo(2))
+		bandwidthLimit := metrics[bandwidthLimitDesc.String()][0]
+		Expect(labelsOf(bandwidthLimit)).To(Equal(map[string]string{"namespace": "test-ns", "name": "test-export"}))
+		Expect(bandwidthLimit.GetGauge().GetValue()).To(BeEquivalentTo(limit.Value()))
+	})
+
+	DescribeTable("should not reuse the names of the internal export server metrics", func(desc *prometheus.Desc, name, internalName string) {
+		Expect(desc.String()).To(ContainSubstring(`fqName: "` + name + `"`))
+		Expect(desc.String()).ToNot(ContainSubstring(internalName))
+	},
+		Entry("bytes served", bytesServedDesc, "kubevirt_vmexport_bytes_served_total", virtexportserver.BytesServedMetricName),
+		Entry("active connections", activeConnectionsDesc, "kubevirt_vmexport_active_connections", virtexportserver.ActiveConnectionsMetricName),
+		Entry("bandwidth limit", bandwidthLimitDesc, "kubevirt_vmexport_bandwidth_limit_bytes", virtexportserver.BandwidthLimitMetricName),
+	)
+
+	It("should report a bandwidth limit of 0 when the downloads are not throttled", func() {
+		scraper.Report([]*exportv1.VirtualMachineExport{createVMExport(exportv1.Ready)})
+
+		metrics := collect()
+		Expect(metrics[bandwidthLimitDesc.String()]).To(HaveLen(1))
+		Expect(metrics[bandwidthLimitDesc.String()][0].GetGauge().GetValue()).To(BeZero())
+	})
+
+	DescribeTable("should not report exports that are not being served", func(vmExport *exportv1.VirtualMachineExport) {
+		scraper.Report([]*exportv1.VirtualMachineExport{vmExport})
+		Expect(collect()).To(BeEmpty())
+	},
+		Entry("without a status", &exportv1.VirtualMachineExport{}),
+		Entry("in pending phase", createVMExport(exportv1.Pending)),
+		Entry("in terminated phase", createVMExport(exportv1.Terminated)),
+	)
+})
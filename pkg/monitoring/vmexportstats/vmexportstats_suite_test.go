@@ -0,0 +1,11 @@
+package vmexportstats_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestVMExportStats(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
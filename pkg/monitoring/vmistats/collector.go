@@ -66,6 +66,15 @@ var (
 		nil,
 	)
 
+	vmiGuestAgentConnectedDesc = prometheus.NewDesc(
+		"kubevirt_vmi_guest_agent_connected",
+		"Indication for a running VirtualMachineInstance that its guest agent is connected.",
+		[]string{
+			"node", "namespace", "name",
+		},
+		nil,
+	)
+
 	instancetypeVendorLabel = "instancetype.kubevirt.io/vendor"
 
 	// vendors whose instance types are whitelisted for telemetry
@@ -135,6 +144,7 @@ func (co *VMICollector) Collect(ch chan<- prometheus.Metric) {
 
 	co.updateVMIsPhase(vmis, ch)
 	co.updateVMIMetrics(vmis, ch)
+	updateVMIGuestAgentMetrics(vmis, ch)
 	return
 }
 
@@ -289,3 +299,25 @@ func (co *VMICollector) updateVMIMetrics(vmis []*k6tv1.VirtualMachineInstance, c
 		ch <- mv
 	}
 }
+
+func updateVMIGuestAgentMetrics(vmis []*k6tv1.VirtualMachineInstance, ch chan<- prometheus.Metric) {
+	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+	for _, vmi := range vmis {
+		if vmi.Status.Phase != k6tv1.Running {
+			continue
+		}
+		connected := 0.0
+		if conditionManager.HasConditionWithStatus(vmi, k6tv1.VirtualMachineInstanceAgentConnected, k8sv1.ConditionTrue) {
+			connected = 1.0
+		}
+		mv, err := prometheus.NewConstMetric(
+			vmiGuestAgentConnectedDesc, prometheus.GaugeValue,
+			connected,
+			vmi.Status.NodeName, vmi.Namespace, vmi.Name,
+		)
+		if err != nil {
+			continue
+		}
+		ch <- mv
+	}
+}
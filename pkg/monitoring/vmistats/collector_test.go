@@ -72,6 +72,60 @@ var _ = Describe("VMI Stats Collector", func() {
 			Entry("VMI Eviction policy is not set and vm migratable status is not known", nil, k8sv1.ConditionUnknown, 0.0),
 		)
 	})
+
+	Context("VMI guest agent", func() {
+
+		DescribeTable("Add guest agent connected metrics", func(agentCondStatus k8sv1.ConditionStatus, expectedVal float64) {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			vmi := &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-ns",
+					Name:      "testvmi",
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					NodeName: "testNode",
+					Phase:    k6tv1.Running,
+				},
+			}
+			if agentCondStatus != k8sv1.ConditionUnknown {
+				vmi.Status.Conditions = []k6tv1.VirtualMachineInstanceCondition{
+					{
+						Type:   k6tv1.VirtualMachineInstanceAgentConnected,
+						Status: agentCondStatus,
+					},
+				}
+			}
+
+			updateVMIGuestAgentMetrics([]*k6tv1.VirtualMachineInstance{vmi}, ch)
+
+			result := <-ch
+			dto := &io_prometheus_client.Metric{}
+			result.Write(dto)
+
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_guest_agent_connected"))
+			Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(expectedVal))
+		},
+			Entry("guest agent is connected", k8sv1.ConditionTrue, 1.0),
+			Entry("guest agent is not connected", k8sv1.ConditionFalse, 0.0),
+			Entry("guest agent condition is missing", k8sv1.ConditionUnknown, 0.0),
+		)
+
+		It("should not report VMIs which are not running", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			vmi := &k6tv1.VirtualMachineInstance{
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					Phase: k6tv1.Scheduled,
+				},
+			}
+			updateVMIGuestAgentMetrics([]*k6tv1.VirtualMachineInstance{vmi}, ch)
+
+			Expect(ch).To(BeEmpty())
+		})
+	})
 })
 
 func createVMISForEviction(evictionStrategy *k6tv1.EvictionStrategy, migratableCondStatus k8sv1.ConditionStatus) []*k6tv1.VirtualMachineInstance {
@@ -194,7 +194,7 @@ type DHCPHandler struct {
 	options       dhcp.Options
 }
 
-func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, _ dhcp.Options) (d dhcp.Packet) {
+func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, options dhcp.Options) (d dhcp.Packet) {
 	log.Log.V(4).Info("Serving a new request")
 	if len(h.clientMAC) != 0 {
 		if mac := p.CHAddr(); !bytes.Equal(mac, h.clientMAC) {
@@ -212,6 +212,11 @@ func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, _ dhcp.
 
 	case dhcp.Request:
 		log.Log.V(4).Info("The request has message type REQUEST")
+		if requestedIP := requestedIPAddress(p, options); requestedIP != nil && !requestedIP.Equal(h.clientIP) {
+			// A migrated guest renewing a lease it was not offered by this server
+			log.Log.V(4).Infof("The requested IP %s is not the client IP %s", requestedIP, h.clientIP)
+			return dhcp.ReplyPacket(p, dhcp.NAK, h.serverIP, nil, 0, nil)
+		}
 		return dhcp.ReplyPacket(p, dhcp.ACK, h.serverIP, h.clientIP, h.leaseDuration,
 			h.options.SelectOrderOrAll(nil))
 
@@ -222,6 +227,18 @@ func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, _ dhcp.
 	}
 }
 
+// requestedIPAddress returns the address the client asks for, either while selecting a lease
+// or while renewing/rebinding the lease it already holds.
+func requestedIPAddress(p dhcp.Packet, options dhcp.Options) net.IP {
+	if requestedIP := net.IP(options[dhcp.OptionRequestedIPAddress]); len(requestedIP) == net.IPv4len {
+		return requestedIP
+	}
+	if clientIP := p.CIAddr(); !clientIP.Equal(net.IPv4zero) {
+		return clientIP
+	}
+	return nil
+}
+
 func sortRoutes(routes []netlink.Route) []netlink.Route {
 	// Default route must come last, otherwise it may not get applied
 	// because there is no route to its gateway yet
@@ -249,4 +249,62 @@ var _ = Describe("DHCP Server", func() {
 			})
 		})
 	})
+
+	Context("serving DHCP requests", func() {
+		var (
+			handler   *DHCPHandler
+			clientMAC net.HardwareAddr
+		)
+
+		BeforeEach(func() {
+			clientMAC = net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+			handler = &DHCPHandler{
+				serverIP:      net.ParseIP("169.254.75.10").To4(),
+				clientIP:      net.ParseIP("10.35.0.6").To4(),
+				clientMAC:     clientMAC,
+				leaseDuration: infiniteLease,
+			}
+		})
+
+		newRequest := func(clientIP net.IP, options ...dhcp4.Option) (dhcp4.Packet, dhcp4.Options) {
+			packet := dhcp4.RequestPacket(dhcp4.Request, clientMAC, clientIP, []byte{1, 2, 3, 4}, false, options)
+			return packet, packet.ParseOptions()
+		}
+
+		replyType := func(reply dhcp4.Packet) dhcp4.MessageType {
+			Expect(reply).ToNot(BeNil())
+			return dhcp4.MessageType(reply.ParseOptions()[dhcp4.OptionDHCPMessageType][0])
+		}
+
+		It("should ACK a request for the client IP", func() {
+			packet, options := newRequest(net.IPv4zero,
+				dhcp4.Option{Code: dhcp4.OptionRequestedIPAddress, Value: handler.clientIP})
+			reply := handler.ServeDHCP(packet, dhcp4.Request, options)
+			Expect(replyType(reply)).To(Equal(dhcp4.ACK))
+			Expect(reply.YIAddr().Equal(handler.clientIP)).To(BeTrue())
+		})
+
+		It("should ACK the renewal of the client IP lease", func() {
+			packet, options := newRequest(handler.clientIP)
+			reply := handler.ServeDHCP(packet, dhcp4.Request, options)
+			Expect(replyType(reply)).To(Equal(dhcp4.ACK))
+			Expect(reply.YIAddr().Equal(handler.clientIP)).To(BeTrue())
+		})
+
+		It("should NAK a request for another IP", func() {
+			packet, options := newRequest(net.IPv4zero,
+				dhcp4.Option{Code: dhcp4.OptionRequestedIPAddress, Value: net.ParseIP("10.35.0.7").To4()})
+			Expect(replyType(handler.ServeDHCP(packet, dhcp4.Request, options))).To(Equal(dhcp4.NAK))
+		})
+
+		It("should NAK the renewal of another IP lease", func() {
+			packet, options := newRequest(net.ParseIP("10.35.0.7").To4())
+			Expect(replyType(handler.ServeDHCP(packet, dhcp4.Request, options))).To(Equal(dhcp4.NAK))
+		})
+
+		It("should ignore requests from other clients", func() {
+			packet := dhcp4.RequestPacket(dhcp4.Request, net.HardwareAddr{0x02, 0, 0, 0, 0, 0x02}, handler.clientIP, []byte{1, 2, 3, 4}, false, nil)
+			Expect(handler.ServeDHCP(packet, dhcp4.Request, packet.ParseOptions())).To(BeNil())
+		})
+	})
 })
@@ -13,6 +13,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/network/cache"
 	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
 	virtnetlink "kubevirt.io/kubevirt/pkg/network/link"
+	"kubevirt.io/kubevirt/pkg/network/vmispec"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
@@ -71,6 +72,12 @@ func (b *BridgePodNetworkConfigurator) DiscoverPodNetworkInterface(podIfaceName
 	if err != nil {
 		return err
 	}
+	if b.vmMac == nil {
+		b.vmMac, err = b.retrieveMacAddressOfMigratingGuest()
+		if err != nil {
+			return err
+		}
+	}
 	if b.vmMac == nil {
 		b.vmMac = &b.podNicLink.Attrs().HardwareAddr
 	}
@@ -78,6 +85,24 @@ func (b *BridgePodNetworkConfigurator) DiscoverPodNetworkInterface(podIfaceName
 	return nil
 }
 
+// retrieveMacAddressOfMigratingGuest returns the MAC address reported by the guest on the migration source.
+// The migrated guest keeps using it, while the target pod link gets a new MAC address from the CNI.
+func (b *BridgePodNetworkConfigurator) retrieveMacAddressOfMigratingGuest() (*net.HardwareAddr, error) {
+	migrationState := b.vmi.Status.MigrationState
+	if migrationState == nil || migrationState.Completed {
+		return nil, nil
+	}
+	ifaceStatus := vmispec.LookupInterfaceStatusByName(b.vmi.Status.Interfaces, b.vmiSpecIface.Name)
+	if ifaceStatus == nil || ifaceStatus.MAC == "" {
+		return nil, nil
+	}
+	mac, err := net.ParseMAC(ifaceStatus.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the migrating guest MAC address %q: %v", ifaceStatus.MAC, err)
+	}
+	return &mac, nil
+}
+
 func (b *BridgePodNetworkConfigurator) GenerateNonRecoverableDHCPConfig() *cache.DHCPConfig {
 	if !b.ipamEnabled {
 		return &cache.DHCPConfig{IPAMDisabled: true}
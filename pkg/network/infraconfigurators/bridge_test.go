@@ -125,6 +125,38 @@ var _ = Describe("Bridge infrastructure configurator", func() {
 			Expect(bridgeConfigurator.podIfaceRoutes).To(ConsistOf(defaultGwRoute))
 		})
 
+		It("uses the pod link MAC address for the guest", func() {
+			podLink.LinkAttrs.HardwareAddr = net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+			bridgeConfigurator := newMockedBridgeConfigurator(
+				vmi,
+				iface,
+				handler,
+				launcherPID,
+				withLink(podLink),
+				withIPOnLink(podLink, podIP),
+				withRoutesOnLink(podLink, defaultGwRoute))
+			Expect(bridgeConfigurator.DiscoverPodNetworkInterface(ifaceName)).To(Succeed())
+			Expect(bridgeConfigurator.vmMac.String()).To(Equal("02:00:00:00:00:01"))
+		})
+
+		It("keeps the guest MAC address on a migration target", func() {
+			podLink.LinkAttrs.HardwareAddr = net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{}
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+				{Name: iface.Name, MAC: "02:00:00:00:00:02"},
+			}
+			bridgeConfigurator := newMockedBridgeConfigurator(
+				vmi,
+				iface,
+				handler,
+				launcherPID,
+				withLink(podLink),
+				withIPOnLink(podLink, podIP),
+				withRoutesOnLink(podLink, defaultGwRoute))
+			Expect(bridgeConfigurator.DiscoverPodNetworkInterface(ifaceName)).To(Succeed())
+			Expect(bridgeConfigurator.vmMac.String()).To(Equal("02:00:00:00:00:02"))
+		})
+
 		It("fails to discover pod information when the pod does not feature routes", func() {
 			bridgeConfigurator := newMockedBridgeConfigurator(
 				vmi,
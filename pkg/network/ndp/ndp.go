@@ -0,0 +1,167 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+// Package ndp sends unsolicited IPv6 neighbor advertisements on behalf of a guest, so the neighbors
+// of a migrated guest learn its new location as they do from the gratuitous ARPs for IPv4.
+package ndp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/vmispec"
+)
+
+const (
+	// maxAdvertisements is MAX_NEIGHBOR_ADVERTISEMENT of RFC 4861
+	maxAdvertisements     = 3
+	advertisementInterval = 100 * time.Millisecond
+
+	ethernetHeaderLen = 14
+	ipv6HeaderLen     = 40
+	// the neighbor advertisement message followed by the target link-layer address option
+	advertisementLen = 24 + 8
+
+	icmpv6ProtocolNumber      = 58
+	neighborAdvertisementType = 136
+	overrideFlag              = 0x20
+	targetLinkLayerAddrOption = 2
+	hopLimit                  = 255
+)
+
+var allNodesMulticast = net.ParseIP("ff02::1")
+
+// allNodesMulticastMAC is the ethernet address ff02::1 maps to
+var allNodesMulticastMAC = net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x01}
+
+// GuestAddresses returns the MAC and the IPv6 addresses the guest reported on the interface of the network
+func GuestAddresses(vmi *v1.VirtualMachineInstance, networkName string) (net.HardwareAddr, []net.IP, error) {
+	ifaceStatus := vmispec.LookupInterfaceStatusByName(vmi.Status.Interfaces, networkName)
+	if ifaceStatus == nil || ifaceStatus.MAC == "" {
+		return nil, nil, nil
+	}
+	mac, err := net.ParseMAC(ifaceStatus.MAC)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the guest MAC address %q: %v", ifaceStatus.MAC, err)
+	}
+	var ips []net.IP
+	for _, address := range ifaceStatus.IPs {
+		if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+			ips = append(ips, ip)
+		}
+	}
+	return mac, ips, nil
+}
+
+// UnsolicitedNeighborAdvertisement returns the ethernet frame advertising that ip is at mac to all the nodes of the link
+func UnsolicitedNeighborAdvertisement(mac net.HardwareAddr, ip net.IP) []byte {
+	frame := make([]byte, ethernetHeaderLen+ipv6HeaderLen+advertisementLen)
+
+	copy(frame[0:6], allNodesMulticastMAC)
+	copy(frame[6:12], mac)
+	binary.BigEndian.PutUint16(frame[12:14], unix.ETH_P_IPV6)
+
+	header := frame[ethernetHeaderLen : ethernetHeaderLen+ipv6HeaderLen]
+	header[0] = 6 << 4
+	binary.BigEndian.PutUint16(header[4:6], advertisementLen)
+	header[6] = icmpv6ProtocolNumber
+	header[7] = hopLimit
+	copy(header[8:24], ip.To16())
+	copy(header[24:40], allNodesMulticast)
+
+	message := frame[ethernetHeaderLen+ipv6HeaderLen:]
+	message[0] = neighborAdvertisementType
+	message[4] = overrideFlag
+	copy(message[8:24], ip.To16())
+	message[24] = targetLinkLayerAddrOption
+	message[25] = 1 // in units of 8 bytes
+	copy(message[26:32], mac)
+	binary.BigEndian.PutUint16(message[2:4], checksum(header[8:24], header[24:40], message))
+
+	return frame
+}
+
+// checksum returns the ICMPv6 checksum of the message, computed over the IPv6 pseudo header
+func checksum(src, dst, message []byte) uint16 {
+	pseudoHeader := make([]byte, 0, 40+len(message))
+	pseudoHeader = append(pseudoHeader, src...)
+	pseudoHeader = append(pseudoHeader, dst...)
+	pseudoHeader = binary.BigEndian.AppendUint32(pseudoHeader, uint32(len(message)))
+	pseudoHeader = append(pseudoHeader, 0, 0, 0, icmpv6ProtocolNumber)
+	pseudoHeader = append(pseudoHeader, message...)
+
+	var sum uint32
+	for i := 0; i+1 < len(pseudoHeader); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(pseudoHeader[i : i+2]))
+	}
+	if len(pseudoHeader)%2 == 1 {
+		sum += uint32(pseudoHeader[len(pseudoHeader)-1]) << 8
+	}
+	for sum > 0xffff {
+		sum = (sum >> 16) + (sum & 0xffff)
+	}
+	return ^uint16(sum)
+}
+
+// Advertise sends unsolicited neighbor advertisements of the ips at mac out of the interface.
+// It needs CAP_NET_RAW in the network namespace of the interface.
+func Advertise(ifaceName string, mac net.HardwareAddr, ips []net.IP) error {
+	if len(ips) == 0 {
+		return nil
+	}
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return err
+	}
+	// the socket only sends, so it does not bind to any protocol
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open a packet socket: %v", err)
+	}
+	defer unix.Close(fd)
+
+	addr := &unix.SockaddrLinklayer{
+		Protocol: htons(unix.ETH_P_IPV6),
+		Ifindex:  iface.Index,
+		Halen:    uint8(len(allNodesMulticastMAC)),
+	}
+	copy(addr.Addr[:], allNodesMulticastMAC)
+
+	for round := 0; round < maxAdvertisements; round++ {
+		if round > 0 {
+			time.Sleep(advertisementInterval)
+		}
+		for _, ip := range ips {
+			if err := unix.Sendto(fd, UnsolicitedNeighborAdvertisement(mac, ip), 0, addr); err != nil {
+				return fmt.Errorf("failed to advertise %s at %s on %s: %v", ip, mac, ifaceName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func htons(i uint16) uint16 {
+	return i<<8 | i>>8
+}
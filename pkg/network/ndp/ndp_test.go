@@ -0,0 +1,125 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+
+package ndp_test
+
+import (
+	"encoding/binary"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/ndp"
+)
+
+var _ = Describe("neighbor discovery", func() {
+	const (
+		networkName = "default"
+		guestMAC    = "02:00:00:00:00:01"
+	)
+
+	Context("unsolicited neighbor advertisement", func() {
+		mac, _ := net.ParseMAC(guestMAC)
+		ip := net.ParseIP("fd10:244::8c")
+
+		It("should advertise the address at the MAC to all the nodes", func() {
+			frame := ndp.UnsolicitedNeighborAdvertisement(mac, ip)
+			Expect(frame).To(HaveLen(14 + 40 + 32))
+
+			By("sending an IPv6 ethernet frame from the MAC to the all nodes multicast address")
+			Expect(net.HardwareAddr(frame[0:6]).String()).To(Equal("33:33:00:00:00:01"))
+			Expect(net.HardwareAddr(frame[6:12]).String()).To(Equal(guestMAC))
+			Expect(binary.BigEndian.Uint16(frame[12:14])).To(BeEquivalentTo(0x86dd))
+
+			By("sending an ICMPv6 packet from the address to all the nodes with the hop limit required by NDP")
+			header := frame[14:54]
+			Expect(header[0] >> 4).To(BeEquivalentTo(6))
+			Expect(binary.BigEndian.Uint16(header[4:6])).To(BeEquivalentTo(32))
+			Expect(header[6]).To(BeEquivalentTo(58))
+			Expect(header[7]).To(BeEquivalentTo(255))
+			Expect(net.IP(header[8:24]).Equal(ip)).To(BeTrue())
+			Expect(net.IP(header[24:40]).String()).To(Equal("ff02::1"))
+
+			By("overriding the cached link-layer address of the target")
+			message := frame[54:]
+			Expect(message[0]).To(BeEquivalentTo(136))
+			Expect(message[1]).To(BeZero())
+			Expect(message[4:8]).To(Equal([]byte{0x20, 0, 0, 0}))
+			Expect(net.IP(message[8:24]).Equal(ip)).To(BeTrue())
+			Expect(message[24:26]).To(Equal([]byte{2, 1}))
+			Expect(net.HardwareAddr(message[26:32]).String()).To(Equal(guestMAC))
+		})
+
+		It("should carry a valid ICMPv6 checksum", func() {
+			frame := ndp.UnsolicitedNeighborAdvertisement(mac, ip)
+			header, message := frame[14:54], frame[54:]
+
+			// the one's complement sum of the pseudo header and of the message including its checksum is all ones
+			data := append(append([]byte{}, header[8:40]...), 0, 0, 0, byte(len(message)), 0, 0, 0, 58)
+			data = append(data, message...)
+			var sum uint32
+			for i := 0; i < len(data); i += 2 {
+				sum += uint32(binary.BigEndian.Uint16(data[i : i+2]))
+			}
+			for sum > 0xffff {
+				sum = (sum >> 16) + (sum & 0xffff)
+			}
+			Expect(sum).To(BeEquivalentTo(0xffff))
+		})
+	})
+
+	Context("guest addresses", func() {
+		newVMI := func(ifaces ...v1.VirtualMachineInstanceNetworkInterface) *v1.VirtualMachineInstance {
+			vmi := &v1.VirtualMachineInstance{}
+			vmi.Status.Interfaces = ifaces
+			return vmi
+		}
+
+		It("should return the MAC and the IPv6 addresses of the guest interface", func() {
+			vmi := newVMI(
+				v1.VirtualMachineInstanceNetworkInterface{Name: "other", MAC: "02:00:00:00:00:02", IPs: []string{"fd10:244::1"}},
+				v1.VirtualMachineInstanceNetworkInterface{
+					Name: networkName,
+					MAC:  guestMAC,
+					IPs:  []string{"10.244.0.8", "fd10:244::8c", "fe80::ff:fe00:1"},
+				},
+			)
+			mac, ips, err := ndp.GuestAddresses(vmi, networkName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mac.String()).To(Equal(guestMAC))
+			Expect(ips).To(ConsistOf(net.ParseIP("fd10:244::8c"), net.ParseIP("fe80::ff:fe00:1")))
+		})
+
+		It("should return no address when the guest did not report the interface", func() {
+			mac, ips, err := ndp.GuestAddresses(newVMI(), networkName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mac).To(BeNil())
+			Expect(ips).To(BeEmpty())
+		})
+
+		It("should fail on an invalid MAC", func() {
+			_, _, err := ndp.GuestAddresses(newVMI(v1.VirtualMachineInstanceNetworkInterface{Name: networkName, MAC: "invalid"}), networkName)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
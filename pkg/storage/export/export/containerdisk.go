@@ -0,0 +1,185 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+
+	"kubevirt.io/kubevirt/pkg/storage/types"
+)
+
+const (
+	pushContainerName = "containerdisk-push"
+	pushCommand       = "push"
+
+	registrySecretVolume    = "registry-secret"
+	registrySecretMountPath = "/registry"
+
+	defaultContainerDiskTag = "latest"
+)
+
+// containerDiskImage returns the image the volume is pushed to
+func containerDiskImage(target *exportv1.VirtualMachineExportContainerDiskTarget, pvc *corev1.PersistentVolumeClaim) string {
+	tag := target.Tag
+	if tag == "" {
+		tag = defaultContainerDiskTag
+	}
+	return fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(target.Repository, "/"), pvc.Name, tag)
+}
+
+// containerDisksPushed checks if all the volumes were pushed by a previous exporter pod
+func containerDisksPushed(vmExport *exportv1.VirtualMachineExport) bool {
+	if vmExport.Status == nil || len(vmExport.Status.ContainerDisks) == 0 {
+		return false
+	}
+	for _, cd := range vmExport.Status.ContainerDisks {
+		if cd.Phase != exportv1.ContainerDiskPushSucceeded {
+			return false
+		}
+	}
+	return true
+}
+
+func (ctrl *VMExportController) shouldPushContainerDisks(vmExport *exportv1.VirtualMachineExport) bool {
+	return vmExport.Spec.ContainerDisk != nil && !containerDisksPushed(vmExport)
+}
+
+// addPushContainer adds an init container pushing the volumes holding a disk image to the registry,
+// it runs once before the export server starts and reports the outcome in its termination message
+func (ctrl *VMExportController) addPushContainer(vmExport *exportv1.VirtualMachineExport, podManifest *corev1.Pod, pvcs []*corev1.PersistentVolumeClaim) {
+	target := vmExport.Spec.ContainerDisk
+	exportContainer := &podManifest.Spec.Containers[0]
+	pushContainer := corev1.Container{
+		Name:            pushContainerName,
+		Image:           exportContainer.Image,
+		ImagePullPolicy: exportContainer.ImagePullPolicy,
+		Args:            []string{pushCommand},
+		VolumeMounts:    append([]corev1.VolumeMount{}, exportContainer.VolumeMounts...),
+		VolumeDevices:   append([]corev1.VolumeDevice{}, exportContainer.VolumeDevices...),
+		SecurityContext: exportContainer.SecurityContext.DeepCopy(),
+	}
+
+	for _, env := range exportContainer.Env {
+		if env.Name == "POD_NAME" {
+			pushContainer.Env = append(pushContainer.Env, env)
+		}
+	}
+
+	for i, pvc := range pvcs {
+		if !ctrl.isKubevirtContentType(pvc) {
+			continue
+		}
+		mountPoint := fmt.Sprintf("%s/%s", fileSystemMountPath, pvc.Name)
+		if types.IsPVCBlock(pvc.Spec.VolumeMode) {
+			mountPoint = fmt.Sprintf("%s/%s", blockVolumeMountPath, pvc.Name)
+		}
+		pushContainer.Env = append(pushContainer.Env, corev1.EnvVar{
+			Name:  fmt.Sprintf("VOLUME%d_EXPORT_PATH", i),
+			Value: mountPoint,
+		}, corev1.EnvVar{
+			Name:  fmt.Sprintf("VOLUME%d_EXPORT_NAME", i),
+			Value: pvc.Name,
+		}, corev1.EnvVar{
+			Name:  fmt.Sprintf("VOLUME%d_EXPORT_IMAGE", i),
+			Value: containerDiskImage(target, pvc),
+		})
+	}
+
+	if target.InsecureSkipTLSVerify {
+		pushContainer.Env = append(pushContainer.Env, corev1.EnvVar{
+			Name:  "REGISTRY_INSECURE_SKIP_TLS_VERIFY",
+			Value: "true",
+		})
+	}
+
+	if target.SecretRef != nil {
+		podManifest.Spec.Volumes = append(podManifest.Spec.Volumes, corev1.Volume{
+			Name: registrySecretVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: *target.SecretRef,
+				},
+			},
+		})
+		pushContainer.VolumeMounts = append(pushContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      registrySecretVolume,
+			ReadOnly:  true,
+			MountPath: registrySecretMountPath,
+		})
+		pushContainer.Env = append(pushContainer.Env, corev1.EnvVar{
+			Name:  "REGISTRY_AUTH_FILE",
+			Value: fmt.Sprintf("%s/%s", registrySecretMountPath, corev1.DockerConfigJsonKey),
+		})
+	}
+
+	podManifest.Spec.InitContainers = append(podManifest.Spec.InitContainers, pushContainer)
+}
+
+// updateContainerDiskStatus tracks the push of the volumes from the state of the push init container
+func (ctrl *VMExportController) updateContainerDiskStatus(vmExport *exportv1.VirtualMachineExport, exporterPod *corev1.Pod, pvcs []*corev1.PersistentVolumeClaim) {
+	if vmExport.Spec.ContainerDisk == nil || !hasPushContainer(exporterPod) {
+		return
+	}
+
+	var containerDisks []exportv1.VirtualMachineExportContainerDisk
+	for _, pvc := range pvcs {
+		if !ctrl.isKubevirtContentType(pvc) {
+			continue
+		}
+		containerDisks = append(containerDisks, exportv1.VirtualMachineExportContainerDisk{
+			Name:  pvc.Name,
+			Image: containerDiskImage(vmExport.Spec.ContainerDisk, pvc),
+			Phase: exportv1.ContainerDiskPushing,
+		})
+	}
+
+	for _, status := range exporterPod.Status.InitContainerStatuses {
+		if status.Name != pushContainerName || status.State.Terminated == nil {
+			continue
+		}
+		terminated := status.State.Terminated
+		var pushed []exportv1.VirtualMachineExportContainerDisk
+		if err := json.Unmarshal([]byte(terminated.Message), &pushed); err == nil && len(pushed) > 0 {
+			containerDisks = pushed
+			break
+		}
+		for i := range containerDisks {
+			containerDisks[i].Phase = exportv1.ContainerDiskPushFailed
+			containerDisks[i].Message = fmt.Sprintf("push container terminated with exit code %d: %s", terminated.ExitCode, terminated.Reason)
+		}
+	}
+
+	vmExport.Status.ContainerDisks = containerDisks
+}
+
+func hasPushContainer(pod *corev1.Pod) bool {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == pushContainerName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package export
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+)
+
+var _ = Describe("ContainerDisk push", func() {
+	var (
+		controller *VMExportController
+		vmExport   *exportv1.VirtualMachineExport
+		pvcs       []*k8sv1.PersistentVolumeClaim
+	)
+
+	newExporterPod := func() *k8sv1.Pod {
+		return &k8sv1.Pod{
+			Spec: k8sv1.PodSpec{
+				Containers: []k8sv1.Container{
+					{
+						Name:  vmExport.Name,
+						Image: "exporter",
+						Env: []k8sv1.EnvVar{
+							{Name: "POD_NAME", Value: "pod"},
+							{Name: "VOLUME0_EXPORT_PATH", Value: "/export-volumes/disk"},
+						},
+						VolumeMounts: []k8sv1.VolumeMount{
+							{Name: "disk", ReadOnly: true, MountPath: "/export-volumes/disk"},
+						},
+						VolumeDevices: []k8sv1.VolumeDevice{
+							{Name: "block", DevicePath: "/dev/export-volumes/block"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		controller = &VMExportController{}
+		vmExport = createPVCVMExport()
+		vmExport.Spec.ContainerDisk = &exportv1.VirtualMachineExportContainerDiskTarget{
+			Repository: "registry.example.com/vms",
+		}
+		pvcs = []*k8sv1.PersistentVolumeClaim{
+			createPVC("disk", "kubevirt"),
+			createPVC("archive", "archive"),
+			createPVC("block", "archive"),
+		}
+		pvcs[2].Spec.VolumeMode = (*k8sv1.PersistentVolumeMode)(pointer.String(string(k8sv1.PersistentVolumeBlock)))
+	})
+
+	It("should add a push init container for the volumes holding a disk image", func() {
+		vmExport.Spec.ContainerDisk.Tag = "v1"
+		pod := newExporterPod()
+		controller.addPushContainer(vmExport, pod, pvcs)
+
+		Expect(pod.Spec.Containers).To(HaveLen(1))
+		Expect(pod.Spec.InitContainers).To(HaveLen(1))
+		push := pod.Spec.InitContainers[0]
+		Expect(push.Name).To(Equal(pushContainerName))
+		Expect(push.Image).To(Equal("exporter"))
+		Expect(push.Args).To(Equal([]string{pushCommand}))
+		Expect(push.VolumeMounts).To(Equal(pod.Spec.Containers[0].VolumeMounts))
+		Expect(push.VolumeDevices).To(Equal(pod.Spec.Containers[0].VolumeDevices))
+		Expect(push.Env).To(Equal([]k8sv1.EnvVar{
+			{Name: "POD_NAME", Value: "pod"},
+			{Name: "VOLUME0_EXPORT_PATH", Value: "/export-volumes/disk"},
+			{Name: "VOLUME0_EXPORT_NAME", Value: "disk"},
+			{Name: "VOLUME0_EXPORT_IMAGE", Value: "registry.example.com/vms/disk:v1"},
+			{Name: "VOLUME2_EXPORT_PATH", Value: "/dev/export-volumes/block"},
+			{Name: "VOLUME2_EXPORT_NAME", Value: "block"},
+			{Name: "VOLUME2_EXPORT_IMAGE", Value: "registry.example.com/vms/block:v1"},
+		}))
+		Expect(pod.Spec.Volumes).To(BeEmpty())
+	})
+
+	It("should mount the registry secret in the push container", func() {
+		vmExport.Spec.ContainerDisk.SecretRef = pointer.String("regcred")
+		vmExport.Spec.ContainerDisk.InsecureSkipTLSVerify = true
+		pod := newExporterPod()
+		controller.addPushContainer(vmExport, pod, pvcs[:1])
+
+		push := pod.Spec.InitContainers[0]
+		Expect(pod.Spec.Volumes).To(ContainElement(k8sv1.Volume{
+			Name: registrySecretVolume,
+			VolumeSource: k8sv1.VolumeSource{
+				Secret: &k8sv1.SecretVolumeSource{SecretName: "regcred"},
+			},
+		}))
+		Expect(push.VolumeMounts).To(ContainElement(k8sv1.VolumeMount{
+			Name:      registrySecretVolume,
+			ReadOnly:  true,
+			MountPath: registrySecretMountPath,
+		}))
+		Expect(push.Env).To(ContainElements(
+			k8sv1.EnvVar{Name: "REGISTRY_AUTH_FILE", Value: "/registry/.dockerconfigjson"},
+			k8sv1.EnvVar{Name: "REGISTRY_INSECURE_SKIP_TLS_VERIFY", Value: "true"},
+		))
+		Expect(pod.Spec.Containers[0].VolumeMounts).To(HaveLen(1))
+	})
+
+	DescribeTable("should push the volumes", func(status *exportv1.VirtualMachineExportStatus, expected bool) {
+		vmExport.Status = status
+		Expect(controller.shouldPushContainerDisks(vmExport)).To(Equal(expected))
+	},
+		Entry("when they were not pushed yet", nil, true),
+		Entry("when a push failed", &exportv1.VirtualMachineExportStatus{
+			ContainerDisks: []exportv1.VirtualMachineExportContainerDisk{
+				{Name: "disk", Phase: exportv1.ContainerDiskPushSucceeded},
+				{Name: "block", Phase: exportv1.ContainerDiskPushFailed},
+			},
+		}, true),
+		Entry("not when they were all pushed", &exportv1.VirtualMachineExportStatus{
+			ContainerDisks: []exportv1.VirtualMachineExportContainerDisk{
+				{Name: "disk", Phase: exportv1.ContainerDiskPushSucceeded},
+			},
+		}, false),
+	)
+
+	It("should not push the volumes without a target", func() {
+		vmExport.Spec.ContainerDisk = nil
+		Expect(controller.shouldPushContainerDisks(vmExport)).To(BeFalse())
+	})
+
+	Context("status", func() {
+		var pod *k8sv1.Pod
+
+		BeforeEach(func() {
+			populateInitialVMExportStatus(vmExport)
+			pod = newExporterPod()
+			controller.addPushContainer(vmExport, pod, pvcs)
+		})
+
+		It("should report the volumes as pushing while the push container runs", func() {
+			pod.Status.InitContainerStatuses = []k8sv1.ContainerStatus{
+				{Name: pushContainerName, State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}}},
+			}
+			controller.updateContainerDiskStatus(vmExport, pod, pvcs)
+			Expect(vmExport.Status.ContainerDisks).To(Equal([]exportv1.VirtualMachineExportContainerDisk{
+				{Name: "disk", Image: "registry.example.com/vms/disk:latest", Phase: exportv1.ContainerDiskPushing},
+				{Name: "block", Image: "registry.example.com/vms/block:latest", Phase: exportv1.ContainerDiskPushing},
+			}))
+		})
+
+		It("should report the outcome from the termination message", func() {
+			pushed := []exportv1.VirtualMachineExportContainerDisk{
+				{Name: "disk", Image: "registry.example.com/vms/disk:latest", Phase: exportv1.ContainerDiskPushSucceeded, Digest: "sha256:1234"},
+				{Name: "block", Image: "registry.example.com/vms/block:latest", Phase: exportv1.ContainerDiskPushFailed, Message: "unauthorized"},
+			}
+			message, err := json.Marshal(pushed)
+			Expect(err).ToNot(HaveOccurred())
+			pod.Status.InitContainerStatuses = []k8sv1.ContainerStatus{
+				{Name: pushContainerName, State: k8sv1.ContainerState{Terminated: &k8sv1.ContainerStateTerminated{Message: string(message)}}},
+			}
+			controller.updateContainerDiskStatus(vmExport, pod, pvcs)
+			Expect(vmExport.Status.ContainerDisks).To(Equal(pushed))
+		})
+
+		It("should report all volumes as failed without a termination message", func() {
+			pod.Status.InitContainerStatuses = []k8sv1.ContainerStatus{
+				{Name: pushContainerName, State: k8sv1.ContainerState{Terminated: &k8sv1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}}},
+			}
+			controller.updateContainerDiskStatus(vmExport, pod, pvcs)
+			Expect(vmExport.Status.ContainerDisks).To(HaveLen(2))
+			for _, cd := range vmExport.Status.ContainerDisks {
+				Expect(cd.Phase).To(Equal(exportv1.ContainerDiskPushFailed))
+				Expect(cd.Message).To(Equal("push container terminated with exit code 137: OOMKilled"))
+			}
+		})
+
+		It("should keep the status when the pod has no push container", func() {
+			vmExport.Status.ContainerDisks = []exportv1.VirtualMachineExportContainerDisk{
+				{Name: "disk", Phase: exportv1.ContainerDiskPushSucceeded},
+			}
+			controller.updateContainerDiskStatus(vmExport, newExporterPod(), pvcs)
+			Expect(vmExport.Status.ContainerDisks).To(Equal([]exportv1.VirtualMachineExportContainerDisk{
+				{Name: "disk", Phase: exportv1.ContainerDiskPushSucceeded},
+			}))
+		})
+	})
+})
@@ -25,6 +25,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"strconv"
 	"time"
 
 	"github.com/openshift/library-go/pkg/build/naming"
@@ -55,6 +56,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
 	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/storage/export/proxyauth"
 	"kubevirt.io/kubevirt/pkg/storage/snapshot"
 	"kubevirt.io/kubevirt/pkg/storage/types"
 	kutil "kubevirt.io/kubevirt/pkg/util"
@@ -113,6 +115,8 @@ const (
 	secretTokenLength = 20
 	// secretTokenKey is the entry used to store the token in the virtualMachineExport secret
 	secretTokenKey = "token"
+	// proxyTokenKey is the entry used to store the token virt-exportproxy forwards authorized requests with in the exporter pod secret
+	proxyTokenKey = "proxy-token"
 
 	requeueTime = time.Second * 3
 
@@ -121,6 +125,7 @@ const (
 	manifestData           = "manifest-data"
 	manifestsPath          = "/manifests/all"
 	secretManifestPath     = "/manifests/secret"
+	ovaPath                = "/manifests/ova"
 	externalHostKey        = "external_host"
 	internalHostKey        = "internal_host"
 	externalCaConfigMapKey = "external_ca_cm"
@@ -531,7 +536,12 @@ func (ctrl *VMExportController) handleSource(vmExport *exportv1.VirtualMachineEx
 		return 0, err
 	}
 
-	return updateStatus(vmExport, pod, service, sourceVolumes)
+	requeue, err := updateStatus(vmExport, pod, service, sourceVolumes)
+	if err == nil && requeue == 0 && isProgressReported(pod) {
+		// Keep refreshing the download progress while the exporter is serving
+		requeue = progressRequeueTime
+	}
+	return requeue, err
 }
 
 func (ctrl *VMExportController) manageExporterPod(vmExport *exportv1.VirtualMachineExport, service *corev1.Service, sourceVolumes *sourceVolumes) (*corev1.Pod, error) {
@@ -670,6 +680,14 @@ func (ctrl *VMExportController) createCertSecretManifest(vmExport *exportv1.Virt
 		certParams.Duration,
 	)
 
+	data := map[string][]byte{
+		"tls.crt": cert.EncodeCertPEM(keyPair.Cert),
+		"tls.key": cert.EncodePrivateKeyPEM(keyPair.Key),
+	}
+	if token := ctrl.getProxyToken(vmExport); token != "" {
+		data[proxyTokenKey] = []byte(token)
+	}
+
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ctrl.getExportSecretName(ownerPod),
@@ -683,13 +701,21 @@ func (ctrl *VMExportController) createCertSecretManifest(vmExport *exportv1.Virt
 			},
 		},
 		Type: corev1.SecretTypeTLS,
-		Data: map[string][]byte{
-			"tls.crt": cert.EncodeCertPEM(keyPair.Cert),
-			"tls.key": cert.EncodePrivateKeyPEM(keyPair.Key),
-		},
+		Data: data,
 	}, nil
 }
 
+// getProxyToken returns the token virt-exportproxy forwards the requests it authorized with the bearer token
+// of the user with. Bearer token authentication is disabled if the proxy key cannot be read.
+func (ctrl *VMExportController) getProxyToken(vmExport *exportv1.VirtualMachineExport) string {
+	key, err := proxyauth.EnsureKey(context.Background(), ctrl.Client, ctrl.KubevirtNamespace)
+	if err != nil {
+		log.Log.Reason(err).Warning("Unable to get the export proxy key, bearer token authentication is disabled")
+		return ""
+	}
+	return proxyauth.Token(key, vmExport.UID)
+}
+
 // handleVMExportToken checks if a secret has been specified for the current export object and, if not, creates one specific to it
 func (ctrl *VMExportController) handleVMExportToken(vmExport *exportv1.VirtualMachineExport) error {
 	// If a tokenSecretRef has been specified, we assume that the corresponding
@@ -899,6 +925,10 @@ func (ctrl *VMExportController) createExporterPodManifest(vmExport *exportv1.Vir
 		ctrl.addVolumeEnvironmentVariables(&podManifest.Spec.Containers[0], pvc, i, mountPoint)
 	}
 
+	if ctrl.shouldPushContainerDisks(vmExport) {
+		ctrl.addPushContainer(vmExport, podManifest, pvcs)
+	}
+
 	// Add token and certs ENV variables
 	podManifest.Spec.Containers[0].Env = append(podManifest.Spec.Containers[0].Env, corev1.EnvVar{
 		Name:  "CERT_FILE",
@@ -918,6 +948,29 @@ func (ctrl *VMExportController) createExporterPodManifest(vmExport *exportv1.Vir
 	}, corev1.EnvVar{
 		Name:  "EXPORT_SECRET_DEF_URI",
 		Value: secretManifestPath,
+	}, corev1.EnvVar{
+		Name:  "EXPORT_OVA_URI",
+		Value: ovaPath,
+	}, corev1.EnvVar{
+		Name:  "PROXY_TOKEN_FILE",
+		Value: "/cert/" + proxyTokenKey,
+	}, corev1.EnvVar{
+		Name:  "EXPORT_NAME",
+		Value: vmExport.Name,
+	}, corev1.EnvVar{
+		Name:  "EXPORT_NAMESPACE",
+		Value: vmExport.Namespace,
+	})
+	if vmExport.Spec.BandwidthLimit != nil {
+		podManifest.Spec.Containers[0].Env = append(podManifest.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  "BANDWIDTH_LIMIT",
+			Value: strconv.FormatInt(vmExport.Spec.BandwidthLimit.Value(), 10),
+		})
+	}
+	podManifest.Spec.Containers[0].Ports = append(podManifest.Spec.Containers[0].Ports, corev1.ContainerPort{
+		Name:          "metrics",
+		ContainerPort: exporterMetricsPort,
+		Protocol:      corev1.ProtocolTCP,
 	})
 
 	tokenSecretRef := ""
@@ -1089,6 +1142,9 @@ func (ctrl *VMExportController) addVolumeEnvironmentVariables(exportContainer *c
 	exportContainer.Env = append(exportContainer.Env, corev1.EnvVar{
 		Name:  fmt.Sprintf("VOLUME%d_EXPORT_PATH", index),
 		Value: mountPoint,
+	}, corev1.EnvVar{
+		Name:  fmt.Sprintf("VOLUME%d_EXPORT_NAME", index),
+		Value: pvc.Name,
 	})
 	if types.IsPVCBlock(pvc.Spec.VolumeMode) {
 		exportContainer.Env = append(exportContainer.Env, corev1.EnvVar{
@@ -1166,6 +1222,7 @@ func (ctrl *VMExportController) updateCommonVMExportStatusFields(vmExport, vmExp
 			if err != nil {
 				return err
 			}
+			ctrl.updateVolumeProgress(vmExportCopy, exporterPod, sourceVolumes.volumes, getVolumeName)
 		} else if exporterPod.Status.Phase == corev1.PodSucceeded {
 			vmExportCopy.Status.Conditions = updateCondition(vmExportCopy.Status.Conditions, newReadyCondition(corev1.ConditionFalse, podCompletedReason, ""))
 			vmExportCopy.Status.Phase = exportv1.Terminated
@@ -1176,6 +1233,7 @@ func (ctrl *VMExportController) updateCommonVMExportStatusFields(vmExport, vmExp
 			vmExportCopy.Status.Conditions = updateCondition(vmExportCopy.Status.Conditions, newReadyCondition(corev1.ConditionFalse, unknownReason, ""))
 			vmExportCopy.Status.Phase = exportv1.Pending
 		}
+		ctrl.updateContainerDiskStatus(vmExportCopy, exporterPod, sourceVolumes.volumes)
 	}
 
 	return nil
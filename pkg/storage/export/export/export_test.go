@@ -38,6 +38,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -64,6 +65,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
 	certutil "kubevirt.io/kubevirt/pkg/certificates/triple/cert"
 	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/storage/export/proxyauth"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
@@ -89,6 +91,9 @@ var (
 		}, {
 			Name:  "TOKEN_FILE",
 			Value: "/token/token",
+		}, {
+			Name:  "PROXY_TOKEN_FILE",
+			Value: "/cert/proxy-token",
 		}}
 )
 
@@ -905,12 +910,37 @@ var _ = Describe("Export controller", func() {
 		}))
 		Expect(pod.Annotations[annCertParams]).To(Equal("{\"Duration\":7200000000000,\"RenewBefore\":3600000000000}"))
 		Expect(pod.Spec.Containers[0].Env).To(ContainElements(expectedPodEnvVars))
+		Expect(pod.Spec.Containers[0].Env).To(ContainElement(k8sv1.EnvVar{Name: "VOLUME0_EXPORT_NAME", Value: testPVCName}))
+		Expect(pod.Spec.Containers[0].Env).To(ContainElements(
+			k8sv1.EnvVar{Name: "EXPORT_NAME", Value: testVMExport.Name},
+			k8sv1.EnvVar{Name: "EXPORT_NAMESPACE", Value: testVMExport.Namespace},
+		))
+		Expect(pod.Spec.Containers[0].Env).ToNot(ContainElement(HaveField("Name", "BANDWIDTH_LIMIT")))
+		Expect(pod.Spec.Containers[0].Ports).To(ContainElement(k8sv1.ContainerPort{
+			Name:          "metrics",
+			ContainerPort: exporterMetricsPort,
+			Protocol:      k8sv1.ProtocolTCP,
+		}))
 	},
 		Entry("PVC", createPVCVMExport, 3),
 		Entry("VM", populateVmExportVM, 4),
 		Entry("Snapshot", populateVmExportVMSnapshot, 4),
 	)
 
+	It("Should pass the bandwidth limit to the exporter pod", func() {
+		testVMExport := createPVCVMExport()
+		bandwidthLimit := resource.MustParse("10Mi")
+		testVMExport.Spec.BandwidthLimit = &bandwidthLimit
+		populateInitialVMExportStatus(testVMExport)
+		Expect(controller.handleVMExportToken(testVMExport)).To(Succeed())
+		service, err := controller.getOrCreateExportService(testVMExport)
+		Expect(err).ToNot(HaveOccurred())
+
+		pod, err := controller.createExporterPodManifest(testVMExport, service, []*k8sv1.PersistentVolumeClaim{createPVC(testPVCName, "kubevirt")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Spec.Containers[0].Env).To(ContainElement(k8sv1.EnvVar{Name: "BANDWIDTH_LIMIT", Value: "10485760"}))
+	})
+
 	It("Should create a secret based on the vm export", func() {
 		cp := &CertParams{Duration: 24 * time.Hour, RenewBefore: 2 * time.Hour}
 		scp, err := serializeCertParams(cp)
@@ -919,6 +949,9 @@ var _ = Describe("Export controller", func() {
 		populateInitialVMExportStatus(testVMExport)
 		err = controller.handleVMExportToken(testVMExport)
 		Expect(err).ToNot(HaveOccurred())
+		k8sClient.Fake.PrependReactor("get", "secrets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			return true, &k8sv1.Secret{Data: map[string][]byte{"key": []byte("proxy-key")}}, nil
+		})
 		testExportPod := &k8sv1.Pod{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "test-export-pod",
@@ -976,6 +1009,42 @@ var _ = Describe("Export controller", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	DescribeTable("should add the proxy token to the exporter pod secret", func(keyErr error, expectedToken bool) {
+		cp := &CertParams{Duration: 24 * time.Hour, RenewBefore: 2 * time.Hour}
+		scp, err := serializeCertParams(cp)
+		Expect(err).ToNot(HaveOccurred())
+		testVMExport := createPVCVMExport()
+		testVMExport.UID = "test-uid"
+		testExportPod := &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-export-pod",
+				Annotations: map[string]string{annCertParams: scp},
+			},
+		}
+		key := []byte("proxy-key")
+		k8sClient.Fake.PrependReactor("get", "secrets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			get, ok := action.(testing.GetAction)
+			Expect(ok).To(BeTrue())
+			Expect(get.GetNamespace()).To(Equal(controller.KubevirtNamespace))
+			Expect(get.GetName()).To(Equal(proxyauth.KeySecretName))
+			if keyErr != nil {
+				return true, nil, keyErr
+			}
+			return true, &k8sv1.Secret{Data: map[string][]byte{"key": key}}, nil
+		})
+		secret, err := controller.createCertSecretManifest(testVMExport, testExportPod)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).To(HaveKey("tls.crt"))
+		if expectedToken {
+			Expect(secret.Data).To(HaveKeyWithValue(proxyTokenKey, []byte(proxyauth.Token(key, testVMExport.UID))))
+		} else {
+			Expect(secret.Data).ToNot(HaveKey(proxyTokenKey))
+		}
+	},
+		Entry("when the proxy key is available", nil, true),
+		Entry("unless the proxy key cannot be read", fmt.Errorf("failure"), false),
+	)
+
 	It("handleVMExportToken should create the export secret if no TokenSecretRef is specified", func() {
 		testVMExport := createPVCVMExportWithoutSecret()
 		expectedName := getDefaultTokenSecretName(testVMExport)
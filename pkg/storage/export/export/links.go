@@ -87,6 +87,10 @@ func (ctrl *VMExportController) getLinks(pvcs []*corev1.PersistentVolumeClaim, e
 				Type: exportv1.AuthHeader,
 				Url:  scheme + path.Join(hostAndBase, linkType, secretManifestPath),
 			},
+			{
+				Type: exportv1.OVABundle,
+				Url:  scheme + path.Join(hostAndBase, linkType, ovaPath),
+			},
 		},
 	}
 	for _, pvc := range pvcs {
@@ -0,0 +1,146 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package export
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	"kubevirt.io/client-go/log"
+
+	virtexportserver "kubevirt.io/kubevirt/pkg/storage/export/virt-exportserver"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
+)
+
+const (
+	exporterMetricsPort = 8444
+
+	// progressRequeueTime is how often the download progress is refreshed while the exporter pod is running
+	progressRequeueTime = time.Second * 10
+
+	progressRequestTimeout = time.Second * 5
+)
+
+// getExporterProgress reads the download progress of the exported volumes from the metrics of the exporter pod,
+// the result is keyed by PVC name
+var getExporterProgress = func(ctrl *VMExportController, vmExport *exportv1.VirtualMachineExport, pod *corev1.Pod) (map[string]exportv1.VirtualMachineExportVolumeProgress, error) {
+	caCert := ctrl.caCertManager.Current()
+	if caCert == nil || caCert.Leaf == nil {
+		return nil, fmt.Errorf("export CA certificate is not available")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert.Leaf)
+
+	client := &http.Client{
+		Timeout: progressRequestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    rootCAs,
+				ServerName: fmt.Sprintf(components.LocalPodDNStemplateString, ctrl.getExportServiceName(vmExport), vmExport.Namespace),
+			},
+		},
+	}
+	url := fmt.Sprintf("https://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(exporterMetricsPort)), virtexportserver.MetricsPath)
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d reading exporter metrics", resp.StatusCode)
+	}
+	return parseExporterProgress(resp.Body)
+}
+
+func parseExporterProgress(r io.Reader) (map[string]exportv1.VirtualMachineExportVolumeProgress, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]exportv1.VirtualMachineExportVolumeProgress)
+	if family, ok := families[virtexportserver.BytesServedMetricName]; ok {
+		for _, metric := range family.GetMetric() {
+			volume := getVolumeLabel(metric.GetLabel())
+			progress := result[volume]
+			progress.BytesServed = int64(metric.GetCounter().GetValue())
+			result[volume] = progress
+		}
+	}
+	if family, ok := families[virtexportserver.ActiveConnectionsMetricName]; ok {
+		for _, metric := range family.GetMetric() {
+			volume := getVolumeLabel(metric.GetLabel())
+			progress := result[volume]
+			progress.ActiveConnections = int32(metric.GetGauge().GetValue())
+			result[volume] = progress
+		}
+	}
+	return result, nil
+}
+
+func getVolumeLabel(labels []*dto.LabelPair) string {
+	for _, label := range labels {
+		if label.GetName() == virtexportserver.VolumeLabel {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+func isProgressReported(pod *corev1.Pod) bool {
+	return pod != nil && pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != ""
+}
+
+// updateVolumeProgress refreshes the download progress of the volumes, the last known progress is kept when the
+// exporter pod cannot be reached. The status is left untouched while the progress does not change, so that idle
+// exports are not written on every refresh.
+func (ctrl *VMExportController) updateVolumeProgress(vmExport *exportv1.VirtualMachineExport, exporterPod *corev1.Pod, pvcs []*corev1.PersistentVolumeClaim, getVolumeName getExportVolumeName) {
+	if !isProgressReported(exporterPod) {
+		return
+	}
+	progress, err := getExporterProgress(ctrl, vmExport, exporterPod)
+	if err != nil {
+		log.Log.Object(vmExport).Reason(err).V(3).Infof("Unable to read the download progress from exporter pod %s/%s", exporterPod.Namespace, exporterPod.Name)
+		return
+	}
+
+	var volumes []exportv1.VirtualMachineExportVolumeProgress
+	for _, pvc := range pvcs {
+		volumeProgress := progress[pvc.Name]
+		volumeProgress.Name = getVolumeName(pvc, vmExport)
+		volumes = append(volumes, volumeProgress)
+	}
+	if !equality.Semantic.DeepEqual(vmExport.Status.Volumes, volumes) {
+		vmExport.Status.Volumes = volumes
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+)
+
+var _ = Describe("Export progress", func() {
+	const metrics = `# HELP kubevirt_exportserver_active_connections The number of downloads of the volume in progress.
+# TYPE kubevirt_exportserver_active_connections gauge
+kubevirt_exportserver_active_connections{volume="pvc1"} 2
+kubevirt_exportserver_active_connections{volume="pvc2"} 0
+# HELP kubevirt_exportserver_bandwidth_limit_bytes The bytes per second limit of the downloads served by the export server, 0 when they are not throttled.
+# TYPE kubevirt_exportserver_bandwidth_limit_bytes gauge
+kubevirt_exportserver_bandwidth_limit_bytes 0
+# HELP kubevirt_exportserver_bytes_served_total The total number of bytes of the volume served by the export server.
+# TYPE kubevirt_exportserver_bytes_served_total counter
+kubevirt_exportserver_bytes_served_total{volume="pvc1"} 1.073741824e+09
+kubevirt_exportserver_bytes_served_total{volume="pvc2"} 512
+`
+
+	runningPod := func(podIP string) *k8sv1.Pod {
+		return &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "virt-export-test", Namespace: testNamespace},
+			Status:     k8sv1.PodStatus{Phase: k8sv1.PodRunning, PodIP: podIP},
+		}
+	}
+
+	It("should parse the exporter metrics", func() {
+		progress, err := parseExporterProgress(strings.NewReader(metrics))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(progress).To(Equal(map[string]exportv1.VirtualMachineExportVolumeProgress{
+			"pvc1": {BytesServed: 1073741824, ActiveConnections: 2},
+			"pvc2": {BytesServed: 512},
+		}))
+	})
+
+	It("should fail to parse invalid metrics", func() {
+		_, err := parseExporterProgress(strings.NewReader("invalid metrics{"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("should only report progress of serving exporter pods", func(pod *k8sv1.Pod, expected bool) {
+		Expect(isProgressReported(pod)).To(Equal(expected))
+	},
+		Entry("without a pod", nil, false),
+		Entry("with a running pod", runningPod("10.0.0.1"), true),
+		Entry("with a running pod without an IP", runningPod(""), false),
+		Entry("with a pending pod", &k8sv1.Pod{Status: k8sv1.PodStatus{Phase: k8sv1.PodPending, PodIP: "10.0.0.1"}}, false),
+		Entry("with a deleted pod", &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{}},
+			Status:     k8sv1.PodStatus{Phase: k8sv1.PodRunning, PodIP: "10.0.0.1"},
+		}, false),
+	)
+
+	Context("updating the volume progress", func() {
+		var (
+			ctrl     *VMExportController
+			vmExport *exportv1.VirtualMachineExport
+			pvcs     []*k8sv1.PersistentVolumeClaim
+		)
+
+		BeforeEach(func() {
+			ctrl = &VMExportController{}
+			vmExport = createPVCVMExport()
+			populateInitialVMExportStatus(vmExport)
+			pvcs = []*k8sv1.PersistentVolumeClaim{createPVC("pvc1", "kubevirt"), createPVC("pvc2", "kubevirt")}
+
+			origGetExporterProgress := getExporterProgress
+			DeferCleanup(func() {
+				getExporterProgress = origGetExporterProgress
+			})
+		})
+
+		It("should report the progress of every volume", func() {
+			getExporterProgress = func(_ *VMExportController, _ *exportv1.VirtualMachineExport, pod *k8sv1.Pod) (map[string]exportv1.VirtualMachineExportVolumeProgress, error) {
+				Expect(pod.Status.PodIP).To(Equal("10.0.0.1"))
+				return parseExporterProgress(strings.NewReader(metrics))
+			}
+			ctrl.updateVolumeProgress(vmExport, runningPod("10.0.0.1"), pvcs, getVolumeName)
+			Expect(vmExport.Status.Volumes).To(Equal([]exportv1.VirtualMachineExportVolumeProgress{
+				{Name: "pvc1", BytesServed: 1073741824, ActiveConnections: 2},
+				{Name: "pvc2", BytesServed: 512},
+			}))
+		})
+
+		It("should report volumes missing from the metrics as not downloaded", func() {
+			getExporterProgress = func(*VMExportController, *exportv1.VirtualMachineExport, *k8sv1.Pod) (map[string]exportv1.VirtualMachineExportVolumeProgress, error) {
+				return map[string]exportv1.VirtualMachineExportVolumeProgress{}, nil
+			}
+			ctrl.updateVolumeProgress(vmExport, runningPod("10.0.0.1"), pvcs, getVolumeName)
+			Expect(vmExport.Status.Volumes).To(Equal([]exportv1.VirtualMachineExportVolumeProgress{
+				{Name: "pvc1"},
+				{Name: "pvc2"},
+			}))
+		})
+
+		It("should keep the last known progress when the exporter cannot be reached", func() {
+			lastKnown := []exportv1.VirtualMachineExportVolumeProgress{{Name: "pvc1", BytesServed: 42}}
+			vmExport.Status.Volumes = lastKnown
+			getExporterProgress = func(*VMExportController, *exportv1.VirtualMachineExport, *k8sv1.Pod) (map[string]exportv1.VirtualMachineExportVolumeProgress, error) {
+				return nil, fmt.Errorf("connection refused")
+			}
+			ctrl.updateVolumeProgress(vmExport, runningPod("10.0.0.1"), pvcs, getVolumeName)
+			Expect(vmExport.Status.Volumes).To(Equal(lastKnown))
+		})
+
+		It("should not read the progress of a pod without an IP", func() {
+			getExporterProgress = func(*VMExportController, *exportv1.VirtualMachineExport, *k8sv1.Pod) (map[string]exportv1.VirtualMachineExportVolumeProgress, error) {
+				Fail("progress should not be read")
+				return nil, nil
+			}
+			ctrl.updateVolumeProgress(vmExport, runningPod(""), pvcs, getVolumeName)
+			Expect(vmExport.Status.Volumes).To(BeEmpty())
+		})
+	})
+})
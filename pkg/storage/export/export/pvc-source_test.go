@@ -292,6 +292,86 @@ var _ = Describe("PVC source", func() {
 		Expect(service.Name).To(Equal(fmt.Sprintf("%s-%s", exportPrefix, testVMExport.Name)))
 	})
 
+	It("Should report the download progress and requeue while the exporter pod is serving", func() {
+		origGetExporterProgress := getExporterProgress
+		DeferCleanup(func() {
+			getExporterProgress = origGetExporterProgress
+		})
+		getExporterProgress = func(*VMExportController, *exportv1.VirtualMachineExport, *k8sv1.Pod) (map[string]exportv1.VirtualMachineExportVolumeProgress, error) {
+			return map[string]exportv1.VirtualMachineExportVolumeProgress{
+				testPVCName: {BytesServed: 1024, ActiveConnections: 1},
+			}, nil
+		}
+
+		testVMExport := createPVCVMExport()
+		pvcInformer.GetStore().Add(createPVC(testPVCName, "kubevirt"))
+		k8sClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			exportPod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+			exportPod.Status = k8sv1.PodStatus{
+				Phase: k8sv1.PodRunning,
+				PodIP: "10.0.0.1",
+			}
+			return true, exportPod, nil
+		})
+		updated := false
+		vmExportClient.Fake.PrependReactor("update", "virtualmachineexports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			vmExport := action.(testing.UpdateAction).GetObject().(*exportv1.VirtualMachineExport)
+			Expect(vmExport.Status.Volumes).To(Equal([]exportv1.VirtualMachineExportVolumeProgress{
+				{Name: testPVCName, BytesServed: 1024, ActiveConnections: 1},
+			}))
+			updated = true
+			return true, vmExport, nil
+		})
+		retry, err := controller.updateVMExport(testVMExport)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(retry).To(BeEquivalentTo(progressRequeueTime))
+		Expect(updated).To(BeTrue())
+	})
+
+	It("Should not update the VMExport status while the download progress does not change", func() {
+		origGetExporterProgress := getExporterProgress
+		DeferCleanup(func() {
+			getExporterProgress = origGetExporterProgress
+		})
+		getExporterProgress = func(*VMExportController, *exportv1.VirtualMachineExport, *k8sv1.Pod) (map[string]exportv1.VirtualMachineExportVolumeProgress, error) {
+			return map[string]exportv1.VirtualMachineExportVolumeProgress{
+				testPVCName: {BytesServed: 1024},
+			}, nil
+		}
+
+		testVMExport := createPVCVMExport()
+		pvcInformer.GetStore().Add(createPVC(testPVCName, "kubevirt"))
+		k8sClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			exportPod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+			exportPod.Status = k8sv1.PodStatus{
+				Phase: k8sv1.PodRunning,
+				PodIP: "10.0.0.1",
+			}
+			Expect(podInformer.GetStore().Add(exportPod)).To(Succeed())
+			return true, exportPod, nil
+		})
+		k8sClient.Fake.PrependReactor("create", "services", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			service := action.(testing.CreateAction).GetObject().(*k8sv1.Service)
+			Expect(serviceInformer.GetStore().Add(service)).To(Succeed())
+			return true, service, nil
+		})
+		updates := 0
+		vmExportClient.Fake.PrependReactor("update", "virtualmachineexports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			testVMExport = action.(testing.UpdateAction).GetObject().(*exportv1.VirtualMachineExport)
+			updates++
+			return true, testVMExport, nil
+		})
+		retry, err := controller.updateVMExport(testVMExport)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(retry).To(BeEquivalentTo(progressRequeueTime))
+		Expect(updates).To(Equal(1))
+
+		retry, err = controller.updateVMExport(testVMExport)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(retry).To(BeEquivalentTo(progressRequeueTime))
+		Expect(updates).To(Equal(1))
+	})
+
 	It("Should properly update VMExport status with a valid token and no pvc, pending pod", func() {
 		testVMExport := createPVCVMExport()
 		expectExporterCreate(k8sClient, k8sv1.PodPending)
@@ -0,0 +1,156 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package ova
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"time"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+// DiskImage is a raw disk image of a volume of the VM
+type DiskImage struct {
+	// ClaimName is the name of the PVC holding the disk image
+	ClaimName string
+	Path      string
+}
+
+type ovaDisk struct {
+	diskFile
+	file *os.File
+}
+
+// Write writes an OVA archive of the VM: the OVF descriptor followed by the disks of the VM in qcow2 format.
+// Disks of the VM without a matching disk image are left out. Each disk is only scanned for its allocated
+// clusters right before it is written, so the archive starts streaming without reading the disks first.
+func Write(w io.Writer, vm *v1.VirtualMachine, diskImages []DiskImage) error {
+	disks, err := openDisks(vm, diskImages)
+	defer func() {
+		for _, disk := range disks {
+			disk.file.Close()
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	var files []diskFile
+	for _, disk := range disks {
+		files = append(files, disk.diskFile)
+	}
+	descriptor, err := generateOVF(vm, files)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeTarHeader(tw, vm.Name+".ovf", int64(len(descriptor))); err != nil {
+		return err
+	}
+	if _, err := tw.Write(descriptor); err != nil {
+		return err
+	}
+	for _, disk := range disks {
+		image, err := newQcow2Image(disk.file, disk.Capacity)
+		if err != nil {
+			return err
+		}
+		if err := writeTarHeader(tw, disk.Href, image.Size()); err != nil {
+			return err
+		}
+		if _, err := image.WriteTo(tw); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// openDisks opens the disk images of the disks of the VM, in the order of the VM disks
+func openDisks(vm *v1.VirtualMachine, diskImages []DiskImage) ([]*ovaDisk, error) {
+	var disks []*ovaDisk
+	if vm.Spec.Template == nil {
+		return disks, nil
+	}
+
+	claims := map[string]string{}
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		if volume.DataVolume != nil {
+			claims[volume.Name] = volume.DataVolume.Name
+		} else if volume.PersistentVolumeClaim != nil {
+			claims[volume.Name] = volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+
+	for _, vmDisk := range vm.Spec.Template.Spec.Domain.Devices.Disks {
+		if vmDisk.CDRom != nil {
+			continue
+		}
+		claimName, exists := claims[vmDisk.Name]
+		if !exists {
+			continue
+		}
+		for _, diskImage := range diskImages {
+			if diskImage.ClaimName != claimName {
+				continue
+			}
+			disk, err := openDisk(vmDisk.Name, diskImage.Path)
+			if err != nil {
+				return disks, err
+			}
+			disks = append(disks, disk)
+			break
+		}
+	}
+	return disks, nil
+}
+
+func openDisk(name, path string) (*ovaDisk, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	// seeking gives the size of block devices as well
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &ovaDisk{
+		diskFile: diskFile{
+			Name:     name,
+			Href:     name + ".qcow2",
+			Capacity: size,
+		},
+		file: f,
+	}, nil
+}
+
+func writeTarHeader(tw *tar.Writer, name string, size int64) error {
+	return tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0644,
+		ModTime:  time.Unix(0, 0),
+	})
+}
@@ -0,0 +1,170 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package ova
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+var _ = Describe("OVA", func() {
+	var (
+		vm      *v1.VirtualMachine
+		tempDir string
+	)
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+		guest := resource.MustParse("2Gi")
+		vm = &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "testvm"},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							CPU:    &v1.CPU{Cores: 2, Sockets: 2},
+							Memory: &v1.Memory{Guest: &guest},
+							Devices: v1.Devices{
+								Disks: []v1.Disk{
+									{Name: "rootdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}}},
+									{Name: "cloudinit", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}}},
+									{Name: "datadisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusSCSI}}},
+									{Name: "installer", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: v1.DiskBusSATA}}},
+								},
+								Interfaces: []v1.Interface{
+									{Name: "default", Model: "e1000", MacAddress: "02:00:00:00:00:01"},
+									{Name: "secondary"},
+								},
+							},
+						},
+						Networks: []v1.Network{
+							{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+							{Name: "secondary", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "bridge-net"}}},
+						},
+						Volumes: []v1.Volume{
+							{Name: "rootdisk", VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "root-dv"}}},
+							{Name: "cloudinit", VolumeSource: v1.VolumeSource{CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"}}},
+							{Name: "datadisk", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "data-pvc"},
+							}}},
+							{Name: "installer", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "iso-pvc"},
+							}}},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	writeDisk := func(name string, size int64, content string) string {
+		path := filepath.Join(tempDir, name)
+		f, err := os.Create(path)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+		Expect(f.Truncate(size)).To(Succeed())
+		_, err = f.WriteAt([]byte(content), 0)
+		Expect(err).ToNot(HaveOccurred())
+		return path
+	}
+
+	It("should package the OVF descriptor and the disks of the VM", func() {
+		out := &bytes.Buffer{}
+		Expect(Write(out, vm, []DiskImage{
+			{ClaimName: "data-pvc", Path: writeDisk("data.img", 4*clusterSize, "data")},
+			{ClaimName: "root-dv", Path: writeDisk("root.img", 2*clusterSize, "root")},
+			{ClaimName: "iso-pvc", Path: writeDisk("iso.img", clusterSize, "iso")},
+			{ClaimName: "unrelated", Path: writeDisk("unrelated.img", clusterSize, "unrelated")},
+		})).To(Succeed())
+
+		tr := tar.NewReader(out)
+		var names []string
+		files := map[string][]byte{}
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+			data, err := io.ReadAll(tr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(HaveLen(int(hdr.Size)))
+			names = append(names, hdr.Name)
+			files[hdr.Name] = data
+		}
+		Expect(names).To(Equal([]string{"testvm.ovf", "rootdisk.qcow2", "datadisk.qcow2"}))
+
+		size, clusters := readQcow2(files["rootdisk.qcow2"])
+		Expect(size).To(Equal(uint64(2 * clusterSize)))
+		Expect(clusters).To(HaveLen(1))
+		Expect(clusters[0]).To(HavePrefix("root"))
+		size, _ = readQcow2(files["datadisk.qcow2"])
+		Expect(size).To(Equal(uint64(4 * clusterSize)))
+
+		descriptor := files["testvm.ovf"]
+		Expect(xml.Unmarshal(descriptor, &struct{}{})).To(Succeed())
+		Expect(string(descriptor)).To(HavePrefix(xml.Header))
+		for _, expected := range []string{
+			`<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1"`,
+			`<File ovf:id="file-rootdisk" ovf:href="rootdisk.qcow2"></File>`,
+			`<Disk ovf:diskId="datadisk" ovf:fileRef="file-datadisk" ovf:capacity="262144" ovf:capacityAllocationUnits="byte" ovf:format="` + Qcow2Format + `"></Disk>`,
+			`<Network ovf:name="pod">`,
+			`<Network ovf:name="bridge-net">`,
+			`<VirtualSystem ovf:id="testvm">`,
+			`<rasd:ResourceType>3</rasd:ResourceType>`,
+			`<rasd:VirtualQuantity>4</rasd:VirtualQuantity>`,
+			`<rasd:VirtualQuantity>2048</rasd:VirtualQuantity>`,
+			`<rasd:HostResource>ovf:/disk/rootdisk</rasd:HostResource>`,
+			`<rasd:ResourceSubType>scsi</rasd:ResourceSubType>`,
+			`<rasd:Address>02:00:00:00:00:01</rasd:Address>`,
+			`<rasd:Connection>bridge-net</rasd:Connection>`,
+			`<rasd:ResourceSubType>e1000</rasd:ResourceSubType>`,
+		} {
+			Expect(string(descriptor)).To(ContainSubstring(expected))
+		}
+		Expect(string(descriptor)).ToNot(ContainSubstring("installer"))
+		Expect(string(descriptor)).ToNot(ContainSubstring("cloudinit"))
+	})
+
+	It("should fail when a disk image is missing", func() {
+		err := Write(&bytes.Buffer{}, vm, []DiskImage{
+			{ClaimName: "root-dv", Path: filepath.Join(tempDir, "missing.img")},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail without a VM template", func() {
+		vm.Spec.Template = nil
+		Expect(Write(&bytes.Buffer{}, vm, nil)).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,355 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package ova
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+)
+
+const (
+	ovfNamespace  = "http://schemas.dmtf.org/ovf/envelope/1"
+	rasdNamespace = "http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData"
+	vssdNamespace = "http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_VirtualSystemSettingData"
+	xsiNamespace  = "http://www.w3.org/2001/XMLSchema-instance"
+
+	// Qcow2Format is the format URI of qcow2 disks, as used by oVirt and virt-v2v
+	Qcow2Format = "http://www.gnome.org/~markmc/qcow-image-format.html"
+
+	virtualSystemType = "kubevirt"
+	podNetworkName    = "pod"
+
+	// CIM resource types of the virtual hardware items
+	resourceTypeCPU            = 3
+	resourceTypeMemory         = 4
+	resourceTypeSCSIController = 6
+	resourceTypeEthernet       = 10
+	resourceTypeDisk           = 17
+	resourceTypeOtherStorage   = 20
+
+	// operatingSystemOther is the CIM operating system id for an unknown guest
+	operatingSystemOther = 1
+)
+
+type envelope struct {
+	XMLName        xml.Name        `xml:"Envelope"`
+	Xmlns          string          `xml:"xmlns,attr"`
+	XmlnsOvf       string          `xml:"xmlns:ovf,attr"`
+	XmlnsRasd      string          `xml:"xmlns:rasd,attr"`
+	XmlnsVssd      string          `xml:"xmlns:vssd,attr"`
+	XmlnsXsi       string          `xml:"xmlns:xsi,attr"`
+	References     []fileReference `xml:"References>File"`
+	DiskSection    diskSection     `xml:"DiskSection"`
+	NetworkSection *networkSection `xml:"NetworkSection,omitempty"`
+	VirtualSystem  virtualSystem   `xml:"VirtualSystem"`
+}
+
+type fileReference struct {
+	ID   string `xml:"ovf:id,attr"`
+	Href string `xml:"ovf:href,attr"`
+}
+
+type diskSection struct {
+	Info  string    `xml:"Info"`
+	Disks []ovfDisk `xml:"Disk"`
+}
+
+type ovfDisk struct {
+	DiskID                  string `xml:"ovf:diskId,attr"`
+	FileRef                 string `xml:"ovf:fileRef,attr"`
+	Capacity                int64  `xml:"ovf:capacity,attr"`
+	CapacityAllocationUnits string `xml:"ovf:capacityAllocationUnits,attr"`
+	Format                  string `xml:"ovf:format,attr"`
+}
+
+type networkSection struct {
+	Info     string       `xml:"Info"`
+	Networks []ovfNetwork `xml:"Network"`
+}
+
+type ovfNetwork struct {
+	Name        string `xml:"ovf:name,attr"`
+	Description string `xml:"Description"`
+}
+
+type virtualSystem struct {
+	ID                     string                 `xml:"ovf:id,attr"`
+	Info                   string                 `xml:"Info"`
+	Name                   string                 `xml:"Name"`
+	OperatingSystemSection operatingSystemSection `xml:"OperatingSystemSection"`
+	VirtualHardwareSection virtualHardwareSection `xml:"VirtualHardwareSection"`
+}
+
+type operatingSystemSection struct {
+	ID          int    `xml:"ovf:id,attr"`
+	Info        string `xml:"Info"`
+	Description string `xml:"Description"`
+}
+
+type virtualHardwareSection struct {
+	Info   string            `xml:"Info"`
+	System virtualSystemData `xml:"System"`
+	Items  []hardwareItem    `xml:"Item"`
+}
+
+type virtualSystemData struct {
+	ElementName             string `xml:"vssd:ElementName"`
+	InstanceID              string `xml:"vssd:InstanceID"`
+	VirtualSystemIdentifier string `xml:"vssd:VirtualSystemIdentifier"`
+	VirtualSystemType       string `xml:"vssd:VirtualSystemType"`
+}
+
+// hardwareItem is a CIM_ResourceAllocationSettingData, its elements are in schema order
+type hardwareItem struct {
+	Address         string `xml:"rasd:Address,omitempty"`
+	AddressOnParent string `xml:"rasd:AddressOnParent,omitempty"`
+	AllocationUnits string `xml:"rasd:AllocationUnits,omitempty"`
+	Connection      string `xml:"rasd:Connection,omitempty"`
+	Description     string `xml:"rasd:Description,omitempty"`
+	ElementName     string `xml:"rasd:ElementName"`
+	HostResource    string `xml:"rasd:HostResource,omitempty"`
+	InstanceID      string `xml:"rasd:InstanceID"`
+	Parent          string `xml:"rasd:Parent,omitempty"`
+	ResourceSubType string `xml:"rasd:ResourceSubType,omitempty"`
+	ResourceType    int    `xml:"rasd:ResourceType"`
+	VirtualQuantity int64  `xml:"rasd:VirtualQuantity,omitempty"`
+}
+
+// diskFile describes a disk image packaged next to the OVF descriptor
+type diskFile struct {
+	// Name is the name of the disk in the VM spec
+	Name     string
+	Href     string
+	Capacity int64
+}
+
+// generateOVF generates an OVF descriptor of the VM holding the given disks
+func generateOVF(vm *v1.VirtualMachine, disks []diskFile) ([]byte, error) {
+	if vm.Spec.Template == nil {
+		return nil, fmt.Errorf("virtual machine %s has no template", vm.Name)
+	}
+	domain := &vm.Spec.Template.Spec.Domain
+
+	env := envelope{
+		Xmlns:     ovfNamespace,
+		XmlnsOvf:  ovfNamespace,
+		XmlnsRasd: rasdNamespace,
+		XmlnsVssd: vssdNamespace,
+		XmlnsXsi:  xsiNamespace,
+		DiskSection: diskSection{
+			Info: "Virtual disk information",
+		},
+		VirtualSystem: virtualSystem{
+			ID:   vm.Name,
+			Info: "A virtual machine",
+			Name: vm.Name,
+			OperatingSystemSection: operatingSystemSection{
+				ID:          operatingSystemOther,
+				Info:        "The kind of installed guest operating system",
+				Description: "Other",
+			},
+			VirtualHardwareSection: virtualHardwareSection{
+				Info: "Virtual hardware requirements",
+				System: virtualSystemData{
+					ElementName:             "Virtual Hardware Family",
+					InstanceID:              "0",
+					VirtualSystemIdentifier: vm.Name,
+					VirtualSystemType:       virtualSystemType,
+				},
+			},
+		},
+	}
+
+	items := []hardwareItem{
+		{
+			AllocationUnits: "hertz * 10^6",
+			Description:     "Number of Virtual CPUs",
+			ElementName:     fmt.Sprintf("%d virtual CPU(s)", vcpus(domain)),
+			ResourceType:    resourceTypeCPU,
+			VirtualQuantity: vcpus(domain),
+		},
+		{
+			AllocationUnits: "byte * 2^20",
+			Description:     "Memory Size",
+			ElementName:     fmt.Sprintf("%dMB of memory", memoryMiB(domain)),
+			ResourceType:    resourceTypeMemory,
+			VirtualQuantity: memoryMiB(domain),
+		},
+	}
+
+	// disks are attached to one controller per bus
+	controllers := map[v1.DiskBus]string{}
+	addresses := map[v1.DiskBus]int{}
+	for _, disk := range disks {
+		env.References = append(env.References, fileReference{
+			ID:   "file-" + disk.Name,
+			Href: disk.Href,
+		})
+		env.DiskSection.Disks = append(env.DiskSection.Disks, ovfDisk{
+			DiskID:                  disk.Name,
+			FileRef:                 "file-" + disk.Name,
+			Capacity:                disk.Capacity,
+			CapacityAllocationUnits: "byte",
+			Format:                  Qcow2Format,
+		})
+
+		bus := diskBus(domain, disk.Name)
+		if _, exists := controllers[bus]; !exists {
+			controllers[bus] = strconv.Itoa(len(items) + 1)
+			resourceType := resourceTypeOtherStorage
+			if bus == v1.DiskBusSCSI {
+				resourceType = resourceTypeSCSIController
+			}
+			items = append(items, hardwareItem{
+				Description:     fmt.Sprintf("%s disk controller", bus),
+				ElementName:     fmt.Sprintf("%s controller", bus),
+				InstanceID:      controllers[bus],
+				ResourceSubType: string(bus),
+				ResourceType:    resourceType,
+			})
+		}
+		items = append(items, hardwareItem{
+			AddressOnParent: strconv.Itoa(addresses[bus]),
+			ElementName:     disk.Name,
+			HostResource:    "ovf:/disk/" + disk.Name,
+			Parent:          controllers[bus],
+			ResourceType:    resourceTypeDisk,
+		})
+		addresses[bus]++
+	}
+
+	networks := networkNames(vm)
+	for _, iface := range domain.Devices.Interfaces {
+		network, exists := networks[iface.Name]
+		if !exists {
+			continue
+		}
+		model := iface.Model
+		if model == "" {
+			model = v1.VirtIO
+		}
+		items = append(items, hardwareItem{
+			Address:         iface.MacAddress,
+			Connection:      network,
+			Description:     fmt.Sprintf("%s ethernet adapter on %s", model, network),
+			ElementName:     iface.Name,
+			ResourceSubType: model,
+			ResourceType:    resourceTypeEthernet,
+		})
+		if env.NetworkSection == nil {
+			env.NetworkSection = &networkSection{Info: "The list of logical networks"}
+		}
+		if !containsNetwork(env.NetworkSection.Networks, network) {
+			env.NetworkSection.Networks = append(env.NetworkSection.Networks, ovfNetwork{
+				Name:        network,
+				Description: fmt.Sprintf("The %s network", network),
+			})
+		}
+	}
+
+	for i := range items {
+		if items[i].InstanceID == "" {
+			items[i].InstanceID = strconv.Itoa(i + 1)
+		}
+	}
+	env.VirtualSystem.VirtualHardwareSection.Items = items
+
+	data, err := xml.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func vcpus(domain *v1.DomainSpec) int64 {
+	if domain.CPU != nil {
+		if count := hardware.GetNumberOfVCPUs(domain.CPU); count > 0 {
+			return count
+		}
+	}
+	for _, resources := range []k8sv1.ResourceList{domain.Resources.Requests, domain.Resources.Limits} {
+		if cpu, exists := resources[k8sv1.ResourceCPU]; exists {
+			if count := cpu.Value(); count > 0 {
+				return count
+			}
+		}
+	}
+	return 1
+}
+
+func memoryMiB(domain *v1.DomainSpec) int64 {
+	var memory *resource.Quantity
+	if domain.Memory != nil && domain.Memory.Guest != nil {
+		memory = domain.Memory.Guest
+	} else if requests, exists := domain.Resources.Requests[k8sv1.ResourceMemory]; exists {
+		memory = &requests
+	} else if limits, exists := domain.Resources.Limits[k8sv1.ResourceMemory]; exists {
+		memory = &limits
+	}
+	if memory == nil {
+		return 0
+	}
+	return divRoundUp(memory.Value(), 1<<20)
+}
+
+func diskBus(domain *v1.DomainSpec, name string) v1.DiskBus {
+	for _, disk := range domain.Devices.Disks {
+		if disk.Name != name {
+			continue
+		}
+		if disk.Disk != nil && disk.Disk.Bus != "" {
+			return disk.Disk.Bus
+		}
+		if disk.LUN != nil && disk.LUN.Bus != "" {
+			return disk.LUN.Bus
+		}
+	}
+	return v1.DiskBusVirtio
+}
+
+// networkNames maps the interfaces to the name of the network they are connected to
+func networkNames(vm *v1.VirtualMachine) map[string]string {
+	result := map[string]string{}
+	for _, network := range vm.Spec.Template.Spec.Networks {
+		if network.Multus != nil {
+			result[network.Name] = network.Multus.NetworkName
+		} else {
+			result[network.Name] = podNetworkName
+		}
+	}
+	return result
+}
+
+func containsNetwork(networks []ovfNetwork, name string) bool {
+	for _, network := range networks {
+		if network.Name == name {
+			return true
+		}
+	}
+	return false
+}
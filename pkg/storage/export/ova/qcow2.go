@@ -0,0 +1,283 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package ova
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+)
+
+const (
+	qcow2Magic        = 0x514649fb
+	qcow2Version      = 3
+	qcow2HeaderLength = 104
+
+	clusterBits = 16
+	clusterSize = 1 << clusterBits
+	// l2Entries is the number of clusters addressed by a single L2 table
+	l2Entries = clusterSize / 8
+	// refcountOrder selects 16 bit refcounts
+	refcountOrder        = 4
+	refcountBlockEntries = clusterSize / 2
+
+	// copiedFlag marks a cluster with a refcount of exactly one
+	copiedFlag = uint64(1) << 63
+)
+
+// qcow2Image streams a raw disk image as a qcow2 image, clusters holding only zeros are left unallocated.
+// The whole layout is computed upfront so the size of the image is known before it is written.
+type qcow2Image struct {
+	disk        io.ReaderAt
+	virtualSize int64
+
+	allocated    []bool
+	dataClusters int64
+	// l2Tables maps the L1 index to the index of its L2 table, or -1 when the range is unallocated
+	l2Tables   []int64
+	l2Count    int64
+	l1Clusters int64
+
+	refcountTableClusters int64
+	refcountBlocks        int64
+}
+
+// newQcow2Image scans the disk for the clusters that need to be allocated
+func newQcow2Image(disk io.ReaderAt, virtualSize int64) (*qcow2Image, error) {
+	q := &qcow2Image{
+		disk:        disk,
+		virtualSize: virtualSize,
+		allocated:   make([]bool, divRoundUp(virtualSize, clusterSize)),
+	}
+
+	buf := make([]byte, clusterSize)
+	for i := range q.allocated {
+		data, err := q.readCluster(int64(i), buf)
+		if err != nil {
+			return nil, err
+		}
+		if !isZero(data) {
+			q.allocated[i] = true
+			q.dataClusters++
+		}
+	}
+
+	q.l2Tables = make([]int64, divRoundUp(int64(len(q.allocated)), l2Entries))
+	for i := range q.l2Tables {
+		q.l2Tables[i] = -1
+		for j := int64(i) * l2Entries; j < int64(len(q.allocated)) && j < int64(i+1)*l2Entries; j++ {
+			if q.allocated[j] {
+				q.l2Tables[i] = q.l2Count
+				q.l2Count++
+				break
+			}
+		}
+	}
+	q.l1Clusters = divRoundUp(int64(len(q.l2Tables))*8, clusterSize)
+
+	// the refcount structures have to cover themselves, grow them until they do
+	for {
+		total := q.clusters()
+		blocks := divRoundUp(total, refcountBlockEntries)
+		tableClusters := divRoundUp(blocks*8, clusterSize)
+		if blocks == q.refcountBlocks && tableClusters == q.refcountTableClusters {
+			break
+		}
+		q.refcountBlocks = blocks
+		q.refcountTableClusters = tableClusters
+	}
+
+	return q, nil
+}
+
+// clusters returns the number of clusters of the image, the first one holds the header
+func (q *qcow2Image) clusters() int64 {
+	return 1 + q.refcountTableClusters + q.refcountBlocks + q.l1Clusters + q.l2Count + q.dataClusters
+}
+
+// Size returns the size of the qcow2 image
+func (q *qcow2Image) Size() int64 {
+	return q.clusters() * clusterSize
+}
+
+func (q *qcow2Image) refcountTableOffset() int64 {
+	return clusterSize
+}
+
+func (q *qcow2Image) refcountBlocksOffset() int64 {
+	return q.refcountTableOffset() + q.refcountTableClusters*clusterSize
+}
+
+func (q *qcow2Image) l1Offset() int64 {
+	return q.refcountBlocksOffset() + q.refcountBlocks*clusterSize
+}
+
+func (q *qcow2Image) l2Offset() int64 {
+	return q.l1Offset() + q.l1Clusters*clusterSize
+}
+
+func (q *qcow2Image) dataOffset() int64 {
+	return q.l2Offset() + q.l2Count*clusterSize
+}
+
+// WriteTo writes the qcow2 image: header, refcount table and blocks, L1 table, L2 tables and the data clusters
+func (q *qcow2Image) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriterSize(w, clusterSize)
+	cw := &countingWriter{w: bw}
+
+	header := make([]byte, clusterSize)
+	binary.BigEndian.PutUint32(header[0:], qcow2Magic)
+	binary.BigEndian.PutUint32(header[4:], qcow2Version)
+	binary.BigEndian.PutUint32(header[20:], clusterBits)
+	binary.BigEndian.PutUint64(header[24:], uint64(q.virtualSize))
+	binary.BigEndian.PutUint32(header[36:], uint32(len(q.l2Tables)))
+	binary.BigEndian.PutUint64(header[40:], uint64(q.l1Offset()))
+	binary.BigEndian.PutUint64(header[48:], uint64(q.refcountTableOffset()))
+	binary.BigEndian.PutUint32(header[56:], uint32(q.refcountTableClusters))
+	binary.BigEndian.PutUint32(header[96:], refcountOrder)
+	binary.BigEndian.PutUint32(header[100:], qcow2HeaderLength)
+	// the header extension area right after the header is left zeroed, which is the end marker
+	if _, err := cw.Write(header); err != nil {
+		return cw.n, err
+	}
+
+	if err := writeTable(cw, q.refcountTableClusters, func(i int64) (uint64, bool) {
+		return uint64(q.refcountBlocksOffset() + i*clusterSize), i < q.refcountBlocks
+	}); err != nil {
+		return cw.n, err
+	}
+
+	block := make([]byte, clusterSize)
+	total := q.clusters()
+	for b := int64(0); b < q.refcountBlocks; b++ {
+		for i := int64(0); i < refcountBlockEntries; i++ {
+			refcount := uint16(0)
+			if b*refcountBlockEntries+i < total {
+				refcount = 1
+			}
+			binary.BigEndian.PutUint16(block[i*2:], refcount)
+		}
+		if _, err := cw.Write(block); err != nil {
+			return cw.n, err
+		}
+	}
+
+	if err := writeTable(cw, q.l1Clusters, func(i int64) (uint64, bool) {
+		if i >= int64(len(q.l2Tables)) || q.l2Tables[i] < 0 {
+			return 0, false
+		}
+		return uint64(q.l2Offset()+q.l2Tables[i]*clusterSize) | copiedFlag, true
+	}); err != nil {
+		return cw.n, err
+	}
+
+	dataCluster := int64(0)
+	for i, l2 := range q.l2Tables {
+		if l2 < 0 {
+			continue
+		}
+		first := int64(i) * l2Entries
+		if err := writeTable(cw, 1, func(j int64) (uint64, bool) {
+			if first+j >= int64(len(q.allocated)) || !q.allocated[first+j] {
+				return 0, false
+			}
+			offset := uint64(q.dataOffset()+dataCluster*clusterSize) | copiedFlag
+			dataCluster++
+			return offset, true
+		}); err != nil {
+			return cw.n, err
+		}
+	}
+
+	for i, allocated := range q.allocated {
+		if !allocated {
+			continue
+		}
+		data, err := q.readCluster(int64(i), block)
+		if err != nil {
+			return cw.n, err
+		}
+		// the last cluster is padded with zeros
+		for j := len(data); j < clusterSize; j++ {
+			block[j] = 0
+		}
+		if _, err := cw.Write(block); err != nil {
+			return cw.n, err
+		}
+	}
+
+	return cw.n, bw.Flush()
+}
+
+// readCluster reads the content of a cluster of the raw disk, the last cluster can be shorter
+func (q *qcow2Image) readCluster(index int64, buf []byte) ([]byte, error) {
+	offset := index * clusterSize
+	length := int64(clusterSize)
+	if offset+length > q.virtualSize {
+		length = q.virtualSize - offset
+	}
+	n, err := q.disk.ReadAt(buf[:length], offset)
+	if err != nil && !(err == io.EOF && int64(n) == length) {
+		return nil, err
+	}
+	return buf[:length], nil
+}
+
+// writeTable writes clusters of big endian 64 bit entries, unset entries are written as zero
+func writeTable(w io.Writer, clusters int64, entry func(int64) (uint64, bool)) error {
+	table := make([]byte, clusterSize)
+	for c := int64(0); c < clusters; c++ {
+		for i := int64(0); i < clusterSize/8; i++ {
+			value, set := entry(c*clusterSize/8 + i)
+			if !set {
+				value = 0
+			}
+			binary.BigEndian.PutUint64(table[i*8:], value)
+		}
+		if _, err := w.Write(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func divRoundUp(n, d int64) int64 {
+	return (n + d - 1) / d
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
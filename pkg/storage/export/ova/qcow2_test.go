@@ -0,0 +1,155 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package ova
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// sparseDisk is a raw disk holding only zeros apart from the data at the given offsets
+type sparseDisk struct {
+	size int64
+	data map[int64]string
+}
+
+func (d *sparseDisk) ReadAt(p []byte, off int64) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	for offset, data := range d.data {
+		for i := range data {
+			if offset+int64(i) >= off && offset+int64(i) < off+int64(len(p)) {
+				p[offset+int64(i)-off] = data[i]
+			}
+		}
+	}
+	if off+int64(len(p)) > d.size {
+		return int(d.size - off), io.EOF
+	}
+	return len(p), nil
+}
+
+// clusters returns the content of the clusters holding data
+func (d *sparseDisk) clusters() map[uint64][]byte {
+	result := map[uint64][]byte{}
+	for offset := range d.data {
+		index := uint64(offset / clusterSize)
+		cluster := make([]byte, clusterSize)
+		d.ReadAt(cluster, int64(index)*clusterSize)
+		result[index] = cluster
+	}
+	return result
+}
+
+// readQcow2 checks the metadata of a qcow2 image and returns its virtual size and the content of the allocated clusters
+func readQcow2(image []byte) (uint64, map[uint64][]byte) {
+	Expect(binary.BigEndian.Uint32(image[0:])).To(Equal(uint32(qcow2Magic)))
+	Expect(binary.BigEndian.Uint32(image[4:])).To(Equal(uint32(qcow2Version)))
+	Expect(binary.BigEndian.Uint32(image[20:])).To(Equal(uint32(clusterBits)))
+	Expect(binary.BigEndian.Uint32(image[96:])).To(Equal(uint32(refcountOrder)))
+	Expect(len(image) % clusterSize).To(BeZero())
+
+	size := binary.BigEndian.Uint64(image[24:])
+	l1Size := binary.BigEndian.Uint32(image[36:])
+	l1Offset := binary.BigEndian.Uint64(image[40:])
+	refcountTableOffset := binary.BigEndian.Uint64(image[48:])
+	refcountTableClusters := binary.BigEndian.Uint32(image[56:])
+
+	used := map[uint64]bool{0: true}
+	markUsed := func(offset uint64, clusters uint64) {
+		for i := uint64(0); i < clusters; i++ {
+			Expect(used).ToNot(HaveKey(offset/clusterSize + i))
+			used[offset/clusterSize+i] = true
+		}
+	}
+	markUsed(refcountTableOffset, uint64(refcountTableClusters))
+	markUsed(l1Offset, uint64(divRoundUp(int64(l1Size)*8, clusterSize)))
+
+	clusters := map[uint64][]byte{}
+	for i := uint64(0); i < uint64(l1Size); i++ {
+		l2Offset := binary.BigEndian.Uint64(image[l1Offset+i*8:]) &^ copiedFlag
+		if l2Offset == 0 {
+			continue
+		}
+		markUsed(l2Offset, 1)
+		for j := uint64(0); j < l2Entries; j++ {
+			dataOffset := binary.BigEndian.Uint64(image[l2Offset+j*8:]) &^ copiedFlag
+			if dataOffset == 0 {
+				continue
+			}
+			markUsed(dataOffset, 1)
+			clusters[i*l2Entries+j] = image[dataOffset : dataOffset+clusterSize]
+		}
+	}
+
+	var refcounted uint64
+	for i := uint64(0); i < uint64(refcountTableClusters)*clusterSize/8; i++ {
+		blockOffset := binary.BigEndian.Uint64(image[refcountTableOffset+i*8:])
+		if blockOffset == 0 {
+			continue
+		}
+		markUsed(blockOffset, 1)
+		for j := uint64(0); j < refcountBlockEntries; j++ {
+			refcount := binary.BigEndian.Uint16(image[blockOffset+j*2:])
+			cluster := i*refcountBlockEntries + j
+			if refcount == 0 {
+				Expect(cluster).To(BeNumerically(">=", len(image)/clusterSize))
+				continue
+			}
+			Expect(refcount).To(Equal(uint16(1)))
+			refcounted++
+		}
+	}
+	Expect(used).To(HaveLen(len(image) / clusterSize))
+	Expect(refcounted).To(Equal(uint64(len(image) / clusterSize)))
+	return size, clusters
+}
+
+var _ = Describe("qcow2", func() {
+	DescribeTable("should convert the raw image", func(size int64, dataOffsets []int64, expectedDataClusters int64) {
+		disk := &sparseDisk{size: size, data: map[int64]string{}}
+		for _, offset := range dataOffsets {
+			disk.data[offset] = "data"
+		}
+
+		image, err := newQcow2Image(disk, size)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(image.dataClusters).To(Equal(expectedDataClusters))
+
+		out := &bytes.Buffer{}
+		n, err := image.WriteTo(out)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(image.Size()))
+		Expect(int64(out.Len())).To(Equal(image.Size()))
+		virtualSize, clusters := readQcow2(out.Bytes())
+		Expect(virtualSize).To(Equal(uint64(size)))
+		Expect(clusters).To(Equal(disk.clusters()))
+	},
+		Entry("when empty", int64(10*clusterSize), nil, int64(0)),
+		Entry("with sparse data", int64(10*clusterSize), []int64{0, 5*clusterSize + 10}, int64(2)),
+		Entry("with a partial last cluster", int64(3*clusterSize+512), []int64{3 * clusterSize}, int64(1)),
+		Entry("with data addressed by several L2 tables", int64(3*l2Entries*clusterSize), []int64{clusterSize, 2*l2Entries*clusterSize + 7}, int64(2)),
+	)
+})
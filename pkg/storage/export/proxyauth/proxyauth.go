@@ -0,0 +1,232 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+// Package proxyauth authorizes export downloads with Kubernetes bearer tokens.
+// virt-exportproxy reviews the bearer token and forwards the authorized requests to the exporter pod
+// with a proxy token. The proxy token is derived from a key only virt-controller and virt-exportproxy
+// can read, so the exporter pods in the user namespaces never hold any cluster wide privilege.
+// Requests reaching the exporter pod directly are authorized with a SelfSubjectAccessReview created
+// with the bearer token itself, which needs no privilege either.
+package proxyauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// Header is the header carrying the proxy token of the requests virt-exportproxy authorized
+	Header = "x-kubevirt-export-proxy-token"
+	// KeySecretName is the secret in the KubeVirt namespace holding the key the proxy tokens are derived from
+	KeySecretName = "kubevirt-export-proxy-key"
+
+	// downloadSubresource is the subresource of the export a user needs get access to in order to download
+	downloadSubresource = "download"
+
+	keySecretKey = "key"
+	keyLength    = 32
+
+	inClusterCAFile = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// ReviewFunc returns true if the bearer token belongs to a user allowed to download from the export
+type ReviewFunc func(ctx context.Context, token, namespace, name string) (bool, error)
+
+// NewTokenReviewer authenticates bearer tokens with a TokenReview and authorizes the user with a
+// SubjectAccessReview for the download subresource of the export
+func NewTokenReviewer(client kubernetes.Interface) ReviewFunc {
+	return func(ctx context.Context, token, namespace, name string) (bool, error) {
+		tr, err := client.AuthenticationV1().TokenReviews().Create(ctx, &authnv1.TokenReview{
+			Spec: authnv1.TokenReviewSpec{Token: token},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		if !tr.Status.Authenticated {
+			log.Log.V(3).Infof("Token not authenticated: %s", tr.Status.Error)
+			return false, nil
+		}
+
+		user := tr.Status.User
+		extra := make(map[string]authzv1.ExtraValue, len(user.Extra))
+		for k, v := range user.Extra {
+			extra[k] = authzv1.ExtraValue(v)
+		}
+		sar, err := client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authzv1.SubjectAccessReview{
+			Spec: authzv1.SubjectAccessReviewSpec{
+				User:               user.Username,
+				UID:                user.UID,
+				Groups:             user.Groups,
+				Extra:              extra,
+				ResourceAttributes: downloadAttributes(namespace, name),
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		if !sar.Status.Allowed {
+			log.Log.V(3).Infof("User %s not allowed to download from %s/%s: %s", user.Username, namespace, name, sar.Status.Reason)
+		}
+		return sar.Status.Allowed, nil
+	}
+}
+
+// ClientForTokenFunc returns a client authenticated with the given bearer token
+type ClientForTokenFunc func(token string) (kubernetes.Interface, error)
+
+// NewSelfSubjectReviewer authorizes bearer tokens with a SelfSubjectAccessReview for the download
+// subresource of the export, created with the bearer token itself. The API server authenticates the
+// token as a TokenReview would, so the reviewer does not need any privilege of its own.
+func NewSelfSubjectReviewer(clientForToken ClientForTokenFunc) ReviewFunc {
+	return func(ctx context.Context, token, namespace, name string) (bool, error) {
+		client, err := clientForToken(token)
+		if err != nil {
+			return false, err
+		}
+		ssar, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authzv1.SelfSubjectAccessReview{
+			Spec: authzv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: downloadAttributes(namespace, name),
+			},
+		}, metav1.CreateOptions{})
+		if errors.IsUnauthorized(err) {
+			log.Log.V(3).Infof("Token not authenticated: %v", err)
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if !ssar.Status.Allowed {
+			log.Log.V(3).Infof("Token not allowed to download from %s/%s: %s", namespace, name, ssar.Status.Reason)
+		}
+		return ssar.Status.Allowed, nil
+	}
+}
+
+// InClusterClientForToken returns clients of the API server of the cluster the pod runs in.
+// Unlike rest.InClusterConfig it does not need the pod to have a ServiceAccount token.
+func InClusterClientForToken() (ClientForTokenFunc, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a cluster")
+	}
+	if _, err := os.Stat(inClusterCAFile); err != nil {
+		return nil, err
+	}
+	return func(token string) (kubernetes.Interface, error) {
+		return kubernetes.NewForConfig(&rest.Config{
+			Host:        "https://" + net.JoinHostPort(host, port),
+			BearerToken: token,
+			TLSClientConfig: rest.TLSClientConfig{
+				CAFile: inClusterCAFile,
+			},
+		})
+	}, nil
+}
+
+func downloadAttributes(namespace, name string) *authzv1.ResourceAttributes {
+	return &authzv1.ResourceAttributes{
+		Namespace:   namespace,
+		Verb:        "get",
+		Group:       exportv1.SchemeGroupVersion.Group,
+		Version:     exportv1.SchemeGroupVersion.Version,
+		Resource:    "virtualmachineexports",
+		Subresource: downloadSubresource,
+		Name:        name,
+	}
+}
+
+// BearerToken returns the bearer token of the request, if any
+func BearerToken(r *http.Request) string {
+	const prefix = "bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) <= len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(auth[len(prefix):])
+}
+
+// Token returns the proxy token of the export with the given UID
+func Token(key []byte, uid types.UID) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(uid))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// GetKey returns the key the proxy tokens are derived from
+func GetKey(ctx context.Context, client kubernetes.Interface, namespace string) ([]byte, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, KeySecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	key := secret.Data[keySecretKey]
+	if len(key) == 0 {
+		return nil, fmt.Errorf("secret %s/%s holds no key", namespace, KeySecretName)
+	}
+	return key, nil
+}
+
+// EnsureKey returns the key the proxy tokens are derived from, generating it if it does not exist yet
+func EnsureKey(ctx context.Context, client kubernetes.Interface, namespace string) ([]byte, error) {
+	key, err := GetKey(ctx, client, namespace)
+	if !errors.IsNotFound(err) {
+		return key, err
+	}
+
+	key = make([]byte, keyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	_, err = client.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      KeySecretName,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			keySecretKey: key,
+		},
+	}, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		// another virt-controller generated it first
+		return GetKey(ctx, client, namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
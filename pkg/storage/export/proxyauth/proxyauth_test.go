@@ -0,0 +1,188 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package proxyauth
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("proxy authorization", func() {
+	const (
+		bearer        = "sa-token"
+		testNamespace = "default"
+		testName      = "test-export"
+		kvNamespace   = "kubevirt"
+	)
+
+	var client *fake.Clientset
+
+	BeforeEach(func() {
+		client = fake.NewSimpleClientset()
+	})
+
+	Context("token reviewer", func() {
+		reactToTokenReview := func(authenticated bool) {
+			client.Fake.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				tr := action.(k8stesting.CreateAction).GetObject().(*authnv1.TokenReview)
+				Expect(tr.Spec.Token).To(Equal(bearer))
+				tr.Status = authnv1.TokenReviewStatus{
+					Authenticated: authenticated,
+					User: authnv1.UserInfo{
+						Username: "system:serviceaccount:default:downloader",
+						Groups:   []string{"system:serviceaccounts"},
+						Extra:    map[string]authnv1.ExtraValue{"key": {"value"}},
+					},
+				}
+				return true, tr, nil
+			})
+		}
+
+		reactToSubjectAccessReview := func(allowed bool) {
+			client.Fake.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				sar := action.(k8stesting.CreateAction).GetObject().(*authzv1.SubjectAccessReview)
+				Expect(sar.Spec.User).To(Equal("system:serviceaccount:default:downloader"))
+				Expect(sar.Spec.Groups).To(ConsistOf("system:serviceaccounts"))
+				Expect(sar.Spec.Extra).To(HaveKeyWithValue("key", authzv1.ExtraValue{"value"}))
+				Expect(*sar.Spec.ResourceAttributes).To(Equal(authzv1.ResourceAttributes{
+					Namespace:   testNamespace,
+					Verb:        "get",
+					Group:       "export.kubevirt.io",
+					Version:     "v1alpha1",
+					Resource:    "virtualmachineexports",
+					Subresource: "download",
+					Name:        testName,
+				}))
+				sar.Status.Allowed = allowed
+				return true, sar, nil
+			})
+		}
+
+		DescribeTable("should allow authenticated and authorized users", func(authenticated, allowed, expected bool) {
+			reactToTokenReview(authenticated)
+			reactToSubjectAccessReview(allowed)
+			result, err := NewTokenReviewer(client)(context.Background(), bearer, testNamespace, testName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+			Entry("authenticated and allowed", true, true, true),
+			Entry("authenticated and not allowed", true, false, false),
+			Entry("not authenticated", false, true, false),
+		)
+
+		It("should return the error of the token review", func() {
+			client.Fake.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf("failure")
+			})
+			_, err := NewTokenReviewer(client)(context.Background(), bearer, testNamespace, testName)
+			Expect(err).To(MatchError("failure"))
+		})
+	})
+
+	Context("self subject reviewer", func() {
+		clientForToken := func(token string) (kubernetes.Interface, error) {
+			Expect(token).To(Equal(bearer))
+			return client, nil
+		}
+
+		reactToSelfSubjectAccessReview := func(allowed bool, err error) {
+			client.Fake.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if err != nil {
+					return true, nil, err
+				}
+				ssar := action.(k8stesting.CreateAction).GetObject().(*authzv1.SelfSubjectAccessReview)
+				Expect(*ssar.Spec.ResourceAttributes).To(Equal(authzv1.ResourceAttributes{
+					Namespace:   testNamespace,
+					Verb:        "get",
+					Group:       "export.kubevirt.io",
+					Version:     "v1alpha1",
+					Resource:    "virtualmachineexports",
+					Subresource: "download",
+					Name:        testName,
+				}))
+				ssar.Status.Allowed = allowed
+				return true, ssar, nil
+			})
+		}
+
+		DescribeTable("should allow tokens of authorized users", func(allowed bool, reviewErr error, expected bool) {
+			reactToSelfSubjectAccessReview(allowed, reviewErr)
+			result, err := NewSelfSubjectReviewer(clientForToken)(context.Background(), bearer, testNamespace, testName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+			Entry("allowed", true, nil, true),
+			Entry("not allowed", false, nil, false),
+			Entry("not authenticated", true, errors.NewUnauthorized("invalid token"), false),
+		)
+
+		It("should return the other errors of the review", func() {
+			reactToSelfSubjectAccessReview(true, fmt.Errorf("failure"))
+			_, err := NewSelfSubjectReviewer(clientForToken)(context.Background(), bearer, testNamespace, testName)
+			Expect(err).To(MatchError("failure"))
+		})
+	})
+
+	Context("proxy key", func() {
+		It("should generate the key once", func() {
+			key, err := EnsureKey(context.Background(), client, kvNamespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key).To(HaveLen(keyLength))
+
+			again, err := EnsureKey(context.Background(), client, kvNamespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(again).To(Equal(key))
+			Expect(GetKey(context.Background(), client, kvNamespace)).To(Equal(key))
+		})
+
+		It("should fail to get a key that does not exist", func() {
+			_, err := GetKey(context.Background(), client, kvNamespace)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail to get an empty key", func() {
+			_, err := client.CoreV1().Secrets(kvNamespace).Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: KeySecretName},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = EnsureKey(context.Background(), client, kvNamespace)
+			Expect(err).To(MatchError("secret kubevirt/kubevirt-export-proxy-key holds no key"))
+		})
+
+		It("should derive distinct tokens per export", func() {
+			key := []byte("key")
+			Expect(Token(key, "uid1")).To(Equal(Token(key, "uid1")))
+			Expect(Token(key, "uid1")).ToNot(Equal(Token(key, "uid2")))
+			Expect(Token([]byte("other"), "uid1")).ToNot(Equal(Token(key, "uid1")))
+		})
+	})
+})
@@ -0,0 +1,358 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"time"
+
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+const (
+	ManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	ConfigMediaType   = "application/vnd.oci.image.config.v1+json"
+	LayerMediaType    = "application/vnd.oci.image.layer.v1.tar+gzip"
+
+	// DiskPath is the path of the disk image in the containerDisk layer
+	DiskPath = "disk/disk.img"
+)
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        descriptor   `json:"config"`
+	Layers        []descriptor `json:"layers"`
+}
+
+type imageConfig struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	RootFS       rootFS `json:"rootfs"`
+}
+
+type rootFS struct {
+	Type    string   `json:"type"`
+	DiffIDs []string `json:"diff_ids"`
+}
+
+type client struct {
+	httpClient    *http.Client
+	ref           *Reference
+	credentials   *Credentials
+	authorization string
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// PushContainerDisk pushes the disk image at diskPath, a file or a block device, as a containerDisk
+// image with the disk under /disk/ and returns the digest of the image manifest
+func PushContainerDisk(httpClient *http.Client, ref *Reference, credentials *Credentials, diskPath string) (string, error) {
+	c := &client{
+		httpClient:  httpClient,
+		ref:         ref,
+		credentials: credentials,
+	}
+	if err := c.authenticate(); err != nil {
+		return "", err
+	}
+
+	layer, diffID, err := c.pushLayer(diskPath)
+	if err != nil {
+		return "", err
+	}
+
+	configBytes, err := json.Marshal(&imageConfig{
+		Architecture: runtime.GOARCH,
+		OS:           "linux",
+		RootFS: rootFS{
+			Type:    "layers",
+			DiffIDs: []string{diffID},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	config := describeBytes(ConfigMediaType, configBytes)
+	if err := c.pushBlob(config, func() io.Reader {
+		return bytes.NewReader(configBytes)
+	}); err != nil {
+		return "", err
+	}
+
+	manifestBytes, err := json.Marshal(&manifest{
+		SchemaVersion: 2,
+		MediaType:     ManifestMediaType,
+		Config:        config,
+		Layers:        []descriptor{layer},
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := c.pushManifest(manifestBytes); err != nil {
+		return "", err
+	}
+	return digest(manifestBytes), nil
+}
+
+// writeLayer writes the gzipped tar of the layer holding the disk, diffID receives the uncompressed tar if set
+func writeLayer(w io.Writer, diskPath string, diffID hash.Hash) error {
+	f, err := os.Open(diskPath)
+	if err != nil {
+		return err
+	}
+	defer util.CloseIOAndCheckErr(f, nil)
+
+	// Seeking works for both files and block devices
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(w)
+	var tw *tar.Writer
+	if diffID != nil {
+		tw = tar.NewWriter(io.MultiWriter(zw, diffID))
+	} else {
+		tw = tar.NewWriter(zw)
+	}
+
+	// Fixed timestamps keep the layer reproducible
+	modTime := time.Unix(0, 0)
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     "disk/",
+		Mode:     0555,
+		Uid:      util.NonRootUID,
+		Gid:      util.NonRootUID,
+		ModTime:  modTime,
+	}); err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     DiskPath,
+		Mode:     0440,
+		Size:     size,
+		Uid:      util.NonRootUID,
+		Gid:      util.NonRootUID,
+		ModTime:  modTime,
+	}); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func describeBytes(mediaType string, data []byte) descriptor {
+	return descriptor{
+		MediaType: mediaType,
+		Digest:    digest(data),
+		Size:      int64(len(data)),
+	}
+}
+
+func digest(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
+
+func (c *client) url(format string, args ...interface{}) string {
+	return fmt.Sprintf("https://%s/v2/%s/", c.ref.Registry, c.ref.Repository) + fmt.Sprintf(format, args...)
+}
+
+func (c *client) do(req *http.Request) (*http.Response, error) {
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	return c.httpClient.Do(req)
+}
+
+// pushBlob uploads a blob in a single request unless the registry already has it
+func (c *client) pushBlob(desc descriptor, body func() io.Reader) error {
+	req, err := http.NewRequest(http.MethodHead, c.url("blobs/%s", desc.Digest), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	location, err := c.startUpload()
+	if err != nil {
+		return fmt.Errorf("unable to start the upload of blob %s: %v", desc.Digest, err)
+	}
+	return c.completeUpload(location, desc, body(), desc.Size)
+}
+
+// pushLayer streams the layer holding the disk to the registry while computing its digests,
+// so the disk is read and compressed only once. It returns the descriptor of the layer and
+// the digest of the uncompressed tar.
+func (c *client) pushLayer(diskPath string) (descriptor, string, error) {
+	location, err := c.startUpload()
+	if err != nil {
+		return descriptor{}, "", fmt.Errorf("unable to start the upload of the disk layer: %v", err)
+	}
+
+	compressed := sha256.New()
+	uncompressed := sha256.New()
+	counter := &countingWriter{}
+	pr, pw := io.Pipe()
+	written := make(chan error, 1)
+	go func() {
+		err := writeLayer(io.MultiWriter(pw, compressed, counter), diskPath, uncompressed)
+		pw.CloseWithError(err)
+		written <- err
+	}()
+
+	req, err := http.NewRequest(http.MethodPatch, location.String(), pr)
+	if err != nil {
+		pr.CloseWithError(err)
+		<-written
+		return descriptor{}, "", err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.do(req)
+	// Unblock the writer if the request did not consume the whole layer
+	pr.CloseWithError(io.ErrClosedPipe)
+	writeErr := <-written
+	if err == nil {
+		resp.Body.Close()
+	}
+	if writeErr != nil && writeErr != io.ErrClosedPipe {
+		return descriptor{}, "", writeErr
+	}
+	if err != nil {
+		return descriptor{}, "", err
+	}
+	if resp.StatusCode != http.StatusAccepted || writeErr != nil {
+		return descriptor{}, "", fmt.Errorf("unable to upload the disk layer: %s", resp.Status)
+	}
+
+	// The upload continues at the location returned by the last request
+	location, err = req.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return descriptor{}, "", err
+	}
+	layer := descriptor{
+		MediaType: LayerMediaType,
+		Digest:    fmt.Sprintf("sha256:%x", compressed.Sum(nil)),
+		Size:      counter.n,
+	}
+	if err := c.completeUpload(location, layer, nil, 0); err != nil {
+		return descriptor{}, "", err
+	}
+	return layer, fmt.Sprintf("sha256:%x", uncompressed.Sum(nil)), nil
+}
+
+// startUpload starts a blob upload and returns the location to upload the blob to
+func (c *client) startUpload() (*url.URL, error) {
+	req, err := http.NewRequest(http.MethodPost, c.url("blobs/uploads/"), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+
+	// The location may be relative to the request and already carry a query
+	return req.URL.Parse(resp.Header.Get("Location"))
+}
+
+// completeUpload completes the upload at location with the last part of the blob
+func (c *client) completeUpload(location *url.URL, desc descriptor, body io.Reader, size int64) error {
+	query := location.Query()
+	query.Set("digest", desc.Digest)
+	location.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodPut, location.String(), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unable to upload blob %s: %s", desc.Digest, resp.Status)
+	}
+	return nil
+}
+
+func (c *client) pushManifest(data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, c.url("manifests/%s", url.PathEscape(c.ref.Tag)), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ManifestMediaType)
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unable to push manifest %s: %s", c.ref, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeRegistry is an in memory stand-in for a registry implementing the parts of the distribution API used to push
+type fakeRegistry struct {
+	lock      sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   int
+	// pending holds the data streamed to the uploads which are not complete yet
+	pending map[string][]byte
+	// token is required as bearer token when set
+	token    string
+	username string
+	password string
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+		pending:   map[string][]byte{},
+	}
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.URL.Path == "/token" {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != f.username || password != f.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(&tokenResponse{Token: f.token})
+		return
+	}
+	if f.token != "" && r.Header.Get("Authorization") != "Bearer "+f.token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="fake"`, r.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	switch {
+	case path == "":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodHead && strings.Contains(path, "/blobs/sha256:"):
+		if _, exists := f.blobs[path[strings.LastIndex(path, "/")+1:]]; !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/blobs/uploads/"):
+		f.uploads++
+		w.Header().Set("Location", fmt.Sprintf("%d?state=fake", f.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPatch && strings.Contains(path, "/blobs/uploads/"):
+		if r.URL.Query().Get("state") != "fake" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.pending[path] = append(f.pending[path], data...)
+		w.Header().Set("Location", fmt.Sprintf("%s?state=fake", path[strings.LastIndex(path, "/")+1:]))
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPut && strings.Contains(path, "/blobs/uploads/"):
+		body, _ := io.ReadAll(r.Body)
+		data := append(f.pending[path], body...)
+		delete(f.pending, path)
+		digest := r.URL.Query().Get("digest")
+		if r.URL.Query().Get("state") != "fake" || digest != fmt.Sprintf("sha256:%x", sha256.Sum256(data)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.blobs[digest] = data
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && strings.Contains(path, "/manifests/"):
+		if r.Header.Get("Content-Type") != ManifestMediaType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.manifests[path] = data
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var _ = Describe("Registry", func() {
+	DescribeTable("should parse the reference", func(ref string, expected *Reference) {
+		result, err := ParseReference(ref)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(expected))
+	},
+		Entry("with a tag", "registry.example.com/vms/disk:v1", &Reference{Registry: "registry.example.com", Repository: "vms/disk", Tag: "v1"}),
+		Entry("without a tag", "registry.example.com/disk", &Reference{Registry: "registry.example.com", Repository: "disk", Tag: "latest"}),
+		Entry("with a port", "localhost:5000/disk:v1", &Reference{Registry: "localhost:5000", Repository: "disk", Tag: "v1"}),
+		Entry("with localhost", "localhost/disk", &Reference{Registry: "localhost", Repository: "disk", Tag: "latest"}),
+	)
+
+	DescribeTable("should reject the reference", func(ref string) {
+		_, err := ParseReference(ref)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("without a registry", "vms/disk:v1"),
+		Entry("with only a name", "disk"),
+		Entry("with a digest", "registry.example.com/disk@sha256:abcd"),
+		Entry("with an uppercase repository", "registry.example.com/Disk"),
+		Entry("with an invalid tag", "registry.example.com/disk:-v1"),
+	)
+
+	Context("with a docker config", func() {
+		It("should return the credentials of the registry", func() {
+			config := fmt.Sprintf(`{"auths":{"https://other.example.com/v1/":{"auth":"%s"},"registry.example.com":{"auth":"%s"}}}`,
+				base64.StdEncoding.EncodeToString([]byte("other:secret")),
+				base64.StdEncoding.EncodeToString([]byte("user:pass:word")))
+			credentials, err := CredentialsFromDockerConfig([]byte(config), "registry.example.com")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials).To(Equal(&Credentials{Username: "user", Password: "pass:word"}))
+		})
+
+		It("should return the username and password of the registry", func() {
+			config := `{"auths":{"https://registry.example.com":{"username":"user","password":"password"}}}`
+			credentials, err := CredentialsFromDockerConfig([]byte(config), "registry.example.com")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials).To(Equal(&Credentials{Username: "user", Password: "password"}))
+		})
+
+		It("should return no credentials for an unknown registry", func() {
+			credentials, err := CredentialsFromDockerConfig([]byte(`{"auths":{}}`), "registry.example.com")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials).To(BeNil())
+		})
+	})
+
+	Context("when pushing", func() {
+		var (
+			registry *fakeRegistry
+			server   *httptest.Server
+			diskPath string
+			ref      *Reference
+		)
+
+		BeforeEach(func() {
+			registry = newFakeRegistry()
+			server = httptest.NewTLSServer(registry)
+			diskPath = filepath.Join(GinkgoT().TempDir(), "disk.img")
+			Expect(os.WriteFile(diskPath, []byte("disk content"), 0644)).To(Succeed())
+			ref = &Reference{Registry: strings.TrimPrefix(server.URL, "https://"), Repository: "vms/disk", Tag: "v1"}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		expectContainerDisk := func(digest string) {
+			data, exists := registry.manifests["vms/disk/manifests/v1"]
+			Expect(exists).To(BeTrue())
+			Expect(digest).To(Equal(fmt.Sprintf("sha256:%x", sha256.Sum256(data))))
+
+			m := &manifest{}
+			Expect(json.Unmarshal(data, m)).To(Succeed())
+			Expect(m.Layers).To(HaveLen(1))
+			Expect(registry.blobs).To(HaveKey(m.Config.Digest))
+
+			layer := registry.blobs[m.Layers[0].Digest]
+			Expect(layer).To(HaveLen(int(m.Layers[0].Size)))
+			zr, err := gzip.NewReader(bytes.NewReader(layer))
+			Expect(err).ToNot(HaveOccurred())
+			tr := tar.NewReader(zr)
+			hdr, err := tr.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hdr.Name).To(Equal("disk/"))
+			hdr, err = tr.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hdr.Name).To(Equal(DiskPath))
+			Expect(hdr.Uid).To(Equal(107))
+			content, err := io.ReadAll(tr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal("disk content"))
+		}
+
+		It("should push the disk as a containerDisk", func() {
+			digest, err := PushContainerDisk(server.Client(), ref, nil, diskPath)
+			Expect(err).ToNot(HaveOccurred())
+			expectContainerDisk(digest)
+		})
+
+		It("should not upload the config the registry already has", func() {
+			_, err := PushContainerDisk(server.Client(), ref, nil, diskPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(registry.uploads).To(Equal(2))
+
+			// the digest of the layer is only known once it is streamed
+			digest, err := PushContainerDisk(server.Client(), ref, nil, diskPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(registry.uploads).To(Equal(3))
+			Expect(registry.blobs).To(HaveLen(2))
+			expectContainerDisk(digest)
+		})
+
+		It("should stream the layer without knowing its size", func() {
+			content := bytes.Repeat([]byte("disk content"), 64*1024)
+			Expect(os.WriteFile(diskPath, content, 0644)).To(Succeed())
+
+			_, err := PushContainerDisk(server.Client(), ref, nil, diskPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(registry.pending).To(BeEmpty())
+			Expect(registry.blobs).To(HaveLen(2))
+		})
+
+		It("should authenticate with a bearer token", func() {
+			registry.token = "token"
+			registry.username = "user"
+			registry.password = "password"
+
+			digest, err := PushContainerDisk(server.Client(), ref, &Credentials{Username: "user", Password: "password"}, diskPath)
+			Expect(err).ToNot(HaveOccurred())
+			expectContainerDisk(digest)
+		})
+
+		It("should fail with wrong credentials", func() {
+			registry.token = "token"
+			registry.username = "user"
+			registry.password = "password"
+
+			_, err := PushContainerDisk(server.Client(), ref, &Credentials{Username: "user", Password: "wrong"}, diskPath)
+			Expect(err).To(MatchError(ContainSubstring("unable to get a token")))
+			Expect(registry.manifests).To(BeEmpty())
+		})
+
+		It("should fail when the disk does not exist", func() {
+			_, err := PushContainerDisk(server.Client(), ref, nil, filepath.Join(filepath.Dir(diskPath), "missing.img"))
+			Expect(err).To(HaveOccurred())
+			Expect(registry.manifests).To(BeEmpty())
+		})
+	})
+})
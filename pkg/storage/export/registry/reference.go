@@ -0,0 +1,78 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const defaultTag = "latest"
+
+var (
+	repositoryRegex = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagRegex        = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+)
+
+// Reference is an image reference of the form registry/repository:tag
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+}
+
+func (r *Reference) String() string {
+	return fmt.Sprintf("%s/%s:%s", r.Registry, r.Repository, r.Tag)
+}
+
+// ParseReference parses an image reference, the registry is mandatory and the tag defaults to latest
+func ParseReference(ref string) (*Reference, error) {
+	if strings.Contains(ref, "@") {
+		return nil, fmt.Errorf("image reference %q must not contain a digest", ref)
+	}
+
+	parts := strings.SplitN(ref, "/", 2)
+	if len(parts) != 2 || !isRegistry(parts[0]) {
+		return nil, fmt.Errorf("image reference %q must start with a registry host", ref)
+	}
+
+	result := &Reference{
+		Registry:   parts[0],
+		Repository: parts[1],
+		Tag:        defaultTag,
+	}
+	if idx := strings.LastIndex(result.Repository, ":"); idx != -1 {
+		result.Tag = result.Repository[idx+1:]
+		result.Repository = result.Repository[:idx]
+	}
+
+	if !repositoryRegex.MatchString(result.Repository) {
+		return nil, fmt.Errorf("image reference %q has an invalid repository %q", ref, result.Repository)
+	}
+	if !tagRegex.MatchString(result.Tag) {
+		return nil, fmt.Errorf("image reference %q has an invalid tag %q", ref, result.Tag)
+	}
+	return result, nil
+}
+
+func isRegistry(host string) bool {
+	return host == "localhost" || strings.ContainsAny(host, ".:")
+}
@@ -0,0 +1,146 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+// Package signedurl creates and verifies pre-signed export download URLs, which can be used
+// until a download with them completes. URLs are signed with the export token, so they can be
+// handed to external tools without sharing the token itself. The used URLs are remembered in
+// memory by the verifier only, so a restarted export server accepts them again until they expire.
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+)
+
+const (
+	// ExpiresParam is the query parameter holding the expiry of the URL as unix time
+	ExpiresParam = "x-kubevirt-export-expires"
+	// NonceParam is the query parameter holding the random value identifying the URL
+	NonceParam = "x-kubevirt-export-nonce"
+	// SignatureParam is the query parameter holding the signature of the URL
+	SignatureParam = "x-kubevirt-export-signature"
+
+	nonceLength = 16
+)
+
+// the export proxy strips this prefix before forwarding to the export server, so it is not signed
+var proxyPrefix = regexp.MustCompile(`^/api/` + regexp.QuoteMeta(exportv1.SchemeGroupVersion.String()) + `/namespaces/[^/]+/virtualmachineexports/[^/]+`)
+
+// Sign returns rawURL with the parameters making it a pre-signed URL valid until expires
+func Sign(rawURL, key string, expires time.Time) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	nonceBytes := make([]byte, nonceLength)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return "", err
+	}
+	nonce := hex.EncodeToString(nonceBytes)
+	expiresValue := strconv.FormatInt(expires.Unix(), 10)
+
+	q := u.Query()
+	q.Set(ExpiresParam, expiresValue)
+	q.Set(NonceParam, nonce)
+	q.Set(SignatureParam, signature(key, u.Path, expiresValue, nonce))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// IsSigned returns true if the URL carries a signature
+func IsSigned(u *url.URL) bool {
+	return u.Query().Has(SignatureParam)
+}
+
+func signature(key, urlPath, expires, nonce string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	fmt.Fprintf(mac, "%s\n%s\n%s", proxyPrefix.ReplaceAllString(urlPath, ""), expires, nonce)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Verifier checks pre-signed URLs and remembers the used ones until they expire
+type Verifier struct {
+	lock sync.Mutex
+	used map[string]time.Time
+	now  func() time.Time
+}
+
+func NewVerifier() *Verifier {
+	return &Verifier{
+		used: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+// Verify returns an error if u is not validly signed with key, is expired or has already been used
+// for a completed download
+func (v *Verifier) Verify(u *url.URL, key string) error {
+	q := u.Query()
+	expiresValue, nonce, sig := q.Get(ExpiresParam), q.Get(NonceParam), q.Get(SignatureParam)
+	if expiresValue == "" || nonce == "" || sig == "" {
+		return fmt.Errorf("incomplete URL signature")
+	}
+	unix, err := strconv.ParseInt(expiresValue, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid URL expiry %q", expiresValue)
+	}
+	expires := time.Unix(unix, 0)
+	if !hmac.Equal([]byte(sig), []byte(signature(key, u.Path, expiresValue, nonce))) {
+		return fmt.Errorf("invalid URL signature")
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	now := v.now()
+	if !now.Before(expires) {
+		return fmt.Errorf("URL expired at %s", expires.UTC().Format(time.RFC3339))
+	}
+	for n, e := range v.used {
+		if !now.Before(e) {
+			delete(v.used, n)
+		}
+	}
+	if _, exists := v.used[nonce]; exists {
+		return fmt.Errorf("URL has already been used")
+	}
+	return nil
+}
+
+// MarkUsed rejects u from now on, it is called once a download with the verified URL completes
+func (v *Verifier) MarkUsed(u *url.URL) {
+	q := u.Query()
+	unix, err := strconv.ParseInt(q.Get(ExpiresParam), 10, 64)
+	if err != nil {
+		return
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.used[q.Get(NonceParam)] = time.Unix(unix, 0)
+}
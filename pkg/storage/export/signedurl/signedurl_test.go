@@ -0,0 +1,125 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package signedurl
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testKey         = "token"
+	internalURL     = "https://virt-export-test.default.svc/volumes/disk/disk.img.gz"
+	externalURL     = "https://proxy.example.com/api/export.kubevirt.io/v1alpha1/namespaces/default/virtualmachineexports/test/volumes/disk/disk.img.gz"
+	exportServerURL = "https://10.0.0.1:8443/volumes/disk/disk.img.gz"
+)
+
+var _ = Describe("Signed URLs", func() {
+	var (
+		verifier *Verifier
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		verifier = NewVerifier()
+		verifier.now = func() time.Time { return now }
+	})
+
+	sign := func(rawURL string, expires time.Time) *url.URL {
+		signed, err := Sign(rawURL, testKey, expires)
+		Expect(err).ToNot(HaveOccurred())
+		u, err := url.Parse(signed)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(IsSigned(u)).To(BeTrue())
+		return u
+	}
+
+	It("should accept a signed URL until it is marked used", func() {
+		u := sign(internalURL, now.Add(time.Minute))
+		Expect(verifier.Verify(u, testKey)).To(Succeed())
+		Expect(verifier.Verify(u, testKey)).To(Succeed())
+		verifier.MarkUsed(u)
+		Expect(verifier.Verify(u, testKey)).To(MatchError("URL has already been used"))
+	})
+
+	It("should accept an external URL forwarded by the export proxy", func() {
+		u := sign(externalURL, now.Add(time.Minute))
+		forwarded, err := url.Parse(exportServerURL)
+		Expect(err).ToNot(HaveOccurred())
+		forwarded.RawQuery = u.RawQuery
+		Expect(verifier.Verify(forwarded, testKey)).To(Succeed())
+	})
+
+	It("should keep existing query parameters", func() {
+		u := sign(internalURL+"?x=y", now.Add(time.Minute))
+		Expect(u.Query().Get("x")).To(Equal("y"))
+		Expect(verifier.Verify(u, testKey)).To(Succeed())
+	})
+
+	It("should reject an expired URL", func() {
+		u := sign(internalURL, now.Add(time.Minute))
+		now = now.Add(2 * time.Minute)
+		Expect(verifier.Verify(u, testKey)).To(MatchError(ContainSubstring("URL expired at")))
+	})
+
+	It("should forget used URLs once they expired", func() {
+		u := sign(internalURL, now.Add(time.Minute))
+		verifier.MarkUsed(u)
+		Expect(verifier.used).To(HaveLen(1))
+		now = now.Add(2 * time.Minute)
+		Expect(verifier.Verify(sign(internalURL, now.Add(time.Minute)), testKey)).To(Succeed())
+		Expect(verifier.used).To(BeEmpty())
+	})
+
+	It("should reject a URL signed with another key", func() {
+		u := sign(internalURL, now.Add(time.Minute))
+		Expect(verifier.Verify(u, "other")).To(MatchError("invalid URL signature"))
+	})
+
+	DescribeTable("should reject a tampered URL", func(tamper func(u *url.URL)) {
+		u := sign(internalURL, now.Add(time.Minute))
+		tamper(u)
+		Expect(verifier.Verify(u, testKey)).ToNot(Succeed())
+	},
+		Entry("path", func(u *url.URL) {
+			u.Path = strings.Replace(u.Path, "disk.img.gz", "disk.img", 1)
+		}),
+		Entry("expiry", func(u *url.URL) {
+			q := u.Query()
+			q.Set(ExpiresParam, "99999999999")
+			u.RawQuery = q.Encode()
+		}),
+		Entry("nonce", func(u *url.URL) {
+			q := u.Query()
+			q.Set(NonceParam, "00")
+			u.RawQuery = q.Encode()
+		}),
+		Entry("missing signature", func(u *url.URL) {
+			q := u.Query()
+			q.Del(SignatureParam)
+			u.RawQuery = q.Encode()
+		}),
+	)
+})
@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virtexportserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/proxyauth"
+	"kubevirt.io/kubevirt/pkg/storage/export/signedurl"
+)
+
+// downloadResponseWriter records whether the whole response of a download was written
+type downloadResponseWriter struct {
+	http.ResponseWriter
+	status int
+	failed bool
+}
+
+func (w *downloadResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *downloadResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	if err != nil {
+		w.failed = true
+	}
+	return n, err
+}
+
+// completed returns true if the response was the whole, successfully written content of a GET request
+func (w *downloadResponseWriter) completed(r *http.Request) bool {
+	return r.Method == http.MethodGet && r.Header.Get("Range") == "" && w.status == http.StatusOK && !w.failed && r.Context().Err() == nil
+}
+
+// isProxyAuthorized returns true if the request carries the proxy token virt-exportproxy forwards
+// the requests it authorized with
+func (s *exportServer) isProxyAuthorized(r *http.Request) bool {
+	token := r.Header.Get(proxyauth.Header)
+	if token == "" {
+		return false
+	}
+	r.Header.Del(proxyauth.Header)
+	expected, err := s.ProxyTokenGetter()
+	if err != nil || expected == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
+
+// isBearerAuthorized returns true if the request carries the bearer token of a user allowed to download
+// from the export. It writes the response of the rejected requests.
+func (s *exportServer) isBearerAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	bearer := proxyauth.BearerToken(r)
+	r.Header.Del("Authorization")
+	if bearer == "" || s.TokenReviewer == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return false
+	}
+	allowed, err := s.TokenReviewer(r.Context(), bearer, s.ExportNamespace, s.ExportName)
+	if err != nil {
+		log.Log.Reason(err).Error("error reviewing bearer token")
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+	if !allowed {
+		w.WriteHeader(http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// authChecker lets a request through if it carries the export token, a valid pre-signed URL, the
+// proxy token of a request virt-exportproxy authorized with the bearer token of the user or, for
+// the requests reaching the exporter pod directly, the bearer token of a user allowed to download.
+// A pre-signed URL can be used until a download with it completes, so HEAD requests, range requests
+// and retries of interrupted downloads keep working. The completed downloads are only remembered by
+// the exporter pod, a restarted exporter pod accepts them again until they expire.
+func (s *exportServer) authChecker(nextHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := s.TokenGetter()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		for _, tok := range []string{getTokenQueryParam(r), getTokenHeader(r)} {
+			if tok == token {
+				nextHandler.ServeHTTP(w, r)
+				return
+			}
+		}
+		if s.isProxyAuthorized(r) {
+			nextHandler.ServeHTTP(w, r)
+			return
+		}
+		if signedurl.IsSigned(r.URL) {
+			if err := s.urlVerifier.Verify(r.URL, token); err != nil {
+				log.Log.Reason(err).Info("Rejected pre-signed URL")
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			dw := &downloadResponseWriter{ResponseWriter: w}
+			nextHandler.ServeHTTP(dw, r)
+			if dw.completed(r) {
+				s.urlVerifier.MarkUsed(r.URL)
+			}
+			return
+		}
+		if s.isBearerAuthorized(w, r) {
+			nextHandler.ServeHTTP(w, r)
+		}
+	})
+}
@@ -0,0 +1,166 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virtexportserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/proxyauth"
+	"kubevirt.io/kubevirt/pkg/storage/export/signedurl"
+)
+
+var _ = Describe("authentication", func() {
+	const (
+		token      = "foo"
+		proxyToken = "proxy-token"
+		rawURI     = "/volume/v1/disk.img"
+	)
+
+	var (
+		es         *exportServer
+		httpServer *httptest.Server
+	)
+
+	BeforeEach(func() {
+		es = newTestServer(token)
+		es.Volumes = []VolumeInfo{{Path: "/tmp", RawURI: rawURI}}
+		es.ProxyTokenGetter = func() (string, error) {
+			return proxyToken, nil
+		}
+	})
+
+	AfterEach(func() {
+		if httpServer != nil {
+			httpServer.Close()
+		}
+	})
+
+	start := func() {
+		es.initHandler()
+		httpServer = httptest.NewServer(es.handler)
+	}
+
+	request := func(method, uri string, header http.Header) int {
+		req, err := http.NewRequest(method, httpServer.URL+uri, nil)
+		Expect(err).ToNot(HaveOccurred())
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+
+	get := func(uri string, header http.Header) int {
+		return request(http.MethodGet, uri, header)
+	}
+
+	Context("with proxy tokens", func() {
+		DescribeTable("should check the proxy token", func(header string, expectedStatus int) {
+			start()
+			Expect(get(rawURI, http.Header{http.CanonicalHeaderKey(proxyauth.Header): []string{header}})).To(Equal(expectedStatus))
+		},
+			Entry("valid", proxyToken, http.StatusOK),
+			Entry("invalid", "other", http.StatusUnauthorized),
+		)
+
+		It("should reject proxy tokens when the exporter has none", func() {
+			es.ProxyTokenGetter = func() (string, error) {
+				return "", nil
+			}
+			start()
+			Expect(get(rawURI, http.Header{http.CanonicalHeaderKey(proxyauth.Header): []string{""}})).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should not accept bearer tokens without a token reviewer", func() {
+			start()
+			Expect(get(rawURI, http.Header{"Authorization": []string{"Bearer " + proxyToken}})).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Context("with bearer tokens", func() {
+		const bearer = "sa-token"
+
+		BeforeEach(func() {
+			es.ExportName = "test-export"
+			es.ExportNamespace = "default"
+		})
+
+		DescribeTable("should review the bearer token of the export", func(allowed bool, reviewErr error, expectedStatus int) {
+			es.TokenReviewer = func(_ context.Context, token, namespace, name string) (bool, error) {
+				Expect(token).To(Equal(bearer))
+				Expect(namespace).To(Equal("default"))
+				Expect(name).To(Equal("test-export"))
+				return allowed, reviewErr
+			}
+			start()
+			Expect(get(rawURI, http.Header{"Authorization": []string{"Bearer " + bearer}})).To(Equal(expectedStatus))
+		},
+			Entry("allowed", true, nil, http.StatusOK),
+			Entry("not allowed", false, nil, http.StatusForbidden),
+			Entry("review failure", false, fmt.Errorf("failure"), http.StatusInternalServerError),
+		)
+
+		It("should not review requests without bearer token", func() {
+			es.TokenReviewer = func(context.Context, string, string, string) (bool, error) {
+				Fail("unexpected review")
+				return false, nil
+			}
+			start()
+			Expect(get(rawURI, nil)).To(Equal(http.StatusUnauthorized))
+			Expect(get(rawURI, http.Header{"x-kubevirt-export-token": []string{token}})).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("with pre-signed URLs", func() {
+		sign := func(uri, key string, expires time.Time) string {
+			signed, err := signedurl.Sign(httpServer.URL+uri, key, expires)
+			Expect(err).ToNot(HaveOccurred())
+			return signed[len(httpServer.URL):]
+		}
+
+		It("should accept a pre-signed URL until a download with it completed", func() {
+			start()
+			uri := sign(rawURI, token, time.Now().Add(time.Minute))
+			Expect(request(http.MethodHead, uri, nil)).To(Equal(http.StatusOK))
+			Expect(get(uri, http.Header{"Range": []string{"bytes=0-1"}})).To(Equal(http.StatusOK))
+			Expect(get(uri, nil)).To(Equal(http.StatusOK))
+			Expect(get(uri, nil)).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should reject an expired pre-signed URL", func() {
+			start()
+			Expect(get(sign(rawURI, token, time.Now().Add(-time.Minute)), nil)).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should reject a URL signed with another key", func() {
+			start()
+			Expect(get(sign(rawURI, "bar", time.Now().Add(time.Minute)), nil)).To(Equal(http.StatusUnauthorized))
+		})
+	})
+})
@@ -37,6 +37,7 @@ import (
 	"time"
 
 	flag "github.com/spf13/pflag"
+	"golang.org/x/time/rate"
 	"sigs.k8s.io/yaml"
 
 	"kubevirt.io/client-go/log"
@@ -49,6 +50,9 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/service"
+	"kubevirt.io/kubevirt/pkg/storage/export/ova"
+	"kubevirt.io/kubevirt/pkg/storage/export/proxyauth"
+	"kubevirt.io/kubevirt/pkg/storage/export/signedurl"
 )
 
 const (
@@ -68,6 +72,7 @@ const (
 type TokenGetterFunc func() (string, error)
 
 type VolumeInfo struct {
+	Name       string
 	Path       string
 	ArchiveURI string
 	DirURI     string
@@ -75,16 +80,33 @@ type VolumeInfo struct {
 	RawGzURI   string
 	VMURI      string
 	SecretURI  string
+	OvaURI     string
 }
 type ExportServerConfig struct {
 	Deadline time.Time
 
 	ListenAddr string
 
+	// MetricsListenAddr serves the export metrics, they are not served when empty
+	MetricsListenAddr string
+
+	// BandwidthLimit is the bytes per second served by the export server, downloads are not throttled when 0
+	BandwidthLimit int64
+
 	CertFile, KeyFile string
 
 	TokenFile string
 
+	// ProxyTokenFile holds the token virt-exportproxy forwards the requests it authorized with
+	ProxyTokenFile string
+
+	// ExportName and ExportNamespace identify the export the bearer tokens are authorized against
+	ExportName, ExportNamespace string
+
+	// TokenReviewer authorizes the bearer tokens of the requests not coming through virt-exportproxy,
+	// bearer tokens are rejected when nil
+	TokenReviewer proxyauth.ReviewFunc
+
 	Volumes []VolumeInfo
 
 	// unit testing helpers
@@ -94,8 +116,10 @@ type ExportServerConfig struct {
 	GzipHandler        func(string) http.Handler
 	VmHandler          func(string, []VolumeInfo, func() (string, error), func() (*corev1.ConfigMap, error)) http.Handler
 	TokenSecretHandler func(TokenGetterFunc) http.Handler
+	OvaHandler         func([]VolumeInfo) http.Handler
 
-	TokenGetter TokenGetterFunc
+	TokenGetter      TokenGetterFunc
+	ProxyTokenGetter TokenGetterFunc
 }
 
 type execReader struct {
@@ -106,7 +130,11 @@ type execReader struct {
 
 type exportServer struct {
 	ExportServerConfig
-	handler http.Handler
+	handler        http.Handler
+	metricsHandler http.Handler
+	urlVerifier    *signedurl.Verifier
+	limiter        *rate.Limiter
+	metrics        *exportMetrics
 }
 
 func (er *execReader) Read(p []byte) (int, error) {
@@ -130,23 +158,31 @@ func (s *exportServer) initHandler() {
 	for i, vi := range s.Volumes {
 		for path, handler := range s.getHandlerMap(vi) {
 			log.Log.Infof("Handling path %s\n", path)
-			mux.Handle(path, tokenChecker(s.TokenGetter, handler))
+			mux.Handle(path, s.authChecker(s.instrument(vi.Name, handler)))
 		}
 		if i == 0 {
 			// Only register once
 			if vi.VMURI != "" {
 				p := vi.Path
-				mux.Handle(filepath.Join(internal, vi.VMURI), tokenChecker(s.TokenGetter, s.VmHandler(p, s.Volumes, getInternalBasePath, getInternalCAConfigMap)))
-				mux.Handle(filepath.Join(external, vi.VMURI), tokenChecker(s.TokenGetter, s.VmHandler(p, s.Volumes, getExternalBasePath, getExternalCAConfigMap)))
+				mux.Handle(filepath.Join(internal, vi.VMURI), s.authChecker(s.instrument("", s.VmHandler(p, s.Volumes, getInternalBasePath, getInternalCAConfigMap))))
+				mux.Handle(filepath.Join(external, vi.VMURI), s.authChecker(s.instrument("", s.VmHandler(p, s.Volumes, getExternalBasePath, getExternalCAConfigMap))))
 			}
 			if vi.SecretURI != "" {
-				mux.Handle(filepath.Join(internal, vi.SecretURI), tokenChecker(s.TokenGetter, s.TokenSecretHandler(s.TokenGetter)))
-				mux.Handle(filepath.Join(external, vi.SecretURI), tokenChecker(s.TokenGetter, s.TokenSecretHandler(s.TokenGetter)))
+				mux.Handle(filepath.Join(internal, vi.SecretURI), s.authChecker(s.TokenSecretHandler(s.TokenGetter)))
+				mux.Handle(filepath.Join(external, vi.SecretURI), s.authChecker(s.TokenSecretHandler(s.TokenGetter)))
+			}
+			if vi.OvaURI != "" {
+				mux.Handle(filepath.Join(internal, vi.OvaURI), s.authChecker(s.instrument("", s.OvaHandler(s.Volumes))))
+				mux.Handle(filepath.Join(external, vi.OvaURI), s.authChecker(s.instrument("", s.OvaHandler(s.Volumes))))
 			}
 		}
 	}
 
 	s.handler = mux
+
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle(MetricsPath, s.metrics.handler())
+	s.metricsHandler = metricsMux
 }
 
 func getInternalCAConfigMap() (*corev1.ConfigMap, error) {
@@ -205,6 +241,19 @@ func (s *exportServer) Run() {
 		ch <- err
 	}()
 
+	var metricsSrv *http.Server
+	if s.MetricsListenAddr != "" {
+		metricsSrv = &http.Server{
+			Addr:    s.MetricsListenAddr,
+			Handler: s.metricsHandler,
+		}
+		go func() {
+			if err := metricsSrv.ListenAndServeTLS(s.CertFile, s.KeyFile); err != http.ErrServerClosed {
+				log.Log.Reason(err).Error("Metrics server stopped")
+			}
+		}()
+	}
+
 	if !s.Deadline.IsZero() {
 		log.Log.Infof("Deadline set to %s", s.Deadline)
 		select {
@@ -212,6 +261,9 @@ func (s *exportServer) Run() {
 			panic(err)
 		case <-time.After(time.Until(s.Deadline)):
 			log.Log.Info("Deadline exceeded, shutting down")
+			if metricsSrv != nil {
+				metricsSrv.Shutdown(context.TODO())
+			}
 			srv.Shutdown(context.TODO())
 		}
 	} else {
@@ -225,7 +277,12 @@ func (s *exportServer) AddFlags() {
 }
 
 func NewExportServer(config ExportServerConfig) service.Service {
-	es := &exportServer{ExportServerConfig: config}
+	es := &exportServer{
+		ExportServerConfig: config,
+		urlVerifier:        signedurl.NewVerifier(),
+		limiter:            newBandwidthLimiter(config.BandwidthLimit),
+		metrics:            newExportMetrics(config.Volumes, config.BandwidthLimit),
+	}
 
 	if es.ArchiveHandler == nil {
 		es.ArchiveHandler = archiveHandler
@@ -251,12 +308,25 @@ func NewExportServer(config ExportServerConfig) service.Service {
 		es.TokenSecretHandler = secretHandler
 	}
 
+	if es.OvaHandler == nil {
+		es.OvaHandler = ovaHandler
+	}
+
 	if es.TokenGetter == nil {
 		es.TokenGetter = func() (string, error) {
 			return getToken(es.TokenFile)
 		}
 	}
 
+	if es.ProxyTokenGetter == nil {
+		es.ProxyTokenGetter = func() (string, error) {
+			if es.ProxyTokenFile == "" {
+				return "", nil
+			}
+			return getToken(es.ProxyTokenFile)
+		}
+	}
+
 	return es
 }
 
@@ -448,23 +518,6 @@ func getTokenHeader(r *http.Request) (token string) {
 	return
 }
 
-func tokenChecker(tokenGetter TokenGetterFunc, nextHandler http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token, err := tokenGetter()
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		for _, tok := range []string{getTokenQueryParam(r), getTokenHeader(r)} {
-			if tok == token {
-				nextHandler.ServeHTTP(w, r)
-				return
-			}
-		}
-		w.WriteHeader(http.StatusUnauthorized)
-	})
-}
-
 func archiveHandler(mountPoint string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		if req.Method != http.MethodGet {
@@ -605,6 +658,43 @@ func vmHandler(filePath string, vi []VolumeInfo, getBasePath func() (string, err
 	})
 }
 
+func ovaHandler(vi []VolumeInfo) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		expandedVm := getExpandedVM()
+		if expandedVm == nil {
+			log.Log.Error("error getting VM definition")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var diskImages []ova.DiskImage
+		for _, info := range vi {
+			if info.RawURI == "" {
+				continue
+			}
+			p := info.Path
+			if fi, err := os.Stat(p); err == nil && fi.IsDir() {
+				p = path.Join(p, "disk.img")
+			}
+			// the raw URI is <base>/<claim name>/disk.img
+			diskImages = append(diskImages, ova.DiskImage{
+				ClaimName: path.Base(path.Dir(info.RawURI)),
+				Path:      p,
+			})
+		}
+		w.Header().Set("Content-Type", "application/x-tar")
+		if err := ova.Write(w, expandedVm, diskImages); err != nil {
+			log.Log.Reason(err).Error("error writing OVA")
+			// the archive is already partially sent, abort the connection so the client
+			// does not take the truncated archive for a complete one
+			panic(http.ErrAbortHandler)
+		}
+	})
+}
+
 func resourceToBytesJson(resources []runtime.Object) ([]byte, error) {
 	list := corev1.List{
 		TypeMeta: metav1.TypeMeta{
@@ -20,11 +20,14 @@
 package virtexportserver
 
 import (
+	"archive/tar"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -67,6 +70,9 @@ func newTestServer(token string) *exportServer {
 		TokenSecretHandler: func(tgf TokenGetterFunc) http.Handler {
 			return http.HandlerFunc(successHandler)
 		},
+		OvaHandler: func([]VolumeInfo) http.Handler {
+			return http.HandlerFunc(successHandler)
+		},
 		TokenGetter: func() (string, error) {
 			return token, nil
 		},
@@ -121,6 +127,10 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest/secret"},
 			"/internal/manifest/secret",
 		),
+		Entry("OVA URI",
+			VolumeInfo{Path: "/tmp", OvaURI: "/manifest/ova"},
+			"/internal/manifest/ova",
+		),
 	)
 
 	DescribeTable("should handle (query param version)", func(vi VolumeInfo, uri string) {
@@ -167,6 +177,10 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest/secret"},
 			"/internal/manifest/secret",
 		),
+		Entry("OVA URI",
+			VolumeInfo{Path: "/tmp", OvaURI: "/manifest/ova"},
+			"/internal/manifest/ova",
+		),
 	)
 
 	DescribeTable("should fail bad token", func(vi VolumeInfo, uri string) {
@@ -210,6 +224,10 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest/secret"},
 			"/external/manifest/secret",
 		),
+		Entry("OVA URI",
+			VolumeInfo{Path: "/tmp", OvaURI: "/manifest/ova"},
+			"/external/manifest/ova",
+		),
 	)
 
 	DescribeTable("should fail bad token (query param version)", func(vi VolumeInfo, uri string) {
@@ -252,6 +270,10 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest/secret"},
 			"/internal/manifest/secret",
 		),
+		Entry("OVA URI",
+			VolumeInfo{Path: "/tmp", OvaURI: "/manifest/ova"},
+			"/internal/manifest/ova",
+		),
 	)
 
 	Context("Vm handler", func() {
@@ -729,4 +751,98 @@ var _ = Describe("exportserver", func() {
 			verifySecret(string(list.Items[0].Raw))
 		})
 	})
+
+	Context("OVA handler", func() {
+		var (
+			orgGetExpandedVM = getExpandedVM
+			diskDir          string
+		)
+
+		BeforeEach(func() {
+			diskDir = GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(diskDir, "disk.img"), []byte("disk data"), 0644)).To(Succeed())
+			getExpandedVM = func() *virtv1.VirtualMachine {
+				return &virtv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-vm"},
+					Spec: virtv1.VirtualMachineSpec{
+						Template: &virtv1.VirtualMachineInstanceTemplateSpec{
+							Spec: virtv1.VirtualMachineInstanceSpec{
+								Domain: virtv1.DomainSpec{
+									Devices: virtv1.Devices{
+										Disks: []virtv1.Disk{{Name: "disk0"}},
+									},
+								},
+								Volumes: []virtv1.Volume{
+									{
+										Name: "disk0",
+										VolumeSource: virtv1.VolumeSource{
+											PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{
+												PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{ClaimName: "test-pvc"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			}
+		})
+
+		AfterEach(func() {
+			getExpandedVM = orgGetExpandedVM
+		})
+
+		It("Should return error on non GET", func() {
+			req, err := http.NewRequest("POST", "https://test.blah.invalid/manifests/ova?x-kubevirt-export-token=bar", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			ovaHandler(nil).ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusBadRequest))
+		})
+
+		It("Should return 500 if getExpandedVM returns nil", func() {
+			getExpandedVM = func() *virtv1.VirtualMachine {
+				return nil
+			}
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/manifests/ova?x-kubevirt-export-token=bar", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			ovaHandler(nil).ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusInternalServerError))
+		})
+
+		It("Should return the OVF descriptor and the exported disks", func() {
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/manifests/ova?x-kubevirt-export-token=bar", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			ovaHandler([]VolumeInfo{
+				{Path: diskDir, RawURI: "/volumes/test-pvc/disk.img"},
+			}).ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusOK))
+			Expect(resp.Header().Get("Content-Type")).To(Equal("application/x-tar"))
+
+			var names []string
+			tr := tar.NewReader(resp.Body)
+			for {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).ToNot(HaveOccurred())
+				names = append(names, hdr.Name)
+			}
+			Expect(names).To(Equal([]string{"test-vm.ovf", "disk0.qcow2"}))
+		})
+
+		It("Should abort the connection if the OVA cannot be written", func() {
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/manifests/ova?x-kubevirt-export-token=bar", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			handler := ovaHandler([]VolumeInfo{
+				{Path: filepath.Join(diskDir, "missing.img"), RawURI: "/volumes/test-pvc/disk.img"},
+			})
+			Expect(func() { handler.ServeHTTP(resp, req) }).To(PanicWith(http.ErrAbortHandler))
+		})
+	})
 })
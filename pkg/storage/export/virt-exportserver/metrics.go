@@ -0,0 +1,150 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virtexportserver
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/time/rate"
+)
+
+// The export server metrics are internal, they are only scraped by virt-controller to keep the progress of the
+// export in its status and are not collected by Prometheus. virt-controller reports them again as the
+// kubevirt_vmexport_* metrics, labeled with the export they belong to.
+const (
+	BytesServedMetricName       = "kubevirt_exportserver_bytes_served_total"
+	ActiveConnectionsMetricName = "kubevirt_exportserver_active_connections"
+	BandwidthLimitMetricName    = "kubevirt_exportserver_bandwidth_limit_bytes"
+
+	VolumeLabel = "volume"
+
+	MetricsPath = "/metrics"
+)
+
+type exportMetrics struct {
+	registry          *prometheus.Registry
+	bytesServed       *prometheus.CounterVec
+	activeConnections *prometheus.GaugeVec
+	bandwidthLimit    prometheus.Gauge
+}
+
+func newExportMetrics(volumes []VolumeInfo, bandwidthLimit int64) *exportMetrics {
+	m := &exportMetrics{
+		registry: prometheus.NewRegistry(),
+		bytesServed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: BytesServedMetricName,
+				Help: "The total number of bytes of the volume served by the export server.",
+			},
+			[]string{VolumeLabel},
+		),
+		activeConnections: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: ActiveConnectionsMetricName,
+				Help: "The number of downloads of the volume in progress.",
+			},
+			[]string{VolumeLabel},
+		),
+		bandwidthLimit: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: BandwidthLimitMetricName,
+				Help: "The bytes per second limit of the downloads served by the export server, 0 when they are not throttled.",
+			},
+		),
+	}
+	m.registry.MustRegister(m.bytesServed, m.activeConnections, m.bandwidthLimit)
+
+	// Report every volume from the start, so that volumes that were never downloaded show up too
+	for _, vi := range volumes {
+		if vi.Name != "" {
+			m.bytesServed.WithLabelValues(vi.Name)
+			m.activeConnections.WithLabelValues(vi.Name)
+		}
+	}
+	m.bandwidthLimit.Set(float64(bandwidthLimit))
+
+	return m
+}
+
+func (m *exportMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func newBandwidthLimiter(bytesPerSecond int64) *rate.Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	// Allow a burst of one second worth of data, writes are split into chunks no larger than the burst
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), int(bytesPerSecond))
+}
+
+// exportResponseWriter throttles the response to the bandwidth limit of the export and counts the bytes served
+type exportResponseWriter struct {
+	http.ResponseWriter
+	ctx         context.Context
+	limiter     *rate.Limiter
+	bytesServed prometheus.Counter
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		chunk := p
+		if w.limiter != nil {
+			if burst := w.limiter.Burst(); len(chunk) > burst {
+				chunk = chunk[:burst]
+			}
+			if err := w.limiter.WaitN(w.ctx, len(chunk)); err != nil {
+				return written, err
+			}
+		}
+		n, err := w.ResponseWriter.Write(chunk)
+		written += n
+		if w.bytesServed != nil {
+			w.bytesServed.Add(float64(n))
+		}
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// instrument throttles the responses of the handler and, when volume is set, reports them in the volume metrics
+func (s *exportServer) instrument(volume string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ew := &exportResponseWriter{
+			ResponseWriter: w,
+			ctx:            r.Context(),
+			limiter:        s.limiter,
+		}
+		if volume != "" {
+			ew.bytesServed = s.metrics.bytesServed.WithLabelValues(volume)
+			activeConnections := s.metrics.activeConnections.WithLabelValues(volume)
+			activeConnections.Inc()
+			defer activeConnections.Dec()
+		}
+		next.ServeHTTP(ew, r)
+	})
+}
@@ -0,0 +1,167 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virtexportserver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("metrics", func() {
+	const token = "foo"
+
+	scrape := func(es *exportServer) string {
+		recorder := httptest.NewRecorder()
+		es.metricsHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		return recorder.Body.String()
+	}
+
+	download := func(es *exportServer, uri string) string {
+		httpServer := httptest.NewServer(es.handler)
+		defer httpServer.Close()
+
+		req, err := http.NewRequest(http.MethodGet, httpServer.URL+uri, nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("x-kubevirt-export-token", token)
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		out, err := io.ReadAll(res.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return string(out)
+	}
+
+	It("should report every volume before it is downloaded", func() {
+		es := newTestServer(token)
+		es.metrics = newExportMetrics([]VolumeInfo{{Name: "v1", Path: "/tmp"}, {Name: "v2", Path: "/tmp"}}, 0)
+		es.initHandler()
+
+		out := scrape(es)
+		Expect(out).To(ContainSubstring(BytesServedMetricName + `{volume="v1"} 0`))
+		Expect(out).To(ContainSubstring(BytesServedMetricName + `{volume="v2"} 0`))
+		Expect(out).To(ContainSubstring(ActiveConnectionsMetricName + `{volume="v1"} 0`))
+		Expect(out).To(ContainSubstring(BandwidthLimitMetricName + " 0"))
+	})
+
+	It("should count the bytes served per volume", func() {
+		es := newTestServer(token)
+		es.Volumes = []VolumeInfo{
+			{Name: "v1", Path: "/tmp", RawURI: "/volume/v1/disk.img"},
+			{Name: "v2", Path: "/tmp", RawURI: "/volume/v2/disk.img"},
+		}
+		es.initHandler()
+
+		Expect(download(es, "/volume/v1/disk.img")).To(Equal("OK"))
+		Expect(download(es, "/volume/v1/disk.img")).To(Equal("OK"))
+		Expect(download(es, "/volume/v2/disk.img")).To(Equal("OK"))
+
+		out := scrape(es)
+		Expect(out).To(ContainSubstring(BytesServedMetricName + `{volume="v1"} 4`))
+		Expect(out).To(ContainSubstring(BytesServedMetricName + `{volume="v2"} 2`))
+		Expect(out).To(ContainSubstring(ActiveConnectionsMetricName + `{volume="v1"} 0`))
+	})
+
+	It("should track the downloads in progress", func() {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		es := newTestServer(token)
+		es.FileHandler = func(string) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				<-release
+				successHandler(w, r)
+			})
+		}
+		es.Volumes = []VolumeInfo{{Name: "v1", Path: "/tmp", RawURI: "/volume/v1/disk.img"}}
+		es.initHandler()
+
+		done := make(chan string)
+		go func() {
+			defer GinkgoRecover()
+			done <- download(es, "/volume/v1/disk.img")
+		}()
+
+		Eventually(started).Should(BeClosed())
+		Expect(scrape(es)).To(ContainSubstring(ActiveConnectionsMetricName + `{volume="v1"} 1`))
+		close(release)
+		Eventually(done).Should(Receive(Equal("OK")))
+		Expect(scrape(es)).To(ContainSubstring(ActiveConnectionsMetricName + `{volume="v1"} 0`))
+	})
+
+	It("should not report manifest downloads as volume traffic", func() {
+		es := newTestServer(token)
+		es.Volumes = []VolumeInfo{{Name: "v1", Path: "/tmp", VMURI: "/manifest"}}
+		es.initHandler()
+
+		Expect(download(es, "/internal/manifest")).To(Equal("OK"))
+		Expect(scrape(es)).ToNot(ContainSubstring(BytesServedMetricName + "{"))
+	})
+
+	Context("bandwidth limit", func() {
+		It("should not throttle without a limit", func() {
+			Expect(newBandwidthLimiter(0)).To(BeNil())
+		})
+
+		It("should report the limit", func() {
+			config := ExportServerConfig{BandwidthLimit: 1024}
+			es := NewExportServer(config).(*exportServer)
+			es.initHandler()
+			Expect(es.limiter).ToNot(BeNil())
+			Expect(scrape(es)).To(ContainSubstring(BandwidthLimitMetricName + " 1024"))
+		})
+
+		It("should write in chunks no larger than the limit and throttle the writes", func() {
+			const limit = 100000
+			recorder := &chunkRecorder{ResponseRecorder: httptest.NewRecorder()}
+			w := &exportResponseWriter{
+				ResponseWriter: recorder,
+				ctx:            httptest.NewRequest(http.MethodGet, "/", nil).Context(),
+				limiter:        newBandwidthLimiter(limit),
+			}
+
+			start := time.Now()
+			n, err := w.Write([]byte(strings.Repeat("a", limit+limit/2)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(n).To(Equal(limit + limit/2))
+			// The first second worth of data is a burst, the rest has to wait for the limiter
+			Expect(time.Since(start)).To(BeNumerically(">=", 400*time.Millisecond))
+			Expect(recorder.chunks).To(Equal([]int{limit, limit / 2}))
+			Expect(recorder.Body.Len()).To(Equal(limit + limit/2))
+		})
+	})
+})
+
+type chunkRecorder struct {
+	*httptest.ResponseRecorder
+	chunks []int
+}
+
+func (r *chunkRecorder) Write(p []byte) (int, error) {
+	r.chunks = append(r.chunks, len(p))
+	return r.ResponseRecorder.Write(p)
+}
@@ -0,0 +1,148 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virtexportserver
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/registry"
+)
+
+// maxTerminationMessageSize is the size of the termination message kept by the kubelet
+const maxTerminationMessageSize = 4096
+
+// ContainerDiskInfo describes a volume pushed as containerDisk image
+type ContainerDiskInfo struct {
+	Name  string
+	Path  string
+	Image string
+}
+
+type PushConfig struct {
+	ContainerDisks []ContainerDiskInfo
+
+	// AuthFile is a docker config json holding the registry credentials
+	AuthFile string
+
+	InsecureSkipTLSVerify bool
+
+	// unit testing helpers
+	Pusher func(*http.Client, *registry.Reference, *registry.Credentials, string) (string, error)
+}
+
+// PushContainerDisks pushes the volumes to the registry and returns the outcome of each push
+func PushContainerDisks(config PushConfig) []exportv1.VirtualMachineExportContainerDisk {
+	pusher := config.Pusher
+	if pusher == nil {
+		pusher = registry.PushContainerDisk
+	}
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: config.InsecureSkipTLSVerify},
+		},
+	}
+
+	var result []exportv1.VirtualMachineExportContainerDisk
+	for _, cd := range config.ContainerDisks {
+		status := exportv1.VirtualMachineExportContainerDisk{
+			Name:  cd.Name,
+			Image: cd.Image,
+			Phase: exportv1.ContainerDiskPushSucceeded,
+		}
+		digest, err := pushContainerDisk(httpClient, pusher, config.AuthFile, cd)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to push volume %s to %s", cd.Name, cd.Image)
+			status.Phase = exportv1.ContainerDiskPushFailed
+			status.Message = err.Error()
+		} else {
+			log.Log.Infof("Pushed volume %s to %s@%s", cd.Name, cd.Image, digest)
+			status.Digest = digest
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+// TerminationMessage encodes the outcome of the pushes to fit in the termination message
+// of the container, the messages of the failed pushes are shortened or left out as needed
+func TerminationMessage(result []exportv1.VirtualMachineExportContainerDisk) ([]byte, error) {
+	data, err := json.Marshal(result)
+	if err != nil || len(data) <= maxTerminationMessageSize {
+		return data, err
+	}
+
+	longest := 0
+	for _, cd := range result {
+		if len(cd.Message) > longest {
+			longest = len(cd.Message)
+		}
+	}
+	shortened := make([]exportv1.VirtualMachineExportContainerDisk, len(result))
+	for maxLen := longest / 2; ; maxLen /= 2 {
+		for i, cd := range result {
+			shortened[i] = cd
+			if len(cd.Message) > maxLen {
+				shortened[i].Message = strings.ToValidUTF8(cd.Message[:maxLen], "")
+			}
+		}
+		data, err = json.Marshal(shortened)
+		if err != nil || len(data) <= maxTerminationMessageSize || maxLen == 0 {
+			return data, err
+		}
+	}
+}
+
+func pushContainerDisk(httpClient *http.Client, pusher func(*http.Client, *registry.Reference, *registry.Credentials, string) (string, error), authFile string, cd ContainerDiskInfo) (string, error) {
+	ref, err := registry.ParseReference(cd.Image)
+	if err != nil {
+		return "", err
+	}
+
+	var credentials *registry.Credentials
+	if authFile != "" {
+		data, err := os.ReadFile(authFile)
+		if err != nil {
+			return "", err
+		}
+		if credentials, err = registry.CredentialsFromDockerConfig(data, ref.Registry); err != nil {
+			return "", err
+		}
+	}
+
+	fi, err := os.Stat(cd.Path)
+	if err != nil {
+		return "", err
+	}
+	diskPath := cd.Path
+	if fi.IsDir() {
+		diskPath = path.Join(diskPath, "disk.img")
+	}
+
+	return pusher(httpClient, ref, credentials, diskPath)
+}
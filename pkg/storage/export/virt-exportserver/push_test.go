@@ -0,0 +1,159 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virtexportserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/registry"
+)
+
+var _ = Describe("PushContainerDisks", func() {
+	var (
+		tempDir string
+		pushed  map[string]string
+	)
+
+	fakePusher := func(_ *http.Client, ref *registry.Reference, credentials *registry.Credentials, diskPath string) (string, error) {
+		if ref.Repository == "vms/broken" {
+			return "", fmt.Errorf("push failed")
+		}
+		username := ""
+		if credentials != nil {
+			username = credentials.Username
+		}
+		pushed[ref.String()] = diskPath + "," + username
+		return "sha256:1234", nil
+	}
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+		pushed = map[string]string{}
+		Expect(os.MkdirAll(filepath.Join(tempDir, "fs"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tempDir, "fs", "disk.img"), []byte("disk"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tempDir, "block"), []byte("disk"), 0644)).To(Succeed())
+	})
+
+	It("should push the disk of filesystem and block volumes", func() {
+		result := PushContainerDisks(PushConfig{
+			ContainerDisks: []ContainerDiskInfo{
+				{Name: "fs", Path: filepath.Join(tempDir, "fs"), Image: "registry.example.com/vms/fs:v1"},
+				{Name: "block", Path: filepath.Join(tempDir, "block"), Image: "registry.example.com/vms/block:v1"},
+			},
+			Pusher: fakePusher,
+		})
+		Expect(result).To(Equal([]exportv1.VirtualMachineExportContainerDisk{
+			{Name: "fs", Image: "registry.example.com/vms/fs:v1", Phase: exportv1.ContainerDiskPushSucceeded, Digest: "sha256:1234"},
+			{Name: "block", Image: "registry.example.com/vms/block:v1", Phase: exportv1.ContainerDiskPushSucceeded, Digest: "sha256:1234"},
+		}))
+		Expect(pushed).To(Equal(map[string]string{
+			"registry.example.com/vms/fs:v1":    filepath.Join(tempDir, "fs", "disk.img") + ",",
+			"registry.example.com/vms/block:v1": filepath.Join(tempDir, "block") + ",",
+		}))
+	})
+
+	It("should use the credentials of the registry from the auth file", func() {
+		authFile := filepath.Join(tempDir, ".dockerconfigjson")
+		Expect(os.WriteFile(authFile, []byte(`{"auths":{"registry.example.com":{"username":"user","password":"password"}}}`), 0644)).To(Succeed())
+
+		result := PushContainerDisks(PushConfig{
+			ContainerDisks: []ContainerDiskInfo{
+				{Name: "fs", Path: filepath.Join(tempDir, "fs"), Image: "registry.example.com/vms/fs:v1"},
+			},
+			AuthFile: authFile,
+			Pusher:   fakePusher,
+		})
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Phase).To(Equal(exportv1.ContainerDiskPushSucceeded))
+		Expect(pushed["registry.example.com/vms/fs:v1"]).To(HaveSuffix(",user"))
+	})
+
+	It("should report the volumes that failed to push", func() {
+		result := PushContainerDisks(PushConfig{
+			ContainerDisks: []ContainerDiskInfo{
+				{Name: "broken", Path: filepath.Join(tempDir, "fs"), Image: "registry.example.com/vms/broken:v1"},
+				{Name: "missing", Path: filepath.Join(tempDir, "missing"), Image: "registry.example.com/vms/missing:v1"},
+				{Name: "invalid", Path: filepath.Join(tempDir, "fs"), Image: "vms/invalid"},
+				{Name: "fs", Path: filepath.Join(tempDir, "fs"), Image: "registry.example.com/vms/fs:v1"},
+			},
+			Pusher: fakePusher,
+		})
+		Expect(result).To(HaveLen(4))
+		for _, cd := range result[:3] {
+			Expect(cd.Phase).To(Equal(exportv1.ContainerDiskPushFailed), cd.Name)
+			Expect(cd.Message).ToNot(BeEmpty())
+		}
+		Expect(result[0].Message).To(Equal("push failed"))
+		Expect(result[3].Phase).To(Equal(exportv1.ContainerDiskPushSucceeded))
+	})
+
+	Context("TerminationMessage", func() {
+		It("should keep the outcome as is when it fits", func() {
+			result := []exportv1.VirtualMachineExportContainerDisk{
+				{Name: "fs", Image: "registry.example.com/vms/fs:v1", Phase: exportv1.ContainerDiskPushFailed, Message: "push failed"},
+			}
+			data, err := TerminationMessage(result)
+			Expect(err).ToNot(HaveOccurred())
+
+			var decoded []exportv1.VirtualMachineExportContainerDisk
+			Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(result))
+		})
+
+		It("should shorten the messages to fit in the termination message", func() {
+			var result []exportv1.VirtualMachineExportContainerDisk
+			for i := 0; i < 4; i++ {
+				result = append(result, exportv1.VirtualMachineExportContainerDisk{
+					Name:    fmt.Sprintf("disk%d", i),
+					Image:   fmt.Sprintf("registry.example.com/vms/disk%d:v1", i),
+					Phase:   exportv1.ContainerDiskPushFailed,
+					Message: strings.Repeat("x", 2000),
+				})
+			}
+			result[0].Phase = exportv1.ContainerDiskPushSucceeded
+			result[0].Digest = "sha256:1234"
+			result[0].Message = ""
+
+			data, err := TerminationMessage(result)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(data)).To(BeNumerically("<=", maxTerminationMessageSize))
+
+			var decoded []exportv1.VirtualMachineExportContainerDisk
+			Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+			Expect(decoded).To(HaveLen(4))
+			Expect(decoded[0]).To(Equal(result[0]))
+			for _, cd := range decoded[1:] {
+				Expect(cd.Phase).To(Equal(exportv1.ContainerDiskPushFailed))
+				Expect(cd.Message).ToNot(BeEmpty())
+				Expect(len(cd.Message)).To(BeNumerically("<", 2000))
+			}
+		})
+	})
+})
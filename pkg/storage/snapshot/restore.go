@@ -91,6 +91,11 @@ var restoreAnnotationsToDelete = []string{
 }
 
 func restorePVCName(vmRestore *snapshotv1.VirtualMachineRestore, name string) string {
+	if options := vmRestore.Spec.NewVirtualMachine; options != nil {
+		if newName, ok := options.NewVolumeNames[name]; ok {
+			return newName
+		}
+	}
 	return fmt.Sprintf("restore-%s-%s", vmRestore.UID, name)
 }
 
@@ -244,7 +249,7 @@ func (ctrl *VMRestoreController) reconcileVolumeRestores(vmRestore *snapshotv1.V
 		return false, err
 	}
 
-	noRestore := volumesNotForRestore(content)
+	noRestore := volumesNotForRestore(vmRestore, content)
 
 	var restores []snapshotv1.VolumeRestore
 	for _, vb := range content.Spec.VolumeBackups {
@@ -301,6 +306,10 @@ func (ctrl *VMRestoreController) reconcileVolumeRestores(vmRestore *snapshotv1.V
 				return false, err
 			}
 			createdPVC = true
+		} else if pvc.Annotations[restoreNameAnnotation] != vmRestore.Name {
+			// Only adopt claims created by this restore, the name of the
+			// claim may have been chosen by the user
+			return false, fmt.Errorf("PVC %s/%s already exists and was not created by restore %s", pvc.Namespace, pvc.Name, vmRestore.Name)
 		} else if pvc.Status.Phase == corev1.ClaimPending {
 			bindingMode, err := ctrl.getBindingMode(pvc)
 			if err != nil {
@@ -350,7 +359,7 @@ func (t *vmRestoreTarget) UpdateDoneRestore() (bool, error) {
 }
 
 func (t *vmRestoreTarget) UpdateRestoreInProgress() error {
-	if !t.doesTargetVMExist() || hasLastRestoreAnnotation(t.vmRestore, t.vm) {
+	if !t.doesTargetVMExist() || hasLastRestoreAnnotation(t.vmRestore, t.vm) || isVolumesOnlyRestore(t.vmRestore) {
 		return nil
 	}
 
@@ -372,7 +381,7 @@ func (t *vmRestoreTarget) UpdateRestoreInProgress() error {
 }
 
 func (t *vmRestoreTarget) Ready() (bool, error) {
-	if !t.doesTargetVMExist() {
+	if !t.doesTargetVMExist() || isVolumesOnlyRestore(t.vmRestore) {
 		return true, nil
 	}
 
@@ -401,6 +410,9 @@ func (t *vmRestoreTarget) Ready() (bool, error) {
 }
 
 func (t *vmRestoreTarget) Reconcile() (bool, error) {
+	if isVolumesOnlyRestore(t.vmRestore) {
+		return false, nil
+	}
 	if updated, err := t.reconcileSpec(); updated || err != nil {
 		return updated, err
 	}
@@ -415,7 +427,7 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 	log.Log.Object(t.vmRestore).V(3).Info("Reconciling VM")
 
 	if t.doesTargetVMExist() && hasLastRestoreAnnotation(t.vmRestore, t.vm) {
-		return false, nil
+		return false, t.claimMemoryStatePVC(t.vm)
 	}
 
 	content, err := t.controller.getSnapshotContent(t.vmRestore)
@@ -431,6 +443,9 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 	var newTemplates = make([]kubevirtv1.DataVolumeTemplateSpec, len(snapshotVM.Spec.DataVolumeTemplates))
 	var newVolumes []kubevirtv1.Volume
 	var deletedDataVolumes []string
+	var keptTemplates []kubevirtv1.DataVolumeTemplateSpec
+	replacedTemplates := sets.NewString()
+	noRestore := volumesNotForRestore(t.vmRestore, content)
 	updatedStatus := false
 
 	for i, t := range snapshotVM.Spec.DataVolumeTemplates {
@@ -440,6 +455,21 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 	for _, v := range snapshotVM.Spec.Template.Spec.Volumes {
 		nv := v.DeepCopy()
 		if nv.DataVolume != nil || nv.PersistentVolumeClaim != nil {
+			// volumes that are not restored keep the current disks of the target
+			if cv := t.targetVolume(nv.Name); cv != nil && noRestore.Has(nv.Name) {
+				if nv.DataVolume != nil {
+					replacedTemplates.Insert(nv.DataVolume.Name)
+				}
+				nv = cv.DeepCopy()
+				if nv.DataVolume != nil {
+					if dvt := t.targetDataVolumeTemplate(nv.DataVolume.Name); dvt != nil {
+						keptTemplates = append(keptTemplates, *dvt.DeepCopy())
+					}
+				}
+				newVolumes = append(newVolumes, *nv)
+				continue
+			}
+
 			for k := range t.vmRestore.Status.Restores {
 				vr := &t.vmRestore.Status.Restores[k]
 				if vr.VolumeName != nv.Name {
@@ -515,6 +545,16 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 		newVolumes = append(newVolumes, *nv)
 	}
 
+	if len(keptTemplates) > 0 || replacedTemplates.Len() > 0 {
+		var templates []kubevirtv1.DataVolumeTemplateSpec
+		for _, dvt := range newTemplates {
+			if !replacedTemplates.Has(dvt.Name) {
+				templates = append(templates, dvt)
+			}
+		}
+		newTemplates = append(templates, keptTemplates...)
+	}
+
 	if t.doesTargetVMExist() && updatedStatus {
 		// find DataVolumes that will no longer exist
 		for _, cdv := range t.vm.Spec.DataVolumeTemplates {
@@ -562,7 +602,13 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 	}
 	newVM.Spec.DataVolumeTemplates = newTemplates
 	newVM.Spec.Template.Spec.Volumes = newVolumes
+	if !t.doesTargetVMExist() && t.vmRestore.Spec.NewVirtualMachine != nil {
+		applyNewVirtualMachineOptions(newVM, t.vmRestore.Spec.NewVirtualMachine)
+	}
 	setLastRestoreAnnotation(t.vmRestore, newVM)
+	if err = t.setRestoreMemoryStateAnnotation(content, newVM); err != nil {
+		return false, err
+	}
 
 	newVM, err = patchVM(newVM, t.vmRestore.Spec.Patches)
 	if err != nil {
@@ -587,9 +633,103 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 		return false, err
 	}
 
+	if err = t.claimMemoryStatePVC(t.vm); err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
+// setRestoreMemoryStateAnnotation makes the restored VM resume from the
+// memory state of the snapshot on its next start, instead of booting
+func (t *vmRestoreTarget) setRestoreMemoryStateAnnotation(content *snapshotv1.VirtualMachineSnapshotContent, vm *kubevirtv1.VirtualMachine) error {
+	// the memory state is only consistent with all the volumes and
+	// the identity of the snapshotted VM
+	if content.Spec.MemoryState == nil || isPartialRestore(t.vmRestore) || t.vmRestore.Spec.NewVirtualMachine != nil {
+		delete(vm.Annotations, kubevirtv1.RestoreMemoryStateAnnotation)
+		return nil
+	}
+
+	claimName := content.Spec.MemoryState.PersistentVolumeClaimName
+	pvc, err := t.controller.getPVC(content.Namespace, claimName)
+	if err != nil {
+		return err
+	}
+
+	if pvc == nil {
+		return fmt.Errorf("memory state pvc %s/%s does not exist and should", content.Namespace, claimName)
+	}
+
+	fileName := pvc.Annotations[kubevirtv1.PVCMemoryDumpAnnotation]
+	if fileName == "" {
+		return fmt.Errorf("memory state pvc %s/%s has no saved memory state", content.Namespace, claimName)
+	}
+
+	if vm.Annotations == nil {
+		vm.Annotations = make(map[string]string)
+	}
+	vm.Annotations[kubevirtv1.RestoreMemoryStateAnnotation] = fmt.Sprintf("%s/%s", claimName, fileName)
+
+	return nil
+}
+
+// claimMemoryStatePVC adds the restored VM as an owner of the memory state
+// pvc it resumes from, so the pvc outlives the deletion of the snapshot
+// and is only garbage collected once the VM is deleted as well
+func (t *vmRestoreTarget) claimMemoryStatePVC(vm *kubevirtv1.VirtualMachine) error {
+	memoryState, exists := vm.Annotations[kubevirtv1.RestoreMemoryStateAnnotation]
+	if !exists {
+		return nil
+	}
+
+	claimName, _, _ := strings.Cut(memoryState, "/")
+	pvc, err := t.controller.getPVC(vm.Namespace, claimName)
+	if err != nil {
+		return err
+	}
+
+	// the VM boots instead of resuming when the memory state is gone
+	if pvc == nil {
+		return nil
+	}
+
+	for _, ownerRef := range pvc.OwnerReferences {
+		if ownerRef.UID == vm.UID {
+			return nil
+		}
+	}
+
+	pvc.OwnerReferences = append(pvc.OwnerReferences, metav1.OwnerReference{
+		APIVersion: kubevirtv1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+		Kind:       kubevirtv1.VirtualMachineGroupVersionKind.Kind,
+		Name:       vm.Name,
+		UID:        vm.UID,
+	})
+	_, err = t.controller.Client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(context.Background(), pvc, metav1.UpdateOptions{})
+	return err
+}
+
+func (t *vmRestoreTarget) targetVolume(name string) *kubevirtv1.Volume {
+	if !t.doesTargetVMExist() {
+		return nil
+	}
+	for i, volume := range t.vm.Spec.Template.Spec.Volumes {
+		if volume.Name == name {
+			return &t.vm.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	return nil
+}
+
+func (t *vmRestoreTarget) targetDataVolumeTemplate(name string) *kubevirtv1.DataVolumeTemplateSpec {
+	for i, dvt := range t.vm.Spec.DataVolumeTemplates {
+		if dvt.Name == name {
+			return &t.vm.Spec.DataVolumeTemplates[i]
+		}
+	}
+	return nil
+}
+
 func (t *vmRestoreTarget) reconcileDataVolumes() (bool, error) {
 	createdDV := false
 	waitingDV := false
@@ -743,7 +883,7 @@ func (t *vmRestoreTarget) createDataVolume(dvt kubevirtv1.DataVolumeTemplateSpec
 	if err != nil {
 		return false, err
 	}
-	if pvc.Annotations[populatedForPVCAnnotation] != dvt.Name || len(pvc.OwnerReferences) > 0 {
+	if pvc == nil || pvc.Annotations[populatedForPVCAnnotation] != dvt.Name || len(pvc.OwnerReferences) > 0 {
 		return false, nil
 	}
 
@@ -868,6 +1008,25 @@ func (ctrl *VMRestoreController) getVM(namespace, name string) (vm *kubevirtv1.V
 	return obj.(*kubevirtv1.VirtualMachine).DeepCopy(), nil
 }
 
+// applyNewVirtualMachineOptions gives a VM created from a snapshot a new identity,
+// so that it does not conflict with the source VM
+func applyNewVirtualMachineOptions(vm *kubevirtv1.VirtualMachine, options *snapshotv1.NewVirtualMachineOptions) {
+	interfaces := vm.Spec.Template.Spec.Domain.Devices.Interfaces
+	for i := range interfaces {
+		// An empty mac address is generated by KubeMacPool when deployed,
+		// otherwise by the network binding
+		interfaces[i].MacAddress = options.NewMacAddresses[interfaces[i].Name]
+	}
+
+	if firmware := vm.Spec.Template.Spec.Domain.Firmware; firmware != nil {
+		firmware.Serial = ""
+		if options.NewSMBiosSerial != nil {
+			firmware.Serial = *options.NewSMBiosSerial
+		}
+		firmware.UUID = ""
+	}
+}
+
 func patchVM(vm *kubevirtv1.VirtualMachine, patches []string) (*kubevirtv1.VirtualMachine, error) {
 	if len(patches) == 0 {
 		return vm, nil
@@ -974,7 +1133,10 @@ func (ctrl *VMRestoreController) createRestorePVC(
 		return fmt.Errorf("missing volumeRestore")
 	}
 	pvc := CreateRestorePVCDefFromVMRestore(vmRestore.Name, volumeRestore.PersistentVolumeClaimName, volumeSnapshot, volumeBackup, sourceVmName, sourceVmNamespace)
-	target.Own(pvc)
+	// PVCs restored on their own are not used by the target and outlive it
+	if !isVolumesOnlyRestore(vmRestore) {
+		target.Own(pvc)
+	}
 
 	_, err = ctrl.Client.CoreV1().PersistentVolumeClaims(vmRestore.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
 	if err != nil {
@@ -1074,13 +1236,19 @@ func updateRestoreCondition(r *snapshotv1.VirtualMachineRestore, c snapshotv1.Co
 }
 
 // Returns a set of volumes not for restore
-// Currently only memory dump volumes should not be restored
-func volumesNotForRestore(content *snapshotv1.VirtualMachineSnapshotContent) sets.String {
+// Memory dump volumes and the volumes not selected by the restore are not restored
+func volumesNotForRestore(vmRestore *snapshotv1.VirtualMachineRestore, content *snapshotv1.VirtualMachineSnapshotContent) sets.String {
 	volumes := content.Spec.Source.VirtualMachine.Spec.Template.Spec.Volumes
 	noRestore := sets.NewString()
+	include := sets.NewString(vmRestore.Spec.IncludeVolumes...)
+	exclude := sets.NewString(vmRestore.Spec.ExcludeVolumes...)
 
 	for _, volume := range volumes {
-		if volume.MemoryDump != nil {
+		switch {
+		case volume.MemoryDump != nil,
+			getRestoreMode(vmRestore) == snapshotv1.VirtualMachineRestoreSpecOnly,
+			include.Len() > 0 && !include.Has(volume.Name),
+			exclude.Has(volume.Name):
 			noRestore.Insert(volume.Name)
 		}
 	}
@@ -1088,6 +1256,25 @@ func volumesNotForRestore(content *snapshotv1.VirtualMachineSnapshotContent) set
 	return noRestore
 }
 
+func getRestoreMode(vmRestore *snapshotv1.VirtualMachineRestore) snapshotv1.VirtualMachineRestoreMode {
+	if vmRestore.Spec.Mode == "" {
+		return snapshotv1.VirtualMachineRestoreFull
+	}
+	return vmRestore.Spec.Mode
+}
+
+// Returns true if the restore does not restore all the volumes into the target
+func isPartialRestore(vmRestore *snapshotv1.VirtualMachineRestore) bool {
+	return getRestoreMode(vmRestore) != snapshotv1.VirtualMachineRestoreFull ||
+		len(vmRestore.Spec.IncludeVolumes) > 0 ||
+		len(vmRestore.Spec.ExcludeVolumes) > 0
+}
+
+// Returns true if the restore only creates new PVCs and leaves the target untouched
+func isVolumesOnlyRestore(vmRestore *snapshotv1.VirtualMachineRestore) bool {
+	return getRestoreMode(vmRestore) == snapshotv1.VirtualMachineRestoreVolumesOnly
+}
+
 func getRestoreVolumeBackup(volName string, content *snapshotv1.VirtualMachineSnapshotContent) (*snapshotv1.VolumeBackup, error) {
 	for _, vb := range content.Spec.VolumeBackups {
 		if vb.VolumeName == volName {
@@ -653,6 +653,134 @@ var _ = Describe("Restore controller", func() {
 				controller.processVMRestoreWorkItem()
 			})
 
+			It("should update VM spec to resume from the memory state and keep it when the snapshot is deleted", func() {
+				contentOwner := metav1.OwnerReference{
+					APIVersion: snapshotv1.SchemeGroupVersion.String(),
+					Kind:       "VirtualMachineSnapshotContent",
+					Name:       sc.Name,
+					UID:        sc.UID,
+					Controller: &t,
+				}
+				memoryStatePVC := &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "memory-state",
+						Namespace: testNamespace,
+						Annotations: map[string]string{
+							v1.PVCMemoryDumpAnnotation: "memory.state",
+						},
+						OwnerReferences: []metav1.OwnerReference{contentOwner},
+					},
+				}
+				pvcSource.Add(memoryStatePVC)
+				sc.Spec.MemoryState = &snapshotv1.MemoryStateBackup{
+					PersistentVolumeClaimName: memoryStatePVC.Name,
+				}
+				vmSnapshotContentSource.Modify(sc)
+
+				r := createRestoreWithOwner()
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete:           &f,
+					DeletedDataVolumes: getDeletedDataVolumes(createModifiedVM()),
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+					},
+				}
+				addVolumeRestores(r)
+				vm := createModifiedVM()
+				vm.Status.RestoreInProgress = &vmRestoreName
+				updatedVM := createSnapshotVM()
+				updatedVM.Status.RestoreInProgress = &vmRestoreName
+				updatedVM.ResourceVersion = "1"
+				updatedVM.Annotations = map[string]string{
+					"restore.kubevirt.io/lastRestoreUID": "restore-uid",
+					v1.RestoreMemoryStateAnnotation:      "memory-state/memory.state",
+				}
+				updatedVM.Spec.DataVolumeTemplates[0].Name = "restore-uid-disk1"
+				updatedVM.Spec.Template.Spec.Volumes[0].DataVolume.Name = "restore-uid-disk1"
+				for i := range r.Status.Restores {
+					r.Status.Restores[i].DataVolumeName = &r.Status.Restores[i].PersistentVolumeClaimName
+				}
+				vmSource.Add(vm)
+				vmInterface.EXPECT().Update(context.Background(), updatedVM).Return(updatedVM, nil)
+				for _, pvc := range getRestorePVCs(r) {
+					pvc.Annotations["cdi.kubevirt.io/storage.populatedFor"] = pvc.Name
+					pvc.Status.Phase = corev1.ClaimBound
+					pvcSource.Add(&pvc)
+				}
+				updatedPVC := false
+				k8sClient.Fake.PrependReactor("update", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					pvc := action.(testing.UpdateAction).GetObject().(*corev1.PersistentVolumeClaim)
+					Expect(pvc.Name).To(Equal(memoryStatePVC.Name))
+					// the VM keeps the pvc from being garbage collected with the snapshot content
+					Expect(pvc.OwnerReferences).To(ConsistOf(contentOwner, metav1.OwnerReference{
+						APIVersion: v1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+						Kind:       v1.VirtualMachineGroupVersionKind.Kind,
+						Name:       updatedVM.Name,
+						UID:        updatedVM.UID,
+					}))
+					updatedPVC = true
+					return true, pvc, nil
+				})
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+				Expect(updatedPVC).To(BeTrue())
+			})
+
+			It("should update VM spec keeping the current disks of excluded volumes", func() {
+				r := createRestoreWithOwner()
+				r.Spec.ExcludeVolumes = []string{diskName}
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+					},
+				}
+				vm := createModifiedVM()
+				vm.Status.RestoreInProgress = &vmRestoreName
+				vm.Spec.DataVolumeTemplates[0].Name = "current-dv"
+				vm.Spec.Template.Spec.Volumes[0].DataVolume.Name = "current-dv"
+				updatedVM := createSnapshotVM()
+				updatedVM.Status.RestoreInProgress = &vmRestoreName
+				updatedVM.ResourceVersion = "1"
+				updatedVM.Annotations = map[string]string{"restore.kubevirt.io/lastRestoreUID": "restore-uid"}
+				updatedVM.Spec.DataVolumeTemplates[0].Name = "current-dv"
+				updatedVM.Spec.Template.Spec.Volumes[0].DataVolume.Name = "current-dv"
+				vmSource.Add(vm)
+				vmInterface.EXPECT().Update(context.Background(), updatedVM).Return(updatedVM, nil)
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should create restore PVCs without touching the VM when restoring volumes only", func() {
+				r := createRestoreWithOwner()
+				r.Spec.Mode = snapshotv1.VirtualMachineRestoreVolumesOnly
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Creating new PVCs"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for new PVCs"),
+					},
+				}
+				addVolumeRestores(r)
+				vm := createModifiedVM()
+				vm.Spec.Running = &t
+				vmSource.Add(vm)
+				vmiSource.Add(createVMI(vm))
+				pvcSize := resource.MustParse("2Gi")
+				fakeVolumeSnapshotProvider.Add(createVolumeSnapshot(r.Status.Restores[0].VolumeSnapshotName, pvcSize))
+				k8sClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					pvc := action.(testing.CreateAction).GetObject().(*corev1.PersistentVolumeClaim)
+					Expect(pvc.Name).To(Equal(r.Status.Restores[0].PersistentVolumeClaimName))
+					Expect(pvc.OwnerReferences).To(BeEmpty())
+					return true, pvc, nil
+				})
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+				Expect(k8sClient.Actions()).To(HaveLen(1))
+			})
+
 			It("should cleanup and unlock vm", func() {
 				r := createRestoreWithOwner()
 				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
@@ -963,6 +1091,72 @@ var _ = Describe("Restore controller", func() {
 						Expect(err).ShouldNot(HaveOccurred())
 					})
 
+					It("with a new identity", func() {
+						snapshotVM := sc.Spec.Source.VirtualMachine
+						snapshotVM.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress = "00:00:5e:00:53:00"
+						snapshotVM.Spec.Template.Spec.Domain.Firmware = &v1.Firmware{
+							UUID:   "source-uuid",
+							Serial: "source-serial",
+						}
+						r.Spec.NewVirtualMachine = &snapshotv1.NewVirtualMachineOptions{
+							NewMacAddresses: map[string]string{"fake-interface": newMacAddress},
+							NewSMBiosSerial: pointer.String("new-serial"),
+						}
+
+						vmInterface.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(func(ctx context.Context, newVM *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+							Expect(newVM.Name).To(Equal(newVmName), "the created VM should be the new VM")
+							Expect(newVM.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal(newMacAddress))
+							Expect(newVM.Spec.Template.Spec.Domain.Firmware.Serial).To(Equal("new-serial"))
+							Expect(newVM.Spec.Template.Spec.Domain.Firmware.UUID).To(BeEmpty())
+							return newVM, nil
+						}).Times(1)
+
+						targetVM, err := controller.getTarget(r)
+						Expect(err).ShouldNot(HaveOccurred())
+						success, err := targetVM.Reconcile()
+						Expect(success).To(BeTrue())
+						Expect(err).ShouldNot(HaveOccurred())
+					})
+
+					It("with renamed volumes", func() {
+						r.Spec.NewVirtualMachine = &snapshotv1.NewVirtualMachineOptions{
+							NewVolumeNames: map[string]string{diskName: "new-vm-disk"},
+						}
+
+						targetVM, err := controller.getTarget(r)
+						Expect(err).ShouldNot(HaveOccurred())
+						updated, err := controller.reconcileVolumeRestores(r, targetVM)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(updated).To(BeTrue())
+						Expect(r.Status.Restores).To(HaveLen(1))
+						Expect(r.Status.Restores[0].PersistentVolumeClaimName).To(Equal("new-vm-disk"))
+						Expect(restoreDVName(r, diskName)).To(Equal("new-vm-disk"))
+					})
+
+					It("should not adopt an existing PVC with a renamed volume name", func() {
+						r.Spec.NewVirtualMachine = &snapshotv1.NewVirtualMachineOptions{
+							NewVolumeNames: map[string]string{diskName: "new-vm-disk"},
+						}
+						Expect(pvcInformer.GetStore().Add(&corev1.PersistentVolumeClaim{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: testNamespace,
+								Name:      "new-vm-disk",
+							},
+							Status: corev1.PersistentVolumeClaimStatus{
+								Phase: corev1.ClaimBound,
+							},
+						})).To(Succeed())
+
+						targetVM, err := controller.getTarget(r)
+						Expect(err).ShouldNot(HaveOccurred())
+						updated, err := controller.reconcileVolumeRestores(r, targetVM)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(updated).To(BeTrue())
+
+						_, err = controller.reconcileVolumeRestores(r, targetVM)
+						Expect(err).To(MatchError(ContainSubstring("was not created by restore")))
+					})
+
 				})
 
 			})
@@ -21,6 +21,7 @@ package snapshot
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"strings"
 	"time"
@@ -38,6 +39,8 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/controller"
+	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
+	utils "kubevirt.io/kubevirt/pkg/util"
 )
 
 const (
@@ -51,6 +54,8 @@ const (
 
 	defaultVolumeSnapshotClassAnnotation = "snapshot.storage.kubernetes.io/is-default-class"
 
+	sourcePausedAnnotation = "snapshot.kubevirt.io/source-paused"
+
 	vmSnapshotContentCreateEvent = "SuccessfulVirtualMachineSnapshotContentCreate"
 
 	volumeSnapshotCreateEvent = "SuccessfulVolumeSnapshotCreate"
@@ -61,6 +66,12 @@ const (
 
 	snapshotRetryInterval = 5 * time.Second
 
+	memoryStatePVCCreateEvent = "SuccessfulMemoryStatePVCCreate"
+
+	memoryStateNotIncludedEvent = "MemoryStateNotIncluded"
+
+	freezeAbortedEvent = "FreezeAborted"
+
 	contentDeletionInterval = 5 * time.Second
 )
 
@@ -238,6 +249,9 @@ func (ctrl *VMSnapshotController) unfreezeSource(vmSnapshot *snapshotv1.VirtualM
 	}
 
 	if source != nil {
+		if vmSnapshot.Spec.IncludeMemory {
+			return source.Unpause()
+		}
 		if err := source.Unfreeze(); err != nil {
 			return err
 		}
@@ -245,6 +259,126 @@ func (ctrl *VMSnapshotController) unfreezeSource(vmSnapshot *snapshotv1.VirtualM
 	return nil
 }
 
+func memoryStatePVCName(vmSnapshot *snapshotv1.VirtualMachineSnapshot) string {
+	return fmt.Sprintf("vmsnapshot-%s-memory", vmSnapshot.UID)
+}
+
+// saveMemoryState pauses the source and saves its memory state to the pvc
+// of the content, and returns true once the memory state has been saved
+func (ctrl *VMSnapshotController) saveMemoryState(vmSnapshot *snapshotv1.VirtualMachineSnapshot, content *snapshotv1.VirtualMachineSnapshotContent) (bool, error) {
+	source, err := ctrl.getSnapshotSource(vmSnapshot)
+	if err != nil {
+		return false, err
+	}
+
+	if source == nil {
+		return false, fmt.Errorf("unable to get snapshot source")
+	}
+
+	claimName := content.Spec.MemoryState.PersistentVolumeClaimName
+	_, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(content.Namespace, claimName))
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		return false, ctrl.createMemoryStatePVC(vmSnapshot, content)
+	}
+
+	if err := source.Pause(); err != nil {
+		return false, err
+	}
+
+	return source.SaveMemoryState(claimName)
+}
+
+func (ctrl *VMSnapshotController) createMemoryStatePVC(vmSnapshot *snapshotv1.VirtualMachineSnapshot, content *snapshotv1.VirtualMachineSnapshotContent) error {
+	vm, err := ctrl.getVM(vmSnapshot)
+	if err != nil {
+		return err
+	}
+
+	if vm == nil {
+		return fmt.Errorf("unable to get snapshot source")
+	}
+
+	vmi, exists, err := ctrl.getVMI(vm)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("vm %s/%s is not running, unable to save its memory state", vm.Namespace, vm.Name)
+	}
+
+	size, err := storagetypes.GetSizeIncludingDefaultFSOverhead(utils.CalcExpectedMemoryDumpSize(vmi))
+	if err != nil {
+		return err
+	}
+
+	t := true
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      content.Spec.MemoryState.PersistentVolumeClaimName,
+			Namespace: content.Namespace,
+			Labels: map[string]string{
+				snapshotSourceNameLabel:      content.Spec.Source.VirtualMachine.Name,
+				snapshotSourceNamespaceLabel: content.Spec.Source.VirtualMachine.Namespace,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         snapshotv1.SchemeGroupVersion.String(),
+					Kind:               "VirtualMachineSnapshotContent",
+					Name:               content.Name,
+					UID:                content.UID,
+					Controller:         &t,
+					BlockOwnerDeletion: &t,
+				},
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: *size,
+				},
+			},
+		},
+	}
+
+	_, err = ctrl.Client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	ctrl.Recorder.Eventf(
+		content,
+		corev1.EventTypeNormal,
+		memoryStatePVCCreateEvent,
+		"Successfully created memory state PVC %s",
+		pvc.Name,
+	)
+
+	return nil
+}
+
+// failVMSnapshot fails the snapshot right away, without waiting for its
+// deadline, when retrying it cannot succeed
+func (ctrl *VMSnapshotController) failVMSnapshot(vmSnapshot *snapshotv1.VirtualMachineSnapshot, reason, message string) error {
+	ctrl.Recorder.Event(vmSnapshot, corev1.EventTypeWarning, reason, message)
+
+	vmSnapshotCpy := vmSnapshot.DeepCopy()
+	if vmSnapshotCpy.Status == nil {
+		vmSnapshotCpy.Status = &snapshotv1.VirtualMachineSnapshotStatus{}
+	}
+	vmSnapshotCpy.Status.Phase = snapshotv1.Failed
+	updateSnapshotCondition(vmSnapshotCpy, newProgressingCondition(corev1.ConditionFalse, message))
+	updateSnapshotCondition(vmSnapshotCpy, newFailureCondition(corev1.ConditionTrue, message))
+
+	_, err := ctrl.Client.VirtualMachineSnapshot(vmSnapshotCpy.Namespace).Update(context.Background(), vmSnapshotCpy, metav1.UpdateOptions{})
+	return err
+}
+
 func (ctrl *VMSnapshotController) removeContentFinalizer(content *snapshotv1.VirtualMachineSnapshotContent) error {
 	if controller.HasFinalizer(content, vmSnapshotContentFinalizer) {
 		cpy := content.DeepCopy()
@@ -295,6 +429,18 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 	currentlyCreated := vmSnapshotContentCreated(content)
 	currentlyError := (content.Status != nil && content.Status.Error != nil) || vmSnapshotError(vmSnapshot) != nil
 
+	if content.Spec.MemoryState != nil && !currentlyCreated && !currentlyError &&
+		vmSnapshot != nil && !vmSnapshotDeleting(vmSnapshot) {
+		saved, err := ctrl.saveMemoryState(vmSnapshot, content)
+		if err != nil {
+			return 0, err
+		}
+
+		if !saved {
+			return snapshotRetryInterval, nil
+		}
+	}
+
 	for _, volumeBackup := range content.Spec.VolumeBackups {
 		if volumeBackup.VolumeSnapshotName == nil {
 			continue
@@ -334,7 +480,9 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 				continue
 			}
 
-			if !didFreeze {
+			// the VM is paused while the memory state is saved and the
+			// volumes are snapshotted, there is no need to freeze it
+			if !didFreeze && content.Spec.MemoryState == nil {
 				source, err := ctrl.getSnapshotSource(vmSnapshot)
 				if err != nil {
 					return 0, err
@@ -351,6 +499,10 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 
 				if !frozen {
 					err := source.Freeze()
+					var abortErr *freezeAbortedError
+					if goerrors.As(err, &abortErr) {
+						return 0, ctrl.failVMSnapshot(vmSnapshot, freezeAbortedEvent, abortErr.Error())
+					}
 					if err != nil {
 						return 0, err
 					}
@@ -565,6 +717,29 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 	if err != nil {
 		return err
 	}
+
+	var memoryState *snapshotv1.MemoryStateBackup
+	if vmSnapshot.Spec.IncludeMemory {
+		online, err := source.Online()
+		if err != nil {
+			return err
+		}
+
+		if online {
+			memoryState = &snapshotv1.MemoryStateBackup{
+				PersistentVolumeClaimName: memoryStatePVCName(vmSnapshot),
+			}
+		} else {
+			ctrl.Recorder.Eventf(
+				vmSnapshot,
+				corev1.EventTypeWarning,
+				memoryStateNotIncludedEvent,
+				"VM %s is not running, memory state is not included in the snapshot",
+				vmSnapshot.Spec.Source.Name,
+			)
+		}
+	}
+
 	content := &snapshotv1.VirtualMachineSnapshotContent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       GetVMSnapshotContentName(vmSnapshot),
@@ -575,6 +750,7 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 			VirtualMachineSnapshotName: &vmSnapshot.Name,
 			Source:                     sourceSpec,
 			VolumeBackups:              volumeBackups,
+			MemoryState:                memoryState,
 		},
 	}
 
@@ -703,9 +879,12 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 	}
 
 	if vmSnapshotDeadlineExceeded(vmSnapshotCpy) {
-		vmSnapshotCpy.Status.Phase = snapshotv1.Failed
-		updateSnapshotCondition(vmSnapshotCpy, newProgressingCondition(corev1.ConditionFalse, vmSnapshotDeadlineExceededError))
-		updateSnapshotCondition(vmSnapshotCpy, newFailureCondition(corev1.ConditionTrue, vmSnapshotDeadlineExceededError))
+		// a snapshot which already failed, e.g. with an aborted freeze, keeps its failure
+		if !vmSnapshotFailed(vmSnapshot) {
+			vmSnapshotCpy.Status.Phase = snapshotv1.Failed
+			updateSnapshotCondition(vmSnapshotCpy, newProgressingCondition(corev1.ConditionFalse, vmSnapshotDeadlineExceededError))
+			updateSnapshotCondition(vmSnapshotCpy, newFailureCondition(corev1.ConditionTrue, vmSnapshotDeadlineExceededError))
+		}
 	} else if vmSnapshotProgressing(vmSnapshotCpy) {
 		vmSnapshotCpy.Status.Phase = snapshotv1.InProgress
 		if source != nil {
@@ -732,6 +911,19 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 				if ga {
 					indications = append(indications, snapshotv1.VMSnapshotGuestAgentIndication)
 
+					hooks, err := source.FreezeHooks()
+					if err != nil {
+						return err
+					}
+
+					// only the hooks run for the freeze of this snapshot are relevant
+					if hooks != nil && hooks.PreFreezeTimestamp != nil && !hooks.PreFreezeTimestamp.Before(&vmSnapshotCpy.CreationTimestamp) {
+						if len(hooks.PreFreezeFailed) > 0 {
+							indications = append(indications, snapshotv1.VMSnapshotFreezeHooksFailedIndication)
+						} else {
+							indications = append(indications, snapshotv1.VMSnapshotFreezeHooksIndication)
+						}
+					}
 				} else {
 					indications = append(indications, snapshotv1.VMSnapshotNoGuestAgentIndication)
 				}
@@ -1260,6 +1260,267 @@ var _ = Describe("Snapshot controlleer", func() {
 				testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
 			})
 
+			It("should fail the snapshot without retrying when a pre-freeze hook aborts the freeze", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.UID = contentUID
+				vm := createLockedVM()
+				vmSource.Add(vm)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+				storageClassSource.Add(createStorageClass())
+				pvcs := createPersistentVolumeClaims()
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+
+				vmi := createVMI(vm)
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:          v1.VirtualMachineInstanceAgentConnected,
+					LastProbeTime: metav1.Now(),
+					Status:        corev1.ConditionTrue,
+				})
+				vmiSource.Add(vmi)
+
+				const hookOutput = "pre-freeze hook flush failed: exited with error code:1, output: database busy"
+				vmiInterface.EXPECT().Freeze(context.Background(), vm.Name, 0*time.Second).Return(util.NewFreezeAbortedError(hookOutput))
+
+				updatedSnapshot := false
+				vmSnapshotClient.Fake.PrependReactor("update", "virtualmachinesnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					updateObj := action.(testing.UpdateAction).GetObject().(*snapshotv1.VirtualMachineSnapshot)
+					Expect(updateObj.Status.Phase).To(Equal(snapshotv1.Failed))
+					Expect(updateObj.Status.Conditions).To(ContainElement(And(
+						HaveField("Type", snapshotv1.ConditionFailure),
+						HaveField("Status", corev1.ConditionTrue),
+						HaveField("Reason", ContainSubstring(hookOutput)),
+					)))
+					updatedSnapshot = true
+					return true, updateObj, nil
+				})
+				vmSnapshotSource.Add(vmSnapshot)
+				addVolumeSnapshotClass(createVolumeSnapshotClasses()[0])
+				controller.processVMSnapshotContentWorkItem()
+
+				Expect(updatedSnapshot).To(BeTrue())
+				testutils.ExpectEvent(recorder, "FreezeAborted")
+				// neither a volume snapshot is created nor the content is updated
+				Expect(k8sSnapshotClient.Actions()).To(BeEmpty())
+			})
+
+			DescribeTable("should indicate the outcome of the freeze hooks", func(hooksStatus *v1.FreezeHooksStatus, expectedIndication snapshotv1.Indication) {
+				storageClass := createStorageClass()
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.CreationTimestamp = timeStamp
+				volumeSnapshotClass := createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.UID = contentUID
+				vm := createLockedVM()
+				vmSource.Add(vm)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+
+				vmi := createVMI(vm)
+				agentCondition := v1.VirtualMachineInstanceCondition{
+					Type:          v1.VirtualMachineInstanceAgentConnected,
+					LastProbeTime: metav1.Now(),
+					Status:        corev1.ConditionTrue,
+				}
+				vmi.Status.Conditions = append(vmi.Status.Conditions, agentCondition)
+				vmi.Spec.FreezeHooks = &v1.FreezeHooks{
+					PreFreeze: []v1.GuestHook{{Name: "flush", Command: []string{"/usr/bin/flush"}}},
+				}
+				vmi.Status.FreezeHooks = hooksStatus
+				vmiSource.Add(vmi)
+
+				vmSnapshot.Status.Indications = append(vmSnapshot.Status.Indications, snapshotv1.VMSnapshotOnlineSnapshotIndication)
+				updatedVMSnapshot := vmSnapshot.DeepCopy()
+				updatedVMSnapshot.ResourceVersion = "1"
+				updatedVMSnapshot.Status.Indications = append(updatedVMSnapshot.Status.Indications, snapshotv1.VMSnapshotGuestAgentIndication)
+				if expectedIndication != "" {
+					updatedVMSnapshot.Status.Indications = append(updatedVMSnapshot.Status.Indications, expectedIndication)
+				}
+
+				updatedContent := vmSnapshotContent.DeepCopy()
+				updatedContent.ResourceVersion = "1"
+				updatedContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse: &f,
+				}
+
+				volumeSnapshots := createVolumeSnapshots(vmSnapshotContent)
+				for i := range volumeSnapshots {
+					vss := snapshotv1.VolumeSnapshotStatus{
+						VolumeSnapshotName: volumeSnapshots[i].Name,
+					}
+					updatedContent.Status.VolumeSnapshotStatus = append(updatedContent.Status.VolumeSnapshotStatus, vss)
+				}
+
+				storageClassSource.Add(storageClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+
+				vmiInterface.EXPECT().Freeze(context.Background(), vm.Name, 0*time.Second).Return(nil)
+				expectVMSnapshotUpdate(vmSnapshotClient, updatedVMSnapshot)
+				expectVolumeSnapshotCreates(k8sSnapshotClient, volumeSnapshotClass.Name, vmSnapshotContent)
+				expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+				vmSnapshotSource.Add(vmSnapshot)
+				addVolumeSnapshotClass(volumeSnapshotClass)
+				controller.processVMSnapshotContentWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+			},
+				Entry("when they succeeded", &v1.FreezeHooksStatus{PreFreezeTimestamp: &timeStamp}, snapshotv1.VMSnapshotFreezeHooksIndication),
+				Entry("when one failed", &v1.FreezeHooksStatus{PreFreezeTimestamp: &timeStamp, PreFreezeFailed: []string{"flush"}}, snapshotv1.VMSnapshotFreezeHooksFailedIndication),
+				Entry("not when they ran before the snapshot", &v1.FreezeHooksStatus{PreFreezeTimestamp: &metav1.Time{Time: timeStamp.Add(-time.Minute)}}, snapshotv1.Indication("")),
+				Entry("not when they did not run yet", nil, snapshotv1.Indication("")),
+			)
+
+			Context("with memory state", func() {
+				var vmSnapshot *snapshotv1.VirtualMachineSnapshot
+				var vmSnapshotContent *snapshotv1.VirtualMachineSnapshotContent
+				var vm *v1.VirtualMachine
+				var vmi *v1.VirtualMachineInstance
+
+				createMemoryStatePVC := func() *corev1.PersistentVolumeClaim {
+					return &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      memoryStatePVCName(vmSnapshot),
+							Namespace: testNamespace,
+						},
+					}
+				}
+
+				BeforeEach(func() {
+					vmSnapshot = createVMSnapshotInProgress()
+					vmSnapshot.Spec.IncludeMemory = true
+					vmSnapshotContent = createVMSnapshotContent()
+					vmSnapshotContent.UID = contentUID
+					vmSnapshotContent.Spec.MemoryState = &snapshotv1.MemoryStateBackup{
+						PersistentVolumeClaimName: memoryStatePVCName(vmSnapshot),
+					}
+					vm = createLockedVM()
+					vm.Spec.Template.Spec.Domain.Resources.Requests = corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					}
+					vmi = createVMI(vm)
+				})
+
+				It("should create the memory state PVC", func() {
+					vmSource.Add(vm)
+					vmiSource.Add(vmi)
+					vmSnapshotSource.Add(vmSnapshot)
+
+					virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+					k8sClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+						create, ok := action.(testing.CreateAction)
+						Expect(ok).To(BeTrue())
+
+						pvc := create.GetObject().(*corev1.PersistentVolumeClaim)
+						Expect(pvc.Name).To(Equal(memoryStatePVCName(vmSnapshot)))
+						Expect(pvc.OwnerReferences).To(HaveLen(1))
+						Expect(pvc.OwnerReferences[0].UID).To(Equal(types.UID(contentUID)))
+						storage := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+						Expect(storage.Cmp(resource.MustParse("1Gi"))).To(Equal(1))
+
+						return true, pvc, nil
+					})
+
+					addVirtualMachineSnapshotContent(vmSnapshotContent)
+					controller.processVMSnapshotContentWorkItem()
+					testutils.ExpectEvent(recorder, "SuccessfulMemoryStatePVCCreate")
+				})
+
+				It("should pause the vm and request its memory state to be saved", func() {
+					vmSource.Add(vm)
+					vmiSource.Add(vmi)
+					vmSnapshotSource.Add(vmSnapshot)
+					pvcSource.Add(createMemoryStatePVC())
+
+					vmUpdate := vm.DeepCopy()
+					vmUpdate.ResourceVersion = "1"
+					vmUpdate.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: memoryStatePVCName(vmSnapshot),
+						Phase:     v1.MemoryDumpAssociating,
+						Format:    v1.MemoryDumpFormatSavedState,
+					}
+					updatedVMSnapshot := vmSnapshot.DeepCopy()
+					updatedVMSnapshot.Annotations = map[string]string{sourcePausedAnnotation: "true"}
+					expectVMSnapshotUpdate(vmSnapshotClient, updatedVMSnapshot)
+					vmiInterface.EXPECT().Pause(context.Background(), vm.Name, &v1.PauseOptions{}).Return(nil)
+					vmInterface.EXPECT().UpdateStatus(context.Background(), vmUpdate).Return(vmUpdate, nil)
+
+					addVirtualMachineSnapshotContent(vmSnapshotContent)
+					controller.processVMSnapshotContentWorkItem()
+				})
+
+				DescribeTable("should unpause the vm", func(pausedBySnapshot bool) {
+					if pausedBySnapshot {
+						vmSnapshot.Annotations = map[string]string{sourcePausedAnnotation: "true"}
+					}
+					vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+						{
+							Type:   v1.VirtualMachineInstancePaused,
+							Status: corev1.ConditionTrue,
+						},
+					}
+					Expect(vmInformer.GetStore().Add(vm)).To(Succeed())
+					Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+					if pausedBySnapshot {
+						vmiInterface.EXPECT().Unpause(context.Background(), vm.Name, &v1.UnpauseOptions{}).Return(nil)
+					}
+
+					Expect(controller.unfreezeSource(vmSnapshot)).To(Succeed())
+				},
+					Entry("when the snapshot paused it", true),
+					Entry("not when it was paused before the snapshot", false),
+				)
+
+				It("should create VolumeSnapshots without freezing once the memory state is saved", func() {
+					vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: memoryStatePVCName(vmSnapshot),
+						Phase:     v1.MemoryDumpCompleted,
+						Format:    v1.MemoryDumpFormatSavedState,
+					}
+					vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+						{
+							Type:   v1.VirtualMachineInstanceAgentConnected,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   v1.VirtualMachineInstancePaused,
+							Status: corev1.ConditionTrue,
+						},
+					}
+					vmSource.Add(vm)
+					vmiSource.Add(vmi)
+					pvcSource.Add(createMemoryStatePVC())
+					pvcs := createPersistentVolumeClaims()
+					for i := range pvcs {
+						pvcSource.Add(&pvcs[i])
+					}
+					storageClassSource.Add(createStorageClass())
+					volumeSnapshotClass := createVolumeSnapshotClasses()[0]
+
+					updatedContent := vmSnapshotContent.DeepCopy()
+					updatedContent.ResourceVersion = "1"
+					updatedContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+						ReadyToUse: &f,
+					}
+					for _, volumeSnapshot := range createVolumeSnapshots(vmSnapshotContent) {
+						updatedContent.Status.VolumeSnapshotStatus = append(updatedContent.Status.VolumeSnapshotStatus, snapshotv1.VolumeSnapshotStatus{
+							VolumeSnapshotName: volumeSnapshot.Name,
+						})
+					}
+
+					expectVolumeSnapshotCreates(k8sSnapshotClient, volumeSnapshotClass.Name, vmSnapshotContent)
+					expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+					vmSnapshotSource.Add(vmSnapshot)
+					addVolumeSnapshotClass(volumeSnapshotClass)
+					addVirtualMachineSnapshotContent(vmSnapshotContent)
+					controller.processVMSnapshotContentWorkItem()
+					testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+				})
+			})
+
 			DescribeTable("should update VirtualMachineSnapshotContent", func(readyToUse bool) {
 				vmSnapshot := createVMSnapshotInProgress()
 				vmSnapshotContent := createVMSnapshotContent()
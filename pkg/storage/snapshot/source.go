@@ -56,8 +56,13 @@ type snapshotSource interface {
 	Online() (bool, error)
 	GuestAgent() (bool, error)
 	Frozen() (bool, error)
+	FreezeHooks() (*kubevirtv1.FreezeHooksStatus, error)
 	Freeze() error
 	Unfreeze() error
+	Paused() (bool, error)
+	Pause() error
+	Unpause() error
+	SaveMemoryState(claimName string) (bool, error)
 	Spec() (snapshotv1.SourceSpec, error)
 	PersistentVolumeClaims() (map[string]string, error)
 }
@@ -325,6 +330,31 @@ func (s *vmSnapshotSource) Frozen() (bool, error) {
 	return vmi.Status.FSFreezeStatus == launcherapi.FSFrozen, nil
 }
 
+// FreezeHooks returns the outcome of the last run of the pre-freeze hooks,
+// or nil if the VMI has none
+func (s *vmSnapshotSource) FreezeHooks() (*kubevirtv1.FreezeHooksStatus, error) {
+	vmi, exists, err := s.controller.getVMI(s.vm)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	if vmi.Spec.FreezeHooks == nil || len(vmi.Spec.FreezeHooks.PreFreeze) == 0 {
+		return nil, nil
+	}
+
+	return vmi.Status.FreezeHooks, nil
+}
+
+// freezeAbortedError is returned by Freeze when a pre-freeze hook of the VM
+// aborted the freeze, retrying it would only run the hooks again
+type freezeAbortedError struct {
+	message string
+}
+
+func (e *freezeAbortedError) Error() string {
+	return fmt.Sprintf("freeze aborted by a pre-freeze hook: %s", e.message)
+}
+
 func (s *vmSnapshotSource) Freeze() error {
 	if !s.Locked() {
 		return fmt.Errorf("attempting to freeze unlocked VM")
@@ -340,6 +370,9 @@ func (s *vmSnapshotSource) Freeze() error {
 	startTime := time.Now()
 	err = s.controller.Client.VirtualMachineInstance(s.vm.Namespace).Freeze(context.Background(), s.vm.Name, getFailureDeadline(s.snapshot))
 	timeTrack(startTime, fmt.Sprintf("Freezing vmi %s", s.vm.Name))
+	if utils.IsFreezeAborted(err) {
+		return &freezeAbortedError{message: err.Error()}
+	}
 	if err != nil {
 		return err
 	}
@@ -368,6 +401,93 @@ func (s *vmSnapshotSource) Unfreeze() error {
 	return nil
 }
 
+func (s *vmSnapshotSource) Paused() (bool, error) {
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	vmi, exists, err := s.controller.getVMI(s.vm)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	return condManager.HasCondition(vmi, kubevirtv1.VirtualMachineInstancePaused), nil
+}
+
+func (s *vmSnapshotSource) Pause() error {
+	if !s.Locked() {
+		return fmt.Errorf("attempting to pause unlocked VM")
+	}
+
+	paused, err := s.Paused()
+	if paused || err != nil {
+		return err
+	}
+
+	// Remember that the snapshot paused the vm, so that a vm paused by
+	// the user is not unpaused once the snapshot is taken
+	if _, exists := s.snapshot.Annotations[sourcePausedAnnotation]; !exists {
+		snapshotCopy := s.snapshot.DeepCopy()
+		if snapshotCopy.Annotations == nil {
+			snapshotCopy.Annotations = make(map[string]string)
+		}
+		snapshotCopy.Annotations[sourcePausedAnnotation] = "true"
+		snapshot, err := s.controller.Client.VirtualMachineSnapshot(snapshotCopy.Namespace).Update(context.Background(), snapshotCopy, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		s.snapshot = snapshot
+	}
+
+	log.Log.V(3).Infof("Pausing vm %s before saving its memory state", s.vm.Name)
+
+	return s.controller.Client.VirtualMachineInstance(s.vm.Namespace).Pause(context.Background(), s.vm.Name, &kubevirtv1.PauseOptions{})
+}
+
+func (s *vmSnapshotSource) Unpause() error {
+	if !s.Locked() {
+		return nil
+	}
+
+	if _, exists := s.snapshot.Annotations[sourcePausedAnnotation]; !exists {
+		return nil
+	}
+
+	paused, err := s.Paused()
+	if !paused || err != nil {
+		return err
+	}
+
+	log.Log.V(3).Infof("Unpausing vm %s after taking the snapshot", s.vm.Name)
+
+	return s.controller.Client.VirtualMachineInstance(s.vm.Namespace).Unpause(context.Background(), s.vm.Name, &kubevirtv1.UnpauseOptions{})
+}
+
+// SaveMemoryState requests the memory state of the VM to be saved to the
+// given pvc, and returns true once it has been saved
+func (s *vmSnapshotSource) SaveMemoryState(claimName string) (bool, error) {
+	request := s.vm.Status.MemoryDumpRequest
+	if request != nil && request.ClaimName == claimName {
+		switch request.Phase {
+		case kubevirtv1.MemoryDumpUnmounting, kubevirtv1.MemoryDumpCompleted:
+			return true, nil
+		case kubevirtv1.MemoryDumpFailed:
+			return false, fmt.Errorf("failed to save memory state of vm %s: %s", s.vm.Name, request.Message)
+		}
+		return false, nil
+	}
+
+	if request != nil && request.Phase != kubevirtv1.MemoryDumpCompleted && request.Phase != kubevirtv1.MemoryDumpFailed {
+		log.Log.V(3).Infof("Memory dump of vm %s to %s in progress", s.vm.Name, request.ClaimName)
+		return false, nil
+	}
+
+	vmCopy := s.vm.DeepCopy()
+	vmCopy.Status.MemoryDumpRequest = &kubevirtv1.VirtualMachineMemoryDumpRequest{
+		ClaimName: claimName,
+		Phase:     kubevirtv1.MemoryDumpAssociating,
+		Format:    kubevirtv1.MemoryDumpFormatSavedState,
+	}
+	return false, s.controller.vmStatusUpdater.UpdateStatus(vmCopy)
+}
+
 func (s *vmSnapshotSource) PersistentVolumeClaims() (map[string]string, error) {
 	return storagetypes.GetPVCsFromVolumes(s.vm.Spec.Template.Spec.Volumes), nil
 }
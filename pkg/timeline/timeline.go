@@ -0,0 +1,234 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+// Package timeline builds the chronologically ordered lifecycle view of a VirtualMachine.
+//
+// The VM controller persists the entries it can derive from the VirtualMachineInstance status
+// (phase transitions, migrations and guest agent connects) on the VirtualMachine, since they are
+// lost once the VMI is gone. virt-api merges them with the Kubernetes events of the VM and its
+// VMIs, which include the libvirt domain lifecycle events recorded by virt-handler.
+package timeline
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+const (
+	// MaxPersistedEntries bounds the timeline stored in the VirtualMachine status
+	MaxPersistedEntries = 100
+
+	// DomainLifecycleReason is the reason of the events virt-handler records for libvirt domain lifecycle changes
+	DomainLifecycleReason = "DomainLifecycle"
+
+	MigrationStartedReason   = "MigrationStarted"
+	MigrationSucceededReason = "MigrationSucceeded"
+	MigrationFailedReason    = "MigrationFailed"
+	AgentConnectedReason     = "AgentConnected"
+	AgentDisconnectedReason  = "AgentDisconnected"
+
+	virtualMachineInstanceKind = "VirtualMachineInstance"
+)
+
+// FromVMI returns the phase transitions, the current migration and the guest agent connection of vmi.
+func FromVMI(vmi *v1.VirtualMachineInstance) []v1.VirtualMachineTimelineEntry {
+	if vmi == nil {
+		return nil
+	}
+
+	var entries []v1.VirtualMachineTimelineEntry
+	for _, transition := range vmi.Status.PhaseTransitionTimestamps {
+		entryType := k8sv1.EventTypeNormal
+		if transition.Phase == v1.Failed {
+			entryType = k8sv1.EventTypeWarning
+		}
+		entries = append(entries, v1.VirtualMachineTimelineEntry{
+			Timestamp: transition.PhaseTransitionTimestamp,
+			Source:    v1.VirtualMachineTimelineSourcePhase,
+			Type:      entryType,
+			Reason:    string(transition.Phase),
+			Message:   fmt.Sprintf("VirtualMachineInstance entered phase %s", transition.Phase),
+			VMIUID:    vmi.UID,
+		})
+	}
+
+	entries = append(entries, fromMigrationState(vmi.Status.MigrationState, vmi.UID)...)
+
+	for _, condition := range vmi.Status.Conditions {
+		if condition.Type == v1.VirtualMachineInstanceAgentConnected && condition.Status == k8sv1.ConditionTrue {
+			entries = append(entries, v1.VirtualMachineTimelineEntry{
+				Timestamp: condition.LastTransitionTime,
+				Source:    v1.VirtualMachineTimelineSourceGuestAgent,
+				Type:      k8sv1.EventTypeNormal,
+				Reason:    AgentConnectedReason,
+				Message:   "Guest agent connected",
+				VMIUID:    vmi.UID,
+			})
+		}
+	}
+
+	return entries
+}
+
+func fromMigrationState(state *v1.VirtualMachineInstanceMigrationState, vmiUID types.UID) []v1.VirtualMachineTimelineEntry {
+	if state == nil || state.StartTimestamp == nil {
+		return nil
+	}
+
+	entries := []v1.VirtualMachineTimelineEntry{{
+		Timestamp: *state.StartTimestamp,
+		Source:    v1.VirtualMachineTimelineSourceMigration,
+		Type:      k8sv1.EventTypeNormal,
+		Reason:    MigrationStartedReason,
+		Message:   fmt.Sprintf("Migration %s from node %s to node %s started", state.MigrationUID, state.SourceNode, state.TargetNode),
+		VMIUID:    vmiUID,
+	}}
+
+	if state.EndTimestamp == nil || !(state.Completed || state.Failed) {
+		return entries
+	}
+	end := v1.VirtualMachineTimelineEntry{
+		Timestamp: *state.EndTimestamp,
+		Source:    v1.VirtualMachineTimelineSourceMigration,
+		Type:      k8sv1.EventTypeNormal,
+		Reason:    MigrationSucceededReason,
+		Message:   fmt.Sprintf("Migration %s to node %s succeeded", state.MigrationUID, state.TargetNode),
+		VMIUID:    vmiUID,
+	}
+	if state.Failed {
+		end.Type = k8sv1.EventTypeWarning
+		end.Reason = MigrationFailedReason
+		end.Message = fmt.Sprintf("Migration %s to node %s failed", state.MigrationUID, state.TargetNode)
+	}
+	return append(entries, end)
+}
+
+// FromEvents converts Kubernetes events into timeline entries.
+// Events recorded by virt-handler for libvirt domain lifecycle changes are attributed to the Domain source.
+func FromEvents(events []k8sv1.Event) []v1.VirtualMachineTimelineEntry {
+	entries := make([]v1.VirtualMachineTimelineEntry, 0, len(events))
+	for _, event := range events {
+		entry := v1.VirtualMachineTimelineEntry{
+			Timestamp: eventTimestamp(&event),
+			Source:    v1.VirtualMachineTimelineSourceEvent,
+			Type:      event.Type,
+			Reason:    event.Reason,
+			Message:   event.Message,
+		}
+		if event.Reason == DomainLifecycleReason {
+			entry.Source = v1.VirtualMachineTimelineSourceDomain
+		}
+		if event.InvolvedObject.Kind == virtualMachineInstanceKind {
+			entry.VMIUID = event.InvolvedObject.UID
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func eventTimestamp(event *k8sv1.Event) metav1.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp
+	case !event.EventTime.IsZero():
+		return metav1.NewTime(event.EventTime.Time)
+	}
+	return event.FirstTimestamp
+}
+
+// Merge combines timelines, drops duplicated entries and orders the result chronologically.
+// Entries with the same timestamp keep their relative order.
+func Merge(timelines ...[]v1.VirtualMachineTimelineEntry) []v1.VirtualMachineTimelineEntry {
+	type entryKey struct {
+		vmiUID    types.UID
+		source    v1.VirtualMachineTimelineSource
+		reason    string
+		message   string
+		timestamp int64
+	}
+
+	seen := map[entryKey]struct{}{}
+	var merged []v1.VirtualMachineTimelineEntry
+	for _, timeline := range timelines {
+		for _, entry := range timeline {
+			key := entryKey{entry.VMIUID, entry.Source, entry.Reason, entry.Message, entry.Timestamp.Unix()}
+			if _, exists := seen[key]; exists {
+				continue
+			}
+			seen[key] = struct{}{}
+			merged = append(merged, entry)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(&merged[j].Timestamp)
+	})
+	return merged
+}
+
+// Update adds the entries of vmi to the timeline persisted on the VirtualMachine and keeps the
+// MaxPersistedEntries most recent ones. A guest agent disconnect has no timestamp of its own,
+// it is recorded at now once a previously connected agent is gone.
+func Update(persisted []v1.VirtualMachineTimelineEntry, vmi *v1.VirtualMachineInstance, now time.Time) []v1.VirtualMachineTimelineEntry {
+	if vmi == nil {
+		return persisted
+	}
+
+	entries := FromVMI(vmi)
+	if agentDisconnected(persisted, entries, vmi) {
+		entries = append(entries, v1.VirtualMachineTimelineEntry{
+			Timestamp: metav1.NewTime(now).Rfc3339Copy(),
+			Source:    v1.VirtualMachineTimelineSourceGuestAgent,
+			Type:      k8sv1.EventTypeNormal,
+			Reason:    AgentDisconnectedReason,
+			Message:   "Guest agent disconnected",
+			VMIUID:    vmi.UID,
+		})
+	}
+
+	merged := Merge(persisted, entries)
+	if len(merged) > MaxPersistedEntries {
+		merged = merged[len(merged)-MaxPersistedEntries:]
+	}
+	return merged
+}
+
+func agentDisconnected(persisted, current []v1.VirtualMachineTimelineEntry, vmi *v1.VirtualMachineInstance) bool {
+	if !vmi.IsFinal() {
+		for _, entry := range current {
+			if entry.Source == v1.VirtualMachineTimelineSourceGuestAgent {
+				return false
+			}
+		}
+	}
+
+	for i := len(persisted) - 1; i >= 0; i-- {
+		if persisted[i].VMIUID == vmi.UID && persisted[i].Source == v1.VirtualMachineTimelineSourceGuestAgent {
+			return persisted[i].Reason == AgentConnectedReason
+		}
+	}
+	return false
+}
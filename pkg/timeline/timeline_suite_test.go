@@ -0,0 +1,11 @@
+package timeline
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestTimeline(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,157 @@
+package timeline
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+var _ = Describe("Timeline", func() {
+	var start time.Time
+
+	at := func(seconds int) metav1.Time {
+		return metav1.NewTime(start.Add(time.Duration(seconds) * time.Second))
+	}
+
+	newVMIAt := func(uid types.UID, offset int, phases ...v1.VirtualMachineInstancePhase) *v1.VirtualMachineInstance {
+		vmi := &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: "testvmi", UID: uid}}
+		for i, phase := range phases {
+			vmi.Status.Phase = phase
+			vmi.Status.PhaseTransitionTimestamps = append(vmi.Status.PhaseTransitionTimestamps, v1.VirtualMachineInstancePhaseTransitionTimestamp{
+				Phase:                    phase,
+				PhaseTransitionTimestamp: at(offset + i),
+			})
+		}
+		return vmi
+	}
+
+	newVMI := func(uid types.UID, phases ...v1.VirtualMachineInstancePhase) *v1.VirtualMachineInstance {
+		return newVMIAt(uid, 0, phases...)
+	}
+
+	reasons := func(entries []v1.VirtualMachineTimelineEntry) []string {
+		var result []string
+		for _, entry := range entries {
+			result = append(result, entry.Reason)
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		start = time.Now().Truncate(time.Second)
+	})
+
+	Context("FromVMI", func() {
+		It("should return the phase transitions, the migration and the guest agent connection", func() {
+			vmi := newVMI("uid", v1.Pending, v1.Scheduling, v1.Scheduled, v1.Running)
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				StartTimestamp: &metav1.Time{Time: at(10).Time},
+				EndTimestamp:   &metav1.Time{Time: at(20).Time},
+				Failed:         true,
+				SourceNode:     "node01",
+				TargetNode:     "node02",
+			}
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+				Type:               v1.VirtualMachineInstanceAgentConnected,
+				Status:             k8sv1.ConditionTrue,
+				LastTransitionTime: at(5),
+			}}
+
+			entries := FromVMI(vmi)
+			Expect(reasons(entries)).To(Equal([]string{"Pending", "Scheduling", "Scheduled", "Running", MigrationStartedReason, MigrationFailedReason, AgentConnectedReason}))
+			for _, entry := range entries {
+				Expect(entry.VMIUID).To(Equal(types.UID("uid")))
+			}
+			Expect(entries[5].Type).To(Equal(k8sv1.EventTypeWarning))
+			Expect(entries[4].Message).To(ContainSubstring("from node node01 to node node02"))
+		})
+
+		It("should not return the end of a migration which is still running", func() {
+			vmi := newVMI("uid", v1.Running)
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				StartTimestamp: &metav1.Time{Time: at(10).Time},
+			}
+			Expect(reasons(FromVMI(vmi))).To(Equal([]string{"Running", MigrationStartedReason}))
+		})
+	})
+
+	Context("FromEvents", func() {
+		It("should attribute domain lifecycle events to the domain source", func() {
+			entries := FromEvents([]k8sv1.Event{
+				{
+					InvolvedObject: k8sv1.ObjectReference{Kind: "VirtualMachineInstance", UID: "uid"},
+					Reason:         DomainLifecycleReason,
+					LastTimestamp:  at(3),
+				},
+				{
+					InvolvedObject: k8sv1.ObjectReference{Kind: "VirtualMachine", UID: "vm-uid"},
+					Reason:         "SuccessfulCreate",
+					EventTime:      metav1.NewMicroTime(at(1).Time),
+				},
+			})
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].Source).To(Equal(v1.VirtualMachineTimelineSourceDomain))
+			Expect(entries[0].VMIUID).To(Equal(types.UID("uid")))
+			Expect(entries[1].Source).To(Equal(v1.VirtualMachineTimelineSourceEvent))
+			Expect(entries[1].VMIUID).To(BeEmpty())
+			Expect(entries[1].Timestamp).To(Equal(at(1)))
+		})
+	})
+
+	Context("Merge", func() {
+		It("should drop duplicates and order the entries chronologically", func() {
+			first := FromVMI(newVMI("uid", v1.Pending, v1.Scheduling))
+			second := FromVMI(newVMI("uid", v1.Pending, v1.Scheduling, v1.Scheduled))
+			events := []v1.VirtualMachineTimelineEntry{{Timestamp: at(0), Reason: "SuccessfulCreate", Source: v1.VirtualMachineTimelineSourceEvent}}
+
+			Expect(reasons(Merge(second, events, first))).To(Equal([]string{"Pending", "SuccessfulCreate", "Scheduling", "Scheduled"}))
+		})
+	})
+
+	Context("Update", func() {
+		It("should keep the entries of previous VMIs", func() {
+			persisted := Update(nil, newVMI("first", v1.Pending, v1.Running, v1.Succeeded), start)
+			persisted = Update(persisted, newVMIAt("second", 10, v1.Pending), start)
+
+			Expect(persisted).To(HaveLen(4))
+			Expect(persisted[0].VMIUID).To(Equal(types.UID("first")))
+			Expect(persisted[3].VMIUID).To(Equal(types.UID("second")))
+		})
+
+		It("should record a guest agent disconnect once the agent is gone", func() {
+			vmi := newVMI("uid", v1.Running)
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+				Type:               v1.VirtualMachineInstanceAgentConnected,
+				Status:             k8sv1.ConditionTrue,
+				LastTransitionTime: at(5),
+			}}
+			persisted := Update(nil, vmi, start.Add(time.Minute))
+			Expect(reasons(persisted)).To(Equal([]string{"Running", AgentConnectedReason}))
+
+			vmi.Status.Conditions = nil
+			persisted = Update(persisted, vmi, start.Add(time.Minute))
+			Expect(reasons(persisted)).To(Equal([]string{"Running", AgentConnectedReason, AgentDisconnectedReason}))
+			Expect(persisted[2].Timestamp.Time).To(BeTemporally("==", start.Add(time.Minute)))
+
+			By("not recording the disconnect twice")
+			Expect(Update(persisted, vmi, start.Add(2*time.Minute))).To(Equal(persisted))
+		})
+
+		It("should keep the most recent entries only", func() {
+			var persisted []v1.VirtualMachineTimelineEntry
+			for i := 0; i < MaxPersistedEntries+10; i++ {
+				persisted = Update(persisted, newVMI(types.UID(fmt.Sprintf("uid-%d", i)), v1.Pending), start)
+			}
+			Expect(persisted).To(HaveLen(MaxPersistedEntries))
+			Expect(persisted[MaxPersistedEntries-1].VMIUID).To(Equal(types.UID(fmt.Sprintf("uid-%d", MaxPersistedEntries+9))))
+		})
+	})
+})
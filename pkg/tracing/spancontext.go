@@ -0,0 +1,111 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	traceparentVersion = "00"
+	sampledFlag        = "01"
+)
+
+type TraceID [16]byte
+
+type SpanID [8]byte
+
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext identifies a span within a trace, it is propagated between components
+// in the W3C traceparent format.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+// IsValid returns true if neither the trace nor the span id are all zeros.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// Traceparent encodes the span context as a W3C traceparent header value.
+func (sc SpanContext) Traceparent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	return strings.Join([]string{traceparentVersion, sc.TraceID.String(), sc.SpanID.String(), sampledFlag}, "-")
+}
+
+// ParseTraceparent decodes a W3C traceparent header value.
+func ParseTraceparent(traceparent string) (SpanContext, error) {
+	sc := SpanContext{}
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) != 4 {
+		return sc, fmt.Errorf("invalid traceparent %q: expected 4 fields", traceparent)
+	}
+	if parts[0] != traceparentVersion {
+		return sc, fmt.Errorf("invalid traceparent %q: unsupported version %s", traceparent, parts[0])
+	}
+	if err := decodeHex(parts[1], sc.TraceID[:]); err != nil {
+		return sc, fmt.Errorf("invalid traceparent %q: trace id: %v", traceparent, err)
+	}
+	if err := decodeHex(parts[2], sc.SpanID[:]); err != nil {
+		return sc, fmt.Errorf("invalid traceparent %q: span id: %v", traceparent, err)
+	}
+	if len(parts[3]) != 2 {
+		return sc, fmt.Errorf("invalid traceparent %q: invalid flags", traceparent)
+	}
+	if !sc.IsValid() {
+		return sc, fmt.Errorf("invalid traceparent %q: all zero trace or span id", traceparent)
+	}
+	return sc, nil
+}
+
+func decodeHex(s string, dst []byte) error {
+	if len(s) != hex.EncodedLen(len(dst)) {
+		return fmt.Errorf("expected %d hex characters, got %d", hex.EncodedLen(len(dst)), len(s))
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
+
+// NewRootSpanContext starts a new trace.
+func NewRootSpanContext() SpanContext {
+	sc := SpanContext{}
+	_, _ = rand.Read(sc.TraceID[:])
+	sc.SpanID = newSpanID()
+	return sc
+}
+
+func newSpanID() SpanID {
+	id := SpanID{}
+	_, _ = rand.Read(id[:])
+	return id
+}
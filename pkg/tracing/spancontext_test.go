@@ -0,0 +1,61 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package tracing
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpanContext", func() {
+	const traceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	It("should round trip a traceparent", func() {
+		sc, err := ParseTraceparent(traceparent)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sc.IsValid()).To(BeTrue())
+		Expect(sc.TraceID.String()).To(Equal("0af7651916cd43dd8448eb211c80319c"))
+		Expect(sc.SpanID.String()).To(Equal("b7ad6b7169203331"))
+		Expect(sc.Traceparent()).To(Equal(traceparent))
+	})
+
+	DescribeTable("should reject an invalid traceparent", func(value string) {
+		_, err := ParseTraceparent(value)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("empty", ""),
+		Entry("missing fields", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331"),
+		Entry("unsupported version", "ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"),
+		Entry("short trace id", "00-0af7651916cd43dd-b7ad6b7169203331-01"),
+		Entry("non hex span id", "00-0af7651916cd43dd8448eb211c80319c-zzad6b7169203331-01"),
+		Entry("all zero trace id", "00-00000000000000000000000000000000-b7ad6b7169203331-01"),
+	)
+
+	It("should start a new trace with random ids", func() {
+		sc := NewRootSpanContext()
+		other := NewRootSpanContext()
+		Expect(sc.IsValid()).To(BeTrue())
+		Expect(sc.TraceID).ToNot(Equal(other.TraceID))
+	})
+
+	It("should not encode an empty span context", func() {
+		Expect(SpanContext{}.Traceparent()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,185 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package tracing
+
+import (
+	"time"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	queueSize     = 2048
+	maxBatchSize  = 256
+	flushInterval = 5 * time.Second
+)
+
+// Span is a single timed operation of a trace.
+type Span struct {
+	tracer *Tracer
+
+	Name         string
+	SpanContext  SpanContext
+	ParentSpanID SpanID
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   map[string]string
+	Err          error
+}
+
+// IsRecording returns false for spans which are dropped when they end,
+// either because tracing is disabled or because they have no parent.
+func (s *Span) IsRecording() bool {
+	return s.tracer != nil
+}
+
+func (s *Span) SetAttribute(key, value string) {
+	if !s.IsRecording() {
+		return
+	}
+	if s.Attributes == nil {
+		s.Attributes = map[string]string{}
+	}
+	s.Attributes[key] = value
+}
+
+// SetError marks the span as failed.
+func (s *Span) SetError(err error) {
+	if !s.IsRecording() {
+		return
+	}
+	s.Err = err
+}
+
+// End finishes the span and hands it over to the exporter.
+func (s *Span) End() {
+	if !s.IsRecording() {
+		return
+	}
+	s.EndTime = time.Now()
+	s.tracer.RecordSpan(s)
+}
+
+// Tracer batches finished spans and exports them in the background.
+type Tracer struct {
+	serviceName string
+	exporter    Exporter
+
+	spans chan *Span
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+func NewTracer(serviceName string, exporter Exporter) *Tracer {
+	t := &Tracer{
+		serviceName: serviceName,
+		exporter:    exporter,
+		spans:       make(chan *Span, queueSize),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// StartSpan starts a child span of parent. Without a valid parent there is no trace
+// to attach to, the returned span is not recorded and carries an empty span context.
+// On a nil tracer the returned span is not recorded either, but it keeps the parent
+// span context so that the trace can still be propagated to the next component.
+func (t *Tracer) StartSpan(parent SpanContext, name string) *Span {
+	if !parent.IsValid() {
+		return &Span{Name: name}
+	}
+	if t == nil {
+		return &Span{Name: name, SpanContext: parent}
+	}
+	return &Span{
+		tracer: t,
+		Name:   name,
+		SpanContext: SpanContext{
+			TraceID: parent.TraceID,
+			SpanID:  newSpanID(),
+		},
+		ParentSpanID: parent.SpanID,
+		StartTime:    time.Now(),
+	}
+}
+
+// RecordSpan queues an already finished span for export. It never blocks,
+// spans are dropped when the exporter can't keep up.
+func (t *Tracer) RecordSpan(span *Span) {
+	if t == nil || !span.SpanContext.IsValid() {
+		return
+	}
+	select {
+	case t.spans <- span:
+	default:
+		log.Log.V(4).Infof("tracing queue is full, dropping span %s", span.Name)
+	}
+}
+
+// Shutdown exports all queued spans and stops the tracer.
+func (t *Tracer) Shutdown() {
+	if t == nil {
+		return
+	}
+	close(t.stop)
+	<-t.done
+}
+
+func (t *Tracer) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var batch []*Span
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := t.exporter.Export(t.serviceName, batch); err != nil {
+			log.Log.Reason(err).Warningf("failed to export %d spans", len(batch))
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case span := <-t.spans:
+			batch = append(batch, span)
+			if len(batch) >= maxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-t.stop:
+			for {
+				select {
+				case span := <-t.spans:
+					batch = append(batch, span)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
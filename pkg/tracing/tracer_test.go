@@ -0,0 +1,232 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+type fakeExporter struct {
+	serviceName string
+	spans       []*Span
+}
+
+func (f *fakeExporter) Export(serviceName string, spans []*Span) error {
+	f.serviceName = serviceName
+	f.spans = append(f.spans, spans...)
+	return nil
+}
+
+var _ = Describe("Tracer", func() {
+	var parent SpanContext
+
+	BeforeEach(func() {
+		parent = NewRootSpanContext()
+	})
+
+	It("should export child spans on shutdown", func() {
+		exporter := &fakeExporter{}
+		tracer := NewTracer("virt-handler", exporter)
+
+		span := tracer.StartSpan(parent, "sync")
+		span.SetAttribute("key", "value")
+		span.SetError(fmt.Errorf("failed"))
+		span.End()
+		tracer.Shutdown()
+
+		Expect(exporter.serviceName).To(Equal("virt-handler"))
+		Expect(exporter.spans).To(HaveLen(1))
+		exported := exporter.spans[0]
+		Expect(exported.Name).To(Equal("sync"))
+		Expect(exported.SpanContext.TraceID).To(Equal(parent.TraceID))
+		Expect(exported.SpanContext.SpanID).ToNot(Equal(parent.SpanID))
+		Expect(exported.ParentSpanID).To(Equal(parent.SpanID))
+		Expect(exported.Attributes).To(HaveKeyWithValue("key", "value"))
+		Expect(exported.Err).To(MatchError("failed"))
+		Expect(exported.EndTime).ToNot(BeTemporally("<", exported.StartTime))
+	})
+
+	It("should not record spans without a parent", func() {
+		exporter := &fakeExporter{}
+		tracer := NewTracer("virt-handler", exporter)
+
+		span := tracer.StartSpan(SpanContext{}, "sync")
+		Expect(span.IsRecording()).To(BeFalse())
+		span.End()
+		tracer.Shutdown()
+
+		Expect(exporter.spans).To(BeEmpty())
+	})
+
+	It("should propagate the parent when tracing is disabled", func() {
+		var tracer *Tracer
+
+		span := tracer.StartSpan(parent, "sync")
+		Expect(span.IsRecording()).To(BeFalse())
+		Expect(span.SpanContext).To(Equal(parent))
+		span.End()
+	})
+
+	Context("exporters", func() {
+		var span *Span
+
+		BeforeEach(func() {
+			span = &Span{
+				Name:         "sync",
+				SpanContext:  SpanContext{TraceID: parent.TraceID, SpanID: newSpanID()},
+				ParentSpanID: parent.SpanID,
+				Attributes:   map[string]string{"kubevirt.io/name": "testvmi"},
+			}
+		})
+
+		expectExportRequest := func(body []byte) {
+			request := &exportTraceServiceRequest{}
+			Expect(json.Unmarshal(body, request)).To(Succeed())
+			Expect(request.ResourceSpans).To(HaveLen(1))
+			Expect(request.ResourceSpans[0].Resource.Attributes).To(ContainElement(newKeyValue("service.name", "virt-controller")))
+			Expect(request.ResourceSpans[0].ScopeSpans).To(HaveLen(1))
+			spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("sync"))
+			Expect(spans[0].TraceID).To(Equal(parent.TraceID.String()))
+			Expect(spans[0].ParentSpanID).To(Equal(parent.SpanID.String()))
+			Expect(spans[0].Attributes).To(ConsistOf(newKeyValue("kubevirt.io/name", "testvmi")))
+			Expect(spans[0].Status.Code).To(Equal(statusCodeOK))
+		}
+
+		It("should post OTLP JSON to the collector", func() {
+			var body []byte
+			var path, contentType string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				contentType = r.Header.Get("Content-Type")
+				body, _ = io.ReadAll(r.Body)
+			}))
+			defer server.Close()
+
+			exporter := NewOTLPExporter(server.URL + "/")
+			Expect(exporter.Export("virt-controller", []*Span{span})).To(Succeed())
+
+			Expect(path).To(Equal("/v1/traces"))
+			Expect(contentType).To(Equal("application/json"))
+			expectExportRequest(body)
+		})
+
+		It("should fail when the collector rejects the spans", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			}))
+			defer server.Close()
+
+			exporter := NewOTLPExporter(server.URL)
+			Expect(exporter.Export("virt-controller", []*Span{span})).ToNot(Succeed())
+		})
+
+		It("should append one line of OTLP JSON per export to a file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "spans.json")
+			exporter := NewFileExporter(path)
+			Expect(exporter.Export("virt-controller", []*Span{span})).To(Succeed())
+			Expect(exporter.Export("virt-controller", []*Span{span})).To(Succeed())
+
+			content, err := os.ReadFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			Expect(lines).To(HaveLen(2))
+			for _, line := range lines {
+				expectExportRequest([]byte(line))
+			}
+		})
+	})
+
+	Context("process wide tracer", func() {
+		AfterEach(func() {
+			Shutdown()
+		})
+
+		It("should be enabled by an exporter in the configuration", func() {
+			Configure("virt-handler", &v1.TracingConfiguration{})
+			Expect(Enabled()).To(BeFalse())
+
+			path := filepath.Join(GinkgoT().TempDir(), "spans.json")
+			Configure("virt-handler", &v1.TracingConfiguration{File: &v1.TracingFileExporter{Path: path}})
+			Expect(Enabled()).To(BeTrue())
+
+			StartSpan(parent, "sync").End()
+			Shutdown()
+			Expect(Enabled()).To(BeFalse())
+			Expect(path).To(BeAnExistingFile())
+		})
+
+		It("should be configured from the environment", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "spans.json")
+			GinkgoT().Setenv(ConfigurationEnvVar, fmt.Sprintf(`{"file":{"path":%q}}`, path))
+
+			Expect(ConfigureFromEnv("virt-launcher")).To(Succeed())
+			Expect(Enabled()).To(BeTrue())
+		})
+	})
+
+	DescribeTable("should only hand the collector endpoint over to virt-launcher", func(config, expected *v1.TracingConfiguration) {
+		Expect(LauncherConfiguration(config)).To(Equal(expected))
+	},
+		Entry("without a configuration", nil, nil),
+		Entry("with the file exporter only", &v1.TracingConfiguration{File: &v1.TracingFileExporter{Path: "/tmp/spans.json"}}, nil),
+		Entry("with both exporters",
+			&v1.TracingConfiguration{
+				OTLP: &v1.TracingOTLPExporter{Endpoint: "http://collector:4318"},
+				File: &v1.TracingFileExporter{Path: "/tmp/spans.json"},
+			},
+			&v1.TracingConfiguration{OTLP: &v1.TracingOTLPExporter{Endpoint: "http://collector:4318"}},
+		),
+	)
+
+	It("should propagate the span context through object annotations", func() {
+		obj := &metav1.ObjectMeta{Name: "testvmi", Namespace: "default"}
+		Expect(FromObject(obj).IsValid()).To(BeFalse())
+
+		SetObjectSpanContext(obj, parent)
+		Expect(obj.Annotations).To(HaveKeyWithValue(v1.TraceparentAnnotation, parent.Traceparent()))
+		Expect(FromObject(obj)).To(Equal(parent))
+	})
+
+	It("should propagate the span context through gRPC metadata", func() {
+		ctx := OutgoingContext(context.Background(), parent)
+		md, ok := metadata.FromOutgoingContext(ctx)
+		Expect(ok).To(BeTrue())
+
+		Expect(FromIncomingContext(metadata.NewIncomingContext(context.Background(), md))).To(Equal(parent))
+		Expect(FromIncomingContext(context.Background()).IsValid()).To(BeFalse())
+	})
+})
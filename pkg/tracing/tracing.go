@@ -0,0 +1,219 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+// Package tracing records OpenTelemetry compatible spans of the VirtualMachineInstance lifecycle.
+//
+// The trace of a VMI is started by virt-api on creation and stored in the kubevirt.io/traceparent
+// annotation. virt-controller and virt-handler parent their spans to it, virt-handler hands the
+// context over to virt-launcher as gRPC metadata on the cmd channel, and virt-launcher sends it
+// back the same way on the notify channel.
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// ConfigurationEnvVar passes the tracing configuration to virt-launcher, which has no access to the KubeVirt CR
+	ConfigurationEnvVar = "KUBEVIRT_TRACING_CONFIGURATION"
+
+	traceparentMetadataKey = "traceparent"
+)
+
+var (
+	lock          sync.RWMutex
+	currentTracer *Tracer
+	currentConfig *v1.TracingConfiguration
+)
+
+// Configure (re)creates the process wide tracer for the given configuration.
+// A nil configuration, or one without any exporter, disables tracing.
+func Configure(serviceName string, config *v1.TracingConfiguration) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if equality.Semantic.DeepEqual(config, currentConfig) {
+		return
+	}
+
+	old := currentTracer
+	currentTracer = nil
+	currentConfig = config.DeepCopy()
+	if exporter := newExporter(config); exporter != nil {
+		currentTracer = NewTracer(serviceName, exporter)
+		log.Log.Infof("tracing enabled for %s", serviceName)
+	} else if old != nil {
+		log.Log.Infof("tracing disabled for %s", serviceName)
+	}
+
+	if old != nil {
+		go old.Shutdown()
+	}
+}
+
+// LauncherConfiguration returns the part of config which is handed over to virt-launcher.
+// The pod environment is readable by everyone allowed to get pods in the VMI namespace,
+// so only the collector endpoint is passed on.
+func LauncherConfiguration(config *v1.TracingConfiguration) *v1.TracingConfiguration {
+	if config == nil || config.OTLP == nil || config.OTLP.Endpoint == "" {
+		return nil
+	}
+	return &v1.TracingConfiguration{
+		OTLP: &v1.TracingOTLPExporter{Endpoint: config.OTLP.Endpoint},
+	}
+}
+
+// ConfigureFromEnv configures tracing from the ConfigurationEnvVar environment variable.
+func ConfigureFromEnv(serviceName string) error {
+	value, exists := os.LookupEnv(ConfigurationEnvVar)
+	if !exists || value == "" {
+		return nil
+	}
+	config := &v1.TracingConfiguration{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return err
+	}
+	Configure(serviceName, config)
+	return nil
+}
+
+// Shutdown exports all pending spans and disables tracing.
+func Shutdown() {
+	lock.Lock()
+	defer lock.Unlock()
+
+	currentTracer.Shutdown()
+	currentTracer = nil
+	currentConfig = nil
+}
+
+// Enabled returns true if spans are exported.
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return currentTracer != nil
+}
+
+func tracer() *Tracer {
+	lock.RLock()
+	defer lock.RUnlock()
+	return currentTracer
+}
+
+func newExporter(config *v1.TracingConfiguration) Exporter {
+	if config == nil {
+		return nil
+	}
+	var exporters multiExporter
+	if config.OTLP != nil && config.OTLP.Endpoint != "" {
+		exporters = append(exporters, NewOTLPExporter(config.OTLP.Endpoint))
+	}
+	if config.File != nil && config.File.Path != "" {
+		exporters = append(exporters, NewFileExporter(config.File.Path))
+	}
+	switch len(exporters) {
+	case 0:
+		return nil
+	case 1:
+		return exporters[0]
+	}
+	return exporters
+}
+
+// StartSpan starts a child span of parent on the process wide tracer.
+func StartSpan(parent SpanContext, name string) *Span {
+	return tracer().StartSpan(parent, name)
+}
+
+// RecordSpan exports an already finished span on the process wide tracer.
+func RecordSpan(span *Span) {
+	tracer().RecordSpan(span)
+}
+
+// FromObject returns the span context stored in the traceparent annotation of obj.
+// An empty span context is returned if the object is not traced.
+func FromObject(obj metav1.Object) SpanContext {
+	traceparent, exists := obj.GetAnnotations()[v1.TraceparentAnnotation]
+	if !exists {
+		return SpanContext{}
+	}
+	sc, err := ParseTraceparent(traceparent)
+	if err != nil {
+		log.Log.V(4).Reason(err).Infof("ignoring the %s annotation of %s/%s", v1.TraceparentAnnotation, obj.GetNamespace(), obj.GetName())
+		return SpanContext{}
+	}
+	return sc
+}
+
+// StartSpanFromObject starts a child span of the trace stored on obj.
+func StartSpanFromObject(obj metav1.Object, name string) *Span {
+	span := StartSpan(FromObject(obj), name)
+	span.SetAttribute("k8s.namespace.name", obj.GetNamespace())
+	span.SetAttribute("kubevirt.io/name", obj.GetName())
+	span.SetAttribute("kubevirt.io/uid", string(obj.GetUID()))
+	return span
+}
+
+// SetObjectSpanContext stores sc in the traceparent annotation of obj.
+func SetObjectSpanContext(obj metav1.Object, sc SpanContext) {
+	if !sc.IsValid() {
+		return
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[v1.TraceparentAnnotation] = sc.Traceparent()
+	obj.SetAnnotations(annotations)
+}
+
+// OutgoingContext attaches sc to the gRPC metadata of an outgoing call.
+func OutgoingContext(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, traceparentMetadataKey, sc.Traceparent())
+}
+
+// FromIncomingContext returns the span context sent along with an incoming gRPC call.
+func FromIncomingContext(ctx context.Context) SpanContext {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return SpanContext{}
+	}
+	values := md.Get(traceparentMetadataKey)
+	if len(values) == 0 {
+		return SpanContext{}
+	}
+	sc, err := ParseTraceparent(values[0])
+	if err != nil {
+		return SpanContext{}
+	}
+	return sc
+}
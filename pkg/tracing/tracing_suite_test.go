@@ -0,0 +1,11 @@
+package tracing
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestTracing(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -100,6 +100,18 @@ func GetNumberOfVCPUs(cpuSpec *v1.CPU) int64 {
 	return int64(vCPUs)
 }
 
+// GetNumberOfEmulatorThreadCPUs returns the number of dedicated pCPUs which are allocated
+// for the emulator and IO threads on top of the vCPUs
+func GetNumberOfEmulatorThreadCPUs(cpuSpec *v1.CPU) int64 {
+	if cpuSpec == nil || !cpuSpec.IsolateEmulatorThread {
+		return 0
+	}
+	if cpuSpec.EmulatorThreadPoolSize == 0 {
+		return 1
+	}
+	return int64(cpuSpec.EmulatorThreadPoolSize)
+}
+
 // ParsePciAddress returns an array of PCI DBSF fields (domain, bus, slot, function)
 func ParsePciAddress(pciAddress string) ([]string, error) {
 	pciAddrRegx, err := regexp.Compile(PCI_ADDRESS_PATTERN)
@@ -88,6 +88,13 @@ var _ = Describe("Hardware utils test", func() {
 			})
 			Expect(vCPUs).To(Equal(int64(4)), "Expect vCPUs")
 		})
+
+		It("should count the emulator thread pool only when the emulator thread is isolated", func() {
+			Expect(GetNumberOfEmulatorThreadCPUs(nil)).To(BeZero())
+			Expect(GetNumberOfEmulatorThreadCPUs(&v1.CPU{EmulatorThreadPoolSize: 2})).To(BeZero())
+			Expect(GetNumberOfEmulatorThreadCPUs(&v1.CPU{IsolateEmulatorThread: true})).To(Equal(int64(1)))
+			Expect(GetNumberOfEmulatorThreadCPUs(&v1.CPU{IsolateEmulatorThread: true, EmulatorThreadPoolSize: 2})).To(Equal(int64(2)))
+		})
 	})
 
 	Context("parse PCI address", func() {
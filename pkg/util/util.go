@@ -5,10 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "kubevirt.io/api/core/v1"
@@ -27,6 +32,7 @@ const (
 	HostRootMount                             = "/proc/1/root/"
 	CPUManagerOS3Path                         = HostRootMount + "var/lib/origin/openshift.local.volumes/cpu_manager_state"
 	CPUManagerPath                            = HostRootMount + "var/lib/kubelet/cpu_manager_state"
+	MemoryStateDir                            = VirtPrivateDir + "/memory-state"
 )
 
 // Alphanums is the list of alphanumeric characters used to create a securely generated random string
@@ -269,6 +275,24 @@ func CalcExpectedMemoryDumpSize(vmi *v1.VirtualMachineInstance) *resource.Quanti
 	return expectedPvcSize
 }
 
+// RestoreMemoryState returns the pvc name and the file name of the memory
+// state the vmi should resume from, if any. The file has to be in the root
+// of the pvc.
+func RestoreMemoryState(vmi *v1.VirtualMachineInstance) (claimName string, fileName string, exists bool) {
+	memoryState, exists := vmi.Annotations[v1.RestoreMemoryStateAnnotation]
+	if !exists {
+		return "", "", false
+	}
+	claimName, fileName, found := strings.Cut(memoryState, "/")
+	if !found || claimName == "" || fileName == "" {
+		return "", "", false
+	}
+	if filepath.Base(fileName) != fileName || fileName == "." || fileName == ".." {
+		return "", "", false
+	}
+	return claimName, fileName, true
+}
+
 // GenerateRandomString creates a securely generated random string using crypto/rand
 func GenerateSecureRandomString(n int) (string, error) {
 	ret := make([]byte, n)
@@ -296,3 +320,53 @@ func GenerateKubeVirtGroupVersionKind(obj runtime.Object) (runtime.Object, error
 
 	return objCopy, nil
 }
+
+// GuestHooksTimeout returns the time the given guest hooks may take to run
+func GuestHooksTimeout(hooks []v1.GuestHook) time.Duration {
+	var timeoutSeconds int32
+	for _, hook := range hooks {
+		if hook.TimeoutSeconds != nil {
+			timeoutSeconds += *hook.TimeoutSeconds
+		} else {
+			timeoutSeconds += v1.DefaultGuestHookTimeoutSeconds
+		}
+	}
+	return time.Duration(timeoutSeconds) * time.Second
+}
+
+// FreezeTimeout returns the time the freeze hooks may add to a freeze of the vmi,
+// the post-thaw hooks are run as well when a pre-freeze hook aborts the freeze
+func FreezeTimeout(vmi *v1.VirtualMachineInstance) time.Duration {
+	if vmi.Spec.FreezeHooks == nil {
+		return 0
+	}
+	return GuestHooksTimeout(vmi.Spec.FreezeHooks.PreFreeze) + GuestHooksTimeout(vmi.Spec.FreezeHooks.PostThaw)
+}
+
+// FreezeAbortedReason is the reason of the error returned by the freeze
+// subresource when a pre-freeze hook of the vmi aborted the freeze
+const FreezeAbortedReason metav1.StatusReason = "FreezeAborted"
+
+// NewFreezeAbortedError returns the error of a freeze aborted by a pre-freeze hook,
+// the message holds the errors and the output of the failed hooks
+func NewFreezeAbortedError(message string) *k8serrors.StatusError {
+	return &k8serrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusUnprocessableEntity,
+		Reason:  FreezeAbortedReason,
+		Message: message,
+	}}
+}
+
+// IsFreezeAborted returns true if the freeze failed because a pre-freeze hook aborted it
+func IsFreezeAborted(err error) bool {
+	return k8serrors.ReasonForError(err) == FreezeAbortedReason
+}
+
+// UnfreezeTimeout returns the time the post-thaw hooks may add to an unfreeze of the vmi
+func UnfreezeTimeout(vmi *v1.VirtualMachineInstance) time.Duration {
+	if vmi.Spec.FreezeHooks == nil {
+		return 0
+	}
+	return GuestHooksTimeout(vmi.Spec.FreezeHooks.PostThaw)
+}
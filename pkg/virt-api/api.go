@@ -62,6 +62,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/monitoring/profiler"
 	"kubevirt.io/kubevirt/pkg/rest/filter"
 	"kubevirt.io/kubevirt/pkg/service"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/openapi"
 	"kubevirt.io/kubevirt/pkg/virt-api/definitions"
@@ -280,6 +281,43 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
 
+		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("timeline")).
+			To(subresourceApp.TimelineVMRequestHandler).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Operation(version.Version+"vm-Timeline").
+			Produces(restful.MIME_JSON).
+			Doc("Get the chronologically ordered lifecycle of a VirtualMachine and its VirtualMachineInstances.").
+			Writes(v1.VirtualMachineTimeline{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineTimeline{}).
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
+
+		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("instancetypediff")).
+			To(subresourceApp.InstancetypeDiffVMRequestHandler).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Operation(version.Version+"vm-InstancetypeDiff").
+			Produces(restful.MIME_JSON).
+			Doc("Compare the instancetype and preference revisions of a VirtualMachine with the current objects.").
+			Writes(v1.VirtualMachineInstancetypeDiff{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstancetypeDiff{}).
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
+
+		instancetypeUpgradeRouteBuilder := subws.PUT(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("instancetypeupgrade")).
+			To(subresourceApp.InstancetypeUpgradeVMRequestHandler).
+			Reads(v1.InstancetypeUpgradeOptions{}).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Operation(version.Version+"vm-InstancetypeUpgrade").
+			Produces(restful.MIME_JSON).
+			Doc("Upgrade a VirtualMachine to the current revisions of its instancetype and preference.").
+			Writes(v1.VirtualMachineInstancetypeDiff{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstancetypeDiff{}).
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, "")
+		instancetypeUpgradeRouteBuilder.ParameterNamed("body").Required(false)
+		subws.Route(instancetypeUpgradeRouteBuilder)
+
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("freeze")).
 			To(subresourceApp.FreezeVMIRequestHandler).
 			Reads(v1.FreezeUnfreezeTimeout{}).
@@ -610,6 +648,18 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachines/expand-spec",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachines/timeline",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/instancetypediff",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/instancetypeupgrade",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/addinterface",
 						Namespaced: true,
@@ -1050,6 +1100,7 @@ func (app *virtAPIApp) Run() {
 	app.clusterConfig.SetConfigModifiedCallback(app.configModificationCallback)
 	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeLogVerbosity)
 	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeRateLimiter)
+	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeTracing)
 
 	var dataSourceInformer cache.SharedIndexInformer
 	if app.hasCDIDataSource {
@@ -1125,6 +1176,11 @@ func (app *virtAPIApp) shouldChangeRateLimiter() {
 	log.Log.V(2).Infof("setting rate limiter for webhooks to %v QPS and %v Burst", qps, burst)
 }
 
+// Update virt-api tracing exporters on relevant config changes
+func (app *virtAPIApp) shouldChangeTracing() {
+	tracing.Configure("virt-api", app.clusterConfig.GetTracingConfiguration())
+}
+
 func (app *virtAPIApp) AddFlags() {
 	app.InitFlags()
 
@@ -0,0 +1,187 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/emicklei/go-restful/v3"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
+)
+
+// InstancetypeDiffVMRequestHandler compares the instancetype and preference ControllerRevisions
+// referenced by the VM with the current instancetype and preference objects.
+func (app *SubresourceAPIApp) InstancetypeDiffVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	diff, err := instancetype.Diff(app.instancetypeMethods, vm)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	writeInstancetypeDiff(diff, response)
+}
+
+// InstancetypeUpgradeVMRequestHandler drops the outdated revision names of the VM, leading the
+// VM controller to store ControllerRevisions of the current instancetype and preference objects.
+// When the VMI is running and only the number of sockets changed within its maximum, the sockets
+// are hotplugged right away, any other change is applied with the next restart of the VM.
+func (app *SubresourceAPIApp) InstancetypeUpgradeVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	opts := &v1.InstancetypeUpgradeOptions{}
+	if request.Request.Body != nil {
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf(unmarshalRequestErrFmt, err)), response)
+			return
+		}
+	}
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	if vm.Spec.Instancetype == nil && vm.Spec.Preference == nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("VM %s does not reference an instancetype or preference", name)), response)
+		return
+	}
+
+	diff, err := instancetype.Diff(app.instancetypeMethods, vm)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	patch, err := instancetype.GenerateUpgradePatch(diff)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	var vmi *v1.VirtualMachineInstance
+	var cpuHotplugPatch []byte
+	if patch != nil {
+		vmi, cpuHotplugPatch, statusErr = app.instancetypeCPUHotplugPatch(vm)
+		if statusErr != nil {
+			writeError(statusErr, response)
+			return
+		}
+
+		_, err = app.virtCli.VirtualMachine(namespace).Patch(context.Background(), name, types.JSONPatchType, patch, &metav1.PatchOptions{DryRun: opts.DryRun})
+		if err != nil {
+			if errors.IsInvalid(err) {
+				writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM was modified concurrently, please retry: %v", err)), response)
+				return
+			}
+			writeError(errors.NewInternalError(fmt.Errorf("unable to patch vm [%s]: %v", name, err)), response)
+			return
+		}
+	}
+
+	if cpuHotplugPatch != nil {
+		_, err = app.virtCli.VirtualMachineInstance(namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, cpuHotplugPatch, &metav1.PatchOptions{DryRun: opts.DryRun})
+		if err != nil {
+			writeError(errors.NewInternalError(fmt.Errorf("VM %s was upgraded, but hotplugging the CPU sockets of the VMI failed, they are updated with the next restart: %v", name, err)), response)
+			return
+		}
+		diff.CPUHotplugged = true
+	}
+
+	writeInstancetypeDiff(diff, response)
+}
+
+// instancetypeCPUHotplugPatch returns the running VMI of the VM and a patch hotplugging the sockets
+// of the current instancetype, or a nil patch when the upgrade has to wait for the next restart
+func (app *SubresourceAPIApp) instancetypeCPUHotplugPatch(vm *v1.VirtualMachine) (*v1.VirtualMachineInstance, []byte, *errors.StatusError) {
+	vmi, err := app.virtCli.VirtualMachineInstance(vm.Namespace).Get(context.Background(), vm.Name, &metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, errors.NewInternalError(err)
+	}
+
+	if !vmi.IsRunning() || vmi.DeletionTimestamp != nil {
+		return nil, nil, nil
+	}
+
+	cpu, err := instancetype.CurrentCPUTopology(app.instancetypeMethods, vm)
+	if err != nil {
+		return nil, nil, errors.NewInternalError(err)
+	}
+
+	if !instancetype.CanHotplugCPU(vmi, cpu) {
+		return nil, nil, nil
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstanceVCPUChange, k8sv1.ConditionTrue) {
+		return nil, nil, errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("another CPU hotplug is in progress, please retry"))
+	}
+
+	if migrations.IsMigrating(vmi) {
+		return nil, nil, errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("CPU hotplug is not allowed while VMI is migrating, please retry"))
+	}
+
+	cpuHotplugPatch, err := instancetype.GenerateCPUHotplugPatch(vmi, cpu)
+	if err != nil {
+		return nil, nil, errors.NewInternalError(err)
+	}
+
+	return vmi, cpuHotplugPatch, nil
+}
+
+func writeInstancetypeDiff(diff *v1.VirtualMachineInstancetypeDiff, response *restful.Response) {
+	diff.TypeMeta = metav1.TypeMeta{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       "VirtualMachineInstancetypeDiff",
+	}
+
+	if err := response.WriteEntity(diff); err != nil {
+		log.Log.Reason(err).Error("Failed to write http response.")
+	}
+}
@@ -0,0 +1,269 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/api/core/v1"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("VirtualMachine instancetype subresources", func() {
+	const (
+		vmName      = "test-vm"
+		vmNamespace = "test-namespace"
+		revision    = "test-vm-instancetype-1"
+	)
+
+	var (
+		vmClient            *kubecli.MockVirtualMachineInterface
+		vmiClient           *kubecli.MockVirtualMachineInstanceInterface
+		virtClient          *kubecli.MockKubevirtClient
+		instancetypeMethods *testutils.MockInstancetypeMethods
+		app                 *SubresourceAPIApp
+
+		request  *restful.Request
+		recorder *httptest.ResponseRecorder
+		response *restful.Response
+
+		vm           *v1.VirtualMachine
+		currentGuest uint32
+	)
+
+	decodeDiff := func() *v1.VirtualMachineInstancetypeDiff {
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		diff := &v1.VirtualMachineInstancetypeDiff{}
+		Expect(json.NewDecoder(recorder.Body).Decode(diff)).To(Succeed())
+		return diff
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		vmClient = kubecli.NewMockVirtualMachineInterface(ctrl)
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		virtClient.EXPECT().VirtualMachine(vmNamespace).Return(vmClient).AnyTimes()
+		vmiClient = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		virtClient.EXPECT().VirtualMachineInstance(vmNamespace).Return(vmiClient).AnyTimes()
+
+		app = NewSubresourceAPIApp(virtClient, 0, nil, nil)
+		instancetypeMethods = testutils.NewMockInstancetypeMethods()
+		app.instancetypeMethods = instancetypeMethods
+
+		currentGuest = 2
+		instancetypeMethods.FindInstancetypeSpecFunc = func(vm *v1.VirtualMachine) (*instancetypev1beta1.VirtualMachineInstancetypeSpec, error) {
+			spec := &instancetypev1beta1.VirtualMachineInstancetypeSpec{}
+			spec.CPU.Guest = 2
+			if vm.Spec.Instancetype.RevisionName == "" {
+				spec.CPU.Guest = currentGuest
+			}
+			return spec, nil
+		}
+		instancetypeMethods.FindPreferenceSpecFunc = func(_ *v1.VirtualMachine) (*instancetypev1beta1.VirtualMachinePreferenceSpec, error) {
+			return nil, nil
+		}
+		instancetypeMethods.ApplyToVmiFunc = func(_ *k8sfield.Path, instancetypeSpec *instancetypev1beta1.VirtualMachineInstancetypeSpec, _ *instancetypev1beta1.VirtualMachinePreferenceSpec, vmiSpec *v1.VirtualMachineInstanceSpec) instancetype.Conflicts {
+			vmiSpec.Domain.CPU = &v1.CPU{Sockets: instancetypeSpec.CPU.Guest, Cores: 1, Threads: 1}
+			return nil
+		}
+
+		request = restful.NewRequest(&http.Request{})
+		request.PathParameters()["name"] = vmName
+		request.PathParameters()["namespace"] = vmNamespace
+		recorder = httptest.NewRecorder()
+		response = restful.NewResponse(recorder)
+		response.SetRequestAccepts(restful.MIME_JSON)
+
+		vm = &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: vmName, Namespace: vmNamespace},
+			Spec: v1.VirtualMachineSpec{
+				Instancetype: &v1.InstancetypeMatcher{
+					Name:         "instancetype",
+					RevisionName: revision,
+				},
+			},
+		}
+	})
+
+	Context("instancetypediff", func() {
+		It("should fail if the VM does not exist", func() {
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(nil, errors.NewNotFound(v1.Resource("virtualmachine"), vmName))
+
+			app.InstancetypeDiffVMRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+		})
+
+		It("should report an up to date VM", func() {
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+
+			app.InstancetypeDiffVMRequestHandler(request, response)
+			diff := decodeDiff()
+			Expect(diff.Kind).To(Equal("VirtualMachineInstancetypeDiff"))
+			Expect(diff.Instancetype.UpToDate).To(BeTrue())
+			Expect(diff.Preference).To(BeNil())
+		})
+
+		It("should report the changes of the instancetype", func() {
+			currentGuest = 4
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+
+			app.InstancetypeDiffVMRequestHandler(request, response)
+			diff := decodeDiff()
+			Expect(diff.Instancetype.UpToDate).To(BeFalse())
+			Expect(diff.Instancetype.Changes).To(ConsistOf(v1.InstancetypeSpecChange{Path: "cpu.guest", Stored: "2", Current: "4"}))
+		})
+	})
+
+	Context("instancetypeupgrade", func() {
+		const upgradePatch = `[{"op":"test","path":"/spec/instancetype/revisionName","value":"test-vm-instancetype-1"},{"op":"remove","path":"/spec/instancetype/revisionName","value":null}]`
+
+		const cpuHotplugPatch = `[{"op":"test","path":"/spec/domain/cpu/sockets","value":2},{"op":"replace","path":"/spec/domain/cpu/sockets","value":4}]`
+
+		var vmi *v1.VirtualMachineInstance
+
+		withOptions := func(opts *v1.InstancetypeUpgradeOptions) {
+			body, err := json.Marshal(opts)
+			Expect(err).ToNot(HaveOccurred())
+			request.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		withRunningVMI := func(maxSockets uint32) {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: vmName, Namespace: vmNamespace},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU: &v1.CPU{Sockets: 2, Cores: 1, Threads: 1, MaxSockets: maxSockets},
+					},
+				},
+				Status: v1.VirtualMachineInstanceStatus{
+					Phase: v1.Running,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			vmi = nil
+			vmiClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, _ *metav1.GetOptions) (*v1.VirtualMachineInstance, error) {
+				if vmi == nil {
+					return nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), vmName)
+				}
+				return vmi, nil
+			}).AnyTimes()
+		})
+
+		It("should reject a VM without instancetype or preference", func() {
+			vm.Spec.Instancetype = nil
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+
+			app.InstancetypeUpgradeVMRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		It("should not patch an up to date VM", func() {
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+			vmClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			app.InstancetypeUpgradeVMRequestHandler(request, response)
+			Expect(decodeDiff().Instancetype.UpToDate).To(BeTrue())
+		})
+
+		It("should drop the outdated revision name of the VM", func() {
+			currentGuest = 4
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+			vmClient.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, []byte(upgradePatch), &metav1.PatchOptions{}).Return(vm, nil)
+
+			app.InstancetypeUpgradeVMRequestHandler(request, response)
+			Expect(decodeDiff().Instancetype.UpToDate).To(BeFalse())
+		})
+
+		It("should pass dry run to the patch of the VM", func() {
+			currentGuest = 4
+			withOptions(&v1.InstancetypeUpgradeOptions{DryRun: []string{metav1.DryRunAll}})
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+			vmClient.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, []byte(upgradePatch), &metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}}).Return(vm, nil)
+
+			app.InstancetypeUpgradeVMRequestHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should fail with a conflict if the VM changed concurrently", func() {
+			currentGuest = 4
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+			vmClient.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, []byte(upgradePatch), &metav1.PatchOptions{}).
+				Return(nil, errors.NewInvalid(v1.VirtualMachineGroupVersionKind.GroupKind(), vmName, nil))
+
+			app.InstancetypeUpgradeVMRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("should hotplug the sockets of the current instancetype into the running VMI", func() {
+			currentGuest = 4
+			withRunningVMI(8)
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+			vmClient.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, []byte(upgradePatch), &metav1.PatchOptions{}).Return(vm, nil)
+			vmiClient.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, []byte(cpuHotplugPatch), &metav1.PatchOptions{}).Return(vmi, nil)
+
+			app.InstancetypeUpgradeVMRequestHandler(request, response)
+			Expect(decodeDiff().CPUHotplugged).To(BeTrue())
+		})
+
+		It("should pass dry run to the CPU hotplug of the VMI", func() {
+			currentGuest = 4
+			withRunningVMI(8)
+			withOptions(&v1.InstancetypeUpgradeOptions{DryRun: []string{metav1.DryRunAll}})
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+			vmClient.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, []byte(upgradePatch), &metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}}).Return(vm, nil)
+			vmiClient.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, []byte(cpuHotplugPatch), &metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}}).Return(vmi, nil)
+
+			app.InstancetypeUpgradeVMRequestHandler(request, response)
+			Expect(decodeDiff().CPUHotplugged).To(BeTrue())
+		})
+
+		DescribeTable("should leave the CPU of the VMI to the next restart", func(maxSockets uint32, phase v1.VirtualMachineInstancePhase) {
+			currentGuest = 4
+			withRunningVMI(maxSockets)
+			vmi.Status.Phase = phase
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+			vmClient.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, []byte(upgradePatch), &metav1.PatchOptions{}).Return(vm, nil)
+			vmiClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			app.InstancetypeUpgradeVMRequestHandler(request, response)
+			Expect(decodeDiff().CPUHotplugged).To(BeFalse())
+		},
+			Entry("without room for more sockets", uint32(0), v1.Running),
+			Entry("with more sockets than its maximum", uint32(3), v1.Running),
+			Entry("when the VMI is not running", uint32(8), v1.Scheduling),
+		)
+
+		It("should not upgrade the VM while another CPU hotplug is in progress", func() {
+			currentGuest = 4
+			withRunningVMI(8)
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+				Type:   v1.VirtualMachineInstanceVCPUChange,
+				Status: k8sv1.ConditionTrue,
+			}}
+			vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+			vmClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			app.InstancetypeUpgradeVMRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+	})
+})
@@ -167,6 +167,30 @@ func (app *SubresourceAPIApp) putRequestHandler(request *restful.Request, respon
 	}
 }
 
+// putGuestHooksRequestHandler forwards a request that runs guest hooks of the vmi,
+// virt-handler is given the time the hooks may take on top of the usual timeout
+func (app *SubresourceAPIApp) putGuestHooksRequestHandler(request *restful.Request, response *restful.Response, validate validation, getVirtHandlerURL URLResolver, hooksTimeout func(*v1.VirtualMachineInstance) time.Duration) {
+	vmi, url, _, statusErr := app.prepareConnection(request, validate, getVirtHandlerURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	httpClient := *app.handlerHttpClient
+	httpClient.Timeout += hooksTimeout(vmi)
+	conn := kubecli.NewVirtHandlerClient(app.virtCli, &httpClient).Port(app.consoleServerPort).ForNode(vmi.Status.NodeName)
+	if err := conn.Put(url, request.Request.Body); err != nil {
+		// the hooks aborted the request, retrying it would not help
+		var handlerErr *kubecli.VirtHandlerResponseError
+		if goerror.As(err, &handlerErr) && handlerErr.StatusCode == http.StatusUnprocessableEntity {
+			writeError(kutil.NewFreezeAbortedError(handlerErr.Body), response)
+			return
+		}
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+}
+
 func (app *SubresourceAPIApp) httpGetRequestHandler(request *restful.Request, response *restful.Response, validate validation, getURL URLResolver, v interface{}) {
 	_, url, conn, err := app.prepareConnection(request, validate, getURL)
 	if err != nil {
@@ -793,7 +817,7 @@ func (app *SubresourceAPIApp) FreezeVMIRequestHandler(request *restful.Request,
 		return conn.FreezeURI(vmi)
 	}
 
-	app.putRequestHandler(request, response, validate, getURL, false)
+	app.putGuestHooksRequestHandler(request, response, validate, getURL, kutil.FreezeTimeout)
 }
 
 func (app *SubresourceAPIApp) UnfreezeVMIRequestHandler(request *restful.Request, response *restful.Response) {
@@ -807,7 +831,7 @@ func (app *SubresourceAPIApp) UnfreezeVMIRequestHandler(request *restful.Request
 	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 		return conn.UnfreezeURI(vmi)
 	}
-	app.putRequestHandler(request, response, validate, getURL, false)
+	app.putGuestHooksRequestHandler(request, response, validate, getURL, kutil.UnfreezeTimeout)
 
 }
 
@@ -49,6 +49,7 @@ import (
 	"kubevirt.io/client-go/api"
 	"kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
+	kutil "kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/status"
 
 	k8sv1 "k8s.io/api/core/v1"
@@ -1998,6 +1999,23 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			Expect(response.StatusCode()).To(Equal(http.StatusOK))
 		})
 
+		It("Should fail with a freeze aborted error when a pre-freeze hook aborts the freeze", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/freeze"),
+					ghttp.RespondWith(http.StatusUnprocessableEntity, "pre-freeze hook flush failed: exited with error code:1"),
+				),
+			)
+
+			expectVMI(Running, UnPaused)
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusUnprocessableEntity)
+			Expect(kutil.IsFreezeAborted(statusErr)).To(BeTrue())
+			Expect(statusErr.ErrStatus.Message).To(Equal("pre-freeze hook flush failed: exited with error code:1"))
+		})
+
 		It("Should fail freezing a not running VMI", func() {
 
 			expectVMI(NotRunning, UnPaused)
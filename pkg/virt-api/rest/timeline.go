@@ -0,0 +1,97 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/emicklei/go-restful/v3"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/timeline"
+)
+
+// TimelineVMRequestHandler merges the timeline persisted on the VM with the entries of the
+// current VMI and the Kubernetes events of both.
+func (app *SubresourceAPIApp) TimelineVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	var vmi *v1.VirtualMachineInstance
+	if vm.Status.Created {
+		vmi, statusErr = app.FetchVirtualMachineInstance(namespace, name)
+		if statusErr != nil && !errors.IsNotFound(statusErr) {
+			writeError(statusErr, response)
+			return
+		}
+	}
+
+	events, err := app.virtCli.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.name", name).String(),
+	})
+	if err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("unable to list the events of vm [%s]: %v", name, err)), response)
+		return
+	}
+
+	result := &v1.VirtualMachineTimeline{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "VirtualMachineTimeline",
+		},
+		Entries: timeline.Merge(vm.Status.Timeline, timeline.FromVMI(vmi), timeline.FromEvents(ownEvents(vm, events.Items))),
+	}
+	if result.Entries == nil {
+		result.Entries = []v1.VirtualMachineTimelineEntry{}
+	}
+
+	if err := response.WriteEntity(result); err != nil {
+		log.Log.Reason(err).Error("Failed to write http response.")
+	}
+}
+
+// ownEvents filters the events of the VM and of its VMIs, other kinds of objects may share the name.
+func ownEvents(vm *v1.VirtualMachine, events []k8sv1.Event) []k8sv1.Event {
+	var result []k8sv1.Event
+	for _, event := range events {
+		switch event.InvolvedObject.Kind {
+		case v1.VirtualMachineGroupVersionKind.Kind:
+			if event.InvolvedObject.UID == vm.UID {
+				result = append(result, event)
+			}
+		case v1.VirtualMachineInstanceGroupVersionKind.Kind:
+			result = append(result, event)
+		}
+	}
+	return result
+}
@@ -0,0 +1,165 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/timeline"
+)
+
+var _ = Describe("VirtualMachine timeline subresource", func() {
+	const (
+		vmName      = "test-vm"
+		vmNamespace = "test-namespace"
+	)
+
+	var (
+		vmClient   *kubecli.MockVirtualMachineInterface
+		vmiClient  *kubecli.MockVirtualMachineInstanceInterface
+		virtClient *kubecli.MockKubevirtClient
+		app        *SubresourceAPIApp
+
+		request  *restful.Request
+		recorder *httptest.ResponseRecorder
+		response *restful.Response
+
+		start time.Time
+		vm    *v1.VirtualMachine
+	)
+
+	at := func(seconds int) metav1.Time {
+		return metav1.NewTime(start.Add(time.Duration(seconds) * time.Second))
+	}
+
+	newEvent := func(name, kind, uid, reason string, timestamp metav1.Time) *k8sv1.Event {
+		return &k8sv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: vmNamespace},
+			InvolvedObject: k8sv1.ObjectReference{
+				Kind:      kind,
+				Name:      vmName,
+				Namespace: vmNamespace,
+				UID:       types.UID(uid),
+			},
+			Type:          k8sv1.EventTypeNormal,
+			Reason:        reason,
+			LastTimestamp: timestamp,
+		}
+	}
+
+	reasons := func(entries []v1.VirtualMachineTimelineEntry) []string {
+		var result []string
+		for _, entry := range entries {
+			result = append(result, entry.Reason)
+		}
+		return result
+	}
+
+	callTimelineApi := func(events ...*k8sv1.Event) *v1.VirtualMachineTimeline {
+		kubeClient := k8sfake.NewSimpleClientset()
+		for _, event := range events {
+			_, err := kubeClient.CoreV1().Events(vmNamespace).Create(context.Background(), event, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+
+		app.TimelineVMRequestHandler(request, response)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		result := &v1.VirtualMachineTimeline{}
+		Expect(json.NewDecoder(recorder.Body).Decode(result)).To(Succeed())
+		return result
+	}
+
+	BeforeEach(func() {
+		start = time.Now().Truncate(time.Second)
+
+		ctrl := gomock.NewController(GinkgoT())
+		vmClient = kubecli.NewMockVirtualMachineInterface(ctrl)
+		vmiClient = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		virtClient.EXPECT().VirtualMachine(vmNamespace).Return(vmClient).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstance(vmNamespace).Return(vmiClient).AnyTimes()
+
+		app = NewSubresourceAPIApp(virtClient, 0, nil, nil)
+
+		request = restful.NewRequest(&http.Request{})
+		request.PathParameters()["name"] = vmName
+		request.PathParameters()["namespace"] = vmNamespace
+		recorder = httptest.NewRecorder()
+		response = restful.NewResponse(recorder)
+		response.SetRequestAccepts(restful.MIME_JSON)
+
+		vm = &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: vmName, Namespace: vmNamespace, UID: "test-uid"},
+		}
+	})
+
+	It("should fail if the VM does not exist", func() {
+		vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(nil, errors.NewNotFound(v1.Resource("virtualmachine"), vmName))
+
+		app.TimelineVMRequestHandler(request, response)
+		ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+	})
+
+	It("should return an empty timeline for a VM which never ran", func() {
+		vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+
+		result := callTimelineApi()
+		Expect(result.Kind).To(Equal("VirtualMachineTimeline"))
+		Expect(result.Entries).To(BeEmpty())
+	})
+
+	It("should merge the persisted timeline, the running VMI and the events", func() {
+		vm.Status.Created = true
+		vm.Status.Timeline = []v1.VirtualMachineTimelineEntry{
+			{Timestamp: at(0), Source: v1.VirtualMachineTimelineSourcePhase, Reason: string(v1.Pending), VMIUID: "old-vmi"},
+			{Timestamp: at(2), Source: v1.VirtualMachineTimelineSourcePhase, Reason: string(v1.Failed), VMIUID: "old-vmi"},
+		}
+		vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+
+		vmi := &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: vmName, Namespace: vmNamespace, UID: "new-vmi"}}
+		vmi.Status.PhaseTransitionTimestamps = []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+			{Phase: v1.Pending, PhaseTransitionTimestamp: at(10)},
+			{Phase: v1.Running, PhaseTransitionTimestamp: at(20)},
+		}
+		vmiClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vmi, nil)
+
+		result := callTimelineApi(
+			newEvent("created", "VirtualMachine", "test-uid", "SuccessfulCreate", at(1)),
+			newEvent("domain", "VirtualMachineInstance", "new-vmi", timeline.DomainLifecycleReason, at(15)),
+			newEvent("pod", "Pod", "pod-uid", "Scheduled", at(12)),
+		)
+		Expect(reasons(result.Entries)).To(Equal([]string{
+			string(v1.Pending), "SuccessfulCreate", string(v1.Failed), string(v1.Pending), timeline.DomainLifecycleReason, string(v1.Running),
+		}))
+		Expect(result.Entries[4].Source).To(Equal(v1.VirtualMachineTimelineSourceDomain))
+	})
+
+	It("should return the persisted timeline if the VMI is already gone", func() {
+		vm.Status.Created = true
+		vm.Status.Timeline = []v1.VirtualMachineTimelineEntry{
+			{Timestamp: at(0), Source: v1.VirtualMachineTimelineSourcePhase, Reason: string(v1.Pending), VMIUID: "old-vmi"},
+		}
+		vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+		vmiClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), vmName))
+
+		result := callTimelineApi()
+		Expect(reasons(result.Entries)).To(Equal([]string{string(v1.Pending)}))
+	})
+})
@@ -35,6 +35,7 @@ import (
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	"kubevirt.io/kubevirt/pkg/util"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -113,6 +114,10 @@ func (mutator *VMIsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1
 			util.MarkAsNonroot(newVMI)
 		}
 
+		if tracing.Enabled() {
+			startTrace(newVMI)
+		}
+
 		var value interface{}
 		value = newVMI.Spec
 		patchOps = append(patchOps, patch.PatchOperation{
@@ -183,3 +188,14 @@ func addNodeSelector(vmi *v1.VirtualMachineInstance, label string) {
 	}
 	vmi.Spec.NodeSelector[label] = ""
 }
+
+// startTrace starts the lifecycle trace of the VMI, unless the client already passed its own trace
+// context. The root span is recorded by virt-controller once the VMI started, virt-api only records
+// the admission.
+func startTrace(vmi *v1.VirtualMachineInstance) {
+	if !tracing.FromObject(vmi).IsValid() {
+		tracing.SetObjectSpanContext(vmi, tracing.NewRootSpanContext())
+	}
+	span := tracing.StartSpanFromObject(vmi, "virt-api.MutateVirtualMachineInstance")
+	span.End()
+}
@@ -22,6 +22,7 @@ package mutators
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	rt "runtime"
 
 	"k8s.io/utils/pointer"
@@ -43,6 +44,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	nodelabellerutil "kubevirt.io/kubevirt/pkg/virt-handler/node-labeller/util"
@@ -1047,6 +1049,36 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		Expect(status.RuntimeUser).NotTo(BeZero())
 	})
 
+	Context("with tracing", func() {
+		It("should start a new trace when tracing is enabled", func() {
+			tracing.Configure("virt-api", &v1.TracingConfiguration{
+				File: &v1.TracingFileExporter{Path: filepath.Join(GinkgoT().TempDir(), "spans.json")},
+			})
+			DeferCleanup(tracing.Shutdown)
+
+			vmiMeta, _, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
+			Expect(vmiMeta.Annotations).To(HaveKey(v1.TraceparentAnnotation))
+			Expect(tracing.FromObject(vmiMeta).IsValid()).To(BeTrue())
+		})
+
+		It("should keep the trace context passed by the client", func() {
+			tracing.Configure("virt-api", &v1.TracingConfiguration{
+				File: &v1.TracingFileExporter{Path: filepath.Join(GinkgoT().TempDir(), "spans.json")},
+			})
+			DeferCleanup(tracing.Shutdown)
+
+			const traceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+			vmi.Annotations = map[string]string{v1.TraceparentAnnotation: traceparent}
+			vmiMeta, _, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
+			Expect(vmiMeta.Annotations).To(HaveKeyWithValue(v1.TraceparentAnnotation, traceparent))
+		})
+
+		It("should not start a trace when tracing is disabled", func() {
+			vmiMeta, _, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
+			Expect(vmiMeta.Annotations).ToNot(HaveKey(v1.TraceparentAnnotation))
+		})
+	})
+
 	It("should add realtime node label selector with realtime workload", func() {
 		vmi.Spec.Domain.CPU = &v1.CPU{Realtime: &v1.Realtime{}}
 		_, vmiSpec, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
@@ -22,9 +22,11 @@ package admitters
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -32,6 +34,7 @@ import (
 	exportv1 "kubevirt.io/api/export/v1alpha1"
 	"kubevirt.io/api/snapshot"
 
+	"kubevirt.io/kubevirt/pkg/storage/export/registry"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
@@ -98,6 +101,14 @@ func (admitter *VMExportAdmitter) Admit(ar *admissionv1.AdmissionReview) *admiss
 			}
 		}
 
+		if vmExport.Spec.ContainerDisk != nil {
+			causes = append(causes, admitter.validateContainerDisk(k8sfield.NewPath("spec", "containerDisk"), vmExport.Spec.ContainerDisk)...)
+		}
+
+		if vmExport.Spec.BandwidthLimit != nil {
+			causes = append(causes, admitter.validateBandwidthLimit(k8sfield.NewPath("spec", "bandwidthLimit"), vmExport.Spec.BandwidthLimit)...)
+		}
+
 	case admissionv1.Update:
 		prevObj := &exportv1.VirtualMachineExport{}
 		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
@@ -211,3 +222,56 @@ func (admitter *VMExportAdmitter) validateVMApiGroup(field *k8sfield.Path, apigr
 
 	return []metav1.StatusCause{}
 }
+
+func (admitter *VMExportAdmitter) validateContainerDisk(field *k8sfield.Path, target *exportv1.VirtualMachineExportContainerDiskTarget) []metav1.StatusCause {
+	if target.Repository == "" {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "containerDisk repository must not be empty",
+				Field:   field.Child("repository").String(),
+			},
+		}
+	}
+
+	// volumes are pushed as <repository>/<volume name>:<tag>, validate the reference a volume would get
+	ref := strings.TrimSuffix(target.Repository, "/") + "/disk"
+	if target.Tag != "" {
+		ref = ref + ":" + target.Tag
+	}
+	if _, err := registry.ParseReference(ref); err != nil {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("invalid containerDisk repository or tag: %v", err),
+				Field:   field.String(),
+			},
+		}
+	}
+
+	if target.SecretRef != nil && *target.SecretRef == "" {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "containerDisk secretRef must not be empty",
+				Field:   field.Child("secretRef").String(),
+			},
+		}
+	}
+
+	return []metav1.StatusCause{}
+}
+
+func (admitter *VMExportAdmitter) validateBandwidthLimit(field *k8sfield.Path, limit *resource.Quantity) []metav1.StatusCause {
+	if limit.Value() <= 0 {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("bandwidthLimit must be at least 1 byte per second, got %s", limit.String()),
+				Field:   field.String(),
+			},
+		}
+	}
+
+	return []metav1.StatusCause{}
+}
@@ -27,8 +27,10 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/api/core/v1"
 	exportv1 "kubevirt.io/api/export/v1alpha1"
@@ -256,6 +258,52 @@ var _ = Describe("Validating VirtualMachineExport Admitter", func() {
 			Entry("virtual machine snapshot", "invalid", vmSnapshotKind),
 			Entry("virtual machine", "invalid", vmKind),
 		)
+
+		DescribeTable("it should validate the containerDisk target", func(target *exportv1.VirtualMachineExportContainerDiskTarget, allowed bool) {
+			export := &exportv1.VirtualMachineExport{
+				Spec: exportv1.VirtualMachineExportSpec{
+					Source: corev1.TypedLocalObjectReference{
+						APIGroup: &kubevirtApiGroup,
+						Kind:     vmKind,
+						Name:     "test",
+					},
+					ContainerDisk: target,
+				},
+			}
+
+			ar := createExportAdmissionReview(export)
+			resp := createTestVMExportAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("with a repository", &exportv1.VirtualMachineExportContainerDiskTarget{Repository: "registry.example.com/vms"}, true),
+			Entry("with a registry and a tag", &exportv1.VirtualMachineExportContainerDiskTarget{Repository: "localhost:5000", Tag: "v1", SecretRef: pointer.String("regcred")}, true),
+			Entry("without a repository", &exportv1.VirtualMachineExportContainerDiskTarget{}, false),
+			Entry("without a registry host", &exportv1.VirtualMachineExportContainerDiskTarget{Repository: "vms"}, false),
+			Entry("with an invalid tag", &exportv1.VirtualMachineExportContainerDiskTarget{Repository: "registry.example.com/vms", Tag: "-v1"}, false),
+			Entry("with an empty secret", &exportv1.VirtualMachineExportContainerDiskTarget{Repository: "registry.example.com/vms", SecretRef: pointer.String("")}, false),
+		)
+
+		DescribeTable("it should validate the bandwidth limit", func(limit string, allowed bool) {
+			bandwidthLimit := resource.MustParse(limit)
+			export := &exportv1.VirtualMachineExport{
+				Spec: exportv1.VirtualMachineExportSpec{
+					Source: corev1.TypedLocalObjectReference{
+						APIGroup: &kubevirtApiGroup,
+						Kind:     vmKind,
+						Name:     "test",
+					},
+					BandwidthLimit: &bandwidthLimit,
+				},
+			}
+
+			ar := createExportAdmissionReview(export)
+			resp := createTestVMExportAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("with a positive limit", "100Mi", true),
+			Entry("with a zero limit", "0", false),
+			Entry("with a negative limit", "-1Mi", false),
+		)
 	})
 })
 
@@ -29,6 +29,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 
@@ -46,6 +47,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/hooks"
 	"kubevirt.io/kubevirt/pkg/network/link"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
+	"kubevirt.io/kubevirt/pkg/util"
 	hwutil "kubevirt.io/kubevirt/pkg/util/hardware"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -70,6 +72,9 @@ const (
 	maxDNSNameservers     = 3
 	maxDNSSearchPaths     = 6
 	maxDNSSearchListChars = 256
+
+	// cgroup v2 cpu.weight lower bound
+	minCPUWeight = 1
 )
 
 var validInterfaceModels = map[string]*struct{}{"e1000": nil, "e1000e": nil, "ne2k_pci": nil, "pcnet": nil, "rtl8139": nil, v1.VirtIO: nil}
@@ -154,7 +159,9 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateCpuRequestDoesNotExceedLimit(field, spec)...)
 	causes = append(causes, validateCpuPinning(field, spec, config)...)
 	causes = append(causes, validateNUMA(field, spec, config)...)
+	causes = append(causes, validateSoftCPUPlacement(field, spec, config)...)
 	causes = append(causes, validateCPUIsolatorThread(field, spec)...)
+	causes = append(causes, validateCPUWeight(field, spec, config)...)
 	causes = append(causes, validateCPUFeaturePolicies(field, spec)...)
 	causes = append(causes, validateCPUHotplug(field, spec)...)
 	causes = append(causes, validateStartStrategy(field, spec)...)
@@ -212,6 +219,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateContainerDisks(field, spec)...)
 
 	causes = append(causes, validateAccessCredentials(field.Child("accessCredentials"), spec.AccessCredentials, spec.Volumes)...)
+	causes = append(causes, validateFreezeHooks(field.Child("freezeHooks"), spec.FreezeHooks)...)
 
 	if spec.DNSPolicy != "" {
 		causes = append(causes, validateDNSPolicy(&spec.DNSPolicy, field.Child("dnsPolicy"))...)
@@ -1106,6 +1114,36 @@ func validateCPUIsolatorThread(field *k8sfield.Path, spec *v1.VirtualMachineInst
 			Field:   field.Child("domain", "cpu", "isolateEmulatorThread").String(),
 		})
 	}
+	if spec.Domain.CPU != nil && spec.Domain.CPU.EmulatorThreadPoolSize > 0 && !spec.Domain.CPU.IsolateEmulatorThread {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "EmulatorThreadPoolSize should be only set in combination with IsolateEmulatorThread",
+			Field:   field.Child("domain", "cpu", "emulatorThreadPoolSize").String(),
+		})
+	}
+	return causes
+}
+
+func validateCPUWeight(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.CPU == nil || spec.Domain.CPU.Weight == nil {
+		return causes
+	}
+	weightField := field.Child("domain", "cpu", "weight").String()
+	if !config.CPUWeightEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config, invalid entry %s", virtconfig.CPUWeightGate, weightField),
+			Field:   weightField,
+		})
+	}
+	maxWeight := config.GetMaxCPUWeight()
+	if weight := *spec.Domain.CPU.Weight; weight < minCPUWeight || weight > maxWeight {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be in the range of %d to %d", weightField, minCPUWeight, maxWeight),
+			Field:   weightField,
+		})
+	}
 	return causes
 }
 
@@ -1158,6 +1196,31 @@ func validateNUMA(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, con
 	return causes
 }
 
+func validateSoftCPUPlacement(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.CPU == nil || !spec.Domain.CPU.SoftCPUPlacement {
+		return causes
+	}
+	if !config.SoftCPUPlacementEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config, invalid entry %s",
+				virtconfig.SoftCPUPlacementGate, field.Child("domain", "cpu", "softCPUPlacement").String()),
+			Field: field.Child("domain", "cpu", "softCPUPlacement").String(),
+		})
+	}
+	if spec.Domain.CPU.DedicatedCPUPlacement {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s and %s are mutually exclusive",
+				field.Child("domain", "cpu", "softCPUPlacement").String(),
+				field.Child("domain", "cpu", "dedicatedCpuPlacement").String(),
+			),
+			Field: field.Child("domain", "cpu", "softCPUPlacement").String(),
+		})
+	}
+	return causes
+}
+
 func validateThreadCountOnArchitecture(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
 	arch := spec.Architecture
 	if arch == "" {
@@ -1663,6 +1726,23 @@ func ValidateVirtualMachineInstanceMandatoryFields(field *k8sfield.Path, spec *v
 	return causes
 }
 
+// validateRestoreMemoryStateAnnotation only lets KubeVirt set or change the restore memory state
+// annotation, since it makes the VMI load a saved memory state instead of booting
+func validateRestoreMemoryStateAnnotation(field *k8sfield.Path, newAnnotations, oldAnnotations map[string]string, accountName string) []metav1.StatusCause {
+	value, exists := newAnnotations[v1.RestoreMemoryStateAnnotation]
+	if !exists || webhooks.IsKubeVirtServiceAccount(accountName) {
+		return nil
+	}
+	if oldValue, oldExists := oldAnnotations[v1.RestoreMemoryStateAnnotation]; oldExists && oldValue == value {
+		return nil
+	}
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueNotSupported,
+		Message: fmt.Sprintf("the %s annotation is reserved for KubeVirt", v1.RestoreMemoryStateAnnotation),
+		Field:   field.Child("annotations", v1.RestoreMemoryStateAnnotation).String(),
+	}}
+}
+
 func ValidateVirtualMachineInstanceMetadata(field *k8sfield.Path, metadata *metav1.ObjectMeta, config *virtconfig.ClusterConfig, accountName string) []metav1.StatusCause {
 
 	var causes []metav1.StatusCause
@@ -1680,6 +1760,8 @@ func ValidateVirtualMachineInstanceMetadata(field *k8sfield.Path, metadata *meta
 		}
 	}
 
+	causes = append(causes, validateRestoreMemoryStateAnnotation(field, annotations, nil, accountName)...)
+
 	// Validate ignition feature gate if set when the corresponding annotation is found
 	if annotations[v1.IgnitionAnnotation] != "" && !config.IgnitionEnabled() {
 		causes = append(causes, metav1.StatusCause{
@@ -1874,6 +1956,77 @@ func validateDomainSpec(field *k8sfield.Path, spec *v1.DomainSpec) []metav1.Stat
 	return causes
 }
 
+func validateFreezeHooks(field *k8sfield.Path, freezeHooks *v1.FreezeHooks) []metav1.StatusCause {
+	if freezeHooks == nil {
+		return nil
+	}
+
+	var causes []metav1.StatusCause
+	validateHooks := func(field *k8sfield.Path, hooks []v1.GuestHook) {
+		names := map[string]struct{}{}
+		for idx, hook := range hooks {
+			hookField := field.Index(idx)
+			// hook names are recorded as a comma separated list
+			for _, msg := range validation.IsDNS1123Label(hook.Name) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s has an invalid name: %s", hookField.String(), msg),
+					Field:   hookField.Child("name").String(),
+				})
+			}
+			if _, exists := names[hook.Name]; exists {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueDuplicate,
+					Message: fmt.Sprintf("%s name %q is not unique", hookField.String(), hook.Name),
+					Field:   hookField.Child("name").String(),
+				})
+			}
+			names[hook.Name] = struct{}{}
+
+			if len(hook.Command) == 0 || hook.Command[0] == "" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueRequired,
+					Message: fmt.Sprintf("%s must have a command", hookField.String()),
+					Field:   hookField.Child("command").String(),
+				})
+			}
+
+			if hook.TimeoutSeconds != nil && *hook.TimeoutSeconds <= 0 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s timeoutSeconds must be greater than 0", hookField.String()),
+					Field:   hookField.Child("timeoutSeconds").String(),
+				})
+			}
+
+			switch hook.FailurePolicy {
+			case "", v1.GuestHookFailurePolicyAbort, v1.GuestHookFailurePolicyContinue:
+			default:
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s failurePolicy %q is not supported", hookField.String(), hook.FailurePolicy),
+					Field:   hookField.Child("failurePolicy").String(),
+				})
+			}
+		}
+	}
+	validateHooks(field.Child("preFreeze"), freezeHooks.PreFreeze)
+	validateHooks(field.Child("postThaw"), freezeHooks.PostThaw)
+
+	// The hooks run as part of the freeze and unfreeze requests and must
+	// not outlast them
+	maxTimeout := time.Duration(v1.MaxFreezeHooksTimeoutSeconds) * time.Second
+	if timeout := util.GuestHooksTimeout(freezeHooks.PreFreeze) + util.GuestHooksTimeout(freezeHooks.PostThaw); timeout > maxTimeout {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s timeouts add up to %s, which exceeds the maximum of %s", field.String(), timeout, maxTimeout),
+			Field:   field.String(),
+		})
+	}
+
+	return causes
+}
+
 func validateAccessCredentials(field *k8sfield.Path, accessCredentials []v1.AccessCredential, volumes []v1.Volume) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 
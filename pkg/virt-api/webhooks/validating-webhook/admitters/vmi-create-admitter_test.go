@@ -481,6 +481,23 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			),
 		)
 
+		DescribeTable("should validate the restore memory state annotation", func(accountName string, expectedCauses int) {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.ObjectMeta = metav1.ObjectMeta{
+				Annotations: map[string]string{v1.RestoreMemoryStateAnnotation: "memory-state/memory.state"},
+			}
+
+			causes := ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, config, accountName)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueNotSupported))
+				Expect(causes[0].Field).To(Equal(fmt.Sprintf("metadata.annotations.%s", v1.RestoreMemoryStateAnnotation)))
+			}
+		},
+			Entry("and reject it when set by a user", "system:serviceaccount:someNamespace:someUser", 1),
+			Entry("and accept it when set by KubeVirt", "system:serviceaccount:kubevirt:"+components.ControllerServiceAccountName, 0),
+		)
+
 		DescribeTable("should accept annotations which require feature gate enabled", func(annotations map[string]string, featureGate string) {
 			enableFeatureGate(featureGate)
 			vmi := api.NewMinimalVMI("testvmi")
@@ -2543,6 +2560,32 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(HaveLen(1))
 			Expect(causes[0].Field).To(Equal("fake.domain.cpu.dedicatedCpuPlacement"))
 		})
+		Context("with soft cpu placement", func() {
+			BeforeEach(func() {
+				vmi.Spec.Domain.CPU = &v1.CPU{SoftCPUPlacement: true}
+			})
+			It("should reject SoftCPUPlacement without the feature gate", func() {
+				disableFeatureGates()
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.cpu.softCPUPlacement"))
+				Expect(causes[0].Message).To(ContainSubstring("SoftCPUPlacement feature gate"))
+			})
+			It("should accept SoftCPUPlacement with the feature gate", func() {
+				enableFeatureGate(virtconfig.SoftCPUPlacementGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+			It("should reject SoftCPUPlacement combined with DedicatedCPUPlacement", func() {
+				enableFeatureGate(virtconfig.SoftCPUPlacementGate)
+				vmi.Spec.Domain.CPU.DedicatedCPUPlacement = true
+				vmi.Spec.Domain.CPU.Cores = 2
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.cpu.softCPUPlacement"))
+				Expect(causes[0].Message).To(ContainSubstring("mutually exclusive"))
+			})
+		})
 		It("should reject specs with IsolateEmulatorThread without DedicatedCPUPlacement set", func() {
 
 			vmi.Spec.Domain.CPU = &v1.CPU{
@@ -2553,6 +2596,48 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(HaveLen(1))
 			Expect(causes[0].Field).To(Equal("fake.domain.cpu.isolateEmulatorThread"))
 		})
+		It("should reject specs with EmulatorThreadPoolSize without IsolateEmulatorThread set", func() {
+			vmi.Spec.Domain.CPU.Cores = 2
+			vmi.Spec.Domain.CPU.EmulatorThreadPoolSize = 2
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.emulatorThreadPoolSize"))
+		})
+		It("should accept specs with an emulator thread pool", func() {
+			vmi.Spec.Domain.CPU.Cores = 2
+			vmi.Spec.Domain.CPU.IsolateEmulatorThread = true
+			vmi.Spec.Domain.CPU.EmulatorThreadPoolSize = 2
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		DescribeTable("should validate the cpu weight", func(weight uint64, maxWeight *uint64, expectedCauses int) {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.CPUWeightGate}
+			kvConfig.Spec.Configuration.MaxCPUWeight = maxWeight
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			vmi.Spec.Domain.CPU = &v1.CPU{Weight: &weight}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake.domain.cpu.weight"))
+			}
+		},
+			Entry("with the minimum weight", uint64(1), nil, 0),
+			Entry("with the default maximum weight", uint64(virtconfig.DefaultMaxCPUWeight), nil, 0),
+			Entry("with a zero weight", uint64(0), nil, 1),
+			Entry("with a weight above the default maximum", uint64(virtconfig.DefaultMaxCPUWeight+1), nil, 1),
+			Entry("with a weight up to the configured maximum", uint64(10000), pointer.Uint64(10000), 0),
+			Entry("with a weight above the configured maximum", uint64(501), pointer.Uint64(500), 1),
+		)
+		It("should reject the cpu weight without the feature gate", func() {
+			weight := uint64(1)
+			vmi.Spec.Domain.CPU = &v1.CPU{Weight: &weight}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.weight"))
+			Expect(causes[0].Message).To(ContainSubstring("CPUWeight feature gate"))
+		})
 		It("should reject specs without inconsistent cpu reqirements", func() {
 			vmi.Spec.Domain.CPU.Cores = 4
 			vmi.Spec.Domain.Resources.Limits = k8sv1.ResourceList{
@@ -2829,6 +2914,69 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		})
 	})
 
+	Context("with freeze hooks", func() {
+		It("should accept valid freeze hooks", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.FreezeHooks = &v1.FreezeHooks{
+				PreFreeze: []v1.GuestHook{
+					{Name: "flush", Command: []string{"/usr/bin/flush", "--all"}, TimeoutSeconds: pointer.Int32(10)},
+					{Name: "lock", Command: []string{"/usr/bin/lock"}, FailurePolicy: v1.GuestHookFailurePolicyContinue},
+				},
+				PostThaw: []v1.GuestHook{
+					{Name: "flush", Command: []string{"/usr/bin/unlock"}, FailurePolicy: v1.GuestHookFailurePolicyAbort},
+				},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		DescribeTable("should reject a pre-freeze hook with", func(hook v1.GuestHook, expectedField string) {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.FreezeHooks = &v1.FreezeHooks{
+				PreFreeze: []v1.GuestHook{hook},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(expectedField))
+		},
+			Entry("an invalid name", v1.GuestHook{Name: "Not_Valid", Command: []string{"/bin/true"}}, "fake.freezeHooks.preFreeze[0].name"),
+			Entry("no command", v1.GuestHook{Name: "hook"}, "fake.freezeHooks.preFreeze[0].command"),
+			Entry("an empty command", v1.GuestHook{Name: "hook", Command: []string{""}}, "fake.freezeHooks.preFreeze[0].command"),
+			Entry("a zero timeout", v1.GuestHook{Name: "hook", Command: []string{"/bin/true"}, TimeoutSeconds: pointer.Int32(0)}, "fake.freezeHooks.preFreeze[0].timeoutSeconds"),
+			Entry("an unknown failure policy", v1.GuestHook{Name: "hook", Command: []string{"/bin/true"}, FailurePolicy: "Retry"}, "fake.freezeHooks.preFreeze[0].failurePolicy"),
+		)
+
+		It("should reject post-thaw hooks with duplicate names", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.FreezeHooks = &v1.FreezeHooks{
+				PostThaw: []v1.GuestHook{
+					{Name: "hook", Command: []string{"/bin/true"}},
+					{Name: "hook", Command: []string{"/bin/false"}},
+				},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueDuplicate))
+			Expect(causes[0].Field).To(Equal("fake.freezeHooks.postThaw[1].name"))
+		})
+
+		It("should reject freeze hooks that may outlast the freeze request", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.FreezeHooks = &v1.FreezeHooks{
+				PreFreeze: []v1.GuestHook{
+					{Name: "flush", Command: []string{"/usr/bin/flush"}, TimeoutSeconds: pointer.Int32(35)},
+				},
+				PostThaw: []v1.GuestHook{
+					{Name: "unlock", Command: []string{"/usr/bin/unlock"}},
+				},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.freezeHooks"))
+			Expect(causes[0].Message).To(ContainSubstring("45s"))
+		})
+	})
+
 	Context("with CPU features", func() {
 		It("should accept valid CPU feature policies", func() {
 			vmi := api.NewMinimalVMI("testvm")
@@ -72,6 +72,10 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 		return reviewResponse
 	}
 
+	if causes := validateRestoreMemoryStateAnnotation(k8sfield.NewPath("metadata"), newVMI.Annotations, oldVMI.Annotations, ar.Request.UserInfo.Username); len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
 	reviewResponse := admissionv1.AdmissionResponse{}
 	reviewResponse.Allowed = true
 	return &reviewResponse
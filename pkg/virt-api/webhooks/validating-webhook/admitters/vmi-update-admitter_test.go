@@ -565,6 +565,44 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		Entry("Should reject regular user", "system:serviceaccount:someNamespace:someUser", BeFalse()),
 	)
 
+	DescribeTable("Updates of the restore memory state annotation", func(oldAnnotations, newAnnotations map[string]string, expected types.GomegaMatcher) {
+		vmi := api.NewMinimalVMI("testvmi")
+		vmi.Annotations = oldAnnotations
+		updateVmi := vmi.DeepCopy()
+		updateVmi.Annotations = newAnnotations
+
+		newVMIBytes, _ := json.Marshal(&updateVmi)
+		oldVMIBytes, _ := json.Marshal(&vmi)
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: "system:serviceaccount:someNamespace:someUser"},
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: admissionv1.Update,
+			},
+		}
+		resp := vmiUpdateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(expected)
+	},
+		Entry("deny a user setting it",
+			nil,
+			map[string]string{v1.RestoreMemoryStateAnnotation: "memory-state/memory.state"},
+			BeFalse()),
+		Entry("deny a user changing it",
+			map[string]string{v1.RestoreMemoryStateAnnotation: "memory-state/memory.state"},
+			map[string]string{v1.RestoreMemoryStateAnnotation: "memory-state/../../etc/passwd"},
+			BeFalse()),
+		Entry("allow a user removing it",
+			map[string]string{v1.RestoreMemoryStateAnnotation: "memory-state/memory.state"},
+			nil,
+			BeTrue()),
+	)
+
 	DescribeTable("Updates in CPU topology", func(oldCPUTopology, newCPUTopology *v1.CPU, expected types.GomegaMatcher) {
 		vmi := api.NewMinimalVMI("testvmi")
 		updateVmi := vmi.DeepCopy()
@@ -30,6 +30,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/cache"
 
@@ -153,6 +155,20 @@ func (admitter *VMRestoreAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 
 		causes = append(causes, snapshotCauses...)
 
+		volumeCauses, err := admitter.validateVolumeSelection(k8sfield.NewPath("spec"), ar.Request.Namespace, vmRestore, targetVMExists)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+
+		causes = append(causes, volumeCauses...)
+
+		newVMCauses, err := admitter.validateNewVirtualMachine(k8sfield.NewPath("spec", "newVirtualMachine"), ar.Request.Namespace, vmRestore, targetVMExists)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+
+		causes = append(causes, newVMCauses...)
+
 	case admissionv1.Update:
 		prevObj := &snapshotv1.VirtualMachineRestore{}
 		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
@@ -204,7 +220,8 @@ func (admitter *VMRestoreAdmitter) validateCreateVM(field *k8sfield.Path, vmRest
 		return nil, nil, true, err
 	}
 
-	if rs != v1.RunStrategyHalted {
+	// restoring the volumes only does not touch the target
+	if rs != v1.RunStrategyHalted && vmRestore.Spec.Mode != snapshotv1.VirtualMachineRestoreVolumesOnly {
 		var cause metav1.StatusCause
 		targetField := field.Child("target")
 		if vm.Spec.Running != nil && *vm.Spec.Running {
@@ -311,3 +328,198 @@ func (admitter *VMRestoreAdmitter) validateSnapshot(field *k8sfield.Path, namesp
 
 	return causes, nil
 }
+
+func (admitter *VMRestoreAdmitter) validateVolumeSelection(field *k8sfield.Path, namespace string, vmRestore *snapshotv1.VirtualMachineRestore, targetVMExists bool) ([]metav1.StatusCause, error) {
+	var causes []metav1.StatusCause
+	spec := vmRestore.Spec
+	volumesSelected := len(spec.IncludeVolumes) > 0 || len(spec.ExcludeVolumes) > 0
+
+	switch spec.Mode {
+	case "", snapshotv1.VirtualMachineRestoreFull, snapshotv1.VirtualMachineRestoreVolumesOnly:
+	case snapshotv1.VirtualMachineRestoreSpecOnly:
+		if volumesSelected {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volumes can not be selected with mode %s", spec.Mode),
+				Field:   field.Child("mode").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("invalid mode %q", spec.Mode),
+			Field:   field.Child("mode").String(),
+		})
+	}
+
+	if len(spec.IncludeVolumes) > 0 && len(spec.ExcludeVolumes) > 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "includeVolumes and excludeVolumes are mutually exclusive",
+			Field:   field.Child("excludeVolumes").String(),
+		})
+	}
+
+	// The volumes that are not restored keep the disks of the target
+	partial := spec.Mode == snapshotv1.VirtualMachineRestoreSpecOnly ||
+		(spec.Mode != snapshotv1.VirtualMachineRestoreVolumesOnly && volumesSelected)
+	if partial && !targetVMExists {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "restoring part of a VirtualMachineSnapshot requires an existing target VirtualMachine",
+			Field:   field.Child("target").String(),
+		})
+	}
+
+	if !volumesSelected {
+		return causes, nil
+	}
+
+	backups, err := admitter.getVolumeBackups(namespace, spec.VirtualMachineSnapshotName)
+	if err != nil {
+		return nil, err
+	}
+	if backups == nil {
+		return causes, nil
+	}
+
+	validateNames := func(names []string, field *k8sfield.Path) {
+		for i, name := range names {
+			if !backups.Has(name) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("VirtualMachineSnapshot %q has no backup of volume %q", spec.VirtualMachineSnapshotName, name),
+					Field:   field.Index(i).String(),
+				})
+			}
+		}
+	}
+	validateNames(spec.IncludeVolumes, field.Child("includeVolumes"))
+	validateNames(spec.ExcludeVolumes, field.Child("excludeVolumes"))
+
+	return causes, nil
+}
+
+func (admitter *VMRestoreAdmitter) validateNewVirtualMachine(field *k8sfield.Path, namespace string, vmRestore *snapshotv1.VirtualMachineRestore, targetVMExists bool) ([]metav1.StatusCause, error) {
+	options := vmRestore.Spec.NewVirtualMachine
+	if options == nil {
+		return nil, nil
+	}
+
+	if targetVMExists {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("VirtualMachine %q already exists", vmRestore.Spec.Target.Name),
+				Field:   field.String(),
+			},
+		}, nil
+	}
+
+	var causes []metav1.StatusCause
+	newNames := sets.NewString()
+	for volumeName, newName := range options.NewVolumeNames {
+		volumeField := field.Child("newVolumeNames").Key(volumeName)
+		for _, msg := range validation.IsDNS1123Subdomain(newName) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("invalid name %q: %s", newName, msg),
+				Field:   volumeField.String(),
+			})
+		}
+		if newNames.Has(newName) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("name %q is used for more than one volume", newName),
+				Field:   volumeField.String(),
+			})
+		}
+		newNames.Insert(newName)
+
+		inUse, err := admitter.volumeNameInUse(namespace, newName)
+		if err != nil {
+			return nil, err
+		}
+		if inUse {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("PersistentVolumeClaim or DataVolume %q already exists", newName),
+				Field:   volumeField.String(),
+			})
+		}
+	}
+
+	if len(options.NewVolumeNames) == 0 {
+		return causes, nil
+	}
+
+	backups, err := admitter.getVolumeBackups(namespace, vmRestore.Spec.VirtualMachineSnapshotName)
+	if err != nil {
+		return nil, err
+	}
+	if backups == nil {
+		return causes, nil
+	}
+
+	for volumeName := range options.NewVolumeNames {
+		if !backups.Has(volumeName) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("VirtualMachineSnapshot %q has no backup of volume %q", vmRestore.Spec.VirtualMachineSnapshotName, volumeName),
+				Field:   field.Child("newVolumeNames").Key(volumeName).String(),
+			})
+		}
+	}
+
+	return causes, nil
+}
+
+// volumeNameInUse returns true if a PersistentVolumeClaim or a DataVolume with the given name exists
+func (admitter *VMRestoreAdmitter) volumeNameInUse(namespace, name string) (bool, error) {
+	_, err := admitter.Client.CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err == nil {
+		return true, nil
+	}
+	if !errors.IsNotFound(err) {
+		return false, err
+	}
+
+	_, err = admitter.Client.CdiClient().CdiV1beta1().DataVolumes(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err == nil {
+		return true, nil
+	}
+	if !errors.IsNotFound(err) {
+		return false, err
+	}
+	return false, nil
+}
+
+// getVolumeBackups returns the names of the volumes backed up by a snapshot,
+// or nil if its content can not be found, which is reported when validating the snapshot
+func (admitter *VMRestoreAdmitter) getVolumeBackups(namespace, snapshotName string) (sets.String, error) {
+	snapshot, err := admitter.Client.VirtualMachineSnapshot(namespace).Get(context.Background(), snapshotName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.Status == nil || snapshot.Status.VirtualMachineSnapshotContentName == nil {
+		return nil, nil
+	}
+
+	content, err := admitter.Client.VirtualMachineSnapshotContent(namespace).Get(context.Background(), *snapshot.Status.VirtualMachineSnapshotContentName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backups := sets.NewString()
+	for _, vb := range content.Spec.VolumeBackups {
+		backups.Insert(vb.VolumeName)
+	}
+	return backups, nil
+}
@@ -35,11 +35,15 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/api/core/v1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	cdifake "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned/fake"
 	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -606,6 +610,162 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 				})
 			})
 
+			Context("with volume backups", func() {
+				const contentName = "snapshot-content"
+
+				var (
+					restore  *snapshotv1.VirtualMachineRestore
+					snapshot *snapshotv1.VirtualMachineSnapshot
+					content  *snapshotv1.VirtualMachineSnapshotContent
+				)
+
+				BeforeEach(func() {
+					restore = &snapshotv1.VirtualMachineRestore{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "restore",
+							Namespace: "default",
+						},
+						Spec: snapshotv1.VirtualMachineRestoreSpec{
+							Target: corev1.TypedLocalObjectReference{
+								APIGroup: &apiGroup,
+								Kind:     "VirtualMachine",
+								Name:     vmName,
+							},
+							VirtualMachineSnapshotName: vmSnapshotName,
+						},
+					}
+					snapshot = &snapshotv1.VirtualMachineSnapshot{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      vmSnapshotName,
+							Namespace: "default",
+						},
+						Status: &snapshotv1.VirtualMachineSnapshotStatus{
+							SourceUID:                         &vmUID,
+							ReadyToUse:                        &t,
+							VirtualMachineSnapshotContentName: pointer.String(contentName),
+						},
+					}
+					content = &snapshotv1.VirtualMachineSnapshotContent{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      contentName,
+							Namespace: "default",
+						},
+						Spec: snapshotv1.VirtualMachineSnapshotContentSpec{
+							VolumeBackups: []snapshotv1.VolumeBackup{
+								{VolumeName: "disk1"},
+								{VolumeName: "disk2"},
+							},
+						},
+					}
+				})
+
+				DescribeTable("should allow", func(mode snapshotv1.VirtualMachineRestoreMode, include, exclude []string) {
+					restore.Spec.Mode = mode
+					restore.Spec.IncludeVolumes = include
+					restore.Spec.ExcludeVolumes = exclude
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshot, content).Admit(ar)
+					Expect(resp.Allowed).To(BeTrue())
+				},
+					Entry("included volumes", snapshotv1.VirtualMachineRestoreFull, []string{"disk1"}, nil),
+					Entry("excluded volumes", snapshotv1.VirtualMachineRestoreMode(""), nil, []string{"disk2"}),
+					Entry("spec only restore", snapshotv1.VirtualMachineRestoreSpecOnly, nil, nil),
+					Entry("volumes only restore", snapshotv1.VirtualMachineRestoreVolumesOnly, []string{"disk2"}, nil),
+				)
+
+				DescribeTable("should reject", func(mode snapshotv1.VirtualMachineRestoreMode, include, exclude []string, field string) {
+					restore.Spec.Mode = mode
+					restore.Spec.IncludeVolumes = include
+					restore.Spec.ExcludeVolumes = exclude
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshot, content).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+				},
+					Entry("an invalid mode", snapshotv1.VirtualMachineRestoreMode("Some"), nil, nil, "spec.mode"),
+					Entry("selecting volumes of a spec only restore", snapshotv1.VirtualMachineRestoreSpecOnly, []string{"disk1"}, nil, "spec.mode"),
+					Entry("both included and excluded volumes", snapshotv1.VirtualMachineRestoreFull, []string{"disk1"}, []string{"disk2"}, "spec.excludeVolumes"),
+					Entry("an unknown included volume", snapshotv1.VirtualMachineRestoreFull, []string{"disk1", "disk3"}, nil, "spec.includeVolumes[1]"),
+					Entry("an unknown excluded volume", snapshotv1.VirtualMachineRestoreFull, nil, []string{"disk3"}, "spec.excludeVolumes[0]"),
+				)
+
+				It("should reject a partial restore when the target does not exist", func() {
+					restore.Spec.ExcludeVolumes = []string{"disk2"}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshot, content).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.target"))
+				})
+
+				It("should reject new VirtualMachine options when the target exists", func() {
+					restore.Spec.NewVirtualMachine = &snapshotv1.NewVirtualMachineOptions{
+						NewSMBiosSerial: pointer.String("serial"),
+					}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshot, content).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.newVirtualMachine"))
+				})
+
+				DescribeTable("should validate new volume names of a new VirtualMachine", func(newVolumeNames map[string]string, allowed bool) {
+					restore.Spec.Target.Name = "new-vm"
+					restore.Spec.NewVirtualMachine = &snapshotv1.NewVirtualMachineOptions{
+						NewMacAddresses: map[string]string{"default": "00:00:5e:00:53:01"},
+						NewVolumeNames:  newVolumeNames,
+					}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshot, content).Admit(ar)
+					Expect(resp.Allowed).To(Equal(allowed))
+					if !allowed {
+						Expect(resp.Result.Details.Causes).To(HaveLen(1))
+						Expect(resp.Result.Details.Causes[0].Field).To(HavePrefix("spec.newVirtualMachine.newVolumeNames"))
+					}
+				},
+					Entry("allow generated names", nil, true),
+					Entry("allow new names", map[string]string{"disk1": "new-disk1", "disk2": "new-disk2"}, true),
+					Entry("reject an invalid name", map[string]string{"disk1": "New_Disk"}, false),
+					Entry("reject a duplicate name", map[string]string{"disk1": "new-disk", "disk2": "new-disk"}, false),
+					Entry("reject an unknown volume", map[string]string{"disk3": "new-disk3"}, false),
+				)
+
+				DescribeTable("should reject a new volume name that is in use", func(existing runtime.Object) {
+					restore.Spec.Target.Name = "new-vm"
+					restore.Spec.NewVirtualMachine = &snapshotv1.NewVirtualMachineOptions{
+						NewVolumeNames: map[string]string{"disk1": "new-disk1"},
+					}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshot, content, existing).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.newVirtualMachine.newVolumeNames[disk1]"))
+				},
+					Entry("by a PersistentVolumeClaim", &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{Name: "new-disk1", Namespace: "default"},
+					}),
+					Entry("by a DataVolume", &cdiv1.DataVolume{
+						ObjectMeta: metav1.ObjectMeta{Name: "new-disk1", Namespace: "default"},
+					}),
+				)
+
+				It("should allow restoring volumes only of a running VM", func() {
+					restore.Spec.Mode = snapshotv1.VirtualMachineRestoreVolumesOnly
+					vm.Spec.Running = &t
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshot, content).Admit(ar)
+					Expect(resp.Allowed).To(BeTrue())
+				})
+			})
+
 		})
 	})
 })
@@ -662,10 +822,28 @@ func createTestVMRestoreAdmitter(
 	ctrl := gomock.NewController(GinkgoT())
 	virtClient := kubecli.NewMockKubevirtClient(ctrl)
 	vmInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
-	kubevirtClient := kubevirtfake.NewSimpleClientset(objs...)
+	var kubevirtObjs, k8sObjs, cdiObjs []runtime.Object
+	for _, obj := range objs {
+		switch obj.(type) {
+		case *corev1.PersistentVolumeClaim:
+			k8sObjs = append(k8sObjs, obj)
+		case *cdiv1.DataVolume:
+			cdiObjs = append(cdiObjs, obj)
+		default:
+			kubevirtObjs = append(kubevirtObjs, obj)
+		}
+	}
+	kubevirtClient := kubevirtfake.NewSimpleClientset(kubevirtObjs...)
+	k8sClient := k8sfake.NewSimpleClientset(k8sObjs...)
+	cdiClient := cdifake.NewSimpleClientset(cdiObjs...)
+
+	virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+	virtClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
 
 	virtClient.EXPECT().VirtualMachineSnapshot("default").
 		Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots("default")).AnyTimes()
+	virtClient.EXPECT().VirtualMachineSnapshotContent("default").
+		Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotContents("default")).AnyTimes()
 	virtClient.EXPECT().VirtualMachine(gomock.Any()).Return(vmInterface).AnyTimes()
 
 	restoreInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
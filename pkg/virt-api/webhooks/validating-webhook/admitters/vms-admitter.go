@@ -153,12 +153,19 @@ func (admitter *VMsAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
+	oldVM := v1.VirtualMachine{}
 	if ar.Request.Operation == admissionv1.Update {
-		oldVM := v1.VirtualMachine{}
 		if err := json.Unmarshal(ar.Request.OldObject.Raw, &oldVM); err != nil {
 			return webhookutils.ToAdmissionResponseError(err)
 		}
+	}
+
+	causes = validateRestoreMemoryStateAnnotation(k8sfield.NewPath("metadata"), vm.Annotations, oldVM.Annotations, accountName)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
 
+	if ar.Request.Operation == admissionv1.Update {
 		if !equality.Semantic.DeepEqual(&oldVM.Spec, &vm.Spec) {
 			causes = admitter.validateVMUpdate(&oldVM, &vm)
 			if len(causes) > 0 {
@@ -418,7 +425,7 @@ func ValidateVirtualMachineSpec(field *k8sfield.Path, spec *v1.VirtualMachineSpe
 			})
 		}
 
-		if spec.Template.Spec.Domain.CPU.Sockets != 0 {
+		if spec.Template.Spec.Domain.CPU != nil && spec.Template.Spec.Domain.CPU.Sockets != 0 {
 			if spec.LiveUpdateFeatures.CPU.MaxSockets != nil {
 				if spec.Template.Spec.Domain.CPU.Sockets > *spec.LiveUpdateFeatures.CPU.MaxSockets {
 					causes = append(causes, metav1.StatusCause{
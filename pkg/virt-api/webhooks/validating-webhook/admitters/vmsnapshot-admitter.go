@@ -90,6 +90,17 @@ func (admitter *VMSnapshotAdmitter) Admit(ar *admissionv1.AdmissionReview) *admi
 			break
 		}
 
+		if vmSnapshot.Spec.IncludeMemory && !admitter.Config.HotplugVolumesEnabled() {
+			causes = []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: "including the memory state requires the HotplugVolumes feature gate",
+					Field:   k8sfield.NewPath("spec", "includeMemory").String(),
+				},
+			}
+			break
+		}
+
 		switch *vmSnapshot.Spec.Source.APIGroup {
 		case core.GroupName:
 			switch vmSnapshot.Spec.Source.Kind {
@@ -70,6 +70,10 @@ const (
 	Multiarchitecture = "MultiArchitecture"
 	// VMLiveUpdateFeaturesGate allows updating ceratin VM fields, such as CPU sockets to enable hot-plug functionality.
 	VMLiveUpdateFeaturesGate = "VMLiveUpdateFeatures"
+	// SoftCPUPlacementGate allows pinning VMIs to the least loaded NUMA node of a host without dedicated CPUs
+	SoftCPUPlacementGate = "SoftCPUPlacement"
+	// CPUWeightGate allows VMIs to set the CPU weight of their pod, up to the cluster wide maxCPUWeight
+	CPUWeightGate = "CPUWeight"
 )
 
 var deprecatedFeatureGates = [...]string{
@@ -231,3 +235,10 @@ func (config *ClusterConfig) MultiArchitectureEnabled() bool {
 func (config *ClusterConfig) VMLiveUpdateFeaturesEnabled() bool {
 	return config.isFeatureGateEnabled(VMLiveUpdateFeaturesGate)
 }
+func (config *ClusterConfig) SoftCPUPlacementEnabled() bool {
+	return config.isFeatureGateEnabled(SoftCPUPlacementGate)
+}
+
+func (config *ClusterConfig) CPUWeightEnabled() bool {
+	return config.isFeatureGateEnabled(CPUWeightGate)
+}
@@ -74,6 +74,7 @@ const (
 	DefaultVirtHandlerLogVerbosity                  = 2
 	DefaultVirtLauncherLogVerbosity                 = 2
 	DefaultVirtOperatorLogVerbosity                 = 2
+	DefaultMaxCPUWeight                      uint64 = 100
 
 	// Default REST configuration settings
 	DefaultVirtHandlerQPS         float32 = 5
@@ -432,6 +433,17 @@ func (c *ClusterConfig) GetKSMConfiguration() *v1.KSMConfiguration {
 	return c.GetConfig().KSMConfiguration
 }
 
+func (c *ClusterConfig) GetMaxCPUWeight() uint64 {
+	if maxCPUWeight := c.GetConfig().MaxCPUWeight; maxCPUWeight != nil {
+		return *maxCPUWeight
+	}
+	return DefaultMaxCPUWeight
+}
+
+func (c *ClusterConfig) GetTracingConfiguration() *v1.TracingConfiguration {
+	return c.GetConfig().Tracing
+}
+
 func (c *ClusterConfig) GetMaximumCpuSockets() (numOfSockets uint32) {
 	liveConfig := c.GetConfig().LiveUpdateConfiguration
 	if liveConfig != nil && liveConfig.MaxCpuSockets != nil {
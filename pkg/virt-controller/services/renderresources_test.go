@@ -180,6 +180,26 @@ var _ = Describe("Resource pod spec renderer", func() {
 				Entry("only CPU requests set by the user", false),
 				Entry("request and limits set by the user", true),
 			)
+
+			It("requires an additional CPU for each CPU of the emulator thread pool", func() {
+				rr = NewResourceRenderer(
+					nil,
+					userSpecifiedCPURequest,
+					WithCPUPinning(&v1.CPU{
+						Cores:                  5,
+						IsolateEmulatorThread:  true,
+						EmulatorThreadPoolSize: 3,
+					}),
+				)
+				Expect(rr.Limits()).To(HaveKeyWithValue(
+					kubev1.ResourceCPU,
+					*resource.NewQuantity(8, resource.BinarySI),
+				))
+				Expect(rr.Requests()).To(HaveKeyWithValue(
+					kubev1.ResourceCPU,
+					addResources(userCPURequest, resource.MustParse("3000m")),
+				))
+			})
 		})
 	})
 
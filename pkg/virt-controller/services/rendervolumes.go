@@ -387,6 +387,31 @@ func withTPM(vmi *v1.VirtualMachineInstance) VolumeRendererOption {
 	}
 }
 
+func withMemoryState(vmi *v1.VirtualMachineInstance) VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		claimName, _, exists := util.RestoreMemoryState(vmi)
+		if !exists {
+			return nil
+		}
+		const volumeName = "memory-state"
+		renderer.podVolumes = append(renderer.podVolumes, k8sv1.Volume{
+			Name: volumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+					ReadOnly:  true,
+				},
+			},
+		})
+		renderer.podVolumeMounts = append(renderer.podVolumeMounts, k8sv1.VolumeMount{
+			Name:      volumeName,
+			MountPath: util.MemoryStateDir,
+			ReadOnly:  true,
+		})
+		return nil
+	}
+}
+
 func withSidecarVolumes(hookSidecars hooks.HookSidecarList) VolumeRendererOption {
 	return func(renderer *VolumeRenderer) error {
 		if len(hookSidecars) != 0 {
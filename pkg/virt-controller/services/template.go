@@ -21,6 +21,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"strconv"
@@ -49,6 +50,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
 	"kubevirt.io/kubevirt/pkg/storage/types"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -251,7 +253,7 @@ func (t *templateService) GetLauncherImage() string {
 }
 
 func (t *templateService) RenderLaunchManifestNoVm(vmi *v1.VirtualMachineInstance) (*k8sv1.Pod, error) {
-	return t.renderLaunchManifest(vmi, nil, true)
+	return t.renderLaunchManifest(vmi, nil, false, true)
 }
 
 func (t *templateService) RenderMigrationManifest(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) (*k8sv1.Pod, error) {
@@ -259,7 +261,7 @@ func (t *templateService) RenderMigrationManifest(vmi *v1.VirtualMachineInstance
 	if err != nil {
 		return nil, fmt.Errorf("can not proceed with the migration when no reproducible image digest can be detected: %v", err)
 	}
-	podManifest, err := t.renderLaunchManifest(vmi, reproducibleImageIDs, false)
+	podManifest, err := t.renderLaunchManifest(vmi, reproducibleImageIDs, true, false)
 	if err != nil {
 		return nil, err
 	}
@@ -278,7 +280,7 @@ func (t *templateService) RenderMigrationManifest(vmi *v1.VirtualMachineInstance
 }
 
 func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (*k8sv1.Pod, error) {
-	return t.renderLaunchManifest(vmi, nil, false)
+	return t.renderLaunchManifest(vmi, nil, false, false)
 }
 
 func (t *templateService) IsPPC64() bool {
@@ -313,7 +315,7 @@ func computePodSecurityContext(vmi *v1.VirtualMachineInstance, seccomp *k8sv1.Se
 	return psc
 }
 
-func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, imageIDs map[string]string, tempPod bool) (*k8sv1.Pod, error) {
+func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, imageIDs map[string]string, migrationTarget bool, tempPod bool) (*k8sv1.Pod, error) {
 	precond.MustNotBeNil(vmi)
 	domain := precond.MustNotBeEmpty(vmi.GetObjectMeta().GetName())
 	namespace := precond.MustNotBeEmpty(vmi.GetObjectMeta().GetNamespace())
@@ -401,7 +403,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, i
 		command = append(command, "--simulate-crash")
 	}
 
-	volumeRenderer, err := t.newVolumeRenderer(vmi, namespace, requestedHookSidecarList)
+	volumeRenderer, err := t.newVolumeRenderer(vmi, namespace, requestedHookSidecarList, migrationTarget)
 	if err != nil {
 		return nil, err
 	}
@@ -438,6 +440,14 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, i
 		compute.Env = append(compute.Env, k8sv1.EnvVar{Name: ENV_VAR_VIRTIOFSD_DEBUG_LOGS, Value: "1"})
 	}
 
+	if tracingConfig := tracing.LauncherConfiguration(t.clusterConfig.GetTracingConfiguration()); tracingConfig != nil && tracing.FromObject(vmi).IsValid() {
+		tracingConfigJSON, err := json.Marshal(tracingConfig)
+		if err != nil {
+			return nil, err
+		}
+		compute.Env = append(compute.Env, k8sv1.EnvVar{Name: tracing.ConfigurationEnvVar, Value: string(tracingConfigJSON)})
+	}
+
 	compute.Env = append(compute.Env, k8sv1.EnvVar{
 		Name: ENV_VAR_POD_NAME,
 		ValueFrom: &k8sv1.EnvVarSource{
@@ -688,13 +698,18 @@ func (t *templateService) newContainerSpecRenderer(vmi *v1.VirtualMachineInstanc
 	return containerRenderer
 }
 
-func (t *templateService) newVolumeRenderer(vmi *v1.VirtualMachineInstance, namespace string, requestedHookSidecarList hooks.HookSidecarList) (*VolumeRenderer, error) {
+func (t *templateService) newVolumeRenderer(vmi *v1.VirtualMachineInstance, namespace string, requestedHookSidecarList hooks.HookSidecarList, migrationTarget bool) (*VolumeRenderer, error) {
 	volumeOpts := []VolumeRendererOption{
 		withVMIConfigVolumes(vmi.Spec.Domain.Devices.Disks, vmi.Spec.Volumes),
 		withVMIVolumes(t.persistentVolumeClaimStore, vmi.Spec.Volumes, vmi.Status.VolumeStatus),
 		withAccessCredentials(vmi.Spec.AccessCredentials),
 		withTPM(vmi),
 	}
+	// The memory state is only read when the domain is first started,
+	// a migration target receives the memory from the source instead.
+	if !migrationTarget {
+		volumeOpts = append(volumeOpts, withMemoryState(vmi))
+	}
 	if len(requestedHookSidecarList) != 0 {
 		volumeOpts = append(volumeOpts, withSidecarVolumes(requestedHookSidecarList))
 	}
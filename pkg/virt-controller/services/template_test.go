@@ -53,6 +53,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/network/istio"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	"kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/topology"
@@ -633,6 +634,53 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with tracing", func() {
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.Tracing = &v1.TracingConfiguration{
+					OTLP: &v1.TracingOTLPExporter{Endpoint: "http://collector:4318"},
+					File: &v1.TracingFileExporter{Path: "/var/log/kubevirt/spans.json"},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+				vmi = &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+				}
+			})
+
+			tracingEnv := func(pod *k8sv1.Pod) *k8sv1.EnvVar {
+				for _, ev := range pod.Spec.Containers[0].Env {
+					if ev.Name == tracing.ConfigurationEnvVar {
+						return &ev
+					}
+				}
+				return nil
+			}
+
+			It("should only pass the collector endpoint to a traced VMI", func() {
+				vmi.Annotations = map[string]string{
+					v1.TraceparentAnnotation: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+				}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				env := tracingEnv(pod)
+				Expect(env).ToNot(BeNil())
+				Expect(env.Value).To(MatchJSON(`{"otlp":{"endpoint":"http://collector:4318"}}`))
+			})
+
+			It("should not pass the tracing configuration to a VMI which is not traced", func() {
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tracingEnv(pod)).To(BeNil())
+			})
+		})
+
 		Context("with access credentials", func() {
 			It("should add volume with secret referenced by cloud-init user secret ref", func() {
 				config, kvInformer, svc = configFactory(defaultArch)
@@ -1075,6 +1123,36 @@ var _ = Describe("Template", func() {
 					"]")
 				Expect(value).To(Equal(expectedIfaces))
 			})
+			It("should mount the memory state to restore only for the initial launch", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+
+				vmi := &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+						Annotations: map[string]string{
+							v1.RestoreMemoryStateAnnotation: "memory-state-pvc/memory.state",
+						},
+					},
+				}
+				hasMemoryStateVolume := func(pod *k8sv1.Pod) bool {
+					for _, volume := range pod.Spec.Volumes {
+						if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == "memory-state-pvc" {
+							return true
+						}
+					}
+					return false
+				}
+
+				sourcePod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(hasMemoryStateVolume(sourcePod)).To(BeTrue())
+
+				targetPod, err := svc.RenderMigrationManifest(vmi, sourcePod)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(hasMemoryStateVolume(targetPod)).To(BeFalse())
+			})
 			DescribeTable("should add Multus networks annotation to the migration target pod with interface name scheme similar to the migration source pod",
 				func(migrationSourcePodNetworksAnnotation, expectedTargetPodMultusNetworksAnnotation map[string]string) {
 					config, kvInformer, svc = configFactory(defaultArch)
@@ -136,6 +136,8 @@ var _ = Describe("Application", func() {
 			cdiConfigInformer,
 			config,
 			topology.NewTopologyHinter(&cache.FakeCustomStore{}, &cache.FakeCustomStore{}, nil),
+			migrationPolicyInformer,
+			cache.NewStore(cache.MetaNamespaceKeyFunc),
 		)
 		app.rsController, _ = NewVMIReplicaSet(vmiInformer, rsInformer, recorder, virtClient, uint(10))
 		app.vmController, _ = NewVMController(vmiInformer,
@@ -143,6 +145,7 @@ var _ = Describe("Application", func() {
 			dataVolumeInformer,
 			pvcInformer,
 			crInformer,
+			clusterInstancetypeInformer,
 			instancetypeMethods,
 			recorder,
 			virtClient,
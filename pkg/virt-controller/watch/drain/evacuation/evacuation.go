@@ -3,6 +3,7 @@ package evacuation
 import (
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -425,7 +426,9 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 		return nil
 	}
 
-	// TODO: should the order be randomized?
+	// Migrate the VMIs which are expected to converge first, the ones whose
+	// memory dirty rate exceeds the migration bandwidth go last
+	sortByMigrationConvergence(migrationCandidates)
 	selectedCandidates := migrationCandidates[0:diff]
 
 	log.DefaultLogger().Infof("node: %v, migrations: %v, candidates: %v, selected: %v", node.Name, len(activeMigrations), len(migrationCandidates), len(selectedCandidates))
@@ -461,6 +464,28 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 	return nil
 }
 
+// migrationConvergenceRank orders VMIs by how likely their migration is to converge,
+// VMIs without an estimate are placed between the convergent and the non-convergent ones
+func migrationConvergenceRank(vmi *virtv1.VirtualMachineInstance) int {
+	condition := controller.NewVirtualMachineInstanceConditionManager().GetCondition(vmi, virtv1.VirtualMachineInstanceMigrationConvergent)
+	switch {
+	case condition == nil:
+		return 1
+	case condition.Status == k8sv1.ConditionTrue:
+		return 0
+	case condition.Status == k8sv1.ConditionFalse:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func sortByMigrationConvergence(vmis []*virtv1.VirtualMachineInstance) {
+	sort.SliceStable(vmis, func(i, j int) bool {
+		return migrationConvergenceRank(vmis[i]) < migrationConvergenceRank(vmis[j])
+	})
+}
+
 func hasMigratedOnEviction(vmi *virtv1.VirtualMachineInstance) bool {
 	return vmi.Status.NodeName != vmi.Status.EvacuationNodeName
 }
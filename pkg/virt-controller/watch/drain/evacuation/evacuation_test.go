@@ -491,6 +491,51 @@ var _ = Describe("Evacuation", func() {
 
 			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
 		})
+
+		It("Should migrate VMIs which are expected to converge first", func() {
+			var maxParallelMigrationsPerOutboundNode uint32 = 1
+			config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				MigrationConfiguration: &v1.MigrationConfiguration{
+					ParallelOutboundMigrationsPerNode: &maxParallelMigrationsPerOutboundNode,
+				},
+			})
+
+			controller, _ = evacuation.
+				NewEvacuationController(
+					vmiInformer,
+					migrationInformer,
+					nodeInformer,
+					podInformer,
+					recorder,
+					virtClient,
+					config)
+
+			nodeName := "node01"
+			addNode(newNode(nodeName))
+
+			withConvergence := func(vmi *v1.VirtualMachineInstance, status v12.ConditionStatus) *v1.VirtualMachineInstance {
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:   v1.VirtualMachineInstanceMigrationConvergent,
+					Status: status,
+				})
+				return vmi
+			}
+			vmiFeeder.Add(withConvergence(newVirtualMachineMarkedForEviction("busyvmi", nodeName), v12.ConditionFalse))
+			vmiFeeder.Add(newVirtualMachineMarkedForEviction("unknownvmi", nodeName))
+			vmiFeeder.Add(withConvergence(newVirtualMachineMarkedForEviction("idlevmi", nodeName), v12.ConditionTrue))
+
+			migrationInterface.
+				EXPECT().
+				Create(gomock.Any(), &v13.CreateOptions{}).
+				DoAndReturn(func(migration *v1.VirtualMachineInstanceMigration, _ *v13.CreateOptions) (*v1.VirtualMachineInstanceMigration, error) {
+					Expect(migration.Spec.VMIName).To(Equal("idlevmi"))
+					return &v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil
+				})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+		})
 	})
 
 	AfterEach(func() {
@@ -688,6 +688,11 @@ func (c *MigrationController) createTargetPod(migration *virtv1.VirtualMachineIn
 		}
 	}
 
+	// Workload updates of a canary upgrade move the VMI to the canary nodes
+	if err := prepareNodeSelectorForWorkloadUpdate(migration, templatePod); err != nil {
+		return err
+	}
+
 	// This is used by the functional test to simulate failures
 	computeImageOverride, ok := migration.Annotations[virtv1.FuncTestMigrationTargetImageOverrideAnnotation]
 	if ok && computeImageOverride != "" {
@@ -1855,6 +1860,28 @@ func (c *MigrationController) alertIfHostModelIsUnschedulable(vmi *virtv1.Virtua
 	}
 }
 
+func prepareNodeSelectorForWorkloadUpdate(migration *virtv1.VirtualMachineInstanceMigration, pod *k8sv1.Pod) error {
+	if _, ok := migration.Annotations[virtv1.WorkloadUpdateMigrationAnnotation]; !ok {
+		return nil
+	}
+	selector, ok := migration.Annotations[virtv1.WorkloadUpdateTargetNodeSelectorAnnotation]
+	if !ok || selector == "" {
+		return nil
+	}
+
+	nodeSelector, err := labels.ConvertSelectorToLabelsMap(selector)
+	if err != nil {
+		return fmt.Errorf("invalid workload update target node selector %q: %v", selector, err)
+	}
+	if pod.Spec.NodeSelector == nil {
+		pod.Spec.NodeSelector = map[string]string{}
+	}
+	for key, value := range nodeSelector {
+		pod.Spec.NodeSelector[key] = value
+	}
+	return nil
+}
+
 func prepareNodeSelectorForHostCpuModel(node *k8sv1.Node, pod *k8sv1.Pod, sourcePod *k8sv1.Pod) error {
 	var hostCpuModel, nodeSelectorKeyForHostModel, hostModelLabelValue string
 	migratedAtLeastOnce := false
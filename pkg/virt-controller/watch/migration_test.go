@@ -599,6 +599,24 @@ var _ = Describe("Migration watcher", func() {
 			controller.Execute()
 			testutils.ExpectEvents(recorder, SuccessfulCreatePodReason)
 		})
+		It("should schedule the target pod of a workload update on the canary nodes", func() {
+			vmi := newVirtualMachine("testvmi", virtv1.Running)
+			migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPending)
+			migration.Annotations[virtv1.WorkloadUpdateMigrationAnnotation] = ""
+			migration.Annotations[virtv1.WorkloadUpdateTargetNodeSelectorAnnotation] = "canary=true,zone=a"
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				pod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue("canary", "true"))
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue("zone", "a"))
+				return true, pod, nil
+			})
+
+			controller.Execute()
+			testutils.ExpectEvents(recorder, SuccessfulCreatePodReason)
+		})
 		It("should not create target pod if multiple pods exist in a non finalized state for VMI", func() {
 			vmi := newVirtualMachine("testvmi", virtv1.Running)
 			migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPending)
@@ -0,0 +1,96 @@
+package watch
+
+import (
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/api/migrations/v1alpha1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+// syncMigrationConvergenceCondition estimates whether a live migration of the VMI would converge,
+// by comparing the last sampled memory dirty rate with the bandwidth the effective migration
+// configuration allows. The evacuation controller uses the result to migrate easy VMIs first.
+func (c *VMIController) syncMigrationConvergenceCondition(vmi *virtv1.VirtualMachineInstance) {
+	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+	if vmi.Status.MemoryDirtyRate == nil {
+		conditionManager.RemoveCondition(vmi, virtv1.VirtualMachineInstanceMigrationConvergent)
+		return
+	}
+
+	migrationConfiguration := c.effectiveMigrationConfiguration(vmi)
+	condition := estimateMigrationConvergence(vmi.Status.MemoryDirtyRate, migrationConfiguration)
+	for i := range vmi.Status.Conditions {
+		existing := &vmi.Status.Conditions[i]
+		if existing.Type == condition.Type && existing.Status == condition.Status && existing.Reason == condition.Reason {
+			// The estimate did not transition, only refresh the sampled dirty rate in the message
+			existing.Message = condition.Message
+			return
+		}
+	}
+	conditionManager.UpdateCondition(vmi, condition)
+}
+
+func (c *VMIController) effectiveMigrationConfiguration(vmi *virtv1.VirtualMachineInstance) *virtv1.MigrationConfiguration {
+	migrationConfiguration := c.clusterConfig.GetMigrationConfiguration().DeepCopy()
+
+	vmiNamespace := &k8sv1.Namespace{}
+	obj, exists, err := c.namespaceStore.GetByKey(vmi.Namespace)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("failed to look up the VMI namespace, ignoring namespace labels when matching migration policies")
+	} else if exists {
+		vmiNamespace = obj.(*k8sv1.Namespace)
+	}
+
+	var policies []v1alpha1.MigrationPolicy
+	for _, obj := range c.migrationPolicyInformer.GetStore().List() {
+		policies = append(policies, *obj.(*v1alpha1.MigrationPolicy))
+	}
+
+	matchedPolicy := MatchPolicy(&v1alpha1.MigrationPolicyList{Items: policies}, vmi, vmiNamespace)
+	if matchedPolicy == nil {
+		return migrationConfiguration
+	}
+
+	if _, err := matchedPolicy.GetMigrationConfByPolicy(migrationConfiguration); err != nil {
+		log.Log.Object(vmi).Reason(err).Warningf("failed to apply migration policy %s", matchedPolicy.Name)
+	}
+	return migrationConfiguration
+}
+
+func estimateMigrationConvergence(dirtyRate *virtv1.VirtualMachineInstanceMemoryDirtyRate, migrationConfiguration *virtv1.MigrationConfiguration) *virtv1.VirtualMachineInstanceCondition {
+	condition := &virtv1.VirtualMachineInstanceCondition{
+		Type:               virtv1.VirtualMachineInstanceMigrationConvergent,
+		Status:             k8sv1.ConditionTrue,
+		LastTransitionTime: v1.Now(),
+	}
+	dirtyRateQuantity := resource.NewQuantity(dirtyRate.BytesPerSecond, resource.BinarySI)
+
+	switch {
+	case isTrue(migrationConfiguration.AllowPostCopy) || isTrue(migrationConfiguration.AllowAutoConverge):
+		condition.Reason = virtv1.VirtualMachineInstanceReasonMigrationForcesConvergence
+		condition.Message = "post-copy or auto-converge is allowed, the migration is forced to converge"
+	case migrationConfiguration.BandwidthPerMigration == nil || migrationConfiguration.BandwidthPerMigration.IsZero():
+		condition.Reason = virtv1.VirtualMachineInstanceReasonUnlimitedMigrationBandwidth
+		condition.Message = fmt.Sprintf("migration bandwidth is not limited, memory dirty rate is %s/s", dirtyRateQuantity)
+	case dirtyRate.BytesPerSecond < migrationConfiguration.BandwidthPerMigration.Value():
+		condition.Reason = virtv1.VirtualMachineInstanceReasonDirtyRateBelowBandwidth
+		condition.Message = fmt.Sprintf("memory dirty rate of %s/s is below the migration bandwidth of %s/s", dirtyRateQuantity, migrationConfiguration.BandwidthPerMigration)
+	default:
+		condition.Status = k8sv1.ConditionFalse
+		condition.Reason = virtv1.VirtualMachineInstanceReasonDirtyRateExceedsBandwidth
+		condition.Message = fmt.Sprintf("memory dirty rate of %s/s exceeds the migration bandwidth of %s/s", dirtyRateQuantity, migrationConfiguration.BandwidthPerMigration)
+	}
+
+	return condition
+}
+
+func isTrue(value *bool) bool {
+	return value != nil && *value
+}
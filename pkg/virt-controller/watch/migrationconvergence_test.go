@@ -0,0 +1,136 @@
+package watch
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	migrationsv1 "kubevirt.io/api/migrations/v1alpha1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Migration convergence", func() {
+	const mib = 1024 * 1024
+
+	DescribeTable("should estimate the convergence", func(migrationConfiguration *virtv1.MigrationConfiguration, status k8sv1.ConditionStatus, reason string) {
+		dirtyRate := &virtv1.VirtualMachineInstanceMemoryDirtyRate{BytesPerSecond: 64 * mib}
+
+		condition := estimateMigrationConvergence(dirtyRate, migrationConfiguration)
+
+		Expect(condition.Type).To(Equal(virtv1.VirtualMachineInstanceMigrationConvergent))
+		Expect(condition.Status).To(Equal(status))
+		Expect(condition.Reason).To(Equal(reason))
+	},
+		Entry("as convergent when the dirty rate is below the bandwidth",
+			&virtv1.MigrationConfiguration{BandwidthPerMigration: resource.NewQuantity(128*mib, resource.BinarySI)},
+			k8sv1.ConditionTrue, virtv1.VirtualMachineInstanceReasonDirtyRateBelowBandwidth),
+		Entry("as not convergent when the dirty rate exceeds the bandwidth",
+			&virtv1.MigrationConfiguration{BandwidthPerMigration: resource.NewQuantity(32*mib, resource.BinarySI)},
+			k8sv1.ConditionFalse, virtv1.VirtualMachineInstanceReasonDirtyRateExceedsBandwidth),
+		Entry("as convergent when the bandwidth is not limited",
+			&virtv1.MigrationConfiguration{BandwidthPerMigration: resource.NewQuantity(0, resource.BinarySI)},
+			k8sv1.ConditionTrue, virtv1.VirtualMachineInstanceReasonUnlimitedMigrationBandwidth),
+		Entry("as convergent when post-copy is allowed",
+			&virtv1.MigrationConfiguration{
+				BandwidthPerMigration: resource.NewQuantity(32*mib, resource.BinarySI),
+				AllowPostCopy:         pointer.Bool(true),
+			},
+			k8sv1.ConditionTrue, virtv1.VirtualMachineInstanceReasonMigrationForcesConvergence),
+		Entry("as convergent when auto-converge is allowed",
+			&virtv1.MigrationConfiguration{
+				BandwidthPerMigration: resource.NewQuantity(32*mib, resource.BinarySI),
+				AllowAutoConverge:     pointer.Bool(true),
+			},
+			k8sv1.ConditionTrue, virtv1.VirtualMachineInstanceReasonMigrationForcesConvergence),
+	)
+
+	Context("with the VMI controller", func() {
+		var (
+			c                       *VMIController
+			migrationPolicyInformer cache.SharedIndexInformer
+			vmi                     *virtv1.VirtualMachineInstance
+		)
+
+		BeforeEach(func() {
+			config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&virtv1.KubeVirtConfiguration{
+				MigrationConfiguration: &virtv1.MigrationConfiguration{
+					BandwidthPerMigration: resource.NewQuantity(128*mib, resource.BinarySI),
+				},
+			})
+			migrationPolicyInformer, _ = testutils.NewFakeInformerFor(&migrationsv1.MigrationPolicy{})
+			c = &VMIController{
+				clusterConfig:           config,
+				migrationPolicyInformer: migrationPolicyInformer,
+				namespaceStore:          cache.NewStore(cache.MetaNamespaceKeyFunc),
+			}
+
+			vmi = &virtv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: k8sv1.NamespaceDefault,
+					Labels:    map[string]string{"workload": "database"},
+				},
+			}
+			vmi.Status.MemoryDirtyRate = &virtv1.VirtualMachineInstanceMemoryDirtyRate{BytesPerSecond: 64 * mib}
+		})
+
+		It("should use the cluster wide migration bandwidth", func() {
+			c.syncMigrationConvergenceCondition(vmi)
+
+			Expect(controller.NewVirtualMachineInstanceConditionManager().HasConditionWithStatusAndReason(vmi,
+				virtv1.VirtualMachineInstanceMigrationConvergent, k8sv1.ConditionTrue, virtv1.VirtualMachineInstanceReasonDirtyRateBelowBandwidth)).To(BeTrue())
+		})
+
+		It("should use the bandwidth of the matching migration policy", func() {
+			Expect(migrationPolicyInformer.GetStore().Add(&migrationsv1.MigrationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "slow"},
+				Spec: migrationsv1.MigrationPolicySpec{
+					Selectors: &migrationsv1.Selectors{
+						VirtualMachineInstanceSelector: migrationsv1.LabelSelector{"workload": "database"},
+					},
+					BandwidthPerMigration: resource.NewQuantity(32*mib, resource.BinarySI),
+				},
+			})).To(Succeed())
+
+			c.syncMigrationConvergenceCondition(vmi)
+
+			Expect(controller.NewVirtualMachineInstanceConditionManager().HasConditionWithStatusAndReason(vmi,
+				virtv1.VirtualMachineInstanceMigrationConvergent, k8sv1.ConditionFalse, virtv1.VirtualMachineInstanceReasonDirtyRateExceedsBandwidth)).To(BeTrue())
+		})
+
+		It("should refresh the message without a transition when the dirty rate changes", func() {
+			c.syncMigrationConvergenceCondition(vmi)
+			Expect(vmi.Status.Conditions).To(HaveLen(1))
+			transitionTime := metav1.NewTime(time.Now().Add(-time.Hour))
+			vmi.Status.Conditions[0].LastTransitionTime = transitionTime
+
+			vmi.Status.MemoryDirtyRate.BytesPerSecond = 96 * mib
+			c.syncMigrationConvergenceCondition(vmi)
+
+			Expect(vmi.Status.Conditions).To(HaveLen(1))
+			Expect(vmi.Status.Conditions[0].Reason).To(Equal(virtv1.VirtualMachineInstanceReasonDirtyRateBelowBandwidth))
+			Expect(vmi.Status.Conditions[0].Message).To(ContainSubstring("96Mi/s"))
+			Expect(vmi.Status.Conditions[0].LastTransitionTime).To(Equal(transitionTime))
+		})
+
+		It("should remove the condition when no dirty rate was sampled", func() {
+			vmi.Status.Conditions = []virtv1.VirtualMachineInstanceCondition{
+				{Type: virtv1.VirtualMachineInstanceMigrationConvergent, Status: k8sv1.ConditionTrue},
+			}
+			vmi.Status.MemoryDirtyRate = nil
+
+			c.syncMigrationConvergenceCondition(vmi)
+
+			Expect(vmi.Status.Conditions).To(BeEmpty())
+		})
+	})
+})
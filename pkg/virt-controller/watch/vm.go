@@ -58,10 +58,12 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/instancetype"
 	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
+	"kubevirt.io/kubevirt/pkg/timeline"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/migrations"
 	"kubevirt.io/kubevirt/pkg/util/status"
@@ -99,6 +101,9 @@ const (
 	// SourcePVCNotAvailabe is added in an event when the source PVC of a valid
 	// clone Datavolume doesn't exist
 	SourcePVCNotAvailabe = "SourcePVCNotAvailabe"
+	// MemoryStateUnavailableReason is added in an event when a restored VM boots
+	// because the memory state it should resume from is gone
+	MemoryStateUnavailableReason = "MemoryStateUnavailable"
 )
 
 const (
@@ -113,11 +118,14 @@ const (
 
 const defaultMaxCrashLoopBackoffDelaySeconds = 300
 
+const maxSocketsRatio = 4
+
 func NewVMController(vmiInformer cache.SharedIndexInformer,
 	vmInformer cache.SharedIndexInformer,
 	dataVolumeInformer cache.SharedIndexInformer,
 	pvcInformer cache.SharedIndexInformer,
 	crInformer cache.SharedIndexInformer,
+	clusterInstancetypeInformer cache.SharedIndexInformer,
 	instancetypeMethods instancetype.Methods,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
@@ -126,17 +134,18 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 	proxy := &sarProxy{client: clientset}
 
 	c := &VMController{
-		Queue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-vm"),
-		vmiInformer:            vmiInformer,
-		vmInformer:             vmInformer,
-		dataVolumeInformer:     dataVolumeInformer,
-		pvcInformer:            pvcInformer,
-		crInformer:             crInformer,
-		instancetypeMethods:    instancetypeMethods,
-		recorder:               recorder,
-		clientset:              clientset,
-		expectations:           controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
-		dataVolumeExpectations: controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		Queue:                       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-vm"),
+		vmiInformer:                 vmiInformer,
+		vmInformer:                  vmInformer,
+		dataVolumeInformer:          dataVolumeInformer,
+		pvcInformer:                 pvcInformer,
+		crInformer:                  crInformer,
+		clusterInstancetypeInformer: clusterInstancetypeInformer,
+		instancetypeMethods:         instancetypeMethods,
+		recorder:                    recorder,
+		clientset:                   clientset,
+		expectations:                controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		dataVolumeExpectations:      controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
 		cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 			return cdiclone.CanServiceAccountClonePVC(proxy, pvcNamespace, pvcName, saNamespace, saName)
 		},
@@ -183,20 +192,21 @@ func (p *sarProxy) Create(sar *authv1.SubjectAccessReview) (*authv1.SubjectAcces
 }
 
 type VMController struct {
-	clientset              kubecli.KubevirtClient
-	Queue                  workqueue.RateLimitingInterface
-	vmiInformer            cache.SharedIndexInformer
-	vmInformer             cache.SharedIndexInformer
-	dataVolumeInformer     cache.SharedIndexInformer
-	pvcInformer            cache.SharedIndexInformer
-	crInformer             cache.SharedIndexInformer
-	instancetypeMethods    instancetype.Methods
-	recorder               record.EventRecorder
-	expectations           *controller.UIDTrackingControllerExpectations
-	dataVolumeExpectations *controller.UIDTrackingControllerExpectations
-	cloneAuthFunc          CloneAuthFunc
-	statusUpdater          *status.VMStatusUpdater
-	clusterConfig          *virtconfig.ClusterConfig
+	clientset                   kubecli.KubevirtClient
+	Queue                       workqueue.RateLimitingInterface
+	vmiInformer                 cache.SharedIndexInformer
+	vmInformer                  cache.SharedIndexInformer
+	dataVolumeInformer          cache.SharedIndexInformer
+	pvcInformer                 cache.SharedIndexInformer
+	crInformer                  cache.SharedIndexInformer
+	clusterInstancetypeInformer cache.SharedIndexInformer
+	instancetypeMethods         instancetype.Methods
+	recorder                    record.EventRecorder
+	expectations                *controller.UIDTrackingControllerExpectations
+	dataVolumeExpectations      *controller.UIDTrackingControllerExpectations
+	cloneAuthFunc               CloneAuthFunc
+	statusUpdater               *status.VMStatusUpdater
+	clusterConfig               *virtconfig.ClusterConfig
 }
 
 func (c *VMController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -205,7 +215,7 @@ func (c *VMController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting VirtualMachine controller.")
 
 	// Wait for cache sync before we start the controller
-	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmInformer.HasSynced, c.dataVolumeInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmInformer.HasSynced, c.dataVolumeInformer.HasSynced, c.clusterInstancetypeInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -474,9 +484,9 @@ func removeMemoryDumpVolumeFromVMISpec(vmiSpec *virtv1.VirtualMachineInstanceSpe
 	return vmiSpec
 }
 
-func applyMemoryDumpVolumeRequestOnVMISpec(vmiSpec *virtv1.VirtualMachineInstanceSpec, claimName string) *virtv1.VirtualMachineInstanceSpec {
+func applyMemoryDumpVolumeRequestOnVMISpec(vmiSpec *virtv1.VirtualMachineInstanceSpec, request *virtv1.VirtualMachineMemoryDumpRequest) *virtv1.VirtualMachineInstanceSpec {
 	for _, volume := range vmiSpec.Volumes {
-		if volume.Name == claimName {
+		if volume.Name == request.ClaimName {
 			return vmiSpec
 		}
 	}
@@ -484,14 +494,15 @@ func applyMemoryDumpVolumeRequestOnVMISpec(vmiSpec *virtv1.VirtualMachineInstanc
 	memoryDumpVol := &virtv1.MemoryDumpVolumeSource{
 		PersistentVolumeClaimVolumeSource: virtv1.PersistentVolumeClaimVolumeSource{
 			PersistentVolumeClaimVolumeSource: k8score.PersistentVolumeClaimVolumeSource{
-				ClaimName: claimName,
+				ClaimName: request.ClaimName,
 			},
 			Hotpluggable: true,
 		},
+		Format: request.Format,
 	}
 
 	newVolume := virtv1.Volume{
-		Name: claimName,
+		Name: request.ClaimName,
 	}
 	newVolume.VolumeSource.MemoryDump = memoryDumpVol
 
@@ -523,7 +534,7 @@ func (c *VMController) generateVMIMemoryDumpVolumePatch(vmi *virtv1.VirtualMachi
 
 	vmiCopy := vmi.DeepCopy()
 	if addVolume {
-		vmiCopy.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vmiCopy.Spec, request.ClaimName)
+		vmiCopy.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vmiCopy.Spec, request)
 	} else {
 		vmiCopy.Spec = *removeMemoryDumpVolumeFromVMISpec(&vmiCopy.Spec, request.ClaimName)
 	}
@@ -647,8 +658,12 @@ func (c *VMController) handleMemoryDumpRequest(vm *virtv1.VirtualMachine, vmi *v
 		}
 		// When in state associating we want to add the memory dump pvc
 		// as a volume in the vm and in the vmi to trigger the mount
-		// to virt launcher and the memory dump
-		vm.Spec.Template.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vm.Spec.Template.Spec, vm.Status.MemoryDumpRequest.ClaimName)
+		// to virt launcher and the memory dump.
+		// A saved state is requested by a snapshot of the vm, which
+		// forbids vm spec updates, so it is only added to the vmi
+		if !isSavedStateMemoryDump(vm.Status.MemoryDumpRequest) {
+			vm.Spec.Template.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vm.Spec.Template.Spec, vm.Status.MemoryDumpRequest)
+		}
 		if _, exists := vmiVolumeMap[vm.Status.MemoryDumpRequest.ClaimName]; exists {
 			return nil
 		}
@@ -1405,6 +1420,10 @@ func (c *VMController) createVMRevision(vm *virtv1.VirtualMachine) (string, erro
 	return cr.Name, nil
 }
 
+func isSavedStateMemoryDump(request *virtv1.VirtualMachineMemoryDumpRequest) bool {
+	return request.Format == virtv1.MemoryDumpFormatSavedState
+}
+
 func hasCompletedMemoryDump(vm *virtv1.VirtualMachine) bool {
 	return vm.Status.MemoryDumpRequest != nil && vm.Status.MemoryDumpRequest.Phase != virtv1.MemoryDumpAssociating && vm.Status.MemoryDumpRequest.Phase != virtv1.MemoryDumpInProgress
 }
@@ -1431,6 +1450,8 @@ func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.Virtual
 
 	setupStableFirmwareUUID(vm, vmi)
 
+	c.setupRestoreMemoryState(vm, vmi)
+
 	// TODO check if vmi labels exist, and when make sure that they match. For now just override them
 	vmi.ObjectMeta.Labels = vm.Spec.Template.ObjectMeta.Labels
 	vmi.ObjectMeta.OwnerReferences = []v1.OwnerReference{
@@ -1482,6 +1503,19 @@ func (c *VMController) applyInstancetypeToVmi(vm *virtv1.VirtualMachine, vmi *vi
 		return fmt.Errorf("VMI conflicts with instancetype spec in fields: [%s]", conflicts.String())
 	}
 
+	// Leave room for the sockets an instancetype upgrade may hotplug through
+	// the instancetype upgrade subresource, as the VM cannot use live update features
+	if c.clusterConfig.VMLiveUpdateFeaturesEnabled() &&
+		vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.Sockets != 0 && vmi.Spec.Domain.CPU.MaxSockets == 0 {
+		maxSockets := c.clusterConfig.GetMaximumCpuSockets()
+		if maxSockets == 0 {
+			maxSockets = vmi.Spec.Domain.CPU.Sockets * maxSocketsRatio
+		}
+		if maxSockets >= vmi.Spec.Domain.CPU.Sockets {
+			vmi.Spec.Domain.CPU.MaxSockets = maxSockets
+		}
+	}
+
 	return nil
 }
 
@@ -1523,6 +1557,80 @@ const magicUUID = "6a1a24a1-4061-4607-8bf4-a3963d0c5895"
 
 var firmwareUUIDns = uuid.Parse(magicUUID)
 
+// setupRestoreMemoryState makes the vmi resume from the memory state
+// the vm was restored with, instead of booting
+func (c *VMController) setupRestoreMemoryState(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	memoryState, exists := vm.Annotations[virtv1.RestoreMemoryStateAnnotation]
+	if !exists {
+		return
+	}
+
+	if !c.memoryStateExists(vm.Namespace, memoryState) {
+		log.Log.Object(vm).Infof("Memory state %s of the restored VM is gone, booting instead of resuming", memoryState)
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, MemoryStateUnavailableReason, "Memory state %s is gone, booting instead of resuming", memoryState)
+		return
+	}
+
+	annotations := make(map[string]string, len(vmi.Annotations)+1)
+	for key, value := range vmi.Annotations {
+		annotations[key] = value
+	}
+	annotations[virtv1.RestoreMemoryStateAnnotation] = memoryState
+	vmi.SetAnnotations(annotations)
+}
+
+// memoryStateExists returns false when the pvc holding the memory state is
+// gone, e.g. if it was deleted along with the snapshot it was saved by
+func (c *VMController) memoryStateExists(namespace, memoryState string) bool {
+	claimName, _, _ := strings.Cut(memoryState, "/")
+	pvc, err := storagetypes.GetPersistentVolumeClaimFromCache(namespace, claimName, c.pvcInformer)
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to get memory state pvc %s/%s", namespace, claimName)
+		return true
+	}
+	return pvc != nil && pvc.DeletionTimestamp == nil
+}
+
+// handleRestoreMemoryStateRequest removes the restore memory state annotation
+// from the vm once a vmi resuming from it has been created, so that the
+// memory state is only restored once, or once it is gone
+func (c *VMController) handleRestoreMemoryStateRequest(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	memoryState, exists := vm.Annotations[virtv1.RestoreMemoryStateAnnotation]
+	if !exists || vmi == nil {
+		return
+	}
+
+	if vmi.Annotations[virtv1.RestoreMemoryStateAnnotation] == memoryState || !c.memoryStateExists(vm.Namespace, memoryState) {
+		delete(vm.Annotations, virtv1.RestoreMemoryStateAnnotation)
+	}
+}
+
+// clearRestoreMemoryState removes the restore memory state annotation from
+// the vmi once it is running, the memory state has been restored by then and
+// must not be mounted by migration target pods
+func (c *VMController) clearRestoreMemoryState(vmi *virtv1.VirtualMachineInstance) error {
+	if vmi == nil || !vmi.IsRunning() {
+		return nil
+	}
+	memoryState, exists := vmi.Annotations[virtv1.RestoreMemoryStateAnnotation]
+	if !exists {
+		return nil
+	}
+
+	value, err := json.Marshal(memoryState)
+	if err != nil {
+		return err
+	}
+	key := patch.EscapeJSONPointer(virtv1.RestoreMemoryStateAnnotation)
+	ops := []string{
+		fmt.Sprintf(`{ "op": "test", "path": "/metadata/annotations/%s", "value": %s }`, key, string(value)),
+		fmt.Sprintf(`{ "op": "remove", "path": "/metadata/annotations/%s" }`, key),
+	}
+
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, controller.GeneratePatchBytes(ops), &v1.PatchOptions{})
+	return err
+}
+
 // setStableUUID makes sure the VirtualMachineInstance being started has a 'stable' UUID.
 // The UUID is 'stable' if doesn't change across reboots.
 func setupStableFirmwareUUID(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
@@ -2069,6 +2177,8 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 	syncStartFailureStatus(vm, vmi)
 	c.syncConditions(vm, vmi, syncErr)
 	c.setPrintableStatus(vm, vmi)
+	vm.Status.Timeline = timeline.Update(vm.Status.Timeline, vmi, time.Now())
+	c.syncResourceRecommendation(vm, vmi)
 
 	// only update if necessary
 	if !equality.Semantic.DeepEqual(vm.Status, vmOrig.Status) {
@@ -2089,6 +2199,37 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 	return nil
 }
 
+// syncResourceRecommendation recomputes the instancetype recommendation from the resource usage
+// reported by the VMI. The last recommendation is kept while the VM is stopped.
+func (c *VMController) syncResourceRecommendation(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	if vmi == nil || vmi.Status.ResourceUsage == nil {
+		return
+	}
+
+	var clusterInstancetypes []*instancetypev1beta1.VirtualMachineClusterInstancetype
+	for _, obj := range c.clusterInstancetypeInformer.GetStore().List() {
+		if clusterInstancetype, ok := obj.(*instancetypev1beta1.VirtualMachineClusterInstancetype); ok {
+			clusterInstancetypes = append(clusterInstancetypes, clusterInstancetype)
+		}
+	}
+
+	now := v1.Now()
+	recommendation := instancetype.Recommend(vm, vmi, clusterInstancetypes, now)
+	if recommendation == nil {
+		return
+	}
+
+	// Only bump the update time when the recommendation changed, to avoid needless status updates
+	if current := vm.Status.ResourceRecommendation; current != nil {
+		recommendation.LastUpdateTime = current.LastUpdateTime
+		if equality.Semantic.DeepEqual(current, recommendation) {
+			return
+		}
+		recommendation.LastUpdateTime = now
+	}
+	vm.Status.ResourceRecommendation = recommendation
+}
+
 func (c *VMController) setPrintableStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
 	// For each status, there's a separate function that evaluates
 	// whether the status is "true" for the given VM.
@@ -2438,7 +2579,14 @@ func (c *VMController) updateMemoryDumpRequest(vm *virtv1.VirtualMachine, vmi *v
 	case virtv1.MemoryDumpAssociating:
 		// Update Phase to InProgrees once the memory dump
 		// is in the list of vm volumes
-		for _, volume := range vm.Spec.Template.Spec.Volumes {
+		volumes := vm.Spec.Template.Spec.Volumes
+		if isSavedStateMemoryDump(vm.Status.MemoryDumpRequest) {
+			volumes = nil
+			if vmi != nil {
+				volumes = vmi.Spec.Volumes
+			}
+		}
+		for _, volume := range volumes {
 			if vm.Status.MemoryDumpRequest.ClaimName == volume.Name {
 				updatedMemoryDumpReq.Phase = virtv1.MemoryDumpInProgress
 				break
@@ -2638,6 +2786,11 @@ func (c *VMController) sync(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachin
 			syncErr = &syncErrorImpl{fmt.Errorf("Error encountered while handling CPU change request: %v", err), HotPlugCPUErrorReason}
 		}
 
+		c.handleRestoreMemoryStateRequest(vmCopy, vmi)
+		if err := c.clearRestoreMemoryState(vmi); err != nil {
+			syncErr = &syncErrorImpl{fmt.Errorf("Error encountered when trying to clear the restore memory state of the vmi: %v", err), FailedUpdateErrorReason}
+		}
+
 		if syncErr == nil {
 			if !equality.Semantic.DeepEqual(vm, vmCopy) {
 				vm, err = c.clientset.VirtualMachine(vmCopy.Namespace).Update(context.Background(), vmCopy)
@@ -2751,10 +2904,6 @@ func (c *VMController) handleDynamicInterfaceRequests(vm *virtv1.VirtualMachine,
 func (c *VMController) setupLiveFeatures(
 	vm *virtv1.VirtualMachine,
 	vmi, VMIDefaults *virtv1.VirtualMachineInstance) {
-	const (
-		maxSocketsRatio = 4
-	)
-
 	if vm.Spec.LiveUpdateFeatures == nil {
 		return
 	}
@@ -18,6 +18,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
@@ -68,6 +69,7 @@ var _ = Describe("VirtualMachine", func() {
 		var dataVolumeSource *framework.FakeControllerSource
 		var pvcInformer cache.SharedIndexInformer
 		var crInformer cache.SharedIndexInformer
+		var clusterInstancetypeInformer cache.SharedIndexInformer
 		var instancetypeMethods *testutils.MockInstancetypeMethods
 		var stop chan struct{}
 		var controller *VMController
@@ -108,6 +110,7 @@ var _ = Describe("VirtualMachine", func() {
 			vmiInformer, vmiSource = testutils.NewFakeInformerWithIndexersFor(&virtv1.VirtualMachineInstance{}, virtcontroller.GetVMIInformerIndexers())
 			vmInformer, vmSource = testutils.NewFakeInformerWithIndexersFor(&virtv1.VirtualMachine{}, virtcontroller.GetVirtualMachineInformerIndexers())
 			pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+			clusterInstancetypeInformer, _ = testutils.NewFakeInformerFor(&instancetypev1beta1.VirtualMachineClusterInstancetype{})
 			crInformer, _ = testutils.NewFakeInformerWithIndexersFor(&appsv1.ControllerRevision{}, cache.Indexers{
 				"vm": func(obj interface{}) ([]string, error) {
 					cr := obj.(*appsv1.ControllerRevision)
@@ -132,6 +135,7 @@ var _ = Describe("VirtualMachine", func() {
 				dataVolumeInformer,
 				pvcInformer,
 				crInformer,
+				clusterInstancetypeInformer,
 				instancetypeMethods,
 				recorder,
 				virtClient,
@@ -1364,6 +1368,55 @@ var _ = Describe("VirtualMachine", func() {
 				Expect(err).ToNot(HaveOccurred())
 			})
 
+			DescribeTable("should clear the restore memory state from the vmi", func(phase virtv1.VirtualMachineInstancePhase, shouldPatch bool) {
+				_, vmi := DefaultVirtualMachine(true)
+				vmi.Status.Phase = phase
+				vmi.ObjectMeta.Annotations = map[string]string{
+					virtv1.RestoreMemoryStateAnnotation: "memory-state/memory.state",
+				}
+
+				if shouldPatch {
+					patch := `[{ "op": "test", "path": "/metadata/annotations/kubevirt.io~1restore-memory-state", "value": "memory-state/memory.state" }, { "op": "remove", "path": "/metadata/annotations/kubevirt.io~1restore-memory-state" }]`
+					vmiInterface.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, []byte(patch), &metav1.PatchOptions{}).Return(vmi, nil)
+				}
+
+				Expect(controller.clearRestoreMemoryState(vmi)).To(Succeed())
+			},
+				Entry("once it is running", virtv1.Running, true),
+				Entry("not before it is running", virtv1.Scheduled, false),
+			)
+
+			Context("restored with a memory state", func() {
+				const memoryState = "memory-state/memory.state"
+
+				var vm *virtv1.VirtualMachine
+
+				BeforeEach(func() {
+					vm, _ = DefaultVirtualMachine(true)
+					vm.Annotations = map[string]string{virtv1.RestoreMemoryStateAnnotation: memoryState}
+				})
+
+				It("should resume the vmi from the memory state", func() {
+					pvc := k8sv1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "memory-state", Namespace: vm.Namespace}}
+					Expect(pvcInformer.GetStore().Add(&pvc)).To(Succeed())
+
+					vmi := controller.setupVMIFromVM(vm)
+					Expect(vmi.Annotations).To(HaveKeyWithValue(virtv1.RestoreMemoryStateAnnotation, memoryState))
+
+					controller.handleRestoreMemoryStateRequest(vm, vmi)
+					Expect(vm.Annotations).ToNot(HaveKey(virtv1.RestoreMemoryStateAnnotation))
+				})
+
+				It("should boot the vmi when the memory state was deleted along with the snapshot", func() {
+					vmi := controller.setupVMIFromVM(vm)
+					Expect(vmi.Annotations).ToNot(HaveKey(virtv1.RestoreMemoryStateAnnotation))
+					testutils.ExpectEvent(recorder, MemoryStateUnavailableReason)
+
+					controller.handleRestoreMemoryStateRequest(vm, vmi)
+					Expect(vm.Annotations).ToNot(HaveKey(virtv1.RestoreMemoryStateAnnotation))
+				})
+			})
+
 			DescribeTable("should get the generation annotation from the vmi", func(annotations map[string]string, desiredGeneration *string, desiredErr error) {
 				_, vmi := DefaultVirtualMachine(true)
 				vmi.ObjectMeta.Annotations = annotations
@@ -1936,6 +1989,69 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		Context("resource recommendation", func() {
+			BeforeEach(func() {
+				Expect(clusterInstancetypeInformer.GetStore().Add(&instancetypev1beta1.VirtualMachineClusterInstancetype{
+					ObjectMeta: metav1.ObjectMeta{Name: "u1.small"},
+					Spec: instancetypev1beta1.VirtualMachineInstancetypeSpec{
+						CPU:    instancetypev1beta1.CPUInstancetype{Guest: 1},
+						Memory: instancetypev1beta1.MemoryInstancetype{Guest: resource.MustParse("2Gi")},
+					},
+				})).To(Succeed())
+			})
+
+			withResourceUsage := func(vmi *virtv1.VirtualMachineInstance) {
+				vmi.Spec.Domain.CPU = &virtv1.CPU{Sockets: 4, Cores: 1, Threads: 1}
+				vmi.Spec.Domain.Memory = &virtv1.Memory{Guest: kvpointer.P(resource.MustParse("8Gi"))}
+				vmi.Status.ResourceUsage = &virtv1.VirtualMachineInstanceResourceUsage{
+					PeakCPU:    resource.MustParse("250m"),
+					PeakMemory: kvpointer.P(resource.MustParse("1Gi")),
+					Samples:    instancetype.MinRecommendationSamples,
+				}
+			}
+
+			It("should recommend a cluster instancetype from the VMI resource usage", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				markAsReady(vmi)
+				withResourceUsage(vmi)
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+					recommendation := arg.(*virtv1.VirtualMachine).Status.ResourceRecommendation
+					Expect(recommendation).ToNot(BeNil())
+					Expect(recommendation.Instancetype).To(Equal("u1.small"))
+					Expect(recommendation.Reason).To(Equal(virtv1.VirtualMachineResourceRecommendationOversized))
+				}).Return(nil, nil)
+
+				controller.Execute()
+			})
+
+			It("should keep the update time of an unchanged recommendation", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				markAsReady(vmi)
+				withResourceUsage(vmi)
+				vm.Status.Created = true
+				vm.Status.Ready = true
+				vm.Status.PrintableStatus = virtv1.VirtualMachineStatusRunning
+				vm.Status.ResourceRecommendation = instancetype.Recommend(vm, vmi, []*instancetypev1beta1.VirtualMachineClusterInstancetype{
+					clusterInstancetypeInformer.GetStore().List()[0].(*instancetypev1beta1.VirtualMachineClusterInstancetype),
+				}, metav1.NewTime(time.Now().Add(-time.Hour)))
+
+				controller.syncResourceRecommendation(vm, vmi)
+				Expect(vm.Status.ResourceRecommendation.LastUpdateTime.Time).To(BeTemporally("<", time.Now().Add(-time.Minute)))
+			})
+
+			It("should keep the recommendation while the VM is stopped", func() {
+				vm, _ := DefaultVirtualMachine(false)
+				vm.Status.ResourceRecommendation = &virtv1.VirtualMachineResourceRecommendation{Instancetype: "u1.small"}
+
+				controller.syncResourceRecommendation(vm, nil)
+				Expect(vm.Status.ResourceRecommendation.Instancetype).To(Equal("u1.small"))
+			})
+		})
+
 		It("should have stable firmware UUIDs", func() {
 			vm1, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
 			vmi1 := controller.setupVMIFromVM(vm1)
@@ -4962,6 +5078,59 @@ var _ = Describe("VirtualMachine", func() {
 				vmi := controller.setupVMIFromVM(vm)
 				Expect(vmi.Spec.Domain.CPU.MaxSockets).To(Equal(defaultSockets * 4))
 			})
+
+			Context("with an instancetype", func() {
+				var vm *virtv1.VirtualMachine
+
+				enableLiveUpdateFeatures := func(maxCpuSockets *uint32) {
+					testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, &v1.KubeVirt{
+						Spec: v1.KubeVirtSpec{
+							Configuration: v1.KubeVirtConfiguration{
+								DeveloperConfiguration: &virtv1.DeveloperConfiguration{
+									FeatureGates: []string{virtconfig.VMLiveUpdateFeaturesGate},
+								},
+								LiveUpdateConfiguration: &virtv1.LiveUpdateConfiguration{
+									MaxCpuSockets: maxCpuSockets,
+								},
+							},
+						},
+					})
+				}
+
+				BeforeEach(func() {
+					vm, _ = DefaultVirtualMachine(true)
+					vm.Spec.Instancetype = &virtv1.InstancetypeMatcher{
+						Name: "instancetype",
+					}
+					instancetypeMethods.FindInstancetypeSpecFunc = func(_ *virtv1.VirtualMachine) (*instancetypev1beta1.VirtualMachineInstancetypeSpec, error) {
+						return &instancetypev1beta1.VirtualMachineInstancetypeSpec{}, nil
+					}
+					instancetypeMethods.ApplyToVmiFunc = func(_ *k8sfield.Path, _ *instancetypev1beta1.VirtualMachineInstancetypeSpec, _ *instancetypev1beta1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) instancetype.Conflicts {
+						vmiSpec.Domain.CPU = &virtv1.CPU{Sockets: 4, Cores: 1, Threads: 1}
+						return nil
+					}
+				})
+
+				It("should not reserve sockets without live update features", func() {
+					newVMI := controller.setupVMIFromVM(vm)
+					Expect(controller.applyInstancetypeToVmi(vm, newVMI, nil)).To(Succeed())
+					Expect(newVMI.Spec.Domain.CPU.MaxSockets).To(BeZero())
+				})
+
+				It("should reserve sockets fitting the topology of the instancetype", func() {
+					enableLiveUpdateFeatures(nil)
+					newVMI := controller.setupVMIFromVM(vm)
+					Expect(controller.applyInstancetypeToVmi(vm, newVMI, nil)).To(Succeed())
+					Expect(newVMI.Spec.Domain.CPU.MaxSockets).To(Equal(uint32(16)))
+				})
+
+				It("should reserve the maximum sockets configured in cluster config", func() {
+					enableLiveUpdateFeatures(kvpointer.P(maxSocketsFromConfig))
+					newVMI := controller.setupVMIFromVM(vm)
+					Expect(controller.applyInstancetypeToVmi(vm, newVMI, nil)).To(Succeed())
+					Expect(newVMI.Spec.Domain.CPU.MaxSockets).To(Equal(maxSocketsFromConfig))
+				})
+			})
 		})
 
 		Context("CPU topology", func() {
@@ -146,6 +146,8 @@ func NewVMIController(templateService services.TemplateService,
 	cdiConfigInformer cache.SharedIndexInformer,
 	clusterConfig *virtconfig.ClusterConfig,
 	topologyHinter topology.Hinter,
+	migrationPolicyInformer cache.SharedIndexInformer,
+	namespaceStore cache.Store,
 ) (*VMIController, error) {
 
 	c := &VMIController{
@@ -165,6 +167,9 @@ func NewVMIController(templateService services.TemplateService,
 		clusterConfig:      clusterConfig,
 		topologyHinter:     topologyHinter,
 		cidsMap:            newCIDsMap(),
+
+		migrationPolicyInformer: migrationPolicyInformer,
+		namespaceStore:          namespaceStore,
 	}
 
 	_, err := c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -265,6 +270,9 @@ type VMIController struct {
 	cdiConfigInformer  cache.SharedIndexInformer
 	clusterConfig      *virtconfig.ClusterConfig
 	cidsMap            *cidsMap
+
+	migrationPolicyInformer cache.SharedIndexInformer
+	namespaceStore          cache.Store
 }
 
 func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -281,6 +289,7 @@ func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
 		c.cdiConfigInformer.HasSynced,
 		c.cdiInformer.HasSynced,
 		c.pvcInformer.HasSynced,
+		c.migrationPolicyInformer.HasSynced,
 	)
 	// Sync the CIDs from exist VMIs
 	var vmis []*virtv1.VirtualMachineInstance
@@ -728,6 +737,8 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 			c.syncCPUHotplug(vmiCopy)
 		}
 
+		c.syncMigrationConvergenceCondition(vmiCopy)
+
 	case vmi.IsScheduled():
 		// Nothing here
 		break
@@ -773,6 +784,7 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 			c.vmiExpectations.LowerExpectations(key, 1, 0)
 			return err
 		}
+		recordPhaseSpans(vmi, vmiCopy, dataVolumes)
 	}
 
 	return nil
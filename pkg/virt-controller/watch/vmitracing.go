@@ -0,0 +1,69 @@
+package watch
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	virtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/tracing"
+)
+
+// recordPhaseSpans records a span for every phase the VMI left, so that the time spent
+// waiting for DataVolumes, for the scheduler and for virt-handler can be told apart.
+// Once the VMI runs, or failed to start, the root span of the lifecycle trace is recorded.
+func recordPhaseSpans(oldVMI, newVMI *virtv1.VirtualMachineInstance, dataVolumes []*cdiv1.DataVolume) {
+	if oldVMI.Status.Phase == newVMI.Status.Phase || !tracing.Enabled() {
+		return
+	}
+	traceContext := tracing.FromObject(newVMI)
+	if !traceContext.IsValid() {
+		return
+	}
+
+	now := time.Now()
+	span := tracing.StartSpanFromObject(newVMI, fmt.Sprintf("virt-controller.Phase%s", oldVMI.Status.Phase))
+	span.StartTime = phaseStartTime(newVMI, oldVMI.Status.Phase)
+	span.SetAttribute("kubevirt.io/phase", string(oldVMI.Status.Phase))
+	span.SetAttribute("kubevirt.io/next-phase", string(newVMI.Status.Phase))
+	if newVMI.Status.NodeName != "" {
+		span.SetAttribute("k8s.node.name", newVMI.Status.NodeName)
+	}
+	if oldVMI.Status.Phase == virtv1.Pending && len(dataVolumes) > 0 {
+		span.SetAttribute("kubevirt.io/datavolumes", strconv.Itoa(len(dataVolumes)))
+	}
+	span.End()
+
+	if newVMI.Status.Phase != virtv1.Running && (!newVMI.IsFinal() || oldVMI.Status.Phase == virtv1.Running) {
+		return
+	}
+
+	root := &tracing.Span{
+		Name:        "VirtualMachineInstance.Start",
+		SpanContext: traceContext,
+		StartTime:   newVMI.CreationTimestamp.Time,
+		EndTime:     now,
+		Attributes: map[string]string{
+			"k8s.namespace.name": newVMI.Namespace,
+			"kubevirt.io/name":   newVMI.Name,
+			"kubevirt.io/uid":    string(newVMI.UID),
+			"kubevirt.io/phase":  string(newVMI.Status.Phase),
+		},
+	}
+	if newVMI.Status.Phase != virtv1.Running {
+		root.Err = fmt.Errorf("VirtualMachineInstance did not start, reached phase %s", newVMI.Status.Phase)
+	}
+	tracing.RecordSpan(root)
+}
+
+func phaseStartTime(vmi *virtv1.VirtualMachineInstance, phase virtv1.VirtualMachineInstancePhase) time.Time {
+	for _, transition := range vmi.Status.PhaseTransitionTimestamps {
+		if transition.Phase == phase {
+			return transition.PhaseTransitionTimestamp.Time
+		}
+	}
+	return vmi.CreationTimestamp.Time
+}
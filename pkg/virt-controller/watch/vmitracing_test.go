@@ -0,0 +1,141 @@
+package watch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/tracing"
+)
+
+var _ = Describe("VMI lifecycle tracing", func() {
+	var (
+		spansFile    string
+		traceContext tracing.SpanContext
+		oldVMI       *virtv1.VirtualMachineInstance
+	)
+
+	type exportedSpan struct {
+		Name         string `json:"name"`
+		SpanID       string `json:"spanId"`
+		ParentSpanID string `json:"parentSpanId"`
+		Status       struct {
+			Code int `json:"code"`
+		} `json:"status"`
+	}
+
+	exportedSpans := func() []exportedSpan {
+		tracing.Shutdown()
+		content, err := os.ReadFile(spansFile)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		Expect(err).ToNot(HaveOccurred())
+
+		var spans []exportedSpan
+		for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+			request := struct {
+				ResourceSpans []struct {
+					ScopeSpans []struct {
+						Spans []exportedSpan `json:"spans"`
+					} `json:"scopeSpans"`
+				} `json:"resourceSpans"`
+			}{}
+			Expect(json.Unmarshal([]byte(line), &request)).To(Succeed())
+			for _, resourceSpans := range request.ResourceSpans {
+				for _, scopeSpans := range resourceSpans.ScopeSpans {
+					spans = append(spans, scopeSpans.Spans...)
+				}
+			}
+		}
+		return spans
+	}
+
+	BeforeEach(func() {
+		spansFile = filepath.Join(GinkgoT().TempDir(), "spans.json")
+		tracing.Configure("virt-controller", &virtv1.TracingConfiguration{File: &virtv1.TracingFileExporter{Path: spansFile}})
+		DeferCleanup(tracing.Shutdown)
+
+		traceContext = tracing.NewRootSpanContext()
+		oldVMI = &virtv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "testvmi",
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+			},
+		}
+		tracing.SetObjectSpanContext(oldVMI, traceContext)
+	})
+
+	withPhase := func(vmi *virtv1.VirtualMachineInstance, phase virtv1.VirtualMachineInstancePhase) *virtv1.VirtualMachineInstance {
+		vmi = vmi.DeepCopy()
+		vmi.Status.Phase = phase
+		vmi.Status.PhaseTransitionTimestamps = append(vmi.Status.PhaseTransitionTimestamps, virtv1.VirtualMachineInstancePhaseTransitionTimestamp{
+			Phase:                    phase,
+			PhaseTransitionTimestamp: metav1.Now(),
+		})
+		return vmi
+	}
+
+	It("should record a span for the phase the VMI left", func() {
+		oldVMI = withPhase(oldVMI, virtv1.Pending)
+
+		recordPhaseSpans(oldVMI, withPhase(oldVMI, virtv1.Scheduling), nil)
+
+		spans := exportedSpans()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name).To(Equal("virt-controller.PhasePending"))
+		Expect(spans[0].ParentSpanID).To(Equal(traceContext.SpanID.String()))
+	})
+
+	It("should record the root span once the VMI runs", func() {
+		oldVMI = withPhase(oldVMI, virtv1.Scheduled)
+
+		recordPhaseSpans(oldVMI, withPhase(oldVMI, virtv1.Running), nil)
+
+		spans := exportedSpans()
+		Expect(spans).To(HaveLen(2))
+		Expect(spans[1].Name).To(Equal("VirtualMachineInstance.Start"))
+		Expect(spans[1].SpanID).To(Equal(traceContext.SpanID.String()))
+		Expect(spans[1].ParentSpanID).To(BeEmpty())
+		Expect(spans[1].Status.Code).To(Equal(1))
+	})
+
+	It("should record a failed root span when the VMI fails to start", func() {
+		oldVMI = withPhase(oldVMI, virtv1.Scheduling)
+
+		recordPhaseSpans(oldVMI, withPhase(oldVMI, virtv1.Failed), nil)
+
+		spans := exportedSpans()
+		Expect(spans).To(HaveLen(2))
+		Expect(spans[1].Name).To(Equal("VirtualMachineInstance.Start"))
+		Expect(spans[1].Status.Code).To(Equal(2))
+	})
+
+	It("should not record the root span again when a running VMI stops", func() {
+		oldVMI = withPhase(oldVMI, virtv1.Running)
+
+		recordPhaseSpans(oldVMI, withPhase(oldVMI, virtv1.Succeeded), nil)
+
+		spans := exportedSpans()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name).To(Equal("virt-controller.PhaseRunning"))
+	})
+
+	It("should not record spans for a VMI which is not traced", func() {
+		oldVMI.Annotations = nil
+		oldVMI = withPhase(oldVMI, virtv1.Pending)
+
+		recordPhaseSpans(oldVMI, withPhase(oldVMI, virtv1.Scheduling), nil)
+
+		Expect(exportedSpans()).To(BeEmpty())
+	})
+})
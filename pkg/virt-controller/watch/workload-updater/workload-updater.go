@@ -14,6 +14,7 @@ import (
 	policy "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -327,6 +328,15 @@ func (c *WorkloadUpdateController) doesRequireMigration(vmi *virtv1.VirtualMachi
 
 	return false
 }
+
+// isCanarySoaking returns true while the canary virt-handlers of the current update soak
+func isCanarySoaking(kv *virtv1.KubeVirt) bool {
+	status := kv.Status.CanaryUpgrade
+	return kv.Spec.CanaryUpgrade != nil && status != nil &&
+		status.TargetDeploymentID == kv.Status.TargetDeploymentID &&
+		status.Phase == virtv1.CanaryUpgradePhaseSoaking
+}
+
 func (c *WorkloadUpdateController) getUpdateData(kv *virtv1.KubeVirt) *updateData {
 	data := &updateData{}
 
@@ -349,6 +359,17 @@ func (c *WorkloadUpdateController) getUpdateData(kv *virtv1.KubeVirt) *updateDat
 		}
 	}
 
+	// while the canary soaks only the workloads of the canary namespaces
+	// are migrated to the canary nodes
+	canarySoaking := isCanarySoaking(kv)
+	canaryNamespaces := make(map[string]bool)
+	if canarySoaking {
+		automatedShutdownAllowed = false
+		for _, namespace := range kv.Spec.CanaryUpgrade.Namespaces {
+			canaryNamespaces[namespace] = true
+		}
+	}
+
 	data.numActiveMigrations = len(migrations)
 
 	objs := c.vmiInformer.GetStore().List()
@@ -371,6 +392,8 @@ func (c *WorkloadUpdateController) getUpdateData(kv *virtv1.KubeVirt) *updateDat
 			continue
 		} else if exists := lookup[vmi.Namespace+"/"+vmi.Name]; exists {
 			continue
+		} else if canarySoaking && !canaryNamespaces[vmi.Namespace] {
+			continue
 		}
 
 		if automatedMigrationAllowed && vmi.IsMigratable() {
@@ -402,6 +425,10 @@ func (c *WorkloadUpdateController) execute(key string) error {
 
 	kv := obj.(*virtv1.KubeVirt)
 
+	if isCanarySoaking(kv) {
+		return c.sync(kv)
+	}
+
 	// don't update workloads unless the infra is completely deployed and not updating
 	if kv.Status.Phase != virtv1.KubeVirtPhaseDeployed {
 		return nil
@@ -517,15 +544,22 @@ func (c *WorkloadUpdateController) sync(kv *virtv1.KubeVirt) error {
 	wg.Add(wgLen)
 	errChan := make(chan error, wgLen)
 
+	canarySoaking := isCanarySoaking(kv)
+
 	c.migrationExpectations.ExpectCreations(key, migrateCount)
 	for _, vmi := range migrationCandidates {
 		go func(vmi *virtv1.VirtualMachineInstance) {
 			defer wg.Done()
+			annotations := map[string]string{
+				virtv1.WorkloadUpdateMigrationAnnotation: "",
+			}
+			if canarySoaking {
+				// the migration controller schedules the target pod on the canary nodes
+				annotations[virtv1.WorkloadUpdateTargetNodeSelectorAnnotation] = labels.Set(kv.Spec.CanaryUpgrade.NodeSelector).String()
+			}
 			createdMigration, err := c.clientset.VirtualMachineInstanceMigration(vmi.Namespace).Create(&virtv1.VirtualMachineInstanceMigration{
 				ObjectMeta: metav1.ObjectMeta{
-					Annotations: map[string]string{
-						virtv1.WorkloadUpdateMigrationAnnotation: "",
-					},
+					Annotations:  annotations,
 					GenerateName: "kubevirt-workload-update-",
 				},
 				Spec: virtv1.VirtualMachineInstanceMigrationSpec{
@@ -437,6 +437,65 @@ var _ = Describe("Workload Updater", func() {
 
 	})
 
+	Context("canary upgrade soaking", func() {
+		newSoakingKubeVirt := func(expectedNumOutdated int, namespaces ...string) *v1.KubeVirt {
+			kv := newKubeVirt(expectedNumOutdated)
+			kv.Status.Phase = v1.KubeVirtPhaseDeploying
+			kv.Status.TargetDeploymentID = "target"
+			kv.Status.ObservedDeploymentID = "observed"
+			kv.Spec.WorkloadUpdateStrategy.WorkloadUpdateMethods = []v1.WorkloadUpdateMethod{v1.WorkloadUpdateMethodLiveMigrate, v1.WorkloadUpdateMethodEvict}
+			kv.Spec.CanaryUpgrade = &v1.KubeVirtCanaryUpgradeStrategy{
+				NodeSelector: map[string]string{"canary": "true"},
+				Namespaces:   namespaces,
+			}
+			kv.Status.CanaryUpgrade = &v1.KubeVirtCanaryUpgradeStatus{
+				Phase:              v1.CanaryUpgradePhaseSoaking,
+				TargetDeploymentID: "target",
+			}
+			return kv
+		}
+
+		It("should only migrate VMIs of the canary namespaces to the canary nodes", func() {
+			newVirtualMachine("testvm-outdated-migratable", true, "madeup", vmiSource, podSource)
+			newVirtualMachine("testvm-outdated-non-migratable", false, "madeup", vmiSource, podSource)
+			waitForNumberOfInstancesOnVMIInformerCache(controller, 2)
+			addKubeVirt(newSoakingKubeVirt(2, v12.NamespaceDefault))
+
+			migrationInterface.EXPECT().Create(gomock.Any(), &metav1.CreateOptions{}).DoAndReturn(func(migration *v1.VirtualMachineInstanceMigration, _ *metav1.CreateOptions) (*v1.VirtualMachineInstanceMigration, error) {
+				Expect(migration.Spec.VMIName).To(Equal("testvm-outdated-migratable"))
+				Expect(migration.Annotations).To(HaveKey(v1.WorkloadUpdateMigrationAnnotation))
+				Expect(migration.Annotations).To(HaveKeyWithValue(v1.WorkloadUpdateTargetNodeSelectorAnnotation, "canary=true"))
+				return &v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil
+			}).Times(1)
+			evictionCount := 0
+			shouldExpectMultiplePodEvictions(&evictionCount)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineInstanceMigrationReason)
+			Expect(evictionCount).To(BeZero())
+		})
+
+		It("should leave VMIs outside of the canary namespaces alone", func() {
+			newVirtualMachine("testvm-outdated-migratable", true, "madeup", vmiSource, podSource)
+			waitForNumberOfInstancesOnVMIInformerCache(controller, 1)
+			addKubeVirt(newSoakingKubeVirt(1, "canary-workloads"))
+
+			controller.Execute()
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should do nothing if the canary is not soaking", func() {
+			newVirtualMachine("testvm-outdated-migratable", true, "madeup", vmiSource, podSource)
+			waitForNumberOfInstancesOnVMIInformerCache(controller, 1)
+			kv := newSoakingKubeVirt(1, v12.NamespaceDefault)
+			kv.Status.CanaryUpgrade.Phase = v1.CanaryUpgradePhaseCanary
+			addKubeVirt(kv)
+
+			controller.Execute()
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
 	AfterEach(func() {
 
 		close(stop)
@@ -60,6 +60,14 @@ type Manager interface {
 	// SetCpuSet returns the cpu set
 	SetCpuSet(subcgroup string, cpulist []int) error
 
+	// SetCpuSetMems sets the memory nodes the cgroup is allowed to allocate memory from
+	// and migrates the already allocated memory to them
+	SetCpuSetMems(subcgroup string, memNodes []int) error
+
+	// SetCpuWeight sets the relative cpu weight, in cgroup v2 units, of the pod's cgroup, which
+	// competes with the other pods of the node. On cgroup v1 the weight is converted to cpu shares.
+	SetCpuWeight(weight uint64) error
+
 	// Create new child cgroup
 	CreateChildCgroup(name string, subSystem string) error
 
@@ -133,4 +133,16 @@ var _ = Describe("cgroup manager", func() {
 		Entry("for v2", V2),
 	)
 
+	DescribeTable("should convert the cpu weight to cpu shares", func(weight, shares uint64) {
+		Expect(convertCPUWeightToShares(weight)).To(Equal(shares))
+		if shares != 0 {
+			Expect(runc_cgroups.ConvertCPUSharesToCgroupV2Value(shares)).To(Equal(weight))
+		}
+	},
+		Entry("unset weight", uint64(0), uint64(0)),
+		Entry("minimum weight", uint64(1), uint64(2)),
+		Entry("default weight", uint64(100), uint64(2598)),
+		Entry("maximum weight", uint64(10000), uint64(262144)),
+	)
+
 })
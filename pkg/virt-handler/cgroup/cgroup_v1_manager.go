@@ -204,3 +204,15 @@ func (v *v1Manager) GetCgroupThreads() ([]int, error) {
 func (v *v1Manager) SetCpuSet(subcgroup string, cpulist []int) error {
 	return setCpuSetHelper(v, subcgroup, cpulist)
 }
+
+func (v *v1Manager) SetCpuSetMems(subcgroup string, memNodes []int) error {
+	// cgroup v1 only migrates already allocated pages when asked to
+	if err := writeCpuSetFile(v, subcgroup, "cpuset.memory_migrate", "1"); err != nil {
+		return err
+	}
+	return setCpuSetMemsHelper(v, subcgroup, memNodes)
+}
+
+func (v *v1Manager) SetCpuWeight(weight uint64) error {
+	return writePodCpuFile(v, "cpu", "cpu.shares", strconv.FormatUint(convertCPUWeightToShares(weight), 10))
+}
@@ -143,3 +143,11 @@ func (v *v2Manager) GetCgroupThreads() ([]int, error) {
 func (v *v2Manager) SetCpuSet(subcgroup string, cpulist []int) error {
 	return setCpuSetHelper(v, subcgroup, cpulist)
 }
+
+func (v *v2Manager) SetCpuSetMems(subcgroup string, memNodes []int) error {
+	return setCpuSetMemsHelper(v, subcgroup, memNodes)
+}
+
+func (v *v2Manager) SetCpuWeight(weight uint64) error {
+	return writePodCpuFile(v, "cpu", "cpu.weight", strconv.FormatUint(weight, 10))
+}
@@ -82,6 +82,26 @@ func (_mr *_MockManagerRecorder) SetCpuSet(arg0, arg1 interface{}) *gomock.Call
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetCpuSet", arg0, arg1)
 }
 
+func (_m *MockManager) SetCpuSetMems(subcgroup string, memNodes []int) error {
+	ret := _m.ctrl.Call(_m, "SetCpuSetMems", subcgroup, memNodes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockManagerRecorder) SetCpuSetMems(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetCpuSetMems", arg0, arg1)
+}
+
+func (_m *MockManager) SetCpuWeight(weight uint64) error {
+	ret := _m.ctrl.Call(_m, "SetCpuWeight", weight)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockManagerRecorder) SetCpuWeight(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetCpuWeight", arg0)
+}
+
 func (_m *MockManager) CreateChildCgroup(name string, subSystem string) error {
 	ret := _m.ctrl.Call(_m, "CreateChildCgroup", name, subSystem)
 	ret0, _ := ret[0].(error)
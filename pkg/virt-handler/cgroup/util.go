@@ -225,6 +225,16 @@ func getCgroupThreadsHelper(manager Manager, fname string) ([]int, error) {
 // set cpus "cpusList" on the allowed CPUs. Optionally on a subcgroup of
 // the pods control group (if subcgroup != nil).
 func setCpuSetHelper(manager Manager, subCgroup string, cpusList []int) error {
+	return writeCpuSetFile(manager, subCgroup, "cpuset.cpus", formatList(cpusList))
+}
+
+// set the memory nodes "memNodes" on the allowed memory nodes. Optionally on a
+// subcgroup of the pods control group (if subcgroup != nil).
+func setCpuSetMemsHelper(manager Manager, subCgroup string, memNodes []int) error {
+	return writeCpuSetFile(manager, subCgroup, "cpuset.mems", formatList(memNodes))
+}
+
+func writeCpuSetFile(manager Manager, subCgroup string, fname string, data string) error {
 	subSysPath, err := manager.GetBasePathToHostSubsystem("cpuset")
 	if err != nil {
 		return err
@@ -234,7 +244,31 @@ func setCpuSetHelper(manager Manager, subCgroup string, cpusList []int) error {
 		subSysPath = filepath.Join(subSysPath, subCgroup)
 	}
 
-	wVal := strings.Trim(strings.Replace(fmt.Sprint(cpusList), " ", ",", -1), "[]")
+	return runc_cgroups.WriteFile(subSysPath, fname, data)
+}
+
+// writePodCpuFile writes a file of the pod level cgroup, the parent of the compute container's cgroup
+func writePodCpuFile(manager Manager, subSystem string, fname string, data string) error {
+	subSysPath, err := manager.GetBasePathToHostSubsystem(subSystem)
+	if err != nil {
+		return err
+	}
+	return runc_cgroups.WriteFile(filepath.Dir(filepath.Clean(subSysPath)), fname, data)
+}
+
+// convertCPUWeightToShares is the inverse of runc's ConvertCPUSharesToCgroupV2Value,
+// it maps the cpu.weight range [1-10000] to the cpu.shares range [2-262144]
+func convertCPUWeightToShares(weight uint64) uint64 {
+	if weight == 0 {
+		return 0
+	}
+	if weight > 10000 {
+		weight = 10000
+	}
+	// round up, so that converting the shares back yields the same weight
+	return 2 + ((weight-1)*262142+9998)/9999
+}
 
-	return runc_cgroups.WriteFile(subSysPath, "cpuset.cpus", wVal)
+func formatList(list []int) string {
+	return strings.Trim(strings.Replace(fmt.Sprint(list), " ", ",", -1), "[]")
 }
@@ -52,6 +52,8 @@ import (
 	com "kubevirt.io/kubevirt/pkg/handler-launcher-com"
 	"kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/info"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	"kubevirt.io/kubevirt/pkg/tracing"
+	"kubevirt.io/kubevirt/pkg/util"
 	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
@@ -350,6 +352,12 @@ func (c *VirtLauncherClient) Close() {
 func (c *VirtLauncherClient) genericSendVMICmd(cmdName string,
 	cmdFunc func(ctx context.Context, request *cmdv1.VMIRequest, opts ...grpc.CallOption) (*cmdv1.Response, error),
 	vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error {
+	return c.genericSendVMICmdWithTimeout(cmdName, cmdFunc, vmi, options, longTimeout)
+}
+
+func (c *VirtLauncherClient) genericSendVMICmdWithTimeout(cmdName string,
+	cmdFunc func(ctx context.Context, request *cmdv1.VMIRequest, opts ...grpc.CallOption) (*cmdv1.Response, error),
+	vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions, timeout time.Duration) error {
 
 	vmiJson, err := json.Marshal(vmi)
 	if err != nil {
@@ -363,13 +371,27 @@ func (c *VirtLauncherClient) genericSendVMICmd(cmdName string,
 		Options: options,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	span := startCmdSpan(cmdName, vmi)
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	response, err := cmdFunc(ctx, request)
+	response, err := cmdFunc(tracing.OutgoingContext(ctx, span.SpanContext), request)
 
 	err = handleError(err, cmdName, response)
+	span.SetError(err)
 	return err
 }
+
+// startCmdSpan traces a command sent to virt-launcher as part of the VMI lifecycle trace.
+// Running VMIs are synced repeatedly, those syncs are not part of the lifecycle and are not traced.
+func startCmdSpan(cmdName string, vmi *v1.VirtualMachineInstance) *tracing.Span {
+	if cmdName == "SyncVMI" && vmi.IsRunning() {
+		return &tracing.Span{Name: cmdName}
+	}
+	return tracing.StartSpanFromObject(vmi, "virt-handler."+cmdName)
+}
+
 func IsUnimplemented(err error) bool {
 	if grpcStatus, ok := status.FromError(err); ok {
 		if grpcStatus.Code() == codes.Unimplemented {
@@ -378,11 +400,24 @@ func IsUnimplemented(err error) bool {
 	}
 	return false
 }
+// IsAborted returns true if the command was aborted within the guest,
+// e.g. a freeze aborted by a pre-freeze hook
+func IsAborted(err error) bool {
+	if grpcStatus, ok := status.FromError(err); ok {
+		if grpcStatus.Code() == codes.Aborted {
+			return true
+		}
+	}
+	return false
+}
+
 func handleError(err error, cmdName string, response *cmdv1.Response) error {
 	if IsDisconnected(err) {
 		return err
 	} else if IsUnimplemented(err) {
 		return err
+	} else if IsAborted(err) {
+		return err
 	} else if err != nil {
 		msg := fmt.Sprintf("unknown error encountered sending command %s: %s", cmdName, err.Error())
 		return fmt.Errorf(msg)
@@ -450,7 +485,8 @@ func (c *VirtLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance
 		UnfreezeTimeoutSeconds: unfreezeTimeoutSeconds,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	// The freeze hooks run within the request
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout+util.FreezeTimeout(vmi))
 	defer cancel()
 	response, err := c.v1client.FreezeVirtualMachine(ctx, request)
 
@@ -459,7 +495,7 @@ func (c *VirtLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance
 }
 
 func (c *VirtLauncherClient) UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error {
-	return c.genericSendVMICmd("Unfreeze", c.v1client.UnfreezeVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
+	return c.genericSendVMICmdWithTimeout("Unfreeze", c.v1client.UnfreezeVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{}, longTimeout+util.UnfreezeTimeout(vmi))
 }
 
 func (c *VirtLauncherClient) VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error {
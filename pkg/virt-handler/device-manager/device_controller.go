@@ -20,6 +20,8 @@
 package device_manager
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
 	"os"
 	"strings"
@@ -27,9 +29,12 @@ import (
 	"time"
 
 	"golang.org/x/net/context"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	k8scli "k8s.io/client-go/kubernetes/typed/core/v1"
 
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
@@ -133,6 +138,8 @@ type DeviceController struct {
 	stop                chan struct{}
 	mdevTypesManager    *MDEVTypesManager
 	clientset           k8scli.CoreV1Interface
+	mdevUsage           MDEVUsageFunc
+	mdevAllocations     *mdevAllocations
 }
 
 func NewDeviceController(
@@ -142,6 +149,7 @@ func NewDeviceController(
 	permanentPlugins []Device,
 	clusterConfig *virtconfig.ClusterConfig,
 	clientset k8scli.CoreV1Interface,
+	mdevUsage MDEVUsageFunc,
 ) *DeviceController {
 	permanentPluginsMap := make(map[string]Device, len(permanentPlugins))
 	for i := range permanentPlugins {
@@ -158,6 +166,8 @@ func NewDeviceController(
 		virtConfig:       clusterConfig,
 		mdevTypesManager: NewMDEVTypesManager(),
 		clientset:        clientset,
+		mdevUsage:        mdevUsage,
+		mdevAllocations:  newMDEVAllocations(),
 	}
 
 	return controller
@@ -233,7 +243,26 @@ func (c *DeviceController) updatePermittedHostDevicePlugins() []Device {
 			mdevResourceName := supportedMdevsMap[mdevTypeName]
 			log.Log.V(4).Infof("Discovered mediated device on the node, type: %s, resourceName: %s", mdevTypeName, mdevResourceName)
 
-			permittedDevices = append(permittedDevices, NewMediatedDevicePlugin(mdevUUIDs, mdevResourceName))
+			plugin := NewMediatedDevicePlugin(mdevUUIDs, mdevResourceName)
+			plugin.allocations = c.mdevAllocations
+			permittedDevices = append(permittedDevices, plugin)
+		}
+	}
+	if len(hostDevs.USB) != 0 {
+		var supportedUSBDevices []v1.USBHostDevice
+		for _, usbDev := range hostDevs.USB {
+			log.Log.V(4).Infof("Permitted USB device in the cluster, resourceName: %s, selectors: %v, externalProvider: %t",
+				usbDev.ResourceName,
+				usbDev.Selectors,
+				usbDev.ExternalResourceProvider)
+			// do not add a device plugin for this resource if it's being provided via an external device plugin
+			if !usbDev.ExternalResourceProvider {
+				supportedUSBDevices = append(supportedUSBDevices, usbDev)
+			}
+		}
+		for usbResourceName, usbDevices := range discoverPermittedHostUSBDevices(supportedUSBDevices) {
+			log.Log.V(4).Infof("Discovered %d USB devices on the node for the resource: %s", len(usbDevices), usbResourceName)
+			permittedDevices = append(permittedDevices, NewUSBDevicePlugin(usbDevices, usbResourceName))
 		}
 	}
 	return permittedDevices
@@ -306,14 +335,73 @@ func (c *DeviceController) refreshMediatedDeviceTypes() bool {
 	}
 	externallyProvidedMdevMap := c.getExternallyProvidedMdevs()
 
+	reconfigurationRequests, err := getMDEVReconfigurationRequests(node)
+	if err != nil {
+		log.Log.Reason(err).Error("ignoring the requested mdev reconfigurations")
+	}
+	usedMDEVs := c.getUsedMDEVs()
+
 	nodeDesiredMdevTypesList := c.virtConfig.GetDesiredMDEVTypes(node)
-	requiresDevicePluginsUpdate, err = c.mdevTypesManager.updateMDEVTypesConfiguration(nodeDesiredMdevTypesList, externallyProvidedMdevMap)
+	requiresDevicePluginsUpdate, err = c.mdevTypesManager.updateMDEVTypesConfiguration(nodeDesiredMdevTypesList, externallyProvidedMdevMap, reconfigurationRequests, usedMDEVs)
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to configure the desired mdev types: %s", strings.Join(nodeDesiredMdevTypesList, ", "))
 	}
+
+	status := c.mdevTypesManager.getMediatedDevicesStatus(reconfigurationRequests, usedMDEVs)
+	if err := c.publishMediatedDevicesStatus(node, status); err != nil {
+		log.Log.Reason(err).Error("failed to publish the mediated devices status")
+	}
 	return requiresDevicePluginsUpdate
 }
 
+// getUsedMDEVs returns nil as long as it can't be determined which mediated devices are used by VMIs.
+// Mediated devices recently handed out to pods are used as well, their VMIs may not be known on the node yet.
+func (c *DeviceController) getUsedMDEVs() map[string]struct{} {
+	if c.mdevUsage == nil {
+		return nil
+	}
+	usedMDEVs, synced := c.mdevUsage()
+	if !synced {
+		return nil
+	}
+	if c.mdevAllocations == nil {
+		return usedMDEVs
+	}
+	used := make(map[string]struct{}, len(usedMDEVs))
+	for uuid := range usedMDEVs {
+		used[uuid] = struct{}{}
+	}
+	for uuid := range c.mdevAllocations.recent() {
+		used[uuid] = struct{}{}
+	}
+	return used
+}
+
+func (c *DeviceController) publishMediatedDevicesStatus(node *k8sv1.Node, status *v1.NodeMediatedDevicesStatus) error {
+	rawStatus, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if node.Annotations[v1.MediatedDevicesStatusAnnotation] == string(rawStatus) {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				v1.MediatedDevicesStatusAnnotation: string(rawStatus),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.clientset.Nodes().Patch(context.Background(), c.host, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch node %s: %v", c.host, err)
+	}
+	return nil
+}
+
 func (c *DeviceController) refreshPermittedDevices() {
 	logger := log.DefaultLogger()
 	debugDevAdded := []string{}
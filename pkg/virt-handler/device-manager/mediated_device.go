@@ -66,6 +66,7 @@ type MediatedDevicePlugin struct {
 	initialized    bool
 	lock           *sync.Mutex
 	deregistered   chan struct{}
+	allocations    *mdevAllocations
 }
 
 func NewMediatedDevicePlugin(mdevs []*MDEV, resourceName string) *MediatedDevicePlugin {
@@ -213,6 +214,9 @@ func (dpi *MediatedDevicePlugin) Allocate(_ context.Context, r *pluginapi.Alloca
 			return resp, fmt.Errorf("failed to allocate resource for resourceName: %s", dpi.resourceName)
 		}
 	}
+	if dpi.allocations != nil {
+		dpi.allocations.allocated(allocatedDevices...)
+	}
 	return resp, nil
 }
 
@@ -0,0 +1,269 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package device_manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+)
+
+// MDEVUsageFunc returns the UUIDs of all mediated devices which are assigned to VMIs on this node.
+// The second return value is false as long as the usage can't be reliably determined yet.
+type MDEVUsageFunc func() (map[string]struct{}, bool)
+
+// mdevAllocationGracePeriod is how long a mediated device handed out to a pod is considered in use
+// without being reported by MDEVUsageFunc, it covers the time until the VMI shows up on the node.
+const mdevAllocationGracePeriod = 5 * time.Minute
+
+// mdevAllocations remembers when the device plugins handed out mediated devices to pods
+type mdevAllocations struct {
+	lock        sync.Mutex
+	allocations map[string]time.Time
+}
+
+func newMDEVAllocations() *mdevAllocations {
+	return &mdevAllocations{allocations: make(map[string]time.Time)}
+}
+
+func (a *mdevAllocations) allocated(uuids ...string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for _, uuid := range uuids {
+		a.allocations[uuid] = time.Now()
+	}
+}
+
+// recent returns the mediated devices which were handed out within the grace period
+func (a *mdevAllocations) recent() map[string]struct{} {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	recent := make(map[string]struct{})
+	for uuid, allocatedAt := range a.allocations {
+		if time.Since(allocatedAt) > mdevAllocationGracePeriod {
+			delete(a.allocations, uuid)
+			continue
+		}
+		recent[uuid] = struct{}{}
+	}
+	return recent
+}
+
+type mdevInstance struct {
+	uuid     string
+	parentID string
+	typeID   string
+	typeName string
+}
+
+// getMDEVReconfigurationRequests returns the requested mdev type for each parent device, as set by the admin on the node
+func getMDEVReconfigurationRequests(node *k8sv1.Node) (map[string]string, error) {
+	requests := make(map[string]string)
+	rawRequests, exists := node.Annotations[v1.MediatedDevicesReconfigurationAnnotation]
+	if !exists || rawRequests == "" {
+		return requests, nil
+	}
+	if err := json.Unmarshal([]byte(rawRequests), &requests); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s annotation: %v", v1.MediatedDevicesReconfigurationAnnotation, err)
+	}
+	for parentID, mdevType := range requests {
+		requests[parentID] = removeSelectorSpaces(mdevType)
+	}
+	return requests, nil
+}
+
+func readMDEVTypeName(typePath string) string {
+	// #nosec No risk for path injection. typePath is composed from static sysfs base paths
+	rawName, err := os.ReadFile(filepath.Join(typePath, "name"))
+	if err != nil {
+		return ""
+	}
+	return removeSelectorSpaces(string(rawName))
+}
+
+// getMDEVInstance reads the parent device and the type of an existing mediated device
+func getMDEVInstance(mdevUUID string) (*mdevInstance, error) {
+	// mdev_type links to the type directory of the parent device, e.g.
+	// /sys/devices/pci0000:64/0000:64:00.0/0000:65:00.0/mdev_supported_types/nvidia-222
+	typePath, err := filepath.EvalSymlinks(filepath.Join(mdevBasePath, mdevUUID, "mdev_type"))
+	if err != nil {
+		return nil, err
+	}
+	typePathParts := strings.Split(typePath, string(os.PathSeparator))
+	if len(typePathParts) < 3 {
+		return nil, fmt.Errorf("invalid mdev type path: %s", typePath)
+	}
+	return &mdevInstance{
+		uuid:     mdevUUID,
+		parentID: typePathParts[len(typePathParts)-3],
+		typeID:   typePathParts[len(typePathParts)-1],
+		typeName: readMDEVTypeName(typePath),
+	}, nil
+}
+
+// discoverMDEVInstances returns all existing mediated devices grouped by their parent device
+func discoverMDEVInstances() map[string][]*mdevInstance {
+	instances := make(map[string][]*mdevInstance)
+	files, err := os.ReadDir(mdevBasePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Log.Reason(err).Errorf("failed to read the content of %s directory", mdevBasePath)
+		}
+		return instances
+	}
+	for _, file := range files {
+		instance, err := getMDEVInstance(file.Name())
+		if err != nil {
+			log.Log.Reason(err).Warningf("failed to determine the type of mdev %s", file.Name())
+			continue
+		}
+		instances[instance.parentID] = append(instances[instance.parentID], instance)
+	}
+	return instances
+}
+
+// findMDEVTypeID looks up the ID of a type supported by the parent device, the type can be requested by its ID or name
+func findMDEVTypeID(parentID string, mdevType string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(mdevClassBusPath, parentID, "mdev_supported_types", "*"))
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files {
+		typeID := filepath.Base(file)
+		if typeID == mdevType || readMDEVTypeName(file) == mdevType {
+			return typeID, nil
+		}
+	}
+	return "", fmt.Errorf("mdev type %s is not supported by device %s", mdevType, parentID)
+}
+
+// reconfigureMDEVParents drives every parent device with a reconfiguration request towards the requested type.
+// Mediated devices of a different type which are not in use are removed right away, so that they
+// are no longer offered to new VMIs. The requested type is only created once all of them are gone.
+func (m *MDEVTypesManager) reconfigureMDEVParents(requests map[string]string, usedMDEVs map[string]struct{}) {
+	instances := discoverMDEVInstances()
+	for parentID, mdevType := range requests {
+		typeID, err := findMDEVTypeID(parentID, mdevType)
+		if err != nil {
+			log.Log.Reason(err).Errorf("failed to reconfigure mdev parent %s", parentID)
+			m.parentErrors[parentID] = err.Error()
+			continue
+		}
+
+		draining := false
+		matching := 0
+		removed := 0
+		for _, instance := range instances[parentID] {
+			if instance.typeID == typeID {
+				matching++
+				continue
+			}
+			if _, used := usedMDEVs[instance.uuid]; used {
+				draining = true
+				continue
+			}
+			if err := Handler.RemoveMDEVType(instance.uuid); err != nil {
+				m.parentErrors[parentID] = err.Error()
+				continue
+			}
+			removed++
+		}
+		if _, failed := m.parentErrors[parentID]; failed {
+			continue
+		}
+		if draining {
+			log.Log.Infof("waiting for VMIs to release the mediated devices of %s before reconfiguring it to %s", parentID, mdevType)
+			m.drainingParents[parentID] = struct{}{}
+			continue
+		}
+		if matching == 0 || removed > 0 {
+			if err := createMdevTypes(typeID, parentID); err != nil {
+				m.parentErrors[parentID] = err.Error()
+			}
+		}
+	}
+}
+
+// getMediatedDevicesStatus reports the configuration of all mdev capable devices on the node
+func (m *MDEVTypesManager) getMediatedDevicesStatus(requests map[string]string, usedMDEVs map[string]struct{}) *v1.NodeMediatedDevicesStatus {
+	m.mdevsConfigurationMutex.Lock()
+	defer m.mdevsConfigurationMutex.Unlock()
+
+	status := &v1.NodeMediatedDevicesStatus{}
+	parents, err := os.ReadDir(mdevClassBusPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Log.Reason(err).Errorf("failed to read the content of %s directory", mdevClassBusPath)
+		}
+		return status
+	}
+	instances := discoverMDEVInstances()
+	for _, parent := range parents {
+		parentID := parent.Name()
+		parentStatus := v1.MediatedDeviceParentStatus{
+			PCIAddress:                  parentID,
+			RequestedMediatedDeviceType: requests[parentID],
+			Phase:                       v1.MediatedDeviceParentUnconfigured,
+		}
+
+		types := make(map[string]struct{})
+		for _, instance := range instances[parentID] {
+			if instance.typeName != "" {
+				types[instance.typeName] = struct{}{}
+			} else {
+				types[instance.typeID] = struct{}{}
+			}
+			if _, used := usedMDEVs[instance.uuid]; used {
+				parentStatus.UsedInstances++
+			} else {
+				parentStatus.AvailableInstances++
+			}
+		}
+		if len(types) > 0 {
+			typeList := make([]string, 0, len(types))
+			for mdevType := range types {
+				typeList = append(typeList, mdevType)
+			}
+			sort.Strings(typeList)
+			parentStatus.MediatedDeviceType = strings.Join(typeList, ",")
+			parentStatus.Phase = v1.MediatedDeviceParentConfigured
+		}
+
+		if _, draining := m.drainingParents[parentID]; draining {
+			parentStatus.Phase = v1.MediatedDeviceParentDraining
+		}
+		if parentError, failed := m.parentErrors[parentID]; failed {
+			parentStatus.Phase = v1.MediatedDeviceParentFailed
+			parentStatus.Error = parentError
+		}
+		status.ParentDevices = append(status.ParentDevices, parentStatus)
+	}
+	return status
+}
@@ -37,6 +37,8 @@ var mdevClassBusPath = "/sys/class/mdev_bus"
 type MDEVTypesManager struct {
 	availableMdevTypesMap   map[string][]string
 	unconfiguredParentsMap  map[string]struct{}
+	drainingParents         map[string]struct{}
+	parentErrors            map[string]string
 	mdevsConfigurationMutex sync.Mutex
 }
 
@@ -44,6 +46,8 @@ func NewMDEVTypesManager() *MDEVTypesManager {
 	initHandler()
 	return &MDEVTypesManager{
 		availableMdevTypesMap: make(map[string][]string),
+		drainingParents:       make(map[string]struct{}),
+		parentErrors:          make(map[string]string),
 	}
 }
 
@@ -75,10 +79,16 @@ func (m *MDEVTypesManager) getAlreadyConfiguredMdevParents() (map[string]struct{
 	return configuredPCICards, nil
 }
 
-func (m *MDEVTypesManager) updateMDEVTypesConfiguration(desiredTypesList []string, externallyProvidedTypesMap map[string]struct{}) (bool, error) {
+// updateMDEVTypesConfiguration configures the desired mdev types on all parent devices, except the ones
+// which have been explicitly requested to be reconfigured. The latter are only reconfigured when the usage
+// of the existing mediated devices is known, i.e. usedMDEVs is not nil.
+func (m *MDEVTypesManager) updateMDEVTypesConfiguration(desiredTypesList []string, externallyProvidedTypesMap map[string]struct{}, reconfigurationRequests map[string]string, usedMDEVs map[string]struct{}) (bool, error) {
 	m.mdevsConfigurationMutex.Lock()
 	defer m.mdevsConfigurationMutex.Unlock()
 
+	m.drainingParents = make(map[string]struct{})
+	m.parentErrors = make(map[string]string)
+
 	// create a map of types that should not be removed
 	typesToKeepMap := make(map[string]struct{})
 	for key, val := range externallyProvidedTypesMap {
@@ -94,9 +104,9 @@ func (m *MDEVTypesManager) updateMDEVTypesConfiguration(desiredTypesList []strin
 
 	// the following will remove all configured types that have not been
 	// created by an external provider and are not in the desiredTypesMap
-	removeUndesiredMDEVs(typesToKeepMap)
+	removeUndesiredMDEVs(typesToKeepMap, reconfigurationRequests)
 
-	err := m.discoverConfigurableMDEVTypes(desiredTypesMap)
+	err := m.discoverConfigurableMDEVTypes(desiredTypesMap, reconfigurationRequests)
 	if err != nil {
 		log.Log.Reason(err).Error("failed to discover which mdev types are available for configuration")
 		return false, err
@@ -106,11 +116,15 @@ func (m *MDEVTypesManager) updateMDEVTypesConfiguration(desiredTypesList []strin
 		m.configureDesiredMDEVTypes()
 	}
 
+	if len(reconfigurationRequests) > 0 && usedMDEVs != nil {
+		m.reconfigureMDEVParents(reconfigurationRequests, usedMDEVs)
+	}
+
 	return true, nil
 }
 
 // discoverConfigurableMDEVTypes will create an intersection of desired and configurable available mdev types
-func (m *MDEVTypesManager) discoverConfigurableMDEVTypes(desiredTypesMap map[string]struct{}) error {
+func (m *MDEVTypesManager) discoverConfigurableMDEVTypes(desiredTypesMap map[string]struct{}, reconfigurationRequests map[string]string) error {
 	// initialize unconfigured parents map
 	m.unconfiguredParentsMap = make(map[string]struct{})
 
@@ -132,6 +146,10 @@ func (m *MDEVTypesManager) discoverConfigurableMDEVTypes(desiredTypesMap map[str
 			return fmt.Errorf("invalid device path: %s", file)
 		}
 		parentID := filePathParts[len(filePathParts)-3]
+		// parents with a reconfiguration request are handled separately
+		if _, requested := reconfigurationRequests[parentID]; requested {
+			continue
+		}
 
 		//find the type's name
 		rawName, err := os.ReadFile(filepath.Join(file, "name"))
@@ -211,6 +229,8 @@ func (m *MDEVTypesManager) configureDesiredMDEVTypes() {
 						m.availableMdevTypesMap[mdevTypeToConfigure] = remainingParents
 						// remove the already configured parent
 						delete(m.unconfiguredParentsMap, parent)
+					} else {
+						m.parentErrors[parent] = err.Error()
 					}
 				}
 			}
@@ -269,13 +289,19 @@ func shouldRemoveMDEV(mdevUUID string, desiredTypesMap map[string]struct{}) bool
 	return true
 }
 
-func removeUndesiredMDEVs(desiredTypesMap map[string]struct{}) {
+func removeUndesiredMDEVs(desiredTypesMap map[string]struct{}, reconfigurationRequests map[string]string) {
 	files, err := os.ReadDir(mdevBasePath)
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to remove mdev types: failed to read the content of %s directory", mdevBasePath)
 		return
 	}
 	for _, file := range files {
+		if instance, err := getMDEVInstance(file.Name()); err == nil {
+			// mdevs of parents with a reconfiguration request are handled separately
+			if _, requested := reconfigurationRequests[instance.parentID]; requested {
+				continue
+			}
+		}
 		if shouldRemoveMDEV(file.Name(), desiredTypesMap) {
 			err = Handler.RemoveMDEVType(file.Name())
 			log.Log.Reason(err).Warningf("failed to remove mdev type: %s", file.Name())
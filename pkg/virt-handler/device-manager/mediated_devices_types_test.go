@@ -2,10 +2,13 @@ package device_manager
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -290,7 +293,7 @@ var _ = Describe("Mediated Devices Types configuration", func() {
 			sc := scenario()
 			createTempMDEVSysfsStructure(sc.pciMDEVDevicesMap)
 			mdevManager := NewMDEVTypesManager()
-			_, err := mdevManager.updateMDEVTypesConfiguration(sc.desiredDevicesList, noExternallyConfiguredMdevs)
+			_, err := mdevManager.updateMDEVTypesConfiguration(sc.desiredDevicesList, noExternallyConfiguredMdevs, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			By("creating the desired mdev types")
@@ -321,7 +324,7 @@ var _ = Describe("Mediated Devices Types configuration", func() {
 			}
 
 			By("removing all created mdevs")
-			_, err = mdevManager.updateMDEVTypesConfiguration([]string{}, noExternallyConfiguredMdevs)
+			_, err = mdevManager.updateMDEVTypesConfiguration([]string{}, noExternallyConfiguredMdevs, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 			files, err := os.ReadDir(fakeMdevDevicesPath)
 			Expect(err).ToNot(HaveOccurred())
@@ -407,7 +410,7 @@ var _ = Describe("Mediated Devices Types configuration", func() {
 
 			By("creating an empty device controller")
 			var noDevices []Device
-			deviceController := NewDeviceController("master", 100, "rw", noDevices, fakeClusterConfig, clientTest.CoreV1())
+			deviceController := NewDeviceController("master", 100, "rw", noDevices, fakeClusterConfig, clientTest.CoreV1(), nil)
 
 			if late {
 				By("refreshing the mediated devices types with no sysfs structure")
@@ -462,6 +465,163 @@ var _ = Describe("Mediated Devices Types configuration", func() {
 			Entry("configure a merged list of mdev types when multiple selectors match node", mergeAllTypesMatchedByNodeLabels, false),
 		)
 	})
+
+	Context("Reconfigure mediated devices", func() {
+		const parentID = "0000:65:00.0"
+		var mdevManager *MDEVTypesManager
+
+		listMdevs := func() []string {
+			files, err := os.ReadDir(fakeMdevDevicesPath)
+			Expect(err).ToNot(HaveOccurred())
+			var uuids []string
+			for _, file := range files {
+				uuids = append(uuids, file.Name())
+			}
+			return uuids
+		}
+
+		BeforeEach(func() {
+			createTempMDEVSysfsStructure(map[string][]string{
+				parentID: {"nvidia-222", "nvidia-223", "nvidia-228"},
+			})
+			mdevManager = NewMDEVTypesManager()
+			_, err := mdevManager.updateMDEVTypesConfiguration([]string{"nvidia-228"}, map[string]struct{}{}, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countCreatedMdevs("nvidia-228")).To(Equal(2))
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(fakeMdevDevicesPath)
+		})
+
+		It("should report the configured mdev type and the used instances", func() {
+			usedMDEVs := map[string]struct{}{listMdevs()[0]: {}}
+			status := mdevManager.getMediatedDevicesStatus(nil, usedMDEVs)
+			Expect(status.ParentDevices).To(ConsistOf(v1.MediatedDeviceParentStatus{
+				PCIAddress:         parentID,
+				MediatedDeviceType: "GRID_T4-8A",
+				Phase:              v1.MediatedDeviceParentConfigured,
+				AvailableInstances: 1,
+				UsedInstances:      1,
+			}))
+		})
+
+		It("should drain a parent before reconfiguring it to the requested type", func() {
+			requests := map[string]string{parentID: "GRID_T4-2B"}
+			usedMDEVs := map[string]struct{}{listMdevs()[0]: {}}
+
+			By("removing the unused mdevs while another one is still used")
+			_, err := mdevManager.updateMDEVTypesConfiguration([]string{"nvidia-228"}, map[string]struct{}{}, requests, usedMDEVs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countCreatedMdevs("nvidia-228")).To(Equal(1))
+			Expect(countCreatedMdevs("nvidia-223")).To(BeZero())
+			status := mdevManager.getMediatedDevicesStatus(requests, usedMDEVs)
+			Expect(status.ParentDevices).To(HaveLen(1))
+			Expect(status.ParentDevices[0].Phase).To(Equal(v1.MediatedDeviceParentDraining))
+			Expect(status.ParentDevices[0].RequestedMediatedDeviceType).To(Equal("GRID_T4-2B"))
+			Expect(status.ParentDevices[0].UsedInstances).To(Equal(1))
+			Expect(status.ParentDevices[0].AvailableInstances).To(BeZero())
+
+			By("creating the requested type once the last mdev got released")
+			_, err = mdevManager.updateMDEVTypesConfiguration([]string{"nvidia-228"}, map[string]struct{}{}, requests, map[string]struct{}{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countCreatedMdevs("nvidia-228")).To(BeZero())
+			Expect(countCreatedMdevs("nvidia-223")).To(Equal(8))
+			status = mdevManager.getMediatedDevicesStatus(requests, map[string]struct{}{})
+			Expect(status.ParentDevices[0].Phase).To(Equal(v1.MediatedDeviceParentConfigured))
+			Expect(status.ParentDevices[0].MediatedDeviceType).To(Equal("GRID_T4-2B"))
+			Expect(status.ParentDevices[0].AvailableInstances).To(Equal(8))
+
+			By("keeping the requested type on subsequent refreshes")
+			_, err = mdevManager.updateMDEVTypesConfiguration([]string{"nvidia-228"}, map[string]struct{}{}, requests, map[string]struct{}{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countCreatedMdevs("nvidia-223")).To(Equal(8))
+			Expect(countCreatedMdevs("nvidia-228")).To(BeZero())
+		})
+
+		It("should not reconfigure a parent while the mdev usage is unknown", func() {
+			requests := map[string]string{parentID: "nvidia-223"}
+			_, err := mdevManager.updateMDEVTypesConfiguration([]string{"nvidia-228"}, map[string]struct{}{}, requests, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countCreatedMdevs("nvidia-228")).To(Equal(2))
+			Expect(countCreatedMdevs("nvidia-223")).To(BeZero())
+		})
+
+		It("should report an error when the requested type is not supported", func() {
+			requests := map[string]string{parentID: "nvidia-999"}
+			_, err := mdevManager.updateMDEVTypesConfiguration([]string{"nvidia-228"}, map[string]struct{}{}, requests, map[string]struct{}{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countCreatedMdevs("nvidia-228")).To(Equal(2))
+			status := mdevManager.getMediatedDevicesStatus(requests, map[string]struct{}{})
+			Expect(status.ParentDevices[0].Phase).To(Equal(v1.MediatedDeviceParentFailed))
+			Expect(status.ParentDevices[0].Error).To(ContainSubstring("nvidia-999"))
+		})
+
+		It("should publish the status on the node", func() {
+			kv := &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kubevirt",
+					Namespace: "kubevirt",
+				},
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						MediatedDevicesConfiguration: &v1.MediatedDevicesConfiguration{
+							MediatedDeviceTypes: []string{"nvidia-228"},
+						},
+					},
+				},
+			}
+			fakeClusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKV(kv)
+			node := &kubev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "master",
+					Annotations: map[string]string{
+						v1.MediatedDevicesReconfigurationAnnotation: `{"0000:65:00.0": "GRID T4-2B"}`,
+					},
+				},
+			}
+			clientTest = fake.NewSimpleClientset(node)
+			noUsedMDEVs := func() (map[string]struct{}, bool) {
+				return map[string]struct{}{}, true
+			}
+			deviceController := NewDeviceController("master", 100, "rw", nil, fakeClusterConfig, clientTest.CoreV1(), noUsedMDEVs)
+			Expect(deviceController.refreshMediatedDeviceTypes()).To(BeTrue())
+			Expect(countCreatedMdevs("nvidia-223")).To(Equal(8))
+
+			node, err := clientTest.CoreV1().Nodes().Get(context.Background(), "master", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			status := &v1.NodeMediatedDevicesStatus{}
+			Expect(json.Unmarshal([]byte(node.Annotations[v1.MediatedDevicesStatusAnnotation]), status)).To(Succeed())
+			Expect(status.ParentDevices).To(ConsistOf(v1.MediatedDeviceParentStatus{
+				PCIAddress:                  parentID,
+				MediatedDeviceType:          "GRID_T4-2B",
+				RequestedMediatedDeviceType: "GRID_T4-2B",
+				Phase:                       v1.MediatedDeviceParentConfigured,
+				AvailableInstances:          8,
+			}))
+		})
+
+		It("should not remove an mdev handed out to a pod whose VMI is not known on the node yet", func() {
+			requests := map[string]string{parentID: "nvidia-223"}
+			allocated := listMdevs()[0]
+			noUsedMDEVs := func() (map[string]struct{}, bool) {
+				return map[string]struct{}{}, true
+			}
+			deviceController := NewDeviceController("master", 100, "rw", nil, nil, nil, noUsedMDEVs)
+			deviceController.mdevAllocations.allocated(allocated)
+
+			usedMDEVs := deviceController.getUsedMDEVs()
+			Expect(usedMDEVs).To(HaveKey(allocated))
+			_, err := mdevManager.updateMDEVTypesConfiguration([]string{"nvidia-228"}, map[string]struct{}{}, requests, usedMDEVs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(listMdevs()).To(ConsistOf(allocated))
+			Expect(countCreatedMdevs("nvidia-223")).To(BeZero())
+
+			By("releasing the mdev once the grace period passed")
+			deviceController.mdevAllocations.allocations[allocated] = time.Now().Add(-mdevAllocationGracePeriod - time.Second)
+			Expect(deviceController.getUsedMDEVs()).To(BeEmpty())
+		})
+	})
 })
 
 func addNode(client *fake.Clientset, node *kubev1.Node) {
@@ -0,0 +1,544 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package device_manager
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+const usbDevicePath = "/dev/bus/usb"
+
+var usbBasePath = "/sys/bus/usb/devices"
+
+type USBDevice struct {
+	// name of the device under /sys/bus/usb/devices, e.g. 1-1.2
+	busPath    string
+	vendor     string
+	product    string
+	serial     string
+	bus        int
+	device     int
+	devicePath string
+}
+
+// hostAddress returns the address of the device in the form expected by virt-launcher
+func (dev *USBDevice) hostAddress() string {
+	return fmt.Sprintf("%d:%d", dev.bus, dev.device)
+}
+
+func (dev *USBDevice) sameIdentity(other *USBDevice) bool {
+	return strings.EqualFold(dev.vendor, other.vendor) && strings.EqualFold(dev.product, other.product) && dev.serial == other.serial
+}
+
+type USBDevicePlugin struct {
+	devs         []*pluginapi.Device
+	server       *grpc.Server
+	socketPath   string
+	stop         <-chan struct{}
+	health       chan deviceHealth
+	resourceName string
+	done         chan struct{}
+	deviceRoot   string
+	usbDevices   map[string]*USBDevice
+	initialized  bool
+	lock         *sync.Mutex
+	deregistered chan struct{}
+}
+
+func NewUSBDevicePlugin(usbDevices []*USBDevice, resourceName string) *USBDevicePlugin {
+	serverSock := SocketPath(strings.Replace(resourceName, "/", "-", -1))
+	usbDevicesMap := make(map[string]*USBDevice, len(usbDevices))
+
+	devs := constructDPIdevicesFromUSB(usbDevices, usbDevicesMap)
+	dpi := &USBDevicePlugin{
+		devs:         devs,
+		socketPath:   serverSock,
+		resourceName: resourceName,
+		deviceRoot:   util.HostRootMount,
+		usbDevices:   usbDevicesMap,
+		health:       make(chan deviceHealth),
+		initialized:  false,
+		lock:         &sync.Mutex{},
+	}
+	return dpi
+}
+
+func constructDPIdevicesFromUSB(usbDevices []*USBDevice, usbDevicesMap map[string]*USBDevice) (devs []*pluginapi.Device) {
+	for _, usbDevice := range usbDevices {
+		usbDevicesMap[usbDevice.busPath] = usbDevice
+		devs = append(devs, &pluginapi.Device{
+			ID:     usbDevice.busPath,
+			Health: pluginapi.Healthy,
+		})
+	}
+	return
+}
+
+// Start starts the device plugin
+func (dpi *USBDevicePlugin) Start(stop <-chan struct{}) (err error) {
+	logger := log.DefaultLogger()
+	dpi.stop = stop
+	dpi.done = make(chan struct{})
+	dpi.deregistered = make(chan struct{})
+
+	err = dpi.cleanup()
+	if err != nil {
+		return err
+	}
+
+	sock, err := net.Listen("unix", dpi.socketPath)
+	if err != nil {
+		return fmt.Errorf("error creating GRPC server socket: %v", err)
+	}
+
+	dpi.server = grpc.NewServer([]grpc.ServerOption{}...)
+	defer dpi.stopDevicePlugin()
+
+	pluginapi.RegisterDevicePluginServer(dpi.server, dpi)
+
+	errChan := make(chan error, 2)
+
+	go func() {
+		errChan <- dpi.server.Serve(sock)
+	}()
+
+	err = waitForGRPCServer(dpi.socketPath, connectionTimeout)
+	if err != nil {
+		return fmt.Errorf("error starting the GRPC server: %v", err)
+	}
+
+	err = dpi.register()
+	if err != nil {
+		return fmt.Errorf("error registering with device plugin manager: %v", err)
+	}
+
+	go func() {
+		errChan <- dpi.healthCheck()
+	}()
+
+	dpi.setInitialized(true)
+	logger.Infof("%s device plugin started", dpi.resourceName)
+	err = <-errChan
+
+	return err
+}
+
+func (dpi *USBDevicePlugin) ListAndWatch(_ *pluginapi.Empty, s pluginapi.DevicePlugin_ListAndWatchServer) error {
+	s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+
+	done := false
+	for {
+		select {
+		case devHealth := <-dpi.health:
+			for _, dev := range dpi.devs {
+				if devHealth.DevId == dev.ID {
+					dev.Health = devHealth.Health
+				}
+			}
+			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+		case <-dpi.stop:
+			done = true
+		case <-dpi.done:
+			done = true
+		}
+		if done {
+			break
+		}
+	}
+	// Send empty list to increase the chance that the kubelet acts fast on stopped device plugins
+	// There exists no explicit way to deregister devices
+	emptyList := []*pluginapi.Device{}
+	if err := s.Send(&pluginapi.ListAndWatchResponse{Devices: emptyList}); err != nil {
+		log.DefaultLogger().Reason(err).Infof("%s device plugin failed to deregister", dpi.resourceName)
+	}
+	close(dpi.deregistered)
+	return nil
+}
+
+func (dpi *USBDevicePlugin) Allocate(_ context.Context, r *pluginapi.AllocateRequest) (*pluginapi.AllocateResponse, error) {
+	resourceNameEnvVar := util.ResourceNameToEnvVar(v1.USBResourcePrefix, dpi.resourceName)
+	allocatedDevices := []string{}
+	resp := new(pluginapi.AllocateResponse)
+	containerResponse := new(pluginapi.ContainerAllocateResponse)
+
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	for _, request := range r.ContainerRequests {
+		deviceSpecs := make([]*pluginapi.DeviceSpec, 0)
+		for _, devID := range request.DevicesIDs {
+			usbDevice, exist := dpi.usbDevices[devID]
+			if !exist {
+				continue
+			}
+			allocatedDevices = append(allocatedDevices, usbDevice.hostAddress())
+			deviceSpecs = append(deviceSpecs, &pluginapi.DeviceSpec{
+				HostPath:      usbDevice.devicePath,
+				ContainerPath: usbDevice.devicePath,
+				Permissions:   "mrw",
+			})
+		}
+		containerResponse.Devices = deviceSpecs
+		envVar := make(map[string]string)
+		envVar[resourceNameEnvVar] = strings.Join(allocatedDevices, ",")
+
+		containerResponse.Envs = envVar
+		resp.ContainerResponses = append(resp.ContainerResponses, containerResponse)
+	}
+	return resp, nil
+}
+
+func (dpi *USBDevicePlugin) healthCheck() error {
+	logger := log.DefaultLogger()
+	monitoredDevices := make(map[string]string)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to creating a fsnotify watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	// This way we don't have to mount /dev from the node
+	busRoot := filepath.Join(dpi.deviceRoot, usbDevicePath)
+	// watching the root catches the bus directories of replugged devices being created
+	err = watcher.Add(busRoot)
+	if err != nil {
+		return fmt.Errorf("failed to add the USB device path %s to the watcher: %v", busRoot, err)
+	}
+
+	// probe all devices, watching the bus directory catches the device node being re-created
+	for _, dev := range dpi.devs {
+		if err := dpi.watchDevice(watcher, monitoredDevices, dev.ID); err != nil {
+			return err
+		}
+	}
+
+	dirName := filepath.Dir(dpi.socketPath)
+	err = watcher.Add(dirName)
+
+	if err != nil {
+		return fmt.Errorf("failed to add the device-plugin kubelet path to the watcher: %v", err)
+	}
+	_, err = os.Stat(dpi.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat the device-plugin socket: %v", err)
+	}
+
+	for {
+		select {
+		case <-dpi.stop:
+			return nil
+		case err := <-watcher.Errors:
+			logger.Reason(err).Errorf("error watching devices and device plugin directory")
+		case event := <-watcher.Events:
+			logger.V(4).Infof("health Event: %v", event)
+			if monDevId, exist := monitoredDevices[event.Name]; exist {
+				// Health in this case is if the device path actually exists
+				if event.Op == fsnotify.Create {
+					logger.Infof("monitored device %s appeared", dpi.resourceName)
+					dpi.health <- deviceHealth{
+						DevId:  monDevId,
+						Health: pluginapi.Healthy,
+					}
+				} else if (event.Op == fsnotify.Remove) || (event.Op == fsnotify.Rename) {
+					logger.Infof("monitored device %s disappeared", dpi.resourceName)
+					dpi.health <- deviceHealth{
+						DevId:  monDevId,
+						Health: pluginapi.Unhealthy,
+					}
+				}
+			} else if event.Name == dpi.socketPath && event.Op == fsnotify.Remove {
+				logger.Infof("device socket file for device %s was removed, kubelet probably restarted.", dpi.resourceName)
+				return nil
+			} else if event.Op == fsnotify.Create && strings.HasPrefix(event.Name, busRoot) {
+				// A replugged device gets a new device number, and a new bus when it is plugged into another port
+				for _, devID := range dpi.resolveReplugged(watcher, monitoredDevices) {
+					logger.Infof("monitored device %s was replugged", dpi.resourceName)
+					dpi.health <- deviceHealth{
+						DevId:  devID,
+						Health: pluginapi.Healthy,
+					}
+				}
+			}
+		}
+	}
+}
+
+// watchDevice watches the bus directory of the device node
+func (dpi *USBDevicePlugin) watchDevice(watcher *fsnotify.Watcher, monitoredDevices map[string]string, devID string) error {
+	dpi.lock.Lock()
+	devicePath := filepath.Join(dpi.deviceRoot, dpi.usbDevices[devID].devicePath)
+	dpi.lock.Unlock()
+
+	if err := watcher.Add(filepath.Dir(devicePath)); err != nil {
+		return fmt.Errorf("failed to add the device %s to the watcher: %v", devicePath, err)
+	}
+	for path, id := range monitoredDevices {
+		if id == devID {
+			delete(monitoredDevices, path)
+		}
+	}
+	monitoredDevices[devicePath] = devID
+	return nil
+}
+
+// resolveReplugged looks the devices whose node is gone up again by their identity and returns the ones found
+func (dpi *USBDevicePlugin) resolveReplugged(watcher *fsnotify.Watcher, monitoredDevices map[string]string) []string {
+	var gone []string
+	for devicePath, devID := range monitoredDevices {
+		if _, err := os.Stat(devicePath); err != nil {
+			gone = append(gone, devID)
+		}
+	}
+
+	var replugged []string
+	for _, devID := range gone {
+
+		dpi.lock.Lock()
+		dev := dpi.usbDevices[devID]
+		claimed := make(map[string]bool, len(dpi.usbDevices))
+		for _, other := range dpi.usbDevices {
+			claimed[other.busPath] = true
+		}
+		resolved := resolveUSBDevice(dev, claimed)
+		if resolved != nil {
+			dev.busPath = resolved.busPath
+			dev.bus = resolved.bus
+			dev.device = resolved.device
+			dev.devicePath = resolved.devicePath
+		}
+		dpi.lock.Unlock()
+
+		if resolved == nil {
+			continue
+		}
+		if err := dpi.watchDevice(watcher, monitoredDevices, devID); err != nil {
+			log.DefaultLogger().Reason(err).Errorf("failed to watch the replugged device %s", resolved.busPath)
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dpi.deviceRoot, resolved.devicePath)); err == nil {
+			replugged = append(replugged, devID)
+		}
+	}
+	return replugged
+}
+
+func (dpi *USBDevicePlugin) GetDevicePath() string {
+	return usbDevicePath
+}
+
+func (dpi *USBDevicePlugin) GetDeviceName() string {
+	return dpi.resourceName
+}
+
+// Stop stops the gRPC server
+func (dpi *USBDevicePlugin) stopDevicePlugin() error {
+	defer func() {
+		if !IsChanClosed(dpi.done) {
+			close(dpi.done)
+		}
+	}()
+
+	// Give the device plugin one second to properly deregister
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+	select {
+	case <-dpi.deregistered:
+	case <-ticker.C:
+	}
+
+	dpi.server.Stop()
+	dpi.setInitialized(false)
+	return dpi.cleanup()
+}
+
+// Register registers the device plugin for the given resourceName with Kubelet.
+func (dpi *USBDevicePlugin) register() error {
+	conn, err := gRPCConnect(pluginapi.KubeletSocket, connectionTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := pluginapi.NewRegistrationClient(conn)
+	reqt := &pluginapi.RegisterRequest{
+		Version:      pluginapi.Version,
+		Endpoint:     path.Base(dpi.socketPath),
+		ResourceName: dpi.resourceName,
+	}
+
+	_, err = client.Register(context.Background(), reqt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dpi *USBDevicePlugin) cleanup() error {
+	if err := os.Remove(dpi.socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (dpi *USBDevicePlugin) GetDevicePluginOptions(_ context.Context, _ *pluginapi.Empty) (*pluginapi.DevicePluginOptions, error) {
+	options := &pluginapi.DevicePluginOptions{
+		PreStartRequired: false,
+	}
+	return options, nil
+}
+
+func (dpi *USBDevicePlugin) PreStartContainer(_ context.Context, _ *pluginapi.PreStartContainerRequest) (*pluginapi.PreStartContainerResponse, error) {
+	res := &pluginapi.PreStartContainerResponse{}
+	return res, nil
+}
+
+func (dpi *USBDevicePlugin) GetInitialized() bool {
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	return dpi.initialized
+}
+
+func (dpi *USBDevicePlugin) setInitialized(initialized bool) {
+	dpi.lock.Lock()
+	dpi.initialized = initialized
+	dpi.lock.Unlock()
+}
+
+func usbSelectorMatches(selector v1.USBSelector, usbDevice *USBDevice) bool {
+	if !strings.EqualFold(selector.Vendor, usbDevice.vendor) || !strings.EqualFold(selector.Product, usbDevice.product) {
+		return false
+	}
+	return selector.BusPath == "" || selector.BusPath == usbDevice.busPath
+}
+
+// discoverPermittedHostUSBDevices returns the host USB devices grouped by the resource they
+// are permitted by. A device matched by several resources is only handed to the first one.
+func discoverPermittedHostUSBDevices(usbHostDevices []v1.USBHostDevice) map[string][]*USBDevice {
+	usbDevicesMap := make(map[string][]*USBDevice)
+	files, err := os.ReadDir(usbBasePath)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to discover USB host devices")
+		return usbDevicesMap
+	}
+	for _, file := range files {
+		// USB interfaces (e.g. 1-1.2:1.0) and root hubs (e.g. usb1) can't be passed through
+		if strings.Contains(file.Name(), ":") || strings.HasPrefix(file.Name(), "usb") {
+			continue
+		}
+		usbDevice, err := readUSBDevice(file.Name())
+		if err != nil {
+			log.DefaultLogger().Reason(err).Errorf("failed to read USB device %s", file.Name())
+			continue
+		}
+		func() {
+			for _, usbHostDevice := range usbHostDevices {
+				for _, selector := range usbHostDevice.Selectors {
+					if usbSelectorMatches(selector, usbDevice) {
+						usbDevicesMap[usbHostDevice.ResourceName] = append(usbDevicesMap[usbHostDevice.ResourceName], usbDevice)
+						return
+					}
+				}
+			}
+		}()
+	}
+	return usbDevicesMap
+}
+
+// resolveUSBDevice finds a device again after it was replugged. It is looked up on the port it was plugged into
+// first, a device with a serial number is also looked up on the ports not claimed by other devices.
+func resolveUSBDevice(dev *USBDevice, claimed map[string]bool) *USBDevice {
+	if candidate, err := readUSBDevice(dev.busPath); err == nil && dev.sameIdentity(candidate) {
+		return candidate
+	}
+	if dev.serial == "" {
+		return nil
+	}
+	files, err := os.ReadDir(usbBasePath)
+	if err != nil {
+		return nil
+	}
+	for _, file := range files {
+		if claimed[file.Name()] || strings.Contains(file.Name(), ":") || strings.HasPrefix(file.Name(), "usb") {
+			continue
+		}
+		if candidate, err := readUSBDevice(file.Name()); err == nil && dev.sameIdentity(candidate) {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func readUSBDevice(busPath string) (*USBDevice, error) {
+	readAttribute := func(attribute string) (string, error) {
+		// #nosec No risk for path injection. Reading static path of USB data
+		content, err := os.ReadFile(filepath.Join(usbBasePath, busPath, attribute))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+	readIntAttribute := func(attribute string) (int, error) {
+		value, err := readAttribute(attribute)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.Atoi(value)
+	}
+
+	usbDevice := &USBDevice{busPath: busPath}
+	var err error
+	if usbDevice.vendor, err = readAttribute("idVendor"); err != nil {
+		return nil, err
+	}
+	if usbDevice.product, err = readAttribute("idProduct"); err != nil {
+		return nil, err
+	}
+	// not every device has a serial number
+	if usbDevice.serial, err = readAttribute("serial"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if usbDevice.bus, err = readIntAttribute("busnum"); err != nil {
+		return nil, err
+	}
+	if usbDevice.device, err = readIntAttribute("devnum"); err != nil {
+		return nil, err
+	}
+	usbDevice.devicePath = filepath.Join(usbDevicePath, fmt.Sprintf("%03d", usbDevice.bus), fmt.Sprintf("%03d", usbDevice.device))
+	return usbDevice, nil
+}
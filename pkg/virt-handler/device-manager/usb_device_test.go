@@ -0,0 +1,193 @@
+package device_manager
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+const (
+	fakeUSBResourceName = "example.org/dongle"
+	fakeUSBVendor       = "046d"
+	fakeUSBProduct      = "c52b"
+)
+
+var _ = Describe("USB Device", func() {
+	var originalUSBBasePath string
+
+	createFakeUSBDevice := func(busPath string, attributes map[string]string) {
+		devicePath := filepath.Join(usbBasePath, busPath)
+		Expect(os.MkdirAll(devicePath, 0700)).To(Succeed())
+		for name, value := range attributes {
+			Expect(os.WriteFile(filepath.Join(devicePath, name), []byte(value+"\n"), 0600)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		By("creating a temporary fake usb directory tree")
+		originalUSBBasePath = usbBasePath
+		fakeUSBBasePath, err := os.MkdirTemp("", "usb")
+		Expect(err).ToNot(HaveOccurred())
+		usbBasePath = fakeUSBBasePath
+
+		createFakeUSBDevice("1-1", map[string]string{"idVendor": fakeUSBVendor, "idProduct": fakeUSBProduct, "busnum": "1", "devnum": "5"})
+		createFakeUSBDevice("1-2", map[string]string{"idVendor": fakeUSBVendor, "idProduct": fakeUSBProduct, "busnum": "1", "devnum": "12"})
+		createFakeUSBDevice("2-3", map[string]string{"idVendor": "0403", "idProduct": "6001", "busnum": "2", "devnum": "3"})
+		// interfaces and root hubs must be ignored
+		createFakeUSBDevice("1-1:1.0", map[string]string{"idVendor": fakeUSBVendor, "idProduct": fakeUSBProduct, "busnum": "1", "devnum": "5"})
+		createFakeUSBDevice("usb1", map[string]string{"idVendor": fakeUSBVendor, "idProduct": fakeUSBProduct, "busnum": "1", "devnum": "1"})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(usbBasePath)
+		usbBasePath = originalUSBBasePath
+	})
+
+	It("should discover all devices matching a selector", func() {
+		devices := discoverPermittedHostUSBDevices([]v1.USBHostDevice{{
+			ResourceName: fakeUSBResourceName,
+			Selectors:    []v1.USBSelector{{Vendor: fakeUSBVendor, Product: fakeUSBProduct}},
+		}})
+		Expect(devices).To(HaveLen(1))
+		Expect(devices[fakeUSBResourceName]).To(ConsistOf(
+			&USBDevice{busPath: "1-1", vendor: fakeUSBVendor, product: fakeUSBProduct, bus: 1, device: 5, devicePath: "/dev/bus/usb/001/005"},
+			&USBDevice{busPath: "1-2", vendor: fakeUSBVendor, product: fakeUSBProduct, bus: 1, device: 12, devicePath: "/dev/bus/usb/001/012"},
+		))
+	})
+
+	It("should only discover the device plugged into the selected bus path", func() {
+		devices := discoverPermittedHostUSBDevices([]v1.USBHostDevice{{
+			ResourceName: fakeUSBResourceName,
+			Selectors:    []v1.USBSelector{{Vendor: fakeUSBVendor, Product: fakeUSBProduct, BusPath: "1-2"}},
+		}})
+		Expect(devices[fakeUSBResourceName]).To(HaveLen(1))
+		Expect(devices[fakeUSBResourceName][0].busPath).To(Equal("1-2"))
+	})
+
+	It("should match any of the selectors of a resource", func() {
+		devices := discoverPermittedHostUSBDevices([]v1.USBHostDevice{{
+			ResourceName: fakeUSBResourceName,
+			Selectors: []v1.USBSelector{
+				{Vendor: "0403", Product: "6001"},
+				{Vendor: "046D", Product: "C52B", BusPath: "1-1"},
+			},
+		}})
+		Expect(devices[fakeUSBResourceName]).To(HaveLen(2))
+	})
+
+	It("should not hand out the same device to two resources", func() {
+		devices := discoverPermittedHostUSBDevices([]v1.USBHostDevice{
+			{
+				ResourceName: fakeUSBResourceName,
+				Selectors:    []v1.USBSelector{{Vendor: fakeUSBVendor, Product: fakeUSBProduct}},
+			},
+			{
+				ResourceName: "example.org/other",
+				Selectors:    []v1.USBSelector{{Vendor: fakeUSBVendor, Product: fakeUSBProduct}},
+			},
+		})
+		Expect(devices).To(HaveLen(1))
+		Expect(devices).To(HaveKey(fakeUSBResourceName))
+	})
+
+	It("should allocate the device node and expose the host address", func() {
+		devices := discoverPermittedHostUSBDevices([]v1.USBHostDevice{{
+			ResourceName: fakeUSBResourceName,
+			Selectors:    []v1.USBSelector{{Vendor: fakeUSBVendor, Product: fakeUSBProduct, BusPath: "1-1"}},
+		}})
+		dpi := NewUSBDevicePlugin(devices[fakeUSBResourceName], fakeUSBResourceName)
+		Expect(dpi.devs).To(HaveLen(1))
+		Expect(dpi.devs[0].ID).To(Equal("1-1"))
+
+		resp, err := dpi.Allocate(nil, &pluginapi.AllocateRequest{
+			ContainerRequests: []*pluginapi.ContainerAllocateRequest{{DevicesIDs: []string{"1-1"}}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.ContainerResponses).To(HaveLen(1))
+		Expect(resp.ContainerResponses[0].Envs).To(HaveKeyWithValue("USB_RESOURCE_EXAMPLE_ORG_DONGLE", "1:5"))
+		Expect(resp.ContainerResponses[0].Devices).To(ConsistOf(&pluginapi.DeviceSpec{
+			HostPath:      "/dev/bus/usb/001/005",
+			ContainerPath: "/dev/bus/usb/001/005",
+			Permissions:   "mrw",
+		}))
+	})
+
+	It("should find a device with a serial number which was replugged into another port", func() {
+		createFakeUSBDevice("2-3", map[string]string{"idVendor": "0403", "idProduct": "6001", "serial": "A10K", "busnum": "2", "devnum": "3"})
+		dev, err := readUSBDevice("2-3")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dev.serial).To(Equal("A10K"))
+
+		Expect(os.RemoveAll(filepath.Join(usbBasePath, "2-3"))).To(Succeed())
+		createFakeUSBDevice("2-4", map[string]string{"idVendor": "0403", "idProduct": "6001", "serial": "A10K", "busnum": "2", "devnum": "7"})
+		Expect(resolveUSBDevice(dev, map[string]bool{"2-3": true})).To(Equal(
+			&USBDevice{busPath: "2-4", vendor: "0403", product: "6001", serial: "A10K", bus: 2, device: 7, devicePath: "/dev/bus/usb/002/007"},
+		))
+
+		By("not taking over a port claimed by another device")
+		Expect(resolveUSBDevice(dev, map[string]bool{"2-3": true, "2-4": true})).To(BeNil())
+	})
+
+	Context("health check", func() {
+		var dpi *USBDevicePlugin
+		var stop chan struct{}
+		var deviceRoot string
+
+		BeforeEach(func() {
+			var err error
+			deviceRoot, err = os.MkdirTemp("", "usb-dev")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.MkdirAll(filepath.Join(deviceRoot, "/dev/bus/usb/001"), 0700)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(deviceRoot, "/dev/bus/usb/001/005"), []byte{}, 0600)).To(Succeed())
+
+			devices := discoverPermittedHostUSBDevices([]v1.USBHostDevice{{
+				ResourceName: fakeUSBResourceName,
+				Selectors:    []v1.USBSelector{{Vendor: fakeUSBVendor, Product: fakeUSBProduct, BusPath: "1-1"}},
+			}})
+			dpi = NewUSBDevicePlugin(devices[fakeUSBResourceName], fakeUSBResourceName)
+			dpi.deviceRoot = deviceRoot
+			dpi.socketPath = filepath.Join(deviceRoot, "test.sock")
+			Expect(os.WriteFile(dpi.socketPath, []byte{}, 0600)).To(Succeed())
+			stop = make(chan struct{})
+			dpi.stop = stop
+		})
+
+		AfterEach(func() {
+			close(stop)
+			os.RemoveAll(deviceRoot)
+		})
+
+		It("should mark a replugged device healthy again and allocate its new device node", func() {
+			go dpi.healthCheck()
+			// give the watcher the time to start
+			time.Sleep(100 * time.Millisecond)
+
+			By("unplugging the device")
+			Expect(os.Remove(filepath.Join(deviceRoot, "/dev/bus/usb/001/005"))).To(Succeed())
+			Eventually(func() string {
+				return (<-dpi.health).Health
+			}, 5*time.Second).Should(Equal(pluginapi.Unhealthy))
+
+			By("plugging the device back in, which assigns it a new device number")
+			createFakeUSBDevice("1-1", map[string]string{"devnum": "9"})
+			Expect(os.WriteFile(filepath.Join(deviceRoot, "/dev/bus/usb/001/009"), []byte{}, 0600)).To(Succeed())
+			Eventually(func() string {
+				return (<-dpi.health).Health
+			}, 5*time.Second).Should(Equal(pluginapi.Healthy))
+
+			resp, err := dpi.Allocate(nil, &pluginapi.AllocateRequest{
+				ContainerRequests: []*pluginapi.ContainerAllocateRequest{{DevicesIDs: []string{"1-1"}}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.ContainerResponses[0].Envs).To(HaveKeyWithValue("USB_RESOURCE_EXAMPLE_ORG_DONGLE", "1:9"))
+			Expect(resp.ContainerResponses[0].Devices[0].HostPath).To(Equal("/dev/bus/usb/001/009"))
+		})
+	})
+})
@@ -235,6 +235,49 @@ func (*VirtualMachineController) prepareVFIO(vmi *v1.VirtualMachineInstance, res
 	return nil
 }
 
+func (*VirtualMachineController) prepareUSB(res isolation.IsolationResult) error {
+	usbBasePath, err := isolation.SafeJoin(res, "dev", "bus", "usb")
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	readDir := func(dir *safepath.Path) (files []os.DirEntry, err error) {
+		err = dir.ExecuteNoFollow(func(safePath string) (err error) {
+			files, err = os.ReadDir(safePath)
+			return err
+		})
+		return files, err
+	}
+
+	buses, err := readDir(usbBasePath)
+	if err != nil {
+		return err
+	}
+	for _, bus := range buses {
+		busPath, err := safepath.JoinNoFollow(usbBasePath, bus.Name())
+		if err != nil {
+			return err
+		}
+		devices, err := readDir(busPath)
+		if err != nil {
+			return err
+		}
+		for _, device := range devices {
+			devicePath, err := safepath.JoinNoFollow(busPath, device.Name())
+			if err != nil {
+				return err
+			}
+			if err := diskutils.DefaultOwnershipManager.SetFileOwnership(devicePath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (d *VirtualMachineController) nonRootSetup(origVMI, vmi *v1.VirtualMachineInstance) error {
 	res, err := d.podIsolationDetector.Detect(origVMI)
 	if err != nil {
@@ -249,5 +292,8 @@ func (d *VirtualMachineController) nonRootSetup(origVMI, vmi *v1.VirtualMachineI
 	if err := d.prepareVFIO(origVMI, res); err != nil {
 		return err
 	}
+	if err := d.prepareUSB(res); err != nil {
+		return err
+	}
 	return nil
 }
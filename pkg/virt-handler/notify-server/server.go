@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"golang.org/x/net/context"
@@ -39,6 +40,8 @@ import (
 	"kubevirt.io/client-go/log"
 
 	notifyv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/notify/v1"
+	"kubevirt.io/kubevirt/pkg/timeline"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
@@ -47,9 +50,12 @@ type Notify struct {
 	EventChan chan watch.Event
 	recorder  record.EventRecorder
 	vmiStore  cache.Store
+
+	domainStatusLock sync.Mutex
+	domainStatus     map[string]api.DomainStatus
 }
 
-func (n *Notify) HandleDomainEvent(_ context.Context, request *notifyv1.DomainEventRequest) (*notifyv1.Response, error) {
+func (n *Notify) HandleDomainEvent(ctx context.Context, request *notifyv1.DomainEventRequest) (*notifyv1.Response, error) {
 	response := &notifyv1.Response{
 		Success: true,
 	}
@@ -77,6 +83,8 @@ func (n *Notify) HandleDomainEvent(_ context.Context, request *notifyv1.DomainEv
 	}
 
 	log.Log.Object(domain).V(3).Infof("Received Domain Event of type %s", request.EventType)
+	recordDomainEventSpan(ctx, domain, request.EventType)
+	n.recordDomainLifecycleEvent(domain, request.EventType)
 	switch request.EventType {
 	case string(watch.Added):
 		n.EventChan <- watch.Event{Type: watch.Added, Object: domain}
@@ -91,6 +99,58 @@ func (n *Notify) HandleDomainEvent(_ context.Context, request *notifyv1.DomainEv
 	return response, nil
 }
 
+// recordDomainEventSpan adds the domain events virt-launcher sends while the VMI starts to its lifecycle trace.
+func recordDomainEventSpan(ctx context.Context, domain *api.Domain, eventType string) {
+	span := tracing.StartSpan(tracing.FromIncomingContext(ctx), "virt-handler.HandleDomainEvent")
+	span.SetAttribute("kubevirt.io/event-type", eventType)
+	span.SetAttribute("kubevirt.io/domain", domain.Name)
+	span.SetAttribute("kubevirt.io/domain-status", string(domain.Status.Status))
+	span.SetAttribute("kubevirt.io/domain-reason", string(domain.Status.Reason))
+	span.End()
+}
+
+// recordDomainLifecycleEvent records a Kubernetes event on the VMI whenever the state of its
+// libvirt domain changes, so that it shows up in the timeline of the VirtualMachine.
+func (n *Notify) recordDomainLifecycleEvent(domain *api.Domain, eventType string) {
+	if n.recorder == nil || n.vmiStore == nil {
+		return
+	}
+	key := domain.ObjectMeta.Namespace + "/" + domain.ObjectMeta.Name
+
+	n.domainStatusLock.Lock()
+	defer n.domainStatusLock.Unlock()
+
+	switch eventType {
+	case string(watch.Deleted):
+		delete(n.domainStatus, key)
+		return
+	case string(watch.Added), string(watch.Modified):
+	default:
+		return
+	}
+
+	last, exists := n.domainStatus[key]
+	if domain.Status.Status == "" || (exists && last.Status == domain.Status.Status && last.Reason == domain.Status.Reason) {
+		return
+	}
+
+	obj, exists, err := n.vmiStore.GetByKey(key)
+	if err != nil || !exists {
+		return
+	}
+	vmi := obj.(*v1.VirtualMachineInstance)
+	if domain.ObjectMeta.UID != "" && domain.ObjectMeta.UID != vmi.UID {
+		return
+	}
+	n.domainStatus[key] = api.DomainStatus{Status: domain.Status.Status, Reason: domain.Status.Reason}
+
+	k8sEventType := k8sv1.EventTypeNormal
+	if domain.Status.Status == api.Crashed || domain.Status.Reason == api.ReasonPausedIOError {
+		k8sEventType = k8sv1.EventTypeWarning
+	}
+	n.recorder.Eventf(vmi, k8sEventType, timeline.DomainLifecycleReason, "Domain is %s (%s)", domain.Status.Status, domain.Status.Reason)
+}
+
 func (n *Notify) HandleK8SEvent(_ context.Context, request *notifyv1.K8SEventRequest) (*notifyv1.Response, error) {
 	response := &notifyv1.Response{
 		Success: true,
@@ -125,9 +185,10 @@ func RunServer(virtShareDir string, stopChan chan struct{}, c chan watch.Event,
 
 	grpcServer := grpc.NewServer([]grpc.ServerOption{}...)
 	notifyServer := &Notify{
-		EventChan: c,
-		recorder:  recorder,
-		vmiStore:  vmiStore,
+		EventChan:    c,
+		recorder:     recorder,
+		vmiStore:     vmiStore,
+		domainStatus: map[string]api.DomainStatus{},
 	}
 	registerInfoServer(grpcServer)
 
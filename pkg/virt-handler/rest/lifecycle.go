@@ -19,11 +19,13 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
 	"github.com/emicklei/go-restful/v3"
+	"google.golang.org/grpc/status"
 
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
@@ -120,7 +122,11 @@ func (lh *LifecycleHandler) FreezeHandler(request *restful.Request, response *re
 
 	unfreezeTimeoutSeconds := int32(unfreezeTimeout.UnfreezeTimeout.Seconds())
 	err = client.FreezeVirtualMachine(vmi, unfreezeTimeoutSeconds)
-	if err != nil {
+	if cmdclient.IsAborted(err) {
+		log.Log.Object(vmi).Reason(err).Error("Freeze of VMI aborted by a pre-freeze hook")
+		response.WriteError(http.StatusUnprocessableEntity, errors.New(status.Convert(err).Message()))
+		return
+	} else if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to freeze VMI")
 		response.WriteError(http.StatusBadRequest, err)
 		return
@@ -0,0 +1,368 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virthandler
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+	"kubevirt.io/kubevirt/pkg/virt-handler/cgroup"
+	nodelabellerapi "kubevirt.io/kubevirt/pkg/virt-handler/node-labeller/api"
+)
+
+const (
+	softCPUPlacementInterval = 30 * time.Second
+	// A VMI is only moved to a different numa node if the load of its
+	// current node exceeds the load of the least loaded node by this gap
+	softCPUPlacementRebalanceThreshold = 0.25
+	unplaced                           = -1
+	// CPUPlacementChanged is the reason set when a soft placed VMI is pinned to a numa node
+	CPUPlacementChanged = "CPUPlacementChanged"
+)
+
+// Not a const for static test purposes
+var procStatPath = "/proc/stat"
+
+type numaNode struct {
+	id   int
+	cpus []int
+}
+
+type cpuTimes struct {
+	busy  uint64
+	total uint64
+}
+
+// softCPUPlacer keeps track of the host numa node each soft placed VMI is pinned to,
+// and chooses the least loaded node for new or imbalanced VMIs.
+type softCPUPlacer struct {
+	nodes      []numaNode
+	lastSample map[int]cpuTimes
+	placements map[types.UID]int
+	lock       sync.Mutex
+}
+
+func newSoftCPUPlacer(capabilities *nodelabellerapi.Capabilities) *softCPUPlacer {
+	placer := &softCPUPlacer{
+		placements: make(map[types.UID]int),
+	}
+	if capabilities == nil {
+		return placer
+	}
+	for _, cell := range capabilities.Host.Topology.Cells.Cell {
+		node := numaNode{id: int(cell.ID)}
+		for _, cpu := range cell.Cpus.CPU {
+			node.cpus = append(node.cpus, int(cpu.ID))
+		}
+		placer.nodes = append(placer.nodes, node)
+	}
+	return placer
+}
+
+// enabled reports whether there is more than one numa node to choose from
+func (p *softCPUPlacer) enabled() bool {
+	return len(p.nodes) > 1
+}
+
+func (p *softCPUPlacer) node(id int) numaNode {
+	for _, node := range p.nodes {
+		if node.id == id {
+			return node
+		}
+	}
+	return numaNode{id: id}
+}
+
+// readCPUTimes parses the per cpu accounting lines of /proc/stat
+func readCPUTimes() (map[int]cpuTimes, error) {
+	f, err := os.Open(procStatPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	times := make(map[int]cpuTimes)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// the aggregated "cpu" line is skipped, only "cpuN" lines are of interest
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "cpu") || fields[0] == "cpu" {
+			continue
+		}
+		cpu, err := strconv.Atoi(strings.TrimPrefix(fields[0], "cpu"))
+		if err != nil {
+			continue
+		}
+		var t cpuTimes
+		for i, field := range fields[1:] {
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %v", procStatPath, err)
+			}
+			t.total += value
+			// idle and iowait
+			if i != 3 && i != 4 {
+				t.busy += value
+			}
+		}
+		times[cpu] = t
+	}
+	return times, scanner.Err()
+}
+
+// sampleNodeLoads returns the average utilization, between 0 and 1, of the cpus of each numa node since the last sample
+func (p *softCPUPlacer) sampleNodeLoads() (map[int]float64, error) {
+	times, err := readCPUTimes()
+	if err != nil {
+		return nil, err
+	}
+	loads := make(map[int]float64)
+	for _, node := range p.nodes {
+		var busy, total uint64
+		for _, cpu := range node.cpus {
+			current, exists := times[cpu]
+			if !exists {
+				continue
+			}
+			last := p.lastSample[cpu]
+			if current.total > last.total && current.busy >= last.busy {
+				busy += current.busy - last.busy
+				total += current.total - last.total
+			}
+		}
+		if total > 0 {
+			loads[node.id] = float64(busy) / float64(total)
+		} else {
+			loads[node.id] = 0
+		}
+	}
+	p.lastSample = times
+	return loads, nil
+}
+
+func (p *softCPUPlacer) leastLoadedNode(loads map[int]float64) int {
+	least := unplaced
+	for _, node := range p.nodes {
+		if least == unplaced || loads[node.id] < loads[least] {
+			least = node.id
+		}
+	}
+	return least
+}
+
+// chooseNode assigns the VMI to the least loaded node when it is not placed yet or when its current
+// node is overloaded. The expected load of the VMI's vCPUs is moved along with it, so that multiple
+// VMIs handled in one round are spread over the nodes.
+func (p *softCPUPlacer) chooseNode(vmi *v1.VirtualMachineInstance, loads map[int]float64) (int, bool) {
+	current, placed := p.placements[vmi.UID]
+	least := p.leastLoadedNode(loads)
+	if least == unplaced {
+		return unplaced, false
+	}
+	if placed && loads[current]-loads[least] <= softCPUPlacementRebalanceThreshold {
+		return current, false
+	}
+
+	vcpus := 1
+	if topology := vmi.Status.CurrentCPUTopology; topology != nil {
+		vcpus = int(topology.Sockets * topology.Cores * topology.Threads)
+	}
+	if cpus := len(p.node(least).cpus); cpus > 0 {
+		expectedLoad := float64(vcpus) / float64(cpus)
+		loads[least] += expectedLoad
+		if placed {
+			loads[current] -= expectedLoad
+		}
+	}
+	p.placements[vmi.UID] = least
+	return least, true
+}
+
+// place chooses the numa node for a VMI which was just started
+func (p *softCPUPlacer) place(vmi *v1.VirtualMachineInstance) (numaNode, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	loads, err := p.sampleNodeLoads()
+	if err != nil {
+		log.Log.Reason(err).Warning("failed to determine the load of the numa nodes")
+		loads = make(map[int]float64)
+	}
+	id, changed := p.chooseNode(vmi, loads)
+	return p.node(id), changed
+}
+
+// rebalance returns the VMIs which have to be moved to a different numa node.
+// VMIs which are not passed are forgotten.
+func (p *softCPUPlacer) rebalance(vmis []*v1.VirtualMachineInstance) (map[*v1.VirtualMachineInstance]numaNode, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	loads, err := p.sampleNodeLoads()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[types.UID]struct{}, len(vmis))
+	for _, vmi := range vmis {
+		known[vmi.UID] = struct{}{}
+	}
+	for uid := range p.placements {
+		if _, exists := known[uid]; !exists {
+			delete(p.placements, uid)
+		}
+	}
+
+	// place the VMIs in a stable order
+	sort.Slice(vmis, func(i, j int) bool {
+		return vmis[i].UID < vmis[j].UID
+	})
+	moves := make(map[*v1.VirtualMachineInstance]numaNode)
+	for _, vmi := range vmis {
+		if id, changed := p.chooseNode(vmi, loads); changed {
+			moves[vmi] = p.node(id)
+		}
+	}
+	return moves, nil
+}
+
+func (p *softCPUPlacer) forget(vmi *v1.VirtualMachineInstance) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.placements, vmi.UID)
+}
+
+// applySoftCPUPlacement pins all threads of the QEMU process, vCPUs and emulator threads alike,
+// to the cpus of the numa node and moves the guest memory to it.
+func (d *VirtualMachineController) applySoftCPUPlacement(vmi *v1.VirtualMachineInstance, node numaNode) error {
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return err
+	}
+	qemuProcess, err := res.GetQEMUProcess()
+	if err != nil {
+		return err
+	}
+	cgroupManager, err := cgroup.NewManagerFromVM(vmi)
+	if err != nil {
+		return err
+	}
+	cpusetStr, err := cgroupManager.GetCpuSet()
+	if err != nil {
+		return err
+	}
+	allowedCPUs, err := hardware.ParseCPUSetLine(cpusetStr, 50000)
+	if err != nil {
+		return fmt.Errorf("failed to parse the VMI cpuset: %v", err)
+	}
+
+	var mask unix.CPUSet
+	count := 0
+	for _, cpu := range node.cpus {
+		for _, allowedCPU := range allowedCPUs {
+			if cpu == allowedCPU {
+				mask.Set(cpu)
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return fmt.Errorf("none of the cpus of numa node %d is available to the VMI", node.id)
+	}
+
+	tasks, err := os.ReadDir(filepath.Join(string(os.PathSeparator), "proc", strconv.Itoa(qemuProcess.Pid()), "task"))
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		tid, err := strconv.Atoi(task.Name())
+		if err != nil {
+			continue
+		}
+		if err := unix.SchedSetaffinity(tid, &mask); err != nil {
+			return fmt.Errorf("failed to pin thread %d to numa node %d: %v", tid, node.id, err)
+		}
+	}
+
+	if err := cgroupManager.SetCpuSetMems("", []int{node.id}); err != nil {
+		return fmt.Errorf("failed to move the memory to numa node %d: %v", node.id, err)
+	}
+
+	log.Log.Object(vmi).Infof("pinned vCPUs, emulator threads and memory to numa node %d", node.id)
+	d.recorder.Eventf(vmi, k8sv1.EventTypeNormal, CPUPlacementChanged, "Pinned to host numa node %d", node.id)
+	return nil
+}
+
+// placeSoftCPUVMI pins a freshly started VMI to the least loaded numa node
+func (d *VirtualMachineController) placeSoftCPUVMI(vmi *v1.VirtualMachineInstance) {
+	if !d.softCPUPlacer.enabled() {
+		return
+	}
+	node, changed := d.softCPUPlacer.place(vmi)
+	if !changed {
+		return
+	}
+	if err := d.applySoftCPUPlacement(vmi, node); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to apply the soft cpu placement")
+		d.softCPUPlacer.forget(vmi)
+	}
+}
+
+// rebalanceSoftCPUPlacement periodically moves soft placed VMIs away from overloaded numa nodes
+func (d *VirtualMachineController) rebalanceSoftCPUPlacement() {
+	if !d.clusterConfig.SoftCPUPlacementEnabled() || !d.softCPUPlacer.enabled() {
+		return
+	}
+
+	var vmis []*v1.VirtualMachineInstance
+	for _, obj := range d.vmiSourceInformer.GetStore().List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if vmi.IsCPUSoftPlaced() && vmi.IsRunning() {
+			vmis = append(vmis, vmi)
+		}
+	}
+
+	moves, err := d.softCPUPlacer.rebalance(vmis)
+	if err != nil {
+		log.Log.Reason(err).Error("failed to rebalance the soft cpu placement")
+		return
+	}
+	for vmi, node := range moves {
+		if err := d.applySoftCPUPlacement(vmi, node); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("failed to apply the soft cpu placement")
+			d.softCPUPlacer.forget(vmi)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package virthandler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	nodelabellerapi "kubevirt.io/kubevirt/pkg/virt-handler/node-labeller/api"
+)
+
+var _ = Describe("Soft CPU placement", func() {
+	var placer *softCPUPlacer
+	var originalProcStatPath string
+	var ticks uint64
+
+	// writeProcStat advances the time by 100 ticks per cpu, busy holds the cumulative busy ticks of each cpu
+	writeProcStat := func(busy ...uint64) {
+		ticks += 100
+		content := "cpu  1 1 1 1 1 1 1 1 0 0\n"
+		for cpu, b := range busy {
+			// user nice system idle iowait irq softirq steal guest guest_nice
+			content += fmt.Sprintf("cpu%d %d 0 0 %d 0 0 0 0 0 0\n", cpu, b, ticks-b)
+		}
+		content += "intr 12345\n"
+		Expect(os.WriteFile(procStatPath, []byte(content), 0600)).To(Succeed())
+	}
+
+	newVMI := func(uid string, cores uint32) *v1.VirtualMachineInstance {
+		vmi := v1.NewVMIReferenceWithUUID("default", uid, types.UID(uid))
+		vmi.Status.CurrentCPUTopology = &v1.CPUTopology{Sockets: 1, Cores: cores, Threads: 1}
+		return vmi
+	}
+
+	BeforeEach(func() {
+		ticks = 0
+		originalProcStatPath = procStatPath
+		procStatPath = filepath.Join(GinkgoT().TempDir(), "stat")
+
+		capabilities := &nodelabellerapi.Capabilities{}
+		capabilities.Host.Topology.Cells.Cell = []nodelabellerapi.Cell{
+			{ID: 0, Cpus: nodelabellerapi.CPUs{CPU: []nodelabellerapi.CPU{{ID: 0}, {ID: 1}}}},
+			{ID: 1, Cpus: nodelabellerapi.CPUs{CPU: []nodelabellerapi.CPU{{ID: 2}, {ID: 3}}}},
+		}
+		placer = newSoftCPUPlacer(capabilities)
+	})
+
+	AfterEach(func() {
+		procStatPath = originalProcStatPath
+	})
+
+	It("should be disabled on hosts with a single numa node", func() {
+		Expect(placer.enabled()).To(BeTrue())
+		Expect(newSoftCPUPlacer(&nodelabellerapi.Capabilities{}).enabled()).To(BeFalse())
+		Expect(newSoftCPUPlacer(nil).enabled()).To(BeFalse())
+	})
+
+	It("should compute the load of each numa node since the last sample", func() {
+		writeProcStat(0, 0, 0, 0)
+		_, err := placer.sampleNodeLoads()
+		Expect(err).ToNot(HaveOccurred())
+
+		// node 0 spent 80 of 200 ticks busy and node 1 20 of 200
+		writeProcStat(50, 30, 10, 10)
+		loads, err := placer.sampleNodeLoads()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loads).To(HaveLen(2))
+		Expect(loads[0]).To(BeNumerically("~", 0.4, 0.001))
+		Expect(loads[1]).To(BeNumerically("~", 0.1, 0.001))
+	})
+
+	It("should place a new VMI on the least loaded numa node", func() {
+		writeProcStat(90, 90, 10, 10)
+		node, changed := placer.place(newVMI("vmi1", 1))
+		Expect(changed).To(BeTrue())
+		Expect(node.id).To(Equal(1))
+		Expect(node.cpus).To(Equal([]int{2, 3}))
+	})
+
+	It("should spread VMIs placed in the same round over the numa nodes", func() {
+		writeProcStat(0, 0, 0, 0)
+		moves, err := placer.rebalance([]*v1.VirtualMachineInstance{newVMI("vmi1", 2), newVMI("vmi2", 2)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(moves).To(HaveLen(2))
+		nodes := map[int]struct{}{}
+		for _, node := range moves {
+			nodes[node.id] = struct{}{}
+		}
+		Expect(nodes).To(HaveLen(2))
+	})
+
+	Context("with a placed VMI", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = newVMI("vmi1", 1)
+			writeProcStat(0, 0, 0, 0)
+			node, changed := placer.place(vmi)
+			Expect(changed).To(BeTrue())
+			Expect(node.id).To(Equal(0))
+		})
+
+		It("should keep the VMI on a slightly busier numa node", func() {
+			writeProcStat(30, 30, 10, 10)
+			moves, err := placer.rebalance([]*v1.VirtualMachineInstance{vmi})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(moves).To(BeEmpty())
+		})
+
+		It("should move the VMI away from an overloaded numa node", func() {
+			writeProcStat(90, 90, 10, 10)
+			moves, err := placer.rebalance([]*v1.VirtualMachineInstance{vmi})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(moves).To(HaveLen(1))
+			Expect(moves[vmi].id).To(Equal(1))
+		})
+
+		It("should forget VMIs which are gone", func() {
+			_, err := placer.rebalance(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(placer.placements).To(BeEmpty())
+		})
+	})
+})
@@ -61,9 +61,14 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	"kubevirt.io/kubevirt/pkg/monitoring/domainstats/resourceusage"
 	netcache "kubevirt.io/kubevirt/pkg/network/cache"
+	"kubevirt.io/kubevirt/pkg/network/namescheme"
+	"kubevirt.io/kubevirt/pkg/network/ndp"
+	"kubevirt.io/kubevirt/pkg/network/netns"
 	netsetup "kubevirt.io/kubevirt/pkg/network/setup"
 	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	"kubevirt.io/kubevirt/pkg/util"
 
 	"kubevirt.io/kubevirt/pkg/virt-handler/heartbeat"
@@ -192,6 +197,7 @@ func NewController(
 	migrationProxy migrationproxy.ProxyManager,
 	capabilities *nodelabellerapi.Capabilities,
 	hostCpuModel string,
+	resourceUsage *resourceusage.Recorder,
 ) (*VirtualMachineController, error) {
 
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-handler-vm")
@@ -217,6 +223,7 @@ func NewController(
 		virtLauncherFSRunDirPattern: "/proc/%d/root/var/run",
 		capabilities:                capabilities,
 		hostCpuModel:                hostCpuModel,
+		resourceUsage:               resourceUsage,
 		vmiExpectations:             controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
 		sriovHotplugExecutorPool:    executor.NewRateLimitedExecutorPool(executor.NewExponentialLimitedBackoffCreator()),
 		ioErrorRetryManager:         NewFailRetryManager("io-error-retry", 10*time.Second, 3*time.Minute, 30*time.Second),
@@ -283,12 +290,57 @@ func NewController(
 		permissions,
 		device_manager.PermanentHostDevicePlugins(maxDevices, permissions),
 		clusterConfig,
-		clientset.CoreV1())
+		clientset.CoreV1(),
+		c.assignedMDEVs)
+	c.softCPUPlacer = newSoftCPUPlacer(capabilities)
 	c.heartBeat = heartbeat.NewHeartBeat(clientset.CoreV1(), c.deviceManagerController, clusterConfig, host)
 
 	return c, nil
 }
 
+// assignedMDEVs returns the UUIDs of all mediated devices which are assigned to domains on this node.
+// The usage is unknown as long as a VMI on this node, or migrating to it, has no domain yet,
+// its pod may already have been handed a mediated device.
+func (d *VirtualMachineController) assignedMDEVs() (map[string]struct{}, bool) {
+	if !d.domainInformer.HasSynced() || !d.vmiSourceInformer.HasSynced() || !d.vmiTargetInformer.HasSynced() {
+		return nil, false
+	}
+	domains := d.domainInformer.GetStore()
+	hasDomain := func(vmi *v1.VirtualMachineInstance) bool {
+		_, exists, err := domains.GetByKey(controller.NamespacedKey(vmi.Namespace, vmi.Name))
+		return err == nil && exists
+	}
+	for _, obj := range d.vmiSourceInformer.GetStore().List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if !vmi.IsFinal() && !hasDomain(vmi) {
+			log.Log.Object(vmi).V(4).Info("the mediated devices usage is unknown until the domain is defined")
+			return nil, false
+		}
+	}
+	for _, obj := range d.vmiTargetInformer.GetStore().List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		migration := vmi.Status.MigrationState
+		if migration == nil || migration.TargetNode != d.host || migration.Completed || migration.Failed {
+			continue
+		}
+		if !hasDomain(vmi) {
+			log.Log.Object(vmi).V(4).Info("the mediated devices usage is unknown until the migration target domain is defined")
+			return nil, false
+		}
+	}
+
+	mdevs := make(map[string]struct{})
+	for _, obj := range domains.List() {
+		domain := obj.(*api.Domain)
+		for _, hostDev := range domain.Spec.Devices.HostDevices {
+			if hostDev.Type == api.HostDeviceMDev && hostDev.Source.Address != nil {
+				mdevs[hostDev.Source.Address.UUID] = struct{}{}
+			}
+		}
+	}
+	return mdevs, true
+}
+
 type VirtualMachineController struct {
 	recorder                 record.EventRecorder
 	clientset                kubecli.KubevirtClient
@@ -319,7 +371,9 @@ type VirtualMachineController struct {
 	virtLauncherFSRunDirPattern string
 	heartBeat                   *heartbeat.HeartBeat
 	capabilities                *nodelabellerapi.Capabilities
+	softCPUPlacer               *softCPUPlacer
 	hostCpuModel                string
+	resourceUsage               *resourceusage.Recorder
 	vmiExpectations             *controller.UIDTrackingControllerExpectations
 	ioErrorRetryManager         *FailRetryManager
 }
@@ -1103,6 +1157,19 @@ func dumpTargetFile(vmiName, volName string) string {
 	return targetFileName
 }
 
+func savedStateTargetFile(vmiName, volName string) string {
+	return fmt.Sprintf("%s-%s-%s.memory.state", vmiName, volName, time.Now().Format("20060102-150405"))
+}
+
+func memoryDumpTargetFile(vmi *v1.VirtualMachineInstance, volName string) string {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == volName && volume.MemoryDump != nil && volume.MemoryDump.Format == v1.MemoryDumpFormatSavedState {
+			return savedStateTargetFile(vmi.Name, volName)
+		}
+	}
+	return dumpTargetFile(vmi.Name, volName)
+}
+
 func (d *VirtualMachineController) updateMemoryDumpInfo(vmi *v1.VirtualMachineInstance, volumeStatus v1.VolumeStatus, domain *api.Domain) (v1.VolumeStatus, bool) {
 	needsRefresh := false
 	switch volumeStatus.Phase {
@@ -1112,7 +1179,7 @@ func (d *VirtualMachineController) updateMemoryDumpInfo(vmi *v1.VirtualMachineIn
 		volumeStatus.Phase = v1.MemoryDumpVolumeInProgress
 		volumeStatus.Message = fmt.Sprintf("Memory dump Volume %s is attached, getting memory dump", volumeStatus.Name)
 		volumeStatus.Reason = VolumeMountedToPodReason
-		volumeStatus.MemoryDumpVolume.TargetFileName = dumpTargetFile(vmi.Name, volumeStatus.Name)
+		volumeStatus.MemoryDumpVolume.TargetFileName = memoryDumpTargetFile(vmi, volumeStatus.Name)
 	case v1.MemoryDumpVolumeInProgress:
 		memoryDumpMetadata := domain.Spec.Metadata.KubeVirt.MemoryDump
 		if memoryDumpMetadata == nil || memoryDumpMetadata.FileName != volumeStatus.MemoryDumpVolume.TargetFileName {
@@ -1154,6 +1221,29 @@ func (d *VirtualMachineController) updateFSFreezeStatus(vmi *v1.VirtualMachineIn
 
 }
 
+func (d *VirtualMachineController) updateFreezeHooksStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain == nil || domain.Spec.Metadata.KubeVirt.FreezeHooks == nil {
+		return
+	}
+
+	splitHooks := func(hooks string) []string {
+		if hooks == "" {
+			return nil
+		}
+		return strings.Split(hooks, ",")
+	}
+
+	freezeHooksMetadata := domain.Spec.Metadata.KubeVirt.FreezeHooks
+	vmi.Status.FreezeHooks = &v1.FreezeHooksStatus{
+		PreFreezeTimestamp: freezeHooksMetadata.PreFreezeTimestamp,
+		PreFreezeFailed:    splitHooks(freezeHooksMetadata.PreFreezeFailed),
+		Aborted:            freezeHooksMetadata.Aborted,
+		PostThawTimestamp:  freezeHooksMetadata.PostThawTimestamp,
+		PostThawFailed:     splitHooks(freezeHooksMetadata.PostThawFailed),
+		Message:            freezeHooksMetadata.Message,
+	}
+}
+
 func IsoGuestVolumePath(vmi *v1.VirtualMachineInstance, volume *v1.Volume) (string, bool) {
 	var volPath string
 
@@ -1273,7 +1363,10 @@ func (d *VirtualMachineController) updateVMIStatusFromDomain(vmi *v1.VirtualMach
 	d.updateGuestInfoFromDomain(vmi, domain)
 	d.updateVolumeStatusesFromDomain(vmi, domain)
 	d.updateFSFreezeStatus(vmi, domain)
+	d.updateFreezeHooksStatus(vmi, domain)
 	d.updateMachineType(vmi, domain)
+	d.updateMemoryDirtyRate(vmi, domain)
+	d.updateResourceUsage(vmi)
 	err = d.netStat.UpdateStatus(vmi, domain)
 	return err
 }
@@ -1542,6 +1635,8 @@ func (c *VirtualMachineController) Run(threadiness int, stopCh chan struct{}) {
 
 	go c.ioErrorRetryManager.Run(stopCh)
 
+	go wait.Until(c.rebalanceSoftCPUPlacement, softCPUPlacementInterval, stopCh)
+
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
@@ -2715,6 +2810,12 @@ func (d *VirtualMachineController) vmUpdateHelperMigrationTarget(origVMI *v1.Vir
 		}
 	}
 
+	if d.shouldConfigureCPUWeight(vmi) {
+		if err := d.configureCPUWeight(vmi); err != nil {
+			return err
+		}
+	}
+
 	options := virtualMachineOptions(nil, 0, nil, d.capabilities, disksInfo, d.clusterConfig)
 	if err := client.SyncMigrationTarget(vmi, options); err != nil {
 		return fmt.Errorf("syncing migration target failed: %v", err)
@@ -2780,6 +2881,27 @@ func (d *VirtualMachineController) affinePitThread(vmi *v1.VirtualMachineInstanc
 	return unix.SchedSetaffinity(pitpid, &Mask)
 }
 
+func (d *VirtualMachineController) shouldConfigureCPUWeight(vmi *v1.VirtualMachineInstance) bool {
+	return d.clusterConfig.CPUWeightEnabled() && vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.Weight != nil
+}
+
+// configureCPUWeight prioritises the VMI's pod against the other pods of the node.
+// It is reapplied on every sync since the kubelet resets the pod cgroup when it updates the pod resources.
+func (d *VirtualMachineController) configureCPUWeight(vmi *v1.VirtualMachineInstance) error {
+	weight := *vmi.Spec.Domain.CPU.Weight
+	if maxWeight := d.clusterConfig.GetMaxCPUWeight(); weight > maxWeight {
+		weight = maxWeight
+	}
+	cgroupManager, err := cgroup.NewManagerFromVM(vmi)
+	if err != nil {
+		return err
+	}
+	if err := cgroupManager.SetCpuWeight(weight); err != nil {
+		return fmt.Errorf("failed to set the cpu weight: %v", err)
+	}
+	return nil
+}
+
 func (d *VirtualMachineController) configureHousekeepingCgroup(vmi *v1.VirtualMachineInstance) error {
 	cgroupManager, err := cgroup.NewManagerFromVM(vmi)
 	if err != nil {
@@ -2806,14 +2928,14 @@ func (d *VirtualMachineController) configureHousekeepingCgroup(vmi *v1.VirtualMa
 		return nil
 	}
 
-	hkcpu, err := strconv.Atoi(domain.Spec.CPUTune.EmulatorPin.CPUSet)
+	hkcpus, err := hardware.ParseCPUSetLine(domain.Spec.CPUTune.EmulatorPin.CPUSet, 50000)
 	if err != nil {
 		return err
 	}
 
-	log.Log.V(3).Object(vmi).Infof("housekeeping cpu: %v", hkcpu)
+	log.Log.V(3).Object(vmi).Infof("housekeeping cpus: %v", hkcpus)
 
-	err = cgroupManager.SetCpuSet("housekeeping", []int{hkcpu})
+	err = cgroupManager.SetCpuSet("housekeeping", hkcpus)
 	if err != nil {
 		return err
 	}
@@ -3020,6 +3142,15 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 			return err
 		}
 	}
+	if vmi.IsCPUSoftPlaced() && !vmi.IsRunning() && !vmi.IsFinal() {
+		d.placeSoftCPUVMI(vmi)
+	}
+	if d.shouldConfigureCPUWeight(vmi) && !vmi.IsFinal() {
+		log.Log.V(4).Object(vmi).Infof("Setting the cpu weight to %d", *vmi.Spec.Domain.CPU.Weight)
+		if err := d.configureCPUWeight(vmi); err != nil {
+			return err
+		}
+	}
 	if vmi.IsCPUDedicated() && !vmi.IsRunning() && !vmi.IsFinal() {
 		log.Log.V(3).Object(vmi).Info("Affining PIT thread")
 		if err := d.affinePitThread(vmi); err != nil {
@@ -3121,8 +3252,14 @@ func (d *VirtualMachineController) processVmUpdate(vmi *v1.VirtualMachineInstanc
 		return d.vmUpdateHelperMigrationTarget(vmi)
 	} else if d.isMigrationSource(vmi) {
 		return d.vmUpdateHelperMigrationSource(vmi, domain)
+	} else if domain == nil {
+		span := tracing.StartSpanFromObject(vmi, "virt-handler.StartVirtualMachineInstance")
+		err := d.vmUpdateHelperDefault(vmi, false)
+		span.SetError(err)
+		span.End()
+		return err
 	} else {
-		return d.vmUpdateHelperDefault(vmi, domain != nil)
+		return d.vmUpdateHelperDefault(vmi, true)
 	}
 }
 
@@ -3273,9 +3410,45 @@ func (d *VirtualMachineController) finalizeMigration(vmi *v1.VirtualMachineInsta
 		return fmt.Errorf("%s: %v", errorMessage, err)
 	}
 
+	d.advertiseMigratedGuestIPv6(vmi)
+
 	return nil
 }
 
+// advertiseMigratedGuestIPv6 makes the IPv6 neighbors of a guest which keeps the source pod IP and MAC on a bridge
+// binding pod network interface learn its new location. The QEMU announcement of the migration target only makes
+// virtio guests advertise their IPv6 addresses, the other NIC models only send RARP. Like that announcement, it is
+// a best-effort, since the guest traffic refreshes the neighbors eventually.
+func (d *VirtualMachineController) advertiseMigratedGuestIPv6(vmi *v1.VirtualMachineInstance) {
+	if _, exists := vmi.Annotations[v1.AllowPodBridgeNetworkLiveMigrationAnnotation]; !exists {
+		return
+	}
+	if !netvmispec.IsPodNetworkWithBridgeBindingInterface(vmi.Spec.Networks, vmi.Spec.Domain.Devices.Interfaces) {
+		return
+	}
+
+	mac, ips, err := ndp.GuestAddresses(vmi, netvmispec.LookupPodNetwork(vmi.Spec.Networks).Name)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("failed to advertise the guest IPv6 addresses after migration")
+		return
+	}
+	if len(ips) == 0 {
+		return
+	}
+
+	isolationRes, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("failed to advertise the guest IPv6 addresses after migration")
+		return
+	}
+	err = netns.New(isolationRes.Pid()).Do(func() error {
+		return ndp.Advertise(namescheme.PrimaryPodInterfaceName, mac, ips)
+	})
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("failed to advertise the guest IPv6 addresses after migration")
+	}
+}
+
 func vmiHasTerminationGracePeriod(vmi *v1.VirtualMachineInstance) bool {
 	// if not set we use the default graceperiod
 	return vmi.Spec.TerminationGracePeriodSeconds == nil ||
@@ -3379,6 +3552,30 @@ func (d *VirtualMachineController) updateMachineType(vmi *v1.VirtualMachineInsta
 	}
 }
 
+func (d *VirtualMachineController) updateMemoryDirtyRate(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain == nil || vmi == nil {
+		return
+	}
+	dirtyRateMetadata := domain.Spec.Metadata.KubeVirt.DirtyRate
+	if dirtyRateMetadata == nil {
+		return
+	}
+	vmi.Status.MemoryDirtyRate = &v1.VirtualMachineInstanceMemoryDirtyRate{
+		BytesPerSecond:  dirtyRateMetadata.BytesPerSecond,
+		SampleTimestamp: dirtyRateMetadata.Timestamp,
+	}
+}
+
+func (d *VirtualMachineController) updateResourceUsage(vmi *v1.VirtualMachineInstance) {
+	if vmi == nil || d.resourceUsage == nil {
+		return
+	}
+	// the last reported usage is kept until the next one is published
+	if usage := d.resourceUsage.PeakUsage(vmi.UID); usage != nil {
+		vmi.Status.ResourceUsage = usage
+	}
+}
+
 func (d *VirtualMachineController) hotplugCPU(vmi *v1.VirtualMachineInstance, client cmdclient.LauncherClient) error {
 	vmiConditions := controller.NewVirtualMachineInstanceConditionManager()
 
@@ -42,6 +42,7 @@ import (
 
 	api2 "kubevirt.io/client-go/api"
 
+	"kubevirt.io/kubevirt/pkg/monitoring/domainstats/resourceusage"
 	netcache "kubevirt.io/kubevirt/pkg/network/cache"
 	neterrors "kubevirt.io/kubevirt/pkg/network/errors"
 	"kubevirt.io/kubevirt/pkg/util"
@@ -79,6 +80,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 	"kubevirt.io/kubevirt/pkg/watchdog"
 )
 
@@ -223,6 +225,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			migrationProxy,
 			nil,
 			"",
+			resourceusage.NewRecorder(time.Minute, time.Hour),
 		)
 		controller.hotplugVolumeMounter = mockHotplugVolumeMounter
 		controller.virtLauncherFSRunDirPattern = filepath.Join(shareDir, "%d")
@@ -719,6 +722,87 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		It("should report the sampled memory dirty rate on the VMI status", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi = addActivePods(vmi, podTestUUID, host)
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+
+			now := metav1.Now()
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.Metadata.KubeVirt.DirtyRate = &api.DirtyRateMetadata{
+				BytesPerSecond: 1048576,
+				Timestamp:      &now,
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, obj interface{}) (*v1.VirtualMachineInstance, error) {
+				vmi := obj.(*v1.VirtualMachineInstance)
+				Expect(vmi.Status.MemoryDirtyRate).To(Equal(&v1.VirtualMachineInstanceMemoryDirtyRate{
+					BytesPerSecond:  1048576,
+					SampleTimestamp: &now,
+				}))
+				return vmi, nil
+			})
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+
+			controller.Execute()
+		})
+
+		It("should report the peak resource usage on the VMI status", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi = addActivePods(vmi, podTestUUID, host)
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			windowStart := time.Now()
+			Expect(controller.resourceUsage.Report(vmi, &stats.DomainStats{
+				Cpu: &stats.DomainStatsCPU{TimeSet: true, Time: 0},
+			}, windowStart)).To(BeFalse())
+			Expect(controller.resourceUsage.Report(vmi, &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{TimeSet: true, Time: uint64(90 * time.Second)},
+				Memory: &stats.DomainStatsMemory{AvailableSet: true, Available: 2097152, UsableSet: true, Usable: 1048576},
+			}, windowStart.Add(time.Minute))).To(BeTrue())
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, obj interface{}) (*v1.VirtualMachineInstance, error) {
+				vmi := obj.(*v1.VirtualMachineInstance)
+				Expect(vmi.Status.ResourceUsage).ToNot(BeNil())
+				Expect(vmi.Status.ResourceUsage.PeakCPU.String()).To(Equal("1500m"))
+				Expect(vmi.Status.ResourceUsage.PeakMemory.String()).To(Equal("1Gi"))
+				Expect(vmi.Status.ResourceUsage.Samples).To(Equal(1))
+				Expect(vmi.Status.ResourceUsage.WindowStart.Time).To(BeTemporally("==", windowStart.Add(time.Minute)))
+				return vmi, nil
+			})
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+
+			controller.Execute()
+		})
+
 		It("should update from Scheduled to Running, if it sees a running Domain", func() {
 			vmi := api2.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -3186,6 +3270,72 @@ var _ = Describe("VirtualMachineInstance", func() {
 		})
 	})
 
+	Context("mediated devices usage", func() {
+		const mdevUUID = "b1e3f1b4-7c64-4c6c-9f3b-2a4f4d5c6e7f"
+
+		newMDEVDomain := func(name string) *api.Domain {
+			domain := api.NewMinimalDomain(name)
+			domain.Spec.Devices.HostDevices = []api.HostDevice{{
+				Type:   api.HostDeviceMDev,
+				Source: api.HostDeviceSource{Address: &api.Address{UUID: mdevUUID}},
+			}}
+			return domain
+		}
+
+		It("should report the mediated devices assigned to domains", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			Expect(vmiSourceInformer.GetStore().Add(vmi)).To(Succeed())
+			Expect(domainInformer.GetStore().Add(newMDEVDomain("testvmi"))).To(Succeed())
+
+			mdevs, synced := controller.assignedMDEVs()
+			Expect(synced).To(BeTrue())
+			Expect(mdevs).To(HaveKey(mdevUUID))
+		})
+
+		It("should not know the usage while a VMI on the node has no domain yet", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Scheduled
+			Expect(vmiSourceInformer.GetStore().Add(vmi)).To(Succeed())
+
+			_, synced := controller.assignedMDEVs()
+			Expect(synced).To(BeFalse())
+
+			Expect(domainInformer.GetStore().Add(newMDEVDomain("testvmi"))).To(Succeed())
+			mdevs, synced := controller.assignedMDEVs()
+			Expect(synced).To(BeTrue())
+			Expect(mdevs).To(HaveKey(mdevUUID))
+		})
+
+		It("should ignore VMIs which are gone from the node", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Succeeded
+			Expect(vmiSourceInformer.GetStore().Add(vmi)).To(Succeed())
+
+			mdevs, synced := controller.assignedMDEVs()
+			Expect(synced).To(BeTrue())
+			Expect(mdevs).To(BeEmpty())
+		})
+
+		It("should not know the usage while the domain of a VMI migrating to the node is not defined yet", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				TargetNode: host,
+				SourceNode: "othernode",
+			}
+			Expect(vmiTargetInformer.GetStore().Add(vmi)).To(Succeed())
+
+			_, synced := controller.assignedMDEVs()
+			Expect(synced).To(BeFalse())
+
+			vmi.Status.MigrationState.Failed = true
+			Expect(vmiTargetInformer.GetStore().Update(vmi)).To(Succeed())
+			_, synced = controller.assignedMDEVs()
+			Expect(synced).To(BeTrue())
+		})
+	})
+
 })
 
 var _ = Describe("DomainNotifyServerRestarts", func() {
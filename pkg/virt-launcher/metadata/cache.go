@@ -22,6 +22,7 @@ package metadata
 import (
 	"k8s.io/apimachinery/pkg/types"
 
+	"kubevirt.io/kubevirt/pkg/tracing"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
@@ -31,6 +32,12 @@ type Cache struct {
 	GracePeriod      SafeData[api.GracePeriodMetadata]
 	AccessCredential SafeData[api.AccessCredentialMetadata]
 	MemoryDump       SafeData[api.MemoryDumpMetadata]
+	DirtyRate        SafeData[api.DirtyRateMetadata]
+	FreezeHooks      SafeData[api.FreezeHooksMetadata]
+
+	// TraceContext is the span context of the last VMI sync, it is sent along with domain events.
+	// Unlike the other fields, it is not persisted in the domain metadata.
+	TraceContext SafeData[tracing.SpanContext]
 
 	notificationSignal chan struct{}
 }
@@ -44,6 +51,8 @@ func NewCache() *Cache {
 	cache.GracePeriod.dirtyChanel = cache.notificationSignal
 	cache.AccessCredential.dirtyChanel = cache.notificationSignal
 	cache.MemoryDump.dirtyChanel = cache.notificationSignal
+	cache.DirtyRate.dirtyChanel = cache.notificationSignal
+	cache.FreezeHooks.dirtyChanel = cache.notificationSignal
 	return cache
 }
 
@@ -44,5 +44,11 @@ func LoadKubevirtMetadata(metadataCache *Cache) api.KubeVirtMetadata {
 	if value, exists := metadataCache.MemoryDump.Load(); exists {
 		kubevirtMetadata.MemoryDump = &value
 	}
+	if value, exists := metadataCache.DirtyRate.Load(); exists {
+		kubevirtMetadata.DirtyRate = &value
+	}
+	if value, exists := metadataCache.FreezeHooks.Load(); exists {
+		kubevirtMetadata.FreezeHooks = &value
+	}
 	return kubevirtMetadata
 }
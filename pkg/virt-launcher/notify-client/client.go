@@ -27,6 +27,7 @@ import (
 	com "kubevirt.io/kubevirt/pkg/handler-launcher-com"
 	"kubevirt.io/kubevirt/pkg/handler-launcher-com/notify/info"
 	notifyv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/notify/v1"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
 	agentpoller "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent-poller"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -57,6 +58,8 @@ type Notifier struct {
 	intervalTimeout time.Duration
 	sendTimeout     time.Duration
 	totalTimeout    time.Duration
+
+	metadataCache *metadata.Cache
 }
 
 type libvirtEvent struct {
@@ -211,7 +214,7 @@ func (n *Notifier) SendDomainEvent(event watch.Event) error {
 
 		ctx, cancel := context.WithTimeout(context.Background(), n.sendTimeout)
 		defer cancel()
-		response, err = n.v1client.HandleDomainEvent(ctx, &request)
+		response, err = n.v1client.HandleDomainEvent(tracing.OutgoingContext(ctx, n.traceContext()), &request)
 
 		if err != nil {
 			log.Log.Reason(err).Errorf("Failed to send domain notify event. closing connection.")
@@ -234,6 +237,16 @@ func (n *Notifier) SendDomainEvent(event watch.Event) error {
 	return nil
 }
 
+// traceContext returns the trace context of the last VMI sync, so that virt-handler can relate
+// the domain events to the VMI lifecycle trace. It has to be called with connLock held.
+func (n *Notifier) traceContext() tracing.SpanContext {
+	if n.metadataCache == nil {
+		return tracing.SpanContext{}
+	}
+	traceContext, _ := n.metadataCache.TraceContext.Load()
+	return traceContext
+}
+
 func newWatchEventError(err error) watch.Event {
 	return watch.Event{Type: watch.Error, Object: &metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}}
 }
@@ -386,9 +399,14 @@ func (n *Notifier) StartDomainNotifier(
 	qemuAgentUserInterval time.Duration,
 	qemuAgentVersionInterval time.Duration,
 	qemuAgentFSFreezeStatusInterval time.Duration,
+	qemuAgentMetricsInterval time.Duration,
 	metadataCache *metadata.Cache,
 ) error {
 
+	n.connLock.Lock()
+	n.metadataCache = metadataCache
+	n.connLock.Unlock()
+
 	eventChan := make(chan libvirtEvent, 10)
 
 	reconnectChan := make(chan bool, 10)
@@ -407,6 +425,7 @@ func (n *Notifier) StartDomainNotifier(
 		qemuAgentUserInterval,
 		qemuAgentVersionInterval,
 		qemuAgentFSFreezeStatusInterval,
+		qemuAgentMetricsInterval,
 	)
 
 	// Run the event process logic in a separate go-routine to not block libvirt
@@ -313,6 +313,25 @@ var _ = Describe("Notify", func() {
 			Expect(event).To(Equal(fmt.Sprintf("%s %s %s involvedObject{kind=VirtualMachineInstance,apiVersion=kubevirt.io/v1}", eventType, eventReason, eventMessage)))
 		})
 
+		It("Should record a k8s event when the domain state changes", func() {
+			vmi := api2.NewMinimalVMI("fake-vmi")
+			vmi.UID = "4321"
+			vmiStore.Add(vmi)
+
+			domain := api.NewMinimalDomainWithNS(vmi.Namespace, vmi.Name)
+			domain.ObjectMeta.UID = vmi.UID
+			domain.SetState(api.Running, api.ReasonUnknown)
+
+			Expect(client.SendDomainEvent(watch.Event{Type: watch.Added, Object: domain})).To(Succeed())
+			Expect(client.SendDomainEvent(watch.Event{Type: watch.Modified, Object: domain})).To(Succeed())
+			domain.SetState(api.Paused, api.ReasonPausedIOError)
+			Expect(client.SendDomainEvent(watch.Event{Type: watch.Modified, Object: domain})).To(Succeed())
+
+			Expect(<-recorder.Events).To(Equal("Normal DomainLifecycle Domain is Running (Unknown) involvedObject{kind=VirtualMachineInstance,apiVersion=kubevirt.io/v1}"))
+			Expect(<-recorder.Events).To(Equal("Warning DomainLifecycle Domain is Paused (IOError) involvedObject{kind=VirtualMachineInstance,apiVersion=kubevirt.io/v1}"))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
 		It("Should generate a k8s event on IO errors", func() {
 			faultDisk := []libvirt.DomainDiskError{
 				{
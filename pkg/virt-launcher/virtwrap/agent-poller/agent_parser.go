@@ -88,6 +88,43 @@ type Filesystem struct {
 	TotalBytes int    `json:"total-bytes,omitempty"`
 }
 
+// Load is the response from 'guest-get-load'
+type Load struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+}
+
+// CPUStats is a single cpu of the response from 'guest-get-cpustats',
+// the agent already converts the clock ticks to milliseconds
+type CPUStats struct {
+	Type    string `json:"type"`
+	CPU     int    `json:"cpu"`
+	User    uint64 `json:"user"`
+	Nice    uint64 `json:"nice"`
+	System  uint64 `json:"system"`
+	Idle    uint64 `json:"idle"`
+	IOWait  uint64 `json:"iowait,omitempty"`
+	IRQ     uint64 `json:"irq,omitempty"`
+	SoftIRQ uint64 `json:"softirq,omitempty"`
+	Steal   uint64 `json:"steal,omitempty"`
+}
+
+// DiskStats is a single block device of the response from 'guest-get-diskstats'
+type DiskStats struct {
+	Name  string `json:"name"`
+	Stats struct {
+		ReadSectors  uint64 `json:"read-sectors,omitempty"`
+		ReadIOs      uint64 `json:"read-ios,omitempty"`
+		ReadTicks    uint64 `json:"read-ticks,omitempty"`
+		WriteSectors uint64 `json:"write-sectors,omitempty"`
+		WriteIOs     uint64 `json:"write-ios,omitempty"`
+		WriteTicks   uint64 `json:"write-ticks,omitempty"`
+		IOsInFlight  uint64 `json:"ios-pgr,omitempty"`
+		TotalTicks   uint64 `json:"total-ticks,omitempty"`
+	} `json:"stats"`
+}
+
 // AgentInfo from the guest VM serves the purpose
 // of checking the GA presence and version compatibility
 type AgentInfo struct {
@@ -225,6 +262,91 @@ func parseUsers(agentReply string) ([]api.User, error) {
 	return convertedResult, nil
 }
 
+// parseLoad from the agent response
+func parseLoad(agentReply string) (api.GuestLoad, error) {
+	result := Load{}
+	response := stripAgentResponse(agentReply)
+
+	err := json.Unmarshal([]byte(response), &result)
+	if err != nil {
+		return api.GuestLoad{}, err
+	}
+
+	return api.GuestLoad{
+		Load1:  result.Load1,
+		Load5:  result.Load5,
+		Load15: result.Load15,
+	}, nil
+}
+
+// parseCPUStats from the agent response, only linux guests report cpu statistics
+func parseCPUStats(agentReply string) ([]api.GuestCPUStats, error) {
+	result := []CPUStats{}
+	response := stripAgentResponse(agentReply)
+
+	err := json.Unmarshal([]byte(response), &result)
+	if err != nil {
+		return []api.GuestCPUStats{}, err
+	}
+
+	convertedResult := []api.GuestCPUStats{}
+
+	for _, cpu := range result {
+		if cpu.Type != "linux" {
+			continue
+		}
+		convertedResult = append(convertedResult, api.GuestCPUStats{
+			CPU:     cpu.CPU,
+			User:    cpu.User,
+			Nice:    cpu.Nice,
+			System:  cpu.System,
+			Idle:    cpu.Idle,
+			IOWait:  cpu.IOWait,
+			IRQ:     cpu.IRQ,
+			SoftIRQ: cpu.SoftIRQ,
+			Steal:   cpu.Steal,
+		})
+	}
+
+	return convertedResult, nil
+}
+
+// wholeDiskNameRegex matches the names of whole disks, partitions, loop, device mapper and other
+// virtual block devices of the guest are not reported to keep the number of series per VMI bounded
+var wholeDiskNameRegex = regexp.MustCompile(`^((s|v|xv|h)d[a-z]+|nvme[0-9]+n[0-9]+|mmcblk[0-9]+)$`)
+
+// parseDiskStats from the agent response, only whole disks are kept
+func parseDiskStats(agentReply string) ([]api.GuestDiskStats, error) {
+	result := []DiskStats{}
+	response := stripAgentResponse(agentReply)
+
+	err := json.Unmarshal([]byte(response), &result)
+	if err != nil {
+		return []api.GuestDiskStats{}, err
+	}
+
+	convertedResult := []api.GuestDiskStats{}
+
+	for _, disk := range result {
+		if !wholeDiskNameRegex.MatchString(disk.Name) {
+			continue
+		}
+		convertedResult = append(convertedResult, api.GuestDiskStats{
+			Name:         disk.Name,
+			ReadIOs:      disk.Stats.ReadIOs,
+			ReadSectors:  disk.Stats.ReadSectors,
+			ReadTicks:    disk.Stats.ReadTicks,
+			WriteIOs:     disk.Stats.WriteIOs,
+			WriteSectors: disk.Stats.WriteSectors,
+			WriteTicks:   disk.Stats.WriteTicks,
+			InFlightIOs:  disk.Stats.IOsInFlight,
+			TotalTicks:   disk.Stats.TotalTicks,
+		})
+	}
+
+	return convertedResult, nil
+}
+
 // parseAgent gets the agent version from response
 func parseAgent(agentReply string) (AgentInfo, error) {
 	gaInfo := AgentInfo{}
@@ -20,6 +20,9 @@
 package agentpoller
 
 import (
+	"fmt"
+	"strings"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -306,5 +309,107 @@ var _ = Describe("Qemu agent poller", func() {
 			}
 			Expect(parseUsers(jsonInput)).To(Equal(expectedUsers))
 		})
+
+		It("should parse Load", func() {
+			jsonInput := `{"return":{"load1":1.5,"load5":0.75,"load15":0.25}}`
+
+			Expect(parseLoad(jsonInput)).To(Equal(api.GuestLoad{
+				Load1:  1.5,
+				Load5:  0.75,
+				Load15: 0.25,
+			}))
+		})
+
+		It("should parse CPUStats of linux guests", func() {
+			jsonInput := `{
+                "return":[
+                    {
+                        "type":"linux",
+                        "cpu":0,
+                        "user":1000,
+                        "nice":10,
+                        "system":500,
+                        "idle":9000,
+                        "iowait":20,
+                        "irq":1,
+                        "softirq":2,
+                        "steal":3,
+                        "guest":0,
+                        "guestnice":0
+                    },
+                    {
+                        "type":"unknown"
+                    }
+                ]
+            }`
+
+			Expect(parseCPUStats(jsonInput)).To(Equal([]api.GuestCPUStats{
+				{
+					CPU:     0,
+					User:    1000,
+					Nice:    10,
+					System:  500,
+					Idle:    9000,
+					IOWait:  20,
+					IRQ:     1,
+					SoftIRQ: 2,
+					Steal:   3,
+				},
+			}))
+		})
+
+		It("should parse DiskStats", func() {
+			jsonInput := `{
+                "return":[
+                    {
+                        "name":"vda",
+                        "major":252,
+                        "minor":0,
+                        "stats":{
+                            "read-sectors":2048,
+                            "read-ios":100,
+                            "read-merges":0,
+                            "read-ticks":50,
+                            "write-sectors":4096,
+                            "write-ios":200,
+                            "write-ticks":80,
+                            "ios-pgr":1,
+                            "total-ticks":120,
+                            "weight-ticks":130
+                        }
+                    }
+                ]
+            }`
+
+			Expect(parseDiskStats(jsonInput)).To(Equal([]api.GuestDiskStats{
+				{
+					Name:         "vda",
+					ReadIOs:      100,
+					ReadSectors:  2048,
+					ReadTicks:    50,
+					WriteIOs:     200,
+					WriteSectors: 4096,
+					WriteTicks:   80,
+					InFlightIOs:  1,
+					TotalTicks:   120,
+				},
+			}))
+		})
+
+		It("should only keep the DiskStats of whole disks", func() {
+			var devices []string
+			for _, name := range []string{"vda", "vda1", "sdb", "sdb2", "nvme0n1", "nvme0n1p1", "mmcblk0", "mmcblk0p2", "loop0", "dm-0", "sr0", "zram0"} {
+				devices = append(devices, fmt.Sprintf(`{"name":%q,"major":252,"minor":0,"stats":{}}`, name))
+			}
+			jsonInput := fmt.Sprintf(`{"return":[%s]}`, strings.Join(devices, ","))
+
+			diskStats, err := parseDiskStats(jsonInput)
+			Expect(err).ToNot(HaveOccurred())
+			var names []string
+			for _, disk := range diskStats {
+				names = append(names, disk.Name)
+			}
+			Expect(names).To(ConsistOf("vda", "sdb", "nvme0n1", "mmcblk0"))
+		})
 	})
 })
@@ -47,10 +47,21 @@ const (
 	GET_FILESYSTEM      AgentCommand = "guest-get-fsinfo"
 	GET_AGENT           AgentCommand = "guest-info"
 	GET_FSFREEZE_STATUS AgentCommand = "guest-fsfreeze-status"
+	GET_LOAD            AgentCommand = "guest-get-load"
+	GET_CPUSTATS        AgentCommand = "guest-get-cpustats"
+	GET_DISKSTATS       AgentCommand = "guest-get-diskstats"
 
 	pollInitialInterval = 10 * time.Second
 )
 
+// metricsCommands report statistics which change on every poll, they are only read on demand
+// and don't fire up an updated event
+var metricsCommands = map[AgentCommand]struct{}{
+	GET_LOAD:      {},
+	GET_CPUSTATS:  {},
+	GET_DISKSTATS: {},
+}
+
 // AgentUpdatedEvent fire up when data is changes in the store
 type AgentUpdatedEvent struct {
 	Type       AgentCommand
@@ -82,6 +93,10 @@ func (s *AsyncAgentStore) Store(key AgentCommand, value interface{}) {
 
 	s.store.Store(key, value)
 
+	if _, isMetrics := metricsCommands[key]; isMetrics {
+		return
+	}
+
 	if updated {
 		domainInfo := api.DomainGuestInfo{}
 		// Fill only updated part of the domainInfo
@@ -180,6 +195,32 @@ func (s *AsyncAgentStore) GetFSFreezeStatus() api.FSFreeze {
 	return fsfreezeStatus
 }
 
+// GetGuestMetrics returns the load, cpu and disk statistics reported by the guest,
+// or nil when the guest agent supports none of the commands
+func (s *AsyncAgentStore) GetGuestMetrics() *api.GuestMetrics {
+	metrics := api.GuestMetrics{}
+	found := false
+
+	if data, ok := s.store.Load(GET_LOAD); ok {
+		load := data.(api.GuestLoad)
+		metrics.Load = &load
+		found = true
+	}
+	if data, ok := s.store.Load(GET_CPUSTATS); ok {
+		metrics.CPUStats = data.([]api.GuestCPUStats)
+		found = true
+	}
+	if data, ok := s.store.Load(GET_DISKSTATS); ok {
+		metrics.DiskStats = data.([]api.GuestDiskStats)
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+	return &metrics
+}
+
 // GetFS returns the filesystem list limited to the limit set
 // set limit to -1 to return the whole list
 func (s *AsyncAgentStore) GetFS(limit int) []api.Filesystem {
@@ -286,6 +327,7 @@ func CreatePoller(
 	qemuAgentUserInterval time.Duration,
 	qemuAgentVersionInterval time.Duration,
 	qemuAgentFSFreezeStatusInterval time.Duration,
+	qemuAgentMetricsInterval time.Duration,
 ) *AgentPoller {
 	p := &AgentPoller{
 		Connection: connecton,
@@ -320,6 +362,12 @@ func CreatePoller(
 		CallTick:      qemuAgentFSFreezeStatusInterval,
 		AgentCommands: []AgentCommand{GET_FSFREEZE_STATUS},
 	})
+	// metrics command group, the agent has no command listing the guest processes, so the
+	// top consumers can't be reported without executing a program in the guest on every poll
+	p.workers = append(p.workers, PollerWorker{
+		CallTick:      qemuAgentMetricsInterval,
+		AgentCommands: []AgentCommand{GET_LOAD, GET_CPUSTATS, GET_DISKSTATS},
+	})
 
 	return p
 }
@@ -416,6 +464,27 @@ func executeAgentCommands(commands []AgentCommand, con cli.Connection, agentStor
 				continue
 			}
 			agentStore.Store(GET_AGENT, agent)
+		case GET_LOAD:
+			load, err := parseLoad(cmdResult)
+			if err != nil {
+				log.Log.Errorf("Cannot parse guest agent load %s", err.Error())
+				continue
+			}
+			agentStore.Store(GET_LOAD, load)
+		case GET_CPUSTATS:
+			cpuStats, err := parseCPUStats(cmdResult)
+			if err != nil {
+				log.Log.Errorf("Cannot parse guest agent cpu stats %s", err.Error())
+				continue
+			}
+			agentStore.Store(GET_CPUSTATS, cpuStats)
+		case GET_DISKSTATS:
+			diskStats, err := parseDiskStats(cmdResult)
+			if err != nil {
+				log.Log.Errorf("Cannot parse guest agent disk stats %s", err.Error())
+				continue
+			}
+			agentStore.Store(GET_DISKSTATS, diskStats)
 		}
 	}
 }
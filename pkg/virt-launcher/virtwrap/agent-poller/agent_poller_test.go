@@ -40,6 +40,22 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(agent).To(Equal(agentVersion))
 		})
 
+		It("should store the guest metrics without firing an event", func() {
+			var agentStore = NewAsyncAgentStore()
+			Expect(agentStore.GetGuestMetrics()).To(BeNil())
+
+			load := api.GuestLoad{Load1: 1, Load5: 0.5, Load15: 0.25}
+			diskStats := []api.GuestDiskStats{{Name: "vda", ReadIOs: 10}}
+			agentStore.Store(GET_LOAD, load)
+			agentStore.Store(GET_DISKSTATS, diskStats)
+
+			Expect(agentStore.AgentUpdated).ToNot(Receive())
+			Expect(agentStore.GetGuestMetrics()).To(Equal(&api.GuestMetrics{
+				Load:      &load,
+				DiskStats: diskStats,
+			}))
+		})
+
 		It("should fire an event for new fsfreezestatus", func() {
 			var agentStore = NewAsyncAgentStore()
 
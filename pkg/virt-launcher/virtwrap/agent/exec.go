@@ -9,6 +9,10 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 )
 
+// execStatusPollInterval is how often the guest agent is asked whether a command exited,
+// independently of how long the command may run
+const execStatusPollInterval = 100 * time.Millisecond
+
 type execReturn struct {
 	Return execReturnData `json:"return"`
 }
@@ -41,13 +45,13 @@ func GuestExec(virConn cli.Connection, domName string, command string, args []st
 	argsStr := ""
 	for _, arg := range args {
 		if argsStr == "" {
-			argsStr = fmt.Sprintf("\"%s\"", arg)
+			argsStr = quote(arg)
 		} else {
-			argsStr = argsStr + fmt.Sprintf(", \"%s\"", arg)
+			argsStr = argsStr + ", " + quote(arg)
 		}
 	}
 
-	cmdExec := fmt.Sprintf(`{"execute": "guest-exec", "arguments": { "path": "%s", "arg": [ %s ], "capture-output":true } }`, command, argsStr)
+	cmdExec := fmt.Sprintf(`{"execute": "guest-exec", "arguments": { "path": %s, "arg": [ %s ], "capture-output":true } }`, quote(command), argsStr)
 	output, err := virConn.QemuAgentCommand(cmdExec, domName)
 	if err != nil {
 		return "", err
@@ -64,7 +68,7 @@ func GuestExec(virConn cli.Connection, domName string, command string, args []st
 
 	exited := false
 	exitCode := 0
-	statusCheck := time.NewTicker(time.Duration(timeoutSeconds) * 100 * time.Millisecond)
+	statusCheck := time.NewTicker(execStatusPollInterval)
 	defer statusCheck.Stop()
 	checkUntil := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
 
@@ -104,3 +108,9 @@ func GuestExec(virConn cli.Connection, domName string, command string, args []st
 
 	return stdOut, nil
 }
+
+// quote returns s as a JSON string, escaping the characters that would break the command
+func quote(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
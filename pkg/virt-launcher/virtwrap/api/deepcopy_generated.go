@@ -788,6 +788,26 @@ func (in *Devices) DeepCopy() *Devices {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirtyRateMetadata) DeepCopyInto(out *DirtyRateMetadata) {
+	*out = *in
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirtyRateMetadata.
+func (in *DirtyRateMetadata) DeepCopy() *DirtyRateMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(DirtyRateMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Disk) DeepCopyInto(out *Disk) {
 	*out = *in
@@ -1671,6 +1691,30 @@ func (in *FilterRef) DeepCopy() *FilterRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeHooksMetadata) DeepCopyInto(out *FreezeHooksMetadata) {
+	*out = *in
+	if in.PreFreezeTimestamp != nil {
+		in, out := &in.PreFreezeTimestamp, &out.PreFreezeTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.PostThawTimestamp != nil {
+		in, out := &in.PostThawTimestamp, &out.PostThawTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeHooksMetadata.
+func (in *FreezeHooksMetadata) DeepCopy() *FreezeHooksMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeHooksMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GracePeriodMetadata) DeepCopyInto(out *GracePeriodMetadata) {
 	*out = *in
@@ -1733,6 +1777,85 @@ func (in *GraphicsListen) DeepCopy() *GraphicsListen {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestCPUStats) DeepCopyInto(out *GuestCPUStats) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestCPUStats.
+func (in *GuestCPUStats) DeepCopy() *GuestCPUStats {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestCPUStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestDiskStats) DeepCopyInto(out *GuestDiskStats) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestDiskStats.
+func (in *GuestDiskStats) DeepCopy() *GuestDiskStats {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestDiskStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestLoad) DeepCopyInto(out *GuestLoad) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestLoad.
+func (in *GuestLoad) DeepCopy() *GuestLoad {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestLoad)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestMetrics) DeepCopyInto(out *GuestMetrics) {
+	*out = *in
+	if in.Load != nil {
+		in, out := &in.Load, &out.Load
+		*out = new(GuestLoad)
+		**out = **in
+	}
+	if in.CPUStats != nil {
+		in, out := &in.CPUStats, &out.CPUStats
+		*out = make([]GuestCPUStats, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiskStats != nil {
+		in, out := &in.DiskStats, &out.DiskStats
+		*out = make([]GuestDiskStats, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestMetrics.
+func (in *GuestMetrics) DeepCopy() *GuestMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GuestOSInfo) DeepCopyInto(out *GuestOSInfo) {
 	*out = *in
@@ -2066,6 +2189,16 @@ func (in *KubeVirtMetadata) DeepCopyInto(out *KubeVirtMetadata) {
 		*out = new(MemoryDumpMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DirtyRate != nil {
+		in, out := &in.DirtyRate, &out.DirtyRate
+		*out = new(DirtyRateMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FreezeHooks != nil {
+		in, out := &in.FreezeHooks, &out.FreezeHooks
+		*out = new(FreezeHooksMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
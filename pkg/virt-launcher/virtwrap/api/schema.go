@@ -99,6 +99,7 @@ const (
 
 	HostDevicePCI  = "pci"
 	HostDeviceMDev = "mdev"
+	HostDeviceUSB  = "usb"
 	AddressPCI     = "pci"
 )
 
@@ -165,6 +166,45 @@ type User struct {
 	LoginTime float64
 }
 
+type GuestLoad struct {
+	Load1  float64
+	Load5  float64
+	Load15 float64
+}
+
+// GuestMetrics holds the statistics reported by the guest agent
+type GuestMetrics struct {
+	Load      *GuestLoad
+	CPUStats  []GuestCPUStats
+	DiskStats []GuestDiskStats
+}
+
+// GuestCPUStats holds the time in milliseconds a guest cpu spent in each mode
+type GuestCPUStats struct {
+	CPU     int
+	User    uint64
+	Nice    uint64
+	System  uint64
+	Idle    uint64
+	IOWait  uint64
+	IRQ     uint64
+	SoftIRQ uint64
+	Steal   uint64
+}
+
+// GuestDiskStats holds the I/O statistics of a guest block device, times are in milliseconds
+type GuestDiskStats struct {
+	Name         string
+	ReadIOs      uint64
+	ReadSectors  uint64
+	ReadTicks    uint64
+	WriteIOs     uint64
+	WriteSectors uint64
+	WriteTicks   uint64
+	InFlightIOs  uint64
+	TotalTicks   uint64
+}
+
 // DomainGuestInfo represent guest agent info for specific domain
 type DomainGuestInfo struct {
 	Interfaces     []InterfaceStatus
@@ -362,6 +402,8 @@ type KubeVirtMetadata struct {
 	Migration        *MigrationMetadata        `xml:"migration,omitempty"`
 	AccessCredential *AccessCredentialMetadata `xml:"accessCredential,omitempty"`
 	MemoryDump       *MemoryDumpMetadata       `xml:"memoryDump,omitempty"`
+	DirtyRate        *DirtyRateMetadata        `xml:"dirtyRate,omitempty"`
+	FreezeHooks      *FreezeHooksMetadata      `xml:"freezeHooks,omitempty"`
 }
 
 type AccessCredentialMetadata struct {
@@ -378,6 +420,23 @@ type MemoryDumpMetadata struct {
 	FailureReason  string       `xml:"failureReason,omitempty"`
 }
 
+// FreezeHooksMetadata is the outcome of the freeze hooks, the failed
+// hooks are comma separated lists of hook names
+type FreezeHooksMetadata struct {
+	PreFreezeTimestamp *metav1.Time `xml:"preFreezeTimestamp,omitempty"`
+	PreFreezeFailed    string       `xml:"preFreezeFailed,omitempty"`
+	Aborted            bool         `xml:"aborted,omitempty"`
+	PostThawTimestamp  *metav1.Time `xml:"postThawTimestamp,omitempty"`
+	PostThawFailed     string       `xml:"postThawFailed,omitempty"`
+	Message            string       `xml:"message,omitempty"`
+}
+
+type DirtyRateMetadata struct {
+	BytesPerSecond int64        `xml:"bytesPerSecond"`
+	CalcPeriod     int          `xml:"calcPeriod,omitempty"`
+	Timestamp      *metav1.Time `xml:"timestamp,omitempty"`
+}
+
 type MigrationMetadata struct {
 	UID            types.UID        `xml:"uid,omitempty"`
 	StartTimestamp *metav1.Time     `xml:"startTimestamp,omitempty"`
@@ -1039,6 +1098,7 @@ type Address struct {
 	Target     string `xml:"target,attr,omitempty"`
 	Unit       string `xml:"unit,attr,omitempty"`
 	UUID       string `xml:"uuid,attr,omitempty"`
+	Device     string `xml:"device,attr,omitempty"`
 }
 
 //END Video -------------------
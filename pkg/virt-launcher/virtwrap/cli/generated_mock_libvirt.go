@@ -53,6 +53,16 @@ func (_mr *_MockConnectionRecorder) DomainDefineXML(arg0 interface{}) *gomock.Ca
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DomainDefineXML", arg0)
 }
 
+func (_m *MockConnection) DomainRestoreFlags(srcFile string, xml string, flags libvirt.DomainSaveRestoreFlags) error {
+	ret := _m.ctrl.Call(_m, "DomainRestoreFlags", srcFile, xml, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockConnectionRecorder) DomainRestoreFlags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DomainRestoreFlags", arg0, arg1, arg2)
+}
+
 func (_m *MockConnection) Close() (int, error) {
 	ret := _m.ctrl.Call(_m, "Close")
 	ret0, _ := ret[0].(int)
@@ -166,6 +176,17 @@ func (_mr *_MockConnectionRecorder) QemuAgentCommand(arg0, arg1 interface{}) *go
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "QemuAgentCommand", arg0, arg1)
 }
 
+func (_m *MockConnection) QemuMonitorCommand(command string, domainName string) (string, error) {
+	ret := _m.ctrl.Call(_m, "QemuMonitorCommand", command, domainName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockConnectionRecorder) QemuMonitorCommand(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "QemuMonitorCommand", arg0, arg1)
+}
+
 func (_m *MockConnection) GetAllDomainStats(statsTypes libvirt.DomainStatsTypes, flags libvirt.ConnectGetAllDomainStatsFlags) ([]libvirt.DomainStats, error) {
 	ret := _m.ctrl.Call(_m, "GetAllDomainStats", statsTypes, flags)
 	ret0, _ := ret[0].([]libvirt.DomainStats)
@@ -615,6 +636,17 @@ func (_mr *_MockVirDomainRecorder) CoreDumpWithFormat(arg0, arg1, arg2 interface
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "CoreDumpWithFormat", arg0, arg1, arg2)
 }
 
+func (_m *MockVirDomain) CreateSnapshotXML(xml string, flags libvirt.DomainSnapshotCreateFlags) (*libvirt.DomainSnapshot, error) {
+	ret := _m.ctrl.Call(_m, "CreateSnapshotXML", xml, flags)
+	ret0, _ := ret[0].(*libvirt.DomainSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) CreateSnapshotXML(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateSnapshotXML", arg0, arg1)
+}
+
 func (_m *MockVirDomain) PinVcpuFlags(vcpu uint, cpuMap []bool, flags libvirt.DomainModificationImpact) error {
 	ret := _m.ctrl.Call(_m, "PinVcpuFlags", vcpu, cpuMap, flags)
 	ret0, _ := ret[0].(error)
@@ -644,3 +676,13 @@ func (_m *MockVirDomain) SetVcpusFlags(vcpu uint, flags libvirt.DomainVcpuFlags)
 func (_mr *_MockVirDomainRecorder) SetVcpusFlags(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetVcpusFlags", arg0, arg1)
 }
+
+func (_m *MockVirDomain) StartDirtyRateCalc(secs int, flags libvirt.DomainDirtyRateCalcFlags) error {
+	ret := _m.ctrl.Call(_m, "StartDirtyRateCalc", secs, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) StartDirtyRateCalc(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StartDirtyRateCalc", arg0, arg1)
+}
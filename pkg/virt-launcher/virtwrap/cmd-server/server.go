@@ -27,6 +27,8 @@ import (
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"k8s.io/apimachinery/pkg/util/json"
 
@@ -34,6 +36,7 @@ import (
 	"kubevirt.io/client-go/log"
 
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap"
@@ -191,15 +194,19 @@ func (l *Launcher) SyncVirtualMachineCPUs(_ context.Context, request *cmdv1.VMIR
 	return response, nil
 }
 
-func (l *Launcher) SyncVirtualMachine(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+func (l *Launcher) SyncVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 
 	vmi, response := getVMIFromRequest(request.Vmi)
 	if !response.Success {
 		return response, nil
 	}
 
+	span := startSyncSpan(ctx, vmi)
+	defer span.End()
+
 	if _, err := l.domainManager.SyncVMI(vmi, l.allowEmulation, request.Options); err != nil {
 		log.Log.Object(vmi).Reason(err).Errorf("Failed to sync vmi")
+		span.SetError(err)
 		response.Success = false
 		response.Message = getErrorMessage(err)
 		return response, nil
@@ -209,6 +216,16 @@ func (l *Launcher) SyncVirtualMachine(_ context.Context, request *cmdv1.VMIReque
 	return response, nil
 }
 
+// startSyncSpan continues the trace virt-handler sent along with the sync request. The VMI annotation
+// is replaced with the span context of the sync, so that the domain manager can parent its spans to it.
+// Without a trace context in the request the annotation is dropped, the sync is not part of the lifecycle trace.
+func startSyncSpan(ctx context.Context, vmi *v1.VirtualMachineInstance) *tracing.Span {
+	span := tracing.StartSpan(tracing.FromIncomingContext(ctx), "virt-launcher.SyncVMI")
+	delete(vmi.Annotations, v1.TraceparentAnnotation)
+	tracing.SetObjectSpanContext(vmi, span.SpanContext)
+	return span
+}
+
 func (l *Launcher) PauseVirtualMachine(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 	vmi, response := getVMIFromRequest(request.Vmi)
 	if !response.Success {
@@ -267,6 +284,11 @@ func (l *Launcher) FreezeVirtualMachine(_ context.Context, request *cmdv1.Freeze
 
 	if err := l.domainManager.FreezeVMI(vmi, request.UnfreezeTimeoutSeconds); err != nil {
 		log.Log.Object(vmi).Reason(err).Errorf("Failed to freeze vmi")
+		// a freeze aborted by a pre-freeze hook must not be retried
+		var abortErr *virtwrap.FreezeAbortedError
+		if errors.As(err, &abortErr) {
+			return nil, status.Error(codes.Aborted, abortErr.Message)
+		}
 		response.Success = false
 		response.Message = getErrorMessage(err)
 		return response, nil
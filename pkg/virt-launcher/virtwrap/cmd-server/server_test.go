@@ -29,6 +29,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/status"
 
 	v1 "kubevirt.io/api/core/v1"
 
@@ -108,6 +109,14 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(client.FreezeVirtualMachine(vmi, int32(0))).To(Succeed())
 		})
 
+		It("should report a freeze aborted by a pre-freeze hook", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().FreezeVMI(vmi, int32(0)).Return(&virtwrap.FreezeAbortedError{Message: "pre-freeze hook flush failed"})
+			err := client.FreezeVirtualMachine(vmi, int32(0))
+			Expect(cmdclient.IsAborted(err)).To(BeTrue())
+			Expect(status.Convert(err).Message()).To(Equal("pre-freeze hook flush failed"))
+		})
+
 		It("should unfreeze a vmi", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			domainManager.EXPECT().UnfreezeVMI(vmi)
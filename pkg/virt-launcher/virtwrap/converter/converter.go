@@ -65,6 +65,7 @@ import (
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
 )
 
 const deviceTypeNotCompatibleFmt = "device %s is of type lun. Not compatible with a file based disk"
@@ -1451,10 +1452,10 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		useIOThreads = true
 
 		if (*vmi.Spec.Domain.IOThreadsPolicy) == v1.IOThreadsPolicyAuto {
-			// When IOThreads policy is set to auto and we've allocated a dedicated
-			// pCPU for the emulator thread, we can place IOThread and Emulator thread in the same pCPU
+			// When IOThreads policy is set to auto and we've allocated dedicated
+			// pCPUs for the emulator thread, we can place one IOThread on each pCPU of the pool
 			if vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.IsolateEmulatorThread {
-				threadPoolLimit = 1
+				threadPoolLimit = int(hardware.GetNumberOfEmulatorThreadCPUs(vmi.Spec.Domain.CPU))
 			} else {
 				numCPUs := 1
 				// Requested CPU's is guaranteed to be no greater than the limit
@@ -1697,6 +1698,10 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				return err
 			}
 		}
+		// The vCPUs and the memory of soft placed VMIs are moved between host numa nodes by virt-handler
+		if vmi.IsCPUSoftPlaced() {
+			vcpu.AdjustDomainForSoftCPUPlacement(&domain.Spec, c.Topology)
+		}
 	}
 
 	// Make use of the tsc frequency topology hint
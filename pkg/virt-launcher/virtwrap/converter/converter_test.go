@@ -2819,7 +2819,7 @@ var _ = Describe("Converter", func() {
 			domain.Spec.IOThreads = &api.IOThreads{}
 			domain.Spec.IOThreads.IOThreads = uint(6)
 
-			Expect(vcpu.FormatDomainIOThreadPin(vmi, domain, 0, c.CPUSet)).To(Succeed())
+			Expect(vcpu.FormatDomainIOThreadPin(vmi, domain, nil, c.CPUSet)).To(Succeed())
 			expectedLayout := []api.CPUTuneIOThreadPin{
 				{IOThread: 1, CPUSet: "5,6,7"},
 				{IOThread: 2, CPUSet: "8,9,10"},
@@ -2851,7 +2851,7 @@ var _ = Describe("Converter", func() {
 			domain.Spec.IOThreads = &api.IOThreads{}
 			domain.Spec.IOThreads.IOThreads = uint(6)
 
-			Expect(vcpu.FormatDomainIOThreadPin(vmi, domain, 0, c.CPUSet)).To(Succeed())
+			Expect(vcpu.FormatDomainIOThreadPin(vmi, domain, nil, c.CPUSet)).To(Succeed())
 			expectedLayout := []api.CPUTuneIOThreadPin{
 				{IOThread: 1, CPUSet: "6"},
 				{IOThread: 2, CPUSet: "5"},
@@ -2863,6 +2863,37 @@ var _ = Describe("Converter", func() {
 			isExpectedThreadsLayout := equality.Semantic.DeepEqual(expectedLayout, domain.Spec.CPUTune.IOThreadPin)
 			Expect(isExpectedThreadsLayout).To(BeTrue())
 		})
+		It("should spread iothreads over the emulator thread pool", func() {
+			vmi.Spec.Domain.CPU.Cores = 2
+			vmi.Spec.Domain.CPU.IsolateEmulatorThread = true
+			vmi.Spec.Domain.CPU.EmulatorThreadPoolSize = 2
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c := &ConverterContext{
+				CPUSet:         []int{5, 6, 7, 8},
+				AllowEmulation: true,
+				Topology: &cmdv1.Topology{
+					NumaCells: []*cmdv1.Cell{{
+						Cpus: []*cmdv1.CPU{
+							{Id: 5},
+							{Id: 6},
+							{Id: 7},
+							{Id: 8},
+						},
+					}},
+				},
+			}
+			domain := vmiToDomain(vmi, c)
+			domain.Spec.IOThreads = &api.IOThreads{}
+			domain.Spec.IOThreads.IOThreads = uint(3)
+
+			Expect(vcpu.AdjustDomainForTopologyAndCPUSet(domain, vmi, c.Topology, c.CPUSet, true)).To(Succeed())
+			Expect(domain.Spec.CPUTune.EmulatorPin).To(Equal(&api.CPUEmulatorPin{CPUSet: "7,8"}))
+			Expect(domain.Spec.CPUTune.IOThreadPin).To(Equal([]api.CPUTuneIOThreadPin{
+				{IOThread: 1, CPUSet: "7"},
+				{IOThread: 2, CPUSet: "8"},
+				{IOThread: 3, CPUSet: "7"},
+			}))
+		})
 	})
 	Context("virtio-net multi-queue", func() {
 		var vmi *v1.VirtualMachineInstance
@@ -187,4 +187,18 @@ var _ = Describe("NumaPlacement", func() {
 			Expect(givenSpec.MemoryBacking.NoSharePages).To(Equal(&api.NoSharePages{}))
 		})
 	})
+
+	Context("with soft cpu placement", func() {
+		It("should allow the memory to be moved between all host numa nodes", func() {
+			AdjustDomainForSoftCPUPlacement(givenSpec, givenTopology)
+			Expect(givenSpec.NUMATune).To(Equal(&api.NUMATune{
+				Memory: api.NumaTuneMemory{Mode: "restrictive", NodeSet: "0,4"},
+			}))
+			Expect(givenSpec.CPUTune).To(Equal(expectedSpec.CPUTune))
+		})
+		It("should not tune the memory without a host numa topology", func() {
+			AdjustDomainForSoftCPUPlacement(givenSpec, &cmdv1.Topology{})
+			Expect(givenSpec.NUMATune).To(BeNil())
+		})
+	})
 })
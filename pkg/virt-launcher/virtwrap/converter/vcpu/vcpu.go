@@ -15,6 +15,7 @@ import (
 
 	v1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
@@ -359,9 +360,17 @@ func isNumaPassthrough(vmi *v12.VirtualMachineInstance) bool {
 	return vmi.Spec.Domain.CPU.NUMA != nil && vmi.Spec.Domain.CPU.NUMA.GuestMappingPassthrough != nil
 }
 
-func appendDomainEmulatorThreadPin(domain *api.Domain, allocatedCpu uint32) {
+func formatCPUList(cpus []uint32) string {
+	list := make([]string, 0, len(cpus))
+	for _, cpu := range cpus {
+		list = append(list, strconv.Itoa(int(cpu)))
+	}
+	return strings.Join(list, ",")
+}
+
+func appendDomainEmulatorThreadPin(domain *api.Domain, allocatedCpus []uint32) {
 	emulatorThread := api.CPUEmulatorPin{
-		CPUSet: strconv.Itoa(int(allocatedCpu)),
+		CPUSet: formatCPUList(allocatedCpus),
 	}
 	domain.Spec.CPUTune.EmulatorPin = &emulatorThread
 }
@@ -373,14 +382,16 @@ func appendDomainIOThreadPin(domain *api.Domain, thread uint32, cpuset string) {
 	domain.Spec.CPUTune.IOThreadPin = append(domain.Spec.CPUTune.IOThreadPin, iothreadPin)
 }
 
-func FormatDomainIOThreadPin(vmi *v12.VirtualMachineInstance, domain *api.Domain, emulatorThread uint32, cpuset []int) error {
+func FormatDomainIOThreadPin(vmi *v12.VirtualMachineInstance, domain *api.Domain, emulatorThreads []uint32, cpuset []int) error {
 	iothreads := int(domain.Spec.IOThreads.IOThreads)
 	vcpus := int(CalculateRequestedVCPUs(domain.Spec.CPU.Topology))
 
-	if vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.IsolateEmulatorThread {
-		// pin the IOThread on the same pCPU as the emulator thread
-		cpuset := strconv.Itoa(int(emulatorThread))
-		appendDomainIOThreadPin(domain, uint32(1), cpuset)
+	if vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.IsolateEmulatorThread && len(emulatorThreads) > 0 {
+		// spread the IOThreads over the pCPUs of the emulator thread pool
+		for thread := 1; thread <= iothreads; thread++ {
+			cpuset := strconv.Itoa(int(emulatorThreads[(thread-1)%len(emulatorThreads)]))
+			appendDomainIOThreadPin(domain, uint32(thread), cpuset)
+		}
 	} else if iothreads >= vcpus {
 		// pin an IOThread on a CPU
 		for thread := 1; thread <= iothreads; thread++ {
@@ -454,18 +465,21 @@ func AdjustDomainForTopologyAndCPUSet(domain *api.Domain, vmi *v12.VirtualMachin
 		}
 	}
 
-	var emulatorThread uint32
-	if vmi.Spec.Domain.CPU.IsolateEmulatorThread {
-		emulatorThread, err = cpuPool.FitThread()
+	var emulatorThreads []uint32
+	for i := int64(0); i < hardware.GetNumberOfEmulatorThreadCPUs(vmi.Spec.Domain.CPU); i++ {
+		emulatorThread, err := cpuPool.FitThread()
 		if err != nil {
 			e := fmt.Errorf("no CPU allocated for the emulation thread: %v", err)
 			log.Log.Reason(e).Error("failed to format emulation thread pin")
 			return e
 		}
-		appendDomainEmulatorThreadPin(domain, emulatorThread)
+		emulatorThreads = append(emulatorThreads, emulatorThread)
+	}
+	if len(emulatorThreads) > 0 {
+		appendDomainEmulatorThreadPin(domain, emulatorThreads)
 	}
 	if useIOThreads {
-		if err := FormatDomainIOThreadPin(vmi, domain, emulatorThread, cpuset); err != nil {
+		if err := FormatDomainIOThreadPin(vmi, domain, emulatorThreads, cpuset); err != nil {
 			log.Log.Reason(err).Error("failed to format domain iothread pinning.")
 			return err
 		}
@@ -537,6 +551,25 @@ func GetVirtualMemory(vmi *v12.VirtualMachineInstance) *resource.Quantity {
 	return &reqMemory
 }
 
+// AdjustDomainForSoftCPUPlacement allows the guest memory to be allocated on any host numa node. With the restrictive
+// mode, libvirt does not bind the memory itself but leaves it to the cpuset cgroup controller, which lets virt-handler
+// move the memory to a different numa node at runtime.
+func AdjustDomainForSoftCPUPlacement(domain *api.DomainSpec, topology *v1.Topology) {
+	if topology == nil || len(topology.NumaCells) == 0 {
+		return
+	}
+	var cellIDs []string
+	for _, cell := range topology.NumaCells {
+		cellIDs = append(cellIDs, strconv.Itoa(int(cell.Id)))
+	}
+	domain.NUMATune = &api.NUMATune{
+		Memory: api.NumaTuneMemory{
+			Mode:    "restrictive",
+			NodeSet: strings.Join(cellIDs, ","),
+		},
+	}
+}
+
 // numaMapping maps numa nodes based on already applied VCPU pinning. The sort result is stable compared to the order
 // of provided host numa nodes.
 func numaMapping(vmi *v12.VirtualMachineInstance, domain *api.DomainSpec, topology *v1.Topology) error {
@@ -51,6 +51,9 @@ const (
 
 	hostdevMDEVAddress0 = "123456789-0"
 	hostdevMDEVAddress1 = "123456789-1"
+
+	hostdevUSBAddress0 = "1:5"
+	hostdevUSBAddress1 = "2:7"
 )
 
 var _ = Describe("Generic Address Pool", func() {
@@ -67,6 +70,7 @@ var _ = Describe("Generic Address Pool", func() {
 		},
 		Entry("PCI", generic.NewPCIAddressPool),
 		Entry("MDEV", generic.NewMDEVAddressPool),
+		Entry("USB", generic.NewUSBAddressPool),
 	)
 
 	DescribeTable("creates an empty pool when no resources are specified",
@@ -77,6 +81,7 @@ var _ = Describe("Generic Address Pool", func() {
 		},
 		Entry("PCI", generic.NewPCIAddressPool),
 		Entry("MDEV", generic.NewMDEVAddressPool),
+		Entry("USB", generic.NewUSBAddressPool),
 	)
 
 	DescribeTable("succeeds to pop 2 addresses from same resource",
@@ -91,6 +96,7 @@ var _ = Describe("Generic Address Pool", func() {
 		},
 		Entry("PCI", generic.NewPCIAddressPool, v1.PCIResourcePrefix, hostdevPCIAddress0, hostdevPCIAddress1),
 		Entry("MDEV", generic.NewMDEVAddressPool, v1.MDevResourcePrefix, hostdevMDEVAddress0, hostdevMDEVAddress1),
+		Entry("USB", generic.NewUSBAddressPool, v1.USBResourcePrefix, hostdevUSBAddress0, hostdevUSBAddress1),
 	)
 
 	DescribeTable("succeeds to pop 2 addresses from two resources",
@@ -111,6 +117,7 @@ var _ = Describe("Generic Address Pool", func() {
 		},
 		Entry("PCI", generic.NewPCIAddressPool, v1.PCIResourcePrefix, hostdevPCIAddress0, hostdevPCIAddress1),
 		Entry("MDEV", generic.NewMDEVAddressPool, v1.MDevResourcePrefix, hostdevMDEVAddress0, hostdevMDEVAddress1),
+		Entry("USB", generic.NewUSBAddressPool, v1.USBResourcePrefix, hostdevUSBAddress0, hostdevUSBAddress1),
 	)
 })
 
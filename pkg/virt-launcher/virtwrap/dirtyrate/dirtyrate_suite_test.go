@@ -0,0 +1,11 @@
+package dirtyrate
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestDirtyRate(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
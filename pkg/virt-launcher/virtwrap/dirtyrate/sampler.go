@@ -0,0 +1,151 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package dirtyrate
+
+import (
+	"time"
+
+	"libvirt.org/go/libvirt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/metadata"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/statsconv"
+)
+
+const (
+	// DefaultSampleInterval is how often a new dirty rate calculation is started
+	DefaultSampleInterval = 5 * time.Minute
+	// DefaultCalcPeriod is the amount of seconds QEMU spends measuring the dirty rate
+	DefaultCalcPeriod = 1
+)
+
+// Sampler periodically asks libvirt to measure how fast the guest dirties its memory
+// and records the last completed measurement in the metadata cache, so that it is
+// reported to virt-handler before any migration is attempted.
+type Sampler struct {
+	virConn       cli.Connection
+	domainName    string
+	metadataCache *metadata.Cache
+	interval      time.Duration
+	calcPeriod    int
+}
+
+func NewSampler(virConn cli.Connection, domainName string, metadataCache *metadata.Cache, interval time.Duration) *Sampler {
+	return &Sampler{
+		virConn:       virConn,
+		domainName:    domainName,
+		metadataCache: metadataCache,
+		interval:      interval,
+		calcPeriod:    DefaultCalcPeriod,
+	}
+}
+
+// Run samples the dirty rate until stopChan is closed.
+func (s *Sampler) Run(stopChan <-chan struct{}) {
+	wait.Until(s.Sample, s.interval, stopChan)
+}
+
+// Sample collects the result of the previous calculation and starts a new one.
+func (s *Sampler) Sample() {
+	if s.isMigrating() {
+		return
+	}
+
+	dom, err := s.virConn.LookupDomainByName(s.domainName)
+	if err != nil {
+		if !domainerrors.IsNotFound(err) {
+			log.Log.Reason(err).Warningf("failed to look up domain %s for dirty rate sampling", s.domainName)
+		}
+		return
+	}
+	defer dom.Free()
+
+	state, _, err := dom.GetState()
+	if err != nil {
+		log.Log.Reason(err).Warningf("failed to get state of domain %s", s.domainName)
+		return
+	}
+	// a paused guest does not dirty memory, the measurement would be meaningless
+	if state != libvirt.DOMAIN_RUNNING {
+		return
+	}
+
+	s.collect()
+
+	if err := dom.StartDirtyRateCalc(s.calcPeriod, 0); err != nil {
+		log.Log.Reason(err).Warningf("failed to start dirty rate calculation for domain %s", s.domainName)
+	}
+}
+
+func (s *Sampler) collect() {
+	domStats, err := s.virConn.GetAllDomainStats(libvirt.DOMAIN_STATS_DIRTYRATE, libvirt.CONNECT_GET_ALL_DOMAINS_STATS_RUNNING)
+	if err != nil {
+		log.Log.Reason(err).Warningf("failed to get dirty rate stats for domain %s", s.domainName)
+		return
+	}
+	defer func() {
+		for i := range domStats {
+			if domStats[i].Domain == nil {
+				continue
+			}
+			if err := domStats[i].Domain.Free(); err != nil {
+				log.Log.Reason(err).Warning("Error freeing a domain.")
+			}
+		}
+	}()
+
+	for i := range domStats {
+		dirtyRate := statsconv.Convert_libvirt_DomainStatsDirtyRate_To_stats_DomainStatsDirtyRate(domStats[i].DirtyRate)
+		if dirtyRateMetadata, measured := toDirtyRateMetadata(dirtyRate); measured {
+			s.metadataCache.DirtyRate.Store(dirtyRateMetadata)
+		}
+	}
+}
+
+func (s *Sampler) isMigrating() bool {
+	migrationMetadata, exists := s.metadataCache.Migration.Load()
+	return exists && !migrationMetadata.Completed
+}
+
+func toDirtyRateMetadata(dirtyRate *stats.DomainStatsDirtyRate) (api.DirtyRateMetadata, bool) {
+	if dirtyRate == nil || !dirtyRate.CalcStatusSet || dirtyRate.CalcStatus != stats.DirtyRateMeasured || !dirtyRate.MegabytesPerSecondSet {
+		return api.DirtyRateMetadata{}, false
+	}
+
+	dirtyRateMetadata := api.DirtyRateMetadata{
+		BytesPerSecond: dirtyRate.MegabytesPerSecond * 1024 * 1024,
+	}
+	if dirtyRate.CalcPeriodSet {
+		dirtyRateMetadata.CalcPeriod = dirtyRate.CalcPeriod
+	}
+	if dirtyRate.CalcStartTimeSet {
+		timestamp := metav1.NewTime(time.Unix(dirtyRate.CalcStartTime, 0))
+		dirtyRateMetadata.Timestamp = &timestamp
+	}
+	return dirtyRateMetadata, true
+}
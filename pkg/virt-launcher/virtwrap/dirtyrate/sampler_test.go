@@ -0,0 +1,136 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package dirtyrate
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"libvirt.org/go/libvirt"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/metadata"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+var _ = Describe("Dirty rate sampler", func() {
+	const domainName = "default_testvmi"
+
+	var (
+		ctrl          *gomock.Controller
+		mockConn      *cli.MockConnection
+		mockDomain    *cli.MockVirDomain
+		metadataCache *metadata.Cache
+		sampler       *Sampler
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockConn = cli.NewMockConnection(ctrl)
+		mockDomain = cli.NewMockVirDomain(ctrl)
+		metadataCache = metadata.NewCache()
+		sampler = NewSampler(mockConn, domainName, metadataCache, DefaultSampleInterval)
+	})
+
+	measuredStats := func(megabytesPerSecond int64) []libvirt.DomainStats {
+		return []libvirt.DomainStats{{
+			DirtyRate: &libvirt.DomainStatsDirtyRate{
+				CalcStatusSet:         true,
+				CalcStatus:            int(libvirt.DOMAIN_DIRTYRATE_MEASURED),
+				CalcStartTimeSet:      true,
+				CalcStartTime:         1666000000,
+				CalcPeriodSet:         true,
+				CalcPeriod:            DefaultCalcPeriod,
+				MegabytesPerSecondSet: true,
+				MegabytesPerSecond:    megabytesPerSecond,
+			},
+		}}
+	}
+
+	It("should store the last measured dirty rate and start a new calculation", func() {
+		mockConn.EXPECT().LookupDomainByName(domainName).Return(mockDomain, nil)
+		mockDomain.EXPECT().Free()
+		mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+		mockConn.EXPECT().GetAllDomainStats(libvirt.DOMAIN_STATS_DIRTYRATE, gomock.Any()).Return(measuredStats(3), nil)
+		mockDomain.EXPECT().StartDirtyRateCalc(DefaultCalcPeriod, gomock.Any()).Return(nil)
+
+		sampler.Sample()
+
+		dirtyRate, exists := metadataCache.DirtyRate.Load()
+		Expect(exists).To(BeTrue())
+		Expect(dirtyRate.BytesPerSecond).To(Equal(int64(3 * 1024 * 1024)))
+		Expect(dirtyRate.CalcPeriod).To(Equal(DefaultCalcPeriod))
+		Expect(dirtyRate.Timestamp).ToNot(BeNil())
+		Expect(dirtyRate.Timestamp.Unix()).To(Equal(int64(1666000000)))
+	})
+
+	It("should not store a dirty rate while the calculation is still running", func() {
+		mockConn.EXPECT().LookupDomainByName(domainName).Return(mockDomain, nil)
+		mockDomain.EXPECT().Free()
+		mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+		mockConn.EXPECT().GetAllDomainStats(libvirt.DOMAIN_STATS_DIRTYRATE, gomock.Any()).Return([]libvirt.DomainStats{{
+			DirtyRate: &libvirt.DomainStatsDirtyRate{
+				CalcStatusSet: true,
+				CalcStatus:    int(libvirt.DOMAIN_DIRTYRATE_MEASURING),
+			},
+		}}, nil)
+		mockDomain.EXPECT().StartDirtyRateCalc(DefaultCalcPeriod, gomock.Any()).Return(nil)
+
+		sampler.Sample()
+
+		_, exists := metadataCache.DirtyRate.Load()
+		Expect(exists).To(BeFalse())
+	})
+
+	It("should not sample a paused domain", func() {
+		mockConn.EXPECT().LookupDomainByName(domainName).Return(mockDomain, nil)
+		mockDomain.EXPECT().Free()
+		mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
+
+		sampler.Sample()
+
+		_, exists := metadataCache.DirtyRate.Load()
+		Expect(exists).To(BeFalse())
+	})
+
+	It("should not sample a migrating domain", func() {
+		metadataCache.Migration.Store(api.MigrationMetadata{UID: "123"})
+
+		sampler.Sample()
+
+		_, exists := metadataCache.DirtyRate.Load()
+		Expect(exists).To(BeFalse())
+	})
+
+	It("should sample again once the migration has completed", func() {
+		metadataCache.Migration.Store(api.MigrationMetadata{UID: "123", Completed: true})
+		mockConn.EXPECT().LookupDomainByName(domainName).Return(mockDomain, nil)
+		mockDomain.EXPECT().Free()
+		mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+		mockConn.EXPECT().GetAllDomainStats(libvirt.DOMAIN_STATS_DIRTYRATE, gomock.Any()).Return(measuredStats(1), nil)
+		mockDomain.EXPECT().StartDirtyRateCalc(DefaultCalcPeriod, gomock.Any()).Return(nil)
+
+		sampler.Sample()
+
+		dirtyRate, exists := metadataCache.DirtyRate.Load()
+		Expect(exists).To(BeTrue())
+		Expect(dirtyRate.BytesPerSecond).To(Equal(int64(1024 * 1024)))
+	})
+})
@@ -0,0 +1,125 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// FreezeAbortedError is returned when a pre-freeze hook aborted the freeze,
+// the message holds the errors and the output of the failed hooks
+type FreezeAbortedError struct {
+	Message string
+}
+
+func (e *FreezeAbortedError) Error() string {
+	return fmt.Sprintf("freeze aborted, %s", e.Message)
+}
+
+func (l *LibvirtDomainManager) runGuestHook(domainName string, hook v1.GuestHook) error {
+	if len(hook.Command) == 0 {
+		return fmt.Errorf("no command")
+	}
+
+	timeoutSeconds := v1.DefaultGuestHookTimeoutSeconds
+	if hook.TimeoutSeconds != nil {
+		timeoutSeconds = *hook.TimeoutSeconds
+	}
+
+	stdOut, err := agent.GuestExec(l.virConn, domainName, hook.Command[0], hook.Command[1:], timeoutSeconds)
+	if output := strings.TrimSpace(stdOut); err != nil && output != "" {
+		return fmt.Errorf("%v, output: %s", err, output)
+	}
+	return err
+}
+
+// runPreFreezeHooks runs the pre-freeze hooks of the VMI and records their outcome.
+// A FreezeAbortedError is returned when the freeze has to be aborted, the post-thaw
+// hooks are then run to revert the pre-freeze hooks that succeeded.
+func (l *LibvirtDomainManager) runPreFreezeHooks(vmi *v1.VirtualMachineInstance, domainName string) error {
+	if vmi.Spec.FreezeHooks == nil || len(vmi.Spec.FreezeHooks.PreFreeze) == 0 {
+		return nil
+	}
+
+	now := metav1.Now()
+	outcome := api.FreezeHooksMetadata{
+		PreFreezeTimestamp: &now,
+	}
+	var failed, messages []string
+	for _, hook := range vmi.Spec.FreezeHooks.PreFreeze {
+		err := l.runGuestHook(domainName, hook)
+		if err == nil {
+			continue
+		}
+
+		log.Log.Object(vmi).Reason(err).Warningf("Pre-freeze hook %s failed", hook.Name)
+		failed = append(failed, hook.Name)
+		messages = append(messages, fmt.Sprintf("pre-freeze hook %s failed: %v", hook.Name, err))
+		if hook.FailurePolicy != v1.GuestHookFailurePolicyContinue {
+			outcome.Aborted = true
+			break
+		}
+	}
+	outcome.PreFreezeFailed = strings.Join(failed, ",")
+	outcome.Message = strings.Join(messages, ", ")
+	l.metadataCache.FreezeHooks.Store(outcome)
+
+	if outcome.Aborted {
+		l.runPostThawHooks(vmi, domainName)
+		return &FreezeAbortedError{Message: outcome.Message}
+	}
+	return nil
+}
+
+// runPostThawHooks runs all the post-thaw hooks of the VMI and records their outcome,
+// failures are only reported since the guest is already thawed
+func (l *LibvirtDomainManager) runPostThawHooks(vmi *v1.VirtualMachineInstance, domainName string) {
+	if vmi.Spec.FreezeHooks == nil || len(vmi.Spec.FreezeHooks.PostThaw) == 0 {
+		return
+	}
+
+	outcome, _ := l.metadataCache.FreezeHooks.Load()
+	now := metav1.Now()
+	outcome.PostThawTimestamp = &now
+
+	var failed, messages []string
+	if outcome.Message != "" {
+		messages = append(messages, outcome.Message)
+	}
+	for _, hook := range vmi.Spec.FreezeHooks.PostThaw {
+		if err := l.runGuestHook(domainName, hook); err != nil {
+			log.Log.Object(vmi).Reason(err).Warningf("Post-thaw hook %s failed", hook.Name)
+			failed = append(failed, hook.Name)
+			messages = append(messages, fmt.Sprintf("post-thaw hook %s failed: %v", hook.Name, err))
+		}
+	}
+	outcome.PostThawFailed = strings.Join(failed, ",")
+	outcome.Message = strings.Join(messages, ", ")
+	l.metadataCache.FreezeHooks.Store(outcome)
+}
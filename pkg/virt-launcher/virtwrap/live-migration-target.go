@@ -31,6 +31,7 @@ import (
 	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	"kubevirt.io/kubevirt/pkg/hooks"
+	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/net/ip"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
@@ -38,14 +39,41 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
 )
 
+// announceSelfCommand announces the guest NICs in 5 rounds, starting after 50ms and backing off by 100ms up to 550ms
+const announceSelfCommand = `{"execute":"announce-self","arguments":{"initial":50,"max":550,"rounds":5,"step":100}}`
+
 func (l *LibvirtDomainManager) finalizeMigrationTarget(vmi *v1.VirtualMachineInstance) error {
 	if err := l.setGuestTime(vmi); err != nil {
 		return err
 	}
 
+	l.announceGuestNetwork(vmi)
+
 	return nil
 }
 
+// announceGuestNetwork makes the network learn the new location of a guest which keeps the source pod IP and MAC
+// on a bridge binding pod network interface. QEMU sends gratuitous ARP and unsolicited NA from virtio guests and
+// RARP on behalf of the others, virt-handler advertises the guest IPv6 addresses of all of them once the migration
+// is finalized. It is a best-effort, since the guest traffic refreshes the network eventually.
+func (l *LibvirtDomainManager) announceGuestNetwork(vmi *v1.VirtualMachineInstance) {
+	if !shouldAnnounceGuestNetwork(vmi) {
+		return
+	}
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	if _, err := l.virConn.QemuMonitorCommand(announceSelfCommand, domName); err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("failed to announce the guest network after migration")
+	}
+}
+
+func shouldAnnounceGuestNetwork(vmi *v1.VirtualMachineInstance) bool {
+	if _, exists := vmi.Annotations[v1.AllowPodBridgeNetworkLiveMigrationAnnotation]; !exists {
+		return false
+	}
+	return netvmispec.IsPodNetworkWithBridgeBindingInterface(vmi.Spec.Networks, vmi.Spec.Domain.Devices.Interfaces)
+}
+
 func shouldBlockMigrationTargetPreparation(vmi *v1.VirtualMachineInstance) bool {
 	if vmi.Annotations == nil {
 		return false
@@ -74,6 +74,7 @@ import (
 	netsetup "kubevirt.io/kubevirt/pkg/network/setup"
 	netsriov "kubevirt.io/kubevirt/pkg/network/sriov"
 	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
+	"kubevirt.io/kubevirt/pkg/tracing"
 	kutil "kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/metadata"
 	accesscredentials "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/access-credentials"
@@ -431,9 +432,20 @@ func (l *LibvirtDomainManager) UpdateVCPUs(vmi *v1.VirtualMachineInstance, optio
 			}
 		}
 		if domain.Spec.CPUTune.EmulatorPin != nil {
-			isolCpu, _ := strconv.Atoi(domain.Spec.CPUTune.EmulatorPin.CPUSet)
-			cpuMap := make([]bool, isolCpu+1)
-			cpuMap[int(isolCpu)] = true
+			isolCpus, err := hardware.ParseCPUSetLine(domain.Spec.CPUTune.EmulatorPin.CPUSet, 50000)
+			if err != nil {
+				return fmt.Errorf("%s: %v", errMsgPrefix, err)
+			}
+			maxCpu := 0
+			for _, isolCpu := range isolCpus {
+				if isolCpu > maxCpu {
+					maxCpu = isolCpu
+				}
+			}
+			cpuMap := make([]bool, maxCpu+1)
+			for _, isolCpu := range isolCpus {
+				cpuMap[isolCpu] = true
+			}
 			err = dom.PinEmulator(cpuMap, affectDomainLiveAndConfigLibvirtFlags)
 			if err != nil {
 				return fmt.Errorf("%s: %v", errMsgPrefix, err)
@@ -959,6 +971,8 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 
 	logger := log.Log.Object(vmi)
 
+	l.metadataCache.TraceContext.Set(tracing.FromObject(vmi))
+
 	domain := &api.Domain{}
 
 	c, err := l.generateConverterContext(vmi, allowEmulation, options, false)
@@ -985,12 +999,15 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 				return nil, err
 			}
 
+			span := tracing.StartSpanFromObject(vmi, "virt-launcher.DefineDomain")
 			dom, err = withNetworkIfacesResources(
 				vmi, &domain.Spec,
 				func(v *v1.VirtualMachineInstance, s *api.DomainSpec) (cli.VirDomain, error) {
 					return l.setDomainSpecWithHooks(v, s)
 				},
 			)
+			span.SetError(err)
+			span.End()
 			if err != nil {
 				return nil, err
 			}
@@ -1021,7 +1038,14 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 			return nil, err
 		}
 		createFlags := getDomainCreateFlags(vmi)
-		err = dom.CreateWithFlags(createFlags)
+		span := tracing.StartSpanFromObject(vmi, "virt-launcher.StartDomain")
+		if statePath, exists := restoreMemoryStatePath(vmi); exists {
+			err = l.restoreMemoryState(vmi, dom, statePath)
+		} else {
+			err = dom.CreateWithFlags(createFlags)
+		}
+		span.SetError(err)
+		span.End()
 		if err != nil {
 			logger.Reason(err).
 				Errorf("Failed to start VirtualMachineInstance with flags %v.", createFlags)
@@ -1341,13 +1365,21 @@ func (l *LibvirtDomainManager) memoryDump(vmi *v1.VirtualMachineInstance, dumpPa
 		return err
 	}
 	defer dom.Free()
-	// keep trying to do memory dump even if remove previous one failed
-	removePreviousMemoryDump(filepath.Dir(dumpPath))
+
+	savedState := isSavedStateMemoryDump(vmi, dumpPath)
+	if !savedState {
+		// keep trying to do memory dump even if remove previous one failed
+		removePreviousMemoryDump(filepath.Dir(dumpPath))
+	}
 
 	logger.Infof("Starting memory dump")
 	failed := false
 	reason := ""
-	err = dom.CoreDumpWithFormat(dumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY)
+	if savedState {
+		err = l.saveMemoryState(vmi, dom, dumpPath)
+	} else {
+		err = dom.CoreDumpWithFormat(dumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY)
+	}
 	if err != nil {
 		failed = true
 		reason = fmt.Sprintf("%s: %s", failedDomainMemoryDump, err)
@@ -1537,9 +1569,17 @@ func (l *LibvirtDomainManager) FreezeVMI(vmi *v1.VirtualMachineInstance, unfreez
 	if fsfreezeStatus == api.FSFrozen {
 		return nil
 	}
+
+	if err := l.runPreFreezeHooks(vmi, domainName); err != nil {
+		log.Log.Errorf("Failed to freeze vmi, %s", err.Error())
+		return err
+	}
+
 	_, err = l.virConn.QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, domainName)
 	if err != nil {
 		log.Log.Errorf("Failed to freeze vmi, %s", err.Error())
+		// revert the pre-freeze hooks
+		l.runPostThawHooks(vmi, domainName)
 		return err
 	}
 
@@ -1566,6 +1606,8 @@ func (l *LibvirtDomainManager) UnfreezeVMI(vmi *v1.VirtualMachineInstance) error
 		log.Log.Errorf("Failed to unfreeze vmi, %s", err.Error())
 		return err
 	}
+
+	l.runPostThawHooks(vmi, domainName)
 	return nil
 }
 
@@ -1956,9 +1998,51 @@ func (l *LibvirtDomainManager) GetGuestInfo() v1.VirtualMachineInstanceGuestAgen
 		})
 	}
 
+	if guestMetrics := l.agentData.GetGuestMetrics(); guestMetrics != nil {
+		guestInfo.Metrics = convertGuestMetrics(guestMetrics)
+	}
+
 	return guestInfo
 }
 
+func convertGuestMetrics(guestMetrics *api.GuestMetrics) *v1.VirtualMachineInstanceGuestMetrics {
+	metrics := &v1.VirtualMachineInstanceGuestMetrics{}
+	if guestMetrics.Load != nil {
+		metrics.Load = &v1.VirtualMachineInstanceGuestLoad{
+			Load1:  guestMetrics.Load.Load1,
+			Load5:  guestMetrics.Load.Load5,
+			Load15: guestMetrics.Load.Load15,
+		}
+	}
+	for _, cpu := range guestMetrics.CPUStats {
+		metrics.CPUStats = append(metrics.CPUStats, v1.VirtualMachineInstanceGuestCPUStats{
+			CPU:     cpu.CPU,
+			User:    cpu.User,
+			Nice:    cpu.Nice,
+			System:  cpu.System,
+			Idle:    cpu.Idle,
+			IOWait:  cpu.IOWait,
+			IRQ:     cpu.IRQ,
+			SoftIRQ: cpu.SoftIRQ,
+			Steal:   cpu.Steal,
+		})
+	}
+	for _, disk := range guestMetrics.DiskStats {
+		metrics.DiskStats = append(metrics.DiskStats, v1.VirtualMachineInstanceGuestDiskStats{
+			Name:         disk.Name,
+			ReadIOs:      disk.ReadIOs,
+			ReadSectors:  disk.ReadSectors,
+			ReadTicks:    disk.ReadTicks,
+			WriteIOs:     disk.WriteIOs,
+			WriteSectors: disk.WriteSectors,
+			WriteTicks:   disk.WriteTicks,
+			InFlightIOs:  disk.InFlightIOs,
+			TotalTicks:   disk.TotalTicks,
+		})
+	}
+	return metrics
+}
+
 // InterfacesStatus returns the interfaces Guest Agent reported
 func (l *LibvirtDomainManager) InterfacesStatus() []api.InterfaceStatus {
 	return l.agentData.GetInterfaceStatus()
@@ -23,6 +23,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -578,6 +579,98 @@ var _ = Describe("Manager", func() {
 
 			Expect(manager.FreezeVMI(vmi, 0)).To(Succeed())
 		})
+		Context("with freeze hooks", func() {
+			const (
+				preFreezeExec = `{"execute": "guest-exec", "arguments": { "path": "/usr/bin/pre", "arg": [ "--flush" ], "capture-output":true } }`
+				postThawExec  = `{"execute": "guest-exec", "arguments": { "path": "/usr/bin/post", "arg": [  ], "capture-output":true } }`
+				execStatus    = `{"execute": "guest-exec-status", "arguments": { "pid": 1 } }`
+			)
+
+			newVMIWithHooks := func(policy v1.GuestHookFailurePolicy) *v1.VirtualMachineInstance {
+				vmi := newVMI(testNamespace, testVmName)
+				vmi.Spec.FreezeHooks = &v1.FreezeHooks{
+					PreFreeze: []v1.GuestHook{{Name: "pre", Command: []string{"/usr/bin/pre", "--flush"}, FailurePolicy: policy}},
+					PostThaw:  []v1.GuestHook{{Name: "post", Command: []string{"/usr/bin/post"}}},
+				}
+				return vmi
+			}
+
+			expectExecWithOutput := func(cmd string, exitCode int, output string) {
+				mockConn.EXPECT().QemuAgentCommand(cmd, testDomainName).Return(`{"return":{"pid":1}}`, nil)
+				mockConn.EXPECT().QemuAgentCommand(execStatus, testDomainName).Return(fmt.Sprintf(`{"return":{"exited":true,"exitcode":%d,"out-data":"%s"}}`, exitCode, base64.StdEncoding.EncodeToString([]byte(output))), nil)
+			}
+
+			expectExec := func(cmd string, exitCode int) {
+				expectExecWithOutput(cmd, exitCode, "")
+			}
+
+			It("should run the pre-freeze hooks before freezing", func() {
+				vmi := newVMIWithHooks(v1.GuestHookFailurePolicyAbort)
+
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"`+string(agentpoller.GET_FSFREEZE_STATUS)+`"}`, testDomainName).Return(expectedThawedOutput, nil)
+				expectExec(preFreezeExec, 0)
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, testDomainName).Return("1", nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				Expect(manager.FreezeVMI(vmi, 0)).To(Succeed())
+
+				hooks, exists := metadataCache.FreezeHooks.Load()
+				Expect(exists).To(BeTrue())
+				Expect(hooks.PreFreezeTimestamp).ToNot(BeNil())
+				Expect(hooks.PreFreezeFailed).To(BeEmpty())
+				Expect(hooks.Aborted).To(BeFalse())
+			})
+
+			It("should abort the freeze and run the post-thaw hooks when a pre-freeze hook fails", func() {
+				vmi := newVMIWithHooks(v1.GuestHookFailurePolicyAbort)
+
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"`+string(agentpoller.GET_FSFREEZE_STATUS)+`"}`, testDomainName).Return(expectedThawedOutput, nil)
+				expectExecWithOutput(preFreezeExec, 1, "database busy\n")
+				expectExec(postThawExec, 0)
+				// no expected call to freeze
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				err := manager.FreezeVMI(vmi, 0)
+				var abortErr *FreezeAbortedError
+				Expect(errors.As(err, &abortErr)).To(BeTrue())
+				Expect(abortErr.Message).To(Equal("pre-freeze hook pre failed: exited with error code:1, output: database busy"))
+
+				hooks, _ := metadataCache.FreezeHooks.Load()
+				Expect(hooks.PreFreezeFailed).To(Equal("pre"))
+				Expect(hooks.Aborted).To(BeTrue())
+				Expect(hooks.PostThawTimestamp).ToNot(BeNil())
+			})
+
+			It("should freeze when a pre-freeze hook with the Continue policy fails", func() {
+				vmi := newVMIWithHooks(v1.GuestHookFailurePolicyContinue)
+
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"`+string(agentpoller.GET_FSFREEZE_STATUS)+`"}`, testDomainName).Return(expectedThawedOutput, nil)
+				expectExec(preFreezeExec, 1)
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, testDomainName).Return("1", nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				Expect(manager.FreezeVMI(vmi, 0)).To(Succeed())
+
+				hooks, _ := metadataCache.FreezeHooks.Load()
+				Expect(hooks.PreFreezeFailed).To(Equal("pre"))
+				Expect(hooks.Aborted).To(BeFalse())
+			})
+
+			It("should run the post-thaw hooks after unfreezing", func() {
+				vmi := newVMIWithHooks(v1.GuestHookFailurePolicyAbort)
+
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"`+string(agentpoller.GET_FSFREEZE_STATUS)+`"}`, testDomainName).Return(expectedFrozenOutput, nil)
+				mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-thaw"}`, testDomainName).Return("1", nil)
+				expectExec(postThawExec, 1)
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				Expect(manager.UnfreezeVMI(vmi)).To(Succeed())
+
+				hooks, _ := metadataCache.FreezeHooks.Load()
+				Expect(hooks.PostThawTimestamp).ToNot(BeNil())
+				Expect(hooks.PostThawFailed).To(Equal("post"))
+			})
+		})
 		It("should fail freeze a VirtualMachineInstance during migration", func() {
 			vmi := newVMI(testNamespace, testVmName)
 			now := metav1.Now()
@@ -1817,6 +1910,48 @@ var _ = Describe("Manager", func() {
 		})
 	})
 
+	Context("on migration target finalization", func() {
+		newVMIWithPodNetworkBridge := func() *v1.VirtualMachineInstance {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			return vmi
+		}
+
+		It("should announce the guest network of a bridge bound pod network", func() {
+			vmi := newVMIWithPodNetworkBridge()
+			vmi.Annotations = map[string]string{v1.AllowPodBridgeNetworkLiveMigrationAnnotation: ""}
+			mockConn.EXPECT().QemuMonitorCommand(announceSelfCommand, testDomainName).Return("", nil)
+			manager := &LibvirtDomainManager{virConn: mockConn}
+			manager.announceGuestNetwork(vmi)
+		})
+
+		It("should tolerate a failure to announce the guest network", func() {
+			vmi := newVMIWithPodNetworkBridge()
+			vmi.Annotations = map[string]string{v1.AllowPodBridgeNetworkLiveMigrationAnnotation: ""}
+			mockConn.EXPECT().QemuMonitorCommand(announceSelfCommand, testDomainName).Return("", fmt.Errorf("announce-self failed"))
+			manager := &LibvirtDomainManager{virConn: mockConn}
+			manager.announceGuestNetwork(vmi)
+		})
+
+		It("should not announce the guest network without the opt-in annotation", func() {
+			vmi := newVMIWithPodNetworkBridge()
+			mockConn.EXPECT().QemuMonitorCommand(gomock.Any(), gomock.Any()).Times(0)
+			manager := &LibvirtDomainManager{virConn: mockConn}
+			manager.announceGuestNetwork(vmi)
+		})
+
+		It("should not announce the guest network of a masquerade bound pod network", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Annotations = map[string]string{v1.AllowPodBridgeNetworkLiveMigrationAnnotation: ""}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+			mockConn.EXPECT().QemuMonitorCommand(gomock.Any(), gomock.Any()).Times(0)
+			manager := &LibvirtDomainManager{virConn: mockConn}
+			manager.announceGuestNetwork(vmi)
+		})
+	})
+
 	Context("on successful VirtualMachineInstance kill", func() {
 		DescribeTable("should try to undefine a VirtualMachineInstance in state",
 			func(state libvirt.DomainState) {
@@ -2111,6 +2246,24 @@ var _ = Describe("Manager", func() {
 			UsedBytes:      0,
 			TotalBytes:     0,
 		}))
+		Expect(guestInfo.Metrics).To(BeNil())
+	})
+
+	It("executes GetGuestInfo with guest metrics", func() {
+		agentStore := agentpoller.NewAsyncAgentStore()
+		agentStore.Store(agentpoller.GET_LOAD, api.GuestLoad{Load1: 2, Load5: 1, Load15: 0.5})
+		agentStore.Store(agentpoller.GET_CPUSTATS, []api.GuestCPUStats{{CPU: 0, User: 100, Idle: 900}})
+		agentStore.Store(agentpoller.GET_DISKSTATS, []api.GuestDiskStats{{Name: "vda", ReadIOs: 10, ReadTicks: 20}})
+
+		manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, &agentStore, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+		libvirtmanager := manager.(*LibvirtDomainManager)
+
+		guestInfo := libvirtmanager.GetGuestInfo()
+		Expect(guestInfo.Metrics).To(Equal(&v1.VirtualMachineInstanceGuestMetrics{
+			Load:      &v1.VirtualMachineInstanceGuestLoad{Load1: 2, Load5: 1, Load15: 0.5},
+			CPUStats:  []v1.VirtualMachineInstanceGuestCPUStats{{CPU: 0, User: 100, Idle: 900}},
+			DiskStats: []v1.VirtualMachineInstanceGuestDiskStats{{Name: "vda", ReadIOs: 10, ReadTicks: 20}},
+		}))
 	})
 
 	It("executes GetUsers", func() {
@@ -2482,6 +2635,21 @@ var _ = Describe("migratableDomXML", func() {
 
 var _ = Describe("Manager helper functions", func() {
 
+	DescribeTable("restoreMemoryStatePath", func(memoryState string, expectedPath string, expectedExists bool) {
+		vmi := api2.NewMinimalVMI("testvmi")
+		vmi.Annotations = map[string]string{v1.RestoreMemoryStateAnnotation: memoryState}
+
+		path, exists := restoreMemoryStatePath(vmi)
+		Expect(exists).To(Equal(expectedExists))
+		Expect(path).To(Equal(expectedPath))
+	},
+		Entry("should return the file in the memory state volume", "memory-state/memory.state", "/var/run/kubevirt-private/memory-state/memory.state", true),
+		Entry("should reject a file outside of the memory state volume", "memory-state/../../etc/passwd", "", false),
+		Entry("should reject a file in a subdirectory", "memory-state/dir/memory.state", "", false),
+		Entry("should reject the parent directory", "memory-state/..", "", false),
+		Entry("should reject a missing file name", "memory-state/", "", false),
+	)
+
 	Context("getVMIEphemeralDisksTotalSize", func() {
 
 		var tmpDir string
@@ -0,0 +1,155 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"libvirt.org/go/libvirt"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+// memoryStateSnapshot is an external memory only libvirt snapshot. The memory
+// file it produces is in the same format as virDomainSaveFlags and can be
+// restored with virDomainRestoreFlags, but unlike a managed save the domain
+// keeps running after the snapshot is taken.
+type memoryStateSnapshot struct {
+	XMLName xml.Name                  `xml:"domainsnapshot"`
+	Memory  memoryStateSnapshotFile   `xml:"memory"`
+	Disks   []memoryStateSnapshotDisk `xml:"disks>disk"`
+}
+
+type memoryStateSnapshotFile struct {
+	Snapshot string `xml:"snapshot,attr"`
+	File     string `xml:"file,attr"`
+}
+
+type memoryStateSnapshotDisk struct {
+	Name     string `xml:"name,attr"`
+	Snapshot string `xml:"snapshot,attr"`
+}
+
+func isSavedStateMemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) bool {
+	volumeName := filepath.Base(filepath.Dir(dumpPath))
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == volumeName && volume.MemoryDump != nil {
+			return volume.MemoryDump.Format == v1.MemoryDumpFormatSavedState
+		}
+	}
+	return false
+}
+
+func memoryStateSnapshotXML(domSpec *api.DomainSpec, statePath string) (string, error) {
+	snapshot := memoryStateSnapshot{
+		Memory: memoryStateSnapshotFile{
+			Snapshot: "external",
+			File:     statePath,
+		},
+	}
+	// The disks are snapshotted separately by the storage backend,
+	// only the memory and device state is saved here
+	for _, disk := range domSpec.Devices.Disks {
+		snapshot.Disks = append(snapshot.Disks, memoryStateSnapshotDisk{
+			Name:     disk.Target.Device,
+			Snapshot: "no",
+		})
+	}
+
+	snapshotXML, err := xml.Marshal(snapshot)
+	if err != nil {
+		return "", err
+	}
+	return string(snapshotXML), nil
+}
+
+// saveMemoryState pauses the domain and saves its memory and device state to
+// statePath. The domain is left paused, so that the volumes can be snapshotted
+// in a state consistent with the saved memory; it is resumed by unpausing the VMI.
+func (l *LibvirtDomainManager) saveMemoryState(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, statePath string) error {
+	logger := log.Log.Object(vmi)
+
+	domState, _, err := dom.GetState()
+	if err != nil {
+		return err
+	}
+	if domState == libvirt.DOMAIN_RUNNING {
+		if err := dom.Suspend(); err != nil {
+			return fmt.Errorf("failed to pause the domain: %v", err)
+		}
+		l.paused.add(vmi.UID)
+		logger.Info("Paused domain to save its memory state")
+	}
+
+	domSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+	snapshotXML, err := memoryStateSnapshotXML(domSpec, statePath)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := dom.CreateSnapshotXML(snapshotXML, libvirt.DOMAIN_SNAPSHOT_CREATE_NO_METADATA)
+	if err != nil {
+		return err
+	}
+	if snapshot != nil {
+		defer snapshot.Free()
+	}
+	return nil
+}
+
+func restoreMemoryStatePath(vmi *v1.VirtualMachineInstance) (string, bool) {
+	_, fileName, exists := util.RestoreMemoryState(vmi)
+	if !exists {
+		return "", false
+	}
+	return filepath.Join(util.MemoryStateDir, fileName), true
+}
+
+// restoreMemoryState starts the defined domain from a saved memory state
+// instead of booting it
+func (l *LibvirtDomainManager) restoreMemoryState(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, statePath string) error {
+	if _, err := os.Stat(statePath); err != nil {
+		return fmt.Errorf("failed to find memory state to restore: %v", err)
+	}
+
+	// Restore with the definition of this launcher, the saved state only
+	// has to be ABI compatible with it
+	domXML, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_MIGRATABLE)
+	if err != nil {
+		return err
+	}
+
+	flags := libvirt.DOMAIN_SAVE_RUNNING
+	if vmi.ShouldStartPaused() {
+		flags = libvirt.DOMAIN_SAVE_PAUSED
+	}
+	return l.virConn.DomainRestoreFlags(statePath, domXML, flags)
+}
@@ -48,6 +48,9 @@ const (
 	VCPURunning = 1
 	//  VIR_VCPU_BLOCKED    = 2,    /* the virtual CPU is blocked on resource */
 	VCPUBlocked = 2
+
+	// VIR_DOMAIN_DIRTYRATE_MEASURED = 2, /* the dirtyrate calculation has completed successfully */
+	DirtyRateMeasured = 2
 )
 
 type DomainStats struct {
@@ -67,6 +70,7 @@ type DomainStats struct {
 	Net   []DomainStatsNet
 	Block []DomainStatsBlock
 	// omitted from libvirt-go: Perf
+	DirtyRate *DomainStatsDirtyRate
 	// extra stats
 	CPUMapSet bool
 	CPUMap    [][]bool
@@ -175,6 +179,17 @@ type DomainStatsMemory struct {
 	Total            uint64
 }
 
+type DomainStatsDirtyRate struct {
+	CalcStatusSet         bool
+	CalcStatus            int
+	CalcStartTimeSet      bool
+	CalcStartTime         int64
+	CalcPeriodSet         bool
+	CalcPeriod            int
+	MegabytesPerSecondSet bool
+	MegabytesPerSecond    int64
+}
+
 // mimic existing structs, but data is taken from
 // DomainJobInfo
 type DomainJobInfo struct {
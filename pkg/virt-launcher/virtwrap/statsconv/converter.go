@@ -54,11 +54,29 @@ func Convert_libvirt_DomainStats_to_stats_DomainStats(ident DomainIdentifier, in
 	out.Vcpu = Convert_libvirt_DomainStatsVcpu_To_stats_DomainStatsVcpu(in.Vcpu)
 	out.Net = Convert_libvirt_DomainStatsNet_To_stats_DomainStatsNet(in.Net, devAliasMap)
 	out.Block = Convert_libvirt_DomainStatsBlock_To_stats_DomainStatsBlock(in.Block, devAliasMap)
+	out.DirtyRate = Convert_libvirt_DomainStatsDirtyRate_To_stats_DomainStatsDirtyRate(in.DirtyRate)
 	out.MigrateDomainJobInfo = inJobInfo
 
 	return nil
 }
 
+func Convert_libvirt_DomainStatsDirtyRate_To_stats_DomainStatsDirtyRate(in *libvirt.DomainStatsDirtyRate) *stats.DomainStatsDirtyRate {
+	if in == nil {
+		return nil
+	}
+
+	return &stats.DomainStatsDirtyRate{
+		CalcStatusSet:         in.CalcStatusSet,
+		CalcStatus:            in.CalcStatus,
+		CalcStartTimeSet:      in.CalcStartTimeSet,
+		CalcStartTime:         in.CalcStartTime,
+		CalcPeriodSet:         in.CalcPeriodSet,
+		CalcPeriod:            in.CalcPeriod,
+		MegabytesPerSecondSet: in.MegabytesPerSecondSet,
+		MegabytesPerSecond:    in.MegabytesPerSecond,
+	}
+}
+
 func Convert_libvirt_DomainStatsCpu_To_stats_DomainStatsCpu(in *libvirt.DomainStatsCPU) *stats.DomainStatsCPU {
 	if in == nil {
 		return &stats.DomainStatsCPU{}
@@ -82,6 +82,7 @@ var _ = Describe("StatsConverter", func() {
 			Expect(out.Cpu).To(Not(BeNil()))
 			Expect(out.Memory).To(Not(BeNil()))
 			Expect(out.MigrateDomainJobInfo).To(Not(BeNil()))
+			Expect(out.DirtyRate).To(Not(BeNil()))
 			Expect(out.Vcpu).To(HaveLen(len(testStats[0].Vcpu)))
 			Expect(out.Net).To(HaveLen(len(testStats[0].Net)))
 			Expect(out.Block).To(HaveLen(len(testStats[0].Block)))
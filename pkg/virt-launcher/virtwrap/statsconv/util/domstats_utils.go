@@ -45,6 +45,16 @@ var Testdata = `[
          }
       ],
       "Perf" : null,
+      "DirtyRate" : {
+         "CalcStatusSet" : true,
+         "CalcStatus" : 2,
+         "CalcStartTimeSet" : true,
+         "CalcStartTime" : 1666000000,
+         "CalcPeriodSet" : true,
+         "CalcPeriod" : 1,
+         "MegabytesPerSecondSet" : true,
+         "MegabytesPerSecond" : 42
+      },
       "Cpu" : {
          "TimeSet" : true,
          "UserSet" : true,
@@ -183,6 +193,16 @@ var Testdataexpected = `{
      "Total": 0,
      "TotalSet": false
    }, 
+   "DirtyRate": {
+     "CalcStatusSet": true,
+     "CalcStatus": 2,
+     "CalcStartTimeSet": true,
+     "CalcStartTime": 1666000000,
+     "CalcPeriodSet": true,
+     "CalcPeriod": 1,
+     "MegabytesPerSecondSet": true,
+     "MegabytesPerSecond": 42
+   },
    "MigrateDomainJobInfo": {
      "DataProcessed": 0,
      "DataProcessedSet": false,
@@ -215,6 +215,8 @@ func Execute() {
 		Namespace:                app.informerFactory.Namespace(),
 		Secrets:                  app.informerFactory.Secrets(),
 		ConfigMap:                app.informerFactory.OperatorConfigMap(),
+		Node:                     app.informerFactory.KubeVirtNode(),
+		Migration:                app.informerFactory.VirtualMachineInstanceMigration(),
 	}
 
 	app.stores = util.Stores{
@@ -237,6 +239,8 @@ func Execute() {
 		NamespaceCache:                app.informerFactory.Namespace().GetStore(),
 		SecretCache:                   app.informerFactory.Secrets().GetStore(),
 		ConfigMapCache:                app.informerFactory.OperatorConfigMap().GetStore(),
+		NodeCache:                     app.informerFactory.KubeVirtNode().GetStore(),
+		MigrationCache:                app.informerFactory.VirtualMachineInstanceMigration().GetStore(),
 	}
 
 	app.crdInformer = app.informerFactory.CRD()
@@ -656,6 +656,10 @@ func (c *KubeVirtController) Run(threadiness int, stopCh <-chan struct{}) {
 	cache.WaitForCacheSync(stopCh, c.informers.PrometheusRule.HasSynced)
 	cache.WaitForCacheSync(stopCh, c.informers.Secrets.HasSynced)
 	cache.WaitForCacheSync(stopCh, c.informers.ConfigMap.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.informers.Node.HasSynced)
+	// The migration CRD is only created by the operator itself, so the
+	// migration cache is not waited for. It is only read during canary
+	// upgrades, when the CRD is already installed.
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -780,14 +784,13 @@ func (c *KubeVirtController) execute(key string) error {
 
 // Loads install strategies into memory, and generates jobs to
 // create install strategies that don't exist yet.
-func (c *KubeVirtController) loadInstallStrategy(kv *v1.KubeVirt) (*install.Strategy, bool, error) {
+func (c *KubeVirtController) loadInstallStrategy(kv *v1.KubeVirt, config *operatorutil.KubeVirtDeploymentConfig) (*install.Strategy, bool, error) {
 
 	kvkey, err := controller.KeyFunc(kv)
 	if err != nil {
 		return nil, true, err
 	}
 
-	config := operatorutil.GetTargetConfigFromKV(kv)
 	// 1. see if we already loaded the install strategy
 	strategy, ok := c.getCachedInstallStrategy(config, kv.Generation)
 	if ok {
@@ -923,6 +926,16 @@ func (c *KubeVirtController) syncInstallation(kv *v1.KubeVirt) error {
 
 	config := operatorutil.GetTargetConfigFromKV(kv)
 
+	// a failed canary upgrade returns all components to the version it started from
+	rollbackConfig, err := operatorutil.GetCanaryRollbackConfig(kv, config)
+	if err != nil {
+		return err
+	}
+	if rollbackConfig != nil {
+		logger.Infof("Rolling back the canary upgrade to version %s", rollbackConfig.GetKubeVirtVersion())
+		config = rollbackConfig
+	}
+
 	// Record current operator version to status section
 	util.SetOperatorVersion(kv)
 
@@ -942,7 +955,7 @@ func (c *KubeVirtController) syncInstallation(kv *v1.KubeVirt) error {
 		util.UpdateConditionsDeploying(kv)
 	}
 
-	targetStrategy, targetPending, err = c.loadInstallStrategy(kv)
+	targetStrategy, targetPending, err = c.loadInstallStrategy(kv, config)
 	if err != nil {
 		return err
 	}
@@ -1042,7 +1055,7 @@ func (c *KubeVirtController) syncDeletion(kv *v1.KubeVirt) error {
 
 	// If we still have cached objects around, more deletions need to take place.
 	if !c.stores.AllEmpty() {
-		_, pending, err := c.loadInstallStrategy(kv)
+		_, pending, err := c.loadInstallStrategy(kv, operatorutil.GetTargetConfigFromKV(kv))
 		if err != nil {
 			return err
 		}
@@ -86,9 +86,9 @@ const (
 
 	NAMESPACE = "kubevirt-test"
 
-	resourceCount = 75
+	resourceCount = 76
 	patchCount    = 50
-	updateCount   = 26
+	updateCount   = 27
 )
 
 type KubeVirtTestData struct {
@@ -115,6 +115,8 @@ type KubeVirtTestData struct {
 	installStrategyConfigMapSource *framework.FakeControllerSource
 	installStrategyJobSource       *framework.FakeControllerSource
 	infrastructurePodSource        *framework.FakeControllerSource
+	nodeSource                     *framework.FakeControllerSource
+	migrationSource                *framework.FakeControllerSource
 	podDisruptionBudgetSource      *framework.FakeControllerSource
 	serviceMonitorSource           *framework.FakeControllerSource
 	namespaceSource                *framework.FakeControllerSource
@@ -255,6 +257,10 @@ func (k *KubeVirtTestData) BeforeTest() {
 	k.stores.SecretCache = k.informers.Secrets.GetStore()
 	k.informers.ConfigMap, k.configMapSource = testutils.NewFakeInformerFor(&k8sv1.ConfigMap{})
 	k.stores.ConfigMapCache = k.informers.ConfigMap.GetStore()
+	k.informers.Node, k.nodeSource = testutils.NewFakeInformerFor(&k8sv1.Node{})
+	k.stores.NodeCache = k.informers.Node.GetStore()
+	k.informers.Migration, k.migrationSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceMigration{})
+	k.stores.MigrationCache = k.informers.Migration.GetStore()
 
 	k.controller, _ = NewKubeVirtController(k.virtClient, k.apiServiceClient, k.kvInformer, k.recorder, k.stores, k.informers, NAMESPACE)
 	k.controller.delayedQueueAdder = func(key interface{}, queue workqueue.RateLimitingInterface) {
@@ -1231,7 +1237,7 @@ func (k *KubeVirtTestData) addAllWithExclusionMap(config *util.KubeVirtDeploymen
 		all = append(all, crd)
 	}
 	// cr
-	all = append(all, components.NewPrometheusRuleCR(config.GetNamespace(), config.WorkloadUpdatesEnabled()))
+	all = append(all, components.NewPrometheusRuleCR(config.GetNamespace(), config.WorkloadUpdatesEnabled(), config.GetAlertingConfiguration()))
 	// sccs
 	all = append(all, components.NewKubeVirtControllerSCC(NAMESPACE))
 	all = append(all, components.NewKubeVirtHandlerSCC(NAMESPACE))
@@ -2427,7 +2433,7 @@ var _ = Describe("KubeVirt Operator", func() {
 
 			Expect(kvTestData.controller.stores.ServiceAccountCache.List()).To(HaveLen(4))
 			Expect(kvTestData.controller.stores.ClusterRoleCache.List()).To(HaveLen(8))
-			Expect(kvTestData.controller.stores.ClusterRoleBindingCache.List()).To(HaveLen(6))
+			Expect(kvTestData.controller.stores.ClusterRoleBindingCache.List()).To(HaveLen(7))
 			Expect(kvTestData.controller.stores.RoleCache.List()).To(HaveLen(5))
 			Expect(kvTestData.controller.stores.RoleBindingCache.List()).To(HaveLen(5))
 			Expect(kvTestData.controller.stores.CrdCache.List()).To(HaveLen(16))
@@ -3150,6 +3156,8 @@ func syncCaches(stop chan struct{}, kvInformer cache.SharedIndexInformer, inform
 	go informers.Secrets.Run(stop)
 	go informers.ConfigMap.Run(stop)
 	go informers.Route.Run(stop)
+	go informers.Node.Run(stop)
+	go informers.Migration.Run(stop)
 
 	Expect(cache.WaitForCacheSync(stop, kvInformer.HasSynced)).To(BeTrue())
 
@@ -3176,6 +3184,8 @@ func syncCaches(stop chan struct{}, kvInformer cache.SharedIndexInformer, inform
 	cache.WaitForCacheSync(stop, informers.Secrets.HasSynced)
 	cache.WaitForCacheSync(stop, informers.ConfigMap.HasSynced)
 	cache.WaitForCacheSync(stop, informers.Route.HasSynced)
+	cache.WaitForCacheSync(stop, informers.Node.HasSynced)
+	cache.WaitForCacheSync(stop, informers.Migration.HasSynced)
 }
 
 func injectMetadata(objectMeta *metav1.ObjectMeta, config *util.KubeVirtDeploymentConfig) {
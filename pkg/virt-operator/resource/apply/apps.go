@@ -311,6 +311,18 @@ func (r *Reconciler) syncDaemonSet(daemonSet *appsv1.DaemonSet) (bool, error) {
 		return true, nil
 	}
 
+	// staged upgrade
+	// roll the new virt-handler out to the canary nodes
+	// soak, while the control plane and the canary workloads are updated
+	// continue with the rollout on all nodes
+	if r.shouldStageDaemonSetUpdate(cachedDaemonSet) {
+		promoted, err := r.processStagedUpgrade(cachedDaemonSet, daemonSet)
+		if err != nil || !promoted {
+			soaking := r.kv.Status.CanaryUpgrade != nil && r.kv.Status.CanaryUpgrade.Phase == v1.CanaryUpgradePhaseSoaking
+			return soaking && err == nil, err
+		}
+	}
+
 	// canary pod upgrade
 	// first update virt-handler with maxUnavailable=1
 	// patch daemonSet with new version
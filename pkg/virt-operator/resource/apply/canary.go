@@ -0,0 +1,277 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/virt-operator/util"
+)
+
+const (
+	failedCanaryUpgradeReason = "FailedCanaryUpgrade"
+
+	// canaryUpgradeResyncInterval is how often a canary in progress is re-evaluated
+	canaryUpgradeResyncInterval = 30 * time.Second
+	defaultCanarySoakPeriod     = time.Hour
+)
+
+// canaryUpgradeInProgress returns true while virt-operator waits on the canary nodes
+func canaryUpgradeInProgress(kv *v1.KubeVirt) bool {
+	status := kv.Status.CanaryUpgrade
+	if kv.Spec.CanaryUpgrade == nil || status == nil || status.TargetDeploymentID != kv.Status.TargetDeploymentID {
+		return false
+	}
+	return status.Phase == v1.CanaryUpgradePhaseCanary || status.Phase == v1.CanaryUpgradePhaseSoaking
+}
+
+func canarySoakPeriod(strategy *v1.KubeVirtCanaryUpgradeStrategy) time.Duration {
+	if strategy.SoakPeriod != nil {
+		return strategy.SoakPeriod.Duration
+	}
+	return defaultCanarySoakPeriod
+}
+
+func canaryFailureThreshold(strategy *v1.KubeVirtCanaryUpgradeStrategy) int32 {
+	if strategy.FailureThreshold != nil {
+		return *strategy.FailureThreshold
+	}
+	return 0
+}
+
+func restartCanaryUpgrade(status *v1.KubeVirtCanaryUpgradeStatus) {
+	now := metav1.Now()
+	status.Phase = v1.CanaryUpgradePhaseCanary
+	status.StartTime = &now
+	status.SoakStartTime = nil
+	status.Failures = 0
+	status.Message = ""
+}
+
+// shouldStageDaemonSetUpdate returns true if the update of the daemonSet is held by a canary stage
+func (r *Reconciler) shouldStageDaemonSetUpdate(cachedDaemonSet *appsv1.DaemonSet) bool {
+	if r.kv.Spec.CanaryUpgrade == nil || cachedDaemonSet.Name != "virt-handler" || util.CanaryRolledBack(r.kv) {
+		return false
+	}
+	status := r.kv.Status.CanaryUpgrade
+	return !util.DaemonSetIsUpToDate(r.kv, cachedDaemonSet) ||
+		(status != nil && status.TargetDeploymentID == r.kv.Status.TargetDeploymentID)
+}
+
+// processStagedUpgrade rolls the new virt-handler out to the canary nodes and holds the rollout
+// for the soak period.
+// It returns true once the canary got promoted and the rollout may continue on all nodes.
+func (r *Reconciler) processStagedUpgrade(cachedDaemonSet, newDS *appsv1.DaemonSet) (bool, error) {
+	strategy := r.kv.Spec.CanaryUpgrade
+	status := r.kv.Status.CanaryUpgrade
+
+	if status == nil || status.TargetDeploymentID != r.kv.Status.TargetDeploymentID {
+		status = &v1.KubeVirtCanaryUpgradeStatus{
+			TargetDeploymentID:       r.kv.Status.TargetDeploymentID,
+			PreviousDeploymentConfig: r.kv.Status.ObservedDeploymentConfig,
+		}
+		restartCanaryUpgrade(status)
+		r.kv.Status.CanaryUpgrade = status
+	}
+
+	switch status.Phase {
+	case v1.CanaryUpgradePhasePromoted:
+		return true, nil
+	case v1.CanaryUpgradePhasePaused, v1.CanaryUpgradePhaseRolledBack:
+		if status.ObservedGeneration == r.kv.Generation {
+			log.Log.V(4).Infof("canary upgrade of daemonSet %v is %s", cachedDaemonSet.Name, status.Phase)
+			return false, nil
+		}
+		log.Log.Infof("restarting the canary upgrade of daemonSet %v", cachedDaemonSet.Name)
+		restartCanaryUpgrade(status)
+	}
+
+	canaryNodes := r.getCanaryNodes(strategy.NodeSelector)
+	canaryPods := r.getDaemonSetPodsOnNodes(cachedDaemonSet, canaryNodes)
+
+	status.Failures = r.countCanaryFailures(canaryNodes, canaryPods, strategy.Namespaces, status)
+	if status.Failures > canaryFailureThreshold(strategy) {
+		return false, r.failCanaryUpgrade(cachedDaemonSet)
+	}
+
+	switch status.Phase {
+	case v1.CanaryUpgradePhaseCanary:
+		if !util.DaemonSetIsUpToDate(r.kv, cachedDaemonSet) || cachedDaemonSet.Spec.UpdateStrategy.Type != appsv1.OnDeleteDaemonSetStrategyType {
+			// only pods which get deleted are replaced by the new virt-handler
+			newDS.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.OnDeleteDaemonSetStrategyType,
+			}
+			if _, err := r.patchDaemonSet(cachedDaemonSet, newDS); err != nil {
+				return false, fmt.Errorf("unable to start canary upgrade for daemonset %+v: %v", newDS, err)
+			}
+			return false, nil
+		}
+
+		if len(canaryNodes) == 0 {
+			status.Message = "no node matches the canary node selector"
+			return false, nil
+		}
+
+		canaryReady := true
+		for _, pod := range canaryPods {
+			if util.PodIsUpToDate(pod, r.kv) {
+				continue
+			}
+			canaryReady = false
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+			err := r.clientset.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+			if err != nil {
+				return false, fmt.Errorf("unable to replace virt-handler pod %s on canary node %s: %v", pod.Name, pod.Spec.NodeName, err)
+			}
+			log.Log.V(2).Infof("replacing virt-handler pod %s on canary node %s", pod.Name, pod.Spec.NodeName)
+		}
+
+		// replaced pods are only counted by the daemonSet once they are ready
+		if !canaryReady || cachedDaemonSet.Status.ObservedGeneration != cachedDaemonSet.Generation ||
+			cachedDaemonSet.Status.NumberReady != cachedDaemonSet.Status.DesiredNumberScheduled {
+			status.Message = ""
+			return false, nil
+		}
+
+		now := metav1.Now()
+		status.Phase = v1.CanaryUpgradePhaseSoaking
+		status.SoakStartTime = &now
+		status.Message = ""
+		log.Log.Infof("canary virt-handlers of daemonSet %v are ready, soaking", cachedDaemonSet.Name)
+		return false, nil
+	case v1.CanaryUpgradePhaseSoaking:
+		if status.SoakStartTime != nil && time.Since(status.SoakStartTime.Time) < canarySoakPeriod(strategy) {
+			return false, nil
+		}
+
+		newDS.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.RollingUpdateDaemonSetStrategyType,
+		}
+		setMaxUnavailable(newDS, daemonSetFastMaxUnavailable)
+		if _, err := r.patchDaemonSet(cachedDaemonSet, newDS); err != nil {
+			return false, fmt.Errorf("unable to promote canary upgrade for daemonset %+v: %v", newDS, err)
+		}
+		status.Phase = v1.CanaryUpgradePhasePromoted
+		status.Message = ""
+		log.Log.Infof("canary upgrade of daemonSet %v promoted", cachedDaemonSet.Name)
+		return false, nil
+	}
+
+	return false, nil
+}
+
+func (r *Reconciler) getCanaryNodes(nodeSelector map[string]string) map[string]*corev1.Node {
+	selector := labels.SelectorFromSet(nodeSelector)
+
+	canaryNodes := map[string]*corev1.Node{}
+	for _, obj := range r.stores.NodeCache.List() {
+		node := obj.(*corev1.Node)
+		if selector.Matches(labels.Set(node.Labels)) {
+			canaryNodes[node.Name] = node
+		}
+	}
+	return canaryNodes
+}
+
+func (r *Reconciler) getDaemonSetPods(daemonSet *appsv1.DaemonSet) []*corev1.Pod {
+	pods := []*corev1.Pod{}
+
+	for _, obj := range r.stores.InfrastructurePodCache.List() {
+		pod := obj.(*corev1.Pod)
+		owner := metav1.GetControllerOf(pod)
+
+		if owner != nil && owner.Name == daemonSet.Name {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+func (r *Reconciler) getDaemonSetPodsOnNodes(daemonSet *appsv1.DaemonSet, nodes map[string]*corev1.Node) []*corev1.Pod {
+	pods := []*corev1.Pod{}
+
+	for _, pod := range r.getDaemonSetPods(daemonSet) {
+		if _, ok := nodes[pod.Spec.NodeName]; ok {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// countCanaryFailures counts the unhealthy canary virt-handlers and the failed workload update
+// migrations to the canary nodes. A canary virt-handler is unhealthy when it crash loops, and while
+// soaking also when it is no longer ready or when its node heartbeat timed out.
+func (r *Reconciler) countCanaryFailures(canaryNodes map[string]*corev1.Node, canaryPods []*corev1.Pod, namespaces []string, status *v1.KubeVirtCanaryUpgradeStatus) int32 {
+	var failures int32
+	soaking := status.Phase == v1.CanaryUpgradePhaseSoaking
+
+	for _, pod := range canaryPods {
+		if !util.PodIsUpToDate(pod, r.kv) {
+			continue
+		}
+		if util.PodIsCrashLooping(pod) {
+			failures++
+		} else if soaking && (!util.PodIsReady(pod) || !isNodeSchedulable(canaryNodes[pod.Spec.NodeName])) {
+			failures++
+		}
+	}
+
+	canaryNamespaces := map[string]bool{}
+	for _, namespace := range namespaces {
+		canaryNamespaces[namespace] = true
+	}
+	for _, obj := range r.stores.MigrationCache.List() {
+		migration := obj.(*v1.VirtualMachineInstanceMigration)
+		if !canaryNamespaces[migration.Namespace] {
+			continue
+		}
+		if _, ok := migration.Annotations[v1.WorkloadUpdateTargetNodeSelectorAnnotation]; !ok {
+			continue
+		}
+		if status.StartTime != nil && migration.CreationTimestamp.Before(status.StartTime) {
+			continue
+		}
+		if migration.Status.Phase == v1.MigrationFailed {
+			failures++
+		}
+	}
+	return failures
+}
+
+// isNodeSchedulable reports the health virt-handler publishes on its node, the node is marked
+// unschedulable once the virt-handler heartbeat times out
+func isNodeSchedulable(node *corev1.Node) bool {
+	return node != nil && node.Labels[v1.NodeSchedulable] != "false"
+}
+
+func (r *Reconciler) failCanaryUpgrade(cachedDaemonSet *appsv1.DaemonSet) error {
+	strategy := r.kv.Spec.CanaryUpgrade
+	status := r.kv.Status.CanaryUpgrade
+
+	status.ObservedGeneration = r.kv.Generation
+	status.Message = fmt.Sprintf("%d canary failures exceeded the threshold of %d", status.Failures, canaryFailureThreshold(strategy))
+
+	if strategy.FailurePolicy == v1.CanaryUpgradeFailurePolicyRollback {
+		if status.PreviousDeploymentConfig != "" {
+			// the KubeVirt controller targets the previous deployment config from now on
+			status.Phase = v1.CanaryUpgradePhaseRolledBack
+			r.recorder.Eventf(cachedDaemonSet, corev1.EventTypeWarning, failedCanaryUpgradeReason, "daemonSet %v canary upgrade failed, rolling all components back to the previous version: %s", cachedDaemonSet.Name, status.Message)
+			return nil
+		}
+		status.Message = fmt.Sprintf("%s, rollback failed: the previously installed version is unknown", status.Message)
+	}
+
+	status.Phase = v1.CanaryUpgradePhasePaused
+	r.recorder.Eventf(cachedDaemonSet, corev1.EventTypeWarning, failedCanaryUpgradeReason, "daemonSet %v canary upgrade paused: %s", cachedDaemonSet.Name, status.Message)
+	return nil
+}
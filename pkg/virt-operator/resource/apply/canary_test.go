@@ -0,0 +1,493 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package apply
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-operator/util"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Canary upgrade", func() {
+	const (
+		canaryNamespace = "canary-workloads"
+		oldID           = "old.id"
+		newID           = "new.id"
+	)
+
+	var ctrl *gomock.Controller
+	var clientset *kubecli.MockKubevirtClient
+	var kubeClient *fake.Clientset
+	var mockPodCacheStore *cache.FakeCustomStore
+	var nodeStore cache.Store
+	var migrationStore cache.Store
+	var recorder *record.FakeRecorder
+	var kv *v1.KubeVirt
+	var r *Reconciler
+
+	var cachedDaemonSet *appsv1.DaemonSet
+	var newDaemonSet *appsv1.DaemonSet
+	var pods []*corev1.Pod
+	var patchedDaemonSet *appsv1.DaemonSet
+	var deletedPods []string
+
+	setInstallStrategyAnnotations := func(objectMeta *metav1.ObjectMeta, id string) {
+		objectMeta.Annotations = map[string]string{
+			v1.InstallStrategyVersionAnnotation:    Version,
+			v1.InstallStrategyRegistryAnnotation:   Registry,
+			v1.InstallStrategyIdentifierAnnotation: id,
+		}
+	}
+
+	newHandlerPod := func(name, nodeName, id string, ready bool) *corev1.Pod {
+		isController := true
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: Namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					{Name: cachedDaemonSet.Name, Controller: &isController},
+				},
+				Labels: map[string]string{
+					appsv1.DefaultDaemonSetUniqueLabelKey: id + "-hash",
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Ready: ready},
+				},
+			},
+		}
+		setInstallStrategyAnnotations(&pod.ObjectMeta, id)
+		return pod
+	}
+
+	newNode := func(name string, nodeLabels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: nodeLabels,
+			},
+		}
+	}
+
+	markDaemonSetReady := func(desired, ready int32) {
+		cachedDaemonSet.Status.DesiredNumberScheduled = desired
+		cachedDaemonSet.Status.NumberReady = ready
+	}
+
+	setCanaryStatus := func(phase v1.CanaryUpgradePhase) {
+		startTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		kv.Status.CanaryUpgrade = &v1.KubeVirtCanaryUpgradeStatus{
+			Phase:              phase,
+			TargetDeploymentID: newID,
+			StartTime:          &startTime,
+		}
+		if phase == v1.CanaryUpgradePhaseSoaking {
+			soakStartTime := metav1.Now()
+			kv.Status.CanaryUpgrade.SoakStartTime = &soakStartTime
+		}
+	}
+
+	updateCachedDaemonSet := func(updateStrategy appsv1.DaemonSetUpdateStrategy) {
+		setInstallStrategyAnnotations(&cachedDaemonSet.ObjectMeta, newID)
+		cachedDaemonSet.Spec.UpdateStrategy = updateStrategy
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clientset = kubecli.NewMockKubevirtClient(ctrl)
+
+		kubeClient = fake.NewSimpleClientset()
+		clientset.EXPECT().AppsV1().Return(kubeClient.AppsV1()).AnyTimes()
+		clientset.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+
+		nodeStore = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		Expect(nodeStore.Add(newNode("canary-node", map[string]string{"canary": "true"}))).To(Succeed())
+		Expect(nodeStore.Add(newNode("other-node", nil))).To(Succeed())
+		migrationStore = cache.NewStore(cache.MetaNamespaceKeyFunc)
+
+		var err error
+		cachedDaemonSet, err = tests.GetDefaultVirtHandlerDaemonSet(Namespace, &util.KubeVirtDeploymentConfig{
+			Registry:        Registry,
+			KubeVirtVersion: Version,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		newDaemonSet = cachedDaemonSet.DeepCopy()
+		setInstallStrategyAnnotations(&cachedDaemonSet.ObjectMeta, oldID)
+		setInstallStrategyAnnotations(&newDaemonSet.ObjectMeta, newID)
+		markDaemonSetReady(2, 2)
+
+		pods = []*corev1.Pod{
+			newHandlerPod("handler-canary", "canary-node", oldID, true),
+			newHandlerPod("handler-other", "other-node", oldID, true),
+		}
+		mockPodCacheStore = &cache.FakeCustomStore{}
+		mockPodCacheStore.ListFunc = func() []interface{} {
+			objs := []interface{}{}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			return objs
+		}
+
+		patchedDaemonSet = nil
+		kubeClient.Fake.PrependReactor("patch", "daemonsets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			patches := []patch.PatchOperation{}
+			Expect(json.Unmarshal(action.(testing.PatchAction).GetPatch(), &patches)).To(Succeed())
+
+			patchedDaemonSet = &appsv1.DaemonSet{}
+			for _, p := range patches {
+				value, err := json.Marshal(p.Value)
+				Expect(err).ToNot(HaveOccurred())
+				switch p.Path {
+				case "/spec":
+					Expect(json.Unmarshal(value, &patchedDaemonSet.Spec)).To(Succeed())
+				case "/metadata/annotations":
+					Expect(json.Unmarshal(value, &patchedDaemonSet.Annotations)).To(Succeed())
+				}
+			}
+			return true, patchedDaemonSet, nil
+		})
+
+		deletedPods = nil
+		kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			deletedPods = append(deletedPods, action.(testing.DeleteAction).GetName())
+			return true, nil, nil
+		})
+
+		kv = &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  Namespace,
+				Generation: 1,
+			},
+			Spec: v1.KubeVirtSpec{
+				CanaryUpgrade: &v1.KubeVirtCanaryUpgradeStrategy{
+					NodeSelector: map[string]string{"canary": "true"},
+					Namespaces:   []string{canaryNamespace},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				TargetKubeVirtVersion:  Version,
+				TargetKubeVirtRegistry: Registry,
+				TargetDeploymentID:     newID,
+			},
+		}
+
+		recorder = record.NewFakeRecorder(10)
+		recorder.IncludeObject = true
+		r = &Reconciler{
+			clientset: clientset,
+			kv:        kv,
+			stores: util.Stores{
+				InfrastructurePodCache: mockPodCacheStore,
+				NodeCache:              nodeStore,
+				MigrationCache:         migrationStore,
+			},
+			recorder: recorder,
+		}
+	})
+
+	It("should start by updating the daemonSet with the OnDelete strategy", func() {
+		promoted, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+
+		Expect(patchedDaemonSet).ToNot(BeNil())
+		Expect(util.DaemonSetIsUpToDate(kv, patchedDaemonSet)).To(BeTrue())
+		Expect(patchedDaemonSet.Spec.UpdateStrategy.Type).To(Equal(appsv1.OnDeleteDaemonSetStrategyType))
+		Expect(deletedPods).To(BeEmpty())
+
+		Expect(kv.Status.CanaryUpgrade).ToNot(BeNil())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhaseCanary))
+		Expect(kv.Status.CanaryUpgrade.TargetDeploymentID).To(Equal(newID))
+		Expect(kv.Status.CanaryUpgrade.StartTime).ToNot(BeNil())
+	})
+
+	It("should only replace the virt-handlers on the canary nodes", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseCanary)
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+
+		promoted, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(patchedDaemonSet).To(BeNil())
+		Expect(deletedPods).To(ConsistOf("handler-canary"))
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhaseCanary))
+	})
+
+	It("should wait until the replaced virt-handlers are ready", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseCanary)
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		pods[0] = newHandlerPod("handler-canary", "canary-node", newID, false)
+		markDaemonSetReady(2, 1)
+
+		promoted, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(deletedPods).To(BeEmpty())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhaseCanary))
+	})
+
+	It("should report when no node matches the canary node selector", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseCanary)
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		kv.Spec.CanaryUpgrade.NodeSelector = map[string]string{"canary": "none"}
+
+		promoted, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(deletedPods).To(BeEmpty())
+		Expect(kv.Status.CanaryUpgrade.Message).To(ContainSubstring("no node matches"))
+	})
+
+	It("should start soaking once the canary virt-handlers are ready", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseCanary)
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		pods[0] = newHandlerPod("handler-canary", "canary-node", newID, true)
+
+		promoted, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhaseSoaking))
+		Expect(kv.Status.CanaryUpgrade.SoakStartTime).ToNot(BeNil())
+	})
+
+	It("should let the control plane update while soaking", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseSoaking)
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		pods[0] = newHandlerPod("handler-canary", "canary-node", newID, true)
+		r.stores.DaemonSetCache = &MockStore{get: cachedDaemonSet}
+		r.expectations = &util.Expectations{}
+
+		done, err := r.syncDaemonSet(newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(done).To(BeTrue())
+		Expect(patchedDaemonSet).To(BeNil())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhaseSoaking))
+	})
+
+	It("should continue the rollout on all nodes after the soak period", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseSoaking)
+		soakStartTime := metav1.NewTime(time.Now().Add(-30 * time.Minute))
+		kv.Status.CanaryUpgrade.SoakStartTime = &soakStartTime
+		kv.Spec.CanaryUpgrade.SoakPeriod = &metav1.Duration{Duration: 20 * time.Minute}
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		pods[0] = newHandlerPod("handler-canary", "canary-node", newID, true)
+
+		promoted, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhasePromoted))
+
+		Expect(patchedDaemonSet).ToNot(BeNil())
+		Expect(patchedDaemonSet.Spec.UpdateStrategy.Type).To(Equal(appsv1.RollingUpdateDaemonSetStrategyType))
+		Expect(patchedDaemonSet.Spec.UpdateStrategy.RollingUpdate).ToNot(BeNil())
+		Expect(patchedDaemonSet.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable.String()).To(Equal("10%"))
+
+		promoted, err = r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeTrue())
+	})
+
+	It("should pause when a canary virt-handler crashes", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseSoaking)
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		pods[0] = newHandlerPod("handler-canary", "canary-node", newID, false)
+		pods[0].Status.ContainerStatuses[0].RestartCount = 1
+
+		promoted, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(patchedDaemonSet).To(BeNil())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhasePaused))
+		Expect(kv.Status.CanaryUpgrade.Failures).To(BeEquivalentTo(1))
+		Expect(kv.Status.CanaryUpgrade.ObservedGeneration).To(Equal(kv.Generation))
+		testutils.ExpectEvent(recorder, failedCanaryUpgradeReason)
+
+		By("staying paused until the KubeVirt CR changes")
+		promoted, err = r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhasePaused))
+
+		By("restarting the canary stage once the KubeVirt CR changed")
+		pods[0].Status.ContainerStatuses[0].RestartCount = 0
+		kv.Generation = 2
+		promoted, err = r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhaseSoaking))
+		Expect(kv.Status.CanaryUpgrade.Failures).To(BeZero())
+	})
+
+	It("should tolerate failures up to the threshold", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseSoaking)
+		threshold := int32(1)
+		kv.Spec.CanaryUpgrade.FailureThreshold = &threshold
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		pods[0] = newHandlerPod("handler-canary", "canary-node", newID, true)
+		migration := &v1.VirtualMachineInstanceMigration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "failed-migration",
+				Namespace:         canaryNamespace,
+				CreationTimestamp: metav1.Now(),
+				Annotations: map[string]string{
+					v1.WorkloadUpdateMigrationAnnotation:          "",
+					v1.WorkloadUpdateTargetNodeSelectorAnnotation: "canary=true",
+				},
+			},
+			Status: v1.VirtualMachineInstanceMigrationStatus{
+				Phase: v1.MigrationFailed,
+			},
+		}
+		Expect(migrationStore.Add(migration)).To(Succeed())
+
+		_, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kv.Status.CanaryUpgrade.Failures).To(BeEquivalentTo(1))
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhaseSoaking))
+
+		By("ignoring failed migrations which happened before the canary stage or outside the canary namespaces")
+		oldMigration := migration.DeepCopy()
+		oldMigration.Name = "old-migration"
+		oldMigration.CreationTimestamp = metav1.NewTime(time.Now().Add(-3 * time.Hour))
+		Expect(migrationStore.Add(oldMigration)).To(Succeed())
+		otherMigration := migration.DeepCopy()
+		otherMigration.Namespace = "other-namespace"
+		Expect(migrationStore.Add(otherMigration)).To(Succeed())
+
+		_, err = r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kv.Status.CanaryUpgrade.Failures).To(BeEquivalentTo(1))
+
+		By("pausing once the threshold is exceeded")
+		secondMigration := migration.DeepCopy()
+		secondMigration.Name = "second-failed-migration"
+		Expect(migrationStore.Add(secondMigration)).To(Succeed())
+
+		_, err = r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kv.Status.CanaryUpgrade.Failures).To(BeEquivalentTo(2))
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhasePaused))
+		testutils.ExpectEvent(recorder, failedCanaryUpgradeReason)
+	})
+
+	DescribeTable("should count unhealthy canary virt-handlers while soaking", func(ready bool, nodeLabels map[string]string) {
+		setCanaryStatus(v1.CanaryUpgradePhaseSoaking)
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		pods[0] = newHandlerPod("handler-canary", "canary-node", newID, ready)
+		Expect(nodeStore.Update(newNode("canary-node", nodeLabels))).To(Succeed())
+
+		promoted, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(kv.Status.CanaryUpgrade.Failures).To(BeEquivalentTo(1))
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhasePaused))
+		testutils.ExpectEvent(recorder, failedCanaryUpgradeReason)
+	},
+		Entry("which is no longer ready", false, map[string]string{"canary": "true"}),
+		Entry("whose heartbeat timed out", true, map[string]string{"canary": "true", v1.NodeSchedulable: "false"}),
+	)
+
+	It("should not count canary virt-handlers which are not ready yet before soaking", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseCanary)
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		pods[0] = newHandlerPod("handler-canary", "canary-node", newID, false)
+		markDaemonSetReady(2, 1)
+
+		_, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kv.Status.CanaryUpgrade.Failures).To(BeZero())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhaseCanary))
+	})
+
+	It("should remember the previously installed version when the canary stage starts", func() {
+		kv.Status.ObservedDeploymentConfig = `{"id":"old.id"}`
+
+		_, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kv.Status.CanaryUpgrade.PreviousDeploymentConfig).To(Equal(`{"id":"old.id"}`))
+	})
+
+	It("should roll all components back to the previous version with the Rollback policy", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseSoaking)
+		kv.Status.CanaryUpgrade.PreviousDeploymentConfig = `{"id":"old.id"}`
+		kv.Spec.CanaryUpgrade.FailurePolicy = v1.CanaryUpgradeFailurePolicyRollback
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		pods[0] = newHandlerPod("handler-canary", "canary-node", newID, false)
+		pods[0].Status.ContainerStatuses[0].RestartCount = 3
+
+		promoted, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promoted).To(BeFalse())
+		Expect(patchedDaemonSet).To(BeNil())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhaseRolledBack))
+		Expect(kv.Status.CanaryUpgrade.ObservedGeneration).To(Equal(kv.Generation))
+		Expect(util.CanaryRolledBack(kv)).To(BeTrue())
+		testutils.ExpectEvent(recorder, failedCanaryUpgradeReason)
+
+		By("rolling virt-handler back like any other component, without a canary stage")
+		kv.Status.TargetDeploymentID = oldID
+		Expect(r.shouldStageDaemonSetUpdate(cachedDaemonSet)).To(BeFalse())
+	})
+
+	It("should pause if the previous version is unknown", func() {
+		setCanaryStatus(v1.CanaryUpgradePhaseSoaking)
+		kv.Spec.CanaryUpgrade.FailurePolicy = v1.CanaryUpgradeFailurePolicyRollback
+		updateCachedDaemonSet(appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType})
+		pods[0] = newHandlerPod("handler-canary", "canary-node", newID, false)
+		pods[0].Status.ContainerStatuses[0].RestartCount = 3
+
+		_, err := r.processStagedUpgrade(cachedDaemonSet, newDaemonSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patchedDaemonSet).To(BeNil())
+		Expect(kv.Status.CanaryUpgrade.Phase).To(Equal(v1.CanaryUpgradePhasePaused))
+		Expect(kv.Status.CanaryUpgrade.Message).To(ContainSubstring("rollback failed"))
+		testutils.ExpectEvent(recorder, failedCanaryUpgradeReason)
+	})
+
+	AfterEach(func() {
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
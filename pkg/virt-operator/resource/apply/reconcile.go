@@ -599,6 +599,10 @@ func (r *Reconciler) Sync(queue workqueue.RateLimitingInterface) (bool, error) {
 
 	if shouldTakeUpdatePath(targetVersion, observedVersion) {
 		finished, err := r.updateKubeVirtSystem(controllerDeploymentsRolledOver)
+		if canaryUpgradeInProgress(r.kv) {
+			// nothing is watched which reports the soak period passing
+			queue.AddAfter(r.kvKey, canaryUpgradeResyncInterval)
+		}
 		if !finished || err != nil {
 			return false, err
 		}
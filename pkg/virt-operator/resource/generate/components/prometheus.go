@@ -4,15 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 
 	"github.com/coreos/prometheus-operator/pkg/apis/monitoring"
 	v1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	virtv1 "kubevirt.io/api/core/v1"
 )
 
 const (
@@ -69,7 +73,7 @@ func NewServiceMonitorCR(namespace string, monitorNamespace string, insecureSkip
 }
 
 // NewPrometheusRuleCR returns a PrometheusRule with a group of alerts for the KubeVirt deployment.
-func NewPrometheusRuleCR(namespace string, workloadUpdatesEnabled bool) *v1.PrometheusRule {
+func NewPrometheusRuleCR(namespace string, workloadUpdatesEnabled bool, alerting *virtv1.AlertingConfiguration) *v1.PrometheusRule {
 	return &v1.PrometheusRule{
 		TypeMeta: v12.TypeMeta{
 			APIVersion: v12.SchemeGroupVersion.String(),
@@ -83,12 +87,13 @@ func NewPrometheusRuleCR(namespace string, workloadUpdatesEnabled bool) *v1.Prom
 				"k8s-app":          "kubevirt",
 			},
 		},
-		Spec: *NewPrometheusRuleSpec(namespace, workloadUpdatesEnabled),
+		Spec: *NewPrometheusRuleSpec(namespace, workloadUpdatesEnabled, alerting),
 	}
 }
 
-// NewPrometheusRuleSpec makes a prometheus rule spec for kubevirt
-func NewPrometheusRuleSpec(ns string, workloadUpdatesEnabled bool) *v1.PrometheusRuleSpec {
+// NewPrometheusRuleSpec makes a prometheus rule spec for kubevirt.
+// The alerts can be disabled and their thresholds and durations overridden by the alerting configuration.
+func NewPrometheusRuleSpec(ns string, workloadUpdatesEnabled bool, alerting *virtv1.AlertingConfiguration) *v1.PrometheusRuleSpec {
 	getRestCallsFailedWarning := func(failingCallsPercentage int, component, duration string) string {
 		const restCallsFailWarningTemplate = "More than %d%% of the rest calls failed in %s for the last %s"
 		return fmt.Sprintf(restCallsFailWarningTemplate, failingCallsPercentage, component, duration)
@@ -494,34 +499,41 @@ func NewPrometheusRuleSpec(ns string, workloadUpdatesEnabled bool) *v1.Prometheu
 				operatorHealthImpactLabelKey: "none",
 			},
 		},
+		{
+			Alert: "VirtualMachineInstanceGuestAgentMissing",
+			Expr:  intstr.FromString("kubevirt_vmi_guest_agent_connected == 0"),
+			For:   "30m",
+			Annotations: map[string]string{
+				"description": "The guest agent of VirtualMachineInstance {{ $labels.namespace }}/{{ $labels.name }} is not connected.",
+				"summary":     "A running VirtualMachineInstance has no connected guest agent.",
+				"runbook_url": fmt.Sprintf(runbookURLTemplate, "VirtualMachineInstanceGuestAgentMissing"),
+			},
+			Labels: map[string]string{
+				severityAlertLabelKey:        "info",
+				operatorHealthImpactLabelKey: "none",
+			},
+		},
 	}...)
 
+	overrides := getAlertRuleOverrides(alerting)
+	for _, alert := range getThresholdAlerts(runbookURLTemplate, workloadUpdatesEnabled) {
+		threshold := alert.defaultThreshold
+		if override, exists := overrides[alert.rule.Alert]; exists && override.Threshold != nil {
+			threshold = formatThreshold(*override.Threshold)
+		}
+		alert.rule.Expr = intstr.FromString(fmt.Sprintf(alert.exprTemplate, threshold))
+		kubevirtRules = append(kubevirtRules, alert.rule)
+	}
+
 	ruleSpec := &v1.PrometheusRuleSpec{
 		Groups: []v1.RuleGroup{
 			{
 				Name:  "kubevirt.rules",
-				Rules: kubevirtRules,
+				Rules: applyAlertRuleOverrides(kubevirtRules, overrides),
 			},
 		},
 	}
 
-	if workloadUpdatesEnabled {
-		ruleSpec.Groups[0].Rules = append(ruleSpec.Groups[0].Rules, v1.Rule{
-
-			Alert: "OutdatedVirtualMachineInstanceWorkloads",
-			Expr:  intstr.FromString("kubevirt_vmi_outdated_count != 0"),
-			For:   "1440m",
-			Annotations: map[string]string{
-				"summary":     "Some running VMIs are still active in outdated pods after KubeVirt control plane update has completed.",
-				"runbook_url": fmt.Sprintf(runbookURLTemplate, "OutdatedVirtualMachineInstanceWorkloads"),
-			},
-			Labels: map[string]string{
-				severityAlertLabelKey:        "warning",
-				operatorHealthImpactLabelKey: "none",
-			},
-		})
-	}
-
 	for _, group := range ruleSpec.Groups {
 		for _, rule := range group.Rules {
 			if rule.Alert == "" {
@@ -535,6 +547,127 @@ func NewPrometheusRuleSpec(ns string, workloadUpdatesEnabled bool) *v1.Prometheu
 	return ruleSpec
 }
 
+// thresholdAlert is an alerting rule whose expression compares against a threshold which can be
+// overridden through the alerting configuration of the KubeVirt CR.
+type thresholdAlert struct {
+	rule v1.Rule
+	// exprTemplate is the expression of the rule with a single %s verb for the threshold
+	exprTemplate     string
+	defaultThreshold string
+}
+
+func getThresholdAlerts(runbookURLTemplate string, workloadUpdatesEnabled bool) []thresholdAlert {
+	alerts := []thresholdAlert{
+		{
+			rule: v1.Rule{
+				Alert: "VirtualMachineMigrationStuck",
+				For:   "5m",
+				Annotations: map[string]string{
+					"description": "VirtualMachine {{ $labels.namespace }}/{{ $labels.name }} has been migrating for {{ $value | humanizeDuration }}.",
+					"summary":     "A live migration of a VirtualMachine does not make progress.",
+					"runbook_url": fmt.Sprintf(runbookURLTemplate, "VirtualMachineMigrationStuck"),
+				},
+				Labels: map[string]string{
+					severityAlertLabelKey:        "warning",
+					operatorHealthImpactLabelKey: "none",
+				},
+			},
+			// the timestamp is 0 while the VM is in a different status and -1 if it has no conditions yet
+			exprTemplate:     "(time() - (kubevirt_vm_migrating_status_last_transition_timestamp_seconds > 0)) > %s",
+			defaultThreshold: "3600",
+		},
+		{
+			rule: v1.Rule{
+				Alert: "VirtualMachineStartFailure",
+				For:   "5m",
+				Annotations: map[string]string{
+					"description": "VirtualMachine {{ $labels.namespace }}/{{ $labels.name }} has been failing to start for {{ $value | humanizeDuration }}.",
+					"summary":     "A VirtualMachine is in CrashLoopBackOff or another error status.",
+					"runbook_url": fmt.Sprintf(runbookURLTemplate, "VirtualMachineStartFailure"),
+				},
+				Labels: map[string]string{
+					severityAlertLabelKey:        "warning",
+					operatorHealthImpactLabelKey: "none",
+				},
+			},
+			exprTemplate:     "(time() - (kubevirt_vm_error_status_last_transition_timestamp_seconds > 0)) > %s",
+			defaultThreshold: "300",
+		},
+		{
+			rule: v1.Rule{
+				Alert: "VirtualMachineInstanceStorageLatencyHigh",
+				For:   "10m",
+				Annotations: map[string]string{
+					"description": "The average latency of the {{ $labels.drive }} drive of VirtualMachineInstance {{ $labels.namespace }}/{{ $labels.name }} is {{ $value | humanize }}ms.",
+					"summary":     "The storage of a VirtualMachineInstance responds slowly.",
+					"runbook_url": fmt.Sprintf(runbookURLTemplate, "VirtualMachineInstanceStorageLatencyHigh"),
+				},
+				Labels: map[string]string{
+					severityAlertLabelKey:        "warning",
+					operatorHealthImpactLabelKey: "none",
+				},
+			},
+			// divides by zero, and thus never fires, while a drive is idle
+			exprTemplate:     "(rate(kubevirt_vmi_storage_read_times_ms_total[5m]) + rate(kubevirt_vmi_storage_write_times_ms_total[5m])) / (rate(kubevirt_vmi_storage_iops_read_total[5m]) + rate(kubevirt_vmi_storage_iops_write_total[5m])) > %s",
+			defaultThreshold: "100",
+		},
+	}
+
+	if workloadUpdatesEnabled {
+		alerts = append(alerts, thresholdAlert{
+			rule: v1.Rule{
+				Alert: "OutdatedVirtualMachineInstanceWorkloads",
+				For:   "1440m",
+				Annotations: map[string]string{
+					"summary":     "Some running VMIs are still active in outdated pods after KubeVirt control plane update has completed.",
+					"runbook_url": fmt.Sprintf(runbookURLTemplate, "OutdatedVirtualMachineInstanceWorkloads"),
+				},
+				Labels: map[string]string{
+					severityAlertLabelKey:        "warning",
+					operatorHealthImpactLabelKey: "none",
+				},
+			},
+			exprTemplate:     "kubevirt_vmi_outdated_count > %s",
+			defaultThreshold: "0",
+		})
+	}
+
+	return alerts
+}
+
+func getAlertRuleOverrides(alerting *virtv1.AlertingConfiguration) map[string]virtv1.AlertRuleConfiguration {
+	overrides := map[string]virtv1.AlertRuleConfiguration{}
+	if alerting == nil {
+		return overrides
+	}
+	for _, rule := range alerting.Rules {
+		overrides[rule.Alert] = rule
+	}
+	return overrides
+}
+
+// applyAlertRuleOverrides drops the disabled alerts and replaces the durations of the others.
+// Recording rules are never touched.
+func applyAlertRuleOverrides(rules []v1.Rule, overrides map[string]virtv1.AlertRuleConfiguration) []v1.Rule {
+	result := make([]v1.Rule, 0, len(rules))
+	for _, rule := range rules {
+		if override, exists := overrides[rule.Alert]; exists && rule.Alert != "" {
+			if override.Disabled {
+				continue
+			}
+			if override.For != nil {
+				rule.For = model.Duration(override.For.Duration).String()
+			}
+		}
+		result = append(result, rule)
+	}
+	return result
+}
+
+func formatThreshold(threshold resource.Quantity) string {
+	return strconv.FormatFloat(threshold.AsApproximateFloat64(), 'f', -1, 64)
+}
+
 type KubevirtRecordingRule struct {
 	v1.Rule
 	MType       prometheusv1.MetricType
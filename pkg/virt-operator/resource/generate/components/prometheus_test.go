@@ -3,9 +3,16 @@ package components
 import (
 	"fmt"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	v1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/api/core/v1"
 )
 
 var _ = Describe("Prometheus", func() {
@@ -18,7 +25,7 @@ var _ = Describe("Prometheus", func() {
 	})
 
 	It("should use the default runbook URL template when no ENV Variable is set", func() {
-		promRule := NewPrometheusRuleCR("mynamespace", true)
+		promRule := NewPrometheusRuleCR("mynamespace", true, nil)
 
 		for _, group := range promRule.Spec.Groups {
 			for _, rule := range group.Rules {
@@ -35,7 +42,7 @@ var _ = Describe("Prometheus", func() {
 		desiredRunbookURLTemplate := "desired/runbookURL/template/%s"
 		os.Setenv(runbookURLTemplateEnv, desiredRunbookURLTemplate)
 
-		promRule := NewPrometheusRuleCR("mynamespace", true)
+		promRule := NewPrometheusRuleCR("mynamespace", true, nil)
 
 		for _, group := range promRule.Spec.Groups {
 			for _, rule := range group.Rules {
@@ -47,4 +54,90 @@ var _ = Describe("Prometheus", func() {
 			}
 		}
 	})
+
+	Context("alerting configuration", func() {
+		findAlert := func(spec *v1.PrometheusRuleSpec, name string) *v1.Rule {
+			for _, group := range spec.Groups {
+				for i, rule := range group.Rules {
+					if rule.Alert == name {
+						return &group.Rules[i]
+					}
+				}
+			}
+			return nil
+		}
+
+		DescribeTable("should ship the alert with its default threshold", func(name, expr, duration string) {
+			rule := findAlert(NewPrometheusRuleSpec("mynamespace", true, nil), name)
+			Expect(rule).ToNot(BeNil())
+			Expect(rule.Expr.String()).To(Equal(expr))
+			Expect(rule.For).To(Equal(duration))
+		},
+			Entry("for stuck migrations", "VirtualMachineMigrationStuck",
+				"(time() - (kubevirt_vm_migrating_status_last_transition_timestamp_seconds > 0)) > 3600", "5m"),
+			Entry("for VMs failing to start", "VirtualMachineStartFailure",
+				"(time() - (kubevirt_vm_error_status_last_transition_timestamp_seconds > 0)) > 300", "5m"),
+			Entry("for missing guest agents", "VirtualMachineInstanceGuestAgentMissing",
+				"kubevirt_vmi_guest_agent_connected == 0", "30m"),
+			Entry("for storage latency", "VirtualMachineInstanceStorageLatencyHigh",
+				"(rate(kubevirt_vmi_storage_read_times_ms_total[5m]) + rate(kubevirt_vmi_storage_write_times_ms_total[5m])) / (rate(kubevirt_vmi_storage_iops_read_total[5m]) + rate(kubevirt_vmi_storage_iops_write_total[5m])) > 100", "10m"),
+			Entry("for outdated launchers", "OutdatedVirtualMachineInstanceWorkloads",
+				"kubevirt_vmi_outdated_count > 0", "1440m"),
+		)
+
+		It("should not ship the outdated launchers alert without workload updates", func() {
+			Expect(findAlert(NewPrometheusRuleSpec("mynamespace", false, nil), "OutdatedVirtualMachineInstanceWorkloads")).To(BeNil())
+		})
+
+		It("should drop disabled alerts", func() {
+			alerting := &virtv1.AlertingConfiguration{
+				Rules: []virtv1.AlertRuleConfiguration{
+					{Alert: "VirtualMachineStartFailure", Disabled: true},
+					{Alert: "VirtAPIDown", Disabled: true},
+				},
+			}
+			spec := NewPrometheusRuleSpec("mynamespace", true, alerting)
+			Expect(findAlert(spec, "VirtualMachineStartFailure")).To(BeNil())
+			Expect(findAlert(spec, "VirtAPIDown")).To(BeNil())
+			Expect(findAlert(spec, "VirtualMachineMigrationStuck")).ToNot(BeNil())
+			Expect(spec.Groups[0].Rules).To(HaveLen(len(NewPrometheusRuleSpec("mynamespace", true, nil).Groups[0].Rules) - 2))
+		})
+
+		It("should override thresholds and durations", func() {
+			threshold := resource.MustParse("1500m")
+			outdatedThreshold := resource.MustParse("10")
+			alerting := &virtv1.AlertingConfiguration{
+				Rules: []virtv1.AlertRuleConfiguration{
+					{Alert: "VirtualMachineMigrationStuck", Threshold: &threshold, For: &metav1.Duration{Duration: 90 * time.Minute}},
+					{Alert: "OutdatedVirtualMachineInstanceWorkloads", Threshold: &outdatedThreshold},
+					{Alert: "VirtAPIDown", For: &metav1.Duration{Duration: 30 * time.Second}},
+				},
+			}
+			spec := NewPrometheusRuleSpec("mynamespace", true, alerting)
+
+			rule := findAlert(spec, "VirtualMachineMigrationStuck")
+			Expect(rule.Expr.String()).To(Equal("(time() - (kubevirt_vm_migrating_status_last_transition_timestamp_seconds > 0)) > 1.5"))
+			Expect(rule.For).To(Equal("1h30m"))
+
+			rule = findAlert(spec, "OutdatedVirtualMachineInstanceWorkloads")
+			Expect(rule.Expr.String()).To(Equal("kubevirt_vmi_outdated_count > 10"))
+			Expect(rule.For).To(Equal("1440m"))
+
+			rule = findAlert(spec, "VirtAPIDown")
+			Expect(rule.Expr.String()).To(Equal("kubevirt_virt_api_up_total == 0"))
+			Expect(rule.For).To(Equal("30s"))
+		})
+
+		It("should keep the common labels on configured alerts", func() {
+			threshold := resource.MustParse("60")
+			alerting := &virtv1.AlertingConfiguration{
+				Rules: []virtv1.AlertRuleConfiguration{
+					{Alert: "VirtualMachineStartFailure", Threshold: &threshold},
+				},
+			}
+			rule := findAlert(NewPrometheusRuleSpec("mynamespace", true, alerting), "VirtualMachineStartFailure")
+			Expect(rule.Labels).To(HaveKeyWithValue(partOfAlertLabelKey, partOfAlertLabelValue))
+			Expect(rule.Labels).To(HaveKeyWithValue(componentAlertLabelKey, componentAlertLabelValue))
+		})
+	})
 })
@@ -656,6 +656,86 @@ var CRDsValidation map[string]string = map[string]string{
       type: object
     spec:
       properties:
+        alerting:
+          description: Alerting allows to disable and tune the alerting rules deployed
+            alongside KubeVirt
+          properties:
+            rules:
+              description: Rules overrides the defaults of individual alerting rules,
+                identified by their alert name
+              items:
+                description: AlertRuleConfiguration overrides the defaults of a single
+                  alerting rule
+                properties:
+                  alert:
+                    description: Alert is the name of the alert, e.g. VirtualMachineStartFailure
+                    type: string
+                  disabled:
+                    description: Disabled removes the alert from the deployed PrometheusRule
+                    type: boolean
+                  for:
+                    description: For replaces the duration the expression of the alert
+                      needs to hold before the alert fires
+                    type: string
+                  threshold:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: Threshold replaces the value the expression of the
+                      alert is compared against. It is ignored for alerts without
+                      a configurable threshold.
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                required:
+                - alert
+                type: object
+              type: array
+              x-kubernetes-list-map-keys:
+              - alert
+              x-kubernetes-list-type: map
+          type: object
+        canaryUpgrade:
+          description: 'CanaryUpgrade stages updates of KubeVirt: the new virt-handler
+            is rolled out to a subset of the nodes first and only selected workloads
+            are updated, before the rollout continues on all nodes.'
+          properties:
+            failurePolicy:
+              description: "FailurePolicy defines what happens once the failures exceed
+                the threshold. Pause holds the rollout, Rollback returns all KubeVirt
+                components to the previously installed version. Any change of the
+                KubeVirt spec restarts a paused or rolled back canary stage. \n Defaults
+                to Pause"
+              type: string
+            failureThreshold:
+              description: "FailureThreshold is the number of failures tolerated during
+                the canary stage. Crash looping canary virt-handler pods, canary virt-handlers
+                which stop being ready or stop sending heartbeats while soaking, and
+                failed workload update migrations count as failures. \n Defaults to
+                0"
+              format: int32
+              type: integer
+            namespaces:
+              description: Namespaces lists the namespaces whose outdated VMIs are
+                live migrated to the canary nodes while the canary soaks. It requires
+                LiveMigrate to be one of the workload update methods. The control
+                plane is updated once the canary virt-handlers are ready.
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: atomic
+            nodeSelector:
+              additionalProperties:
+                type: string
+              description: NodeSelector selects the canary nodes, which receive the
+                new virt-handler first
+              type: object
+            soakPeriod:
+              description: "SoakPeriod is how long the canary is watched before the
+                rollout continues on all nodes \n Defaults to 1 hour"
+              type: string
+          required:
+          - nodeSelector
+          type: object
         certificateRotateStrategy:
           properties:
             selfSigned:
@@ -1065,6 +1145,13 @@ var CRDsValidation map[string]string = map[string]string{
               type: object
             machineType:
               type: string
+            maxCPUWeight:
+              description: MaxCPUWeight is the highest CPU weight a VirtualMachineInstance
+                may request in spec.domain.cpu.weight, in cgroup v2 cpu.weight units.
+                Defaults to 100, the weight of a pod without a CPU request. Requires
+                the CPUWeight feature gate.
+              format: int64
+              type: integer
             mediatedDevicesConfiguration:
               description: MediatedDevicesConfiguration holds information about MDEV
                 types to be defined, if available
@@ -1263,6 +1350,49 @@ var CRDsValidation map[string]string = map[string]string{
                     type: object
                   type: array
                   x-kubernetes-list-type: atomic
+                usb:
+                  items:
+                    description: USBHostDevice represents a set of host USB devices
+                      allowed for passthrough
+                    properties:
+                      externalResourceProvider:
+                        description: If true, KubeVirt will leave the allocation and
+                          monitoring to an external device plugin
+                        type: boolean
+                      resourceName:
+                        description: 'Identifies the list of USB host devices. e.g:
+                          kubevirt.io/storage, kubevirt.io/bootable-usb, etc'
+                        type: string
+                      selectors:
+                        description: Each USB host device matching any of the selectors
+                          is exposed as an allocatable device of the resource.
+                        items:
+                          description: USBSelector identifies one or more host USB
+                            devices
+                          properties:
+                            busPath:
+                              description: BusPath optionally restricts the selector
+                                to the device plugged into a specific port, as named
+                                under /sys/bus/usb/devices, e.g. 1-1.2
+                              type: string
+                            product:
+                              description: The product ID of the USB device, e.g.
+                                c52b
+                              type: string
+                            vendor:
+                              description: The vendor ID of the USB device, e.g. 046d
+                              type: string
+                          required:
+                          - product
+                          - vendor
+                          type: object
+                        type: array
+                        x-kubernetes-list-type: atomic
+                    required:
+                    - resourceName
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
               type: object
             seccompConfiguration:
               description: SeccompConfiguration holds Seccomp configuration for Kubevirt
@@ -1395,6 +1525,37 @@ var CRDsValidation map[string]string = map[string]string{
                   - VersionTLS13
                   type: string
               type: object
+            tracing:
+              description: Tracing enables OpenTelemetry tracing of the VirtualMachineInstance
+                lifecycle.
+              properties:
+                file:
+                  description: File appends spans, encoded as OTLP JSON, to a file
+                    on the local filesystem of each component. It is meant for debugging
+                    and testing without a collector.
+                  properties:
+                    path:
+                      description: Path is the file the spans are appended to.
+                      type: string
+                  required:
+                  - path
+                  type: object
+                otlp:
+                  description: OTLP exports spans to an OpenTelemetry collector using
+                    OTLP over HTTP.
+                  properties:
+                    endpoint:
+                      description: Endpoint is the base URL of the collector, for
+                        example http://otel-collector.monitoring:4318. Spans are sent
+                        to <endpoint>/v1/traces without credentials, from the KubeVirt
+                        components and from the virt-launcher pods. A collector which
+                        requires authentication has to be fronted by an in-cluster
+                        collector which adds the credentials.
+                      type: string
+                  required:
+                  - endpoint
+                  type: object
+              type: object
             virtualMachineInstancesPerNode:
               type: integer
             virtualMachineOptions:
@@ -3376,6 +3537,43 @@ var CRDsValidation map[string]string = map[string]string{
       description: KubeVirtStatus represents information pertaining to a KubeVirt
         deployment.
       properties:
+        canaryUpgrade:
+          description: KubeVirtCanaryUpgradeStatus reports the progress of a canary
+            upgrade
+          properties:
+            failures:
+              description: Failures is the number of failures observed during the
+                current canary stage
+              format: int32
+              type: integer
+            message:
+              type: string
+            observedGeneration:
+              description: ObservedGeneration is the generation of the KubeVirt CR
+                which paused or rolled back the canary
+              format: int64
+              type: integer
+            phase:
+              description: CanaryUpgradePhase is the stage of a canary upgrade
+              type: string
+            previousDeploymentConfig:
+              description: PreviousDeploymentConfig is the deployment config which
+                was installed when the canary upgrade started
+              type: string
+            soakStartTime:
+              description: SoakStartTime is when all canary virt-handlers became ready
+              format: date-time
+              type: string
+            startTime:
+              description: StartTime is when the current canary stage started, failed
+                migrations are counted from here
+              format: date-time
+              type: string
+            targetDeploymentID:
+              description: TargetDeploymentID identifies the install strategy which
+                is rolled out
+              type: string
+          type: object
         conditions:
           items:
             description: KubeVirtCondition represents a condition of a KubeVirt deployment
@@ -5416,6 +5614,14 @@ var CRDsValidation map[string]string = map[string]string{
                             to place the VirtualMachineInstance on a node with enough
                             dedicated pCPUs and pin the vCPUs to it.
                           type: boolean
+                        emulatorThreadPoolSize:
+                          description: EmulatorThreadPoolSize defines how many dedicated
+                            pCPUs are allocated for the emulator and IO threads when
+                            IsolateEmulatorThread is set. The IO threads of disks
+                            with a dedicated IO thread are spread over the pool. Defaults
+                            to 1.
+                          format: int32
+                          type: integer
                         features:
                           description: Features specifies the CPU features list inside
                             the VMI.
@@ -5487,11 +5693,29 @@ var CRDsValidation map[string]string = map[string]string{
                             the vmi. Must be a value greater or equal 1.
                           format: int32
                           type: integer
+                        softCPUPlacement:
+                          description: SoftCPUPlacement requests virt-handler to pin
+                            the vCPUs, the emulator threads and the memory of the
+                            VirtualMachineInstance to the least loaded NUMA node of
+                            the host, without requiring dedicated pCPUs. The placement
+                            is periodically rebalanced. Can't be combined with DedicatedCPUPlacement.
+                          type: boolean
                         threads:
                           description: Threads specifies the number of threads inside
                             the vmi. Must be a value greater or equal 1.
                           format: int32
                           type: integer
+                        weight:
+                          description: Weight sets the relative CPU weight of the
+                            VirtualMachineInstance, which is used to prioritise between
+                            VirtualMachineInstances competing for CPU time on the
+                            same node. The range is 1 to 10000, like cgroup v2 cpu.weight,
+                            capped by the cluster wide maxCPUWeight. On cgroup v1
+                            hosts it is converted to cpu.shares. Defaults to the weight
+                            derived from the CPU request. Requires the CPUWeight feature
+                            gate.
+                          format: int64
+                          type: integer
                       type: object
                     devices:
                       description: Devices allows adding disks, network interfaces,
@@ -6358,6 +6582,78 @@ var CRDsValidation map[string]string = map[string]string{
                     VirtualMachineInstance should be migrated instead of shut-off
                     in case of a node drain.
                   type: string
+                freezeHooks:
+                  description: FreezeHooks are commands run in the guest by the guest
+                    agent before its filesystems are frozen and after they are thawed,
+                    so that the applications of the guest are consistent while it
+                    is frozen
+                  properties:
+                    postThaw:
+                      description: PostThaw hooks are run in order after the filesystems
+                        are thawed. They are also run when the freeze is aborted by
+                        a failed pre-freeze hook
+                      items:
+                        description: GuestHook is a command run in the guest by the
+                          guest agent
+                        properties:
+                          command:
+                            description: Command is the path of the executable in
+                              the guest followed by its arguments
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                          failurePolicy:
+                            description: FailurePolicy defines what happens when the
+                              command fails or times out, defaults to Abort
+                            type: string
+                          name:
+                            description: Name identifies the hook in the status
+                            type: string
+                          timeoutSeconds:
+                            description: TimeoutSeconds is the time the command is
+                              given to exit, defaults to 10
+                            format: int32
+                            type: integer
+                        required:
+                        - command
+                        - name
+                        type: object
+                      type: array
+                      x-kubernetes-list-type: atomic
+                    preFreeze:
+                      description: PreFreeze hooks are run in order before the filesystems
+                        are frozen
+                      items:
+                        description: GuestHook is a command run in the guest by the
+                          guest agent
+                        properties:
+                          command:
+                            description: Command is the path of the executable in
+                              the guest followed by its arguments
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                          failurePolicy:
+                            description: FailurePolicy defines what happens when the
+                              command fails or times out, defaults to Abort
+                            type: string
+                          name:
+                            description: Name identifies the hook in the status
+                            type: string
+                          timeoutSeconds:
+                            description: TimeoutSeconds is the time the command is
+                              given to exit, defaults to 10
+                            format: int32
+                            type: integer
+                        required:
+                        - command
+                        - name
+                        type: object
+                      type: array
+                      x-kubernetes-list-type: atomic
+                  type: object
                 hostname:
                   description: Specifies the hostname of the vmi If not specified,
                     the hostname will be set to the name of the vmi, if dhcp or cloud-init
@@ -7217,6 +7513,9 @@ var CRDsValidation map[string]string = map[string]string{
                               in the same namespace as the pod using this volume.
                               More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
                             type: string
+                          format:
+                            description: Format of the memory dump, defaults to CoreDump
+                            type: string
                           hotpluggable:
                             description: Hotpluggable indicates whether the volume
                               can be hotplugged and hotunplugged.
@@ -7413,6 +7712,9 @@ var CRDsValidation map[string]string = map[string]string{
             fileName:
               description: FileName represents the name of the output file
               type: string
+            format:
+              description: Format of the memory dump, defaults to CoreDump
+              type: string
             message:
               description: Message is a detailed message about failure of the memory
                 dump
@@ -7444,6 +7746,60 @@ var CRDsValidation map[string]string = map[string]string{
         ready:
           description: Ready indicates if the virtual machine is running and ready
           type: boolean
+        resourceRecommendation:
+          description: ResourceRecommendation is the cluster instancetype matching
+            the resource usage observed while the VM was running
+          nullable: true
+          properties:
+            cpu:
+              description: CPU is the number of guest CPUs required to serve the observed
+                peak with headroom
+              format: int32
+              type: integer
+            instancetype:
+              description: Instancetype is the name of the recommended VirtualMachineClusterInstancetype
+              type: string
+            lastUpdateTime:
+              description: LastUpdateTime is when the recommendation was last computed
+              format: date-time
+              type: string
+            memory:
+              anyOf:
+              - type: integer
+              - type: string
+              description: Memory is the guest memory required to serve the observed
+                peak with headroom
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            peakCPU:
+              anyOf:
+              - type: integer
+              - type: string
+              description: PeakCPU is the highest number of CPUs used by the guest
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            peakMemory:
+              anyOf:
+              - type: integer
+              - type: string
+              description: PeakMemory is the highest amount of memory used by the
+                guest
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            reason:
+              description: Reason describes how the VM compares to the recommendation
+              type: string
+            samples:
+              description: Samples is the number of usage samples the recommendation
+                is based on
+              type: integer
+          required:
+          - cpu
+          - lastUpdateTime
+          - peakCPU
+          - reason
+          - samples
+          type: object
         restoreInProgress:
           description: RestoreInProgress is the name of the VirtualMachineRestore
             currently executing
@@ -7491,6 +7847,42 @@ var CRDsValidation map[string]string = map[string]string{
             - action
             type: object
           type: array
+        timeline:
+          description: Timeline holds the most recent lifecycle entries of the VirtualMachineInstances
+            started for this VM, so that they survive VirtualMachineInstance restarts.
+            The full, merged, timeline is available through the timeline subresource.
+          items:
+            description: VirtualMachineTimelineEntry is a single entry of the VirtualMachine
+              timeline
+            properties:
+              message:
+                description: Message is a human readable description of the entry
+                type: string
+              reason:
+                description: Reason is a short, machine understandable, description
+                  of the entry
+                type: string
+              source:
+                description: Source is the origin of the entry
+                type: string
+              timestamp:
+                description: Timestamp is when the entry happened
+                format: date-time
+                type: string
+              type:
+                description: Type is either Normal or Warning
+                type: string
+              vmiUID:
+                description: VMIUID is the UID of the VirtualMachineInstance the entry
+                  belongs to
+                type: string
+            required:
+            - reason
+            - source
+            - timestamp
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
         volumeRequests:
           description: VolumeRequests indicates a list of volumes add or remove from
             the VMI template and hotplug on an active running VMI.
@@ -8628,6 +9020,38 @@ var CRDsValidation map[string]string = map[string]string{
       description: VirtualMachineExportSpec is the spec for a VirtualMachineExport
         resource
       properties:
+        bandwidthLimit:
+          anyOf:
+          - type: integer
+          - type: string
+          description: BandwidthLimit limits the bytes per second served by the export
+            server, shared by all the downloads of the export. If this field is omitted,
+            the downloads are not throttled.
+          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+          x-kubernetes-int-or-string: true
+        containerDisk:
+          description: ContainerDisk pushes the exported volumes to an OCI registry
+            as containerDisk images
+          properties:
+            insecureSkipTLSVerify:
+              description: InsecureSkipTLSVerify skips the verification of the registry
+                certificate
+              type: boolean
+            repository:
+              description: Repository is the registry repository the volumes are pushed
+                to, e.g. registry.example.com/vms. Each volume is pushed as <repository>/<volume
+                name>:<tag>
+              type: string
+            secretRef:
+              description: SecretRef is the name of a kubernetes.io/dockerconfigjson
+                secret holding the registry credentials
+              type: string
+            tag:
+              description: Tag is the tag of the pushed images, defaults to latest
+              type: string
+          required:
+          - repository
+          type: object
         source:
           description: TypedLocalObjectReference contains enough information to let
             you locate the typed referenced object inside the same namespace.
@@ -8691,6 +9115,36 @@ var CRDsValidation map[string]string = map[string]string{
             type: object
           type: array
           x-kubernetes-list-type: atomic
+        containerDisks:
+          description: ContainerDisks tracks the push of the exported volumes to the
+            registry
+          items:
+            description: VirtualMachineExportContainerDisk contains the state of the
+              push of a volume to the registry
+            properties:
+              digest:
+                description: Digest is the digest of the pushed image manifest
+                type: string
+              image:
+                description: Image is the reference of the pushed image
+                type: string
+              message:
+                type: string
+              name:
+                description: Name is the name of the exported volume
+                type: string
+              phase:
+                description: ContainerDiskPushPhase is the phase of the push of a
+                  volume to the registry
+                type: string
+            required:
+            - image
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-map-keys:
+          - name
+          x-kubernetes-list-type: map
         links:
           description: VirtualMachineExportLinks contains the links that point the
             exported VM resources
@@ -8857,6 +9311,32 @@ var CRDsValidation map[string]string = map[string]string{
             This is mainly to easily identify the source VirtualMachine in case of
             a VirtualMachineSnapshot
           type: string
+        volumes:
+          description: Volumes reports the download progress of the exported volumes
+          items:
+            description: VirtualMachineExportVolumeProgress contains the download
+              progress of an exported volume
+            properties:
+              activeConnections:
+                description: ActiveConnections is the number of downloads of the volume
+                  in progress
+                format: int32
+                type: integer
+              bytesServed:
+                description: BytesServed is the number of bytes of the volume served
+                  by the export server
+                format: int64
+                type: integer
+              name:
+                description: Name is the name of the exported volume
+                type: string
+            required:
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-map-keys:
+          - name
+          x-kubernetes-list-type: map
       type: object
   required:
   - spec
@@ -9910,6 +10390,13 @@ var CRDsValidation map[string]string = map[string]string{
                     the VirtualMachineInstance on a node with enough dedicated pCPUs
                     and pin the vCPUs to it.
                   type: boolean
+                emulatorThreadPoolSize:
+                  description: EmulatorThreadPoolSize defines how many dedicated pCPUs
+                    are allocated for the emulator and IO threads when IsolateEmulatorThread
+                    is set. The IO threads of disks with a dedicated IO thread are
+                    spread over the pool. Defaults to 1.
+                  format: int32
+                  type: integer
                 features:
                   description: Features specifies the CPU features list inside the
                     VMI.
@@ -9978,11 +10465,27 @@ var CRDsValidation map[string]string = map[string]string{
                     vmi. Must be a value greater or equal 1.
                   format: int32
                   type: integer
+                softCPUPlacement:
+                  description: SoftCPUPlacement requests virt-handler to pin the vCPUs,
+                    the emulator threads and the memory of the VirtualMachineInstance
+                    to the least loaded NUMA node of the host, without requiring dedicated
+                    pCPUs. The placement is periodically rebalanced. Can't be combined
+                    with DedicatedCPUPlacement.
+                  type: boolean
                 threads:
                   description: Threads specifies the number of threads inside the
                     vmi. Must be a value greater or equal 1.
                   format: int32
                   type: integer
+                weight:
+                  description: Weight sets the relative CPU weight of the VirtualMachineInstance,
+                    which is used to prioritise between VirtualMachineInstances competing
+                    for CPU time on the same node. The range is 1 to 10000, like cgroup
+                    v2 cpu.weight, capped by the cluster wide maxCPUWeight. On cgroup
+                    v1 hosts it is converted to cpu.shares. Defaults to the weight
+                    derived from the CPU request. Requires the CPUWeight feature gate.
+                  format: int64
+                  type: integer
               type: object
             devices:
               description: Devices allows adding disks, network interfaces, and others
@@ -10789,6 +11292,77 @@ var CRDsValidation map[string]string = map[string]string{
           description: EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance
             should be migrated instead of shut-off in case of a node drain.
           type: string
+        freezeHooks:
+          description: FreezeHooks are commands run in the guest by the guest agent
+            before its filesystems are frozen and after they are thawed, so that the
+            applications of the guest are consistent while it is frozen
+          properties:
+            postThaw:
+              description: PostThaw hooks are run in order after the filesystems are
+                thawed. They are also run when the freeze is aborted by a failed pre-freeze
+                hook
+              items:
+                description: GuestHook is a command run in the guest by the guest
+                  agent
+                properties:
+                  command:
+                    description: Command is the path of the executable in the guest
+                      followed by its arguments
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  failurePolicy:
+                    description: FailurePolicy defines what happens when the command
+                      fails or times out, defaults to Abort
+                    type: string
+                  name:
+                    description: Name identifies the hook in the status
+                    type: string
+                  timeoutSeconds:
+                    description: TimeoutSeconds is the time the command is given to
+                      exit, defaults to 10
+                    format: int32
+                    type: integer
+                required:
+                - command
+                - name
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            preFreeze:
+              description: PreFreeze hooks are run in order before the filesystems
+                are frozen
+              items:
+                description: GuestHook is a command run in the guest by the guest
+                  agent
+                properties:
+                  command:
+                    description: Command is the path of the executable in the guest
+                      followed by its arguments
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  failurePolicy:
+                    description: FailurePolicy defines what happens when the command
+                      fails or times out, defaults to Abort
+                    type: string
+                  name:
+                    description: Name identifies the hook in the status
+                    type: string
+                  timeoutSeconds:
+                    description: TimeoutSeconds is the time the command is given to
+                      exit, defaults to 10
+                    format: int32
+                    type: integer
+                required:
+                - command
+                - name
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+          type: object
         hostname:
           description: Specifies the hostname of the vmi If not specified, the hostname
             will be set to the name of the vmi, if dhcp or cloud-init is configured
@@ -11602,6 +12176,9 @@ var CRDsValidation map[string]string = map[string]string{
                       in the same namespace as the pod using this volume. More info:
                       https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
                     type: string
+                  format:
+                    description: Format of the memory dump, defaults to CoreDump
+                    type: string
                   hotpluggable:
                     description: Hotpluggable indicates whether the volume can be
                       hotplugged and hotunplugged.
@@ -11764,6 +12341,42 @@ var CRDsValidation map[string]string = map[string]string{
             meant to be used by KubeVirt core components only and can't be set or
             modified by users.
           type: string
+        freezeHooks:
+          description: FreezeHooks is the outcome of the freeze hooks of the last
+            freeze and thaw of the guest
+          properties:
+            aborted:
+              description: Aborted is true when the last freeze was aborted by a failed
+                pre-freeze hook
+              type: boolean
+            message:
+              description: Message describes the failures of the hooks
+              type: string
+            postThawFailed:
+              description: PostThawFailed are the names of the post-thaw hooks that
+                failed on the last thaw
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: atomic
+            postThawTimestamp:
+              description: PostThawTimestamp is the time the post-thaw hooks of the
+                last thaw were run
+              format: date-time
+              type: string
+            preFreezeFailed:
+              description: PreFreezeFailed are the names of the pre-freeze hooks that
+                failed on the last freeze
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: atomic
+            preFreezeTimestamp:
+              description: PreFreezeTimestamp is the time the pre-freeze hooks of
+                the last freeze were run
+              format: date-time
+              type: string
+          type: object
         fsFreezeStatus:
           description: FSFreezeStatus is the state of the fs of the guest it can be
             either frozen or thawed
@@ -11842,6 +12455,24 @@ var CRDsValidation map[string]string = map[string]string{
               description: QEMU machine type is the actual chipset of the VirtualMachineInstance.
               type: string
           type: object
+        memoryDirtyRate:
+          description: MemoryDirtyRate is the guest memory dirty rate measured by
+            the last periodic sample. It is used to predict whether a live migration
+            of the VMI will converge.
+          properties:
+            bytesPerSecond:
+              description: BytesPerSecond is the amount of guest memory dirtied per
+                second
+              format: int64
+              type: integer
+            sampleTimestamp:
+              description: SampleTimestamp is the time the measurement was started
+              format: date-time
+              nullable: true
+              type: string
+          required:
+          - bytesPerSecond
+          type: object
         migrationMethod:
           description: 'Represents the method using which the vmi can be migrated:
             live migration or block migration'
@@ -12046,6 +12677,39 @@ var CRDsValidation map[string]string = map[string]string{
           description: A brief CamelCase message indicating details about why the
             VMI is in this state. e.g. 'NodeUnresponsive'
           type: string
+        resourceUsage:
+          description: ResourceUsage is the peak guest CPU and memory usage observed
+            over the sampling window. It is used to recommend a right-sized instancetype
+            for the owning VM.
+          properties:
+            peakCPU:
+              anyOf:
+              - type: integer
+              - type: string
+              description: PeakCPU is the highest number of CPUs used by the guest
+                between two consecutive samples
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            peakMemory:
+              anyOf:
+              - type: integer
+              - type: string
+              description: PeakMemory is the highest amount of memory used by the
+                guest, not counting reclaimable caches
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            samples:
+              description: Samples is the number of samples in the window
+              type: integer
+            windowStart:
+              description: WindowStart is the time of the oldest sample in the window
+              format: date-time
+              nullable: true
+              type: string
+          required:
+          - peakCPU
+          - samples
+          type: object
         runtimeUser:
           description: RuntimeUser is used to determine what user will be used in
             launcher
@@ -12561,6 +13225,13 @@ var CRDsValidation map[string]string = map[string]string{
                     the VirtualMachineInstance on a node with enough dedicated pCPUs
                     and pin the vCPUs to it.
                   type: boolean
+                emulatorThreadPoolSize:
+                  description: EmulatorThreadPoolSize defines how many dedicated pCPUs
+                    are allocated for the emulator and IO threads when IsolateEmulatorThread
+                    is set. The IO threads of disks with a dedicated IO thread are
+                    spread over the pool. Defaults to 1.
+                  format: int32
+                  type: integer
                 features:
                   description: Features specifies the CPU features list inside the
                     VMI.
@@ -12629,11 +13300,27 @@ var CRDsValidation map[string]string = map[string]string{
                     vmi. Must be a value greater or equal 1.
                   format: int32
                   type: integer
+                softCPUPlacement:
+                  description: SoftCPUPlacement requests virt-handler to pin the vCPUs,
+                    the emulator threads and the memory of the VirtualMachineInstance
+                    to the least loaded NUMA node of the host, without requiring dedicated
+                    pCPUs. The placement is periodically rebalanced. Can't be combined
+                    with DedicatedCPUPlacement.
+                  type: boolean
                 threads:
                   description: Threads specifies the number of threads inside the
                     vmi. Must be a value greater or equal 1.
                   format: int32
                   type: integer
+                weight:
+                  description: Weight sets the relative CPU weight of the VirtualMachineInstance,
+                    which is used to prioritise between VirtualMachineInstances competing
+                    for CPU time on the same node. The range is 1 to 10000, like cgroup
+                    v2 cpu.weight, capped by the cluster wide maxCPUWeight. On cgroup
+                    v1 hosts it is converted to cpu.shares. Defaults to the weight
+                    derived from the CPU request. Requires the CPUWeight feature gate.
+                  format: int64
+                  type: integer
               type: object
             devices:
               description: Devices allows adding disks, network interfaces, and others
@@ -14667,6 +15354,14 @@ var CRDsValidation map[string]string = map[string]string{
                             to place the VirtualMachineInstance on a node with enough
                             dedicated pCPUs and pin the vCPUs to it.
                           type: boolean
+                        emulatorThreadPoolSize:
+                          description: EmulatorThreadPoolSize defines how many dedicated
+                            pCPUs are allocated for the emulator and IO threads when
+                            IsolateEmulatorThread is set. The IO threads of disks
+                            with a dedicated IO thread are spread over the pool. Defaults
+                            to 1.
+                          format: int32
+                          type: integer
                         features:
                           description: Features specifies the CPU features list inside
                             the VMI.
@@ -14738,11 +15433,29 @@ var CRDsValidation map[string]string = map[string]string{
                             the vmi. Must be a value greater or equal 1.
                           format: int32
                           type: integer
+                        softCPUPlacement:
+                          description: SoftCPUPlacement requests virt-handler to pin
+                            the vCPUs, the emulator threads and the memory of the
+                            VirtualMachineInstance to the least loaded NUMA node of
+                            the host, without requiring dedicated pCPUs. The placement
+                            is periodically rebalanced. Can't be combined with DedicatedCPUPlacement.
+                          type: boolean
                         threads:
                           description: Threads specifies the number of threads inside
                             the vmi. Must be a value greater or equal 1.
                           format: int32
                           type: integer
+                        weight:
+                          description: Weight sets the relative CPU weight of the
+                            VirtualMachineInstance, which is used to prioritise between
+                            VirtualMachineInstances competing for CPU time on the
+                            same node. The range is 1 to 10000, like cgroup v2 cpu.weight,
+                            capped by the cluster wide maxCPUWeight. On cgroup v1
+                            hosts it is converted to cpu.shares. Defaults to the weight
+                            derived from the CPU request. Requires the CPUWeight feature
+                            gate.
+                          format: int64
+                          type: integer
                       type: object
                     devices:
                       description: Devices allows adding disks, network interfaces,
@@ -15609,6 +16322,78 @@ var CRDsValidation map[string]string = map[string]string{
                     VirtualMachineInstance should be migrated instead of shut-off
                     in case of a node drain.
                   type: string
+                freezeHooks:
+                  description: FreezeHooks are commands run in the guest by the guest
+                    agent before its filesystems are frozen and after they are thawed,
+                    so that the applications of the guest are consistent while it
+                    is frozen
+                  properties:
+                    postThaw:
+                      description: PostThaw hooks are run in order after the filesystems
+                        are thawed. They are also run when the freeze is aborted by
+                        a failed pre-freeze hook
+                      items:
+                        description: GuestHook is a command run in the guest by the
+                          guest agent
+                        properties:
+                          command:
+                            description: Command is the path of the executable in
+                              the guest followed by its arguments
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                          failurePolicy:
+                            description: FailurePolicy defines what happens when the
+                              command fails or times out, defaults to Abort
+                            type: string
+                          name:
+                            description: Name identifies the hook in the status
+                            type: string
+                          timeoutSeconds:
+                            description: TimeoutSeconds is the time the command is
+                              given to exit, defaults to 10
+                            format: int32
+                            type: integer
+                        required:
+                        - command
+                        - name
+                        type: object
+                      type: array
+                      x-kubernetes-list-type: atomic
+                    preFreeze:
+                      description: PreFreeze hooks are run in order before the filesystems
+                        are frozen
+                      items:
+                        description: GuestHook is a command run in the guest by the
+                          guest agent
+                        properties:
+                          command:
+                            description: Command is the path of the executable in
+                              the guest followed by its arguments
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                          failurePolicy:
+                            description: FailurePolicy defines what happens when the
+                              command fails or times out, defaults to Abort
+                            type: string
+                          name:
+                            description: Name identifies the hook in the status
+                            type: string
+                          timeoutSeconds:
+                            description: TimeoutSeconds is the time the command is
+                              given to exit, defaults to 10
+                            format: int32
+                            type: integer
+                        required:
+                        - command
+                        - name
+                        type: object
+                      type: array
+                      x-kubernetes-list-type: atomic
+                  type: object
                 hostname:
                   description: Specifies the hostname of the vmi If not specified,
                     the hostname will be set to the name of the vmi, if dhcp or cloud-init
@@ -16468,6 +17253,9 @@ var CRDsValidation map[string]string = map[string]string{
                               in the same namespace as the pod using this volume.
                               More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
                             type: string
+                          format:
+                            description: Format of the memory dump, defaults to CoreDump
+                            type: string
                           hotpluggable:
                             description: Hotpluggable indicates whether the volume
                               can be hotplugged and hotunplugged.
@@ -18902,6 +19690,14 @@ var CRDsValidation map[string]string = map[string]string{
                                     on a node with enough dedicated pCPUs and pin
                                     the vCPUs to it.
                                   type: boolean
+                                emulatorThreadPoolSize:
+                                  description: EmulatorThreadPoolSize defines how
+                                    many dedicated pCPUs are allocated for the emulator
+                                    and IO threads when IsolateEmulatorThread is set.
+                                    The IO threads of disks with a dedicated IO thread
+                                    are spread over the pool. Defaults to 1.
+                                  format: int32
+                                  type: integer
                                 features:
                                   description: Features specifies the CPU features
                                     list inside the VMI.
@@ -18981,12 +19777,32 @@ var CRDsValidation map[string]string = map[string]string{
                                     1.
                                   format: int32
                                   type: integer
+                                softCPUPlacement:
+                                  description: SoftCPUPlacement requests virt-handler
+                                    to pin the vCPUs, the emulator threads and the
+                                    memory of the VirtualMachineInstance to the least
+                                    loaded NUMA node of the host, without requiring
+                                    dedicated pCPUs. The placement is periodically
+                                    rebalanced. Can't be combined with DedicatedCPUPlacement.
+                                  type: boolean
                                 threads:
                                   description: Threads specifies the number of threads
                                     inside the vmi. Must be a value greater or equal
                                     1.
                                   format: int32
                                   type: integer
+                                weight:
+                                  description: Weight sets the relative CPU weight
+                                    of the VirtualMachineInstance, which is used to
+                                    prioritise between VirtualMachineInstances competing
+                                    for CPU time on the same node. The range is 1
+                                    to 10000, like cgroup v2 cpu.weight, capped by
+                                    the cluster wide maxCPUWeight. On cgroup v1 hosts
+                                    it is converted to cpu.shares. Defaults to the
+                                    weight derived from the CPU request. Requires
+                                    the CPUWeight feature gate.
+                                  format: int64
+                                  type: integer
                               type: object
                             devices:
                               description: Devices allows adding disks, network interfaces,
@@ -19906,6 +20722,80 @@ var CRDsValidation map[string]string = map[string]string{
                             if the VirtualMachineInstance should be migrated instead
                             of shut-off in case of a node drain.
                           type: string
+                        freezeHooks:
+                          description: FreezeHooks are commands run in the guest by
+                            the guest agent before its filesystems are frozen and
+                            after they are thawed, so that the applications of the
+                            guest are consistent while it is frozen
+                          properties:
+                            postThaw:
+                              description: PostThaw hooks are run in order after the
+                                filesystems are thawed. They are also run when the
+                                freeze is aborted by a failed pre-freeze hook
+                              items:
+                                description: GuestHook is a command run in the guest
+                                  by the guest agent
+                                properties:
+                                  command:
+                                    description: Command is the path of the executable
+                                      in the guest followed by its arguments
+                                    items:
+                                      type: string
+                                    type: array
+                                    x-kubernetes-list-type: atomic
+                                  failurePolicy:
+                                    description: FailurePolicy defines what happens
+                                      when the command fails or times out, defaults
+                                      to Abort
+                                    type: string
+                                  name:
+                                    description: Name identifies the hook in the status
+                                    type: string
+                                  timeoutSeconds:
+                                    description: TimeoutSeconds is the time the command
+                                      is given to exit, defaults to 10
+                                    format: int32
+                                    type: integer
+                                required:
+                                - command
+                                - name
+                                type: object
+                              type: array
+                              x-kubernetes-list-type: atomic
+                            preFreeze:
+                              description: PreFreeze hooks are run in order before
+                                the filesystems are frozen
+                              items:
+                                description: GuestHook is a command run in the guest
+                                  by the guest agent
+                                properties:
+                                  command:
+                                    description: Command is the path of the executable
+                                      in the guest followed by its arguments
+                                    items:
+                                      type: string
+                                    type: array
+                                    x-kubernetes-list-type: atomic
+                                  failurePolicy:
+                                    description: FailurePolicy defines what happens
+                                      when the command fails or times out, defaults
+                                      to Abort
+                                    type: string
+                                  name:
+                                    description: Name identifies the hook in the status
+                                    type: string
+                                  timeoutSeconds:
+                                    description: TimeoutSeconds is the time the command
+                                      is given to exit, defaults to 10
+                                    format: int32
+                                    type: integer
+                                required:
+                                - command
+                                - name
+                                type: object
+                              type: array
+                              x-kubernetes-list-type: atomic
+                          type: object
                         hostname:
                           description: Specifies the hostname of the vmi If not specified,
                             the hostname will be set to the name of the vmi, if dhcp
@@ -20824,6 +21714,10 @@ var CRDsValidation map[string]string = map[string]string{
                                       in the same namespace as the pod using this
                                       volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
                                     type: string
+                                  format:
+                                    description: Format of the memory dump, defaults
+                                      to CoreDump
+                                    type: string
                                   hotpluggable:
                                     description: Hotpluggable indicates whether the
                                       volume can be hotplugged and hotunplugged.
@@ -21561,6 +22455,52 @@ var CRDsValidation map[string]string = map[string]string{
     spec:
       description: VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource
       properties:
+        excludeVolumes:
+          description: ExcludeVolumes is the list of volumes not to restore. Volumes
+            that are not restored keep their current disks
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        includeVolumes:
+          description: IncludeVolumes is the list of volumes to restore, all the volumes
+            of the snapshot are restored if empty. Volumes that are not restored keep
+            their current disks
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        mode:
+          description: Mode defines what is restored from the snapshot, defaults to
+            Full
+          type: string
+        newVirtualMachine:
+          description: NewVirtualMachine holds the options applied to the target when
+            it does not exist and is created from the snapshot, so that it can run
+            next to the source
+          properties:
+            newMacAddresses:
+              additionalProperties:
+                type: string
+              description: NewMacAddresses manually sets that target interfaces' mac
+                addresses. The key is the interface name and the value is the new
+                mac address. If this field is not specified, a new MAC address will
+                be generated automatically, as for any interface that is not included
+                in this map.
+              type: object
+            newSMBiosSerial:
+              description: NewSMBiosSerial manually sets that target's SMbios serial.
+                If this field is not specified, a new serial will be generated automatically.
+              type: string
+            newVolumeNames:
+              additionalProperties:
+                type: string
+              description: NewVolumeNames manually sets the names of the restored
+                PVCs and DataVolumes. The key is the volume name and the value is
+                the new name. Restored volumes that are not included in this map get
+                a generated name.
+              type: object
+          type: object
         patches:
           description: "If the target for the restore does not exist, it will be created.
             Patches holds JSON patches that would be applied to the target manifest
@@ -21684,6 +22624,12 @@ var CRDsValidation map[string]string = map[string]string{
             snapshot to take. In case we pass this deadline we mark this snapshot
             as failed. Defaults to DefaultFailureDeadline - 5min
           type: string
+        includeMemory:
+          description: IncludeMemory indicates that the memory and device state of
+            a running VM should be saved along with its volumes, so that a restored
+            VM resumes from the snapshot instead of booting. The VM is paused while
+            the memory state is saved and the volume snapshots are taken.
+          type: boolean
         source:
           description: TypedLocalObjectReference contains enough information to let
             you locate the typed referenced object inside the same namespace.
@@ -21807,6 +22753,17 @@ var CRDsValidation map[string]string = map[string]string{
       description: VirtualMachineSnapshotContentSpec is the spec for a VirtualMachineSnapshotContent
         resource
       properties:
+        memoryState:
+          description: MemoryStateBackup contains the saved memory and device state
+            of the VM
+          properties:
+            persistentVolumeClaimName:
+              description: PersistentVolumeClaimName is the name of the pvc the memory
+                state is saved to
+              type: string
+          required:
+          - persistentVolumeClaimName
+          type: object
         source:
           description: SourceSpec contains the appropriate spec for the resource being
             snapshotted
@@ -23951,6 +24908,15 @@ var CRDsValidation map[string]string = map[string]string{
                                         on a node with enough dedicated pCPUs and
                                         pin the vCPUs to it.
                                       type: boolean
+                                    emulatorThreadPoolSize:
+                                      description: EmulatorThreadPoolSize defines
+                                        how many dedicated pCPUs are allocated for
+                                        the emulator and IO threads when IsolateEmulatorThread
+                                        is set. The IO threads of disks with a dedicated
+                                        IO thread are spread over the pool. Defaults
+                                        to 1.
+                                      format: int32
+                                      type: integer
                                     features:
                                       description: Features specifies the CPU features
                                         list inside the VMI.
@@ -24032,12 +24998,33 @@ var CRDsValidation map[string]string = map[string]string{
                                         or equal 1.
                                       format: int32
                                       type: integer
+                                    softCPUPlacement:
+                                      description: SoftCPUPlacement requests virt-handler
+                                        to pin the vCPUs, the emulator threads and
+                                        the memory of the VirtualMachineInstance to
+                                        the least loaded NUMA node of the host, without
+                                        requiring dedicated pCPUs. The placement is
+                                        periodically rebalanced. Can't be combined
+                                        with DedicatedCPUPlacement.
+                                      type: boolean
                                     threads:
                                       description: Threads specifies the number of
                                         threads inside the vmi. Must be a value greater
                                         or equal 1.
                                       format: int32
                                       type: integer
+                                    weight:
+                                      description: Weight sets the relative CPU weight
+                                        of the VirtualMachineInstance, which is used
+                                        to prioritise between VirtualMachineInstances
+                                        competing for CPU time on the same node. The
+                                        range is 1 to 10000, like cgroup v2 cpu.weight,
+                                        capped by the cluster wide maxCPUWeight. On
+                                        cgroup v1 hosts it is converted to cpu.shares.
+                                        Defaults to the weight derived from the CPU
+                                        request. Requires the CPUWeight feature gate.
+                                      format: int64
+                                      type: integer
                                   type: object
                                 devices:
                                   description: Devices allows adding disks, network
@@ -24990,6 +25977,83 @@ var CRDsValidation map[string]string = map[string]string{
                                 if the VirtualMachineInstance should be migrated instead
                                 of shut-off in case of a node drain.
                               type: string
+                            freezeHooks:
+                              description: FreezeHooks are commands run in the guest
+                                by the guest agent before its filesystems are frozen
+                                and after they are thawed, so that the applications
+                                of the guest are consistent while it is frozen
+                              properties:
+                                postThaw:
+                                  description: PostThaw hooks are run in order after
+                                    the filesystems are thawed. They are also run
+                                    when the freeze is aborted by a failed pre-freeze
+                                    hook
+                                  items:
+                                    description: GuestHook is a command run in the
+                                      guest by the guest agent
+                                    properties:
+                                      command:
+                                        description: Command is the path of the executable
+                                          in the guest followed by its arguments
+                                        items:
+                                          type: string
+                                        type: array
+                                        x-kubernetes-list-type: atomic
+                                      failurePolicy:
+                                        description: FailurePolicy defines what happens
+                                          when the command fails or times out, defaults
+                                          to Abort
+                                        type: string
+                                      name:
+                                        description: Name identifies the hook in the
+                                          status
+                                        type: string
+                                      timeoutSeconds:
+                                        description: TimeoutSeconds is the time the
+                                          command is given to exit, defaults to 10
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - command
+                                    - name
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
+                                preFreeze:
+                                  description: PreFreeze hooks are run in order before
+                                    the filesystems are frozen
+                                  items:
+                                    description: GuestHook is a command run in the
+                                      guest by the guest agent
+                                    properties:
+                                      command:
+                                        description: Command is the path of the executable
+                                          in the guest followed by its arguments
+                                        items:
+                                          type: string
+                                        type: array
+                                        x-kubernetes-list-type: atomic
+                                      failurePolicy:
+                                        description: FailurePolicy defines what happens
+                                          when the command fails or times out, defaults
+                                          to Abort
+                                        type: string
+                                      name:
+                                        description: Name identifies the hook in the
+                                          status
+                                        type: string
+                                      timeoutSeconds:
+                                        description: TimeoutSeconds is the time the
+                                          command is given to exit, defaults to 10
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - command
+                                    - name
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
+                              type: object
                             hostname:
                               description: Specifies the hostname of the vmi If not
                                 specified, the hostname will be set to the name of
@@ -25946,6 +27010,10 @@ var CRDsValidation map[string]string = map[string]string{
                                           in the same namespace as the pod using this
                                           volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
                                         type: string
+                                      format:
+                                        description: Format of the memory dump, defaults
+                                          to CoreDump
+                                        type: string
                                       hotpluggable:
                                         description: Hotpluggable indicates whether
                                           the volume can be hotplugged and hotunplugged.
@@ -26161,6 +27229,9 @@ var CRDsValidation map[string]string = map[string]string{
                           description: FileName represents the name of the output
                             file
                           type: string
+                        format:
+                          description: Format of the memory dump, defaults to CoreDump
+                          type: string
                         message:
                           description: Message is a detailed message about failure
                             of the memory dump
@@ -26194,6 +27265,64 @@ var CRDsValidation map[string]string = map[string]string{
                       description: Ready indicates if the virtual machine is running
                         and ready
                       type: boolean
+                    resourceRecommendation:
+                      description: ResourceRecommendation is the cluster instancetype
+                        matching the resource usage observed while the VM was running
+                      nullable: true
+                      properties:
+                        cpu:
+                          description: CPU is the number of guest CPUs required to
+                            serve the observed peak with headroom
+                          format: int32
+                          type: integer
+                        instancetype:
+                          description: Instancetype is the name of the recommended
+                            VirtualMachineClusterInstancetype
+                          type: string
+                        lastUpdateTime:
+                          description: LastUpdateTime is when the recommendation was
+                            last computed
+                          format: date-time
+                          type: string
+                        memory:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: Memory is the guest memory required to serve
+                            the observed peak with headroom
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
+                        peakCPU:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: PeakCPU is the highest number of CPUs used
+                            by the guest
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
+                        peakMemory:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: PeakMemory is the highest amount of memory
+                            used by the guest
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
+                        reason:
+                          description: Reason describes how the VM compares to the
+                            recommendation
+                          type: string
+                        samples:
+                          description: Samples is the number of usage samples the
+                            recommendation is based on
+                          type: integer
+                      required:
+                      - cpu
+                      - lastUpdateTime
+                      - peakCPU
+                      - reason
+                      - samples
+                      type: object
                     restoreInProgress:
                       description: RestoreInProgress is the name of the VirtualMachineRestore
                         currently executing
@@ -26244,6 +27373,44 @@ var CRDsValidation map[string]string = map[string]string{
                         - action
                         type: object
                       type: array
+                    timeline:
+                      description: Timeline holds the most recent lifecycle entries
+                        of the VirtualMachineInstances started for this VM, so that
+                        they survive VirtualMachineInstance restarts. The full, merged,
+                        timeline is available through the timeline subresource.
+                      items:
+                        description: VirtualMachineTimelineEntry is a single entry
+                          of the VirtualMachine timeline
+                        properties:
+                          message:
+                            description: Message is a human readable description of
+                              the entry
+                            type: string
+                          reason:
+                            description: Reason is a short, machine understandable,
+                              description of the entry
+                            type: string
+                          source:
+                            description: Source is the origin of the entry
+                            type: string
+                          timestamp:
+                            description: Timestamp is when the entry happened
+                            format: date-time
+                            type: string
+                          type:
+                            description: Type is either Normal or Warning
+                            type: string
+                          vmiUID:
+                            description: VMIUID is the UID of the VirtualMachineInstance
+                              the entry belongs to
+                            type: string
+                        required:
+                        - reason
+                        - source
+                        - timestamp
+                        type: object
+                      type: array
+                      x-kubernetes-list-type: atomic
                     volumeRequests:
                       description: VolumeRequests indicates a list of volumes add
                         or remove from the VMI template and hotplug on an active running
@@ -41,6 +41,9 @@ const (
 	VMInstancesGuestOSInfo = "virtualmachineinstances/guestosinfo"
 	VMInstancesFileSysList = "virtualmachineinstances/filesystemlist"
 	VMInstancesUserList    = "virtualmachineinstances/userlist"
+	VMTimeline             = "virtualmachines/timeline"
+	VMInstancetypeDiff     = "virtualmachines/instancetypediff"
+	VMInstancetypeUpgrade  = "virtualmachines/instancetypeupgrade"
 )
 
 func GetAllCluster() []runtime.Object {
@@ -177,6 +180,8 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachines/expand-spec",
 					"virtualmachines/portforward",
+					VMTimeline,
+					VMInstancetypeDiff,
 				},
 				Verbs: []string{
 					"get",
@@ -195,6 +200,7 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/migrate",
 					"virtualmachines/memorydump",
 					"virtualmachines/addinterface",
+					VMInstancetypeUpgrade,
 				},
 				Verbs: []string{
 					"update",
@@ -250,6 +256,17 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
 				},
 			},
+			{
+				APIGroups: []string{
+					GroupNameExport,
+				},
+				Resources: []string{
+					"virtualmachineexports/download",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
 			{
 				APIGroups: []string{
 					GroupNameClone,
@@ -357,6 +374,8 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachines/expand-spec",
 					"virtualmachines/portforward",
+					VMTimeline,
+					VMInstancetypeDiff,
 				},
 				Verbs: []string{
 					"get",
@@ -375,6 +394,7 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/migrate",
 					"virtualmachines/memorydump",
 					"virtualmachines/addinterface",
+					VMInstancetypeUpgrade,
 				},
 				Verbs: []string{
 					"update",
@@ -430,6 +450,17 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					GroupNameExport,
+				},
+				Resources: []string{
+					"virtualmachineexports/download",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
 			{
 				APIGroups: []string{
 					GroupNameClone,
@@ -512,6 +543,8 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachines/expand-spec",
+					VMTimeline,
+					VMInstancetypeDiff,
 					VMInstancesGuestOSInfo,
 					VMInstancesFileSysList,
 					VMInstancesUserList,
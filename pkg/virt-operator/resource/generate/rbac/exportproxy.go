@@ -34,6 +34,7 @@ func GetAllExportProxy(namespace string) []runtime.Object {
 		newExportProxyServiceAccount(namespace),
 		newExportProxyClusterRole(),
 		newExportProxyClusterRoleBinding(namespace),
+		newExportProxyAuthDelegatorClusterRoleBinding(namespace),
 		newExportProxyRole(namespace),
 		newExportProxyRoleBinding(namespace),
 	}
@@ -122,6 +123,34 @@ func newExportProxyClusterRoleBinding(namespace string) *rbacv1.ClusterRoleBindi
 	}
 }
 
+// newExportProxyAuthDelegatorClusterRoleBinding allows the proxy to review the bearer tokens of download requests
+func newExportProxyAuthDelegatorClusterRoleBinding(namespace string) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kubevirt-exportproxy-auth-delegator",
+			Labels: map[string]string{
+				virtv1.AppLabel: "",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "system:auth-delegator",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Namespace: namespace,
+				Name:      ExportProxyServiceAccountName,
+			},
+		},
+	}
+}
+
 func newExportProxyRole(namespace string) *rbacv1.Role {
 	return &rbacv1.Role{
 		TypeMeta: metav1.TypeMeta{
@@ -150,6 +179,20 @@ func newExportProxyRole(namespace string) *rbacv1.Role {
 					"kubevirt-export-ca",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"secrets",
+				},
+				Verbs: []string{
+					"get",
+				},
+				ResourceNames: []string{
+					"kubevirt-export-proxy-key",
+				},
+			},
 		},
 	}
 }
@@ -101,6 +101,9 @@ const (
 	// lookup key in AdditionalProperties
 	AdditionalPropertiesPersistentReservationEnabled = "PersistentReservationEnabled"
 
+	// lookup key in AdditionalProperties
+	AdditionalPropertiesAlerting = "Alerting"
+
 	// account to use if one is not explicitly named
 	DefaultMonitorAccount = "prometheus-k8s"
 
@@ -252,6 +255,10 @@ func getKVMapFromSpec(spec v1.KubeVirtSpec) map[string]string {
 			// these are handled in the root deployment config already
 			continue
 		}
+		if name == "CanaryUpgrade" {
+			// only affects how the install strategy is rolled out, not its content
+			continue
+		}
 		if name == "ImagePullSecrets" {
 			value, err := json.Marshal(v.Field(i).Interface())
 			if err != nil {
@@ -261,6 +268,18 @@ func getKVMapFromSpec(spec v1.KubeVirtSpec) map[string]string {
 			}
 			continue
 		}
+		if name == AdditionalPropertiesAlerting {
+			// only present when set, to keep the install strategy of unconfigured deployments unchanged
+			if !v.Field(i).IsNil() {
+				value, err := json.Marshal(v.Field(i).Interface())
+				if err != nil {
+					fmt.Printf("Cannot encode Alerting to JSON %v", err)
+				} else {
+					kvMap[name] = string(value)
+				}
+			}
+			continue
+		}
 		value := v.Field(i).String()
 		kvMap[name] = value
 	}
@@ -583,6 +602,29 @@ func (c *KubeVirtDeploymentConfig) SetObservedDeploymentConfig(kv *v1.KubeVirt)
 	return err
 }
 
+// CanaryRolledBack returns true while a failed canary upgrade returns all components to the
+// deployment config it started from. Any change of the KubeVirt spec ends the rollback.
+func CanaryRolledBack(kv *v1.KubeVirt) bool {
+	status := kv.Status.CanaryUpgrade
+	return kv.Spec.CanaryUpgrade != nil && status != nil &&
+		status.Phase == v1.CanaryUpgradePhaseRolledBack &&
+		status.ObservedGeneration == kv.Generation &&
+		status.PreviousDeploymentConfig != ""
+}
+
+// GetCanaryRollbackConfig returns the deployment config a rolled back canary upgrade of the target config
+// returns to, or nil if the target is not rolled back
+func GetCanaryRollbackConfig(kv *v1.KubeVirt, target *KubeVirtDeploymentConfig) (*KubeVirtDeploymentConfig, error) {
+	if !CanaryRolledBack(kv) || kv.Status.CanaryUpgrade.TargetDeploymentID != target.GetDeploymentID() {
+		return nil, nil
+	}
+	previous := &KubeVirtDeploymentConfig{}
+	if err := json.Unmarshal([]byte(kv.Status.CanaryUpgrade.PreviousDeploymentConfig), previous); err != nil {
+		return nil, fmt.Errorf("unable to parse the previous deployment config of the canary upgrade: %v", err)
+	}
+	return previous, nil
+}
+
 func (c *KubeVirtDeploymentConfig) GetImagePullPolicy() k8sv1.PullPolicy {
 	p := c.AdditionalProperties[AdditionalPropertiesNamePullPolicy]
 	if p != "" {
@@ -612,6 +654,19 @@ func (c *KubeVirtDeploymentConfig) WorkloadUpdatesEnabled() bool {
 	return enabled
 }
 
+func (c *KubeVirtDeploymentConfig) GetAlertingConfiguration() *v1.AlertingConfiguration {
+	s, ok := c.AdditionalProperties[AdditionalPropertiesAlerting]
+	if !ok {
+		return nil
+	}
+	alerting := &v1.AlertingConfiguration{}
+	if err := json.Unmarshal([]byte(s), alerting); err != nil {
+		fmt.Printf("Unable to parse alerting configuration: %v\n", err)
+		return nil
+	}
+	return alerting
+}
+
 func (c *KubeVirtDeploymentConfig) PersistentReservationEnabled() bool {
 	_, enabled := c.AdditionalProperties[AdditionalPropertiesPersistentReservationEnabled]
 	return enabled
@@ -22,12 +22,17 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
+
+	v1 "kubevirt.io/api/core/v1"
 )
 
 var _ = Describe("Operator Config", func() {
@@ -295,6 +300,78 @@ var _ = Describe("Operator Config", func() {
 
 	})
 
+	Describe("alerting configuration", func() {
+		It("should not be part of the config if not set on the KubeVirt CR", func() {
+			config := GetTargetConfigFromKVWithEnvVarManager(&v1.KubeVirt{}, envVarManager)
+			Expect(config.AdditionalProperties).ToNot(HaveKey(AdditionalPropertiesAlerting))
+			Expect(config.GetAlertingConfiguration()).To(BeNil())
+		})
+
+		It("should be passed through and change the config ID", func() {
+			threshold := resource.MustParse("120")
+			alerting := &v1.AlertingConfiguration{
+				Rules: []v1.AlertRuleConfiguration{
+					{Alert: "VirtualMachineStartFailure", Disabled: true},
+					{Alert: "VirtualMachineMigrationStuck", Threshold: &threshold, For: &metav1.Duration{Duration: 5 * time.Minute}},
+				},
+			}
+			kv := &v1.KubeVirt{Spec: v1.KubeVirtSpec{Alerting: alerting}}
+
+			config := GetTargetConfigFromKVWithEnvVarManager(kv, envVarManager)
+			Expect(config.GetAlertingConfiguration()).To(Equal(alerting))
+			Expect(config.GetDeploymentID()).ToNot(Equal(GetTargetConfigFromKVWithEnvVarManager(&v1.KubeVirt{}, envVarManager).GetDeploymentID()))
+		})
+	})
+
+	Describe("canary rollback", func() {
+		var kv *v1.KubeVirt
+		var target *KubeVirtDeploymentConfig
+		var previous *KubeVirtDeploymentConfig
+
+		BeforeEach(func() {
+			kv = &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec: v1.KubeVirtSpec{
+					ImageTag:      "v1.1.0",
+					CanaryUpgrade: &v1.KubeVirtCanaryUpgradeStrategy{},
+				},
+			}
+			target = GetTargetConfigFromKVWithEnvVarManager(kv, envVarManager)
+			previous = GetTargetConfigFromKVWithEnvVarManager(&v1.KubeVirt{Spec: v1.KubeVirtSpec{ImageTag: "v1.0.0"}}, envVarManager)
+			previousJson, err := previous.GetJson()
+			Expect(err).ToNot(HaveOccurred())
+			kv.Status.CanaryUpgrade = &v1.KubeVirtCanaryUpgradeStatus{
+				Phase:                    v1.CanaryUpgradePhaseRolledBack,
+				TargetDeploymentID:       target.GetDeploymentID(),
+				ObservedGeneration:       2,
+				PreviousDeploymentConfig: previousJson,
+			}
+		})
+
+		It("should return the config the canary upgrade started from", func() {
+			config, err := GetCanaryRollbackConfig(kv, target)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).ToNot(BeNil())
+			Expect(config.GetKubeVirtVersion()).To(Equal("v1.0.0"))
+			Expect(config.GetDeploymentID()).To(Equal(previous.GetDeploymentID()))
+		})
+
+		It("should end the rollback once the KubeVirt spec changed", func() {
+			kv.Generation = 3
+			Expect(GetCanaryRollbackConfig(kv, target)).To(BeNil())
+		})
+
+		It("should not roll back another target", func() {
+			other := GetTargetConfigFromKVWithEnvVarManager(&v1.KubeVirt{Spec: v1.KubeVirtSpec{ImageTag: "v1.2.0"}}, envVarManager)
+			Expect(GetCanaryRollbackConfig(kv, other)).To(BeNil())
+		})
+
+		It("should not roll back a paused canary upgrade", func() {
+			kv.Status.CanaryUpgrade.Phase = v1.CanaryUpgradePhasePaused
+			Expect(GetCanaryRollbackConfig(kv, target)).To(BeNil())
+		})
+	})
+
 	Context("Product Names and Versions", func() {
 		DescribeTable("label validation", func(testVector string, expectedResult bool) {
 			Expect(IsValidLabel(testVector)).To(Equal(expectedResult))
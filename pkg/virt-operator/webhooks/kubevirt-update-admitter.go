@@ -38,6 +38,7 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/api/core/v1"
@@ -78,6 +79,7 @@ func (admitter *KubeVirtUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *
 	results = append(results, validateCustomizeComponents(newKV.Spec.CustomizeComponents)...)
 	results = append(results, validateCertificates(newKV.Spec.CertificateRotationStrategy.SelfSigned)...)
 	results = append(results, validateGuestToRequestHeadroom(newKV.Spec.Configuration.AdditionalGuestMemoryOverheadRatio)...)
+	results = append(results, validateMaxCPUWeight(field.NewPath("spec", "configuration", "maxCPUWeight"), newKV.Spec.Configuration.MaxCPUWeight)...)
 
 	if !equality.Semantic.DeepEqual(currKV.Spec.Configuration.TLSConfiguration, newKV.Spec.Configuration.TLSConfiguration) {
 		if newKV.Spec.Configuration.TLSConfiguration != nil {
@@ -110,6 +112,11 @@ func (admitter *KubeVirtUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *
 		results = append(results, validateInfraReplicas(newKV.Spec.Infra.Replicas)...)
 	}
 
+	if newKV.Spec.CanaryUpgrade != nil {
+		results = append(results,
+			validateCanaryUpgrade(field.NewPath("spec", "canaryUpgrade"), newKV.Spec.CanaryUpgrade, newKV.Spec.WorkloadUpdateStrategy)...)
+	}
+
 	response := validating_webhooks.NewAdmissionResponse(results)
 
 	if featureGatesChanged(&currKV.Spec, &newKV.Spec) {
@@ -485,3 +492,79 @@ func validateGuestToRequestHeadroom(ratioStrPtr *string) (causes []metav1.Status
 
 	return
 }
+
+func validateMaxCPUWeight(field *field.Path, maxCPUWeight *uint64) (causes []metav1.StatusCause) {
+	const (
+		minWeight = 1
+		maxWeight = 10000
+	)
+	if maxCPUWeight != nil && (*maxCPUWeight < minWeight || *maxCPUWeight > maxWeight) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be in the range of %d to %d", field.String(), minWeight, maxWeight),
+			Field:   field.String(),
+		})
+	}
+	return causes
+}
+
+func validateCanaryUpgrade(canaryField *field.Path, canary *v1.KubeVirtCanaryUpgradeStrategy, workloadUpdateStrategy v1.KubeVirtWorkloadUpdateStrategy) (causes []metav1.StatusCause) {
+	invalid := func(path *field.Path, format string, args ...interface{}) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s %s", path.String(), fmt.Sprintf(format, args...)),
+			Field:   path.String(),
+		})
+	}
+
+	nodeSelectorField := canaryField.Child("nodeSelector")
+	if len(canary.NodeSelector) == 0 {
+		invalid(nodeSelectorField, "must select the canary nodes")
+	}
+	for key, value := range canary.NodeSelector {
+		for _, msg := range k8svalidation.IsQualifiedName(key) {
+			invalid(nodeSelectorField.Key(key), "is not a valid label key: %s", msg)
+		}
+		for _, msg := range k8svalidation.IsValidLabelValue(value) {
+			invalid(nodeSelectorField.Key(key), "is not a valid label value: %s", msg)
+		}
+	}
+
+	for i, namespace := range canary.Namespaces {
+		for _, msg := range k8svalidation.IsDNS1123Label(namespace) {
+			invalid(canaryField.Child("namespaces").Index(i), "is not a valid namespace name: %s", msg)
+		}
+	}
+	if len(canary.Namespaces) > 0 && !hasWorkloadUpdateMethod(workloadUpdateStrategy, v1.WorkloadUpdateMethodLiveMigrate) {
+		invalid(canaryField.Child("namespaces"), "requires the %s workload update method", v1.WorkloadUpdateMethodLiveMigrate)
+	}
+
+	if canary.SoakPeriod != nil && canary.SoakPeriod.Duration < 0 {
+		invalid(canaryField.Child("soakPeriod"), "must not be negative")
+	}
+	if canary.FailureThreshold != nil && *canary.FailureThreshold < 0 {
+		invalid(canaryField.Child("failureThreshold"), "must not be negative")
+	}
+
+	switch canary.FailurePolicy {
+	case "", v1.CanaryUpgradeFailurePolicyPause, v1.CanaryUpgradeFailurePolicyRollback:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s %s is not supported, supported values are %s and %s", canaryField.Child("failurePolicy").String(),
+				canary.FailurePolicy, v1.CanaryUpgradeFailurePolicyPause, v1.CanaryUpgradeFailurePolicyRollback),
+			Field: canaryField.Child("failurePolicy").String(),
+		})
+	}
+
+	return causes
+}
+
+func hasWorkloadUpdateMethod(strategy v1.KubeVirtWorkloadUpdateStrategy, method v1.WorkloadUpdateMethod) bool {
+	for _, m := range strategy.WorkloadUpdateMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
@@ -23,6 +23,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -162,6 +163,68 @@ var _ = Describe("Validating KubeVirtUpdate Admitter", func() {
 		)
 	})
 
+	DescribeTable("validateMaxCPUWeight", func(maxCPUWeight *uint64, expectedCauses int) {
+		causes := validateMaxCPUWeight(test.Child("maxCPUWeight"), maxCPUWeight)
+		Expect(causes).To(HaveLen(expectedCauses))
+		for _, cause := range causes {
+			Expect(cause.Field).To(Equal("test.maxCPUWeight"))
+		}
+	},
+		Entry("unset", nil, 0),
+		Entry("minimum", pointer.Uint64(1), 0),
+		Entry("maximum", pointer.Uint64(10000), 0),
+		Entry("zero", pointer.Uint64(0), 1),
+		Entry("above the cgroup maximum", pointer.Uint64(10001), 1),
+	)
+
+	Context("validateCanaryUpgrade", func() {
+		liveMigrate := v1.KubeVirtWorkloadUpdateStrategy{
+			WorkloadUpdateMethods: []v1.WorkloadUpdateMethod{v1.WorkloadUpdateMethodLiveMigrate},
+		}
+		canaryField := test.Child("canaryUpgrade")
+
+		It("should accept a valid canary upgrade", func() {
+			canary := &v1.KubeVirtCanaryUpgradeStrategy{
+				NodeSelector:     map[string]string{"kubevirt.io/canary": "true"},
+				Namespaces:       []string{"canary-workloads"},
+				SoakPeriod:       &metav1.Duration{Duration: time.Hour},
+				FailureThreshold: pointer.Int32(2),
+				FailurePolicy:    v1.CanaryUpgradeFailurePolicyRollback,
+			}
+			Expect(validateCanaryUpgrade(canaryField, canary, liveMigrate)).To(BeEmpty())
+		})
+
+		DescribeTable("should reject", func(canary *v1.KubeVirtCanaryUpgradeStrategy, strategy v1.KubeVirtWorkloadUpdateStrategy, expectedField string) {
+			causes := validateCanaryUpgrade(canaryField, canary, strategy)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(expectedField))
+		},
+			Entry("an empty node selector",
+				&v1.KubeVirtCanaryUpgradeStrategy{}, liveMigrate, "test.canaryUpgrade.nodeSelector"),
+			Entry("an invalid node selector key",
+				&v1.KubeVirtCanaryUpgradeStrategy{NodeSelector: map[string]string{"not a/valid/key": "true"}}, liveMigrate,
+				"test.canaryUpgrade.nodeSelector[not a/valid/key]"),
+			Entry("an invalid node selector value",
+				&v1.KubeVirtCanaryUpgradeStrategy{NodeSelector: map[string]string{"canary": "not valid"}}, liveMigrate,
+				"test.canaryUpgrade.nodeSelector[canary]"),
+			Entry("an invalid namespace",
+				&v1.KubeVirtCanaryUpgradeStrategy{NodeSelector: map[string]string{"canary": "true"}, Namespaces: []string{"Invalid_NS"}}, liveMigrate,
+				"test.canaryUpgrade.namespaces[0]"),
+			Entry("namespaces without the LiveMigrate workload update method",
+				&v1.KubeVirtCanaryUpgradeStrategy{NodeSelector: map[string]string{"canary": "true"}, Namespaces: []string{"default"}}, v1.KubeVirtWorkloadUpdateStrategy{},
+				"test.canaryUpgrade.namespaces"),
+			Entry("a negative soak period",
+				&v1.KubeVirtCanaryUpgradeStrategy{NodeSelector: map[string]string{"canary": "true"}, SoakPeriod: &metav1.Duration{Duration: -time.Minute}}, liveMigrate,
+				"test.canaryUpgrade.soakPeriod"),
+			Entry("a negative failure threshold",
+				&v1.KubeVirtCanaryUpgradeStrategy{NodeSelector: map[string]string{"canary": "true"}, FailureThreshold: pointer.Int32(-1)}, liveMigrate,
+				"test.canaryUpgrade.failureThreshold"),
+			Entry("an unknown failure policy",
+				&v1.KubeVirtCanaryUpgradeStrategy{NodeSelector: map[string]string{"canary": "true"}, FailurePolicy: "Ignore"}, liveMigrate,
+				"test.canaryUpgrade.failurePolicy"),
+		)
+	})
+
 	Context("deprecations", func() {
 		var admitter *KubeVirtUpdateAdmitter
 
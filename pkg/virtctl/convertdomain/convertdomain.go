@@ -0,0 +1,98 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package convertdomain
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+	"kubevirt.io/kubevirt/pkg/vmimport"
+)
+
+const (
+	COMMAND_CONVERT_DOMAIN = "convert-domain"
+
+	DiskSizeFlag = "disk-size"
+)
+
+type command struct {
+	diskSize string
+}
+
+// NewCommand returns a cobra.Command converting a libvirt domain XML or an OVF descriptor to a VirtualMachine manifest
+func NewCommand() *cobra.Command {
+	c := command{diskSize: vmimport.DefaultDiskSize}
+	cmd := &cobra.Command{
+		Use:   "convert-domain (FILE)",
+		Short: "Convert a libvirt domain XML or an OVF descriptor to a VirtualMachine manifest.",
+		Long: `Convert a libvirt domain XML or an OVF descriptor to a VirtualMachine manifest.
+
+The disks of the VirtualMachine are backed by DataVolumes waiting for the content of the original disks to be uploaded.
+Parts of the definition which cannot be represented in the VirtualMachine are reported as warnings.`,
+		Args:    templates.ExactArgs(COMMAND_CONVERT_DOMAIN, 1),
+		Example: usage(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args[0])
+		},
+	}
+	cmd.Flags().StringVar(&c.diskSize, DiskSizeFlag, c.diskSize, "The size of the DataVolumes of the disks whose capacity is not part of the definition, like the disks of a libvirt domain.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Convert the XML of a libvirt domain:
+  virsh dumpxml mydomain > mydomain.xml
+  {{ProgramName}} convert-domain mydomain.xml --disk-size=20Gi > myvm.yaml
+
+  # Convert the OVF descriptor of an OVA:
+  {{ProgramName}} convert-domain myvm.ovf > myvm.yaml`
+}
+
+func (c *command) run(cmd *cobra.Command, file string) error {
+	diskSize, err := resource.ParseQuantity(c.diskSize)
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %v", DiskSizeFlag, err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	result, err := vmimport.Convert(data, vmimport.Options{DiskSize: diskSize})
+	if err != nil {
+		return err
+	}
+	for _, unsupported := range result.Unsupported {
+		cmd.PrintErrf("Warning: %s\n", unsupported)
+	}
+
+	out, err := yaml.Marshal(result.VirtualMachine)
+	if err != nil {
+		return err
+	}
+	cmd.Print(string(out))
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package convertdomain_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "kubevirt.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/convertdomain"
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+const domainXML = `<domain type='kvm'>
+  <name>myvm</name>
+  <memory unit='MiB'>1024</memory>
+  <vcpu>2</vcpu>
+  <devices>
+    <disk type='file' device='disk'>
+      <source file='/var/lib/libvirt/images/myvm.qcow2'/>
+      <target dev='vda' bus='virtio'/>
+    </disk>
+    <hostdev mode='subsystem' type='pci'/>
+  </devices>
+</domain>`
+
+var _ = Describe("convert-domain", func() {
+	var file string
+
+	BeforeEach(func() {
+		file = filepath.Join(GinkgoT().TempDir(), "domain.xml")
+		Expect(os.WriteFile(file, []byte(domainXML), 0644)).To(Succeed())
+	})
+
+	It("should print the VirtualMachine manifest", func() {
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut(convertdomain.COMMAND_CONVERT_DOMAIN, file, fmt.Sprintf("--%s=20Gi", convertdomain.DiskSizeFlag))()
+		Expect(err).ToNot(HaveOccurred())
+
+		vm := &v1.VirtualMachine{}
+		Expect(yaml.Unmarshal(out, vm)).To(Succeed())
+		Expect(vm.Name).To(Equal("myvm"))
+		Expect(vm.Spec.Template.Spec.Domain.CPU.Sockets).To(Equal(uint32(2)))
+		Expect(vm.Spec.Template.Spec.Domain.Memory.Guest.String()).To(Equal("1Gi"))
+		Expect(vm.Spec.DataVolumeTemplates).To(HaveLen(1))
+		Expect(vm.Spec.DataVolumeTemplates[0].Spec.Storage.Resources.Requests.Storage().String()).To(Equal("20Gi"))
+	})
+
+	It("should fail on an invalid disk size", func() {
+		err := clientcmd.NewRepeatableVirtctlCommand(convertdomain.COMMAND_CONVERT_DOMAIN, file, fmt.Sprintf("--%s=big", convertdomain.DiskSizeFlag))()
+		Expect(err).To(MatchError(ContainSubstring("invalid --disk-size")))
+	})
+
+	It("should fail on a missing file", func() {
+		err := clientcmd.NewRepeatableVirtctlCommand(convertdomain.COMMAND_CONVERT_DOMAIN, filepath.Join(GinkgoT().TempDir(), "missing.xml"))()
+		Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+	})
+
+	It("should require the file argument", func() {
+		err := clientcmd.NewRepeatableVirtctlCommand(convertdomain.COMMAND_CONVERT_DOMAIN)()
+		Expect(err).To(MatchError(ContainSubstring("argument validation failed")))
+	})
+})
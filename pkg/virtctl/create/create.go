@@ -25,6 +25,8 @@ import (
 	"kubevirt.io/kubevirt/pkg/virtctl/create/clone"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/create/instancetype"
+	"kubevirt.io/kubevirt/pkg/virtctl/create/migrationpolicy"
+	"kubevirt.io/kubevirt/pkg/virtctl/create/pool"
 	"kubevirt.io/kubevirt/pkg/virtctl/create/preference"
 	"kubevirt.io/kubevirt/pkg/virtctl/create/vm"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
@@ -47,6 +49,8 @@ func NewCommand() *cobra.Command {
 	cmd.AddCommand(preference.NewCommand())
 	cmd.AddCommand(instancetype.NewCommand())
 	cmd.AddCommand(clone.NewCommand())
+	cmd.AddCommand(pool.NewCommand())
+	cmd.AddCommand(migrationpolicy.NewCommand())
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 
 	return cmd
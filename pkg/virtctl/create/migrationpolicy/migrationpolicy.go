@@ -0,0 +1,177 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package migrationpolicy
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	migrationsv1 "kubevirt.io/api/migrations/v1alpha1"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/create/params"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	MigrationPolicy = "migration-policy"
+
+	NameFlag                    = "name"
+	NamespaceSelectorFlag       = "namespace-selector"
+	VMISelectorFlag             = "vmi-selector"
+	BandwidthFlag               = "bandwidth"
+	AllowAutoConvergeFlag       = "allow-auto-converge"
+	AllowPostCopyFlag           = "allow-post-copy"
+	CompletionTimeoutPerGiBFlag = "completion-timeout-per-gib"
+)
+
+type createMigrationPolicy struct {
+	name                    string
+	namespaceSelector       map[string]string
+	vmiSelector             map[string]string
+	bandwidth               string
+	allowAutoConverge       bool
+	allowPostCopy           bool
+	completionTimeoutPerGiB int64
+}
+
+type optionFn func(*createMigrationPolicy, *migrationsv1.MigrationPolicySpec) error
+
+var optFns = map[string]optionFn{
+	BandwidthFlag:               withBandwidth,
+	AllowAutoConvergeFlag:       withAllowAutoConverge,
+	AllowPostCopyFlag:           withAllowPostCopy,
+	CompletionTimeoutPerGiBFlag: withCompletionTimeoutPerGiB,
+}
+
+func NewCommand() *cobra.Command {
+	c := createMigrationPolicy{}
+	cmd := &cobra.Command{
+		Use:     MigrationPolicy,
+		Short:   "Create a MigrationPolicy manifest.",
+		Long:    "Create a MigrationPolicy manifest.\n\nThe policy applies to all VirtualMachineInstances matching both the namespace and the VirtualMachineInstance selector.\nMigration settings which are not specified are taken from the cluster wide migration configuration.",
+		Args:    cobra.NoArgs,
+		Example: c.usage(),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.run(cmd)
+		},
+	}
+
+	cmd.Flags().StringVar(&c.name, NameFlag, c.name, "Specify the name of the MigrationPolicy.")
+	cmd.Flags().StringToStringVar(&c.namespaceSelector, NamespaceSelectorFlag, c.namespaceSelector, "Specify the labels of the namespaces the policy applies to, e.g. key1=value1,key2=value2. Can be provided multiple times.")
+	cmd.Flags().StringToStringVar(&c.vmiSelector, VMISelectorFlag, c.vmiSelector, "Specify the labels of the VirtualMachineInstances the policy applies to, e.g. key1=value1,key2=value2. Can be provided multiple times.")
+	cmd.Flags().StringVar(&c.bandwidth, BandwidthFlag, c.bandwidth, "Specify the bandwidth limit of each migration, e.g. 64Mi.")
+	cmd.Flags().BoolVar(&c.allowAutoConverge, AllowAutoConvergeFlag, c.allowAutoConverge, "Specify whether the guest CPU may be throttled to let the migration converge.")
+	cmd.Flags().BoolVar(&c.allowPostCopy, AllowPostCopyFlag, c.allowPostCopy, "Specify whether the migration may switch to post-copy mode.")
+	cmd.Flags().Int64Var(&c.completionTimeoutPerGiB, CompletionTimeoutPerGiBFlag, c.completionTimeoutPerGiB, "Specify the time in seconds per GiB of guest memory after which a migration is canceled.")
+
+	cmd.Flags().SortFlags = false
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+
+	return cmd
+}
+
+func (c *createMigrationPolicy) usage() string {
+	return `  # Create a manifest for a MigrationPolicy with a random name applying to the VirtualMachineInstances with a label:
+  {{ProgramName}} create migration-policy --vmi-selector=workload=database
+
+  # Create a manifest for a MigrationPolicy limiting the bandwidth of migrations in labeled namespaces:
+  {{ProgramName}} create migration-policy --name=my-policy --namespace-selector=tier=production --bandwidth=128Mi
+
+  # Create a manifest for a MigrationPolicy allowing busy VirtualMachineInstances to converge:
+  {{ProgramName}} create migration-policy --vmi-selector=busy=true --allow-auto-converge --allow-post-copy --completion-timeout-per-gib=300`
+}
+
+func (c *createMigrationPolicy) run(cmd *cobra.Command) error {
+	if c.name == "" {
+		c.name = "migration-policy-" + rand.String(5)
+	}
+
+	if len(c.namespaceSelector) == 0 && len(c.vmiSelector) == 0 {
+		return fmt.Errorf("at least one of --%s or --%s must be specified", NamespaceSelectorFlag, VMISelectorFlag)
+	}
+
+	policy := &migrationsv1.MigrationPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       migrationsv1.MigrationPolicyKind.Kind,
+			APIVersion: migrationsv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: c.name,
+		},
+		Spec: migrationsv1.MigrationPolicySpec{
+			Selectors: &migrationsv1.Selectors{
+				NamespaceSelector:              c.namespaceSelector,
+				VirtualMachineInstanceSelector: c.vmiSelector,
+			},
+		},
+	}
+
+	for flag, fn := range optFns {
+		if cmd.Flags().Changed(flag) {
+			if err := fn(c, &policy.Spec); err != nil {
+				return err
+			}
+		}
+	}
+
+	out, err := yaml.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	cmd.Print(string(out))
+	return nil
+}
+
+func withBandwidth(c *createMigrationPolicy, spec *migrationsv1.MigrationPolicySpec) error {
+	bandwidth, err := resource.ParseQuantity(c.bandwidth)
+	if err != nil {
+		return params.FlagErr(BandwidthFlag, "%w", err)
+	}
+	if bandwidth.Sign() < 0 {
+		return params.FlagErr(BandwidthFlag, "bandwidth must not be negative")
+	}
+
+	spec.BandwidthPerMigration = &bandwidth
+	return nil
+}
+
+func withAllowAutoConverge(c *createMigrationPolicy, spec *migrationsv1.MigrationPolicySpec) error {
+	spec.AllowAutoConverge = &c.allowAutoConverge
+	return nil
+}
+
+func withAllowPostCopy(c *createMigrationPolicy, spec *migrationsv1.MigrationPolicySpec) error {
+	spec.AllowPostCopy = &c.allowPostCopy
+	return nil
+}
+
+func withCompletionTimeoutPerGiB(c *createMigrationPolicy, spec *migrationsv1.MigrationPolicySpec) error {
+	if c.completionTimeoutPerGiB <= 0 {
+		return params.FlagErr(CompletionTimeoutPerGiBFlag, "completion timeout must be greater than 0")
+	}
+
+	spec.CompletionTimeoutPerGiB = &c.completionTimeoutPerGiB
+	return nil
+}
@@ -0,0 +1,85 @@
+package migrationpolicy_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	migrationsv1 "kubevirt.io/api/migrations/v1alpha1"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/kubevirt/tests/clientcmd"
+
+	. "kubevirt.io/kubevirt/pkg/virtctl/create/migrationpolicy"
+)
+
+const create = "create"
+
+var _ = Describe("create migration-policy", func() {
+	Context("Manifest is created successfully", func() {
+		It("MigrationPolicy with random name and selectors", func() {
+			out, err := runCmd(
+				setFlag(NamespaceSelectorFlag, "tier=production"),
+				setFlag(VMISelectorFlag, "workload=database,size=large"),
+				setFlag(VMISelectorFlag, "zone=a"),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			policy := unmarshalPolicy(out)
+
+			Expect(policy.Name).To(MatchRegexp("migration-policy-[a-z0-9]{5}"))
+			Expect(policy.Spec.Selectors.NamespaceSelector).To(Equal(migrationsv1.LabelSelector{"tier": "production"}))
+			Expect(policy.Spec.Selectors.VirtualMachineInstanceSelector).To(Equal(migrationsv1.LabelSelector{"workload": "database", "size": "large", "zone": "a"}))
+			Expect(policy.Spec.BandwidthPerMigration).To(BeNil())
+			Expect(policy.Spec.AllowAutoConverge).To(BeNil())
+			Expect(policy.Spec.AllowPostCopy).To(BeNil())
+			Expect(policy.Spec.CompletionTimeoutPerGiB).To(BeNil())
+		})
+
+		It("MigrationPolicy with migration settings", func() {
+			out, err := runCmd(
+				setFlag(NameFlag, "my-policy"),
+				setFlag(VMISelectorFlag, "busy=true"),
+				setFlag(BandwidthFlag, "128Mi"),
+				setFlag(AllowAutoConvergeFlag, "true"),
+				setFlag(AllowPostCopyFlag, "false"),
+				setFlag(CompletionTimeoutPerGiBFlag, "300"),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			policy := unmarshalPolicy(out)
+
+			Expect(policy.Name).To(Equal("my-policy"))
+			Expect(policy.Spec.BandwidthPerMigration.String()).To(Equal("128Mi"))
+			Expect(*policy.Spec.AllowAutoConverge).To(BeTrue())
+			Expect(*policy.Spec.AllowPostCopy).To(BeFalse())
+			Expect(*policy.Spec.CompletionTimeoutPerGiB).To(Equal(int64(300)))
+		})
+	})
+
+	Describe("Manifest is not created successfully", func() {
+		DescribeTable("Invalid arguments", func(errMsg string, flags ...string) {
+			out, err := runCmd(flags...)
+			Expect(err).To(MatchError(errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Missing selectors", "at least one of --namespace-selector or --vmi-selector must be specified", setFlag(BandwidthFlag, "128Mi")),
+			Entry("Invalid bandwidth", "failed to parse \"--bandwidth\" flag: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'", setFlag(VMISelectorFlag, "a=b"), setFlag(BandwidthFlag, "abc")),
+			Entry("Negative bandwidth", "failed to parse \"--bandwidth\" flag: bandwidth must not be negative", setFlag(VMISelectorFlag, "a=b"), setFlag(BandwidthFlag, "-1Mi")),
+			Entry("Invalid completion timeout", "failed to parse \"--completion-timeout-per-gib\" flag: completion timeout must be greater than 0", setFlag(VMISelectorFlag, "a=b"), setFlag(CompletionTimeoutPerGiBFlag, "0")),
+		)
+	})
+})
+
+func setFlag(flag, parameter string) string {
+	return "--" + flag + "=" + parameter
+}
+
+func runCmd(args ...string) ([]byte, error) {
+	_args := append([]string{create, MigrationPolicy}, args...)
+	return clientcmd.NewRepeatableVirtctlCommandWithOut(_args...)()
+}
+
+func unmarshalPolicy(bytes []byte) *migrationsv1.MigrationPolicy {
+	policy := &migrationsv1.MigrationPolicy{}
+	Expect(yaml.Unmarshal(bytes, policy)).To(Succeed())
+	Expect(policy.Kind).To(Equal(migrationsv1.MigrationPolicyKind.Kind))
+	Expect(policy.APIVersion).To(Equal(migrationsv1.SchemeGroupVersion.String()))
+	return policy
+}
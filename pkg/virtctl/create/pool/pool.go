@@ -0,0 +1,204 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package pool
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	v1 "kubevirt.io/api/core/v1"
+	poolv1 "kubevirt.io/api/pool/v1alpha1"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/create/params"
+	"kubevirt.io/kubevirt/pkg/virtctl/create/vm"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	Pool = "pool"
+
+	NameFlag     = "name"
+	ReplicasFlag = "replicas"
+	VMFileFlag   = "vm-file"
+
+	poolLabel                   = "kubevirt.io/vmpool"
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+type createPool struct {
+	name     string
+	replicas int32
+	vmFile   string
+
+	vmFlags *vm.VMFlags
+}
+
+func NewCommand() *cobra.Command {
+	c := createPool{
+		replicas: 1,
+	}
+	cmd := &cobra.Command{
+		Use:     Pool,
+		Short:   "Create a VirtualMachinePool manifest.",
+		Long:    "Create a VirtualMachinePool manifest.\n\nThe VirtualMachine template of the pool is either read from an existing VirtualMachine manifest\nor created from the same flags as used by create vm.",
+		Args:    cobra.NoArgs,
+		Example: c.usage(),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.run(cmd)
+		},
+	}
+
+	cmd.Flags().StringVar(&c.name, NameFlag, c.name, "Specify the name of the VirtualMachinePool.")
+	cmd.Flags().Int32Var(&c.replicas, ReplicasFlag, c.replicas, "Specify the number of VirtualMachines in the pool.")
+	cmd.Flags().StringVar(&c.vmFile, VMFileFlag, c.vmFile, "Specify a file containing the VirtualMachine manifest to be used as template of the pool, use - to read it from stdin.")
+	c.vmFlags = vm.AddVMFlags(cmd)
+
+	cmd.Flags().SortFlags = false
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+
+	return cmd
+}
+
+func (c *createPool) usage() string {
+	return `  # Create a manifest for a VirtualMachinePool with a random name and an ephemeral containerdisk volume:
+  {{ProgramName}} create pool --volume-containerdisk=src:my.registry/my-image:my-tag
+
+  # Create a manifest for a VirtualMachinePool with three replicas, a specified instancetype and cloned DataSource:
+  {{ProgramName}} create pool --name=my-pool --replicas=3 --instancetype=my-instancetype --volume-datasource=src:my-ds
+
+  # Create a manifest for a VirtualMachinePool using an existing VirtualMachine as template:
+  kubectl get vm my-vm -o yaml | {{ProgramName}} create pool --name=my-pool --replicas=3 --vm-file=-`
+}
+
+func (c *createPool) run(cmd *cobra.Command) error {
+	if c.name == "" {
+		c.name = "pool-" + rand.String(5)
+	}
+
+	if c.replicas < 0 {
+		return params.FlagErr(ReplicasFlag, "replicas must not be negative")
+	}
+
+	var template *v1.VirtualMachine
+	var err error
+	if cmd.Flags().Changed(VMFileFlag) {
+		if c.vmFlags.Changed(cmd) {
+			return params.FlagErr(VMFileFlag, "flags of create vm cannot be used together with a VirtualMachine manifest")
+		}
+		template, err = readVM(cmd.InOrStdin(), c.vmFile)
+	} else {
+		template, err = c.vmFlags.NewVM(cmd, c.name)
+	}
+	if err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(c.newPool(template))
+	if err != nil {
+		return err
+	}
+
+	cmd.Print(string(out))
+	return nil
+}
+
+func (c *createPool) newPool(template *v1.VirtualMachine) *poolv1.VirtualMachinePool {
+	selectorLabels := map[string]string{poolLabel: c.name}
+
+	vmTemplate := &poolv1.VirtualMachineTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      withLabels(template.Labels, selectorLabels),
+			Annotations: template.Annotations,
+		},
+		Spec: template.Spec,
+	}
+	if vmTemplate.Spec.Template != nil {
+		vmTemplate.Spec.Template.ObjectMeta.Labels = withLabels(vmTemplate.Spec.Template.ObjectMeta.Labels, selectorLabels)
+	}
+
+	replicas := c.replicas
+	return &poolv1.VirtualMachinePool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       poolv1.VirtualMachinePoolKind,
+			APIVersion: poolv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: c.name,
+		},
+		Spec: poolv1.VirtualMachinePoolSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			VirtualMachineTemplate: vmTemplate,
+		},
+	}
+}
+
+func withLabels(labels, additional map[string]string) map[string]string {
+	merged := map[string]string{}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	for k, v := range additional {
+		merged[k] = v
+	}
+	return merged
+}
+
+func readVM(stdin io.Reader, path string) (*v1.VirtualMachine, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, params.FlagErr(VMFileFlag, "%w", err)
+	}
+
+	template := &v1.VirtualMachine{}
+	if err := yaml.Unmarshal(data, template); err != nil {
+		return nil, params.FlagErr(VMFileFlag, "%w", err)
+	}
+	if template.Kind != v1.VirtualMachineGroupVersionKind.Kind {
+		return nil, params.FlagErr(VMFileFlag, "expected a %s manifest, got kind \"%s\"", v1.VirtualMachineGroupVersionKind.Kind, template.Kind)
+	}
+	if template.Spec.Template == nil {
+		return nil, params.FlagErr(VMFileFlag, "the VirtualMachine has no template")
+	}
+
+	// Identifiers assigned to the existing VirtualMachine must not be shared by the VirtualMachines of the pool
+	delete(template.Annotations, lastAppliedConfigAnnotation)
+	for i := range template.Spec.Template.Spec.Domain.Devices.Interfaces {
+		template.Spec.Template.Spec.Domain.Devices.Interfaces[i].MacAddress = ""
+	}
+	if firmware := template.Spec.Template.Spec.Domain.Firmware; firmware != nil {
+		firmware.UUID = ""
+		firmware.Serial = ""
+	}
+
+	return template, nil
+}
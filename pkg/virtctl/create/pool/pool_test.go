@@ -0,0 +1,150 @@
+package pool_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+	poolv1 "kubevirt.io/api/pool/v1alpha1"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/create/vm"
+	"kubevirt.io/kubevirt/tests/clientcmd"
+
+	. "kubevirt.io/kubevirt/pkg/virtctl/create/pool"
+)
+
+const create = "create"
+
+var _ = Describe("create pool", func() {
+	Context("Manifest is created successfully", func() {
+		It("Pool with random name and one replica", func() {
+			out, err := runCmd()
+			Expect(err).ToNot(HaveOccurred())
+			pool := unmarshalPool(out)
+
+			Expect(pool.Name).To(MatchRegexp("pool-[a-z0-9]{5}"))
+			Expect(*pool.Spec.Replicas).To(Equal(int32(1)))
+		})
+
+		It("Pool with template created from the create vm flags", func() {
+			out, err := runCmd(
+				setFlag(NameFlag, "my-pool"),
+				setFlag(ReplicasFlag, "3"),
+				setFlag(vm.InstancetypeFlag, "my-instancetype"),
+				setFlag(vm.ContainerdiskVolumeFlag, "src:my.registry/my-image:my-tag"),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			pool := unmarshalPool(out)
+
+			Expect(pool.Name).To(Equal("my-pool"))
+			Expect(*pool.Spec.Replicas).To(Equal(int32(3)))
+			Expect(pool.Spec.Selector.MatchLabels).To(Equal(map[string]string{"kubevirt.io/vmpool": "my-pool"}))
+
+			template := pool.Spec.VirtualMachineTemplate
+			Expect(template.ObjectMeta.Labels).To(Equal(pool.Spec.Selector.MatchLabels))
+			Expect(template.Spec.Template.ObjectMeta.Labels).To(Equal(pool.Spec.Selector.MatchLabels))
+			Expect(template.Spec.Instancetype.Name).To(Equal("my-instancetype"))
+			Expect(template.Spec.Template.Spec.Volumes).To(HaveLen(1))
+			Expect(template.Spec.Template.Spec.Volumes[0].Name).To(Equal("my-pool-containerdisk-0"))
+			Expect(template.Spec.Template.Spec.Volumes[0].ContainerDisk.Image).To(Equal("my.registry/my-image:my-tag"))
+		})
+
+		It("Pool with template read from a VirtualMachine manifest", func() {
+			memory := resource.MustParse("1Gi")
+			existing := &v1.VirtualMachine{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       v1.VirtualMachineGroupVersionKind.Kind,
+					APIVersion: v1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "my-vm",
+					Labels: map[string]string{"app": "web"},
+					Annotations: map[string]string{
+						"kubectl.kubernetes.io/last-applied-configuration": "{}",
+						"my-annotation": "value",
+					},
+				},
+				Spec: v1.VirtualMachineSpec{
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: v1.VirtualMachineInstanceSpec{
+							Domain: v1.DomainSpec{
+								Memory:   &v1.Memory{Guest: &memory},
+								Firmware: &v1.Firmware{UUID: "5d307ca9-b3ef-428c-8861-06e72d69f223"},
+								Devices: v1.Devices{
+									Interfaces: []v1.Interface{{Name: "default", MacAddress: "02:00:00:00:00:01"}},
+								},
+							},
+						},
+					},
+				},
+			}
+			vmFile := filepath.Join(GinkgoT().TempDir(), "vm.yaml")
+			data, err := yaml.Marshal(existing)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.WriteFile(vmFile, data, 0600)).To(Succeed())
+
+			out, err := runCmd(setFlag(NameFlag, "my-pool"), setFlag(VMFileFlag, vmFile))
+			Expect(err).ToNot(HaveOccurred())
+			pool := unmarshalPool(out)
+
+			template := pool.Spec.VirtualMachineTemplate
+			Expect(template.ObjectMeta.Labels).To(Equal(map[string]string{"app": "web", "kubevirt.io/vmpool": "my-pool"}))
+			Expect(template.ObjectMeta.Annotations).To(Equal(map[string]string{"my-annotation": "value"}))
+			Expect(template.Spec.Template.ObjectMeta.Labels).To(Equal(map[string]string{"kubevirt.io/vmpool": "my-pool"}))
+			Expect(template.Spec.Template.Spec.Domain.Memory.Guest.String()).To(Equal("1Gi"))
+			Expect(template.Spec.Template.Spec.Domain.Firmware.UUID).To(BeEmpty())
+			Expect(template.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(BeEmpty())
+		})
+	})
+
+	Describe("Manifest is not created successfully", func() {
+		It("Negative replicas are not allowed", func() {
+			out, err := runCmd(setFlag(ReplicasFlag, "-1"))
+			Expect(err).To(MatchError("failed to parse \"--replicas\" flag: replicas must not be negative"))
+			Expect(out).To(BeEmpty())
+		})
+
+		It("Flags of create vm are not allowed together with a VirtualMachine manifest", func() {
+			out, err := runCmd(setFlag(VMFileFlag, "vm.yaml"), setFlag(vm.MemoryFlag, "1Gi"))
+			Expect(err).To(MatchError("failed to parse \"--vm-file\" flag: flags of create vm cannot be used together with a VirtualMachine manifest"))
+			Expect(out).To(BeEmpty())
+		})
+
+		It("VirtualMachine manifest must contain a VirtualMachine", func() {
+			vmFile := filepath.Join(GinkgoT().TempDir(), "vmi.yaml")
+			Expect(os.WriteFile(vmFile, []byte("kind: VirtualMachineInstance\n"), 0600)).To(Succeed())
+
+			out, err := runCmd(setFlag(VMFileFlag, vmFile))
+			Expect(err).To(MatchError("failed to parse \"--vm-file\" flag: expected a VirtualMachine manifest, got kind \"VirtualMachineInstance\""))
+			Expect(out).To(BeEmpty())
+		})
+
+		It("Invalid create vm flags are reported", func() {
+			out, err := runCmd(setFlag(vm.ContainerdiskVolumeFlag, "name:my-cd"))
+			Expect(err).To(MatchError("failed to parse \"--volume-containerdisk\" flag: src must be specified"))
+			Expect(out).To(BeEmpty())
+		})
+	})
+})
+
+func setFlag(flag, parameter string) string {
+	return "--" + flag + "=" + parameter
+}
+
+func runCmd(args ...string) ([]byte, error) {
+	_args := append([]string{create, Pool}, args...)
+	return clientcmd.NewRepeatableVirtctlCommandWithOut(_args...)()
+}
+
+func unmarshalPool(bytes []byte) *poolv1.VirtualMachinePool {
+	pool := &poolv1.VirtualMachinePool{}
+	Expect(yaml.Unmarshal(bytes, pool)).To(Succeed())
+	Expect(pool.Kind).To(Equal(poolv1.VirtualMachinePoolKind))
+	Expect(pool.APIVersion).To(Equal(poolv1.SchemeGroupVersion.String()))
+	return pool
+}
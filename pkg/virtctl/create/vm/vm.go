@@ -57,6 +57,11 @@ const (
 	InferInstancetypeFlag      = "infer-instancetype"
 	InferPreferenceFlag        = "infer-preference"
 	VolumeImportFlag           = "volume-import"
+	InterfaceFlag              = "interface"
+	AccessCredFlag             = "access-cred"
+	SysprepFlag                = "sysprep"
+	GPUFlag                    = "gpu"
+	HostDeviceFlag             = "host-device"
 
 	cloudInitDisk    = "cloudinitdisk"
 	inferNoOptDefVal = "inferNoOptDefVal"
@@ -68,6 +73,22 @@ const (
 	s3               = "s3"
 	vddk             = "vddk"
 	snapshot         = "snapshot"
+	sysprepDisk      = "sysprep"
+
+	podNetwork = "pod"
+
+	bindingMasquerade = "masquerade"
+	bindingBridge     = "bridge"
+	bindingSRIOV      = "sriov"
+
+	accessCredSSH      = "ssh"
+	accessCredPassword = "password"
+
+	methodQemuGuestAgent = "qemu-guest-agent"
+	methodConfigDrive    = "configdrive"
+
+	sysprepConfigMap = "configmap"
+	sysprepSecret    = "secret"
 )
 
 type createVM struct {
@@ -87,6 +108,11 @@ type createVM struct {
 	inferInstancetype      string
 	inferPreference        string
 	volumeImport           []string
+	interfaces             []string
+	accessCreds            []string
+	sysprep                string
+	gpus                   []string
+	hostDevices            []string
 
 	bootOrders map[uint]string
 }
@@ -115,6 +141,29 @@ type blankVolume struct {
 	Size *resource.Quantity `param:"size"`
 }
 
+type networkInterface struct {
+	Name    string `param:"name"`
+	Binding string `param:"binding"`
+	Network string `param:"network"`
+}
+
+type accessCredential struct {
+	Type   string `param:"type"`
+	Source string `param:"src"`
+	Method string `param:"method"`
+	User   string `param:"user"`
+}
+
+type sysprepSource struct {
+	Type   string `param:"type"`
+	Source string `param:"src"`
+}
+
+type device struct {
+	Name       string `param:"name"`
+	DeviceName string `param:"devicename"`
+}
+
 type optionFn func(*createVM, *v1.VirtualMachine) error
 
 var optFns = map[string]optionFn{
@@ -131,6 +180,11 @@ var optFns = map[string]optionFn{
 	CloudInitUserDataFlag:    withCloudInitUserData,
 	CloudInitNetworkDataFlag: withCloudInitNetworkData,
 	VolumeImportFlag:         withImportedVolume,
+	InterfaceFlag:            withInterfaces,
+	AccessCredFlag:           withAccessCredentials,
+	SysprepFlag:              withSysprep,
+	GPUFlag:                  withGPUs,
+	HostDeviceFlag:           withHostDevices,
 }
 
 // Unless the boot order is specified by the user volumes have the following fixed boot order:
@@ -149,6 +203,11 @@ var flags = []string{
 	VolumeImportFlag,
 	CloudInitUserDataFlag,
 	CloudInitNetworkDataFlag,
+	InterfaceFlag,
+	AccessCredFlag,
+	SysprepFlag,
+	GPUFlag,
+	HostDeviceFlag,
 	InferInstancetypeFlag,
 	InferPreferenceFlag,
 }
@@ -261,6 +320,43 @@ func NewCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&c.name, NameFlag, c.name, "Specify the name of the VM.")
+	c.addFlags(cmd)
+
+	cmd.Flags().SortFlags = false
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+
+	return cmd
+}
+
+// VMFlags allows other create commands to generate a VirtualMachine from the same flags as create vm.
+type VMFlags struct {
+	c createVM
+}
+
+// AddVMFlags registers all flags of create vm except the name on cmd.
+func AddVMFlags(cmd *cobra.Command) *VMFlags {
+	f := &VMFlags{c: defaultCreateVM()}
+	f.c.addFlags(cmd)
+	return f
+}
+
+// NewVM creates a VirtualMachine with the given name from the flags set on cmd.
+func (f *VMFlags) NewVM(cmd *cobra.Command, name string) (*v1.VirtualMachine, error) {
+	f.c.name = name
+	return f.c.build(cmd)
+}
+
+// Changed returns true if any of the create vm flags was set on cmd.
+func (f *VMFlags) Changed(cmd *cobra.Command) bool {
+	for _, flag := range append([]string{TerminationGracePeriodFlag, MemoryFlag}, flags...) {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *createVM) addFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&c.runStrategy, RunStrategyFlag, c.runStrategy, "Specify the RunStrategy of the VM.")
 	cmd.Flags().Int64Var(&c.terminationGracePeriod, TerminationGracePeriodFlag, c.terminationGracePeriod, "Specify the termination grace period of the VM.")
 
@@ -294,11 +390,12 @@ func NewCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&c.cloudInitUserData, CloudInitUserDataFlag, c.cloudInitUserData, "Specify the base64 encoded cloud-init user data of the VM.")
 	cmd.Flags().StringVar(&c.cloudInitNetworkData, CloudInitNetworkDataFlag, c.cloudInitNetworkData, "Specify the base64 encoded cloud-init network data of the VM.")
+	cmd.Flags().StringVar(&c.sysprep, SysprepFlag, c.sysprep, fmt.Sprintf("Specify the ConfigMap or Secret containing the sysprep answer files of the VM.\nSupported parameters: %s", params.Supported(sysprepSource{})))
 
-	cmd.Flags().SortFlags = false
-	cmd.SetUsageTemplate(templates.UsageTemplate())
-
-	return cmd
+	cmd.Flags().StringArrayVar(&c.interfaces, InterfaceFlag, c.interfaces, fmt.Sprintf("Specify a network interface of the VM and the network it is connected to. Can be provided multiple times.\nThe network is either %s or the name of a Multus network, the binding one of %s, %s or %s.\nSupported parameters: %s", podNetwork, bindingMasquerade, bindingBridge, bindingSRIOV, params.Supported(networkInterface{})))
+	cmd.Flags().StringArrayVar(&c.accessCreds, AccessCredFlag, c.accessCreds, fmt.Sprintf("Specify a Secret containing access credentials to be injected into the VM. Can be provided multiple times.\nThe type is either %s or %s, the method one of %s or %s.\nSupported parameters: %s", accessCredSSH, accessCredPassword, methodQemuGuestAgent, methodConfigDrive, params.Supported(accessCredential{})))
+	cmd.Flags().StringArrayVar(&c.gpus, GPUFlag, c.gpus, fmt.Sprintf("Specify a GPU to be passed through to the VM. Can be provided multiple times.\nSupported parameters: %s", params.Supported(device{})))
+	cmd.Flags().StringArrayVar(&c.hostDevices, HostDeviceFlag, c.hostDevices, fmt.Sprintf("Specify a host device to be passed through to the VM. Can be provided multiple times.\nSupported parameters: %s", params.Supported(device{})))
 }
 
 func defaultCreateVM() createVM {
@@ -339,19 +436,11 @@ func volumeShouldNotExist(flag string, vm *v1.VirtualMachine, name string) error
 
 func (c *createVM) run(cmd *cobra.Command) error {
 	c.setDefaults()
-	vm, err := c.newVM()
+	vm, err := c.build(cmd)
 	if err != nil {
 		return err
 	}
 
-	for _, flag := range flags {
-		if cmd.Flags().Changed(flag) {
-			if err := optFns[flag](c, vm); err != nil {
-				return err
-			}
-		}
-	}
-
 	out, err := yaml.Marshal(vm)
 	if err != nil {
 		return err
@@ -361,6 +450,23 @@ func (c *createVM) run(cmd *cobra.Command) error {
 	return nil
 }
 
+func (c *createVM) build(cmd *cobra.Command) (*v1.VirtualMachine, error) {
+	vm, err := c.newVM()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, flag := range flags {
+		if cmd.Flags().Changed(flag) {
+			if err := optFns[flag](c, vm); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return vm, nil
+}
+
 func (c *createVM) setDefaults() {
 	if c.name == "" {
 		c.name = "vm-" + rand.String(5)
@@ -420,7 +526,19 @@ func (c *createVM) usage() string {
   {{ProgramName}} create vm --instancetype=my-instancetype --preference=my-preference --volume-pvc=my-pvc
 
   # Create a manifest for a VirtualMachine with a specified DataVolumeTemplate
-  {{ProgramName}} create vm --volume-import type:pvc,name:my-pvc,namespace:default,size:256Mi`
+  {{ProgramName}} create vm --volume-import type:pvc,name:my-pvc,namespace:default,size:256Mi
+
+  # Create a manifest for a VirtualMachine connected to the pod network and a bridged Multus network
+  {{ProgramName}} create vm --volume-datasource=src:my-ds --interface=network:pod --interface=name:secondary,binding:bridge,network:my-ns/my-net
+
+  # Create a manifest for a VirtualMachine with SSH keys injected into the home directory of the user 'fedora' by the guest agent
+  {{ProgramName}} create vm --volume-datasource=src:my-ds --access-cred=src:my-ssh-keys,user:fedora
+
+  # Create a manifest for a Windows VirtualMachine with a sysprep answer file from a ConfigMap
+  {{ProgramName}} create vm --volume-pvc=src:my-windows-pvc --sysprep=src:my-answer-files
+
+  # Create a manifest for a VirtualMachine with a passed through GPU and host device
+  {{ProgramName}} create vm --volume-datasource=src:my-ds --gpu=devicename:nvidia.com/TU104GL_Tesla_T4 --host-device=name:nic,devicename:vendor.com/my-device`
 }
 
 func (c *createVM) newVM() (*v1.VirtualMachine, error) {
@@ -892,6 +1010,280 @@ func withCloudInitData(flag string, c *createVM, vm *v1.VirtualMachine) error {
 	return nil
 }
 
+func withInterfaces(c *createVM, vm *v1.VirtualMachine) error {
+	for _, interfaceParams := range c.interfaces {
+		iface := networkInterface{}
+		err := params.Map(InterfaceFlag, interfaceParams, &iface)
+		if err != nil {
+			return err
+		}
+
+		if iface.Network == "" {
+			iface.Network = podNetwork
+		}
+
+		network := v1.Network{}
+		if iface.Network == podNetwork {
+			for _, existing := range vm.Spec.Template.Spec.Networks {
+				if existing.Pod != nil {
+					return params.FlagErr(InterfaceFlag, "only one interface can be connected to the pod network")
+				}
+			}
+			network.Pod = &v1.PodNetwork{}
+			if iface.Name == "" {
+				iface.Name = "default"
+			}
+			if iface.Binding == "" {
+				iface.Binding = bindingMasquerade
+			}
+		} else {
+			_, name, err := params.SplitPrefixedName(iface.Network)
+			if err != nil {
+				return params.FlagErr(InterfaceFlag, "network invalid: %w", err)
+			}
+			network.Multus = &v1.MultusNetwork{NetworkName: iface.Network}
+			if iface.Name == "" {
+				iface.Name = name
+			}
+			if iface.Binding == "" {
+				iface.Binding = bindingBridge
+			}
+		}
+
+		for _, existing := range vm.Spec.Template.Spec.Networks {
+			if existing.Name == iface.Name {
+				return params.FlagErr(InterfaceFlag, "there is already an interface with name '%s'", iface.Name)
+			}
+		}
+		network.Name = iface.Name
+
+		vmIface := v1.Interface{Name: iface.Name}
+		switch iface.Binding {
+		case bindingMasquerade:
+			if network.Pod == nil {
+				return params.FlagErr(InterfaceFlag, "binding %s can only be used with the %s network", bindingMasquerade, podNetwork)
+			}
+			vmIface.Masquerade = &v1.InterfaceMasquerade{}
+		case bindingBridge:
+			vmIface.Bridge = &v1.InterfaceBridge{}
+		case bindingSRIOV:
+			if network.Multus == nil {
+				return params.FlagErr(InterfaceFlag, "binding %s can only be used with a Multus network", bindingSRIOV)
+			}
+			vmIface.SRIOV = &v1.InterfaceSRIOV{}
+		default:
+			return params.FlagErr(InterfaceFlag, "invalid binding \"%s\", supported values are: %s, %s, %s", iface.Binding, bindingMasquerade, bindingBridge, bindingSRIOV)
+		}
+
+		vm.Spec.Template.Spec.Domain.Devices.Interfaces = append(vm.Spec.Template.Spec.Domain.Devices.Interfaces, vmIface)
+		vm.Spec.Template.Spec.Networks = append(vm.Spec.Template.Spec.Networks, network)
+	}
+
+	return nil
+}
+
+func withAccessCredentials(c *createVM, vm *v1.VirtualMachine) error {
+	for _, accessCredParams := range c.accessCreds {
+		cred := accessCredential{}
+		err := params.Map(AccessCredFlag, accessCredParams, &cred)
+		if err != nil {
+			return err
+		}
+
+		if cred.Source == "" {
+			return params.FlagErr(AccessCredFlag, "src must be specified")
+		}
+		if cred.Type == "" {
+			cred.Type = accessCredSSH
+		}
+		if cred.Method == "" {
+			cred.Method = methodQemuGuestAgent
+		}
+
+		secret := &v1.AccessCredentialSecretSource{SecretName: cred.Source}
+		var accessCred v1.AccessCredential
+		switch cred.Type {
+		case accessCredSSH:
+			accessCred, err = sshAccessCredential(cred, secret, vm)
+			if err != nil {
+				return err
+			}
+		case accessCredPassword:
+			if cred.Method != methodQemuGuestAgent {
+				return params.FlagErr(AccessCredFlag, "type %s only supports the method %s", accessCredPassword, methodQemuGuestAgent)
+			}
+			if cred.User != "" {
+				return params.FlagErr(AccessCredFlag, "user cannot be specified with type %s, the secret contains the users", accessCredPassword)
+			}
+			accessCred.UserPassword = &v1.UserPasswordAccessCredential{
+				Source: v1.UserPasswordAccessCredentialSource{Secret: secret},
+				PropagationMethod: v1.UserPasswordAccessCredentialPropagationMethod{
+					QemuGuestAgent: &v1.QemuGuestAgentUserPasswordAccessCredentialPropagation{},
+				},
+			}
+		default:
+			return params.FlagErr(AccessCredFlag, "invalid type \"%s\", supported values are: %s, %s", cred.Type, accessCredSSH, accessCredPassword)
+		}
+
+		vm.Spec.Template.Spec.AccessCredentials = append(vm.Spec.Template.Spec.AccessCredentials, accessCred)
+	}
+
+	return nil
+}
+
+func sshAccessCredential(cred accessCredential, secret *v1.AccessCredentialSecretSource, vm *v1.VirtualMachine) (v1.AccessCredential, error) {
+	sshPublicKey := &v1.SSHPublicKeyAccessCredential{
+		Source: v1.SSHPublicKeyAccessCredentialSource{Secret: secret},
+	}
+
+	switch cred.Method {
+	case methodQemuGuestAgent:
+		if cred.User == "" {
+			return v1.AccessCredential{}, params.FlagErr(AccessCredFlag, "user must be specified with method %s", methodQemuGuestAgent)
+		}
+		sshPublicKey.PropagationMethod.QemuGuestAgent = &v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation{
+			Users: []string{cred.User},
+		}
+	case methodConfigDrive:
+		if cred.User != "" {
+			return v1.AccessCredential{}, params.FlagErr(AccessCredFlag, "user cannot be specified with method %s", methodConfigDrive)
+		}
+		if err := useCloudInitConfigDrive(vm); err != nil {
+			return v1.AccessCredential{}, err
+		}
+		sshPublicKey.PropagationMethod.ConfigDrive = &v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation{}
+	default:
+		return v1.AccessCredential{}, params.FlagErr(AccessCredFlag, "invalid method \"%s\", supported values are: %s, %s", cred.Method, methodQemuGuestAgent, methodConfigDrive)
+	}
+
+	return v1.AccessCredential{SSHPublicKey: sshPublicKey}, nil
+}
+
+// useCloudInitConfigDrive provides the cloud-init data of the VM through a config drive,
+// which is required to propagate SSH public keys with the configdrive method.
+func useCloudInitConfigDrive(vm *v1.VirtualMachine) error {
+	for i, vol := range vm.Spec.Template.Spec.Volumes {
+		if vol.CloudInitConfigDrive != nil {
+			return nil
+		}
+		if vol.Name == cloudInitDisk && vol.CloudInitNoCloud != nil {
+			vm.Spec.Template.Spec.Volumes[i].CloudInitNoCloud = nil
+			vm.Spec.Template.Spec.Volumes[i].CloudInitConfigDrive = &v1.CloudInitConfigDriveSource{
+				UserDataBase64:    vol.CloudInitNoCloud.UserDataBase64,
+				NetworkDataBase64: vol.CloudInitNoCloud.NetworkDataBase64,
+			}
+			return nil
+		}
+	}
+
+	return params.FlagErr(AccessCredFlag, "method %s requires cloud-init user data to be specified", methodConfigDrive)
+}
+
+func withSysprep(c *createVM, vm *v1.VirtualMachine) error {
+	sysprep := sysprepSource{}
+	err := params.Map(SysprepFlag, c.sysprep, &sysprep)
+	if err != nil {
+		return err
+	}
+
+	if sysprep.Source == "" {
+		return params.FlagErr(SysprepFlag, "src must be specified")
+	}
+
+	if err := volumeShouldNotExist(SysprepFlag, vm, sysprepDisk); err != nil {
+		return err
+	}
+
+	source := &v1.SysprepSource{}
+	switch sysprep.Type {
+	case "", sysprepConfigMap:
+		source.ConfigMap = &k8sv1.LocalObjectReference{Name: sysprep.Source}
+	case sysprepSecret:
+		source.Secret = &k8sv1.LocalObjectReference{Name: sysprep.Source}
+	default:
+		return params.FlagErr(SysprepFlag, "invalid type \"%s\", supported values are: %s, %s", sysprep.Type, sysprepConfigMap, sysprepSecret)
+	}
+
+	vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+		Name: sysprepDisk,
+		VolumeSource: v1.VolumeSource{
+			Sysprep: source,
+		},
+	})
+
+	// Windows reads the answer files only from a CD-ROM
+	vm.Spec.Template.Spec.Domain.Devices.Disks = append(vm.Spec.Template.Spec.Domain.Devices.Disks, v1.Disk{
+		Name: sysprepDisk,
+		DiskDevice: v1.DiskDevice{
+			CDRom: &v1.CDRomTarget{
+				Bus: v1.DiskBusSATA,
+			},
+		},
+	})
+
+	return nil
+}
+
+func withGPUs(c *createVM, vm *v1.VirtualMachine) error {
+	for i, gpuParams := range c.gpus {
+		gpu := device{}
+		if err := parseDevice(GPUFlag, gpuParams, &gpu, fmt.Sprintf("gpu-%d", i)); err != nil {
+			return err
+		}
+
+		for _, existing := range vm.Spec.Template.Spec.Domain.Devices.GPUs {
+			if existing.Name == gpu.Name {
+				return params.FlagErr(GPUFlag, "there is already a GPU with name '%s'", gpu.Name)
+			}
+		}
+
+		vm.Spec.Template.Spec.Domain.Devices.GPUs = append(vm.Spec.Template.Spec.Domain.Devices.GPUs, v1.GPU{
+			Name:       gpu.Name,
+			DeviceName: gpu.DeviceName,
+		})
+	}
+
+	return nil
+}
+
+func withHostDevices(c *createVM, vm *v1.VirtualMachine) error {
+	for i, hostDeviceParams := range c.hostDevices {
+		hostDevice := device{}
+		if err := parseDevice(HostDeviceFlag, hostDeviceParams, &hostDevice, fmt.Sprintf("hostdevice-%d", i)); err != nil {
+			return err
+		}
+
+		for _, existing := range vm.Spec.Template.Spec.Domain.Devices.HostDevices {
+			if existing.Name == hostDevice.Name {
+				return params.FlagErr(HostDeviceFlag, "there is already a host device with name '%s'", hostDevice.Name)
+			}
+		}
+
+		vm.Spec.Template.Spec.Domain.Devices.HostDevices = append(vm.Spec.Template.Spec.Domain.Devices.HostDevices, v1.HostDevice{
+			Name:       hostDevice.Name,
+			DeviceName: hostDevice.DeviceName,
+		})
+	}
+
+	return nil
+}
+
+func parseDevice(flag, paramsStr string, dev *device, defaultName string) error {
+	if err := params.Map(flag, paramsStr, dev); err != nil {
+		return err
+	}
+
+	if dev.DeviceName == "" {
+		return params.FlagErr(flag, "devicename must be specified")
+	}
+
+	if dev.Name == "" {
+		dev.Name = defaultName
+	}
+
+	return nil
+}
+
 func withImportedVolume(c *createVM, vm *v1.VirtualMachine) error {
 	for _, volume := range c.volumeImport {
 		volumeSourceType, err := params.GetParamByName("type", volume)
@@ -464,6 +464,128 @@ var _ = Describe("create vm", func() {
 			Expect(string(decoded)).To(Equal(cloudInitNetworkData))
 		})
 
+		DescribeTable("VM with specified interface", func(params string, iface v1.Interface, network v1.Network) {
+			out, err := runCmd(setFlag(InterfaceFlag, params))
+			Expect(err).ToNot(HaveOccurred())
+			vm := unmarshalVM(out)
+
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces).To(ConsistOf(iface))
+			Expect(vm.Spec.Template.Spec.Networks).To(ConsistOf(network))
+		},
+			Entry("with pod network", "network:pod",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}},
+				v1.Network{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+			),
+			Entry("with pod network and bridge binding", "name:my-iface,binding:bridge",
+				v1.Interface{Name: "my-iface", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				v1.Network{Name: "my-iface", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+			),
+			Entry("with Multus network", "network:my-ns/my-net",
+				v1.Interface{Name: "my-net", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				v1.Network{Name: "my-net", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "my-ns/my-net"}}},
+			),
+			Entry("with Multus network and sriov binding", "name:my-iface,binding:sriov,network:my-net",
+				v1.Interface{Name: "my-iface", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}},
+				v1.Network{Name: "my-iface", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "my-net"}}},
+			),
+		)
+
+		It("VM with multiple interfaces", func() {
+			out, err := runCmd(
+				setFlag(InterfaceFlag, "network:pod"),
+				setFlag(InterfaceFlag, "name:secondary,network:my-net"),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			vm := unmarshalVM(out)
+
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces).To(HaveLen(2))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].Name).To(Equal("default"))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces[1].Name).To(Equal("secondary"))
+			Expect(vm.Spec.Template.Spec.Networks).To(HaveLen(2))
+			Expect(vm.Spec.Template.Spec.Networks[0].Pod).ToNot(BeNil())
+			Expect(vm.Spec.Template.Spec.Networks[1].Multus.NetworkName).To(Equal("my-net"))
+		})
+
+		DescribeTable("VM with specified access credential", func(params string, accessCred v1.AccessCredential) {
+			out, err := runCmd(setFlag(AccessCredFlag, params))
+			Expect(err).ToNot(HaveOccurred())
+			vm := unmarshalVM(out)
+
+			Expect(vm.Spec.Template.Spec.AccessCredentials).To(ConsistOf(accessCred))
+		},
+			Entry("with ssh key and user", "src:my-keys,user:fedora", v1.AccessCredential{
+				SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+					Source: v1.SSHPublicKeyAccessCredentialSource{Secret: &v1.AccessCredentialSecretSource{SecretName: "my-keys"}},
+					PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+						QemuGuestAgent: &v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation{Users: []string{"fedora"}},
+					},
+				},
+			}),
+			Entry("with password", "type:password,src:my-passwords", v1.AccessCredential{
+				UserPassword: &v1.UserPasswordAccessCredential{
+					Source: v1.UserPasswordAccessCredentialSource{Secret: &v1.AccessCredentialSecretSource{SecretName: "my-passwords"}},
+					PropagationMethod: v1.UserPasswordAccessCredentialPropagationMethod{
+						QemuGuestAgent: &v1.QemuGuestAgentUserPasswordAccessCredentialPropagation{},
+					},
+				},
+			}),
+		)
+
+		It("VM with ssh key propagated through config drive", func() {
+			userDataB64 := base64.StdEncoding.EncodeToString([]byte(cloudInitUserData))
+			out, err := runCmd(
+				setFlag(CloudInitUserDataFlag, userDataB64),
+				setFlag(AccessCredFlag, "src:my-keys,method:configdrive"),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			vm := unmarshalVM(out)
+
+			Expect(vm.Spec.Template.Spec.Volumes).To(HaveLen(1))
+			Expect(vm.Spec.Template.Spec.Volumes[0].Name).To(Equal("cloudinitdisk"))
+			Expect(vm.Spec.Template.Spec.Volumes[0].VolumeSource.CloudInitNoCloud).To(BeNil())
+			Expect(vm.Spec.Template.Spec.Volumes[0].VolumeSource.CloudInitConfigDrive).ToNot(BeNil())
+			Expect(vm.Spec.Template.Spec.Volumes[0].VolumeSource.CloudInitConfigDrive.UserDataBase64).To(Equal(userDataB64))
+
+			Expect(vm.Spec.Template.Spec.AccessCredentials).To(HaveLen(1))
+			Expect(vm.Spec.Template.Spec.AccessCredentials[0].SSHPublicKey.PropagationMethod.ConfigDrive).ToNot(BeNil())
+		})
+
+		DescribeTable("VM with specified sysprep", func(params string, source *v1.SysprepSource) {
+			out, err := runCmd(setFlag(SysprepFlag, params))
+			Expect(err).ToNot(HaveOccurred())
+			vm := unmarshalVM(out)
+
+			Expect(vm.Spec.Template.Spec.Volumes).To(HaveLen(1))
+			Expect(vm.Spec.Template.Spec.Volumes[0].Name).To(Equal("sysprep"))
+			Expect(vm.Spec.Template.Spec.Volumes[0].VolumeSource.Sysprep).To(Equal(source))
+
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Disks).To(HaveLen(1))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Disks[0].Name).To(Equal("sysprep"))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Disks[0].CDRom).ToNot(BeNil())
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Disks[0].CDRom.Bus).To(Equal(v1.DiskBusSATA))
+		},
+			Entry("with ConfigMap", "src:my-answers", &v1.SysprepSource{ConfigMap: &k8sv1.LocalObjectReference{Name: "my-answers"}}),
+			Entry("with Secret", "type:secret,src:my-answers", &v1.SysprepSource{Secret: &k8sv1.LocalObjectReference{Name: "my-answers"}}),
+		)
+
+		It("VM with specified GPUs and host devices", func() {
+			out, err := runCmd(
+				setFlag(GPUFlag, "devicename:nvidia.com/TU104GL_Tesla_T4"),
+				setFlag(GPUFlag, "name:my-gpu,devicename:nvidia.com/GRID_T4-1Q"),
+				setFlag(HostDeviceFlag, "name:my-device,devicename:vendor.com/my-device"),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			vm := unmarshalVM(out)
+
+			Expect(vm.Spec.Template.Spec.Domain.Devices.GPUs).To(Equal([]v1.GPU{
+				{Name: "gpu-0", DeviceName: "nvidia.com/TU104GL_Tesla_T4"},
+				{Name: "my-gpu", DeviceName: "nvidia.com/GRID_T4-1Q"},
+			}))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.HostDevices).To(Equal([]v1.HostDevice{
+				{Name: "my-device", DeviceName: "vendor.com/my-device"},
+			}))
+		})
+
 		It("Complex example", func() {
 			const vmName = "my-vm"
 			const runStrategy = v1.RunStrategyManual
@@ -799,6 +921,60 @@ var _ = Describe("create vm", func() {
 			),
 		)
 
+		DescribeTable("Invalid arguments to InterfaceFlag", func(errMsg string, flags ...string) {
+			out, err := runCmd(flags...)
+
+			Expect(err).To(MatchError(errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Unknown param", "failed to parse \"--interface\" flag: unknown param(s): test:test", setFlag(InterfaceFlag, "test:test")),
+			Entry("Invalid binding", "failed to parse \"--interface\" flag: invalid binding \"madethisup\", supported values are: masquerade, bridge, sriov", setFlag(InterfaceFlag, "binding:madethisup")),
+			Entry("Masquerade with Multus network", "failed to parse \"--interface\" flag: binding masquerade can only be used with the pod network", setFlag(InterfaceFlag, "binding:masquerade,network:my-net")),
+			Entry("SR-IOV with pod network", "failed to parse \"--interface\" flag: binding sriov can only be used with a Multus network", setFlag(InterfaceFlag, "binding:sriov")),
+			Entry("Invalid network", "failed to parse \"--interface\" flag: network invalid: name cannot be empty", setFlag(InterfaceFlag, "network:my-ns/")),
+			Entry("Multiple pod networks", "failed to parse \"--interface\" flag: only one interface can be connected to the pod network", setFlag(InterfaceFlag, "network:pod"), setFlag(InterfaceFlag, "name:other")),
+			Entry("Duplicate name", "failed to parse \"--interface\" flag: there is already an interface with name 'my-net'", setFlag(InterfaceFlag, "network:my-net"), setFlag(InterfaceFlag, "network:other-ns/my-net")),
+		)
+
+		DescribeTable("Invalid arguments to AccessCredFlag", func(errMsg string, flags ...string) {
+			out, err := runCmd(flags...)
+
+			Expect(err).To(MatchError(errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Missing src", "failed to parse \"--access-cred\" flag: src must be specified", setFlag(AccessCredFlag, "user:fedora")),
+			Entry("Invalid type", "failed to parse \"--access-cred\" flag: invalid type \"madethisup\", supported values are: ssh, password", setFlag(AccessCredFlag, "type:madethisup,src:my-keys")),
+			Entry("Invalid method", "failed to parse \"--access-cred\" flag: invalid method \"madethisup\", supported values are: qemu-guest-agent, configdrive", setFlag(AccessCredFlag, "src:my-keys,method:madethisup")),
+			Entry("Missing user with guest agent", "failed to parse \"--access-cred\" flag: user must be specified with method qemu-guest-agent", setFlag(AccessCredFlag, "src:my-keys")),
+			Entry("User with config drive", "failed to parse \"--access-cred\" flag: user cannot be specified with method configdrive", setFlag(AccessCredFlag, "src:my-keys,method:configdrive,user:fedora")),
+			Entry("Config drive without cloud-init", "failed to parse \"--access-cred\" flag: method configdrive requires cloud-init user data to be specified", setFlag(AccessCredFlag, "src:my-keys,method:configdrive")),
+			Entry("Password with config drive", "failed to parse \"--access-cred\" flag: type password only supports the method qemu-guest-agent", setFlag(AccessCredFlag, "type:password,src:my-passwords,method:configdrive")),
+			Entry("Password with user", "failed to parse \"--access-cred\" flag: user cannot be specified with type password, the secret contains the users", setFlag(AccessCredFlag, "type:password,src:my-passwords,user:fedora")),
+		)
+
+		DescribeTable("Invalid arguments to SysprepFlag", func(errMsg string, flags ...string) {
+			out, err := runCmd(flags...)
+
+			Expect(err).To(MatchError(errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Missing src", "failed to parse \"--sysprep\" flag: src must be specified", setFlag(SysprepFlag, "type:secret")),
+			Entry("Invalid type", "failed to parse \"--sysprep\" flag: invalid type \"madethisup\", supported values are: configmap, secret", setFlag(SysprepFlag, "type:madethisup,src:my-answers")),
+			Entry("Volume already exists", "failed to parse \"--sysprep\" flag: there is already a volume with name 'sysprep'", setFlag(PvcVolumeFlag, "src:my-pvc,name:sysprep"), setFlag(SysprepFlag, "src:my-answers")),
+		)
+
+		DescribeTable("Invalid arguments to GPUFlag and HostDeviceFlag", func(errMsg string, flags ...string) {
+			out, err := runCmd(flags...)
+
+			Expect(err).To(MatchError(errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Missing GPU devicename", "failed to parse \"--gpu\" flag: devicename must be specified", setFlag(GPUFlag, "name:my-gpu")),
+			Entry("Duplicate GPU name", "failed to parse \"--gpu\" flag: there is already a GPU with name 'my-gpu'", setFlag(GPUFlag, "name:my-gpu,devicename:a"), setFlag(GPUFlag, "name:my-gpu,devicename:b")),
+			Entry("Missing host device devicename", "failed to parse \"--host-device\" flag: devicename must be specified", setFlag(HostDeviceFlag, "name:my-device")),
+			Entry("Duplicate host device name", "failed to parse \"--host-device\" flag: there is already a host device with name 'my-device'", setFlag(HostDeviceFlag, "name:my-device,devicename:a"), setFlag(HostDeviceFlag, "name:my-device,devicename:b")),
+		)
+
 		It("Duplicate boot orders are not allowed", func() {
 			out, err := runCmd(
 				setFlag(ContainerdiskVolumeFlag, "src:my.registry/my-image:my-tag,bootorder:1"),
@@ -0,0 +1,106 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package imageupload
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// uploadCheckpoint records the progress of an upload in a local file, so that an interrupted upload can be
+// resumed with --resume. The CDI upload proxy only accepts the whole image in a single request, so a resumed
+// upload sends the image again to the same volume, but it skips creating the volume and, once the image was
+// completely sent, the upload itself.
+type uploadCheckpoint struct {
+	Namespace    string    `json:"namespace"`
+	Name         string    `json:"name"`
+	ImagePath    string    `json:"imagePath"`
+	ImageSize    int64     `json:"imageSize"`
+	ImageModTime time.Time `json:"imageModTime"`
+	// Uploaded is set once the whole image was accepted by the upload proxy
+	Uploaded bool `json:"uploaded"`
+}
+
+func newUploadCheckpoint(namespace, name string, file *os.File) (*uploadCheckpoint, error) {
+	fi, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	imagePath, err := filepath.Abs(file.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &uploadCheckpoint{
+		Namespace:    namespace,
+		Name:         name,
+		ImagePath:    imagePath,
+		ImageSize:    fi.Size(),
+		ImageModTime: fi.ModTime().UTC(),
+	}, nil
+}
+
+// defaultCheckpointPath returns the checkpoint file used when --checkpoint-file is not set
+func defaultCheckpointPath(namespace, name string) string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("virtctl-image-upload-%s-%s.json", namespace, name))
+}
+
+func loadUploadCheckpoint(path string) (*uploadCheckpoint, error) {
+	// #nosec G304 No risk for path injection as this function executes with
+	// the same privileges as those of virtctl user who supplies the path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &uploadCheckpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %s: %v", path, err)
+	}
+	return checkpoint, nil
+}
+
+// save writes the checkpoint atomically, an interruption never leaves a partial checkpoint behind
+func (c *uploadCheckpoint) save(path string) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// resumes returns an error if the checkpoint does not record an upload of the same image to the same volume
+func (c *uploadCheckpoint) resumes(saved *uploadCheckpoint) error {
+	if c.Namespace != saved.Namespace || c.Name != saved.Name {
+		return fmt.Errorf("the checkpoint records an upload to %s/%s", saved.Namespace, saved.Name)
+	}
+	if c.ImagePath != saved.ImagePath {
+		return fmt.Errorf("the checkpoint records an upload of %s", saved.ImagePath)
+	}
+	if c.ImageSize != saved.ImageSize || !c.ImageModTime.Equal(saved.ImageModTime) {
+		return fmt.Errorf("%s changed since the upload was interrupted", c.ImagePath)
+	}
+	return nil
+}
@@ -21,8 +21,12 @@ package imageupload
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"net/url"
@@ -108,6 +112,11 @@ var (
 	createPVC         bool
 	forceBind         bool
 	archiveUpload     bool
+	sparse            bool
+	verify            bool
+	resume            bool
+	retries           uint
+	checkpointFile    string
 )
 
 // HTTPClientCreator is a function that creates http clients
@@ -160,6 +169,11 @@ func NewImageUploadCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd.Flags().BoolVar(&noCreate, "no-create", false, "Don't attempt to create a new DataVolume/PVC.")
 	cmd.Flags().UintVar(&uploadPodWaitSecs, "wait-secs", 300, "Seconds to wait for upload pod to start.")
 	cmd.Flags().BoolVar(&forceBind, "force-bind", false, "Force bind the PVC, ignoring the WaitForFirstConsumer logic.")
+	cmd.Flags().BoolVar(&sparse, "sparse", true, "Compress the zero regions of raw images away while uploading them.")
+	cmd.Flags().BoolVar(&verify, "verify", false, "Verify the checksum of the data written to the volume after the upload, only raw images can be verified.")
+	cmd.Flags().UintVar(&retries, "retries", 3, "Times an interrupted upload is sent again to the same DataVolume/PVC before giving up.")
+	cmd.Flags().StringVar(&checkpointFile, "checkpoint-file", "", "File recording the progress of the upload, defaults to a file in the temporary directory named after the namespace and the DataVolume/PVC.")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Resume the upload recorded in the checkpoint file, reusing its DataVolume/PVC and skipping the data once it was completely uploaded.")
 	cmd.Flags().StringVar(&defaultInstancetype, "default-instancetype", "", "The default instance type to associate with the image.")
 	cmd.Flags().StringVar(&defaultInstancetypeKind, "default-instancetype-kind", "", "The default instance type kind to associate with the image.")
 	cmd.Flags().StringVar(&defaultPreference, "default-preference", "", "The default preference to associate with the image.")
@@ -186,6 +200,12 @@ func usage() string {
   # Upload a local disk image to an existing PersistentVolumeClaim
   {{ProgramName}} image-upload pvc fedora-pvc --no-create --image-path=/images/fedora30.qcow2
 
+  # Upload a local raw disk image to a newly created DataVolume and verify the uploaded data
+  {{ProgramName}} image-upload dv fedora-dv --size=10Gi --image-path=/images/fedora30.img --verify
+
+  # Resume an interrupted upload to a DataVolume
+  {{ProgramName}} image-upload dv fedora-dv --image-path=/images/fedora30.qcow2 --resume
+
   # Upload to a DataVolume with explicit URL to CDI Upload Proxy
   {{ProgramName}} image-upload dv fedora-dv --uploadproxy-url=https://cdi-uploadproxy.mycluster.com --image-path=/images/fedora30.qcow2
 
@@ -229,6 +249,9 @@ func parseArgs(args []string) error {
 		if blockVolume {
 			return fmt.Errorf("In archive upload the volume mode should always be filesystem")
 		}
+		if verify {
+			return fmt.Errorf("cannot verify an archive upload")
+		}
 	}
 
 	if len(args) != 2 {
@@ -279,6 +302,14 @@ func (c *command) run(args []string) error {
 	}
 	defer util.CloseIOAndCheckErr(file, nil)
 
+	rawImage, err := isRawImage(file)
+	if err != nil {
+		return err
+	}
+	if verify && !rawImage {
+		return fmt.Errorf("cannot verify %s, only raw images can be verified", imagePath)
+	}
+
 	namespace, _, err := c.clientConfig.Namespace()
 	if err != nil {
 		return err
@@ -289,6 +320,72 @@ func (c *command) run(args []string) error {
 		return fmt.Errorf("cannot obtain KubeVirt client: %v", err)
 	}
 
+	if checkpointFile == "" {
+		checkpointFile = defaultCheckpointPath(namespace, name)
+	}
+	checkpoint, err := newUploadCheckpoint(namespace, name, file)
+	if err != nil {
+		return err
+	}
+	if resume {
+		saved, err := loadUploadCheckpoint(checkpointFile)
+		if err != nil {
+			return fmt.Errorf("cannot resume the upload: %v", err)
+		}
+		if err := checkpoint.resumes(saved); err != nil {
+			return fmt.Errorf("cannot resume the upload: %v", err)
+		}
+		checkpoint.Uploaded = saved.Uploaded
+		// The volume of the interrupted upload is reused
+		noCreate = true
+	}
+
+	var checksum hash.Hash
+	if verify {
+		checksum = sha256.New()
+	}
+
+	if checkpoint.Uploaded {
+		fmt.Printf("Data of %s already uploaded to %s/%s\n", imagePath, namespace, name)
+		if checksum != nil {
+			if _, err := io.Copy(checksum, file); err != nil {
+				return err
+			}
+		}
+	} else {
+		err = uploadImage(virtClient, namespace, file, rawImage, checkpoint, checksum)
+		if err != nil {
+			if _, statErr := os.Stat(checkpointFile); statErr == nil {
+				fmt.Printf("The upload can be resumed with --resume\n")
+			}
+			return err
+		}
+	}
+
+	fmt.Println("Uploading data completed successfully, waiting for processing to complete, you can hit ctrl-c without interrupting the progress")
+	err = UploadProcessingCompleteFunc(virtClient, namespace, name, processingWaitInterval, processingWaitTotal)
+	if err != nil {
+		fmt.Printf("Timed out waiting for post upload processing to complete, please check upload pod status for progress\n")
+		return err
+	}
+
+	if verify {
+		if err := verifyUpload(virtClient, namespace, name, hex.EncodeToString(checksum.Sum(nil)), checkpoint.ImageSize); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Remove(checkpointFile); err != nil && !os.IsNotExist(err) {
+		fmt.Printf("Failed to remove the checkpoint file %s: %v\n", checkpointFile, err)
+	}
+
+	fmt.Printf("Uploading %s completed successfully\n", imagePath)
+	return nil
+}
+
+// uploadImage prepares the volume and uploads the file to it. The interrupted uploads are sent again from the
+// start once the upload server is ready again, since the upload proxy does not accept data at an offset.
+func uploadImage(virtClient kubecli.KubevirtClient, namespace string, file *os.File, rawImage bool, checkpoint *uploadCheckpoint, checksum hash.Hash) error {
 	pvc, err := getAndValidateUploadPVC(virtClient, namespace, name, noCreate, archiveUpload)
 	if err != nil {
 		if !(k8serrors.IsNotFound(err) && !noCreate) {
@@ -323,17 +420,20 @@ func (c *command) run(args []string) error {
 		fmt.Printf("Using existing PVC %s/%s\n", namespace, pvc.Name)
 	}
 
-	if createPVC {
-		err = waitUploadServerReady(virtClient, namespace, name, uploadReadyWaitInterval, time.Duration(uploadPodWaitSecs)*time.Second)
-		if err != nil {
-			return err
-		}
-	} else {
-		err = waitDvUploadScheduled(virtClient, namespace, name, uploadReadyWaitInterval, time.Duration(uploadPodWaitSecs)*time.Second)
-		if err != nil {
-			return err
+	if err := checkpoint.save(checkpointFile); err != nil {
+		return fmt.Errorf("cannot write the checkpoint file: %v", err)
+	}
+
+	waitUploadReady := func() error {
+		if createPVC {
+			return waitUploadServerReady(virtClient, namespace, name, uploadReadyWaitInterval, time.Duration(uploadPodWaitSecs)*time.Second)
 		}
+		return waitDvUploadScheduled(virtClient, namespace, name, uploadReadyWaitInterval, time.Duration(uploadPodWaitSecs)*time.Second)
+	}
+	if err := waitUploadReady(); err != nil {
+		return err
 	}
+
 	if uploadProxyURL == "" {
 		uploadProxyURL, err = getUploadProxyURL(virtClient.CdiClient())
 		if err != nil {
@@ -355,25 +455,38 @@ func (c *command) run(args []string) error {
 
 	fmt.Printf("Uploading data to %s\n", uploadProxyURL)
 
-	token, err := getUploadToken(virtClient.CdiClient(), namespace, name)
-	if err != nil {
-		return err
-	}
+	for attempt := uint(0); ; attempt++ {
+		// The upload token is short lived, a new one is requested for every attempt
+		token, err := getUploadToken(virtClient.CdiClient(), namespace, name)
+		if err != nil {
+			return err
+		}
 
-	err = uploadData(uploadProxyURL, token, file, insecure)
-	if err != nil {
-		return err
-	}
+		err = uploadData(uploadProxyURL, token, file, insecure, sparse && rawImage && !archiveUpload, checksum)
+		if err == nil {
+			break
+		}
+		if attempt >= retries || !isRetriableUploadError(err) {
+			return err
+		}
 
-	fmt.Println("Uploading data completed successfully, waiting for processing to complete, you can hit ctrl-c without interrupting the progress")
-	err = UploadProcessingCompleteFunc(virtClient, namespace, name, processingWaitInterval, processingWaitTotal)
-	if err != nil {
-		fmt.Printf("Timed out waiting for post upload processing to complete, please check upload pod status for progress\n")
-	} else {
-		fmt.Printf("Uploading %s completed successfully\n", imagePath)
+		fmt.Printf("Upload interrupted: %v, uploading again (%d/%d)\n", err, attempt+1, retries)
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if checksum != nil {
+			checksum.Reset()
+		}
+		if err := waitUploadReady(); err != nil {
+			return err
+		}
 	}
 
-	return err
+	checkpoint.Uploaded = true
+	if err := checkpoint.save(checkpointFile); err != nil {
+		return fmt.Errorf("cannot write the checkpoint file: %v", err)
+	}
+	return nil
 }
 
 func getHTTPClient(insecure bool) *http.Client {
@@ -428,7 +541,29 @@ func ConstructUploadProxyPathAsync(uploadProxyURL, token string, insecure bool)
 	return u.String(), nil
 }
 
-func uploadData(uploadProxyURL, token string, file *os.File, insecure bool) error {
+// uploadStatusError is returned when the upload proxy rejects the upload
+type uploadStatusError struct {
+	statusCode int
+	body       string
+}
+
+func (e *uploadStatusError) Error() string {
+	return fmt.Sprintf("unexpected return value %d, %s", e.statusCode, e.body)
+}
+
+// isRetriableUploadError returns true if the upload was interrupted by the network or failed on the server side,
+// the requests the upload proxy refused are not uploaded again
+func isRetriableUploadError(err error) bool {
+	var statusErr *uploadStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// uploadData streams the file to the upload proxy, the zero regions of the file are compressed away when sparse is
+// set and the data read from the file is added to the checksum when one is passed
+func uploadData(uploadProxyURL, token string, file *os.File, insecure, sparse bool, checksum hash.Hash) error {
 	url, err := ConstructUploadProxyPathAsync(uploadProxyURL, token, insecure)
 	if err != nil {
 		return err
@@ -440,14 +575,25 @@ func uploadData(uploadProxyURL, token string, file *os.File, insecure bool) erro
 	}
 
 	bar := pb.New64(fi.Size()).SetUnits(pb.U_BYTES)
-	reader := bar.NewProxyReader(file)
+	var reader io.Reader = bar.NewProxyReader(file)
+	if checksum != nil {
+		reader = io.TeeReader(reader, checksum)
+	}
+	contentLength := fi.Size()
+	if sparse {
+		sparseReader := newSparseReader(reader)
+		defer sparseReader.Close()
+		reader = sparseReader
+		// The size of the compressed stream is only known once it is sent
+		contentLength = -1
+	}
 
 	client := httpClientCreatorFunc(insecure)
 	req, _ := http.NewRequest("POST", url, io.NopCloser(reader))
 
 	req.Header.Add("Authorization", "Bearer "+token)
 	req.Header.Add("Content-Type", "application/octet-stream")
-	req.ContentLength = fi.Size()
+	req.ContentLength = contentLength
 
 	fmt.Println()
 	bar.Start()
@@ -466,7 +612,7 @@ func uploadData(uploadProxyURL, token string, file *os.File, insecure bool) erro
 		if err != nil {
 			return err
 		}
-		return fmt.Errorf("unexpected return value %d, %s", resp.StatusCode, string(body))
+		return &uploadStatusError{statusCode: resp.StatusCode, body: string(body)}
 	}
 
 	return nil
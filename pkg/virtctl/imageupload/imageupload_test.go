@@ -1,11 +1,17 @@
 package imageupload_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -27,6 +33,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
+	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
 	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
 	"kubevirt.io/kubevirt/pkg/virtctl/utils"
 	"kubevirt.io/kubevirt/tests"
@@ -56,6 +63,8 @@ const (
 
 var _ = Describe("ImageUpload", func() {
 
+	origUploadedChecksumFunc := imageupload.UploadedChecksumFunc
+
 	var (
 		ctrl       *gomock.Controller
 		kubeClient *fakek8sclient.Clientset
@@ -68,12 +77,19 @@ var _ = Describe("ImageUpload", func() {
 
 		imagePath       string
 		archiveFilePath string
+
+		receivedLock sync.Mutex
+		received     []byte
+		uploads      int
+		failUploads  int
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
 		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		// the failed uploads leave their checkpoint file in the temporary directory
+		GinkgoT().Setenv("TMPDIR", GinkgoT().TempDir())
 
 		imageFile, err := os.CreateTemp("", "test_image")
 		Expect(err).ToNot(HaveOccurred())
@@ -283,6 +299,11 @@ var _ = Describe("ImageUpload", func() {
 			return false, nil, nil
 		})
 
+		// the upload token requests are not persisted, every upload attempt requests a token with the same name
+		cdiClient.Fake.PrependReactor("create", "uploadtokenrequests", func(action testing.Action) (bool, runtime.Object, error) {
+			return true, action.(testing.CreateAction).GetObject(), nil
+		})
+
 		kubeClient.Fake.PrependReactor("get", "storageclasses", func(action testing.Action) (bool, runtime.Object, error) {
 			_, ok := action.(testing.GetAction)
 			Expect(ok).To(BeTrue())
@@ -442,6 +463,8 @@ var _ = Describe("ImageUpload", func() {
 		dvCreateCalled.False()
 		pvcCreateCalled.False()
 		updateCalled.False()
+		uploads = 0
+		failUploads = 0
 
 		config := createCDIConfig()
 		cdiobjects = append(cdiobjects, config)
@@ -464,6 +487,16 @@ var _ = Describe("ImageUpload", func() {
 				}
 				return
 			}
+			body, err := io.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			receivedLock.Lock()
+			defer receivedLock.Unlock()
+			received = body
+			uploads++
+			if uploads <= failUploads {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
 			w.WriteHeader(statusCode)
 		}))
 		config.Status.UploadProxyURL = &server.URL
@@ -488,6 +521,22 @@ var _ = Describe("ImageUpload", func() {
 		server.Close()
 	}
 
+	receivedData := func() []byte {
+		receivedLock.Lock()
+		defer receivedLock.Unlock()
+		return received
+	}
+
+	uploadCount := func() int {
+		receivedLock.Lock()
+		defer receivedLock.Unlock()
+		return uploads
+	}
+
+	writeImage := func(data []byte) {
+		Expect(os.WriteFile(imagePath, data, 0600)).To(Succeed())
+	}
+
 	Context("Successful upload to PVC", func() {
 
 		It("PVC does not exist deprecated args", func() {
@@ -787,6 +836,8 @@ var _ = Describe("ImageUpload", func() {
 				[]string{"dv", targetName, "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null", "--archive-path", "/dev/null.tar"}),
 			Entry("Archive path and block volume true provided", "In archive upload the volume mode should always be filesystem",
 				[]string{"dv", targetName, "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--archive-path", "/dev/null.tar", "--block-volume"}),
+			Entry("Archive path and verify provided", "cannot verify an archive upload",
+				[]string{"dv", targetName, "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--archive-path", "/dev/null.tar", "--verify"}),
 			Entry("PVC name and args", "cannot use --pvc-name and args",
 				[]string{"foo", "--pvc-name", targetName, "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null"}),
 			Entry("Unexpected resource type", "invalid resource type foo",
@@ -808,6 +859,219 @@ var _ = Describe("ImageUpload", func() {
 		})
 	})
 
+	Context("Sparse and verified upload", func() {
+		It("Should compress the zero regions of raw images away", func() {
+			image := make([]byte, 5*1024*1024)
+			copy(image[2*1024*1024:], "hello world")
+			writeImage(image)
+			testInit(http.StatusOK)
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName, "--size", pvcSize,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath)
+			Expect(cmd()).To(Succeed())
+
+			Expect(len(receivedData())).To(BeNumerically("<", 2*1024*1024))
+			reader, err := gzip.NewReader(bytes.NewReader(receivedData()))
+			Expect(err).ToNot(HaveOccurred())
+			uploaded, err := io.ReadAll(reader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uploaded).To(Equal(image))
+		})
+
+		DescribeTable("Should upload the image as it is", func(image []byte, extraArgs ...string) {
+			writeImage(image)
+			testInit(http.StatusOK)
+			args := append([]string{commandName, "pvc", targetName, "--size", pvcSize,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath}, extraArgs...)
+			Expect(clientcmd.NewRepeatableVirtctlCommand(args...)()).To(Succeed())
+			Expect(receivedData()).To(Equal(image))
+		},
+			Entry("when the image is not raw", append([]byte("QFI\xfb"), make([]byte, 1024)...)),
+			Entry("when sparse upload is disabled", make([]byte, 1024), "--sparse=false"),
+		)
+
+		Context("with --verify", func() {
+			BeforeEach(func() {
+				guestfs.SetImageInfoGetFunc(func(kubecli.KubevirtClient) (*kubecli.GuestfsInfo, error) {
+					return &kubecli.GuestfsInfo{Registry: "someregistry", Tag: "sometag"}, nil
+				})
+				DeferCleanup(func() {
+					guestfs.SetDefaultImageInfoGetFunc()
+					imageupload.UploadedChecksumFunc = origUploadedChecksumFunc
+				})
+			})
+
+			It("Should verify the checksum of the uploaded data", func() {
+				testInit(http.StatusOK)
+				called := false
+				imageupload.UploadedChecksumFunc = func(_ kubernetes.Interface, namespace, name, image string, size int64, _, _ time.Duration) (string, error) {
+					Expect(namespace).To(Equal(targetNamespace))
+					Expect(name).To(Equal(targetName))
+					Expect(image).To(Equal("someregistry/libguestfs-tools:sometag"))
+					Expect(size).To(BeEquivalentTo(len("hello world")))
+					called = true
+					return fmt.Sprintf("%x", sha256.Sum256([]byte("hello world"))), nil
+				}
+				cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "dv", targetName, "--size", pvcSize,
+					"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--verify")
+				Expect(cmd()).To(Succeed())
+				Expect(called).To(BeTrue())
+			})
+
+			It("Should fail when the uploaded data does not match the image", func() {
+				testInit(http.StatusOK)
+				kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (bool, runtime.Object, error) {
+					pod := action.(testing.CreateAction).GetObject().(*v1.Pod)
+					Expect(pod.Spec.Containers[0].Image).To(Equal("someregistry/libguestfs-tools:sometag"))
+					Expect(pod.Spec.Containers[0].Command).To(Equal([]string{"/bin/sh", "-c", "head -c 11 /disk/disk.img | sha256sum"}))
+					Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(targetName))
+					pod.Status.Phase = v1.PodSucceeded
+					return false, pod, nil
+				})
+				cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName, "--size", pvcSize,
+					"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--verify")
+				err := cmd()
+				Expect(err).To(MatchError(ContainSubstring("does not match the checksum of the image")))
+				_, err = kubeClient.CoreV1().Pods(targetNamespace).Get(context.Background(), targetName+"-verify", metav1.GetOptions{})
+				Expect(err).To(MatchError(ContainSubstring("not found")))
+			})
+
+			It("Should refuse to verify images that are not raw", func() {
+				writeImage(append([]byte("QFI\xfb"), make([]byte, 1024)...))
+				testInit(http.StatusOK)
+				cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName, "--size", pvcSize,
+					"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--verify")
+				Expect(cmd()).To(MatchError(fmt.Sprintf("cannot verify %s, only raw images can be verified", imagePath)))
+				Expect(pvcCreateCalled.IsTrue()).To(BeFalse())
+			})
+		})
+
+		AfterEach(func() {
+			testDone()
+		})
+	})
+
+	Context("Resumable upload", func() {
+		var checkpointFile string
+
+		BeforeEach(func() {
+			checkpointFile = filepath.Join(GinkgoT().TempDir(), "checkpoint.json")
+		})
+
+		writeCheckpoint := func(modify func(checkpoint map[string]interface{})) {
+			fi, err := os.Stat(imagePath)
+			Expect(err).ToNot(HaveOccurred())
+			checkpoint := map[string]interface{}{
+				"namespace":    targetNamespace,
+				"name":         targetName,
+				"imagePath":    imagePath,
+				"imageSize":    fi.Size(),
+				"imageModTime": fi.ModTime().UTC(),
+			}
+			modify(checkpoint)
+			data, err := json.Marshal(checkpoint)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.WriteFile(checkpointFile, data, 0600)).To(Succeed())
+		}
+
+		It("Should upload the image again when the upload is interrupted", func() {
+			testInit(http.StatusOK)
+			failUploads = 2
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName, "--size", pvcSize,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--checkpoint-file", checkpointFile,
+				"--sparse=false")
+			Expect(cmd()).To(Succeed())
+			Expect(uploadCount()).To(Equal(3))
+			Expect(receivedData()).To(Equal([]byte("hello world")))
+			Expect(checkpointFile).ToNot(BeAnExistingFile())
+		})
+
+		It("Should give up after the retries", func() {
+			testInit(http.StatusOK)
+			failUploads = 3
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName, "--size", pvcSize,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--checkpoint-file", checkpointFile,
+				"--retries", "2")
+			Expect(cmd()).To(MatchError(ContainSubstring("unexpected return value 503")))
+			Expect(uploadCount()).To(Equal(3))
+			Expect(checkpointFile).To(BeAnExistingFile())
+		})
+
+		It("Should not upload the image again when the upload proxy refuses it", func() {
+			testInit(http.StatusBadRequest)
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName, "--size", pvcSize,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--checkpoint-file", checkpointFile)
+			Expect(cmd()).To(MatchError(ContainSubstring("unexpected return value 400")))
+			Expect(uploadCount()).To(Equal(1))
+		})
+
+		It("Should resume the upload to the volume of the interrupted upload", func() {
+			testInit(http.StatusOK)
+			failUploads = 1
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName, "--size", pvcSize,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--checkpoint-file", checkpointFile,
+				"--retries", "0")
+			Expect(cmd()).ToNot(Succeed())
+			Expect(pvcCreateCalled.IsTrue()).To(BeTrue())
+			pvcCreateCalled.False()
+
+			cmd = clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--checkpoint-file", checkpointFile,
+				"--resume", "--sparse=false")
+			Expect(cmd()).To(Succeed())
+			Expect(pvcCreateCalled.IsTrue()).To(BeFalse())
+			Expect(uploadCount()).To(Equal(2))
+			Expect(receivedData()).To(Equal([]byte("hello world")))
+			Expect(checkpointFile).ToNot(BeAnExistingFile())
+		})
+
+		It("Should not upload the image again once it was completely uploaded", func() {
+			testInit(http.StatusOK, pvcSpec())
+			writeCheckpoint(func(checkpoint map[string]interface{}) {
+				checkpoint["uploaded"] = true
+			})
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--checkpoint-file", checkpointFile,
+				"--resume")
+			Expect(cmd()).To(Succeed())
+			Expect(uploadCount()).To(BeZero())
+			Expect(checkpointFile).ToNot(BeAnExistingFile())
+		})
+
+		DescribeTable("Should refuse to resume", func(expected string, modify func(checkpoint map[string]interface{})) {
+			testInit(http.StatusOK, pvcSpec())
+			writeCheckpoint(modify)
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--checkpoint-file", checkpointFile,
+				"--resume")
+			Expect(cmd()).To(MatchError(ContainSubstring(expected)))
+			Expect(uploadCount()).To(BeZero())
+		},
+			Entry("an upload to another volume", "the checkpoint records an upload to default/other", func(checkpoint map[string]interface{}) {
+				checkpoint["name"] = "other"
+			}),
+			Entry("an upload of another image", "the checkpoint records an upload of /other.img", func(checkpoint map[string]interface{}) {
+				checkpoint["imagePath"] = "/other.img"
+			}),
+			Entry("an upload of a modified image", "changed since the upload was interrupted", func(checkpoint map[string]interface{}) {
+				checkpoint["imageSize"] = 1
+			}),
+		)
+
+		It("Should refuse to resume without a checkpoint", func() {
+			testInit(http.StatusOK, pvcSpec())
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, "pvc", targetName,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "--checkpoint-file", checkpointFile,
+				"--resume")
+			Expect(cmd()).To(MatchError(ContainSubstring("cannot resume the upload")))
+		})
+
+		AfterEach(func() {
+			testDone()
+		})
+	})
+
 	Context("URL validation", func() {
 		serverURL := "http://localhost:12345"
 		DescribeTable("Server URL validations", func(serverUrl string, expected string) {
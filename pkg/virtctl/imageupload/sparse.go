@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package imageupload
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+)
+
+// sparseBlockSize is the granularity at which the zero regions of the image are detected
+const sparseBlockSize = 1024 * 1024
+
+// imageMagics are the headers of the formats CDI converts or decompresses, an image without any of them is raw
+var imageMagics = []struct {
+	offset int64
+	magic  []byte
+}{
+	{0, []byte("QFI\xfb")},               // qcow2
+	{0, []byte{0x1f, 0x8b}},              // gzip
+	{0, []byte("\xfd7zXZ\x00")},          // xz
+	{0, []byte{0x28, 0xb5, 0x2f, 0xfd}},  // zstd
+	{0, []byte("KDMV")},                  // vmdk
+	{0, []byte("# Disk DescriptorFile")}, // vmdk descriptor
+	{0, []byte("vhdxfile")},              // vhdx
+	{0, []byte("conectix")},              // vhd
+	{64, []byte{0x7f, 0x10, 0xda, 0xbe}}, // vdi
+	{257, []byte("ustar")},               // tar
+}
+
+var zeroBlock = make([]byte, sparseBlockSize)
+
+// isRawImage tells whether the image is a raw disk, which CDI writes to the volume as it is
+func isRawImage(r io.ReaderAt) (bool, error) {
+	header := make([]byte, 512)
+	n, err := r.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	header = header[:n]
+	for _, m := range imageMagics {
+		end := m.offset + int64(len(m.magic))
+		if end <= int64(len(header)) && bytes.Equal(header[m.offset:end], m.magic) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// newSparseReader streams the raw image as gzip, which CDI decompresses while writing the volume. The zero blocks
+// are compressed in their own gzip members so that they take almost no space on the wire, while the data blocks are
+// only stored to not spend CPU on data that does not compress well.
+func newSparseReader(r io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeSparse(pw, r))
+	}()
+	return pr
+}
+
+func writeSparse(w io.Writer, r io.Reader) error {
+	// The levels are valid, creating the writers cannot fail
+	data, _ := gzip.NewWriterLevel(w, gzip.NoCompression)
+	zeros, _ := gzip.NewWriterLevel(w, gzip.BestSpeed)
+
+	// Every change between zero and data blocks closes the current member and starts a new one, the members are
+	// concatenated into a single multistream gzip
+	current := data
+	started := false
+	buf := make([]byte, sparseBlockSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			next := data
+			if bytes.Equal(buf[:n], zeroBlock[:n]) {
+				next = zeros
+			}
+			if next != current {
+				if started {
+					if err := current.Close(); err != nil {
+						return err
+					}
+				}
+				next.Reset(w)
+				current = next
+			}
+			started = true
+			if _, err := current.Write(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return current.Close()
+}
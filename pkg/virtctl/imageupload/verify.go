@@ -0,0 +1,167 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package imageupload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
+)
+
+const (
+	verifyPodSuffix     = "-verify"
+	verifyContainerName = "verify"
+	verifyVolumeName    = "volume"
+	verifyDiskDir       = "/disk"
+	verifyDiskPath      = verifyDiskDir + "/disk.img"
+	verifyDevicePath    = "/dev/vda"
+)
+
+type uploadedChecksumFunc func(kubernetes.Interface, string, string, string, int64, time.Duration, time.Duration) (string, error)
+
+// UploadedChecksumFunc the function called to read the sha256 checksum of the data written to the volume.
+var UploadedChecksumFunc uploadedChecksumFunc = getUploadedChecksum
+
+// verifyUpload compares the checksum of the uploaded image with the checksum of the data CDI wrote to the volume.
+// The volume may be larger than the image, only as many bytes as the image has are read back.
+func verifyUpload(client kubecli.KubevirtClient, namespace, name, checksum string, size int64) error {
+	fmt.Println("Verifying the uploaded data")
+	image, err := guestfs.GetImage(client)
+	if err != nil {
+		return err
+	}
+	uploaded, err := UploadedChecksumFunc(client, namespace, name, image, size, processingWaitInterval, processingWaitTotal)
+	if err != nil {
+		return fmt.Errorf("failed to read the checksum of the uploaded data: %v", err)
+	}
+	if uploaded != checksum {
+		return fmt.Errorf("the checksum of the uploaded data %s does not match the checksum of the image %s", uploaded, checksum)
+	}
+	fmt.Printf("Checksum %s of the uploaded data verified\n", uploaded)
+	return nil
+}
+
+func getUploadedChecksum(client kubernetes.Interface, namespace, name, image string, size int64, interval, timeout time.Duration) (string, error) {
+	pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Create(context.Background(), newVerifyPod(pvc, image, size), metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := client.CoreV1().Pods(namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{}); err != nil {
+			fmt.Printf("Failed to delete pod %s/%s: %v\n", namespace, pod.Name, err)
+		}
+	}()
+
+	err = wait.PollImmediate(interval, timeout, func() (bool, error) {
+		pod, err := client.CoreV1().Pods(namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if pod.Status.Phase == v1.PodFailed {
+			return false, fmt.Errorf("pod %s/%s failed", namespace, pod.Name)
+		}
+		return pod.Status.Phase == v1.PodSucceeded, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	logs, err := client.CoreV1().Pods(namespace).GetLogs(pod.Name, &v1.PodLogOptions{Container: verifyContainerName}).DoRaw(context.Background())
+	if err != nil {
+		return "", err
+	}
+	// sha256sum prints the checksum followed by the file name
+	fields := strings.Fields(string(logs))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("pod %s/%s did not report a checksum", namespace, pod.Name)
+	}
+	return fields[0], nil
+}
+
+func newVerifyPod(pvc *v1.PersistentVolumeClaim, image string, size int64) *v1.Pod {
+	container := v1.Container{
+		Name:  verifyContainerName,
+		Image: image,
+		SecurityContext: &v1.SecurityContext{
+			AllowPrivilegeEscalation: pointer.Bool(false),
+			Capabilities: &v1.Capabilities{
+				Drop: []v1.Capability{"ALL"},
+			},
+		},
+	}
+
+	path := verifyDiskPath
+	if pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == v1.PersistentVolumeBlock {
+		path = verifyDevicePath
+		container.VolumeDevices = []v1.VolumeDevice{{
+			Name:       verifyVolumeName,
+			DevicePath: verifyDevicePath,
+		}}
+	} else {
+		container.VolumeMounts = []v1.VolumeMount{{
+			Name:      verifyVolumeName,
+			ReadOnly:  true,
+			MountPath: verifyDiskDir,
+		}}
+	}
+	container.Command = []string{"/bin/sh", "-c", fmt.Sprintf("head -c %d %s | sha256sum", size, path)}
+
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvc.Name + verifyPodSuffix,
+			Namespace: pvc.Namespace,
+		},
+		Spec: v1.PodSpec{
+			SecurityContext: &v1.PodSecurityContext{
+				RunAsNonRoot: pointer.Bool(true),
+				SeccompProfile: &v1.SeccompProfile{
+					Type: v1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Containers: []v1.Container{container},
+			Volumes: []v1.Volume{{
+				Name: verifyVolumeName,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						ClaimName: pvc.Name,
+						ReadOnly:  true,
+					},
+				},
+			}},
+			RestartPolicy: v1.RestartPolicyNever,
+		},
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package instancetype
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_INSTANCETYPE = "instancetype"
+	COMMAND_DIFF         = "diff"
+	COMMAND_UPGRADE      = "upgrade"
+
+	outputFormatArg      = "output"
+	outputFormatArgShort = "o"
+
+	TABLE = "table"
+	JSON  = "json"
+	YAML  = "yaml"
+)
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   COMMAND_INSTANCETYPE,
+		Short: "Inspect and upgrade the instancetype and preference revisions of virtual machines.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Print(cmd.UsageString())
+		},
+	}
+
+	cmd.AddCommand(
+		NewDiffCommand(clientConfig),
+		NewUpgradeCommand(clientConfig),
+	)
+
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewDiffCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	var outputFormat string
+	cmd := &cobra.Command{
+		Use:     "diff (VM)",
+		Short:   "Show the difference between the stored instancetype and preference revisions of a virtual machine and the current objects.",
+		Example: usageDiff(),
+		Args:    templates.ExactArgs(COMMAND_DIFF, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(clientConfig, cmd, args[0], outputFormat)
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, outputFormatArg, outputFormatArgShort, TABLE, "Specify a format that will be used to display output, one of table, json or yaml.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usageDiff() string {
+	return `  # Show what changed in the instancetype and preference of a virtual machine called 'myvm':
+  {{ProgramName}} instancetype diff myvm
+
+  # Show the difference in yaml format:
+  {{ProgramName}} instancetype diff myvm --output yaml`
+}
+
+func runDiff(clientConfig clientcmd.ClientConfig, cmd *cobra.Command, name, format string) error {
+	if format != TABLE && format != JSON && format != YAML {
+		return fmt.Errorf("not supported output format defined: %s", format)
+	}
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	diff, err := virtClient.VirtualMachine(namespace).InstancetypeDiff(cmd.Context(), name)
+	if err != nil {
+		return fmt.Errorf("error getting the instancetype diff of VirtualMachine %s in namespace %s: %w", name, namespace, err)
+	}
+
+	var output []byte
+	switch format {
+	case JSON:
+		output, err = json.MarshalIndent(diff, "", "  ")
+	case YAML:
+		output, err = yaml.Marshal(diff)
+	default:
+		return printDiffTable(cmd.OutOrStdout(), diff)
+	}
+	if err != nil {
+		return err
+	}
+	cmd.Println(string(output))
+	return nil
+}
+
+func printDiffTable(out io.Writer, diff *v1.VirtualMachineInstancetypeDiff) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "MATCHER\tNAME\tREVISION\tUP-TO-DATE\tPATH\tSTORED\tCURRENT")
+	printRevisionDiff(w, "instancetype", diff.Instancetype)
+	printRevisionDiff(w, "preference", diff.Preference)
+	return w.Flush()
+}
+
+func printRevisionDiff(w io.Writer, matcher string, diff *v1.InstancetypeRevisionDiff) {
+	if diff == nil {
+		return
+	}
+
+	prefix := fmt.Sprintf("%s\t%s\t%s\t%s", matcher, diff.Name, valueOrNone(diff.RevisionName), strconv.FormatBool(diff.UpToDate))
+	if len(diff.Changes) == 0 {
+		fmt.Fprintf(w, "%s\t\t\t\n", prefix)
+		return
+	}
+	for _, change := range diff.Changes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", prefix, change.Path, valueOrNone(change.Stored), valueOrNone(change.Current))
+	}
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
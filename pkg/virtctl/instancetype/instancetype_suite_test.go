@@ -0,0 +1,11 @@
+package instancetype_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestInstancetype(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,180 @@
+package instancetype_test
+
+import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/api/core/v1"
+	apiinstancetype "kubevirt.io/api/instancetype"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("Instancetype command", func() {
+	const vmName = "testvm"
+
+	var (
+		ctrl        *gomock.Controller
+		vmInterface *kubecli.MockVirtualMachineInterface
+		outdated    *v1.VirtualMachineInstancetypeDiff
+		upToDate    *v1.VirtualMachineInstancetypeDiff
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+
+		outdated = &v1.VirtualMachineInstancetypeDiff{
+			Instancetype: &v1.InstancetypeRevisionDiff{
+				Name:         "u1.medium",
+				RevisionName: "testvm-u1.medium-1",
+				Changes: []v1.InstancetypeSpecChange{
+					{Path: "cpu.guest", Stored: "1", Current: "2"},
+					{Path: "memory.hugepages.pageSize", Current: `"2Mi"`},
+				},
+			},
+			Preference: &v1.InstancetypeRevisionDiff{
+				Name:         "fedora",
+				RevisionName: "testvm-fedora-1",
+				UpToDate:     true,
+			},
+		}
+		upToDate = &v1.VirtualMachineInstancetypeDiff{
+			Instancetype: &v1.InstancetypeRevisionDiff{Name: "u1.medium", UpToDate: true},
+		}
+	})
+
+	Context("diff", func() {
+		It("should print the difference as a table", func() {
+			vmInterface.EXPECT().InstancetypeDiff(gomock.Any(), vmName).Return(outdated, nil)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("instancetype", "diff", vmName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal(
+				"MATCHER       NAME       REVISION            UP-TO-DATE  PATH                       STORED  CURRENT\n" +
+					"instancetype  u1.medium  testvm-u1.medium-1  false       cpu.guest                  1       2\n" +
+					"instancetype  u1.medium  testvm-u1.medium-1  false       memory.hugepages.pageSize  <none>  \"2Mi\"\n" +
+					"preference    fedora     testvm-fedora-1     true                                           \n",
+			))
+		})
+
+		It("should print the difference in yaml format", func() {
+			vmInterface.EXPECT().InstancetypeDiff(gomock.Any(), vmName).Return(outdated, nil)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("instancetype", "diff", vmName, "--output", "yaml")()
+			Expect(err).ToNot(HaveOccurred())
+
+			printed := &v1.VirtualMachineInstancetypeDiff{}
+			Expect(yaml.Unmarshal(out, printed)).To(Succeed())
+			Expect(printed).To(Equal(outdated))
+		})
+
+		It("should fail with an unsupported output format", func() {
+			err := clientcmd.NewRepeatableVirtctlCommand("instancetype", "diff", vmName, "--output", "xml")()
+			Expect(err).To(MatchError("not supported output format defined: xml"))
+		})
+	})
+
+	Context("upgrade", func() {
+		newVM := func(name, namespace string, instancetype *v1.InstancetypeMatcher, preference *v1.PreferenceMatcher) v1.VirtualMachine {
+			return v1.VirtualMachine{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: v1.VirtualMachineSpec{
+					Instancetype: instancetype,
+					Preference:   preference,
+				},
+			}
+		}
+
+		It("should upgrade a single VM", func() {
+			vmInterface.EXPECT().InstancetypeUpgrade(gomock.Any(), vmName, &v1.InstancetypeUpgradeOptions{}).Return(outdated, nil)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("instancetype", "upgrade", vmName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal("VM default/testvm upgraded to the current instancetype u1.medium (2 changes)\n"))
+		})
+
+		It("should report hotplugged CPU sockets", func() {
+			hotplugged := outdated.DeepCopy()
+			hotplugged.CPUHotplugged = true
+			vmInterface.EXPECT().InstancetypeUpgrade(gomock.Any(), vmName, &v1.InstancetypeUpgradeOptions{}).Return(hotplugged, nil)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("instancetype", "upgrade", vmName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(HaveSuffix("VM default/testvm CPU sockets hotplugged\n"))
+		})
+
+		It("should report a VM which is already up to date", func() {
+			vmInterface.EXPECT().InstancetypeUpgrade(gomock.Any(), vmName, &v1.InstancetypeUpgradeOptions{}).Return(upToDate, nil)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("instancetype", "upgrade", vmName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal("VM default/testvm is up to date\n"))
+		})
+
+		It("should pass dry run to the upgrade", func() {
+			vmInterface.EXPECT().InstancetypeUpgrade(gomock.Any(), vmName, &v1.InstancetypeUpgradeOptions{DryRun: []string{k8smetav1.DryRunAll}}).Return(outdated, nil)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("instancetype", "upgrade", vmName, "--dry-run")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("(dry run)"))
+		})
+
+		It("should upgrade the VMs using the selected instancetype", func() {
+			vmInterface.EXPECT().List(gomock.Any(), gomock.Any()).Return(&v1.VirtualMachineList{
+				Items: []v1.VirtualMachine{
+					newVM("cluster", k8smetav1.NamespaceDefault, &v1.InstancetypeMatcher{Name: "u1.medium"}, nil),
+					newVM("namespaced", k8smetav1.NamespaceDefault, &v1.InstancetypeMatcher{Name: "u1.medium", Kind: apiinstancetype.SingularResourceName}, nil),
+					newVM("other", k8smetav1.NamespaceDefault, &v1.InstancetypeMatcher{Name: "u1.large"}, nil),
+					newVM("none", k8smetav1.NamespaceDefault, nil, nil),
+				},
+			}, nil)
+			vmInterface.EXPECT().InstancetypeUpgrade(gomock.Any(), "cluster", gomock.Any()).Return(outdated, nil)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("instancetype", "upgrade", "--instancetype", "u1.medium", "--kind", "cluster")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal("VM default/cluster upgraded to the current instancetype u1.medium (2 changes)\n"))
+		})
+
+		It("should select VMs across all namespaces and keep going on errors", func() {
+			otherInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
+			allInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceAll).Return(allInterface)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine("other").Return(otherInterface).AnyTimes()
+
+			preference := &v1.PreferenceMatcher{Name: "fedora"}
+			allInterface.EXPECT().List(gomock.Any(), gomock.Any()).Return(&v1.VirtualMachineList{
+				Items: []v1.VirtualMachine{
+					newVM("failing", "other", nil, preference),
+					newVM("current", k8smetav1.NamespaceDefault, nil, preference),
+				},
+			}, nil)
+			otherInterface.EXPECT().InstancetypeUpgrade(gomock.Any(), "failing", gomock.Any()).Return(nil, fmt.Errorf("conflict"))
+			vmInterface.EXPECT().InstancetypeUpgrade(gomock.Any(), "current", gomock.Any()).Return(upToDate, nil)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("instancetype", "upgrade", "--preference", "fedora", "--all-namespaces")()
+			Expect(err).To(MatchError(ContainSubstring("error upgrading the instancetype of VirtualMachine failing in namespace other: conflict")))
+			Expect(string(out)).To(ContainSubstring("VM default/current is up to date"))
+		})
+
+		DescribeTable("should reject invalid arguments", func(expectedErr string, args ...string) {
+			err := clientcmd.NewRepeatableVirtctlCommand(append([]string{"instancetype", "upgrade"}, args...)...)()
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+			Entry("without VM and selector", "either a VM name or one of the --instancetype and --preference flags is required"),
+			Entry("with VM and selector", "a VM name cannot be combined", vmName, "--instancetype", "u1.medium"),
+			Entry("with VM and all namespaces", "--all-namespaces can only be used", vmName, "--all-namespaces"),
+			Entry("with an unknown kind", "unsupported kind foo", "--instancetype", "u1.medium", "--kind", "foo"),
+		)
+	})
+})
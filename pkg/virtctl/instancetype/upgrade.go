@@ -0,0 +1,218 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package instancetype
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/api/core/v1"
+	apiinstancetype "kubevirt.io/api/instancetype"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	instancetypeArg  = "instancetype"
+	preferenceArg    = "preference"
+	kindArg          = "kind"
+	allNamespacesArg = "all-namespaces"
+	dryRunArg        = "dry-run"
+
+	clusterScope    = "cluster"
+	namespacedScope = "namespaced"
+)
+
+type upgrade struct {
+	clientConfig  clientcmd.ClientConfig
+	instancetype  string
+	preference    string
+	kind          string
+	allNamespaces bool
+	dryRun        bool
+}
+
+func NewUpgradeCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := upgrade{clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:     "upgrade [VM]",
+		Short:   "Upgrade virtual machines to the current revision of their instancetype and preference.",
+		Long:    "Upgrade virtual machines to the current revision of their instancetype and preference.\nThe new revision is applied with the next restart of a running virtual machine, CPU sockets are hotplugged right away when the running virtual machine has room for them.",
+		Example: usageUpgrade(),
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    c.run,
+	}
+	cmd.Flags().StringVar(&c.instancetype, instancetypeArg, "", "Upgrade all virtual machines using the instancetype with this name.")
+	cmd.Flags().StringVar(&c.preference, preferenceArg, "", "Upgrade all virtual machines using the preference with this name.")
+	cmd.Flags().StringVar(&c.kind, kindArg, "", "Only select instancetypes and preferences of this kind, one of cluster or namespaced. Selects both by default.")
+	cmd.Flags().BoolVarP(&c.allNamespaces, allNamespacesArg, "A", false, "Select virtual machines across all namespaces.")
+	cmd.Flags().BoolVar(&c.dryRun, dryRunArg, false, "If true, only report the virtual machines that would be upgraded without upgrading them.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usageUpgrade() string {
+	return `  # Upgrade a virtual machine called 'myvm' to the current revision of its instancetype and preference:
+  {{ProgramName}} instancetype upgrade myvm
+
+  # Upgrade all virtual machines of the namespace using the cluster instancetype 'u1.medium':
+  {{ProgramName}} instancetype upgrade --instancetype u1.medium --kind cluster
+
+  # Show which virtual machines across all namespaces using the preference 'fedora' would be upgraded:
+  {{ProgramName}} instancetype upgrade --preference fedora --all-namespaces --dry-run`
+}
+
+func (c *upgrade) run(cmd *cobra.Command, args []string) error {
+	bulk := c.instancetype != "" || c.preference != ""
+	if len(args) == 1 && bulk {
+		return fmt.Errorf("a VM name cannot be combined with the --%s and --%s flags", instancetypeArg, preferenceArg)
+	}
+	if len(args) == 0 && !bulk {
+		return fmt.Errorf("either a VM name or one of the --%s and --%s flags is required", instancetypeArg, preferenceArg)
+	}
+	if len(args) == 1 && c.allNamespaces {
+		return fmt.Errorf("--%s can only be used together with the --%s and --%s flags", allNamespacesArg, instancetypeArg, preferenceArg)
+	}
+	if c.kind != "" && c.kind != clusterScope && c.kind != namespacedScope {
+		return fmt.Errorf("unsupported kind %s, use %s or %s", c.kind, clusterScope, namespacedScope)
+	}
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	if len(args) == 1 {
+		return c.upgradeVM(cmd, virtClient, namespace, args[0])
+	}
+
+	if c.allNamespaces {
+		namespace = k8smetav1.NamespaceAll
+	}
+	vms, err := virtClient.VirtualMachine(namespace).List(cmd.Context(), &k8smetav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing VirtualMachines: %w", err)
+	}
+
+	var errs []error
+	selected := 0
+	for i := range vms.Items {
+		vm := &vms.Items[i]
+		if !c.selects(vm) {
+			continue
+		}
+		selected++
+		if err := c.upgradeVM(cmd, virtClient, vm.Namespace, vm.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if selected == 0 {
+		cmd.Println("No VirtualMachines found using the given instancetype or preference")
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *upgrade) upgradeVM(cmd *cobra.Command, virtClient kubecli.KubevirtClient, namespace, name string) error {
+	opts := &v1.InstancetypeUpgradeOptions{}
+	if c.dryRun {
+		opts.DryRun = []string{k8smetav1.DryRunAll}
+	}
+
+	diff, err := virtClient.VirtualMachine(namespace).InstancetypeUpgrade(cmd.Context(), name, opts)
+	if err != nil {
+		return fmt.Errorf("error upgrading the instancetype of VirtualMachine %s in namespace %s: %w", name, namespace, err)
+	}
+
+	var upgraded []string
+	for _, matcher := range []struct {
+		name string
+		diff *v1.InstancetypeRevisionDiff
+	}{
+		{"instancetype", diff.Instancetype},
+		{"preference", diff.Preference},
+	} {
+		if matcher.diff != nil && !matcher.diff.UpToDate {
+			upgraded = append(upgraded, fmt.Sprintf("%s %s (%d changes)", matcher.name, matcher.diff.Name, len(matcher.diff.Changes)))
+		}
+	}
+
+	if len(upgraded) == 0 {
+		cmd.Printf("VM %s/%s is up to date\n", namespace, name)
+		return nil
+	}
+
+	dryRunSuffix := ""
+	if c.dryRun {
+		dryRunSuffix = " (dry run)"
+	}
+	cmd.Printf("VM %s/%s upgraded to the current %s%s\n", namespace, name, strings.Join(upgraded, " and "), dryRunSuffix)
+	if diff.CPUHotplugged {
+		cmd.Printf("VM %s/%s CPU sockets hotplugged%s\n", namespace, name, dryRunSuffix)
+	}
+	return nil
+}
+
+func (c *upgrade) selects(vm *v1.VirtualMachine) bool {
+	if c.instancetype != "" {
+		if vm.Spec.Instancetype == nil || vm.Spec.Instancetype.Name != c.instancetype || !c.selectsKind(instancetypeScope(vm.Spec.Instancetype.Kind)) {
+			return false
+		}
+	}
+	if c.preference != "" {
+		if vm.Spec.Preference == nil || vm.Spec.Preference.Name != c.preference || !c.selectsKind(preferenceScope(vm.Spec.Preference.Kind)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *upgrade) selectsKind(scope string) bool {
+	return c.kind == "" || c.kind == scope
+}
+
+func instancetypeScope(kind string) string {
+	switch strings.ToLower(kind) {
+	case apiinstancetype.SingularResourceName, apiinstancetype.PluralResourceName:
+		return namespacedScope
+	default:
+		return clusterScope
+	}
+}
+
+func preferenceScope(kind string) string {
+	switch strings.ToLower(kind) {
+	case apiinstancetype.SingularPreferenceResourceName, apiinstancetype.PluralPreferenceResourceName:
+		return namespacedScope
+	default:
+		return clusterScope
+	}
+}
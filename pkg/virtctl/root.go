@@ -15,11 +15,13 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/virtctl/configuration"
 	"kubevirt.io/kubevirt/pkg/virtctl/console"
+	"kubevirt.io/kubevirt/pkg/virtctl/convertdomain"
 	"kubevirt.io/kubevirt/pkg/virtctl/create"
 	"kubevirt.io/kubevirt/pkg/virtctl/credentials"
 	"kubevirt.io/kubevirt/pkg/virtctl/expose"
 	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
 	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
+	"kubevirt.io/kubevirt/pkg/virtctl/instancetype"
 	"kubevirt.io/kubevirt/pkg/virtctl/memorydump"
 	"kubevirt.io/kubevirt/pkg/virtctl/network"
 	"kubevirt.io/kubevirt/pkg/virtctl/pause"
@@ -100,6 +102,7 @@ func NewVirtctlCommand() (*cobra.Command, clientcmd.ClientConfig) {
 		vm.NewAddVolumeCommand(clientConfig),
 		vm.NewRemoveVolumeCommand(clientConfig),
 		vm.NewExpandCommand(clientConfig),
+		vm.NewCommand(clientConfig),
 		memorydump.NewMemoryDumpCommand(clientConfig),
 		pause.NewPauseCommand(clientConfig),
 		pause.NewUnpauseCommand(clientConfig),
@@ -110,9 +113,11 @@ func NewVirtctlCommand() (*cobra.Command, clientcmd.ClientConfig) {
 		guestfs.NewGuestfsShellCommand(clientConfig),
 		vmexport.NewVirtualMachineExportCommand(clientConfig),
 		create.NewCommand(),
+		convertdomain.NewCommand(),
 		network.NewAddInterfaceCommand(clientConfig),
 		network.NewRemoveInterfaceCommand(clientConfig),
 		credentials.NewCommand(clientConfig),
+		instancetype.NewCommand(clientConfig),
 		optionsCmd,
 	)
 	return rootCmd, clientConfig
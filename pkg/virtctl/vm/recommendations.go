@@ -0,0 +1,194 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_RECOMMENDATIONS = "recommendations"
+
+	allNamespacesArg = "all-namespaces"
+	allArg           = "all"
+)
+
+type recommendations struct {
+	clientConfig  clientcmd.ClientConfig
+	outputFormat  string
+	allNamespaces bool
+	all           bool
+}
+
+// recommendation is the printed form of the resource recommendation of a single VM
+type recommendation struct {
+	Namespace      string                                  `json:"namespace"`
+	Name           string                                  `json:"name"`
+	Instancetype   string                                  `json:"instancetype,omitempty"`
+	Recommendation v1.VirtualMachineResourceRecommendation `json:"recommendation"`
+}
+
+func NewRecommendationsCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := recommendations{clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:     "recommendations [VM]",
+		Short:   "List virtual machines whose instancetype does not match their observed resource usage.",
+		Example: usageRecommendations(),
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    c.run,
+	}
+	cmd.Flags().StringVarP(&c.outputFormat, outputFormatArg, outputFormatArgShort, TABLE, "Specify a format that will be used to display output, one of table, json or yaml.")
+	cmd.Flags().BoolVarP(&c.allNamespaces, allNamespacesArg, "A", false, "List the recommendations of virtual machines across all namespaces.")
+	cmd.Flags().BoolVar(&c.all, allArg, false, "Also list virtual machines which are already right-sized.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usageRecommendations() string {
+	return `  # List the virtual machines of the namespace which are oversized or undersized:
+  {{ProgramName}} vm recommendations
+
+  # Show the recommendation of a virtual machine called 'myvm':
+  {{ProgramName}} vm recommendations myvm
+
+  # List the recommendations of all virtual machines across all namespaces in yaml format:
+  {{ProgramName}} vm recommendations --all-namespaces --all --output yaml`
+}
+
+func (c *recommendations) run(cmd *cobra.Command, args []string) error {
+	if c.outputFormat != TABLE && c.outputFormat != JSON && c.outputFormat != YAML {
+		return fmt.Errorf("not supported output format defined: %s", c.outputFormat)
+	}
+	if len(args) == 1 && c.allNamespaces {
+		return fmt.Errorf("--%s cannot be combined with a VM name", allNamespacesArg)
+	}
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	var vms []v1.VirtualMachine
+	if len(args) == 1 {
+		vm, err := virtClient.VirtualMachine(namespace).Get(cmd.Context(), args[0], &k8smetav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting VirtualMachine %s in namespace %s: %w", args[0], namespace, err)
+		}
+		if vm.Status.ResourceRecommendation == nil {
+			return fmt.Errorf("no resource recommendation available yet for VirtualMachine %s in namespace %s", args[0], namespace)
+		}
+		vms = append(vms, *vm)
+	} else {
+		if c.allNamespaces {
+			namespace = k8smetav1.NamespaceAll
+		}
+		list, err := virtClient.VirtualMachine(namespace).List(cmd.Context(), &k8smetav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("error listing VirtualMachines: %w", err)
+		}
+		vms = list.Items
+	}
+
+	var candidates []recommendation
+	for _, vm := range vms {
+		if vm.Status.ResourceRecommendation == nil {
+			continue
+		}
+		// a single VM is always shown, even when it is right-sized
+		if len(args) == 0 && !c.all && vm.Status.ResourceRecommendation.Reason == v1.VirtualMachineResourceRecommendationRightSized {
+			continue
+		}
+		candidate := recommendation{
+			Namespace:      vm.Namespace,
+			Name:           vm.Name,
+			Recommendation: *vm.Status.ResourceRecommendation,
+		}
+		if vm.Spec.Instancetype != nil {
+			candidate.Instancetype = vm.Spec.Instancetype.Name
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	var output []byte
+	switch c.outputFormat {
+	case JSON:
+		output, err = json.MarshalIndent(candidates, "", "  ")
+	case YAML:
+		output, err = yaml.Marshal(candidates)
+	default:
+		if len(candidates) == 0 {
+			cmd.Println("No resource recommendations found")
+			return nil
+		}
+		return printRecommendationsTable(cmd.OutOrStdout(), candidates)
+	}
+	if err != nil {
+		return err
+	}
+	cmd.Println(string(output))
+	return nil
+}
+
+func printRecommendationsTable(out io.Writer, candidates []recommendation) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tINSTANCETYPE\tRECOMMENDED\tREASON\tCPU\tMEMORY\tPEAK CPU\tPEAK MEMORY\tUPDATED")
+	for _, candidate := range candidates {
+		r := candidate.Recommendation
+		memory, peakMemory := "<none>", "<none>"
+		if r.Memory != nil {
+			memory = r.Memory.String()
+		}
+		if r.PeakMemory != nil {
+			peakMemory = r.PeakMemory.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			candidate.Namespace,
+			candidate.Name,
+			valueOrNone(candidate.Instancetype),
+			valueOrNone(r.Instancetype),
+			r.Reason,
+			r.CPU,
+			memory,
+			r.PeakCPU.String(),
+			peakMemory,
+			r.LastUpdateTime.UTC().Format(time.RFC3339),
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,125 @@
+package vm_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("Recommendations command", func() {
+	var (
+		ctrl        *gomock.Controller
+		vmInterface *kubecli.MockVirtualMachineInterface
+		vms         *v1.VirtualMachineList
+	)
+
+	newVM := func(name, namespace string, reason v1.VirtualMachineResourceRecommendationReason) v1.VirtualMachine {
+		return v1.VirtualMachine{
+			ObjectMeta: k8smetav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1.VirtualMachineSpec{
+				Instancetype: &v1.InstancetypeMatcher{Name: "u1.xlarge"},
+			},
+			Status: v1.VirtualMachineStatus{
+				ResourceRecommendation: &v1.VirtualMachineResourceRecommendation{
+					Instancetype:   "u1.medium",
+					Reason:         reason,
+					CPU:            1,
+					Memory:         pointer.P(resource.MustParse("3Gi")),
+					PeakCPU:        resource.MustParse("500m"),
+					PeakMemory:     pointer.P(resource.MustParse("2Gi")),
+					Samples:        60,
+					LastUpdateTime: k8smetav1.NewTime(time.Date(2023, 5, 4, 10, 0, 0, 0, time.UTC)),
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+
+		stopped := newVM("stopped", k8smetav1.NamespaceDefault, "")
+		stopped.Status.ResourceRecommendation = nil
+		vms = &v1.VirtualMachineList{
+			Items: []v1.VirtualMachine{
+				newVM("oversized", k8smetav1.NamespaceDefault, v1.VirtualMachineResourceRecommendationOversized),
+				newVM("rightsized", k8smetav1.NamespaceDefault, v1.VirtualMachineResourceRecommendationRightSized),
+				stopped,
+			},
+		}
+	})
+
+	It("should list the candidates for rightsizing as a table", func() {
+		vmInterface.EXPECT().List(gomock.Any(), gomock.Any()).Return(vms, nil)
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "recommendations")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(
+			"NAMESPACE  NAME       INSTANCETYPE  RECOMMENDED  REASON     CPU  MEMORY  PEAK CPU  PEAK MEMORY  UPDATED\n" +
+				"default    oversized  u1.xlarge     u1.medium    Oversized  1    3Gi     500m      2Gi          2023-05-04T10:00:00Z\n",
+		))
+	})
+
+	It("should include right-sized VMs with --all", func() {
+		vmInterface.EXPECT().List(gomock.Any(), gomock.Any()).Return(vms, nil)
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "recommendations", "--all", "--output", "json")()
+		Expect(err).ToNot(HaveOccurred())
+
+		var printed []map[string]interface{}
+		Expect(json.Unmarshal(out, &printed)).To(Succeed())
+		Expect(printed).To(HaveLen(2))
+		Expect(printed[1]).To(HaveKeyWithValue("name", "rightsized"))
+	})
+
+	It("should list the recommendations across all namespaces", func() {
+		allInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceAll).Return(allInterface)
+		allInterface.EXPECT().List(gomock.Any(), gomock.Any()).Return(&v1.VirtualMachineList{
+			Items: []v1.VirtualMachine{newVM("undersized", "other", v1.VirtualMachineResourceRecommendationUndersized)},
+		}, nil)
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "recommendations", "-A")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("other      undersized  u1.xlarge     u1.medium    Undersized"))
+	})
+
+	It("should show the recommendation of a single right-sized VM", func() {
+		vm := newVM("rightsized", k8smetav1.NamespaceDefault, v1.VirtualMachineResourceRecommendationRightSized)
+		vmInterface.EXPECT().Get(gomock.Any(), "rightsized", gomock.Any()).Return(&vm, nil)
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "recommendations", "rightsized")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("RightSized"))
+	})
+
+	It("should fail when a VM has no recommendation yet", func() {
+		vmInterface.EXPECT().Get(gomock.Any(), "stopped", gomock.Any()).Return(&vms.Items[2], nil)
+
+		err := clientcmd.NewRepeatableVirtctlCommand("vm", "recommendations", "stopped")()
+		Expect(err).To(MatchError("no resource recommendation available yet for VirtualMachine stopped in namespace default"))
+	})
+
+	It("should report when there is nothing to recommend", func() {
+		vmInterface.EXPECT().List(gomock.Any(), gomock.Any()).Return(&v1.VirtualMachineList{}, nil)
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "recommendations")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("No resource recommendations found\n"))
+	})
+})
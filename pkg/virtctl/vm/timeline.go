@@ -0,0 +1,126 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_TIMELINE = "timeline"
+
+	TABLE = "table"
+)
+
+func NewTimelineCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	var timelineOutputFormat string
+	cmd := &cobra.Command{
+		Use:     "timeline (VM)",
+		Short:   "Show the lifecycle of a virtual machine and its virtual machine instances in chronological order.",
+		Example: usageTimeline(),
+		Args:    templates.ExactArgs(COMMAND_TIMELINE, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTimeline(clientConfig, cmd, args[0], timelineOutputFormat)
+		},
+	}
+	cmd.Flags().StringVarP(&timelineOutputFormat, outputFormatArg, outputFormatArgShort, TABLE, "Specify a format that will be used to display output, one of table, json or yaml.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usageTimeline() string {
+	return `  # Show the timeline of a virtual machine called 'myvm':
+  {{ProgramName}} vm timeline myvm
+
+  # Show the timeline of a virtual machine called 'myvm' in yaml format:
+  {{ProgramName}} vm timeline myvm --output yaml`
+}
+
+func runTimeline(clientConfig clientcmd.ClientConfig, cmd *cobra.Command, name, format string) error {
+	if format != TABLE && format != JSON && format != YAML {
+		return fmt.Errorf("not supported output format defined: %s", format)
+	}
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	timeline, err := virtClient.VirtualMachine(namespace).Timeline(cmd.Context(), name)
+	if err != nil {
+		return fmt.Errorf("error getting the timeline of VirtualMachine %s in namespace %s: %w", name, namespace, err)
+	}
+
+	var output []byte
+	switch format {
+	case JSON:
+		output, err = json.MarshalIndent(timeline, "", "  ")
+	case YAML:
+		output, err = yaml.Marshal(timeline)
+	default:
+		return printTimelineTable(cmd.OutOrStdout(), timeline)
+	}
+	if err != nil {
+		return err
+	}
+	cmd.Println(string(output))
+	return nil
+}
+
+func printTimelineTable(out io.Writer, timeline *v1.VirtualMachineTimeline) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "TIMESTAMP\tSOURCE\tTYPE\tREASON\tVMI\tMESSAGE")
+	for _, entry := range timeline.Entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Timestamp.UTC().Format(time.RFC3339),
+			entry.Source,
+			valueOrNone(entry.Type),
+			entry.Reason,
+			valueOrNone(string(entry.VMIUID)),
+			entry.Message,
+		)
+	}
+	return w.Flush()
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
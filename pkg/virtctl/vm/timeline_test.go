@@ -0,0 +1,88 @@
+package vm_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("Timeline command", func() {
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var timeline *v1.VirtualMachineTimeline
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+
+		timestamp := k8smetav1.NewTime(time.Date(2023, 5, 4, 10, 0, 0, 0, time.UTC))
+		timeline = &v1.VirtualMachineTimeline{
+			Entries: []v1.VirtualMachineTimelineEntry{
+				{
+					Timestamp: timestamp,
+					Source:    v1.VirtualMachineTimelineSourcePhase,
+					Type:      k8sv1.EventTypeNormal,
+					Reason:    "Running",
+					Message:   "VirtualMachineInstance entered phase Running",
+					VMIUID:    "1234",
+				},
+				{
+					Timestamp: timestamp,
+					Source:    v1.VirtualMachineTimelineSourceEvent,
+					Reason:    "SuccessfulCreate",
+				},
+			},
+		}
+	})
+
+	It("should print the timeline as a table", func() {
+		vmInterface.EXPECT().Timeline(gomock.Any(), vmName).Return(timeline, nil)
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "timeline", vmName)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(
+			"TIMESTAMP             SOURCE  TYPE    REASON            VMI     MESSAGE\n" +
+				"2023-05-04T10:00:00Z  Phase   Normal  Running           1234    VirtualMachineInstance entered phase Running\n" +
+				"2023-05-04T10:00:00Z  Event   <none>  SuccessfulCreate  <none>  \n",
+		))
+	})
+
+	It("should print the timeline in yaml format", func() {
+		vmInterface.EXPECT().Timeline(gomock.Any(), vmName).Return(timeline, nil)
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "timeline", vmName, "--output", "yaml")()
+		Expect(err).ToNot(HaveOccurred())
+
+		printed := &v1.VirtualMachineTimeline{}
+		Expect(yaml.Unmarshal(out, printed)).To(Succeed())
+		Expect(printed.Entries).To(HaveLen(2))
+		Expect(printed.Entries[0].VMIUID).To(Equal(timeline.Entries[0].VMIUID))
+		Expect(printed.Entries[1].Reason).To(Equal(timeline.Entries[1].Reason))
+	})
+
+	It("should fail with an unsupported output format", func() {
+		err := clientcmd.NewRepeatableVirtctlCommand("vm", "timeline", vmName, "--output", invalidFormat)()
+		Expect(err).To(MatchError(fmt.Sprintf("not supported output format defined: %s", invalidFormat)))
+	})
+
+	It("should fail if the timeline cannot be fetched", func() {
+		vmInterface.EXPECT().Timeline(gomock.Any(), vmName).Return(nil, fmt.Errorf("not found"))
+
+		err := clientcmd.NewRepeatableVirtctlCommand("vm", "timeline", vmName)()
+		Expect(err).To(MatchError(ContainSubstring("not found")))
+	})
+})
@@ -88,6 +88,25 @@ var (
 	outputFormat string
 )
 
+// NewCommand groups the commands which inspect a virtual machine
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vm",
+		Short: "Inspect a virtual machine.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Print(cmd.UsageString())
+		},
+	}
+
+	cmd.AddCommand(
+		NewTimelineCommand(clientConfig),
+		NewRecommendationsCommand(clientConfig),
+	)
+
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
 func NewStartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "start (VM)",
@@ -47,15 +47,17 @@ import (
 
 	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
 
+	"kubevirt.io/kubevirt/pkg/storage/export/signedurl"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 const (
 	// Available vmexport functions
-	CREATE   = "create"
-	DELETE   = "delete"
-	DOWNLOAD = "download"
+	CREATE     = "create"
+	DELETE     = "delete"
+	DOWNLOAD   = "download"
+	PUSH_IMAGE = "push-image"
 
 	// Available vmexport flags
 	OUTPUT_FLAG         = "--output"
@@ -70,11 +72,21 @@ const (
 	OUTPUT_FORMAT_FLAG  = "--manifest-output-format"
 	SERVICE_URL_FLAG    = "--service-url"
 	INCLUDE_SECRET_FLAG = "--include-secret"
+	FORMAT_FLAG         = "--format"
+	PRESIGN_FLAG        = "--presign"
+
+	IMAGE_FLAG             = "--image"
+	TAG_FLAG               = "--tag"
+	REGISTRY_SECRET_FLAG   = "--registry-secret"
+	INSECURE_REGISTRY_FLAG = "--insecure-registry"
 
 	// Possible output format for manifests
 	OUTPUT_FORMAT_JSON = "json"
 	OUTPUT_FORMAT_YAML = "yaml"
 
+	// Possible download formats
+	FORMAT_OVA = "ova"
+
 	ACCEPT           = "Accept"
 	APPLICATION_YAML = "application/yaml"
 	APPLICATION_JSON = "application/json"
@@ -83,6 +95,8 @@ const (
 	processingWaitInterval = 2 * time.Second
 	// processingWaitTotal is the maximum time used to wait for a virtualMachineExport to be ready
 	processingWaitTotal = 2 * time.Minute
+	// pushWaitTotal is the maximum time used to wait for the volumes of a virtualMachineExport to be pushed
+	pushWaitTotal = time.Hour
 
 	// exportTokenHeader is the http header used to download the exported volume using the secret token
 	exportTokenHeader = "x-kubevirt-export-token"
@@ -97,6 +111,8 @@ const (
 	ErrIncompatibleFlag = "the '%s' flag is incompatible with '%s'"
 	// ErrRequiredExportType serves as error message when no export kind is provided
 	ErrRequiredExportType = "need to specify export kind when attempting to create a VirtualMachineExport [--pvc|--vm|--snapshot]"
+	// ErrRequiredImage serves as error message when pushing a newly created VirtualMachineExport without a target image
+	ErrRequiredImage = "need to specify the '--image' flag when creating a VirtualMachineExport to push"
 	// ErrIncompatibleExportType serves as error message when an export kind is provided with an incompatible argument
 	ErrIncompatibleExportType = "should not specify export kind"
 	// ErrIncompatibleExportTypeManifest serves as error message when a PVC kind is defined when getting manifest
@@ -123,6 +139,12 @@ var (
 	volumeName           string
 	ttl                  string
 	manifestOutputFormat string
+	image                string
+	tag                  string
+	registrySecret       string
+	insecureRegistry     bool
+	downloadFormat       string
+	presign              string
 )
 
 type exportFunc func(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error
@@ -150,6 +172,9 @@ type VMExportInfo struct {
 	ServiceURL     string
 	ExportSource   k8sv1.TypedLocalObjectReference
 	TTL            metav1.Duration
+	ContainerDisk  *exportv1.VirtualMachineExportContainerDiskTarget
+	Format         string
+	PresignExpiry  time.Duration
 }
 
 type command struct {
@@ -199,7 +224,19 @@ func usage() string {
 	{{ProgramName}} vmexport download vm1-export --vm=vm1 --manifest
 
 	# Get the VirtualMachine manifest in Yaml format from an existing VirtualMachineExport including CDI header secret
-	{{ProgramName}} vmexport download existing-export --include-secret --manifest`
+	{{ProgramName}} vmexport download existing-export --include-secret --manifest
+
+	# Create a VirtualMachineExport and download the VirtualMachine as an OVA bundle with its disks in qcow2 format
+	{{ProgramName}} vmexport download vm1-export --vm=vm1 --format=ova --output=vm1.ova
+
+	# Print a pre-signed URL to download a volume from an already existing VirtualMachineExport within the next hour
+	{{ProgramName}} vmexport download vm1-export --volume=volume1 --presign=1h
+
+	# Create a VirtualMachineExport and push its volumes as containerDisk images, each volume is pushed to <image>/<volume>:<tag>
+	{{ProgramName}} vmexport push-image vm1-export --vm=vm1 --image=registry.example.com/vms --tag=v1 --registry-secret=regcred
+
+	# Wait for the volumes of an already existing VirtualMachineExport to be pushed
+	{{ProgramName}} vmexport push-image vm1-export --keep-vme`
 	return usage
 }
 
@@ -223,12 +260,18 @@ func NewVirtualMachineExportCommand(clientConfig clientcmd.ClientConfig) *cobra.
 	cmd.Flags().StringVar(&outputFile, "output", "", "Specifies the output path of the volume to be downloaded.")
 	cmd.Flags().StringVar(&volumeName, "volume", "", "Specifies the volume to be downloaded.")
 	cmd.Flags().BoolVar(&insecure, "insecure", false, "When used with the 'download' option, specifies that the http request should be insecure.")
-	cmd.Flags().BoolVar(&keepVme, "keep-vme", false, "When used with the 'download' option, specifies that the vmexport object should not be deleted after the download finishes.")
+	cmd.Flags().BoolVar(&keepVme, "keep-vme", false, "When used with the 'download' or 'push-image' option, specifies that the vmexport object should not be deleted after the download finishes.")
 	cmd.Flags().StringVar(&ttl, "ttl", "", "The time after the export was created that it is eligible to be automatically deleted, defaults to 2 hours by the server side if not specified")
 	cmd.Flags().StringVar(&manifestOutputFormat, "manifest-output-format", "", "Manifest output format, defaults to Yaml. Valid options are yaml or json")
 	cmd.Flags().StringVar(&serviceUrl, "service-url", "", "Specify service url to use in the returned manifest, instead of the external URL in the Virtual Machine export status. This is useful for NodePorts or if you don't have an external URL configured")
 	cmd.Flags().BoolVar(&includeSecret, "include-secret", false, "When used with manifest and set to true include a secret that contains proper headers for CDI to import using the manifest")
 	cmd.Flags().BoolVar(&exportManifest, "manifest", false, "Instead of downloading a volume, retrieve the VM manifest")
+	cmd.Flags().StringVar(&downloadFormat, "format", "", "Instead of downloading a volume, download the whole VM in the specified format. Valid options are ova")
+	cmd.Flags().StringVar(&presign, "presign", "", "Instead of downloading, print a pre-signed URL that downloads without the export token until a download with it completed or the given duration elapses, e.g. 30m.")
+	cmd.Flags().StringVar(&image, "image", "", "The registry repository the volumes are pushed to as containerDisk images, each volume is pushed to <image>/<volume name>:<tag>.")
+	cmd.Flags().StringVar(&tag, "tag", "", "The tag of the pushed containerDisk images, defaults to latest.")
+	cmd.Flags().StringVar(&registrySecret, "registry-secret", "", "The name of a kubernetes.io/dockerconfigjson secret holding the registry credentials.")
+	cmd.Flags().BoolVar(&insecureRegistry, "insecure-registry", false, "Skip the verification of the registry certificate when pushing the containerDisk images.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 
 	return cmd
@@ -256,7 +299,7 @@ func (c *command) run(args []string) error {
 		return fmt.Errorf("cannot obtain KubeVirt client: %v", err)
 	}
 
-	// Finally, run the vmexport function (create|delete|download)
+	// Finally, run the vmexport function (create|delete|download|push-image)
 	if err := exportFunction(virtClient, &vmeInfo); err != nil {
 		return err
 	}
@@ -265,7 +308,7 @@ func (c *command) run(args []string) error {
 }
 
 // parseExportArguments parses and validates vmexport arguments and flags. These arguments should always be:
-//  1. The vmexport function (create|delete|download)
+//  1. The vmexport function (create|delete|download|push-image)
 //  2. The VirtualMachineExport name
 func (c *command) parseExportArguments(args []string, vmeInfo *VMExportInfo) error {
 	funcName := strings.ToLower(args[0])
@@ -287,6 +330,11 @@ func (c *command) parseExportArguments(args []string, vmeInfo *VMExportInfo) err
 		if err := handleDownloadFlags(); err != nil {
 			return err
 		}
+	case PUSH_IMAGE:
+		exportFunction = PushImageVirtualMachineExport
+		if err := handlePushImageFlags(); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("invalid function '%s'", funcName)
 	}
@@ -315,6 +363,7 @@ func (c *command) parseExportArguments(args []string, vmeInfo *VMExportInfo) err
 	vmeInfo.OutputFormat = manifestOutputFormat
 	vmeInfo.IncludeSecret = includeSecret
 	vmeInfo.ExportManifest = exportManifest
+	vmeInfo.Format = downloadFormat
 	vmeInfo.TTL = metav1.Duration{}
 	if ttl != "" {
 		duration, err := time.ParseDuration(ttl)
@@ -323,6 +372,26 @@ func (c *command) parseExportArguments(args []string, vmeInfo *VMExportInfo) err
 		}
 		vmeInfo.TTL = metav1.Duration{Duration: duration}
 	}
+	if presign != "" {
+		duration, err := time.ParseDuration(presign)
+		if err != nil {
+			return err
+		}
+		if duration <= 0 {
+			return fmt.Errorf(ErrInvalidValue, PRESIGN_FLAG, "positive durations")
+		}
+		vmeInfo.PresignExpiry = duration
+	}
+	if image != "" {
+		vmeInfo.ContainerDisk = &exportv1.VirtualMachineExportContainerDiskTarget{
+			Repository:            image,
+			Tag:                   tag,
+			InsecureSkipTLSVerify: insecureRegistry,
+		}
+		if registrySecret != "" {
+			vmeInfo.ContainerDisk.SecretRef = &registrySecret
+		}
+	}
 
 	return nil
 }
@@ -364,6 +433,9 @@ func CreateVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExport
 	if vmeInfo.TTL.Duration > 0 {
 		vmexport.Spec.TTLDuration = &vmeInfo.TTL
 	}
+	if vmeInfo.ContainerDisk != nil {
+		vmexport.Spec.ContainerDisk = vmeInfo.ContainerDisk
+	}
 
 	vmexport, err = client.VirtualMachineExport(vmeInfo.Namespace).Create(context.TODO(), vmexport, metav1.CreateOptions{})
 	if err != nil {
@@ -404,7 +476,8 @@ func DownloadVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExpo
 		}
 	}
 
-	if !vmeInfo.KeepVme && !vmeInfo.ExportManifest {
+	// The export has to outlive the command for a pre-signed URL to be usable
+	if !vmeInfo.KeepVme && !vmeInfo.ExportManifest && vmeInfo.PresignExpiry == 0 {
 		defer DeleteVirtualMachineExport(client, vmeInfo)
 	}
 
@@ -421,11 +494,21 @@ func DownloadVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExpo
 		return fmt.Errorf("unable to get '%s/%s' VirtualMachineExport", vmeInfo.Namespace, vmeInfo.Name)
 	}
 
-	if vmeInfo.ExportManifest {
+	if vmeInfo.PresignExpiry > 0 {
+		// Print a pre-signed URL instead of downloading
+		if err := printSignedUrl(client, vmexport, vmeInfo); err != nil {
+			return err
+		}
+	} else if vmeInfo.ExportManifest {
 		// Grab the VM Manifest and display it.
 		if err := getVirtualMachineManifest(client, vmexport, vmeInfo); err != nil {
 			return err
 		}
+	} else if vmeInfo.Format == FORMAT_OVA {
+		// Download the whole VM as an OVA bundle
+		if err := downloadOVA(client, vmexport, vmeInfo); err != nil {
+			return err
+		}
 	} else {
 		// Download the exported volume
 		if err := downloadVolume(client, vmexport, vmeInfo); err != nil {
@@ -435,6 +518,39 @@ func DownloadVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExpo
 	return nil
 }
 
+// PushImageVirtualMachineExport handles the process of pushing the volumes of a VirtualMachineExport object as containerDisk images
+func PushImageVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error {
+	if vmeInfo.ShouldCreate {
+		if err := CreateVirtualMachineExport(client, vmeInfo); err != nil {
+			if !errExportAlreadyExists(err) {
+				return err
+			}
+		}
+	}
+
+	if !vmeInfo.KeepVme {
+		defer DeleteVirtualMachineExport(client, vmeInfo)
+	}
+
+	vmexport, err := waitForContainerDisks(client, vmeInfo, processingWaitInterval, pushWaitTotal)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, cd := range vmexport.Status.ContainerDisks {
+		if cd.Phase != exportv1.ContainerDiskPushSucceeded {
+			failed = append(failed, fmt.Sprintf("%s: %s", cd.Name, cd.Message))
+			continue
+		}
+		fmt.Printf("Volume %s pushed to %s@%s\n", cd.Name, cd.Image, cd.Digest)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to push volumes of VirtualMachineExport '%s/%s': %s", vmeInfo.Namespace, vmeInfo.Name, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
 func printRequestBody(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, manifestUrl string, headers map[string]string) error {
 	resp, err := HandleHTTPRequest(client, vmexport, manifestUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, headers)
 	if err != nil {
@@ -483,6 +599,60 @@ func downloadVolume(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMac
 		return err
 	}
 
+	return downloadToOutput(client, vmexport, vmeInfo, downloadUrl)
+}
+
+// downloadOVA handles the process of downloading the OVA bundle of the VirtualMachine from a VirtualMachineExport
+func downloadOVA(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) error {
+	downloadUrl, err := getOVAUrl(vmexport, vmeInfo)
+	if err != nil {
+		return err
+	}
+
+	return downloadToOutput(client, vmexport, vmeInfo, downloadUrl)
+}
+
+func getOVAUrl(vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) (string, error) {
+	manifestMap, err := GetManifestUrlsFromVirtualMachineExport(vmexport, vmeInfo)
+	if err != nil {
+		return "", err
+	}
+	downloadUrl, ok := manifestMap[exportv1.OVABundle]
+	if !ok {
+		return "", fmt.Errorf("unable to access the OVA bundle from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
+	}
+	return downloadUrl, nil
+}
+
+// printSignedUrl prints a pre-signed URL to download the requested volume or OVA bundle, it is rejected once a download with it completed
+func printSignedUrl(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) error {
+	var (
+		downloadUrl string
+		err         error
+	)
+	if vmeInfo.Format == FORMAT_OVA {
+		downloadUrl, err = getOVAUrl(vmexport, vmeInfo)
+	} else {
+		downloadUrl, err = GetUrlFromVirtualMachineExport(vmexport, vmeInfo)
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := getTokenFromSecret(client, vmexport)
+	if err != nil {
+		return err
+	}
+	signedUrl, err := signedurl.Sign(downloadUrl, token, time.Now().Add(vmeInfo.PresignExpiry))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(vmeInfo.OutputWriter, signedUrl)
+	return nil
+}
+
+// downloadToOutput downloads the content of the url to the expected output
+func downloadToOutput(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, downloadUrl string) error {
 	resp, err := HandleHTTPRequest(client, vmexport, downloadUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, nil)
 	if err != nil {
 		return err
@@ -630,6 +800,48 @@ func waitForVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExpor
 	return err
 }
 
+// waitForContainerDisks waits for the volumes of the VirtualMachineExport to be pushed and returns the last seen object
+func waitForContainerDisks(client kubecli.KubevirtClient, vmeInfo *VMExportInfo, interval, timeout time.Duration) (*exportv1.VirtualMachineExport, error) {
+	var vmexport *exportv1.VirtualMachineExport
+	err := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		var err error
+		vmexport, err = getVirtualMachineExport(client, vmeInfo)
+		if err != nil {
+			return true, err
+		}
+
+		if vmexport == nil {
+			return true, fmt.Errorf("unable to get '%s/%s' VirtualMachineExport", vmeInfo.Namespace, vmeInfo.Name)
+		}
+
+		if vmexport.Spec.ContainerDisk == nil {
+			return true, fmt.Errorf("VirtualMachineExport '%s/%s' has no containerDisk target", vmeInfo.Namespace, vmeInfo.Name)
+		}
+
+		if vmexport.Status == nil {
+			return false, nil
+		}
+
+		if len(vmexport.Status.ContainerDisks) == 0 {
+			if vmexport.Status.Phase == exportv1.Ready {
+				return true, fmt.Errorf("VirtualMachineExport '%s/%s' has no volume to push", vmeInfo.Namespace, vmeInfo.Name)
+			}
+			fmt.Printf("waiting for VM Export %s status to be ready...\n", vmeInfo.Name)
+			return false, nil
+		}
+
+		for _, cd := range vmexport.Status.ContainerDisks {
+			if cd.Phase == exportv1.ContainerDiskPushing {
+				fmt.Printf("waiting for VM Export %s volumes to be pushed...\n", vmeInfo.Name)
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+
+	return vmexport, err
+}
+
 // HandleHTTPRequestFunc function used to handle http requests
 type HandleHTTPRequestFunc func(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, downloadUrl string, insecure bool, exportURL string, headers map[string]string) (*http.Response, error)
 
@@ -794,8 +1006,14 @@ func handleCreateFlags() error {
 	if serviceUrl != "" {
 		return fmt.Errorf(ErrIncompatibleFlag, SERVICE_URL_FLAG, CREATE)
 	}
+	if downloadFormat != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, FORMAT_FLAG, CREATE)
+	}
+	if presign != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, PRESIGN_FLAG, CREATE)
+	}
 
-	return nil
+	return handleContainerDiskFlags()
 }
 
 // handleDeleteFlags ensures that only compatible flag combinations are used with 'delete'
@@ -819,6 +1037,15 @@ func handleDeleteFlags() error {
 	if serviceUrl != "" {
 		return fmt.Errorf(ErrIncompatibleFlag, SERVICE_URL_FLAG, CREATE)
 	}
+	if image != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, IMAGE_FLAG, DELETE)
+	}
+	if downloadFormat != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, FORMAT_FLAG, DELETE)
+	}
+	if presign != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, PRESIGN_FLAG, DELETE)
+	}
 
 	return nil
 }
@@ -845,6 +1072,86 @@ func handleDownloadFlags() error {
 		}
 	}
 
+	if downloadFormat != "" {
+		downloadFormat = strings.ToLower(downloadFormat)
+		if downloadFormat != FORMAT_OVA {
+			return fmt.Errorf(ErrInvalidValue, FORMAT_FLAG, FORMAT_OVA)
+		}
+		if exportManifest {
+			return fmt.Errorf(ErrIncompatibleFlag, MANIFEST_FLAG, FORMAT_FLAG)
+		}
+		if volumeName != "" {
+			return fmt.Errorf(ErrIncompatibleFlag, VOLUME_FLAG, FORMAT_FLAG)
+		}
+		if pvc != "" {
+			return fmt.Errorf(ErrIncompatibleFlag, PVC_FLAG, FORMAT_FLAG)
+		}
+		if outputFile == "" && presign == "" {
+			return fmt.Errorf(ErrRequiredFlag, OUTPUT_FLAG, FORMAT_FLAG)
+		}
+	}
+
+	if presign != "" {
+		if exportManifest {
+			return fmt.Errorf(ErrIncompatibleFlag, MANIFEST_FLAG, PRESIGN_FLAG)
+		}
+		if outputFile != "" {
+			return fmt.Errorf(ErrIncompatibleFlag, OUTPUT_FLAG, PRESIGN_FLAG)
+		}
+	}
+
+	if image != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, IMAGE_FLAG, DOWNLOAD)
+	}
+
+	return nil
+}
+
+// handlePushImageFlags ensures that only compatible flag combinations are used with 'push-image'
+func handlePushImageFlags() error {
+	// We assume that the vmexport should be created if a source has been specified
+	if hasSource := vm != "" || snapshot != "" || pvc != ""; hasSource {
+		shouldCreate = true
+		if image == "" {
+			return fmt.Errorf(ErrRequiredImage)
+		}
+	} else if image != "" {
+		return fmt.Errorf(ErrRequiredExportType)
+	}
+
+	if outputFile != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, OUTPUT_FLAG, PUSH_IMAGE)
+	}
+	if volumeName != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, VOLUME_FLAG, PUSH_IMAGE)
+	}
+	if exportManifest {
+		return fmt.Errorf(ErrIncompatibleFlag, MANIFEST_FLAG, PUSH_IMAGE)
+	}
+	if downloadFormat != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, FORMAT_FLAG, PUSH_IMAGE)
+	}
+	if presign != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, PRESIGN_FLAG, PUSH_IMAGE)
+	}
+
+	return handleContainerDiskFlags()
+}
+
+// handleContainerDiskFlags ensures that the containerDisk target flags are only used along with an image
+func handleContainerDiskFlags() error {
+	if image != "" {
+		return nil
+	}
+	if tag != "" {
+		return fmt.Errorf(ErrRequiredFlag, IMAGE_FLAG, TAG_FLAG)
+	}
+	if registrySecret != "" {
+		return fmt.Errorf(ErrRequiredFlag, IMAGE_FLAG, REGISTRY_SECRET_FLAG)
+	}
+	if insecureRegistry {
+		return fmt.Errorf(ErrRequiredFlag, IMAGE_FLAG, INSECURE_REGISTRY_FLAG)
+	}
 	return nil
 }
 
@@ -5,6 +5,10 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,11 +20,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	fakek8sclient "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
 	exportv1 "kubevirt.io/api/export/v1alpha1"
 	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
 
 	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/storage/export/signedurl"
 	"kubevirt.io/kubevirt/pkg/virtctl/utils"
 	virtctlvmexport "kubevirt.io/kubevirt/pkg/virtctl/vmexport"
 	"kubevirt.io/kubevirt/tests/clientcmd"
@@ -53,10 +59,6 @@ var _ = Describe("vmexport", func() {
 		vmExportClient = kubevirtfake.NewSimpleClientset()
 	})
 
-	setflag := func(flag, parameter string) string {
-		return fmt.Sprintf("%s=%s", flag, parameter)
-	}
-
 	addDefaultReactors := func() {
 		vmExportClient.Fake.PrependReactor("create", "virtualmachineexports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
 			create, ok := action.(testing.CreateAction)
@@ -281,6 +283,21 @@ var _ = Describe("vmexport", func() {
 			Entry("Using 'manifest' with pvc flag", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.PVC_FLAG, virtctlvmexport.MANIFEST_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.PVC_FLAG, "test")),
 			Entry("Using 'manifest' with volume type", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.VOLUME_FLAG, virtctlvmexport.MANIFEST_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.VOLUME_FLAG, "volume")),
 			Entry("Using 'manifest' with invalid output_format_flag", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.OUTPUT_FORMAT_FLAG, "json/yaml"), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.OUTPUT_FORMAT_FLAG, "invalid")),
+			Entry("Using 'push-image' with export type and without image", virtctlvmexport.ErrRequiredImage, virtctlvmexport.PUSH_IMAGE, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test")),
+			Entry("Using 'push-image' with image and without export type", virtctlvmexport.ErrRequiredExportType, virtctlvmexport.PUSH_IMAGE, vmexportName, setflag(virtctlvmexport.IMAGE_FLAG, "registry.example.com/vms")),
+			Entry("Using 'push-image' with invalid flag", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.OUTPUT_FLAG, virtctlvmexport.PUSH_IMAGE), virtctlvmexport.PUSH_IMAGE, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.IMAGE_FLAG, "registry.example.com/vms"), setflag(virtctlvmexport.OUTPUT_FLAG, "disk.img")),
+			Entry("Using 'create' with tag and without image", fmt.Sprintf(virtctlvmexport.ErrRequiredFlag, virtctlvmexport.IMAGE_FLAG, virtctlvmexport.TAG_FLAG), virtctlvmexport.CREATE, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.TAG_FLAG, "v1")),
+			Entry("Using 'download' with image", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.IMAGE_FLAG, virtctlvmexport.DOWNLOAD), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.IMAGE_FLAG, "registry.example.com/vms")),
+			Entry("Using 'format' with invalid value", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_OVA), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, "vmdk"), setflag(virtctlvmexport.OUTPUT_FLAG, "vm.ova")),
+			Entry("Using 'format' without output", fmt.Sprintf(virtctlvmexport.ErrRequiredFlag, virtctlvmexport.OUTPUT_FLAG, virtctlvmexport.FORMAT_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_OVA)),
+			Entry("Using 'format' with volume", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.VOLUME_FLAG, virtctlvmexport.FORMAT_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_OVA), setflag(virtctlvmexport.VOLUME_FLAG, "volume")),
+			Entry("Using 'format' with manifest", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.MANIFEST_FLAG, virtctlvmexport.FORMAT_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_OVA), virtctlvmexport.MANIFEST_FLAG),
+			Entry("Using 'format' with pvc flag", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.PVC_FLAG, virtctlvmexport.FORMAT_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_OVA), setflag(virtctlvmexport.PVC_FLAG, "test")),
+			Entry("Using 'presign' with output", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.OUTPUT_FLAG, virtctlvmexport.PRESIGN_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.PRESIGN_FLAG, "1h"), setflag(virtctlvmexport.OUTPUT_FLAG, "disk.img")),
+			Entry("Using 'presign' with manifest", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.MANIFEST_FLAG, virtctlvmexport.PRESIGN_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.PRESIGN_FLAG, "1h"), virtctlvmexport.MANIFEST_FLAG),
+			Entry("Using 'presign' with a negative duration", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.PRESIGN_FLAG, "positive durations"), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.PRESIGN_FLAG, "-1h")),
+			Entry("Using 'create' with presign", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.PRESIGN_FLAG, virtctlvmexport.CREATE), virtctlvmexport.CREATE, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.PRESIGN_FLAG, "1h")),
+			Entry("Using 'create' with format", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.FORMAT_FLAG, virtctlvmexport.CREATE), virtctlvmexport.CREATE, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_OVA)),
 		)
 
 		AfterEach(func() {
@@ -423,6 +440,83 @@ var _ = Describe("vmexport", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		// Push image tests
+		It("Succesfully create a VirtualMachineExport and push its volumes", func() {
+			vmexport := utils.VMExportSpecVM(vmexportName, metav1.NamespaceDefault, "test-vm", secretName)
+			vmexport.Status = &exportv1.VirtualMachineExportStatus{
+				Phase: exportv1.Ready,
+				ContainerDisks: []exportv1.VirtualMachineExportContainerDisk{
+					{Name: volumeName, Image: "registry.example.com/vms/test-volume:v1", Phase: exportv1.ContainerDiskPushSucceeded, Digest: "sha256:1234"},
+				},
+			}
+			vmExportClient.Fake.PrependReactor("create", "virtualmachineexports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				create, ok := action.(testing.CreateAction)
+				Expect(ok).To(BeTrue())
+				vme, ok := create.GetObject().(*exportv1.VirtualMachineExport)
+				Expect(ok).To(BeTrue())
+				Expect(vme.Spec.ContainerDisk).To(Equal(&exportv1.VirtualMachineExportContainerDiskTarget{
+					Repository:            "registry.example.com/vms",
+					Tag:                   "v1",
+					SecretRef:             pointer.String("regcred"),
+					InsecureSkipTLSVerify: true,
+				}))
+
+				vmexport.Spec.ContainerDisk = vme.Spec.ContainerDisk
+				utils.HandleVMExportGet(vmExportClient, vmexport, vmexportName)
+				return true, vme, nil
+			})
+			handleVMExportDelete(vmExportClient, vmexportName)
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.PUSH_IMAGE, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test-vm"),
+				setflag(virtctlvmexport.IMAGE_FLAG, "registry.example.com/vms"), setflag(virtctlvmexport.TAG_FLAG, "v1"),
+				setflag(virtctlvmexport.REGISTRY_SECRET_FLAG, "regcred"), virtctlvmexport.INSECURE_REGISTRY_FLAG)
+			err := cmd()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			testDone()
+		})
+	})
+
+	Context("Push image", func() {
+		var vmexport *exportv1.VirtualMachineExport
+
+		BeforeEach(func() {
+			testInit(http.StatusOK)
+			vmexport = utils.VMExportSpecVM(vmexportName, metav1.NamespaceDefault, "test-vm", secretName)
+			vmexport.Spec.ContainerDisk = &exportv1.VirtualMachineExportContainerDiskTarget{
+				Repository: "registry.example.com/vms",
+			}
+			vmexport.Status = &exportv1.VirtualMachineExportStatus{Phase: exportv1.Ready}
+			utils.HandleVMExportGet(vmExportClient, vmexport, vmexportName)
+		})
+
+		It("should fail when a volume failed to push", func() {
+			vmexport.Status.ContainerDisks = []exportv1.VirtualMachineExportContainerDisk{
+				{Name: "disk1", Phase: exportv1.ContainerDiskPushSucceeded, Digest: "sha256:1234"},
+				{Name: "disk2", Phase: exportv1.ContainerDiskPushFailed, Message: "unauthorized"},
+			}
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.PUSH_IMAGE, vmexportName, virtctlvmexport.KEEP_FLAG)
+			err := cmd()
+			Expect(err).To(MatchError("failed to push volumes of VirtualMachineExport 'default/test-vme': disk2: unauthorized"))
+		})
+
+		It("should fail when there is no volume to push", func() {
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.PUSH_IMAGE, vmexportName, virtctlvmexport.KEEP_FLAG)
+			err := cmd()
+			Expect(err).To(MatchError(ContainSubstring("has no volume to push")))
+		})
+
+		It("should fail when the VirtualMachineExport has no containerDisk target", func() {
+			vmexport.Spec.ContainerDisk = nil
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.PUSH_IMAGE, vmexportName, virtctlvmexport.KEEP_FLAG)
+			err := cmd()
+			Expect(err).To(MatchError(ContainSubstring("has no containerDisk target")))
+		})
+
 		AfterEach(func() {
 			testDone()
 		})
@@ -642,8 +736,147 @@ var _ = Describe("vmexport", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("OVA", func() {
+		const ovaUrl = "https://ova.example.com/manifests/ova"
+
+		var (
+			orgHttpFunc virtctlvmexport.HandleHTTPRequestFunc
+			vmexport    *exportv1.VirtualMachineExport
+		)
+
+		BeforeEach(func() {
+			orgHttpFunc = virtctlvmexport.HandleHTTPRequest
+			testInit(http.StatusOK)
+			vmexport = utils.VMExportSpecVM(vmexportName, metav1.NamespaceDefault, "test", secretName)
+			vmexport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name:    volumeName,
+					Formats: utils.GetExportVolumeFormat(server.URL, exportv1.KubeVirtGz),
+				},
+			}, secretName)
+			utils.HandleSecretGet(kubeClient, secretName)
+		})
+
+		AfterEach(func() {
+			virtctlvmexport.HandleHTTPRequest = orgHttpFunc
+			testDone()
+		})
+
+		It("should download the OVA bundle", func() {
+			output := filepath.Join(GinkgoT().TempDir(), "vm.ova")
+			virtctlvmexport.HandleHTTPRequest = func(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, downloadUrl string, insecure bool, exportURL string, headers map[string]string) (*http.Response, error) {
+				Expect(downloadUrl).To(Equal(ovaUrl))
+				resp := http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("ova")),
+				}
+				return &resp, nil
+			}
+			vmexport.Status.Links.External.Manifests = append(vmexport.Status.Links.External.Manifests, exportv1.VirtualMachineExportManifest{
+				Type: exportv1.OVABundle,
+				Url:  ovaUrl,
+			})
+			utils.HandleVMExportCreate(vmExportClient, vmexport)
+			handleVMExportDelete(vmExportClient, vmexportName)
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_OVA), setflag(virtctlvmexport.OUTPUT_FLAG, output))
+			Expect(cmd()).To(Succeed())
+			Expect(os.ReadFile(output)).To(Equal([]byte("ova")))
+		})
+
+		It("should print a pre-signed URL of the OVA bundle", func() {
+			vmexport.Status.Links.External.Manifests = append(vmexport.Status.Links.External.Manifests, exportv1.VirtualMachineExportManifest{
+				Type: exportv1.OVABundle,
+				Url:  ovaUrl,
+			})
+			utils.HandleVMExportGet(vmExportClient, vmexport, vmexportName)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut(commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_OVA), setflag(virtctlvmexport.PRESIGN_FLAG, "1h"))()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(HavePrefix(ovaUrl + "?"))
+		})
+
+		It("should fail when the VirtualMachineExport has no OVA bundle", func() {
+			output := filepath.Join(GinkgoT().TempDir(), "vm.ova")
+			vmexport.Status.Links.External.Manifests = append(vmexport.Status.Links.External.Manifests, exportv1.VirtualMachineExportManifest{
+				Type: exportv1.AllManifests,
+				Url:  manifestUrl,
+			})
+			utils.HandleVMExportGet(vmExportClient, vmexport, vmexportName)
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.FORMAT_OVA), setflag(virtctlvmexport.OUTPUT_FLAG, output), virtctlvmexport.KEEP_FLAG)
+			err := cmd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to access the OVA bundle"))
+		})
+	})
+})
+
+var _ = Describe("Presign", func() {
+	var (
+		ctrl           *gomock.Controller
+		kubeClient     *fakek8sclient.Clientset
+		vmExportClient *kubevirtfake.Clientset
+		vmexport       *exportv1.VirtualMachineExport
+	)
+
+	const downloadUrl = "https://test.something.somewhere/volumes/test-volume/disk.img.gz"
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fakek8sclient.NewSimpleClientset()
+		vmExportClient = kubevirtfake.NewSimpleClientset()
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineExport(metav1.NamespaceDefault).Return(vmExportClient.ExportV1alpha1().VirtualMachineExports(metav1.NamespaceDefault)).AnyTimes()
+		virtctlvmexport.ExportProcessingComplete = utils.WaitExportCompleteDefault
+
+		vmexport = utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test", secretName)
+		vmexport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+			{
+				Name:    volumeName,
+				Formats: utils.GetExportVolumeFormat(downloadUrl, exportv1.KubeVirtGz),
+			},
+		}, secretName)
+		utils.HandleSecretGet(kubeClient, secretName)
+		vmExportClient.Fake.PrependReactor("delete", "virtualmachineexports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			Fail("the VirtualMachineExport must be kept for the pre-signed URL to be usable")
+			return true, nil, nil
+		})
+	})
+
+	It("should print a URL signed with the export token", func() {
+		utils.HandleVMExportGet(vmExportClient, vmexport, vmexportName)
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut(commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.VOLUME_FLAG, volumeName), setflag(virtctlvmexport.PRESIGN_FLAG, "30m"))()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(HavePrefix(downloadUrl + "?"))
+
+		u, err := url.Parse(strings.TrimSpace(string(out)))
+		Expect(err).ToNot(HaveOccurred())
+		expires, err := strconv.ParseInt(u.Query().Get(signedurl.ExpiresParam), 10, 64)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(time.Unix(expires, 0)).To(BeTemporally("~", time.Now().Add(30*time.Minute), time.Minute))
+		verifier := signedurl.NewVerifier()
+		Expect(verifier.Verify(u, "test")).To(Succeed())
+		Expect(verifier.Verify(u, "other")).ToNot(Succeed())
+	})
+
+	It("should fail when the volume is not available", func() {
+		vmexport.Status.Links.External.Volumes = nil
+		utils.HandleVMExportGet(vmExportClient, vmexport, vmexportName)
+
+		err := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.PRESIGN_FLAG, "30m"))()
+		Expect(err).To(MatchError(ContainSubstring("unable to access the volume info")))
+	})
 })
 
+func setflag(flag, parameter string) string {
+	return fmt.Sprintf("%s=%s", flag, parameter)
+}
+
 func handleVMExportDelete(client *kubevirtfake.Clientset, name string) {
 	client.Fake.PrependReactor("delete", "virtualmachineexports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
 		delete, ok := action.(testing.DeleteAction)
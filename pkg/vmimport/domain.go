@@ -0,0 +1,647 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vmimport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	stateOn  = "on"
+	stateOff = "off"
+	yes      = "yes"
+)
+
+// handledDomainElements are the elements of a domain which are either converted or meaningless for a VirtualMachine
+var handledDomainElements = map[string]bool{
+	"name": true, "uuid": true, "title": true, "description": true, "metadata": true,
+	"memory": true, "currentMemory": true, "memoryBacking": true, "vcpu": true, "cpu": true,
+	"os": true, "sysinfo": true, "features": true, "clock": true, "devices": true,
+	"on_poweroff": true, "on_reboot": true, "on_crash": true, "pm": true, "seclabel": true, "resource": true, "genid": true,
+}
+
+var handledFeatureElements = map[string]bool{
+	"acpi": true, "apic": true, "hyperv": true, "smm": true, "kvm": true, "pvspinlock": true,
+	"pae": true, "hap": true, "vmcoreinfo": true, "vmport": true,
+}
+
+var handledDeviceElements = map[string]bool{
+	"emulator": true, "disk": true, "interface": true, "input": true, "serial": true, "console": true,
+	"channel": true, "controller": true, "video": true, "graphics": true, "audio": true, "memballoon": true,
+	"watchdog": true, "rng": true, "tpm": true, "sound": true, "redirdev": true, "vsock": true,
+}
+
+// domainExtras holds the parts of the domain XML which are not part of api.DomainSpec
+type domainExtras struct {
+	OS struct {
+		Firmware string `xml:"firmware,attr"`
+	} `xml:"os"`
+}
+
+// xmlNode is a generic XML element, used to find the elements which are not converted
+type xmlNode struct {
+	XMLName xml.Name
+	Nodes   []xmlNode `xml:",any"`
+}
+
+// FromDomainXML converts a libvirt domain XML to a VirtualMachine
+func FromDomainXML(data []byte, opts Options) (*Result, error) {
+	domain := &api.DomainSpec{}
+	if err := xml.Unmarshal(data, domain); err != nil {
+		return nil, fmt.Errorf("failed to parse domain XML: %v", err)
+	}
+	extras := &domainExtras{}
+	if err := xml.Unmarshal(data, extras); err != nil {
+		return nil, fmt.Errorf("failed to parse domain XML: %v", err)
+	}
+	root := &xmlNode{}
+	if err := xml.Unmarshal(data, root); err != nil {
+		return nil, fmt.Errorf("failed to parse domain XML: %v", err)
+	}
+
+	c := newConverter(domain.Name, opts)
+	c.reportUnknownElements(root)
+	if domain.UUID != "" {
+		c.firmware().UUID = types.UID(domain.UUID)
+	}
+	if err := c.convertDomainCPU(domain); err != nil {
+		return nil, err
+	}
+	if err := c.convertDomainMemory(domain); err != nil {
+		return nil, err
+	}
+	c.convertDomainOS(domain, extras)
+	c.convertDomainFeatures(domain.Features)
+	c.convertDomainClock(domain.Clock)
+	if err := c.convertDomainDisks(domain); err != nil {
+		return nil, err
+	}
+	c.convertDomainInterfaces(domain)
+	c.convertDomainDevices(&domain.Devices)
+	c.convertDomainBootOrder(domain)
+	c.finish()
+	return c.result(), nil
+}
+
+func (c *converter) reportUnknownElements(root *xmlNode) {
+	for _, node := range root.Nodes {
+		name := node.XMLName.Local
+		switch {
+		case !handledDomainElements[name]:
+			c.reportUnsupported("%s: element cannot be represented", name)
+		case name == "features":
+			for _, feature := range node.Nodes {
+				if !handledFeatureElements[feature.XMLName.Local] {
+					c.reportUnsupported("features/%s: feature cannot be represented", feature.XMLName.Local)
+				}
+			}
+		case name == "devices":
+			for _, device := range node.Nodes {
+				if !handledDeviceElements[device.XMLName.Local] {
+					c.reportUnsupported("devices/%s: device cannot be represented", device.XMLName.Local)
+				}
+			}
+		}
+	}
+}
+
+func (c *converter) firmware() *v1.Firmware {
+	if c.domain().Firmware == nil {
+		c.domain().Firmware = &v1.Firmware{}
+	}
+	return c.domain().Firmware
+}
+
+func (c *converter) convertDomainCPU(domain *api.DomainSpec) error {
+	count := uint32(1)
+	if domain.VCPU != nil {
+		count = domain.VCPU.CPUs
+	}
+	var sockets, cores, threads uint32
+	if topology := domain.CPU.Topology; topology != nil {
+		sockets, cores, threads = topology.Sockets, topology.Cores, topology.Threads
+	}
+	c.setCPU(count, sockets, cores, threads)
+
+	cpu := c.domain().CPU
+	switch domain.CPU.Mode {
+	case "", "custom":
+		cpu.Model = domain.CPU.Model
+	case v1.CPUModeHostPassthrough, v1.CPUModeHostModel:
+		cpu.Model = domain.CPU.Mode
+	default:
+		c.reportUnsupported("cpu: mode %s cannot be represented", domain.CPU.Mode)
+	}
+	for _, feature := range domain.CPU.Features {
+		cpu.Features = append(cpu.Features, v1.CPUFeature{
+			Name:   feature.Name,
+			Policy: feature.Policy,
+		})
+	}
+	if domain.CPU.NUMA != nil {
+		c.reportUnsupported("cpu/numa: guest NUMA cells cannot be represented")
+	}
+	if domain.CPUTune != nil {
+		c.reportUnsupported("cputune: CPU pinning cannot be represented")
+	}
+	return nil
+}
+
+func (c *converter) convertDomainMemory(domain *api.DomainSpec) error {
+	memory, err := toBytes(domain.Memory.Value, domain.Memory.Unit)
+	if err != nil {
+		return fmt.Errorf("memory: %v", err)
+	}
+	c.setMemory(memory)
+
+	if backing := domain.MemoryBacking; backing != nil {
+		if backing.HugePages != nil {
+			pageSize := int64(2 << 20)
+			if len(backing.HugePages.HugePage) > 0 {
+				page := backing.HugePages.HugePage[0]
+				size, err := strconv.ParseUint(page.Size, 10, 64)
+				if err != nil {
+					return fmt.Errorf("memoryBacking/hugepages: invalid page size %s", page.Size)
+				}
+				if pageSize, err = toBytes(size, page.Unit); err != nil {
+					return fmt.Errorf("memoryBacking/hugepages: %v", err)
+				}
+			}
+			c.domain().Memory.Hugepages = &v1.Hugepages{
+				PageSize: resource.NewQuantity(pageSize, resource.BinarySI).String(),
+			}
+		}
+		if backing.Source != nil || backing.Access != nil || backing.Allocation != nil || backing.NoSharePages != nil {
+			c.reportUnsupported("memoryBacking: only hugepages can be represented")
+		}
+	}
+	return nil
+}
+
+func (c *converter) convertDomainOS(domain *api.DomainSpec, extras *domainExtras) {
+	switch domain.OS.Type.Arch {
+	case "":
+	case "x86_64":
+		c.vm.Spec.Template.Spec.Architecture = "amd64"
+	case "aarch64":
+		c.vm.Spec.Template.Spec.Architecture = "arm64"
+	case "ppc64le", "s390x":
+		c.vm.Spec.Template.Spec.Architecture = domain.OS.Type.Arch
+	default:
+		c.reportUnsupported("os/type: architecture %s cannot be represented", domain.OS.Type.Arch)
+	}
+
+	machine := domain.OS.Type.Machine
+	switch {
+	case machine == "":
+	case strings.Contains(machine, "q35"):
+		c.domain().Machine = &v1.Machine{Type: "q35"}
+	case machine == "pc" || strings.HasPrefix(machine, "pc-i440fx"):
+		c.reportUnsupported("os/type: machine type %s cannot be represented, q35 is used instead", machine)
+	default:
+		c.domain().Machine = &v1.Machine{Type: machine}
+	}
+
+	loader := domain.OS.BootLoader
+	if extras.OS.Firmware == "efi" || (loader != nil && loader.Type == "pflash") {
+		secureBoot := loader != nil && loader.Secure == yes
+		c.firmware().Bootloader = &v1.Bootloader{
+			EFI: &v1.EFI{SecureBoot: &secureBoot},
+		}
+	}
+	if domain.OS.Kernel != "" || domain.OS.Initrd != "" {
+		c.reportUnsupported("os/kernel: direct kernel boot from a host path cannot be represented")
+	}
+
+	if domain.SysInfo != nil {
+		for _, entry := range domain.SysInfo.System {
+			if entry.Name == "serial" {
+				c.firmware().Serial = entry.Value
+			}
+		}
+		var chassis v1.Chassis
+		for _, entry := range domain.SysInfo.Chassis {
+			switch entry.Name {
+			case "manufacturer":
+				chassis.Manufacturer = entry.Value
+			case "version":
+				chassis.Version = entry.Value
+			case "serial":
+				chassis.Serial = entry.Value
+			case "asset":
+				chassis.Asset = entry.Value
+			case "sku":
+				chassis.Sku = entry.Value
+			}
+		}
+		if chassis != (v1.Chassis{}) {
+			c.domain().Chassis = &chassis
+		}
+	}
+}
+
+func (c *converter) convertDomainFeatures(features *api.Features) {
+	if features == nil {
+		return
+	}
+	result := &v1.Features{}
+	if features.ACPI == nil {
+		result.ACPI = v1.FeatureState{Enabled: pointer.P(false)}
+	}
+	if features.APIC != nil {
+		result.APIC = &v1.FeatureAPIC{}
+	}
+	if features.SMM != nil {
+		result.SMM = &v1.FeatureState{}
+	}
+	if features.KVM != nil && features.KVM.Hidden != nil && features.KVM.Hidden.State == stateOn {
+		result.KVM = &v1.FeatureKVM{Hidden: true}
+	}
+	if features.PVSpinlock != nil {
+		result.Pvspinlock = &v1.FeatureState{Enabled: pointer.P(features.PVSpinlock.State != stateOff)}
+	}
+	if features.PMU != nil {
+		c.reportUnsupported("features/pmu: feature cannot be represented")
+	}
+	if hyperv := features.Hyperv; hyperv != nil {
+		result.Hyperv = &v1.FeatureHyperv{
+			Relaxed:         featureState(hyperv.Relaxed),
+			VAPIC:           featureState(hyperv.VAPIC),
+			VPIndex:         featureState(hyperv.VPIndex),
+			Runtime:         featureState(hyperv.Runtime),
+			SyNIC:           featureState(hyperv.SyNIC),
+			Reset:           featureState(hyperv.Reset),
+			Frequencies:     featureState(hyperv.Frequencies),
+			Reenlightenment: featureState(hyperv.Reenlightenment),
+			TLBFlush:        featureState(hyperv.TLBFlush),
+			IPI:             featureState(hyperv.IPI),
+			EVMCS:           featureState(hyperv.EVMCS),
+		}
+		if hyperv.Spinlocks != nil {
+			result.Hyperv.Spinlocks = &v1.FeatureSpinlocks{
+				Enabled: pointer.P(hyperv.Spinlocks.State != stateOff),
+				Retries: hyperv.Spinlocks.Retries,
+			}
+		}
+		if hyperv.SyNICTimer != nil {
+			result.Hyperv.SyNICTimer = &v1.SyNICTimer{
+				Enabled: pointer.P(hyperv.SyNICTimer.State != stateOff),
+				Direct:  featureState(hyperv.SyNICTimer.Direct),
+			}
+		}
+		if hyperv.VendorID != nil {
+			result.Hyperv.VendorID = &v1.FeatureVendorID{
+				Enabled:  pointer.P(hyperv.VendorID.State != stateOff),
+				VendorID: hyperv.VendorID.Value,
+			}
+		}
+	}
+	c.domain().Features = result
+}
+
+func (c *converter) convertDomainClock(clock *api.Clock) {
+	if clock == nil {
+		return
+	}
+	result := &v1.Clock{}
+	switch clock.Offset {
+	case "", "utc":
+		result.UTC = &v1.ClockOffsetUTC{}
+	case "timezone":
+		timezone := v1.ClockOffsetTimezone(clock.Timezone)
+		result.Timezone = &timezone
+	default:
+		c.reportUnsupported("clock: offset %s cannot be represented, utc is used instead", clock.Offset)
+		result.UTC = &v1.ClockOffsetUTC{}
+	}
+
+	timer := &v1.Timer{}
+	for _, t := range clock.Timer {
+		var present *bool
+		if t.Present != "" {
+			present = pointer.P(t.Present == yes)
+		}
+		switch t.Name {
+		case "rtc":
+			timer.RTC = &v1.RTCTimer{Enabled: present, TickPolicy: v1.RTCTickPolicy(t.TickPolicy), Track: v1.RTCTimerTrack(t.Track)}
+		case "pit":
+			timer.PIT = &v1.PITTimer{Enabled: present, TickPolicy: v1.PITTickPolicy(t.TickPolicy)}
+		case "hpet":
+			timer.HPET = &v1.HPETTimer{Enabled: present, TickPolicy: v1.HPETTickPolicy(t.TickPolicy)}
+		case "kvmclock":
+			timer.KVM = &v1.KVMTimer{Enabled: present}
+		case "hypervclock":
+			timer.Hyperv = &v1.HypervTimer{Enabled: present}
+		default:
+			c.reportUnsupported("clock/timer: timer %s cannot be represented", t.Name)
+		}
+	}
+	if *timer != (v1.Timer{}) {
+		result.Timer = timer
+	}
+	c.domain().Clock = result
+}
+
+func (c *converter) convertDomainDisks(domain *api.DomainSpec) error {
+	for i, disk := range domain.Devices.Disks {
+		name := disk.Target.Device
+		if name == "" {
+			name = fmt.Sprintf("disk%d", i)
+		}
+		if disk.Device == "floppy" {
+			c.reportUnsupported("disk %s: floppy disks cannot be represented", name)
+			continue
+		}
+		if disk.Device == "cdrom" && disk.Source == (api.DiskSource{}) {
+			c.reportUnsupported("disk %s: empty cdrom drives cannot be represented", name)
+			continue
+		}
+
+		bus := c.diskBus(name, disk.Target.Bus)
+		result := v1.Disk{
+			Name:   name,
+			Serial: disk.Serial,
+		}
+		switch disk.Device {
+		case "cdrom":
+			result.CDRom = &v1.CDRomTarget{Bus: bus}
+			if bus == v1.DiskBusVirtio {
+				result.CDRom.Bus = v1.DiskBusSATA
+			}
+		case "lun":
+			result.LUN = &v1.LunTarget{Bus: v1.DiskBusSCSI, ReadOnly: disk.ReadOnly != nil}
+		default:
+			result.Disk = &v1.DiskTarget{Bus: bus, ReadOnly: disk.ReadOnly != nil}
+		}
+		if disk.Shareable != nil {
+			result.Shareable = pointer.P(true)
+		}
+		if disk.BootOrder != nil {
+			result.BootOrder = pointer.P(disk.BootOrder.Order)
+		}
+		if disk.BlockIO != nil {
+			result.BlockSize = &v1.BlockSize{
+				Custom: &v1.CustomBlockSize{
+					Logical:  disk.BlockIO.LogicalBlockSize,
+					Physical: disk.BlockIO.PhysicalBlockSize,
+				},
+			}
+		}
+		if driver := disk.Driver; driver != nil {
+			switch cache := v1.DriverCache(driver.Cache); cache {
+			case "", "default":
+			case v1.CacheNone, v1.CacheWriteThrough, v1.CacheWriteBack:
+				result.Cache = cache
+			default:
+				c.reportUnsupported("disk %s: cache mode %s cannot be represented", name, cache)
+			}
+			switch driver.IO {
+			case "", "default":
+			case v1.IONative, v1.IOThreads:
+				result.IO = driver.IO
+			default:
+				c.reportUnsupported("disk %s: io mode %s cannot be represented", name, driver.IO)
+			}
+		}
+		c.addDisk(result, c.opts.DiskSize)
+	}
+	return nil
+}
+
+func (c *converter) diskBus(name string, bus v1.DiskBus) v1.DiskBus {
+	switch bus {
+	case "", v1.DiskBusVirtio:
+		return v1.DiskBusVirtio
+	case v1.DiskBusSATA, v1.DiskBusSCSI, v1.DiskBusUSB:
+		return bus
+	case "ide":
+		c.reportUnsupported("disk %s: the IDE bus cannot be represented, SATA is used instead", name)
+		return v1.DiskBusSATA
+	default:
+		c.reportUnsupported("disk %s: the %s bus cannot be represented, virtio is used instead", name, bus)
+		return v1.DiskBusVirtio
+	}
+}
+
+func (c *converter) convertDomainInterfaces(domain *api.DomainSpec) {
+	for i, iface := range domain.Devices.Interfaces {
+		name := fmt.Sprintf("nic%d", i)
+		var networkName string
+		switch iface.Type {
+		case "user":
+		case "network":
+			if iface.Source.Network != "default" {
+				networkName = iface.Source.Network
+			}
+		case "bridge":
+			networkName = iface.Source.Bridge
+		default:
+			c.reportUnsupported("interface %s: interfaces of type %s cannot be represented", name, iface.Type)
+			continue
+		}
+
+		result := v1.Interface{Name: name}
+		if iface.Model != nil {
+			result.Model = c.interfaceModel(name, iface.Model.Type)
+		}
+		if iface.MAC != nil {
+			result.MacAddress = iface.MAC.MAC
+		}
+		if iface.BootOrder != nil {
+			result.BootOrder = pointer.P(iface.BootOrder.Order)
+		}
+		c.addInterface(result, networkName)
+	}
+}
+
+func (c *converter) convertDomainDevices(devices *api.Devices) {
+	result := &c.domain().Devices
+
+	if len(devices.Graphics) == 0 && len(devices.Video) == 0 {
+		result.AutoattachGraphicsDevice = pointer.P(false)
+	}
+	if len(devices.Serials) == 0 && len(devices.Consoles) == 0 {
+		result.AutoattachSerialConsole = pointer.P(false)
+	}
+	if devices.Ballooning != nil && devices.Ballooning.Model == "none" {
+		result.AutoattachMemBalloon = pointer.P(false)
+	}
+	if devices.VSOCK != nil {
+		result.AutoattachVSOCK = pointer.P(true)
+	}
+
+	for _, input := range devices.Inputs {
+		// the PS/2 mouse and keyboard are always present
+		if input.Bus == "ps2" || input.Bus == "" {
+			continue
+		}
+		if input.Type != v1.InputTypeTablet && input.Type != v1.InputTypeKeyboard {
+			c.reportUnsupported("input: %s devices cannot be represented", input.Type)
+			continue
+		}
+		if input.Bus != v1.InputBusUSB && input.Bus != v1.InputBusVirtio {
+			c.reportUnsupported("input: %s devices on the %s bus cannot be represented", input.Type, input.Bus)
+			continue
+		}
+		result.Inputs = append(result.Inputs, v1.Input{
+			Name: fmt.Sprintf("%s%d", input.Type, len(result.Inputs)),
+			Type: input.Type,
+			Bus:  input.Bus,
+		})
+	}
+
+	if watchdog := devices.Watchdog; watchdog != nil {
+		if watchdog.Model == "i6300esb" {
+			action := v1.WatchdogAction(watchdog.Action)
+			switch action {
+			case v1.WatchdogActionPoweroff, v1.WatchdogActionReset, v1.WatchdogActionShutdown:
+			default:
+				c.reportUnsupported("watchdog: action %s cannot be represented, reset is used instead", watchdog.Action)
+				action = v1.WatchdogActionReset
+			}
+			result.Watchdog = &v1.Watchdog{
+				Name: "watchdog",
+				WatchdogDevice: v1.WatchdogDevice{
+					I6300ESB: &v1.I6300ESBWatchdog{Action: action},
+				},
+			}
+		} else {
+			c.reportUnsupported("watchdog: model %s cannot be represented", watchdog.Model)
+		}
+	}
+
+	if devices.Rng != nil {
+		result.Rng = &v1.Rng{}
+	}
+
+	for i, tpm := range devices.TPMs {
+		if i > 0 {
+			c.reportUnsupported("tpm: only one TPM device can be represented")
+			break
+		}
+		result.TPM = &v1.TPMDevice{}
+		if tpm.Backend.PersistentState == yes {
+			result.TPM.Persistent = pointer.P(true)
+		}
+	}
+
+	for i, sound := range devices.SoundCards {
+		if i > 0 {
+			c.reportUnsupported("sound: only one sound device can be represented")
+			break
+		}
+		if sound.Model != "ich9" && sound.Model != "ac97" {
+			c.reportUnsupported("sound: model %s cannot be represented", sound.Model)
+			continue
+		}
+		result.Sound = &v1.SoundDevice{Name: "sound", Model: sound.Model}
+	}
+
+	if len(devices.Redirs) > 0 {
+		result.ClientPassthrough = &v1.ClientPassthroughDevices{}
+	}
+}
+
+// convertDomainBootOrder applies the boot devices of the os element when the devices have no boot order of their own
+func (c *converter) convertDomainBootOrder(domain *api.DomainSpec) {
+	devices := &c.domain().Devices
+	for _, disk := range devices.Disks {
+		if disk.BootOrder != nil {
+			return
+		}
+	}
+	for _, iface := range devices.Interfaces {
+		if iface.BootOrder != nil {
+			return
+		}
+	}
+
+	order := uint(1)
+	for _, boot := range domain.OS.BootOrder {
+		switch boot.Dev {
+		case "hd", "cdrom":
+			for i := range devices.Disks {
+				disk := &devices.Disks[i]
+				// repeated boot devices of the same kind boot from the next disk of that kind
+				if disk.BootOrder == nil && (disk.CDRom != nil) == (boot.Dev == "cdrom") {
+					disk.BootOrder = pointer.P(order)
+					order++
+					break
+				}
+			}
+		case "network":
+			for i := range devices.Interfaces {
+				iface := &devices.Interfaces[i]
+				if iface.BootOrder == nil {
+					iface.BootOrder = pointer.P(order)
+					order++
+					break
+				}
+			}
+		default:
+			c.reportUnsupported("os/boot: booting from %s cannot be represented", boot.Dev)
+		}
+	}
+}
+
+// toBytes converts a libvirt scaled integer to bytes, see https://libvirt.org/formatdomain.html#memory-allocation
+func toBytes(value uint64, unit string) (int64, error) {
+	multiplier := uint64(1)
+	switch unit {
+	case "b", "bytes":
+	case "KB":
+		multiplier = 1000
+	case "", "k", "KiB":
+		multiplier = 1 << 10
+	case "MB":
+		multiplier = 1000 * 1000
+	case "M", "MiB":
+		multiplier = 1 << 20
+	case "GB":
+		multiplier = 1000 * 1000 * 1000
+	case "G", "GiB":
+		multiplier = 1 << 30
+	case "TB":
+		multiplier = 1000 * 1000 * 1000 * 1000
+	case "T", "TiB":
+		multiplier = 1 << 40
+	default:
+		return 0, fmt.Errorf("unknown unit %s", unit)
+	}
+	return int64(value * multiplier), nil
+}
+
+func featureState(state *api.FeatureState) *v1.FeatureState {
+	if state == nil {
+		return nil
+	}
+	return &v1.FeatureState{Enabled: pointer.P(state.State != stateOff)}
+}
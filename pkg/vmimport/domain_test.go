@@ -0,0 +1,298 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vmimport
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/api/core/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+const testDomainXML = `<domain type='kvm'>
+  <name>Fedora_38</name>
+  <uuid>5d9c7a5e-38b3-4e2b-8ad4-c2a4b2a0f0a1</uuid>
+  <memory unit='KiB'>4194304</memory>
+  <currentMemory unit='KiB'>4194304</currentMemory>
+  <vcpu placement='static'>4</vcpu>
+  <os firmware='efi'>
+    <type arch='x86_64' machine='pc-q35-7.2'>hvm</type>
+    <loader readonly='yes' secure='yes' type='pflash'>/usr/share/edk2/ovmf/OVMF_CODE.secboot.fd</loader>
+    <boot dev='cdrom'/>
+    <boot dev='hd'/>
+  </os>
+  <features>
+    <acpi/>
+    <apic/>
+    <smm state='on'/>
+    <vmport state='off'/>
+    <hyperv>
+      <relaxed state='on'/>
+      <spinlocks state='on' retries='8191'/>
+    </hyperv>
+  </features>
+  <cpu mode='host-passthrough' check='none' migratable='on'>
+    <topology sockets='1' dies='1' cores='2' threads='2'/>
+  </cpu>
+  <clock offset='utc'>
+    <timer name='rtc' tickpolicy='catchup'/>
+    <timer name='pit' tickpolicy='delay'/>
+    <timer name='hpet' present='no'/>
+  </clock>
+  <on_poweroff>destroy</on_poweroff>
+  <pm>
+    <suspend-to-mem enabled='no'/>
+  </pm>
+  <devices>
+    <emulator>/usr/bin/qemu-system-x86_64</emulator>
+    <disk type='file' device='disk'>
+      <driver name='qemu' type='qcow2' cache='none' io='native'/>
+      <source file='/var/lib/libvirt/images/fedora.qcow2'/>
+      <target dev='vda' bus='virtio'/>
+      <serial>root-disk</serial>
+    </disk>
+    <disk type='file' device='cdrom'>
+      <driver name='qemu' type='raw'/>
+      <source file='/var/lib/libvirt/images/Fedora-38.iso'/>
+      <target dev='sda' bus='sata'/>
+      <readonly/>
+    </disk>
+    <disk type='file' device='cdrom'>
+      <target dev='sdb' bus='sata'/>
+      <readonly/>
+    </disk>
+    <controller type='usb' index='0' model='qemu-xhci'/>
+    <interface type='network'>
+      <mac address='52:54:00:6b:3c:58'/>
+      <source network='default'/>
+      <model type='virtio'/>
+    </interface>
+    <interface type='bridge'>
+      <mac address='52:54:00:6b:3c:59'/>
+      <source bridge='br1'/>
+      <model type='e1000e'/>
+    </interface>
+    <serial type='pty'>
+      <target type='isa-serial' port='0'/>
+    </serial>
+    <console type='pty'>
+      <target type='serial' port='0'/>
+    </console>
+    <channel type='unix'>
+      <target type='virtio' name='org.qemu.guest_agent.0'/>
+    </channel>
+    <input type='tablet' bus='usb'/>
+    <input type='mouse' bus='ps2'/>
+    <graphics type='vnc' port='-1' autoport='yes'/>
+    <video>
+      <model type='virtio' heads='1' primary='yes'/>
+    </video>
+    <watchdog model='i6300esb' action='poweroff'/>
+    <memballoon model='none'/>
+    <rng model='virtio'>
+      <backend model='random'>/dev/urandom</backend>
+    </rng>
+    <tpm model='tpm-crb'>
+      <backend type='emulator' version='2.0' persistent_state='yes'/>
+    </tpm>
+  </devices>
+</domain>`
+
+var _ = Describe("Domain XML", func() {
+	It("should convert a libvirt domain to a VirtualMachine", func() {
+		result, err := Convert([]byte(testDomainXML), Options{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Unsupported).To(ConsistOf(
+			"disk sdb: empty cdrom drives cannot be represented",
+		))
+
+		vm := result.VirtualMachine
+		Expect(vm.Name).To(Equal("fedora-38"))
+		Expect(vm.Spec.RunStrategy).To(HaveValue(Equal(v1.RunStrategyHalted)))
+		spec := vm.Spec.Template.Spec
+		Expect(spec.Architecture).To(Equal("amd64"))
+
+		domain := spec.Domain
+		Expect(domain.CPU).To(Equal(&v1.CPU{Sockets: 1, Cores: 2, Threads: 2, Model: v1.CPUModeHostPassthrough}))
+		Expect(domain.Memory.Guest.String()).To(Equal("4Gi"))
+		Expect(domain.Machine).To(Equal(&v1.Machine{Type: "q35"}))
+		Expect(domain.Firmware).To(Equal(&v1.Firmware{
+			UUID: "5d9c7a5e-38b3-4e2b-8ad4-c2a4b2a0f0a1",
+			Bootloader: &v1.Bootloader{
+				EFI: &v1.EFI{SecureBoot: pointer.P(true)},
+			},
+		}))
+		Expect(domain.Features).To(Equal(&v1.Features{
+			APIC: &v1.FeatureAPIC{},
+			SMM:  &v1.FeatureState{},
+			Hyperv: &v1.FeatureHyperv{
+				Relaxed:   &v1.FeatureState{Enabled: pointer.P(true)},
+				Spinlocks: &v1.FeatureSpinlocks{Enabled: pointer.P(true), Retries: pointer.P(uint32(8191))},
+			},
+		}))
+		Expect(domain.Clock.UTC).ToNot(BeNil())
+		Expect(domain.Clock.Timer).To(Equal(&v1.Timer{
+			RTC:  &v1.RTCTimer{TickPolicy: v1.RTCTickPolicyCatchup},
+			PIT:  &v1.PITTimer{TickPolicy: v1.PITTickPolicyDelay},
+			HPET: &v1.HPETTimer{Enabled: pointer.P(false)},
+		}))
+
+		Expect(domain.Devices.Disks).To(Equal([]v1.Disk{
+			{
+				Name:       "vda",
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}},
+				BootOrder:  pointer.P(uint(2)),
+				Serial:     "root-disk",
+				Cache:      v1.CacheNone,
+				IO:         v1.IONative,
+			},
+			{
+				Name:       "sda",
+				DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: v1.DiskBusSATA}},
+				BootOrder:  pointer.P(uint(1)),
+			},
+		}))
+		Expect(spec.Volumes).To(Equal([]v1.Volume{
+			{Name: "vda", VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "fedora-38-vda"}}},
+			{Name: "sda", VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "fedora-38-sda"}}},
+		}))
+		Expect(vm.Spec.DataVolumeTemplates).To(HaveLen(2))
+		Expect(vm.Spec.DataVolumeTemplates[0].Name).To(Equal("fedora-38-vda"))
+		Expect(vm.Spec.DataVolumeTemplates[0].Spec).To(Equal(cdiv1.DataVolumeSpec{
+			Source: &cdiv1.DataVolumeSource{Upload: &cdiv1.DataVolumeSourceUpload{}},
+			Storage: &cdiv1.StorageSpec{
+				Resources: k8sv1.ResourceRequirements{
+					Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse(DefaultDiskSize)},
+				},
+			},
+		}))
+
+		Expect(domain.Devices.Interfaces).To(Equal([]v1.Interface{
+			{
+				Name:                   "nic0",
+				Model:                  v1.VirtIO,
+				MacAddress:             "52:54:00:6b:3c:58",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+			},
+			{
+				Name:                   "nic1",
+				Model:                  "e1000e",
+				MacAddress:             "52:54:00:6b:3c:59",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+			},
+		}))
+		Expect(spec.Networks).To(Equal([]v1.Network{
+			{Name: "nic0", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+			{Name: "nic1", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "br1"}}},
+		}))
+
+		Expect(domain.Devices.Inputs).To(Equal([]v1.Input{{Name: "tablet0", Type: v1.InputTypeTablet, Bus: v1.InputBusUSB}}))
+		Expect(domain.Devices.Watchdog).To(Equal(&v1.Watchdog{
+			Name:           "watchdog",
+			WatchdogDevice: v1.WatchdogDevice{I6300ESB: &v1.I6300ESBWatchdog{Action: v1.WatchdogActionPoweroff}},
+		}))
+		Expect(domain.Devices.AutoattachMemBalloon).To(HaveValue(BeFalse()))
+		Expect(domain.Devices.AutoattachGraphicsDevice).To(BeNil())
+		Expect(domain.Devices.AutoattachSerialConsole).To(BeNil())
+		Expect(domain.Devices.AutoattachPodInterface).To(BeNil())
+		Expect(domain.Devices.Rng).To(Equal(&v1.Rng{}))
+		Expect(domain.Devices.TPM).To(Equal(&v1.TPMDevice{Persistent: pointer.P(true)}))
+	})
+
+	It("should use the requested disk size", func() {
+		result, err := FromDomainXML([]byte(testDomainXML), Options{DiskSize: resource.MustParse("30Gi")})
+		Expect(err).ToNot(HaveOccurred())
+		for _, dv := range result.VirtualMachine.Spec.DataVolumeTemplates {
+			Expect(dv.Spec.Storage.Resources.Requests).To(HaveKeyWithValue(k8sv1.ResourceStorage, resource.MustParse("30Gi")))
+		}
+	})
+
+	DescribeTable("should report what cannot be represented", func(element, expected string) {
+		result, err := FromDomainXML([]byte(`<domain type='kvm'><name>vm</name><memory>1048576</memory>`+element+`</domain>`), Options{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Unsupported).To(ContainElement(expected))
+	},
+		Entry("unknown domain elements", "<iothreads>2</iothreads>", "iothreads: element cannot be represented"),
+		Entry("unknown features", "<features><ioapic driver='kvm'/></features>", "features/ioapic: feature cannot be represented"),
+		Entry("host devices", "<devices><hostdev mode='subsystem' type='pci'/></devices>", "devices/hostdev: device cannot be represented"),
+		Entry("floppy disks", "<devices><disk type='file' device='floppy'><source file='/f.img'/><target dev='fda' bus='fdc'/></disk></devices>", "disk fda: floppy disks cannot be represented"),
+		Entry("IDE disks", "<devices><disk type='file' device='disk'><source file='/d.img'/><target dev='hda' bus='ide'/></disk></devices>", "disk hda: the IDE bus cannot be represented, SATA is used instead"),
+		Entry("macvtap interfaces", "<devices><interface type='direct'><source dev='eth0' mode='bridge'/></interface></devices>", "interface nic0: interfaces of type direct cannot be represented"),
+		Entry("unsupported NIC models", "<devices><interface type='network'><source network='default'/><model type='vmxnet3'/></interface></devices>", "interface nic0: model vmxnet3 cannot be represented, virtio is used instead"),
+		Entry("a second interface on the pod network", "<devices><interface type='user'/><interface type='network'><source network='default'/></interface></devices>", "interface nic1: only one interface can be connected to the pod network"),
+		Entry("i440fx machines", "<os><type machine='pc-i440fx-7.2'>hvm</type></os>", "os/type: machine type pc-i440fx-7.2 cannot be represented, q35 is used instead"),
+		Entry("direct kernel boot", "<os><type>hvm</type><kernel>/boot/vmlinuz</kernel></os>", "os/kernel: direct kernel boot from a host path cannot be represented"),
+		Entry("CPU pinning", "<vcpu>2</vcpu><cputune><vcpupin vcpu='0' cpuset='1'/></cputune>", "cputune: CPU pinning cannot be represented"),
+		Entry("localtime clocks", "<clock offset='localtime'/>", "clock: offset localtime cannot be represented, utc is used instead"),
+		Entry("watchdog models", "<devices><watchdog model='ib700' action='reset'/></devices>", "watchdog: model ib700 cannot be represented"),
+	)
+
+	It("should disable devices the domain does not have", func() {
+		result, err := FromDomainXML([]byte(`<domain type='kvm'><name>vm</name><memory unit='MiB'>512</memory><features><apic/></features><devices/></domain>`), Options{})
+		Expect(err).ToNot(HaveOccurred())
+		domain := result.VirtualMachine.Spec.Template.Spec.Domain
+		Expect(domain.CPU).To(Equal(&v1.CPU{Sockets: 1, Cores: 1, Threads: 1}))
+		Expect(domain.Memory.Guest.String()).To(Equal("512Mi"))
+		Expect(domain.Features.ACPI.Enabled).To(HaveValue(BeFalse()))
+		Expect(domain.Devices.AutoattachGraphicsDevice).To(HaveValue(BeFalse()))
+		Expect(domain.Devices.AutoattachSerialConsole).To(HaveValue(BeFalse()))
+		Expect(domain.Devices.AutoattachPodInterface).To(HaveValue(BeFalse()))
+	})
+
+	It("should boot from the next disk on repeated boot devices", func() {
+		result, err := FromDomainXML([]byte(`<domain type='kvm'><name>vm</name><memory unit='MiB'>512</memory>
+  <os><boot dev='hd'/><boot dev='hd'/></os>
+  <devices>
+    <disk type='file' device='disk'><source file='/a.qcow2'/><target dev='vda' bus='virtio'/></disk>
+    <disk type='file' device='disk'><source file='/b.qcow2'/><target dev='vdb' bus='virtio'/></disk>
+  </devices></domain>`), Options{})
+		Expect(err).ToNot(HaveOccurred())
+		disks := result.VirtualMachine.Spec.Template.Spec.Domain.Devices.Disks
+		Expect(disks).To(HaveLen(2))
+		Expect(disks[0].BootOrder).To(HaveValue(Equal(uint(1))))
+		Expect(disks[1].BootOrder).To(HaveValue(Equal(uint(2))))
+	})
+
+	It("should fail on an invalid memory unit", func() {
+		_, err := FromDomainXML([]byte(`<domain type='kvm'><name>vm</name><memory unit='parsecs'>1</memory></domain>`), Options{})
+		Expect(err).To(MatchError("memory: unknown unit parsecs"))
+	})
+
+	It("should fail on unknown documents", func() {
+		_, err := Convert([]byte(`<network><name>default</name></network>`), Options{})
+		Expect(err).To(MatchError("unknown root element network, expected a libvirt domain or an OVF envelope"))
+	})
+
+	DescribeTable("should convert scaled integers to bytes", func(value uint64, unit string, expected int64) {
+		Expect(toBytes(value, unit)).To(Equal(expected))
+	},
+		Entry("without unit", uint64(1), "", int64(1024)),
+		Entry("in bytes", uint64(512), "b", int64(512)),
+		Entry("in KB", uint64(1), "KB", int64(1000)),
+		Entry("in MiB", uint64(2), "MiB", int64(2<<20)),
+		Entry("in G", uint64(1), "G", int64(1<<30)),
+	)
+})
@@ -0,0 +1,486 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vmimport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+// CIM resource types of the virtual hardware items
+const (
+	resourceTypeOther          = 1
+	resourceTypeCPU            = 3
+	resourceTypeMemory         = 4
+	resourceTypeIDEController  = 5
+	resourceTypeSCSIController = 6
+	resourceTypeEthernet       = 10
+	resourceTypeFloppy         = 14
+	resourceTypeCDDrive        = 15
+	resourceTypeDVDDrive       = 16
+	resourceTypeDisk           = 17
+	resourceTypeOtherStorage   = 20
+	resourceTypeUSBController  = 23
+	resourceTypeGraphics       = 24
+	resourceTypeSound          = 35
+
+	ovfPodNetwork = "pod"
+
+	// oVirt types its sections with xsi:type instead of naming them
+	ovfDiskSectionType            = "DiskSection_Type"
+	ovfVirtualSystemType          = "VirtualSystem_Type"
+	ovfVirtualHardwareSectionType = "VirtualHardwareSection_Type"
+)
+
+// ovirtInterfaceModels maps the oVirt network interface types to interface models
+var ovirtInterfaceModels = map[string]string{
+	"0": "rtl8139",
+	"1": "rtl8139",
+	"2": "e1000",
+	"3": v1.VirtIO,
+	"6": "e1000e",
+}
+
+var allocationUnitsExpression = regexp.MustCompile(`^byte\s*\*\s*(2|10)\^(\d+)$`)
+
+type ovfEnvelope struct {
+	References    []ovfFile          `xml:"References>File"`
+	Disks         []ovfDisk          `xml:"DiskSection>Disk"`
+	VirtualSystem ovfVirtualSystem   `xml:"VirtualSystem"`
+	Sections      []ovfSection       `xml:"Section"`
+	Contents      []ovfVirtualSystem `xml:"Content"`
+}
+
+type ovfFile struct {
+	ID   string `xml:"id,attr"`
+	Href string `xml:"href,attr"`
+}
+
+type ovfDisk struct {
+	DiskID                  string `xml:"diskId,attr"`
+	FileRef                 string `xml:"fileRef,attr"`
+	Capacity                string `xml:"capacity,attr"`
+	CapacityAllocationUnits string `xml:"capacityAllocationUnits,attr"`
+	// Size is the size in GiB of oVirt disks, which have no capacity
+	Size string `xml:"size,attr"`
+	// Interface is the bus of oVirt disks, which are not attached to controller items
+	Interface string `xml:"disk-interface,attr"`
+}
+
+type ovfVirtualSystem struct {
+	Type     string               `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+	ID       string               `xml:"id,attr"`
+	Name     string               `xml:"Name"`
+	Hardware []ovfHardwareSection `xml:"VirtualHardwareSection"`
+	Sections []ovfSection         `xml:"Section"`
+}
+
+// ovfSection is a section typed with xsi:type, it holds the content of all the section types that are converted
+type ovfSection struct {
+	Type  string    `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+	Disks []ovfDisk `xml:"Disk"`
+	ovfHardwareSection
+}
+
+type ovfHardwareSection struct {
+	Items             []ovfItem   `xml:"Item"`
+	StorageItems      []ovfItem   `xml:"StorageItem"`
+	EthernetPortItems []ovfItem   `xml:"EthernetPortItem"`
+	Configs           []ovfConfig `xml:"Config"`
+}
+
+// ovfConfig is a VMware specific key value configuration
+type ovfConfig struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// ovfItem is a CIM_ResourceAllocationSettingData, storage and ethernet port items share the same element names
+type ovfItem struct {
+	Address         string `xml:"Address"`
+	AddressOnParent string `xml:"AddressOnParent"`
+	AllocationUnits string `xml:"AllocationUnits"`
+	Connection      string `xml:"Connection"`
+	ElementName     string `xml:"ElementName"`
+	HostResource    string `xml:"HostResource"`
+	InstanceID      string `xml:"InstanceID"`
+	Parent          string `xml:"Parent"`
+	ResourceSubType string `xml:"ResourceSubType"`
+	ResourceType    int    `xml:"ResourceType"`
+	VirtualQuantity int64  `xml:"VirtualQuantity"`
+	CoresPerSocket  uint32 `xml:"CoresPerSocket"`
+	// oVirt specific names of the item elements
+	Caption         string `xml:"Caption"`
+	OVirtInstanceID string `xml:"InstanceId"`
+	MACAddress      string `xml:"MACAddress"`
+	CPUPerSocket    uint32 `xml:"cpu_per_socket"`
+}
+
+// normalize moves the sections typed with xsi:type to the fields of the sections named after their type
+func (env *ovfEnvelope) normalize() {
+	for _, section := range env.Sections {
+		if sectionType(section.Type) == ovfDiskSectionType {
+			env.Disks = append(env.Disks, section.Disks...)
+		}
+	}
+	for _, content := range env.Contents {
+		if sectionType(content.Type) == ovfVirtualSystemType && len(env.VirtualSystem.Hardware) == 0 {
+			env.VirtualSystem = content
+		}
+	}
+	for _, section := range env.VirtualSystem.Sections {
+		if sectionType(section.Type) == ovfVirtualHardwareSectionType {
+			env.VirtualSystem.Hardware = append(env.VirtualSystem.Hardware, section.ovfHardwareSection)
+		}
+	}
+	for i := range env.VirtualSystem.Hardware {
+		hardware := &env.VirtualSystem.Hardware[i]
+		for _, items := range [][]ovfItem{hardware.Items, hardware.StorageItems, hardware.EthernetPortItems} {
+			for j := range items {
+				items[j].normalize()
+			}
+		}
+	}
+}
+
+func (item *ovfItem) normalize() {
+	if item.InstanceID == "" {
+		item.InstanceID = item.OVirtInstanceID
+	}
+	if item.ElementName == "" {
+		item.ElementName = item.Caption
+	}
+	if item.Address == "" {
+		item.Address = item.MACAddress
+	}
+	if item.CoresPerSocket == 0 {
+		item.CoresPerSocket = item.CPUPerSocket
+	}
+}
+
+// sectionType strips the namespace prefix of an xsi:type
+func sectionType(xsiType string) string {
+	if i := strings.LastIndex(xsiType, ":"); i >= 0 {
+		return xsiType[i+1:]
+	}
+	return xsiType
+}
+
+// capacity returns the capacity of the disk, oVirt disks only have a size in GiB
+func (disk *ovfDisk) capacity() (*resource.Quantity, error) {
+	if disk.Capacity == "" && disk.Size != "" {
+		size, err := strconv.ParseInt(disk.Size, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size %s of disk %s", disk.Size, disk.DiskID)
+		}
+		return resource.NewQuantity(size<<30, resource.BinarySI), nil
+	}
+	capacity, err := strconv.ParseInt(disk.Capacity, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity %s of disk %s", disk.Capacity, disk.DiskID)
+	}
+	units := disk.CapacityAllocationUnits
+	if units == "" {
+		units = "byte"
+	}
+	multiplier, err := allocationUnits(units)
+	if err != nil {
+		return nil, err
+	}
+	return resource.NewQuantity(capacity*multiplier, resource.BinarySI), nil
+}
+
+// FromOVF converts the virtual system of an OVF descriptor to a VirtualMachine
+func FromOVF(data []byte, opts Options) (*Result, error) {
+	env := &ovfEnvelope{}
+	if err := xml.Unmarshal(data, env); err != nil {
+		return nil, fmt.Errorf("failed to parse OVF descriptor: %v", err)
+	}
+	env.normalize()
+	system := &env.VirtualSystem
+	if len(system.Hardware) == 0 {
+		return nil, fmt.Errorf("OVF descriptor has no virtual hardware section")
+	}
+	if len(system.Hardware) > 1 {
+		return nil, fmt.Errorf("OVF descriptors with multiple virtual hardware sections are not supported")
+	}
+	hardware := &system.Hardware[0]
+
+	name := system.Name
+	if name == "" {
+		name = system.ID
+	}
+	c := newConverter(name, opts)
+
+	var items []ovfItem
+	items = append(items, hardware.Items...)
+	items = append(items, hardware.StorageItems...)
+	items = append(items, hardware.EthernetPortItems...)
+	controllers := map[string]ovfItem{}
+	for _, item := range items {
+		switch item.ResourceType {
+		case resourceTypeIDEController, resourceTypeSCSIController, resourceTypeOtherStorage:
+			controllers[item.InstanceID] = item
+		}
+	}
+
+	c.setCPU(1, 0, 0, 0)
+	for _, item := range items {
+		var err error
+		switch item.ResourceType {
+		case resourceTypeCPU:
+			c.convertOVFCPU(item)
+		case resourceTypeMemory:
+			err = c.convertOVFMemory(item)
+		case resourceTypeDisk, resourceTypeCDDrive, resourceTypeDVDDrive:
+			err = c.convertOVFDisk(env, item, controllers)
+		case resourceTypeEthernet:
+			c.convertOVFInterface(item)
+		case resourceTypeFloppy:
+			c.reportUnsupported("item %s: floppy disks cannot be represented", item.ElementName)
+		case resourceTypeOther, resourceTypeIDEController, resourceTypeSCSIController, resourceTypeOtherStorage,
+			resourceTypeUSBController, resourceTypeGraphics, resourceTypeSound:
+			// controllers are converted with their disks, the others are always present
+		default:
+			c.reportUnsupported("item %s: resource type %d cannot be represented", item.ElementName, item.ResourceType)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, config := range hardware.Configs {
+		if config.Key == "firmware" && config.Value == "efi" {
+			c.firmware().Bootloader = &v1.Bootloader{
+				EFI: &v1.EFI{SecureBoot: pointer.P(false)},
+			}
+		}
+	}
+
+	c.finish()
+	return c.result(), nil
+}
+
+func (c *converter) convertOVFCPU(item ovfItem) {
+	count := uint32(item.VirtualQuantity)
+	if item.CoresPerSocket > 0 && count%item.CoresPerSocket == 0 {
+		c.setCPU(count, count/item.CoresPerSocket, item.CoresPerSocket, 1)
+		return
+	}
+	c.setCPU(count, 0, 0, 0)
+}
+
+func (c *converter) convertOVFMemory(item ovfItem) error {
+	units := item.AllocationUnits
+	if units == "" {
+		units = "byte * 2^20"
+	}
+	multiplier, err := allocationUnits(units)
+	if err != nil {
+		return fmt.Errorf("item %s: %v", item.ElementName, err)
+	}
+	c.setMemory(item.VirtualQuantity * multiplier)
+	return nil
+}
+
+func (c *converter) convertOVFDisk(env *ovfEnvelope, item ovfItem, controllers map[string]ovfItem) error {
+	name := item.ElementName
+	if name == "" {
+		name = "disk" + item.InstanceID
+	}
+	cdrom := item.ResourceType != resourceTypeDisk
+
+	hostDisk, referenced, err := ovfHostDisk(env, item.HostResource)
+	if err != nil {
+		return fmt.Errorf("item %s: %v", name, err)
+	}
+	if !referenced {
+		if cdrom {
+			c.reportUnsupported("item %s: empty cdrom drives cannot be represented", name)
+		} else {
+			c.reportUnsupported("item %s: disk without a disk or file reference cannot be represented", name)
+		}
+		return nil
+	}
+	// the size of a referenced file is the size of its content, which may be compressed or sparse,
+	// so the capacity of disks missing from the disk section is unknown
+	size := c.opts.DiskSize
+	if hostDisk != nil {
+		capacity, err := hostDisk.capacity()
+		if err != nil {
+			return fmt.Errorf("item %s: %v", name, err)
+		}
+		size = *capacity
+	}
+
+	bus := v1.DiskBusVirtio
+	if controller, exists := controllers[item.Parent]; exists {
+		bus = c.ovfControllerBus(name, controller)
+	} else if hostDisk != nil && hostDisk.Interface != "" {
+		bus = c.ovirtDiskBus(name, hostDisk.Interface)
+	}
+	disk := v1.Disk{Name: name}
+	if cdrom {
+		if bus == v1.DiskBusVirtio {
+			bus = v1.DiskBusSATA
+		}
+		disk.CDRom = &v1.CDRomTarget{Bus: bus}
+	} else {
+		disk.Disk = &v1.DiskTarget{Bus: bus}
+	}
+	c.addDisk(disk, size)
+	return nil
+}
+
+// ovfHostDisk returns the disk section entry of the disk or file referenced by the host resource, if any,
+// and whether the host resource references a disk or file at all. oVirt references its disks by
+// <image group>/<image> instead of ovf:/disk/<disk>.
+func ovfHostDisk(env *ovfEnvelope, hostResource string) (*ovfDisk, bool, error) {
+	reference := strings.TrimPrefix(hostResource, "ovf:")
+	switch {
+	case strings.HasPrefix(reference, "/disk/"):
+		id := strings.TrimPrefix(reference, "/disk/")
+		for i := range env.Disks {
+			if env.Disks[i].DiskID == id {
+				return &env.Disks[i], true, nil
+			}
+		}
+		return nil, false, fmt.Errorf("disk %s not found in the disk section", id)
+	case strings.HasPrefix(reference, "/file/"):
+		id := strings.TrimPrefix(reference, "/file/")
+		for _, file := range env.References {
+			if file.ID != id {
+				continue
+			}
+			for i := range env.Disks {
+				if env.Disks[i].FileRef == id {
+					return &env.Disks[i], true, nil
+				}
+			}
+			return nil, true, nil
+		}
+		return nil, false, fmt.Errorf("file %s not found in the references", id)
+	case reference != "":
+		for i := range env.Disks {
+			if env.Disks[i].FileRef == reference || env.Disks[i].DiskID == path.Base(reference) {
+				return &env.Disks[i], true, nil
+			}
+		}
+	}
+	return nil, false, nil
+}
+
+func (c *converter) ovfControllerBus(name string, controller ovfItem) v1.DiskBus {
+	switch controller.ResourceType {
+	case resourceTypeIDEController:
+		c.reportUnsupported("item %s: the IDE bus cannot be represented, SATA is used instead", name)
+		return v1.DiskBusSATA
+	case resourceTypeSCSIController:
+		return v1.DiskBusSCSI
+	}
+	subType := strings.ToLower(controller.ResourceSubType)
+	switch {
+	case strings.Contains(subType, "virtio"):
+		return v1.DiskBusVirtio
+	case strings.Contains(subType, "sata"), strings.Contains(subType, "ahci"):
+		return v1.DiskBusSATA
+	case strings.Contains(subType, "scsi"):
+		return v1.DiskBusSCSI
+	case strings.Contains(subType, "usb"):
+		return v1.DiskBusUSB
+	}
+	c.reportUnsupported("item %s: the %s bus cannot be represented, virtio is used instead", name, controller.ResourceSubType)
+	return v1.DiskBusVirtio
+}
+
+// ovirtDiskBus returns the bus of an oVirt disk interface
+func (c *converter) ovirtDiskBus(name, diskInterface string) v1.DiskBus {
+	switch strings.ToLower(diskInterface) {
+	case "virtio":
+		return v1.DiskBusVirtio
+	case "virtio_scsi", "spapr_vscsi":
+		return v1.DiskBusSCSI
+	case "sata":
+		return v1.DiskBusSATA
+	case "ide":
+		c.reportUnsupported("item %s: the IDE bus cannot be represented, SATA is used instead", name)
+		return v1.DiskBusSATA
+	}
+	c.reportUnsupported("item %s: the %s bus cannot be represented, virtio is used instead", name, diskInterface)
+	return v1.DiskBusVirtio
+}
+
+func (c *converter) convertOVFInterface(item ovfItem) {
+	name := item.ElementName
+	if name == "" {
+		name = "nic" + item.InstanceID
+	}
+	name = sanitizeName(name, "nic")
+
+	networkName := item.Connection
+	if networkName == ovfPodNetwork {
+		networkName = ""
+	}
+	model := item.ResourceSubType
+	if ovirtModel, exists := ovirtInterfaceModels[model]; exists {
+		model = ovirtModel
+	}
+	c.addInterface(v1.Interface{
+		Name:       name,
+		Model:      c.interfaceModel(name, model),
+		MacAddress: item.Address,
+	}, networkName)
+}
+
+// allocationUnits returns the number of bytes of the programmatic units of a CIM allocation, e.g. byte * 2^20
+func allocationUnits(units string) (int64, error) {
+	switch strings.ToLower(strings.TrimSpace(units)) {
+	case "byte", "bytes":
+		return 1, nil
+	case "kilobytes", "kb":
+		return 1 << 10, nil
+	case "megabytes", "mb":
+		return 1 << 20, nil
+	case "gigabytes", "gb":
+		return 1 << 30, nil
+	}
+	match := allocationUnitsExpression.FindStringSubmatch(strings.TrimSpace(units))
+	if match == nil {
+		return 0, fmt.Errorf("unknown allocation units %s", units)
+	}
+	base, _ := strconv.ParseFloat(match[1], 64)
+	exponent, _ := strconv.ParseFloat(match[2], 64)
+	multiplier := math.Pow(base, exponent)
+	if multiplier > math.MaxInt64 {
+		return 0, fmt.Errorf("allocation units %s are too large", units)
+	}
+	return int64(multiplier), nil
+}
@@ -0,0 +1,336 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vmimport
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+const testOVF = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1"
+    xmlns:rasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData"
+    xmlns:vssd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_VirtualSystemSettingData"
+    xmlns:vmw="http://www.vmware.com/schema/ovf">
+  <References>
+    <File ovf:id="file1" ovf:href="disk1.vmdk" ovf:size="1073741824"/>
+    <File ovf:id="file2" ovf:href="tools.iso" ovf:size="4194304"/>
+  </References>
+  <DiskSection>
+    <Info>Virtual disk information</Info>
+    <Disk ovf:diskId="vmdisk1" ovf:fileRef="file1" ovf:capacity="20" ovf:capacityAllocationUnits="byte * 2^30"/>
+  </DiskSection>
+  <NetworkSection>
+    <Info>The list of logical networks</Info>
+    <Network ovf:name="VM Network"/>
+  </NetworkSection>
+  <VirtualSystem ovf:id="web01">
+    <Info>A virtual machine</Info>
+    <Name>Web 01</Name>
+    <VirtualHardwareSection>
+      <Info>Virtual hardware requirements</Info>
+      <Item>
+        <rasd:AllocationUnits>hertz * 10^6</rasd:AllocationUnits>
+        <rasd:ElementName>4 virtual CPU(s)</rasd:ElementName>
+        <rasd:InstanceID>1</rasd:InstanceID>
+        <rasd:ResourceType>3</rasd:ResourceType>
+        <rasd:VirtualQuantity>4</rasd:VirtualQuantity>
+        <vmw:CoresPerSocket ovf:required="false">2</vmw:CoresPerSocket>
+      </Item>
+      <Item>
+        <rasd:AllocationUnits>byte * 2^20</rasd:AllocationUnits>
+        <rasd:ElementName>8192MB of memory</rasd:ElementName>
+        <rasd:InstanceID>2</rasd:InstanceID>
+        <rasd:ResourceType>4</rasd:ResourceType>
+        <rasd:VirtualQuantity>8192</rasd:VirtualQuantity>
+      </Item>
+      <Item>
+        <rasd:ElementName>SCSI Controller 0</rasd:ElementName>
+        <rasd:InstanceID>3</rasd:InstanceID>
+        <rasd:ResourceSubType>VirtualSCSI</rasd:ResourceSubType>
+        <rasd:ResourceType>6</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:ElementName>SATA Controller 0</rasd:ElementName>
+        <rasd:InstanceID>4</rasd:InstanceID>
+        <rasd:ResourceSubType>vmware.sata.ahci</rasd:ResourceSubType>
+        <rasd:ResourceType>20</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:AddressOnParent>0</rasd:AddressOnParent>
+        <rasd:ElementName>Hard disk 1</rasd:ElementName>
+        <rasd:HostResource>ovf:/disk/vmdisk1</rasd:HostResource>
+        <rasd:InstanceID>5</rasd:InstanceID>
+        <rasd:Parent>3</rasd:Parent>
+        <rasd:ResourceType>17</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:ElementName>CD/DVD drive 1</rasd:ElementName>
+        <rasd:HostResource>ovf:/file/file2</rasd:HostResource>
+        <rasd:InstanceID>6</rasd:InstanceID>
+        <rasd:Parent>4</rasd:Parent>
+        <rasd:ResourceType>15</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:ElementName>CD/DVD drive 2</rasd:ElementName>
+        <rasd:InstanceID>7</rasd:InstanceID>
+        <rasd:Parent>4</rasd:Parent>
+        <rasd:ResourceType>15</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:Address>00:50:56:aa:bb:cc</rasd:Address>
+        <rasd:Connection>VM Network</rasd:Connection>
+        <rasd:ElementName>Network adapter 1</rasd:ElementName>
+        <rasd:InstanceID>8</rasd:InstanceID>
+        <rasd:ResourceSubType>VmxNet3</rasd:ResourceSubType>
+        <rasd:ResourceType>10</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:ElementName>Floppy drive 1</rasd:ElementName>
+        <rasd:InstanceID>9</rasd:InstanceID>
+        <rasd:ResourceType>14</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:ElementName>Serial port 1</rasd:ElementName>
+        <rasd:InstanceID>10</rasd:InstanceID>
+        <rasd:ResourceType>21</rasd:ResourceType>
+      </Item>
+      <vmw:Config ovf:required="false" vmw:key="firmware" vmw:value="efi"/>
+    </VirtualHardwareSection>
+  </VirtualSystem>
+</Envelope>`
+
+const testOVirtOVF = `<?xml version="1.0" encoding="UTF-8"?>
+<ovf:Envelope xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1/"
+    xmlns:rasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData"
+    xmlns:vssd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_VirtualSystemSettingData"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" ovf:version="4.4.0.0">
+  <References>
+    <File ovf:href="7a6d3c02-2d5e-4b3c-9c5e-0f1c7e1d9a01/5c1f2a8e-93b4-4d7a-8d1e-6a0b2f4c8e11" ovf:id="5c1f2a8e-93b4-4d7a-8d1e-6a0b2f4c8e11" ovf:size="2147483648"/>
+    <File ovf:href="0d2e8b4f-1a3c-4e5d-8f6a-7b9c0d1e2f30/8e7d6c5b-4a39-4281-9f0e-1d2c3b4a5f60" ovf:id="8e7d6c5b-4a39-4281-9f0e-1d2c3b4a5f60" ovf:size="1073741824"/>
+  </References>
+  <Section xsi:type="ovf:NetworkSection_Type">
+    <Info>List of networks</Info>
+    <Network ovf:name="ovirtmgmt"/>
+  </Section>
+  <Section xsi:type="ovf:DiskSection_Type">
+    <Info>List of Virtual Disks</Info>
+    <Disk ovf:diskId="5c1f2a8e-93b4-4d7a-8d1e-6a0b2f4c8e11" ovf:size="20" ovf:actual_size="2"
+        ovf:fileRef="7a6d3c02-2d5e-4b3c-9c5e-0f1c7e1d9a01/5c1f2a8e-93b4-4d7a-8d1e-6a0b2f4c8e11"
+        ovf:format="http://www.vmware.com/specifications/vmdk.html#sparse" ovf:volume-format="COW"
+        ovf:disk-interface="VirtIO_SCSI" ovf:boot="true" ovf:disk-alias="db01_Disk1"/>
+    <Disk ovf:diskId="8e7d6c5b-4a39-4281-9f0e-1d2c3b4a5f60" ovf:size="50" ovf:actual_size="1"
+        ovf:fileRef="0d2e8b4f-1a3c-4e5d-8f6a-7b9c0d1e2f30/8e7d6c5b-4a39-4281-9f0e-1d2c3b4a5f60"
+        ovf:format="http://www.vmware.com/specifications/vmdk.html#sparse" ovf:volume-format="COW"
+        ovf:disk-interface="VirtIO" ovf:boot="false" ovf:disk-alias="db01_Disk2"/>
+  </Section>
+  <Content ovf:id="out" xsi:type="ovf:VirtualSystem_Type">
+    <Name>db01</Name>
+    <Section xsi:type="ovf:OperatingSystemSection_Type">
+      <Info>Guest Operating System</Info>
+      <Description>rhel_8x64</Description>
+    </Section>
+    <Section xsi:type="ovf:VirtualHardwareSection_Type">
+      <Info>4 CPU, 4096 Memory</Info>
+      <System>
+        <vssd:VirtualSystemType>ENGINE 4.4.0.0</vssd:VirtualSystemType>
+      </System>
+      <Item>
+        <rasd:Caption>4 virtual cpu</rasd:Caption>
+        <rasd:Description>Number of virtual CPU</rasd:Description>
+        <rasd:InstanceId>1</rasd:InstanceId>
+        <rasd:ResourceType>3</rasd:ResourceType>
+        <rasd:num_of_sockets>2</rasd:num_of_sockets>
+        <rasd:cpu_per_socket>2</rasd:cpu_per_socket>
+        <rasd:threads_per_cpu>1</rasd:threads_per_cpu>
+        <rasd:VirtualQuantity>4</rasd:VirtualQuantity>
+      </Item>
+      <Item>
+        <rasd:Caption>4096 MB of memory</rasd:Caption>
+        <rasd:Description>Memory Size</rasd:Description>
+        <rasd:InstanceId>2</rasd:InstanceId>
+        <rasd:ResourceType>4</rasd:ResourceType>
+        <rasd:AllocationUnits>MegaBytes</rasd:AllocationUnits>
+        <rasd:VirtualQuantity>4096</rasd:VirtualQuantity>
+      </Item>
+      <Item>
+        <rasd:Caption>db01_Disk1</rasd:Caption>
+        <rasd:InstanceId>5c1f2a8e-93b4-4d7a-8d1e-6a0b2f4c8e11</rasd:InstanceId>
+        <rasd:ResourceType>17</rasd:ResourceType>
+        <rasd:HostResource>7a6d3c02-2d5e-4b3c-9c5e-0f1c7e1d9a01/5c1f2a8e-93b4-4d7a-8d1e-6a0b2f4c8e11</rasd:HostResource>
+        <rasd:Parent>00000000-0000-0000-0000-000000000000</rasd:Parent>
+        <Type>disk</Type>
+        <Device>disk</Device>
+        <BootOrder>1</BootOrder>
+      </Item>
+      <Item>
+        <rasd:Caption>db01_Disk2</rasd:Caption>
+        <rasd:InstanceId>8e7d6c5b-4a39-4281-9f0e-1d2c3b4a5f60</rasd:InstanceId>
+        <rasd:ResourceType>17</rasd:ResourceType>
+        <rasd:HostResource>0d2e8b4f-1a3c-4e5d-8f6a-7b9c0d1e2f30/8e7d6c5b-4a39-4281-9f0e-1d2c3b4a5f60</rasd:HostResource>
+        <rasd:Parent>00000000-0000-0000-0000-000000000000</rasd:Parent>
+        <Type>disk</Type>
+        <Device>disk</Device>
+      </Item>
+      <Item>
+        <rasd:Caption>Ethernet adapter on ovirtmgmt</rasd:Caption>
+        <rasd:InstanceId>3f4e5d6c-7b8a-4999-8a7b-6c5d4e3f2a10</rasd:InstanceId>
+        <rasd:ResourceType>10</rasd:ResourceType>
+        <rasd:OtherResourceType>ovirtmgmt</rasd:OtherResourceType>
+        <rasd:ResourceSubType>3</rasd:ResourceSubType>
+        <rasd:Connection>ovirtmgmt</rasd:Connection>
+        <rasd:Linked>true</rasd:Linked>
+        <rasd:Name>nic1</rasd:Name>
+        <rasd:ElementName>nic1</rasd:ElementName>
+        <rasd:MACAddress>56:6f:a2:b1:00:01</rasd:MACAddress>
+        <Type>interface</Type>
+        <Device>bridge</Device>
+      </Item>
+    </Section>
+  </Content>
+</ovf:Envelope>`
+
+var _ = Describe("OVF", func() {
+	It("should convert an OVF virtual system to a VirtualMachine", func() {
+		result, err := Convert([]byte(testOVF), Options{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Unsupported).To(ConsistOf(
+			"item CD/DVD drive 2: empty cdrom drives cannot be represented",
+			"interface network-adapter-1: model vmxnet3 cannot be represented, virtio is used instead",
+			"item Floppy drive 1: floppy disks cannot be represented",
+			"item Serial port 1: resource type 21 cannot be represented",
+		))
+
+		vm := result.VirtualMachine
+		Expect(vm.Name).To(Equal("web-01"))
+		spec := vm.Spec.Template.Spec
+		domain := spec.Domain
+		Expect(domain.CPU).To(Equal(&v1.CPU{Sockets: 2, Cores: 2, Threads: 1}))
+		Expect(domain.Memory.Guest.String()).To(Equal("8Gi"))
+		Expect(domain.Firmware).To(Equal(&v1.Firmware{
+			Bootloader: &v1.Bootloader{EFI: &v1.EFI{SecureBoot: pointer.P(false)}},
+		}))
+
+		Expect(domain.Devices.Disks).To(Equal([]v1.Disk{
+			{Name: "hard-disk-1", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusSCSI}}},
+			{Name: "cd-dvd-drive-1", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: v1.DiskBusSATA}}},
+		}))
+		Expect(vm.Spec.DataVolumeTemplates).To(HaveLen(2))
+		Expect(vm.Spec.DataVolumeTemplates[0].Name).To(Equal("web-01-hard-disk-1"))
+		Expect(vm.Spec.DataVolumeTemplates[0].Spec.Storage.Resources.Requests.Storage().String()).To(Equal("20Gi"))
+		Expect(vm.Spec.DataVolumeTemplates[1].Name).To(Equal("web-01-cd-dvd-drive-1"))
+		Expect(vm.Spec.DataVolumeTemplates[1].Spec.Storage.Resources.Requests.Storage().String()).To(Equal(DefaultDiskSize))
+
+		Expect(domain.Devices.Interfaces).To(Equal([]v1.Interface{
+			{
+				Name:                   "network-adapter-1",
+				Model:                  v1.VirtIO,
+				MacAddress:             "00:50:56:aa:bb:cc",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+			},
+		}))
+		Expect(spec.Networks).To(Equal([]v1.Network{
+			{Name: "network-adapter-1", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "vm-network"}}},
+		}))
+		Expect(domain.Devices.AutoattachPodInterface).To(HaveValue(BeFalse()))
+	})
+
+	It("should convert an oVirt OVF virtual system to a VirtualMachine", func() {
+		result, err := Convert([]byte(testOVirtOVF), Options{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Unsupported).To(BeEmpty())
+
+		vm := result.VirtualMachine
+		Expect(vm.Name).To(Equal("db01"))
+		domain := vm.Spec.Template.Spec.Domain
+		Expect(domain.CPU).To(Equal(&v1.CPU{Sockets: 2, Cores: 2, Threads: 1}))
+		Expect(domain.Memory.Guest.String()).To(Equal("4Gi"))
+
+		Expect(domain.Devices.Disks).To(Equal([]v1.Disk{
+			{Name: "db01-disk1", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusSCSI}}},
+			{Name: "db01-disk2", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}}},
+		}))
+		Expect(vm.Spec.DataVolumeTemplates).To(HaveLen(2))
+		Expect(vm.Spec.DataVolumeTemplates[0].Spec.Storage.Resources.Requests.Storage().String()).To(Equal("20Gi"))
+		Expect(vm.Spec.DataVolumeTemplates[1].Spec.Storage.Resources.Requests.Storage().String()).To(Equal("50Gi"))
+
+		Expect(domain.Devices.Interfaces).To(Equal([]v1.Interface{
+			{
+				Name:                   "nic1",
+				Model:                  v1.VirtIO,
+				MacAddress:             "56:6f:a2:b1:00:01",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+			},
+		}))
+		Expect(vm.Spec.Template.Spec.Networks).To(Equal([]v1.Network{
+			{Name: "nic1", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "ovirtmgmt"}}},
+		}))
+	})
+
+	It("should size disks without a disk section entry with the default disk size", func() {
+		result, err := FromOVF([]byte(`<Envelope><References><File ovf:id="file1" ovf:href="disk.vmdk" ovf:size="1048576"/></References>
+<VirtualSystem ovf:id="vm"><VirtualHardwareSection>
+  <Item><ElementName>disk</ElementName><HostResource>ovf:/file/file1</HostResource><ResourceType>17</ResourceType></Item>
+</VirtualHardwareSection></VirtualSystem></Envelope>`), Options{DiskSize: resource.MustParse("30Gi")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.VirtualMachine.Spec.DataVolumeTemplates).To(HaveLen(1))
+		Expect(result.VirtualMachine.Spec.DataVolumeTemplates[0].Spec.Storage.Resources.Requests.Storage().String()).To(Equal("30Gi"))
+	})
+
+	It("should connect interfaces of the pod network to the pod network", func() {
+		result, err := FromOVF([]byte(`<Envelope><VirtualSystem ovf:id="vm"><VirtualHardwareSection>
+  <Item><ElementName>default</ElementName><Connection>pod</Connection><ResourceSubType>virtio</ResourceSubType><ResourceType>10</ResourceType></Item>
+</VirtualHardwareSection></VirtualSystem></Envelope>`), Options{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Unsupported).To(BeEmpty())
+		Expect(result.VirtualMachine.Name).To(Equal("vm"))
+		Expect(result.VirtualMachine.Spec.Template.Spec.Networks).To(Equal([]v1.Network{
+			{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+		}))
+		Expect(result.VirtualMachine.Spec.Template.Spec.Domain.Devices.Interfaces[0].Masquerade).ToNot(BeNil())
+	})
+
+	It("should fail without a virtual hardware section", func() {
+		_, err := FromOVF([]byte(`<Envelope><VirtualSystem ovf:id="vm"/></Envelope>`), Options{})
+		Expect(err).To(MatchError("OVF descriptor has no virtual hardware section"))
+	})
+
+	It("should fail when a disk is missing from the disk section", func() {
+		_, err := FromOVF([]byte(`<Envelope><VirtualSystem ovf:id="vm"><VirtualHardwareSection>
+  <Item><ElementName>disk</ElementName><HostResource>ovf:/disk/missing</HostResource><ResourceType>17</ResourceType></Item>
+</VirtualHardwareSection></VirtualSystem></Envelope>`), Options{})
+		Expect(err).To(MatchError("item disk: disk missing not found in the disk section"))
+	})
+
+	DescribeTable("should parse allocation units", func(units string, expected int64) {
+		Expect(allocationUnits(units)).To(Equal(expected))
+	},
+		Entry("bytes", "byte", int64(1)),
+		Entry("binary prefixes", "byte * 2^20", int64(1<<20)),
+		Entry("decimal prefixes", "byte * 10^3", int64(1000)),
+		Entry("legacy names", "MegaBytes", int64(1<<20)),
+	)
+})
@@ -0,0 +1,274 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+// Package vmimport converts foreign VM definitions, libvirt domain XML and OVF descriptors, to VirtualMachines.
+package vmimport
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+const (
+	// DefaultDiskSize is the size of the DataVolumes of the disks whose capacity is not part of the definition
+	DefaultDiskSize = "10Gi"
+
+	domainRootElement = "domain"
+	ovfRootElement    = "Envelope"
+
+	maxNameLength = 63
+)
+
+var invalidNameChars = regexp.MustCompile("[^a-z0-9-]+")
+
+// Options tunes the conversion
+type Options struct {
+	// DiskSize is the size of the DataVolumes of the disks whose capacity is not part of the definition
+	DiskSize resource.Quantity
+}
+
+// Result is a VirtualMachine converted from a foreign VM definition
+type Result struct {
+	VirtualMachine *v1.VirtualMachine
+	// Unsupported lists the parts of the definition that cannot be represented in the VirtualMachine
+	Unsupported []string
+}
+
+// Convert converts a libvirt domain XML or an OVF descriptor, depending on its root element, to a VirtualMachine
+func Convert(data []byte, opts Options) (*Result, error) {
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+	switch root {
+	case domainRootElement:
+		return FromDomainXML(data, opts)
+	case ovfRootElement:
+		return FromOVF(data, opts)
+	default:
+		return nil, fmt.Errorf("unknown root element %s, expected a libvirt domain or an OVF envelope", root)
+	}
+}
+
+func rootElement(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return "", fmt.Errorf("no XML element found")
+		}
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// converter builds the VirtualMachine and collects what cannot be represented
+type converter struct {
+	vm          *v1.VirtualMachine
+	opts        Options
+	unsupported []string
+}
+
+func newConverter(name string, opts Options) *converter {
+	if opts.DiskSize.IsZero() {
+		opts.DiskSize = resource.MustParse(DefaultDiskSize)
+	}
+	// imported VMs are halted until the content of their disks has been uploaded
+	runStrategy := v1.RunStrategyHalted
+	return &converter{
+		opts: opts,
+		vm: &v1.VirtualMachine{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       v1.VirtualMachineGroupVersionKind.Kind,
+				APIVersion: v1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: sanitizeName(name, "vm"),
+			},
+			Spec: v1.VirtualMachineSpec{
+				RunStrategy: &runStrategy,
+				Template:    &v1.VirtualMachineInstanceTemplateSpec{},
+			},
+		},
+	}
+}
+
+func (c *converter) result() *Result {
+	return &Result{
+		VirtualMachine: c.vm,
+		Unsupported:    c.unsupported,
+	}
+}
+
+func (c *converter) domain() *v1.DomainSpec {
+	return &c.vm.Spec.Template.Spec.Domain
+}
+
+func (c *converter) reportUnsupported(format string, args ...interface{}) {
+	c.unsupported = append(c.unsupported, fmt.Sprintf(format, args...))
+}
+
+func (c *converter) setCPU(count, sockets, cores, threads uint32) {
+	if count == 0 {
+		count = 1
+	}
+	if sockets == 0 && cores == 0 && threads == 0 {
+		sockets, cores, threads = count, 1, 1
+	}
+	if sockets*cores*threads != count {
+		c.reportUnsupported("cpu: topology of %d sockets, %d cores and %d threads does not match %d vCPUs", sockets, cores, threads, count)
+	}
+	if c.domain().CPU == nil {
+		c.domain().CPU = &v1.CPU{}
+	}
+	c.domain().CPU.Sockets = sockets
+	c.domain().CPU.Cores = cores
+	c.domain().CPU.Threads = threads
+}
+
+func (c *converter) setMemory(bytes int64) {
+	c.domain().Memory = &v1.Memory{
+		Guest: resource.NewQuantity(bytes, resource.BinarySI),
+	}
+}
+
+// addDisk adds the disk backed by a DataVolume waiting for the content of the original disk to be uploaded
+func (c *converter) addDisk(disk v1.Disk, size resource.Quantity) {
+	disk.Name = c.uniqueDiskName(disk.Name)
+	dvName := sanitizeName(c.vm.Name+"-"+disk.Name, disk.Name)
+	c.domain().Devices.Disks = append(c.domain().Devices.Disks, disk)
+	c.vm.Spec.Template.Spec.Volumes = append(c.vm.Spec.Template.Spec.Volumes, v1.Volume{
+		Name: disk.Name,
+		VolumeSource: v1.VolumeSource{
+			DataVolume: &v1.DataVolumeSource{
+				Name: dvName,
+			},
+		},
+	})
+	c.vm.Spec.DataVolumeTemplates = append(c.vm.Spec.DataVolumeTemplates, v1.DataVolumeTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: dvName,
+		},
+		Spec: cdiv1.DataVolumeSpec{
+			Source: &cdiv1.DataVolumeSource{
+				Upload: &cdiv1.DataVolumeSourceUpload{},
+			},
+			Storage: &cdiv1.StorageSpec{
+				Resources: k8sv1.ResourceRequirements{
+					Requests: k8sv1.ResourceList{
+						k8sv1.ResourceStorage: size,
+					},
+				},
+			},
+		},
+	})
+}
+
+func (c *converter) uniqueDiskName(name string) string {
+	name = sanitizeName(name, "disk")
+	unique := name
+	for i := 1; ; i++ {
+		exists := false
+		for _, disk := range c.domain().Devices.Disks {
+			if disk.Name == unique {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			return unique
+		}
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+}
+
+// addInterface adds the interface, connected to the pod network when networkName is empty or to a multus network otherwise
+func (c *converter) addInterface(iface v1.Interface, networkName string) {
+	network := v1.Network{Name: iface.Name}
+	if networkName == "" {
+		for _, existing := range c.vm.Spec.Template.Spec.Networks {
+			if existing.Pod != nil {
+				c.reportUnsupported("interface %s: only one interface can be connected to the pod network", iface.Name)
+				return
+			}
+		}
+		network.Pod = &v1.PodNetwork{}
+		iface.InterfaceBindingMethod = v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}
+	} else {
+		network.Multus = &v1.MultusNetwork{NetworkName: sanitizeName(networkName, "network")}
+		iface.InterfaceBindingMethod = v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}
+	}
+	c.domain().Devices.Interfaces = append(c.domain().Devices.Interfaces, iface)
+	c.vm.Spec.Template.Spec.Networks = append(c.vm.Spec.Template.Spec.Networks, network)
+}
+
+// finish disables the pod network when no interface is connected to it
+func (c *converter) finish() {
+	for _, network := range c.vm.Spec.Template.Spec.Networks {
+		if network.Pod != nil {
+			return
+		}
+	}
+	c.domain().Devices.AutoattachPodInterface = pointer.P(false)
+}
+
+// interfaceModel maps a NIC model to a model supported by KubeVirt
+func (c *converter) interfaceModel(name, model string) string {
+	model = strings.ToLower(model)
+	switch model {
+	case "", v1.VirtIO, "virtio-transitional", "virtio-non-transitional":
+		return v1.VirtIO
+	case "e1000", "e1000e", "ne2k_pci", "pcnet", "rtl8139":
+		return model
+	case "pcnet32":
+		return "pcnet"
+	}
+	c.reportUnsupported("interface %s: model %s cannot be represented, virtio is used instead", name, model)
+	return v1.VirtIO
+}
+
+// sanitizeName turns name into a DNS-1123 label, fallback is used when nothing is left of name
+func sanitizeName(name, fallback string) string {
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return fallback
+	}
+	return name
+}
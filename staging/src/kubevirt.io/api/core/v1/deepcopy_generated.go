@@ -118,6 +118,55 @@ func (in *AddVolumeOptions) DeepCopy() *AddVolumeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertRuleConfiguration) DeepCopyInto(out *AlertRuleConfiguration) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRuleConfiguration.
+func (in *AlertRuleConfiguration) DeepCopy() *AlertRuleConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertRuleConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingConfiguration) DeepCopyInto(out *AlertingConfiguration) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]AlertRuleConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingConfiguration.
+func (in *AlertingConfiguration) DeepCopy() *AlertingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArchConfiguration) DeepCopyInto(out *ArchConfiguration) {
 	*out = *in
@@ -293,6 +342,11 @@ func (in *CPU) DeepCopyInto(out *CPU) {
 		*out = new(NUMA)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(uint64)
+		**out = **in
+	}
 	if in.Realtime != nil {
 		in, out := &in.Realtime, &out.Realtime
 		*out = new(Realtime)
@@ -1624,6 +1678,70 @@ func (in *Flags) DeepCopy() *Flags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeHooks) DeepCopyInto(out *FreezeHooks) {
+	*out = *in
+	if in.PreFreeze != nil {
+		in, out := &in.PreFreeze, &out.PreFreeze
+		*out = make([]GuestHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostThaw != nil {
+		in, out := &in.PostThaw, &out.PostThaw
+		*out = make([]GuestHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeHooks.
+func (in *FreezeHooks) DeepCopy() *FreezeHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeHooksStatus) DeepCopyInto(out *FreezeHooksStatus) {
+	*out = *in
+	if in.PreFreezeTimestamp != nil {
+		in, out := &in.PreFreezeTimestamp, &out.PreFreezeTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.PreFreezeFailed != nil {
+		in, out := &in.PreFreezeFailed, &out.PreFreezeFailed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostThawTimestamp != nil {
+		in, out := &in.PostThawTimestamp, &out.PostThawTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.PostThawFailed != nil {
+		in, out := &in.PostThawFailed, &out.PostThawFailed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeHooksStatus.
+func (in *FreezeHooksStatus) DeepCopy() *FreezeHooksStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeHooksStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FreezeUnfreezeTimeout) DeepCopyInto(out *FreezeUnfreezeTimeout) {
 	*out = *in
@@ -1714,6 +1832,32 @@ func (in *GuestAgentPing) DeepCopy() *GuestAgentPing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestHook) DeepCopyInto(out *GuestHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestHook.
+func (in *GuestHook) DeepCopy() *GuestHook {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPETTimer) DeepCopyInto(out *HPETTimer) {
 	*out = *in
@@ -1936,6 +2080,65 @@ func (in *InstancetypeMatcher) DeepCopy() *InstancetypeMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancetypeRevisionDiff) DeepCopyInto(out *InstancetypeRevisionDiff) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]InstancetypeSpecChange, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancetypeRevisionDiff.
+func (in *InstancetypeRevisionDiff) DeepCopy() *InstancetypeRevisionDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancetypeRevisionDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancetypeSpecChange) DeepCopyInto(out *InstancetypeSpecChange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancetypeSpecChange.
+func (in *InstancetypeSpecChange) DeepCopy() *InstancetypeSpecChange {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancetypeSpecChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancetypeUpgradeOptions) DeepCopyInto(out *InstancetypeUpgradeOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancetypeUpgradeOptions.
+func (in *InstancetypeUpgradeOptions) DeepCopy() *InstancetypeUpgradeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancetypeUpgradeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Interface) DeepCopyInto(out *Interface) {
 	*out = *in
@@ -2217,6 +2420,68 @@ func (in *KubeVirt) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtCanaryUpgradeStatus) DeepCopyInto(out *KubeVirtCanaryUpgradeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SoakStartTime != nil {
+		in, out := &in.SoakStartTime, &out.SoakStartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtCanaryUpgradeStatus.
+func (in *KubeVirtCanaryUpgradeStatus) DeepCopy() *KubeVirtCanaryUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtCanaryUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtCanaryUpgradeStrategy) DeepCopyInto(out *KubeVirtCanaryUpgradeStrategy) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SoakPeriod != nil {
+		in, out := &in.SoakPeriod, &out.SoakPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtCanaryUpgradeStrategy.
+func (in *KubeVirtCanaryUpgradeStrategy) DeepCopy() *KubeVirtCanaryUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtCanaryUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeVirtCertificateRotateStrategy) DeepCopyInto(out *KubeVirtCertificateRotateStrategy) {
 	*out = *in
@@ -2393,6 +2658,16 @@ func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
 		*out = new(LiveUpdateConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxCPUWeight != nil {
+		in, out := &in.MaxCPUWeight, &out.MaxCPUWeight
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2502,6 +2777,16 @@ func (in *KubeVirtSpec) DeepCopyInto(out *KubeVirtSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.CustomizeComponents.DeepCopyInto(&out.CustomizeComponents)
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(AlertingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CanaryUpgrade != nil {
+		in, out := &in.CanaryUpgrade, &out.CanaryUpgrade
+		*out = new(KubeVirtCanaryUpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2540,6 +2825,11 @@ func (in *KubeVirtStatus) DeepCopyInto(out *KubeVirtStatus) {
 		*out = make([]GenerationStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.CanaryUpgrade != nil {
+		in, out := &in.CanaryUpgrade, &out.CanaryUpgrade
+		*out = new(KubeVirtCanaryUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2723,6 +3013,22 @@ func (in *Machine) DeepCopy() *Machine {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MediatedDeviceParentStatus) DeepCopyInto(out *MediatedDeviceParentStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MediatedDeviceParentStatus.
+func (in *MediatedDeviceParentStatus) DeepCopy() *MediatedDeviceParentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MediatedDeviceParentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MediatedDevicesConfiguration) DeepCopyInto(out *MediatedDevicesConfiguration) {
 	*out = *in
@@ -3068,6 +3374,27 @@ func (in *NodeMediatedDeviceTypesConfig) DeepCopy() *NodeMediatedDeviceTypesConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMediatedDevicesStatus) DeepCopyInto(out *NodeMediatedDevicesStatus) {
+	*out = *in
+	if in.ParentDevices != nil {
+		in, out := &in.ParentDevices, &out.ParentDevices
+		*out = make([]MediatedDeviceParentStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMediatedDevicesStatus.
+func (in *NodeMediatedDevicesStatus) DeepCopy() *NodeMediatedDevicesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMediatedDevicesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
 	*out = *in
@@ -3175,6 +3502,13 @@ func (in *PermittedHostDevices) DeepCopyInto(out *PermittedHostDevices) {
 		*out = make([]MediatedHostDevice, len(*in))
 		copy(*out, *in)
 	}
+	if in.USB != nil {
+		in, out := &in.USB, &out.USB
+		*out = make([]USBHostDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -4044,6 +4378,101 @@ func (in *TopologyHints) DeepCopy() *TopologyHints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingConfiguration) DeepCopyInto(out *TracingConfiguration) {
+	*out = *in
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(TracingOTLPExporter)
+		**out = **in
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(TracingFileExporter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingConfiguration.
+func (in *TracingConfiguration) DeepCopy() *TracingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingFileExporter) DeepCopyInto(out *TracingFileExporter) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingFileExporter.
+func (in *TracingFileExporter) DeepCopy() *TracingFileExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingFileExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingOTLPExporter) DeepCopyInto(out *TracingOTLPExporter) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingOTLPExporter.
+func (in *TracingOTLPExporter) DeepCopy() *TracingOTLPExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingOTLPExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *USBHostDevice) DeepCopyInto(out *USBHostDevice) {
+	*out = *in
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]USBSelector, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new USBHostDevice.
+func (in *USBHostDevice) DeepCopy() *USBHostDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(USBHostDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *USBSelector) DeepCopyInto(out *USBSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new USBSelector.
+func (in *USBSelector) DeepCopy() *USBSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(USBSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnpauseOptions) DeepCopyInto(out *UnpauseOptions) {
 	*out = *in
@@ -4386,6 +4815,11 @@ func (in *VirtualMachineInstanceGuestAgentInfo) DeepCopyInto(out *VirtualMachine
 		copy(*out, *in)
 	}
 	in.FSInfo.DeepCopyInto(&out.FSInfo)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(VirtualMachineInstanceGuestMetrics)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4407,6 +4841,85 @@ func (in *VirtualMachineInstanceGuestAgentInfo) DeepCopyObject() runtime.Object
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceGuestCPUStats) DeepCopyInto(out *VirtualMachineInstanceGuestCPUStats) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestCPUStats.
+func (in *VirtualMachineInstanceGuestCPUStats) DeepCopy() *VirtualMachineInstanceGuestCPUStats {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceGuestCPUStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceGuestDiskStats) DeepCopyInto(out *VirtualMachineInstanceGuestDiskStats) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestDiskStats.
+func (in *VirtualMachineInstanceGuestDiskStats) DeepCopy() *VirtualMachineInstanceGuestDiskStats {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceGuestDiskStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceGuestLoad) DeepCopyInto(out *VirtualMachineInstanceGuestLoad) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestLoad.
+func (in *VirtualMachineInstanceGuestLoad) DeepCopy() *VirtualMachineInstanceGuestLoad {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceGuestLoad)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceGuestMetrics) DeepCopyInto(out *VirtualMachineInstanceGuestMetrics) {
+	*out = *in
+	if in.Load != nil {
+		in, out := &in.Load, &out.Load
+		*out = new(VirtualMachineInstanceGuestLoad)
+		**out = **in
+	}
+	if in.CPUStats != nil {
+		in, out := &in.CPUStats, &out.CPUStats
+		*out = make([]VirtualMachineInstanceGuestCPUStats, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiskStats != nil {
+		in, out := &in.DiskStats, &out.DiskStats
+		*out = make([]VirtualMachineInstanceGuestDiskStats, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestMetrics.
+func (in *VirtualMachineInstanceGuestMetrics) DeepCopy() *VirtualMachineInstanceGuestMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceGuestMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceGuestOSInfo) DeepCopyInto(out *VirtualMachineInstanceGuestOSInfo) {
 	*out = *in
@@ -4503,6 +5016,26 @@ func (in *VirtualMachineInstanceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceMemoryDirtyRate) DeepCopyInto(out *VirtualMachineInstanceMemoryDirtyRate) {
+	*out = *in
+	if in.SampleTimestamp != nil {
+		in, out := &in.SampleTimestamp, &out.SampleTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceMemoryDirtyRate.
+func (in *VirtualMachineInstanceMemoryDirtyRate) DeepCopy() *VirtualMachineInstanceMemoryDirtyRate {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceMemoryDirtyRate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceMigration) DeepCopyInto(out *VirtualMachineInstanceMigration) {
 	*out = *in
@@ -4974,6 +5507,32 @@ func (in *VirtualMachineInstanceReplicaSetStatus) DeepCopy() *VirtualMachineInst
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceResourceUsage) DeepCopyInto(out *VirtualMachineInstanceResourceUsage) {
+	*out = *in
+	out.PeakCPU = in.PeakCPU.DeepCopy()
+	if in.PeakMemory != nil {
+		in, out := &in.PeakMemory, &out.PeakMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.WindowStart != nil {
+		in, out := &in.WindowStart, &out.WindowStart
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceResourceUsage.
+func (in *VirtualMachineInstanceResourceUsage) DeepCopy() *VirtualMachineInstanceResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSpec) {
 	*out = *in
@@ -5055,6 +5614,11 @@ func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FreezeHooks != nil {
+		in, out := &in.FreezeHooks, &out.FreezeHooks
+		*out = new(FreezeHooks)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5117,6 +5681,11 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FreezeHooks != nil {
+		in, out := &in.FreezeHooks, &out.FreezeHooks
+		*out = new(FreezeHooksStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TopologyHints != nil {
 		in, out := &in.TopologyHints, &out.TopologyHints
 		*out = new(TopologyHints)
@@ -5137,6 +5706,16 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		*out = new(CPUTopology)
 		**out = **in
 	}
+	if in.MemoryDirtyRate != nil {
+		in, out := &in.MemoryDirtyRate, &out.MemoryDirtyRate
+		*out = new(VirtualMachineInstanceMemoryDirtyRate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(VirtualMachineInstanceResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5168,6 +5747,41 @@ func (in *VirtualMachineInstanceTemplateSpec) DeepCopy() *VirtualMachineInstance
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstancetypeDiff) DeepCopyInto(out *VirtualMachineInstancetypeDiff) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Instancetype != nil {
+		in, out := &in.Instancetype, &out.Instancetype
+		*out = new(InstancetypeRevisionDiff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preference != nil {
+		in, out := &in.Preference, &out.Preference
+		*out = new(InstancetypeRevisionDiff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstancetypeDiff.
+func (in *VirtualMachineInstancetypeDiff) DeepCopy() *VirtualMachineInstancetypeDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstancetypeDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineInstancetypeDiff) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInterfaceRequest) DeepCopyInto(out *VirtualMachineInterfaceRequest) {
 	*out = *in
@@ -5277,6 +5891,34 @@ func (in *VirtualMachineOptions) DeepCopy() *VirtualMachineOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineResourceRecommendation) DeepCopyInto(out *VirtualMachineResourceRecommendation) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	out.PeakCPU = in.PeakCPU.DeepCopy()
+	if in.PeakMemory != nil {
+		in, out := &in.PeakMemory, &out.PeakMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineResourceRecommendation.
+func (in *VirtualMachineResourceRecommendation) DeepCopy() *VirtualMachineResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 	*out = *in
@@ -5434,6 +6076,18 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = make([]VirtualMachineTimelineEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceRecommendation != nil {
+		in, out := &in.ResourceRecommendation, &out.ResourceRecommendation
+		*out = new(VirtualMachineResourceRecommendation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5447,6 +6101,55 @@ func (in *VirtualMachineStatus) DeepCopy() *VirtualMachineStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineTimeline) DeepCopyInto(out *VirtualMachineTimeline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]VirtualMachineTimelineEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineTimeline.
+func (in *VirtualMachineTimeline) DeepCopy() *VirtualMachineTimeline {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineTimeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineTimeline) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineTimelineEntry) DeepCopyInto(out *VirtualMachineTimelineEntry) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineTimelineEntry.
+func (in *VirtualMachineTimelineEntry) DeepCopy() *VirtualMachineTimelineEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineTimelineEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineVolumeRequest) DeepCopyInto(out *VirtualMachineVolumeRequest) {
 	*out = *in
@@ -313,6 +313,12 @@ type CPU struct {
 	// with enough dedicated pCPUs and pin the vCPUs to it.
 	// +optional
 	DedicatedCPUPlacement bool `json:"dedicatedCpuPlacement,omitempty"`
+	// SoftCPUPlacement requests virt-handler to pin the vCPUs, the emulator threads and the memory
+	// of the VirtualMachineInstance to the least loaded NUMA node of the host, without requiring
+	// dedicated pCPUs. The placement is periodically rebalanced.
+	// Can't be combined with DedicatedCPUPlacement.
+	// +optional
+	SoftCPUPlacement bool `json:"softCPUPlacement,omitempty"`
 
 	// NUMA allows specifying settings for the guest NUMA topology
 	// +optional
@@ -322,6 +328,19 @@ type CPU struct {
 	// the emulator thread on it.
 	// +optional
 	IsolateEmulatorThread bool `json:"isolateEmulatorThread,omitempty"`
+	// EmulatorThreadPoolSize defines how many dedicated pCPUs are allocated for the emulator
+	// and IO threads when IsolateEmulatorThread is set. The IO threads of disks with a
+	// dedicated IO thread are spread over the pool.
+	// Defaults to 1.
+	// +optional
+	EmulatorThreadPoolSize uint32 `json:"emulatorThreadPoolSize,omitempty"`
+	// Weight sets the relative CPU weight of the VirtualMachineInstance, which is used to
+	// prioritise between VirtualMachineInstances competing for CPU time on the same node.
+	// The range is 1 to 10000, like cgroup v2 cpu.weight, capped by the cluster wide maxCPUWeight.
+	// On cgroup v1 hosts it is converted to cpu.shares.
+	// Defaults to the weight derived from the CPU request. Requires the CPUWeight feature gate.
+	// +optional
+	Weight *uint64 `json:"weight,omitempty"`
 	// Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads
 	// +optional
 	Realtime *Realtime `json:"realtime,omitempty"`
@@ -818,6 +837,9 @@ type MemoryDumpVolumeSource struct {
 	// Directly attached to the virt launcher
 	// +optional
 	PersistentVolumeClaimVolumeSource `json:",inline"`
+	// Format of the memory dump, defaults to CoreDump
+	// +optional
+	Format MemoryDumpFormat `json:"format,omitempty"`
 }
 
 type EphemeralVolumeSource struct {
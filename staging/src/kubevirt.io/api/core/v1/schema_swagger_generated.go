@@ -155,17 +155,20 @@ func (ResourceRequirements) SwaggerDoc() map[string]string {
 
 func (CPU) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                      "CPU allows specifying the CPU topology.",
-		"cores":                 "Cores specifies the number of cores inside the vmi.\nMust be a value greater or equal 1.",
-		"sockets":               "Sockets specifies the number of sockets inside the vmi.\nMust be a value greater or equal 1.",
-		"maxSockets":            "MaxSockets specifies the maximum amount of sockets that can\nbe hotplugged",
-		"threads":               "Threads specifies the number of threads inside the vmi.\nMust be a value greater or equal 1.",
-		"model":                 "Model specifies the CPU model inside the VMI.\nList of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.\nIt is possible to specify special cases like \"host-passthrough\" to get the same CPU as the node\nand \"host-model\" to get CPU closest to the node one.\nDefaults to host-model.\n+optional",
-		"features":              "Features specifies the CPU features list inside the VMI.\n+optional",
-		"dedicatedCpuPlacement": "DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node\nwith enough dedicated pCPUs and pin the vCPUs to it.\n+optional",
-		"numa":                  "NUMA allows specifying settings for the guest NUMA topology\n+optional",
-		"isolateEmulatorThread": "IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place\nthe emulator thread on it.\n+optional",
-		"realtime":              "Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads\n+optional",
+		"":                       "CPU allows specifying the CPU topology.",
+		"cores":                  "Cores specifies the number of cores inside the vmi.\nMust be a value greater or equal 1.",
+		"sockets":                "Sockets specifies the number of sockets inside the vmi.\nMust be a value greater or equal 1.",
+		"maxSockets":             "MaxSockets specifies the maximum amount of sockets that can\nbe hotplugged",
+		"threads":                "Threads specifies the number of threads inside the vmi.\nMust be a value greater or equal 1.",
+		"model":                  "Model specifies the CPU model inside the VMI.\nList of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.\nIt is possible to specify special cases like \"host-passthrough\" to get the same CPU as the node\nand \"host-model\" to get CPU closest to the node one.\nDefaults to host-model.\n+optional",
+		"features":               "Features specifies the CPU features list inside the VMI.\n+optional",
+		"dedicatedCpuPlacement":  "DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node\nwith enough dedicated pCPUs and pin the vCPUs to it.\n+optional",
+		"softCPUPlacement":       "SoftCPUPlacement requests virt-handler to pin the vCPUs, the emulator threads and the memory\nof the VirtualMachineInstance to the least loaded NUMA node of the host, without requiring\ndedicated pCPUs. The placement is periodically rebalanced.\nCan't be combined with DedicatedCPUPlacement.\n+optional",
+		"numa":                   "NUMA allows specifying settings for the guest NUMA topology\n+optional",
+		"isolateEmulatorThread":  "IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place\nthe emulator thread on it.\n+optional",
+		"emulatorThreadPoolSize": "EmulatorThreadPoolSize defines how many dedicated pCPUs are allocated for the emulator\nand IO threads when IsolateEmulatorThread is set. The IO threads of disks with a\ndedicated IO thread are spread over the pool.\nDefaults to 1.\n+optional",
+		"weight":                 "Weight sets the relative CPU weight of the VirtualMachineInstance, which is used to\nprioritise between VirtualMachineInstances competing for CPU time on the same node.\nThe range is 1 to 10000, like cgroup v2 cpu.weight, capped by the cluster wide maxCPUWeight.\nOn cgroup v1 hosts it is converted to cpu.shares.\nDefaults to the weight derived from the CPU request. Requires the CPUWeight feature gate.\n+optional",
+		"realtime":               "Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads\n+optional",
 	}
 }
 
@@ -444,7 +447,9 @@ func (PersistentVolumeClaimVolumeSource) SwaggerDoc() map[string]string {
 }
 
 func (MemoryDumpVolumeSource) SwaggerDoc() map[string]string {
-	return map[string]string{}
+	return map[string]string{
+		"format": "Format of the memory dump, defaults to CoreDump\n+optional",
+	}
 }
 
 func (EphemeralVolumeSource) SwaggerDoc() map[string]string {
@@ -162,6 +162,11 @@ type VirtualMachineInstanceSpec struct {
 	// +listType=atomic
 	// +optional
 	AccessCredentials []AccessCredential `json:"accessCredentials,omitempty"`
+	// FreezeHooks are commands run in the guest by the guest agent before its
+	// filesystems are frozen and after they are thawed, so that the applications
+	// of the guest are consistent while it is frozen
+	// +optional
+	FreezeHooks *FreezeHooks `json:"freezeHooks,omitempty"`
 	// Specifies the architecture of the vm guest you are attempting to run. Defaults to the compiled architecture of the KubeVirt components
 	Architecture string `json:"architecture,omitempty"`
 }
@@ -252,6 +257,10 @@ type VirtualMachineInstanceStatus struct {
 	// +optional
 	FSFreezeStatus string `json:"fsFreezeStatus,omitempty"`
 
+	// FreezeHooks is the outcome of the freeze hooks of the last freeze and thaw of the guest
+	// +optional
+	FreezeHooks *FreezeHooksStatus `json:"freezeHooks,omitempty"`
+
 	// +optional
 	TopologyHints *TopologyHints `json:"topologyHints,omitempty"`
 
@@ -280,6 +289,41 @@ type VirtualMachineInstanceStatus struct {
 	// Current topology may differ from the desired topology in the spec while CPU hotplug
 	// takes place.
 	CurrentCPUTopology *CPUTopology `json:"currentCPUTopology,omitempty"`
+
+	// MemoryDirtyRate is the guest memory dirty rate measured by the last periodic sample.
+	// It is used to predict whether a live migration of the VMI will converge.
+	// +optional
+	MemoryDirtyRate *VirtualMachineInstanceMemoryDirtyRate `json:"memoryDirtyRate,omitempty"`
+
+	// ResourceUsage is the peak guest CPU and memory usage observed over the sampling window.
+	// It is used to recommend a right-sized instancetype for the owning VM.
+	// +optional
+	ResourceUsage *VirtualMachineInstanceResourceUsage `json:"resourceUsage,omitempty"`
+}
+
+// VirtualMachineInstanceMemoryDirtyRate represents a sample of the guest memory dirty rate
+type VirtualMachineInstanceMemoryDirtyRate struct {
+	// BytesPerSecond is the amount of guest memory dirtied per second
+	BytesPerSecond int64 `json:"bytesPerSecond"`
+	// SampleTimestamp is the time the measurement was started
+	// +optional
+	// +nullable
+	SampleTimestamp *metav1.Time `json:"sampleTimestamp,omitempty"`
+}
+
+// VirtualMachineInstanceResourceUsage represents the peak guest resource usage over a sampling window
+type VirtualMachineInstanceResourceUsage struct {
+	// PeakCPU is the highest number of CPUs used by the guest between two consecutive samples
+	PeakCPU resource.Quantity `json:"peakCPU"`
+	// PeakMemory is the highest amount of memory used by the guest, not counting reclaimable caches
+	// +optional
+	PeakMemory *resource.Quantity `json:"peakMemory,omitempty"`
+	// Samples is the number of samples in the window
+	Samples int `json:"samples"`
+	// WindowStart is the time of the oldest sample in the window
+	// +optional
+	// +nullable
+	WindowStart *metav1.Time `json:"windowStart,omitempty"`
 }
 
 // PersistentVolumeClaimInfo contains the relavant information virt-handler needs cached about a PVC
@@ -426,6 +470,10 @@ func (v *VirtualMachineInstance) IsCPUDedicated() bool {
 	return v.Spec.Domain.CPU != nil && v.Spec.Domain.CPU.DedicatedCPUPlacement
 }
 
+func (v *VirtualMachineInstance) IsCPUSoftPlaced() bool {
+	return v.Spec.Domain.CPU != nil && v.Spec.Domain.CPU.SoftCPUPlacement
+}
+
 func (v *VirtualMachineInstance) IsBootloaderEFI() bool {
 	return v.Spec.Domain.Firmware != nil && v.Spec.Domain.Firmware.Bootloader != nil &&
 		v.Spec.Domain.Firmware.Bootloader.EFI != nil
@@ -517,6 +565,17 @@ const (
 	VirtualMachineInstanceReasonPRNotMigratable = "PersistentReservationNotLiveMigratable"
 	// Indicates that the VMI is in progress of Hot vCPU Plug/UnPlug
 	VirtualMachineInstanceVCPUChange = "HotVCPUChange"
+
+	// Indicates whether a live migration of the VMI is expected to converge under the effective migration bandwidth
+	VirtualMachineInstanceMigrationConvergent VirtualMachineInstanceConditionType = "MigrationConvergent"
+	// Reason means that the sampled memory dirty rate is lower than the migration bandwidth
+	VirtualMachineInstanceReasonDirtyRateBelowBandwidth = "DirtyRateBelowBandwidth"
+	// Reason means that the sampled memory dirty rate exceeds the migration bandwidth
+	VirtualMachineInstanceReasonDirtyRateExceedsBandwidth = "DirtyRateExceedsBandwidth"
+	// Reason means that the migration bandwidth is not limited, so the estimate cannot be bound by it
+	VirtualMachineInstanceReasonUnlimitedMigrationBandwidth = "UnlimitedMigrationBandwidth"
+	// Reason means that post-copy or auto-converge will force the migration to converge regardless of the dirty rate
+	VirtualMachineInstanceReasonMigrationForcesConvergence = "MigrationForcesConvergence"
 )
 
 const (
@@ -794,6 +853,10 @@ const (
 	// This annotation indicates that a migration is the result of an
 	// automated workload update
 	WorkloadUpdateMigrationAnnotation string = "kubevirt.io/workloadUpdateMigration"
+	// This annotation restricts the target of a workload update migration to the
+	// nodes matching the label selector in its value. It is set for migrations to
+	// the canary nodes of a canary upgrade.
+	WorkloadUpdateTargetNodeSelectorAnnotation string = "kubevirt.io/workloadUpdateTargetNodeSelector"
 	// This label declares whether a particular node is available for
 	// scheduling virtual machine instances on it. Used on Node.
 	NodeSchedulable string = "kubevirt.io/schedulable"
@@ -801,6 +864,16 @@ const (
 	// if a particular node is alive and hence should be available for new
 	// virtual machine instance scheduling. Used on Node.
 	VirtHandlerHeartbeat string = "kubevirt.io/heartbeat"
+	// This annotation is regularly updated by virt-handler and holds a JSON
+	// encoded NodeMediatedDevicesStatus describing the mediated devices
+	// configuration of every mdev capable device on the node. Used on Node.
+	MediatedDevicesStatusAnnotation string = "kubevirt.io/mediated-devices-status"
+	// This annotation can be set by an admin to request the reconfiguration
+	// of mdev capable devices to a different mediated device type. It holds a
+	// JSON encoded map of parent PCI addresses to mdev type names or IDs.
+	// Existing mediated devices of a parent are drained and the parent is only
+	// reconfigured once none of them is used by a VMI. Used on Node.
+	MediatedDevicesReconfigurationAnnotation string = "kubevirt.io/mediated-devices-reconfiguration"
 	// This label indicates what launcher image a VMI is currently running with.
 	OutdatedLauncherImageLabel string = "kubevirt.io/outdatedLauncherImage"
 	// Namespace recommended by Kubernetes for commonly recognized labels
@@ -946,6 +1019,10 @@ const (
 	// pvc name and the timestamp the memory dump was collected
 	PVCMemoryDumpAnnotation string = "kubevirt.io/memory-dump"
 
+	// RestoreMemoryStateAnnotation references the saved memory state, in the
+	// form <pvc name>/<file name>, the VM should resume from on its next start
+	RestoreMemoryStateAnnotation string = "kubevirt.io/restore-memory-state"
+
 	// AllowPodBridgeNetworkLiveMigrationAnnotation allow to run live migration when the
 	// vm has the pod networking bind with a bridge
 	AllowPodBridgeNetworkLiveMigrationAnnotation string = "kubevirt.io/allow-pod-bridge-network-live-migration"
@@ -953,6 +1030,12 @@ const (
 	// VirtualMachineGenerationAnnotation is the generation of a Virtual Machine.
 	VirtualMachineGenerationAnnotation string = "kubevirt.io/vm-generation"
 
+	// TraceparentAnnotation carries the W3C trace context of the VMI lifecycle trace.
+	// It is set by virt-api on creation when tracing is enabled and all components parent their spans to it.
+	// A client may set it to record the VMI lifecycle as part of its own trace, the span id it passes is
+	// used for the VirtualMachineInstance.Start span.
+	TraceparentAnnotation string = "kubevirt.io/traceparent"
+
 	// MigrationTargetReadyTimestamp indicates the time at which the target node
 	// detected that the VMI became active on the target during live migration.
 	MigrationTargetReadyTimestamp string = "kubevirt.io/migration-target-ready-timestamp"
@@ -1536,6 +1619,101 @@ type VirtualMachineStatus struct {
 	// hot-plugged on an active running VMI.
 	// +listType=atomic
 	InterfaceRequests []VirtualMachineInterfaceRequest `json:"interfaceRequests,omitempty" optional:"true"`
+
+	// Timeline holds the most recent lifecycle entries of the VirtualMachineInstances started
+	// for this VM, so that they survive VirtualMachineInstance restarts.
+	// The full, merged, timeline is available through the timeline subresource.
+	// +listType=atomic
+	// +optional
+	Timeline []VirtualMachineTimelineEntry `json:"timeline,omitempty" optional:"true"`
+
+	// ResourceRecommendation is the cluster instancetype matching the resource usage
+	// observed while the VM was running
+	// +nullable
+	// +optional
+	ResourceRecommendation *VirtualMachineResourceRecommendation `json:"resourceRecommendation,omitempty" optional:"true"`
+}
+
+// VirtualMachineResourceRecommendationReason describes how the VM compares to its recommendation
+type VirtualMachineResourceRecommendationReason string
+
+const (
+	// VirtualMachineResourceRecommendationOversized means the VM has more resources than it needs
+	VirtualMachineResourceRecommendationOversized VirtualMachineResourceRecommendationReason = "Oversized"
+	// VirtualMachineResourceRecommendationUndersized means the VM has less resources than it needs
+	VirtualMachineResourceRecommendationUndersized VirtualMachineResourceRecommendationReason = "Undersized"
+	// VirtualMachineResourceRecommendationRightSized means the VM already has the recommended resources
+	VirtualMachineResourceRecommendationRightSized VirtualMachineResourceRecommendationReason = "RightSized"
+	// VirtualMachineResourceRecommendationNoMatch means no cluster instancetype fits the observed usage
+	VirtualMachineResourceRecommendationNoMatch VirtualMachineResourceRecommendationReason = "NoMatchingInstancetype"
+)
+
+// VirtualMachineResourceRecommendation is a right-sizing suggestion for the VM
+type VirtualMachineResourceRecommendation struct {
+	// Instancetype is the name of the recommended VirtualMachineClusterInstancetype
+	// +optional
+	Instancetype string `json:"instancetype,omitempty"`
+	// Reason describes how the VM compares to the recommendation
+	Reason VirtualMachineResourceRecommendationReason `json:"reason"`
+	// CPU is the number of guest CPUs required to serve the observed peak with headroom
+	CPU uint32 `json:"cpu"`
+	// Memory is the guest memory required to serve the observed peak with headroom
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// PeakCPU is the highest number of CPUs used by the guest
+	PeakCPU resource.Quantity `json:"peakCPU"`
+	// PeakMemory is the highest amount of memory used by the guest
+	// +optional
+	PeakMemory *resource.Quantity `json:"peakMemory,omitempty"`
+	// Samples is the number of usage samples the recommendation is based on
+	Samples int `json:"samples"`
+	// LastUpdateTime is when the recommendation was last computed
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// VirtualMachineTimelineSource is the origin of a timeline entry
+type VirtualMachineTimelineSource string
+
+const (
+	// VirtualMachineTimelineSourcePhase entries are VirtualMachineInstance phase transitions
+	VirtualMachineTimelineSourcePhase VirtualMachineTimelineSource = "Phase"
+	// VirtualMachineTimelineSourceMigration entries are live migration state changes
+	VirtualMachineTimelineSourceMigration VirtualMachineTimelineSource = "Migration"
+	// VirtualMachineTimelineSourceGuestAgent entries are guest agent connects and disconnects
+	VirtualMachineTimelineSourceGuestAgent VirtualMachineTimelineSource = "GuestAgent"
+	// VirtualMachineTimelineSourceDomain entries are libvirt domain lifecycle events
+	VirtualMachineTimelineSourceDomain VirtualMachineTimelineSource = "Domain"
+	// VirtualMachineTimelineSourceEvent entries are Kubernetes events of the VirtualMachine and its VirtualMachineInstances
+	VirtualMachineTimelineSourceEvent VirtualMachineTimelineSource = "Event"
+)
+
+// VirtualMachineTimelineEntry is a single entry of the VirtualMachine timeline
+type VirtualMachineTimelineEntry struct {
+	// Timestamp is when the entry happened
+	Timestamp metav1.Time `json:"timestamp"`
+	// Source is the origin of the entry
+	Source VirtualMachineTimelineSource `json:"source"`
+	// Type is either Normal or Warning
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Reason is a short, machine understandable, description of the entry
+	Reason string `json:"reason"`
+	// Message is a human readable description of the entry
+	// +optional
+	Message string `json:"message,omitempty"`
+	// VMIUID is the UID of the VirtualMachineInstance the entry belongs to
+	// +optional
+	VMIUID types.UID `json:"vmiUID,omitempty"`
+}
+
+// VirtualMachineTimeline is the chronologically ordered lifecycle of a VirtualMachine
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineTimeline struct {
+	metav1.TypeMeta `json:",inline"`
+	// Entries are ordered from the oldest to the most recent one
+	// +listType=atomic
+	Entries []VirtualMachineTimelineEntry `json:"entries"`
 }
 
 type VolumeSnapshotStatus struct {
@@ -1862,6 +2040,123 @@ type KubeVirtSpec struct {
 	Workloads *ComponentConfig `json:"workloads,omitempty"`
 
 	CustomizeComponents CustomizeComponents `json:"customizeComponents,omitempty"`
+
+	// Alerting allows to disable and tune the alerting rules deployed alongside KubeVirt
+	// +optional
+	Alerting *AlertingConfiguration `json:"alerting,omitempty"`
+
+	// CanaryUpgrade stages updates of KubeVirt: the new virt-handler is rolled out to a subset of the
+	// nodes first and only selected workloads are updated, before the rollout continues on all nodes.
+	// +optional
+	CanaryUpgrade *KubeVirtCanaryUpgradeStrategy `json:"canaryUpgrade,omitempty"`
+}
+
+// KubeVirtCanaryUpgradeStrategy defines the canary stage of a KubeVirt update
+type KubeVirtCanaryUpgradeStrategy struct {
+	// NodeSelector selects the canary nodes, which receive the new virt-handler first
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// Namespaces lists the namespaces whose outdated VMIs are live migrated to the canary nodes
+	// while the canary soaks. It requires LiveMigrate to be one of the workload update methods.
+	// The control plane is updated once the canary virt-handlers are ready.
+	// +listType=atomic
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// SoakPeriod is how long the canary is watched before the rollout continues on all nodes
+	//
+	// Defaults to 1 hour
+	//
+	// +optional
+	SoakPeriod *metav1.Duration `json:"soakPeriod,omitempty"`
+
+	// FailureThreshold is the number of failures tolerated during the canary stage.
+	// Crash looping canary virt-handler pods, canary virt-handlers which stop being ready or stop
+	// sending heartbeats while soaking, and failed workload update migrations count as failures.
+	//
+	// Defaults to 0
+	//
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// FailurePolicy defines what happens once the failures exceed the threshold.
+	// Pause holds the rollout, Rollback returns all KubeVirt components to the previously installed version.
+	// Any change of the KubeVirt spec restarts a paused or rolled back canary stage.
+	//
+	// Defaults to Pause
+	//
+	// +optional
+	FailurePolicy CanaryUpgradeFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// CanaryUpgradeFailurePolicy defines the reaction to a failing canary
+type CanaryUpgradeFailurePolicy string
+
+const (
+	CanaryUpgradeFailurePolicyPause    CanaryUpgradeFailurePolicy = "Pause"
+	CanaryUpgradeFailurePolicyRollback CanaryUpgradeFailurePolicy = "Rollback"
+)
+
+// CanaryUpgradePhase is the stage of a canary upgrade
+type CanaryUpgradePhase string
+
+const (
+	// The new virt-handler is rolled out to the canary nodes
+	CanaryUpgradePhaseCanary CanaryUpgradePhase = "Canary"
+	// The canary virt-handlers are ready and the canary workloads are updated
+	CanaryUpgradePhaseSoaking CanaryUpgradePhase = "Soaking"
+	// The soak period passed and the rollout continues on all nodes
+	CanaryUpgradePhasePromoted CanaryUpgradePhase = "Promoted"
+	// The failure threshold was exceeded and the rollout is held
+	CanaryUpgradePhasePaused CanaryUpgradePhase = "Paused"
+	// The failure threshold was exceeded and all components are returned to the previous version
+	CanaryUpgradePhaseRolledBack CanaryUpgradePhase = "RolledBack"
+)
+
+// KubeVirtCanaryUpgradeStatus reports the progress of a canary upgrade
+type KubeVirtCanaryUpgradeStatus struct {
+	Phase CanaryUpgradePhase `json:"phase,omitempty"`
+	// TargetDeploymentID identifies the install strategy which is rolled out
+	TargetDeploymentID string `json:"targetDeploymentID,omitempty"`
+	// StartTime is when the current canary stage started, failed migrations are counted from here
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// SoakStartTime is when all canary virt-handlers became ready
+	SoakStartTime *metav1.Time `json:"soakStartTime,omitempty"`
+	// Failures is the number of failures observed during the current canary stage
+	Failures int32 `json:"failures,omitempty"`
+	// PreviousDeploymentConfig is the deployment config which was installed when the canary upgrade started
+	PreviousDeploymentConfig string `json:"previousDeploymentConfig,omitempty"`
+	// ObservedGeneration is the generation of the KubeVirt CR which paused or rolled back the canary
+	ObservedGeneration int64  `json:"observedGeneration,omitempty"`
+	Message            string `json:"message,omitempty"`
+}
+
+// AlertingConfiguration holds the overrides of the alerting rules deployed by virt-operator
+type AlertingConfiguration struct {
+	// Rules overrides the defaults of individual alerting rules, identified by their alert name
+	// +optional
+	// +listType=map
+	// +listMapKey=alert
+	Rules []AlertRuleConfiguration `json:"rules,omitempty"`
+}
+
+// AlertRuleConfiguration overrides the defaults of a single alerting rule
+type AlertRuleConfiguration struct {
+	// Alert is the name of the alert, e.g. VirtualMachineStartFailure
+	Alert string `json:"alert"`
+
+	// Disabled removes the alert from the deployed PrometheusRule
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Threshold replaces the value the expression of the alert is compared against.
+	// It is ignored for alerts without a configurable threshold.
+	// +optional
+	Threshold *resource.Quantity `json:"threshold,omitempty"`
+
+	// For replaces the duration the expression of the alert needs to hold before the alert fires
+	// +optional
+	For *metav1.Duration `json:"for,omitempty"`
 }
 
 type CustomizeComponents struct {
@@ -1942,6 +2237,8 @@ type KubeVirtStatus struct {
 	DefaultArchitecture                     string              `json:"defaultArchitecture,omitempty"`
 	// +listType=atomic
 	Generations []GenerationStatus `json:"generations,omitempty" optional:"true"`
+	// +optional
+	CanaryUpgrade *KubeVirtCanaryUpgradeStatus `json:"canaryUpgrade,omitempty"`
 }
 
 // KubeVirtPhase is a label for the phase of a KubeVirt deployment at the current time.
@@ -2100,6 +2397,63 @@ type MigrateOptions struct {
 	DryRun []string `json:"dryRun,omitempty" protobuf:"bytes,1,rep,name=dryRun"`
 }
 
+// InstancetypeUpgradeOptions may be provided on instancetype upgrade request.
+type InstancetypeUpgradeOptions struct {
+	metav1.TypeMeta `json:",inline"`
+	// When present, indicates that modifications should not be
+	// persisted. An invalid or unrecognized dryRun directive will
+	// result in an error response and no further processing of the
+	// request. Valid values are:
+	// - All: all dry run stages will be processed
+	// +optional
+	// +listType=atomic
+	DryRun []string `json:"dryRun,omitempty"`
+}
+
+// VirtualMachineInstancetypeDiff compares the instancetype and preference ControllerRevisions
+// referenced by a VirtualMachine with the current instancetype and preference objects
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineInstancetypeDiff struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	Instancetype *InstancetypeRevisionDiff `json:"instancetype,omitempty"`
+	// +optional
+	Preference *InstancetypeRevisionDiff `json:"preference,omitempty"`
+	// CPUHotplugged is true when an upgrade hotplugged the CPU sockets of the running VirtualMachineInstance
+	// +optional
+	CPUHotplugged bool `json:"cpuHotplugged,omitempty"`
+}
+
+// InstancetypeRevisionDiff is the difference between a stored ControllerRevision and the current object
+type InstancetypeRevisionDiff struct {
+	// Kind of the instancetype or preference
+	Kind string `json:"kind"`
+	// Name of the instancetype or preference
+	Name string `json:"name"`
+	// RevisionName is the name of the ControllerRevision currently referenced by the VirtualMachine
+	// +optional
+	RevisionName string `json:"revisionName,omitempty"`
+	// UpToDate is true when the stored ControllerRevision matches the current object
+	UpToDate bool `json:"upToDate"`
+	// Changes lists the fields of the spec that differ
+	// +listType=atomic
+	// +optional
+	Changes []InstancetypeSpecChange `json:"changes,omitempty"`
+}
+
+// InstancetypeSpecChange is a single field differing between a stored ControllerRevision and the current object
+type InstancetypeSpecChange struct {
+	// Path of the field within the spec
+	Path string `json:"path"`
+	// Stored is the JSON encoded value within the ControllerRevision, empty when unset
+	// +optional
+	Stored string `json:"stored,omitempty"`
+	// Current is the JSON encoded value within the current object, empty when unset
+	// +optional
+	Current string `json:"current,omitempty"`
+}
+
 // VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -2123,6 +2477,58 @@ type VirtualMachineInstanceGuestAgentInfo struct {
 	// FSFreezeStatus is the state of the fs of the guest
 	// it can be either frozen or thawed
 	FSFreezeStatus string `json:"fsFreezeStatus,omitempty"`
+	// Metrics contains the load, cpu and disk statistics as perceived by the guest.
+	// Only reported by guest agents which support the respective commands.
+	Metrics *VirtualMachineInstanceGuestMetrics `json:"metrics,omitempty"`
+}
+
+// VirtualMachineInstanceGuestMetrics contains the statistics reported by the guest agent
+type VirtualMachineInstanceGuestMetrics struct {
+	// Load is the guest load average
+	Load *VirtualMachineInstanceGuestLoad `json:"load,omitempty"`
+	// CPUStats is the time each guest cpu spent in the different modes
+	// +listType=atomic
+	CPUStats []VirtualMachineInstanceGuestCPUStats `json:"cpuStats,omitempty"`
+	// DiskStats are the I/O statistics of the guest block devices
+	// +listType=atomic
+	DiskStats []VirtualMachineInstanceGuestDiskStats `json:"diskStats,omitempty"`
+}
+
+// VirtualMachineInstanceGuestLoad is the guest load average over 1, 5 and 15 minutes
+type VirtualMachineInstanceGuestLoad struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+}
+
+// VirtualMachineInstanceGuestCPUStats is the time in milliseconds a guest cpu spent in each mode since boot
+type VirtualMachineInstanceGuestCPUStats struct {
+	CPU     int    `json:"cpu"`
+	User    uint64 `json:"user"`
+	Nice    uint64 `json:"nice"`
+	System  uint64 `json:"system"`
+	Idle    uint64 `json:"idle"`
+	IOWait  uint64 `json:"ioWait,omitempty"`
+	IRQ     uint64 `json:"irq,omitempty"`
+	SoftIRQ uint64 `json:"softIRQ,omitempty"`
+	Steal   uint64 `json:"steal,omitempty"`
+}
+
+// VirtualMachineInstanceGuestDiskStats are the I/O statistics of a guest block device since boot.
+// Times are in milliseconds.
+type VirtualMachineInstanceGuestDiskStats struct {
+	// Name is the name of the block device in the guest, e.g. vda
+	Name         string `json:"name"`
+	ReadIOs      uint64 `json:"readIOs,omitempty"`
+	ReadSectors  uint64 `json:"readSectors,omitempty"`
+	ReadTicks    uint64 `json:"readTicks,omitempty"`
+	WriteIOs     uint64 `json:"writeIOs,omitempty"`
+	WriteSectors uint64 `json:"writeSectors,omitempty"`
+	WriteTicks   uint64 `json:"writeTicks,omitempty"`
+	// InFlightIOs is the number of I/Os currently in progress
+	InFlightIOs uint64 `json:"inFlightIOs,omitempty"`
+	// TotalTicks is the time the device spent doing I/Os
+	TotalTicks uint64 `json:"totalTicks,omitempty"`
 }
 
 // List of commands that QEMU guest agent supports
@@ -2175,6 +2581,75 @@ type FreezeUnfreezeTimeout struct {
 	UnfreezeTimeout *metav1.Duration `json:"unfreezeTimeout"`
 }
 
+// FreezeHooks are the guest commands run around a freeze of the guest filesystems.
+// The timeouts of all the hooks may add up to at most 40 seconds
+type FreezeHooks struct {
+	// PreFreeze hooks are run in order before the filesystems are frozen
+	// +optional
+	// +listType=atomic
+	PreFreeze []GuestHook `json:"preFreeze,omitempty"`
+	// PostThaw hooks are run in order after the filesystems are thawed.
+	// They are also run when the freeze is aborted by a failed pre-freeze hook
+	// +optional
+	// +listType=atomic
+	PostThaw []GuestHook `json:"postThaw,omitempty"`
+}
+
+// GuestHook is a command run in the guest by the guest agent
+type GuestHook struct {
+	// Name identifies the hook in the status
+	Name string `json:"name"`
+	// Command is the path of the executable in the guest followed by its arguments
+	// +listType=atomic
+	Command []string `json:"command"`
+	// TimeoutSeconds is the time the command is given to exit, defaults to 10
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// FailurePolicy defines what happens when the command fails or times out, defaults to Abort
+	// +optional
+	FailurePolicy GuestHookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// GuestHookFailurePolicy defines what happens when a guest hook fails
+type GuestHookFailurePolicy string
+
+const (
+	// GuestHookFailurePolicyAbort aborts the freeze when a pre-freeze hook fails
+	GuestHookFailurePolicyAbort GuestHookFailurePolicy = "Abort"
+	// GuestHookFailurePolicyContinue ignores the failure of the hook
+	GuestHookFailurePolicyContinue GuestHookFailurePolicy = "Continue"
+
+	// DefaultGuestHookTimeoutSeconds is the time a guest hook is given to exit when not specified
+	DefaultGuestHookTimeoutSeconds int32 = 10
+	// MaxFreezeHooksTimeoutSeconds is the time all the freeze hooks of a VMI may take together,
+	// a freeze and its hooks have to complete within the timeout of the request
+	MaxFreezeHooksTimeoutSeconds int32 = 40
+)
+
+// FreezeHooksStatus is the outcome of the freeze hooks of the last freeze and thaw
+type FreezeHooksStatus struct {
+	// PreFreezeTimestamp is the time the pre-freeze hooks of the last freeze were run
+	// +optional
+	PreFreezeTimestamp *metav1.Time `json:"preFreezeTimestamp,omitempty"`
+	// PreFreezeFailed are the names of the pre-freeze hooks that failed on the last freeze
+	// +optional
+	// +listType=atomic
+	PreFreezeFailed []string `json:"preFreezeFailed,omitempty"`
+	// Aborted is true when the last freeze was aborted by a failed pre-freeze hook
+	// +optional
+	Aborted bool `json:"aborted,omitempty"`
+	// PostThawTimestamp is the time the post-thaw hooks of the last thaw were run
+	// +optional
+	PostThawTimestamp *metav1.Time `json:"postThawTimestamp,omitempty"`
+	// PostThawFailed are the names of the post-thaw hooks that failed on the last thaw
+	// +optional
+	// +listType=atomic
+	PostThawFailed []string `json:"postThawFailed,omitempty"`
+	// Message describes the failures of the hooks
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // VirtualMachineMemoryDumpRequest represent the memory dump request phase and info
 type VirtualMachineMemoryDumpRequest struct {
 	// ClaimName is the name of the pvc that will contain the memory dump
@@ -2196,8 +2671,21 @@ type VirtualMachineMemoryDumpRequest struct {
 	// Message is a detailed message about failure of the memory dump
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Format of the memory dump, defaults to CoreDump
+	// +optional
+	Format MemoryDumpFormat `json:"format,omitempty"`
 }
 
+type MemoryDumpFormat string
+
+const (
+	// The memory dump is a core dump of the guest memory, used for analysis
+	MemoryDumpFormatCoreDump MemoryDumpFormat = "CoreDump"
+	// The memory dump is the saved memory and device state of the VM, which
+	// the VM can be restored from
+	MemoryDumpFormatSavedState MemoryDumpFormat = "SavedState"
+)
+
 type MemoryDumpPhase string
 
 const (
@@ -2366,6 +2854,16 @@ type KubeVirtConfiguration struct {
 	AutoCPULimitNamespaceLabelSelector *metav1.LabelSelector `json:"autoCPULimitNamespaceLabelSelector,omitempty"`
 	// LiveUpdateConfiguration holds defaults for live update features
 	LiveUpdateConfiguration *LiveUpdateConfiguration `json:"liveUpdateConfiguration,omitempty"`
+
+	// MaxCPUWeight is the highest CPU weight a VirtualMachineInstance may request in spec.domain.cpu.weight,
+	// in cgroup v2 cpu.weight units. Defaults to 100, the weight of a pod without a CPU request.
+	// Requires the CPUWeight feature gate.
+	// +optional
+	MaxCPUWeight *uint64 `json:"maxCPUWeight,omitempty"`
+
+	// Tracing enables OpenTelemetry tracing of the VirtualMachineInstance lifecycle.
+	// +optional
+	Tracing *TracingConfiguration `json:"tracing,omitempty"`
 }
 
 type ArchConfiguration struct {
@@ -2569,6 +3067,7 @@ type LogVerbosity struct {
 const (
 	PCIResourcePrefix  = "PCI_RESOURCE"
 	MDevResourcePrefix = "MDEV_PCI_RESOURCE"
+	USBResourcePrefix  = "USB_RESOURCE"
 )
 
 // PermittedHostDevices holds information about devices allowed for passthrough
@@ -2577,6 +3076,8 @@ type PermittedHostDevices struct {
 	PciHostDevices []PciHostDevice `json:"pciHostDevices,omitempty"`
 	// +listType=atomic
 	MediatedDevices []MediatedHostDevice `json:"mediatedDevices,omitempty"`
+	// +listType=atomic
+	USB []USBHostDevice `json:"usb,omitempty"`
 }
 
 // PciHostDevice represents a host PCI device allowed for passthrough
@@ -2601,6 +3102,32 @@ type MediatedHostDevice struct {
 	ExternalResourceProvider bool   `json:"externalResourceProvider,omitempty"`
 }
 
+// USBHostDevice represents a set of host USB devices allowed for passthrough
+type USBHostDevice struct {
+	// Identifies the list of USB host devices.
+	// e.g: kubevirt.io/storage, kubevirt.io/bootable-usb, etc
+	ResourceName string `json:"resourceName"`
+	// Each USB host device matching any of the selectors is exposed as
+	// an allocatable device of the resource.
+	// +listType=atomic
+	Selectors []USBSelector `json:"selectors,omitempty"`
+	// If true, KubeVirt will leave the allocation and monitoring to an
+	// external device plugin
+	ExternalResourceProvider bool `json:"externalResourceProvider,omitempty"`
+}
+
+// USBSelector identifies one or more host USB devices
+type USBSelector struct {
+	// The vendor ID of the USB device, e.g. 046d
+	Vendor string `json:"vendor"`
+	// The product ID of the USB device, e.g. c52b
+	Product string `json:"product"`
+	// BusPath optionally restricts the selector to the device plugged into a specific
+	// port, as named under /sys/bus/usb/devices, e.g. 1-1.2
+	// +optional
+	BusPath string `json:"busPath,omitempty"`
+}
+
 // MediatedDevicesConfiguration holds information about MDEV types to be defined, if available
 type MediatedDevicesConfiguration struct {
 	// Deprecated. Use mediatedDeviceTypes instead.
@@ -2631,6 +3158,49 @@ type NodeMediatedDeviceTypesConfig struct {
 	MediatedDeviceTypes []string `json:"mediatedDeviceTypes"`
 }
 
+type MediatedDeviceParentPhase string
+
+const (
+	// MediatedDeviceParentUnconfigured means that no mediated devices exist on the parent device
+	MediatedDeviceParentUnconfigured MediatedDeviceParentPhase = "Unconfigured"
+	// MediatedDeviceParentConfigured means that the mediated devices of the parent device match the requested configuration
+	MediatedDeviceParentConfigured MediatedDeviceParentPhase = "Configured"
+	// MediatedDeviceParentDraining means that the parent device waits for VMIs to release its mediated devices before it gets reconfigured
+	MediatedDeviceParentDraining MediatedDeviceParentPhase = "Draining"
+	// MediatedDeviceParentFailed means that the mediated devices of the parent device could not be configured
+	MediatedDeviceParentFailed MediatedDeviceParentPhase = "Failed"
+)
+
+// NodeMediatedDevicesStatus reports the mediated devices configuration of a node.
+// It is published by virt-handler in the kubevirt.io/mediated-devices-status node annotation.
+type NodeMediatedDevicesStatus struct {
+	// ParentDevices lists all mdev capable devices of the node
+	// +optional
+	// +listType=atomic
+	ParentDevices []MediatedDeviceParentStatus `json:"parentDevices,omitempty"`
+}
+
+// MediatedDeviceParentStatus reports the mediated devices configuration of a single mdev capable device
+type MediatedDeviceParentStatus struct {
+	// PCIAddress of the parent device, e.g. 0000:65:00.0
+	PCIAddress string `json:"pciAddress"`
+	// MediatedDeviceType is the type of the mediated devices currently created on the parent device
+	// +optional
+	MediatedDeviceType string `json:"mediatedDeviceType,omitempty"`
+	// RequestedMediatedDeviceType is the type the parent device is being reconfigured to
+	// +optional
+	RequestedMediatedDeviceType string `json:"requestedMediatedDeviceType,omitempty"`
+	// Phase of the parent device configuration
+	Phase MediatedDeviceParentPhase `json:"phase"`
+	// AvailableInstances is the number of mediated devices which are not used by any VMI
+	AvailableInstances int `json:"availableInstances"`
+	// UsedInstances is the number of mediated devices which are used by VMIs
+	UsedInstances int `json:"usedInstances"`
+	// Error holds the reason of the last failed configuration attempt
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
 // KSMConfiguration holds information about KSM.
 // +k8s:openapi-gen=true
 type KSMConfiguration struct {
@@ -2640,6 +3210,36 @@ type KSMConfiguration struct {
 	NodeLabelSelector *metav1.LabelSelector `json:"nodeLabelSelector,omitempty"`
 }
 
+// TracingConfiguration holds the OpenTelemetry tracing options.
+// Spans are only recorded when at least one exporter is configured.
+// +k8s:openapi-gen=true
+type TracingConfiguration struct {
+	// OTLP exports spans to an OpenTelemetry collector using OTLP over HTTP.
+	// +optional
+	OTLP *TracingOTLPExporter `json:"otlp,omitempty"`
+	// File appends spans, encoded as OTLP JSON, to a file on the local filesystem of each component.
+	// It is meant for debugging and testing without a collector.
+	// +optional
+	File *TracingFileExporter `json:"file,omitempty"`
+}
+
+// TracingOTLPExporter holds the configuration of the OTLP/HTTP span exporter.
+// +k8s:openapi-gen=true
+type TracingOTLPExporter struct {
+	// Endpoint is the base URL of the collector, for example http://otel-collector.monitoring:4318.
+	// Spans are sent to <endpoint>/v1/traces without credentials, from the KubeVirt components and
+	// from the virt-launcher pods. A collector which requires authentication has to be fronted by an
+	// in-cluster collector which adds the credentials.
+	Endpoint string `json:"endpoint"`
+}
+
+// TracingFileExporter holds the configuration of the file span exporter.
+// +k8s:openapi-gen=true
+type TracingFileExporter struct {
+	// Path is the file the spans are appended to.
+	Path string `json:"path"`
+}
+
 // NetworkConfiguration holds network options
 type NetworkConfiguration struct {
 	NetworkInterface                  string `json:"defaultNetworkInterface,omitempty"`
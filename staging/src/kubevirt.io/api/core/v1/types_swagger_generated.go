@@ -38,6 +38,7 @@ func (VirtualMachineInstanceSpec) SwaggerDoc() map[string]string {
 		"dnsPolicy":                     "Set DNS policy for the pod.\nDefaults to \"ClusterFirst\".\nValid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'.\nDNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy.\nTo have DNS options set along with hostNetwork, you have to specify DNS policy\nexplicitly to 'ClusterFirstWithHostNet'.\n+optional",
 		"dnsConfig":                     "Specifies the DNS parameters of a pod.\nParameters specified here will be merged to the generated DNS\nconfiguration based on DNSPolicy.\n+optional",
 		"accessCredentials":             "Specifies a set of public keys to inject into the vm guest\n+listType=atomic\n+optional",
+		"freezeHooks":                   "FreezeHooks are commands run in the guest by the guest agent before its\nfilesystems are frozen and after they are thawed, so that the applications\nof the guest are consistent while it is frozen\n+optional",
 		"architecture":                  "Specifies the architecture of the vm guest you are attempting to run. Defaults to the compiled architecture of the KubeVirt components",
 	}
 }
@@ -73,6 +74,7 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"activePods":                    "ActivePods is a mapping of pod UID to node name.\nIt is possible for multiple pods to be running for a single VMI during migration.",
 		"volumeStatus":                  "VolumeStatus contains the statuses of all the volumes\n+optional\n+listType=atomic",
 		"fsFreezeStatus":                "FSFreezeStatus is the state of the fs of the guest\nit can be either frozen or thawed\n+optional",
+		"freezeHooks":                   "FreezeHooks is the outcome of the freeze hooks of the last freeze and thaw of the guest\n+optional",
 		"topologyHints":                 "+optional",
 		"virtualMachineRevisionName":    "VirtualMachineRevisionName is used to get the vm revision of the vmi when doing\nan online vm snapshot\n+optional",
 		"runtimeUser":                   "RuntimeUser is used to determine what user will be used in launcher\n+optional",
@@ -80,6 +82,26 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"selinuxContext":                "SELinuxContext is the actual SELinux context of the virt-launcher pod\n+optional",
 		"machine":                       "Machine shows the final resulting qemu machine type. This can be different\nthan the machine type selected in the spec, due to qemus machine type alias mechanism.\n+optional",
 		"currentCPUTopology":            "CurrentCPUTopology specifies the current CPU topology used by the VM workload.\nCurrent topology may differ from the desired topology in the spec while CPU hotplug\ntakes place.",
+		"memoryDirtyRate":               "MemoryDirtyRate is the guest memory dirty rate measured by the last periodic sample.\nIt is used to predict whether a live migration of the VMI will converge.\n+optional",
+		"resourceUsage":                 "ResourceUsage is the peak guest CPU and memory usage observed over the sampling window.\nIt is used to recommend a right-sized instancetype for the owning VM.\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceMemoryDirtyRate) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachineInstanceMemoryDirtyRate represents a sample of the guest memory dirty rate",
+		"bytesPerSecond":  "BytesPerSecond is the amount of guest memory dirtied per second",
+		"sampleTimestamp": "SampleTimestamp is the time the measurement was started\n+optional\n+nullable",
+	}
+}
+
+func (VirtualMachineInstanceResourceUsage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VirtualMachineInstanceResourceUsage represents the peak guest resource usage over a sampling window",
+		"peakCPU":     "PeakCPU is the highest number of CPUs used by the guest between two consecutive samples",
+		"peakMemory":  "PeakMemory is the highest amount of memory used by the guest, not counting reclaimable caches\n+optional",
+		"samples":     "Samples is the number of samples in the window",
+		"windowStart": "WindowStart is the time of the oldest sample in the window\n+optional\n+nullable",
 	}
 }
 
@@ -359,6 +381,41 @@ func (VirtualMachineStatus) SwaggerDoc() map[string]string {
 		"observedGeneration":     "ObservedGeneration is the generation observed by the vmi when started.\n+optional",
 		"desiredGeneration":      "DesiredGeneration is the generation which is desired for the VMI.\nThis will be used in comparisons with ObservedGeneration to understand when\nthe VMI is out of sync. This will be changed at the same time as\nObservedGeneration to remove errors which could occur if Generation is\nupdated through an Update() before ObservedGeneration in Status.\n+optional",
 		"interfaceRequests":      "InterfaceRequests indicates a list of interfaces added to the VMI template and\nhot-plugged on an active running VMI.\n+listType=atomic",
+		"timeline":               "Timeline holds the most recent lifecycle entries of the VirtualMachineInstances started\nfor this VM, so that they survive VirtualMachineInstance restarts.\nThe full, merged, timeline is available through the timeline subresource.\n+listType=atomic\n+optional",
+		"resourceRecommendation": "ResourceRecommendation is the cluster instancetype matching the resource usage\nobserved while the VM was running\n+nullable\n+optional",
+	}
+}
+
+func (VirtualMachineResourceRecommendation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineResourceRecommendation is a right-sizing suggestion for the VM",
+		"instancetype":   "Instancetype is the name of the recommended VirtualMachineClusterInstancetype\n+optional",
+		"reason":         "Reason describes how the VM compares to the recommendation",
+		"cpu":            "CPU is the number of guest CPUs required to serve the observed peak with headroom",
+		"memory":         "Memory is the guest memory required to serve the observed peak with headroom\n+optional",
+		"peakCPU":        "PeakCPU is the highest number of CPUs used by the guest",
+		"peakMemory":     "PeakMemory is the highest amount of memory used by the guest\n+optional",
+		"samples":        "Samples is the number of usage samples the recommendation is based on",
+		"lastUpdateTime": "LastUpdateTime is when the recommendation was last computed",
+	}
+}
+
+func (VirtualMachineTimelineEntry) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VirtualMachineTimelineEntry is a single entry of the VirtualMachine timeline",
+		"timestamp": "Timestamp is when the entry happened",
+		"source":    "Source is the origin of the entry",
+		"type":      "Type is either Normal or Warning\n+optional",
+		"reason":    "Reason is a short, machine understandable, description of the entry",
+		"message":   "Message is a human readable description of the entry\n+optional",
+		"vmiUID":    "VMIUID is the UID of the VirtualMachineInstance the entry belongs to\n+optional",
+	}
+}
+
+func (VirtualMachineTimeline) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "VirtualMachineTimeline is the chronologically ordered lifecycle of a VirtualMachine\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"entries": "Entries are ordered from the oldest to the most recent one\n+listType=atomic",
 	}
 }
 
@@ -481,6 +538,48 @@ func (KubeVirtSpec) SwaggerDoc() map[string]string {
 		"configuration":           "holds kubevirt configurations.\nsame as the virt-configMap",
 		"infra":                   "selectors and tolerations that should apply to KubeVirt infrastructure components\n+optional",
 		"workloads":               "selectors and tolerations that should apply to KubeVirt workloads\n+optional",
+		"alerting":                "Alerting allows to disable and tune the alerting rules deployed alongside KubeVirt\n+optional",
+		"canaryUpgrade":           "CanaryUpgrade stages updates of KubeVirt: the new virt-handler is rolled out to a subset of the\nnodes first and only selected workloads are updated, before the rollout continues on all nodes.\n+optional",
+	}
+}
+
+func (KubeVirtCanaryUpgradeStrategy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "KubeVirtCanaryUpgradeStrategy defines the canary stage of a KubeVirt update",
+		"nodeSelector":     "NodeSelector selects the canary nodes, which receive the new virt-handler first",
+		"namespaces":       "Namespaces lists the namespaces whose outdated VMIs are live migrated to the canary nodes\nwhile the canary soaks. It requires LiveMigrate to be one of the workload update methods.\nThe control plane is updated once the canary virt-handlers are ready.\n+listType=atomic\n+optional",
+		"soakPeriod":       "SoakPeriod is how long the canary is watched before the rollout continues on all nodes\n\nDefaults to 1 hour\n\n+optional",
+		"failureThreshold": "FailureThreshold is the number of failures tolerated during the canary stage.\nCrash looping canary virt-handler pods, canary virt-handlers which stop being ready or stop\nsending heartbeats while soaking, and failed workload update migrations count as failures.\n\nDefaults to 0\n\n+optional",
+		"failurePolicy":    "FailurePolicy defines what happens once the failures exceed the threshold.\nPause holds the rollout, Rollback returns all KubeVirt components to the previously installed version.\nAny change of the KubeVirt spec restarts a paused or rolled back canary stage.\n\nDefaults to Pause\n\n+optional",
+	}
+}
+
+func (KubeVirtCanaryUpgradeStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                         "KubeVirtCanaryUpgradeStatus reports the progress of a canary upgrade",
+		"targetDeploymentID":       "TargetDeploymentID identifies the install strategy which is rolled out",
+		"startTime":                "StartTime is when the current canary stage started, failed migrations are counted from here",
+		"soakStartTime":            "SoakStartTime is when all canary virt-handlers became ready",
+		"failures":                 "Failures is the number of failures observed during the current canary stage",
+		"previousDeploymentConfig": "PreviousDeploymentConfig is the deployment config which was installed when the canary upgrade started",
+		"observedGeneration":       "ObservedGeneration is the generation of the KubeVirt CR which paused or rolled back the canary",
+	}
+}
+
+func (AlertingConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "AlertingConfiguration holds the overrides of the alerting rules deployed by virt-operator",
+		"rules": "Rules overrides the defaults of individual alerting rules, identified by their alert name\n+optional\n+listType=map\n+listMapKey=alert",
+	}
+}
+
+func (AlertRuleConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "AlertRuleConfiguration overrides the defaults of a single alerting rule",
+		"alert":     "Alert is the name of the alert, e.g. VirtualMachineStartFailure",
+		"disabled":  "Disabled removes the alert from the deployed PrometheusRule\n+optional",
+		"threshold": "Threshold replaces the value the expression of the alert is compared against.\nIt is ignored for alerts without a configurable threshold.\n+optional",
+		"for":       "For replaces the duration the expression of the alert needs to hold before the alert fires\n+optional",
 	}
 }
 
@@ -518,8 +617,9 @@ func (GenerationStatus) SwaggerDoc() map[string]string {
 
 func (KubeVirtStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":            "KubeVirtStatus represents information pertaining to a KubeVirt deployment.",
-		"generations": "+listType=atomic",
+		"":              "KubeVirtStatus represents information pertaining to a KubeVirt deployment.",
+		"generations":   "+listType=atomic",
+		"canaryUpgrade": "+optional",
 	}
 }
 
@@ -576,6 +676,42 @@ func (MigrateOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (InstancetypeUpgradeOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "InstancetypeUpgradeOptions may be provided on instancetype upgrade request.",
+		"dryRun": "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+	}
+}
+
+func (VirtualMachineInstancetypeDiff) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "VirtualMachineInstancetypeDiff compares the instancetype and preference ControllerRevisions\nreferenced by a VirtualMachine with the current instancetype and preference objects\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"instancetype":  "+optional",
+		"preference":    "+optional",
+		"cpuHotplugged": "CPUHotplugged is true when an upgrade hotplugged the CPU sockets of the running VirtualMachineInstance\n+optional",
+	}
+}
+
+func (InstancetypeRevisionDiff) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "InstancetypeRevisionDiff is the difference between a stored ControllerRevision and the current object",
+		"kind":         "Kind of the instancetype or preference",
+		"name":         "Name of the instancetype or preference",
+		"revisionName": "RevisionName is the name of the ControllerRevision currently referenced by the VirtualMachine\n+optional",
+		"upToDate":     "UpToDate is true when the stored ControllerRevision matches the current object",
+		"changes":      "Changes lists the fields of the spec that differ\n+listType=atomic\n+optional",
+	}
+}
+
+func (InstancetypeSpecChange) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "InstancetypeSpecChange is a single field differing between a stored ControllerRevision and the current object",
+		"path":    "Path of the field within the spec",
+		"stored":  "Stored is the JSON encoded value within the ControllerRevision, empty when unset\n+optional",
+		"current": "Current is the JSON encoded value within the current object, empty when unset\n+optional",
+	}
+}
+
 func (VirtualMachineInstanceGuestAgentInfo) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                  "VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
@@ -587,6 +723,37 @@ func (VirtualMachineInstanceGuestAgentInfo) SwaggerDoc() map[string]string {
 		"userList":          "UserList is a list of active guest OS users",
 		"fsInfo":            "FSInfo is a guest os filesystem information containing the disk mapping and disk mounts with usage",
 		"fsFreezeStatus":    "FSFreezeStatus is the state of the fs of the guest\nit can be either frozen or thawed",
+		"metrics":           "Metrics contains the load, cpu and disk statistics as perceived by the guest.\nOnly reported by guest agents which support the respective commands.",
+	}
+}
+
+func (VirtualMachineInstanceGuestMetrics) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VirtualMachineInstanceGuestMetrics contains the statistics reported by the guest agent",
+		"load":      "Load is the guest load average",
+		"cpuStats":  "CPUStats is the time each guest cpu spent in the different modes\n+listType=atomic",
+		"diskStats": "DiskStats are the I/O statistics of the guest block devices\n+listType=atomic",
+	}
+}
+
+func (VirtualMachineInstanceGuestLoad) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineInstanceGuestLoad is the guest load average over 1, 5 and 15 minutes",
+	}
+}
+
+func (VirtualMachineInstanceGuestCPUStats) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineInstanceGuestCPUStats is the time in milliseconds a guest cpu spent in each mode since boot",
+	}
+}
+
+func (VirtualMachineInstanceGuestDiskStats) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VirtualMachineInstanceGuestDiskStats are the I/O statistics of a guest block device since boot.\nTimes are in milliseconds.",
+		"name":        "Name is the name of the block device in the guest, e.g. vda",
+		"inFlightIOs": "InFlightIOs is the number of I/Os currently in progress",
+		"totalTicks":  "TotalTicks is the time the device spent doing I/Os",
 	}
 }
 
@@ -632,6 +799,36 @@ func (FreezeUnfreezeTimeout) SwaggerDoc() map[string]string {
 	}
 }
 
+func (FreezeHooks) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "FreezeHooks are the guest commands run around a freeze of the guest filesystems.\nThe timeouts of all the hooks may add up to at most 40 seconds",
+		"preFreeze": "PreFreeze hooks are run in order before the filesystems are frozen\n+optional\n+listType=atomic",
+		"postThaw":  "PostThaw hooks are run in order after the filesystems are thawed.\nThey are also run when the freeze is aborted by a failed pre-freeze hook\n+optional\n+listType=atomic",
+	}
+}
+
+func (GuestHook) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "GuestHook is a command run in the guest by the guest agent",
+		"name":           "Name identifies the hook in the status",
+		"command":        "Command is the path of the executable in the guest followed by its arguments\n+listType=atomic",
+		"timeoutSeconds": "TimeoutSeconds is the time the command is given to exit, defaults to 10\n+optional",
+		"failurePolicy":  "FailurePolicy defines what happens when the command fails or times out, defaults to Abort\n+optional",
+	}
+}
+
+func (FreezeHooksStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "FreezeHooksStatus is the outcome of the freeze hooks of the last freeze and thaw",
+		"preFreezeTimestamp": "PreFreezeTimestamp is the time the pre-freeze hooks of the last freeze were run\n+optional",
+		"preFreezeFailed":    "PreFreezeFailed are the names of the pre-freeze hooks that failed on the last freeze\n+optional\n+listType=atomic",
+		"aborted":            "Aborted is true when the last freeze was aborted by a failed pre-freeze hook\n+optional",
+		"postThawTimestamp":  "PostThawTimestamp is the time the post-thaw hooks of the last thaw were run\n+optional",
+		"postThawFailed":     "PostThawFailed are the names of the post-thaw hooks that failed on the last thaw\n+optional\n+listType=atomic",
+		"message":            "Message describes the failures of the hooks\n+optional",
+	}
+}
+
 func (VirtualMachineMemoryDumpRequest) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":               "VirtualMachineMemoryDumpRequest represent the memory dump request phase and info",
@@ -642,6 +839,7 @@ func (VirtualMachineMemoryDumpRequest) SwaggerDoc() map[string]string {
 		"endTimestamp":   "EndTimestamp represents the time the memory dump was completed\n+optional",
 		"fileName":       "FileName represents the name of the output file\n+optional",
 		"message":        "Message is a detailed message about failure of the memory dump\n+optional",
+		"format":         "Format of the memory dump, defaults to CoreDump\n+optional",
 	}
 }
 
@@ -722,6 +920,8 @@ func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 		"ksmConfiguration":                   "KSMConfiguration holds the information regarding the enabling the KSM in the nodes (if available).",
 		"autoCPULimitNamespaceLabelSelector": "When set, AutoCPULimitNamespaceLabelSelector will set a CPU limit on virt-launcher for VMIs running inside\nnamespaces that match the label selector.\nThe CPU limit will equal the number of requested vCPUs.\nThis setting does not apply to VMIs with dedicated CPUs.",
 		"liveUpdateConfiguration":            "LiveUpdateConfiguration holds defaults for live update features",
+		"maxCPUWeight":                       "MaxCPUWeight is the highest CPU weight a VirtualMachineInstance may request in spec.domain.cpu.weight,\nin cgroup v2 cpu.weight units. Defaults to 100, the weight of a pod without a CPU request.\nRequires the CPUWeight feature gate.\n+optional",
+		"tracing":                            "Tracing enables OpenTelemetry tracing of the VirtualMachineInstance lifecycle.\n+optional",
 	}
 }
 
@@ -831,6 +1031,7 @@ func (PermittedHostDevices) SwaggerDoc() map[string]string {
 		"":                "PermittedHostDevices holds information about devices allowed for passthrough",
 		"pciHostDevices":  "+listType=atomic",
 		"mediatedDevices": "+listType=atomic",
+		"usb":             "+listType=atomic",
 	}
 }
 
@@ -849,6 +1050,24 @@ func (MediatedHostDevice) SwaggerDoc() map[string]string {
 	}
 }
 
+func (USBHostDevice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                         "USBHostDevice represents a set of host USB devices allowed for passthrough",
+		"resourceName":             "Identifies the list of USB host devices.\ne.g: kubevirt.io/storage, kubevirt.io/bootable-usb, etc",
+		"selectors":                "Each USB host device matching any of the selectors is exposed as\nan allocatable device of the resource.\n+listType=atomic",
+		"externalResourceProvider": "If true, KubeVirt will leave the allocation and monitoring to an\nexternal device plugin",
+	}
+}
+
+func (USBSelector) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "USBSelector identifies one or more host USB devices",
+		"vendor":  "The vendor ID of the USB device, e.g. 046d",
+		"product": "The product ID of the USB device, e.g. c52b",
+		"busPath": "BusPath optionally restricts the selector to the device plugged into a specific\nport, as named under /sys/bus/usb/devices, e.g. 1-1.2\n+optional",
+	}
+}
+
 func (MediatedDevicesConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                        "MediatedDevicesConfiguration holds information about MDEV types to be defined, if available",
@@ -867,6 +1086,26 @@ func (NodeMediatedDeviceTypesConfig) SwaggerDoc() map[string]string {
 	}
 }
 
+func (NodeMediatedDevicesStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "NodeMediatedDevicesStatus reports the mediated devices configuration of a node.\nIt is published by virt-handler in the kubevirt.io/mediated-devices-status node annotation.",
+		"parentDevices": "ParentDevices lists all mdev capable devices of the node\n+optional\n+listType=atomic",
+	}
+}
+
+func (MediatedDeviceParentStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                            "MediatedDeviceParentStatus reports the mediated devices configuration of a single mdev capable device",
+		"pciAddress":                  "PCIAddress of the parent device, e.g. 0000:65:00.0",
+		"mediatedDeviceType":          "MediatedDeviceType is the type of the mediated devices currently created on the parent device\n+optional",
+		"requestedMediatedDeviceType": "RequestedMediatedDeviceType is the type the parent device is being reconfigured to\n+optional",
+		"phase":                       "Phase of the parent device configuration",
+		"availableInstances":          "AvailableInstances is the number of mediated devices which are not used by any VMI",
+		"usedInstances":               "UsedInstances is the number of mediated devices which are used by VMIs",
+		"error":                       "Error holds the reason of the last failed configuration attempt\n+optional",
+	}
+}
+
 func (KSMConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                  "KSMConfiguration holds information about KSM.\n+k8s:openapi-gen=true",
@@ -874,6 +1113,28 @@ func (KSMConfiguration) SwaggerDoc() map[string]string {
 	}
 }
 
+func (TracingConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "TracingConfiguration holds the OpenTelemetry tracing options.\nSpans are only recorded when at least one exporter is configured.\n+k8s:openapi-gen=true",
+		"otlp": "OTLP exports spans to an OpenTelemetry collector using OTLP over HTTP.\n+optional",
+		"file": "File appends spans, encoded as OTLP JSON, to a file on the local filesystem of each component.\nIt is meant for debugging and testing without a collector.\n+optional",
+	}
+}
+
+func (TracingOTLPExporter) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "TracingOTLPExporter holds the configuration of the OTLP/HTTP span exporter.\n+k8s:openapi-gen=true",
+		"endpoint": "Endpoint is the base URL of the collector, for example http://otel-collector.monitoring:4318.\nSpans are sent to <endpoint>/v1/traces without credentials, from the KubeVirt components and\nfrom the virt-launcher pods. A collector which requires authentication has to be fronted by an\nin-cluster collector which adds the credentials.",
+	}
+}
+
+func (TracingFileExporter) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "TracingFileExporter holds the configuration of the file span exporter.\n+k8s:openapi-gen=true",
+		"path": "Path is the file the spans are appended to.",
+	}
+}
+
 func (NetworkConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "NetworkConfiguration holds network options",
@@ -76,6 +76,43 @@ func (in *VirtualMachineExport) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportContainerDisk) DeepCopyInto(out *VirtualMachineExportContainerDisk) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportContainerDisk.
+func (in *VirtualMachineExportContainerDisk) DeepCopy() *VirtualMachineExportContainerDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportContainerDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportContainerDiskTarget) DeepCopyInto(out *VirtualMachineExportContainerDiskTarget) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportContainerDiskTarget.
+func (in *VirtualMachineExportContainerDiskTarget) DeepCopy() *VirtualMachineExportContainerDiskTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportContainerDiskTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineExportLink) DeepCopyInto(out *VirtualMachineExportLink) {
 	*out = *in
@@ -193,6 +230,16 @@ func (in *VirtualMachineExportSpec) DeepCopyInto(out *VirtualMachineExportSpec)
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.ContainerDisk != nil {
+		in, out := &in.ContainerDisk, &out.ContainerDisk
+		*out = new(VirtualMachineExportContainerDiskTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	return
 }
 
@@ -235,6 +282,16 @@ func (in *VirtualMachineExportStatus) DeepCopyInto(out *VirtualMachineExportStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ContainerDisks != nil {
+		in, out := &in.ContainerDisks, &out.ContainerDisks
+		*out = make([]VirtualMachineExportContainerDisk, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VirtualMachineExportVolumeProgress, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -284,3 +341,19 @@ func (in *VirtualMachineExportVolumeFormat) DeepCopy() *VirtualMachineExportVolu
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportVolumeProgress) DeepCopyInto(out *VirtualMachineExportVolumeProgress) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportVolumeProgress.
+func (in *VirtualMachineExportVolumeProgress) DeepCopy() *VirtualMachineExportVolumeProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportVolumeProgress)
+	in.DeepCopyInto(out)
+	return out
+}
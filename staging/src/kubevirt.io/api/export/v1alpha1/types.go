@@ -23,6 +23,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -67,6 +68,34 @@ type VirtualMachineExportSpec struct {
 	// If this field is omitted, a reasonable default is applied.
 	// +optional
 	TTLDuration *metav1.Duration `json:"ttlDuration,omitempty"`
+
+	// ContainerDisk pushes the exported volumes to an OCI registry as containerDisk images
+	// +optional
+	ContainerDisk *VirtualMachineExportContainerDiskTarget `json:"containerDisk,omitempty"`
+
+	// BandwidthLimit limits the bytes per second served by the export server, shared by all the
+	// downloads of the export. If this field is omitted, the downloads are not throttled.
+	// +optional
+	BandwidthLimit *resource.Quantity `json:"bandwidthLimit,omitempty"`
+}
+
+// VirtualMachineExportContainerDiskTarget is the registry the exported volumes are pushed to
+type VirtualMachineExportContainerDiskTarget struct {
+	// Repository is the registry repository the volumes are pushed to, e.g. registry.example.com/vms.
+	// Each volume is pushed as <repository>/<volume name>:<tag>
+	Repository string `json:"repository"`
+
+	// Tag is the tag of the pushed images, defaults to latest
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// SecretRef is the name of a kubernetes.io/dockerconfigjson secret holding the registry credentials
+	// +optional
+	SecretRef *string `json:"secretRef,omitempty"`
+
+	// InsecureSkipTLSVerify skips the verification of the registry certificate
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 }
 
 // VirtualMachineExportPhase is the current phase of the VirtualMachineExport
@@ -113,6 +142,57 @@ type VirtualMachineExportStatus struct {
 	// +optional
 	// +listType=atomic
 	Conditions []Condition `json:"conditions,omitempty"`
+
+	// ContainerDisks tracks the push of the exported volumes to the registry
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ContainerDisks []VirtualMachineExportContainerDisk `json:"containerDisks,omitempty"`
+
+	// Volumes reports the download progress of the exported volumes
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Volumes []VirtualMachineExportVolumeProgress `json:"volumes,omitempty"`
+}
+
+// VirtualMachineExportVolumeProgress contains the download progress of an exported volume
+type VirtualMachineExportVolumeProgress struct {
+	// Name is the name of the exported volume
+	Name string `json:"name"`
+	// BytesServed is the number of bytes of the volume served by the export server
+	// +optional
+	BytesServed int64 `json:"bytesServed,omitempty"`
+	// ActiveConnections is the number of downloads of the volume in progress
+	// +optional
+	ActiveConnections int32 `json:"activeConnections,omitempty"`
+}
+
+// ContainerDiskPushPhase is the phase of the push of a volume to the registry
+type ContainerDiskPushPhase string
+
+const (
+	// ContainerDiskPushing means the volume is being pushed
+	ContainerDiskPushing ContainerDiskPushPhase = "Pushing"
+	// ContainerDiskPushSucceeded means the volume was pushed
+	ContainerDiskPushSucceeded ContainerDiskPushPhase = "Succeeded"
+	// ContainerDiskPushFailed means the volume could not be pushed
+	ContainerDiskPushFailed ContainerDiskPushPhase = "Failed"
+)
+
+// VirtualMachineExportContainerDisk contains the state of the push of a volume to the registry
+type VirtualMachineExportContainerDisk struct {
+	// Name is the name of the exported volume
+	Name string `json:"name"`
+	// Image is the reference of the pushed image
+	Image string `json:"image"`
+	// +optional
+	Phase ContainerDiskPushPhase `json:"phase,omitempty"`
+	// Digest is the digest of the pushed image manifest
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // VirtualMachineExportLinks contains the links that point the exported VM resources
@@ -157,6 +237,8 @@ const (
 	AllManifests ExportManifestType = "all"
 	// AuthHeader returns a CDI compatible secret containing the token as an Auth header
 	AuthHeader ExportManifestType = "auth-header-secret"
+	// OVABundle returns an OVA archive holding an OVF descriptor of the VM and its disks in qcow2 format
+	OVABundle ExportManifestType = "ova"
 )
 
 // VirtualMachineExportVolume contains the name and available formats for the exported volume
@@ -21,6 +21,18 @@ func (VirtualMachineExportSpec) SwaggerDoc() map[string]string {
 		"":               "VirtualMachineExportSpec is the spec for a VirtualMachineExport resource",
 		"tokenSecretRef": "+optional\nTokenSecretRef is the name of the custom-defined secret that contains the token used by the export server pod",
 		"ttlDuration":    "ttlDuration limits the lifetime of an export\nIf this field is set, after this duration has passed from counting from CreationTimestamp,\nthe export is eligible to be automatically deleted.\nIf this field is omitted, a reasonable default is applied.\n+optional",
+		"containerDisk":  "ContainerDisk pushes the exported volumes to an OCI registry as containerDisk images\n+optional",
+		"bandwidthLimit": "BandwidthLimit limits the bytes per second served by the export server, shared by all the\ndownloads of the export. If this field is omitted, the downloads are not throttled.\n+optional",
+	}
+}
+
+func (VirtualMachineExportContainerDiskTarget) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "VirtualMachineExportContainerDiskTarget is the registry the exported volumes are pushed to",
+		"repository":            "Repository is the registry repository the volumes are pushed to, e.g. registry.example.com/vms.\nEach volume is pushed as <repository>/<volume name>:<tag>",
+		"tag":                   "Tag is the tag of the pushed images, defaults to latest\n+optional",
+		"secretRef":             "SecretRef is the name of a kubernetes.io/dockerconfigjson secret holding the registry credentials\n+optional",
+		"insecureSkipTLSVerify": "InsecureSkipTLSVerify skips the verification of the registry certificate\n+optional",
 	}
 }
 
@@ -34,6 +46,28 @@ func (VirtualMachineExportStatus) SwaggerDoc() map[string]string {
 		"serviceName":        "+optional\nServiceName is the name of the service created associated with the Virtual Machine export. It will be used to\ncreate the internal URLs for downloading the images",
 		"virtualMachineName": "+optional\nVirtualMachineName shows the name of the source virtual machine if the source is either a VirtualMachine or\na VirtualMachineSnapshot. This is mainly to easily identify the source VirtualMachine in case of a\nVirtualMachineSnapshot",
 		"conditions":         "+optional\n+listType=atomic",
+		"containerDisks":     "ContainerDisks tracks the push of the exported volumes to the registry\n+optional\n+listType=map\n+listMapKey=name",
+		"volumes":            "Volumes reports the download progress of the exported volumes\n+optional\n+listType=map\n+listMapKey=name",
+	}
+}
+
+func (VirtualMachineExportVolumeProgress) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "VirtualMachineExportVolumeProgress contains the download progress of an exported volume",
+		"name":              "Name is the name of the exported volume",
+		"bytesServed":       "BytesServed is the number of bytes of the volume served by the export server\n+optional",
+		"activeConnections": "ActiveConnections is the number of downloads of the volume in progress\n+optional",
+	}
+}
+
+func (VirtualMachineExportContainerDisk) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "VirtualMachineExportContainerDisk contains the state of the push of a volume to the registry",
+		"name":    "Name is the name of the exported volume",
+		"image":   "Image is the reference of the pushed image",
+		"phase":   "+optional",
+		"digest":  "Digest is the digest of the pushed image manifest\n+optional",
+		"message": "+optional",
 	}
 }
 
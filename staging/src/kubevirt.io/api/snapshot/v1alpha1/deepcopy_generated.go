@@ -70,6 +70,57 @@ func (in *Error) DeepCopy() *Error {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryStateBackup) DeepCopyInto(out *MemoryStateBackup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryStateBackup.
+func (in *MemoryStateBackup) DeepCopy() *MemoryStateBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryStateBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NewVirtualMachineOptions) DeepCopyInto(out *NewVirtualMachineOptions) {
+	*out = *in
+	if in.NewMacAddresses != nil {
+		in, out := &in.NewMacAddresses, &out.NewMacAddresses
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NewSMBiosSerial != nil {
+		in, out := &in.NewSMBiosSerial, &out.NewSMBiosSerial
+		*out = new(string)
+		**out = **in
+	}
+	if in.NewVolumeNames != nil {
+		in, out := &in.NewVolumeNames, &out.NewVolumeNames
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NewVirtualMachineOptions.
+func (in *NewVirtualMachineOptions) DeepCopy() *NewVirtualMachineOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NewVirtualMachineOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentVolumeClaim) DeepCopyInto(out *PersistentVolumeClaim) {
 	*out = *in
@@ -228,6 +279,21 @@ func (in *VirtualMachineRestoreSpec) DeepCopyInto(out *VirtualMachineRestoreSpec
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IncludeVolumes != nil {
+		in, out := &in.IncludeVolumes, &out.IncludeVolumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeVolumes != nil {
+		in, out := &in.ExcludeVolumes, &out.ExcludeVolumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NewVirtualMachine != nil {
+		in, out := &in.NewVirtualMachine, &out.NewVirtualMachine
+		*out = new(NewVirtualMachineOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -398,6 +464,11 @@ func (in *VirtualMachineSnapshotContentSpec) DeepCopyInto(out *VirtualMachineSna
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MemoryState != nil {
+		in, out := &in.MemoryState, &out.MemoryState
+		*out = new(MemoryStateBackup)
+		**out = **in
+	}
 	return
 }
 
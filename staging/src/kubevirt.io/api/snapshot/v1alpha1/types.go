@@ -71,15 +71,25 @@ type VirtualMachineSnapshotSpec struct {
 	// Defaults to DefaultFailureDeadline - 5min
 	// +optional
 	FailureDeadline *metav1.Duration `json:"failureDeadline,omitempty"`
+
+	// IncludeMemory indicates that the memory and device state of a running
+	// VM should be saved along with its volumes, so that a restored VM
+	// resumes from the snapshot instead of booting.
+	// The VM is paused while the memory state is saved and the volume
+	// snapshots are taken.
+	// +optional
+	IncludeMemory bool `json:"includeMemory,omitempty"`
 }
 
 // Indication is a way to indicate the state of the vm when taking the snapshot
 type Indication string
 
 const (
-	VMSnapshotOnlineSnapshotIndication Indication = "Online"
-	VMSnapshotNoGuestAgentIndication   Indication = "NoGuestAgent"
-	VMSnapshotGuestAgentIndication     Indication = "GuestAgent"
+	VMSnapshotOnlineSnapshotIndication    Indication = "Online"
+	VMSnapshotNoGuestAgentIndication      Indication = "NoGuestAgent"
+	VMSnapshotGuestAgentIndication        Indication = "GuestAgent"
+	VMSnapshotFreezeHooksIndication       Indication = "FreezeHooks"
+	VMSnapshotFreezeHooksFailedIndication Indication = "FreezeHooksFailed"
 )
 
 // VirtualMachineSnapshotPhase is the current phase of the VirtualMachineSnapshot
@@ -211,6 +221,15 @@ type VirtualMachineSnapshotContentSpec struct {
 
 	// +optional
 	VolumeBackups []VolumeBackup `json:"volumeBackups,omitempty"`
+
+	// +optional
+	MemoryState *MemoryStateBackup `json:"memoryState,omitempty"`
+}
+
+// MemoryStateBackup contains the saved memory and device state of the VM
+type MemoryStateBackup struct {
+	// PersistentVolumeClaimName is the name of the pvc the memory state is saved to
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
 }
 
 type VirtualMachine struct {
@@ -321,8 +340,61 @@ type VirtualMachineRestoreSpec struct {
 	// +optional
 	// +listType=atomic
 	Patches []string `json:"patches,omitempty"`
+
+	// Mode defines what is restored from the snapshot, defaults to Full
+	// +optional
+	Mode VirtualMachineRestoreMode `json:"mode,omitempty"`
+
+	// IncludeVolumes is the list of volumes to restore, all the volumes
+	// of the snapshot are restored if empty.
+	// Volumes that are not restored keep their current disks
+	// +optional
+	// +listType=set
+	IncludeVolumes []string `json:"includeVolumes,omitempty"`
+
+	// ExcludeVolumes is the list of volumes not to restore.
+	// Volumes that are not restored keep their current disks
+	// +optional
+	// +listType=set
+	ExcludeVolumes []string `json:"excludeVolumes,omitempty"`
+
+	// NewVirtualMachine holds the options applied to the target when it does not
+	// exist and is created from the snapshot, so that it can run next to the source
+	// +optional
+	NewVirtualMachine *NewVirtualMachineOptions `json:"newVirtualMachine,omitempty"`
+}
+
+// NewVirtualMachineOptions are the options applied to a VirtualMachine created by a restore
+type NewVirtualMachineOptions struct {
+	// NewMacAddresses manually sets that target interfaces' mac addresses. The key is the interface name and the
+	// value is the new mac address. If this field is not specified, a new MAC address will
+	// be generated automatically, as for any interface that is not included in this map.
+	// +optional
+	NewMacAddresses map[string]string `json:"newMacAddresses,omitempty"`
+	// NewSMBiosSerial manually sets that target's SMbios serial. If this field is not specified, a new serial will
+	// be generated automatically.
+	// +optional
+	NewSMBiosSerial *string `json:"newSMBiosSerial,omitempty"`
+	// NewVolumeNames manually sets the names of the restored PVCs and DataVolumes. The key is the volume name
+	// and the value is the new name. Restored volumes that are not included in this map get a generated name.
+	// +optional
+	NewVolumeNames map[string]string `json:"newVolumeNames,omitempty"`
 }
 
+// VirtualMachineRestoreMode defines what a VirtualMachineRestore restores
+type VirtualMachineRestoreMode string
+
+const (
+	// VirtualMachineRestoreFull restores both the volumes and the spec of the target
+	VirtualMachineRestoreFull VirtualMachineRestoreMode = "Full"
+
+	// VirtualMachineRestoreSpecOnly restores the spec of the target, the volumes keep their current disks
+	VirtualMachineRestoreSpecOnly VirtualMachineRestoreMode = "SpecOnly"
+
+	// VirtualMachineRestoreVolumesOnly restores the volumes as new PVCs, without modifying the target
+	VirtualMachineRestoreVolumesOnly VirtualMachineRestoreMode = "VolumesOnly"
+)
+
 // VirtualMachineRestoreStatus is the spec for a VirtualMachineRestoreresource
 type VirtualMachineRestoreStatus struct {
 	// +optional
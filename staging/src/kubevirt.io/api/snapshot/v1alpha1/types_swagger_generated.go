@@ -14,6 +14,7 @@ func (VirtualMachineSnapshotSpec) SwaggerDoc() map[string]string {
 		"":                "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot resource",
 		"deletionPolicy":  "+optional",
 		"failureDeadline": "This time represents the number of seconds we permit the vm snapshot\nto take. In case we pass this deadline we mark this snapshot\nas failed.\nDefaults to DefaultFailureDeadline - 5min\n+optional",
+		"includeMemory":   "IncludeMemory indicates that the memory and device state of a running\nVM should be saved along with its volumes, so that a restored VM\nresumes from the snapshot instead of booting.\nThe VM is paused while the memory state is saved and the volume\nsnapshots are taken.\n+optional",
 	}
 }
 
@@ -75,6 +76,14 @@ func (VirtualMachineSnapshotContentSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":              "VirtualMachineSnapshotContentSpec is the spec for a VirtualMachineSnapshotContent resource",
 		"volumeBackups": "+optional",
+		"memoryState":   "+optional",
+	}
+}
+
+func (MemoryStateBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                          "MemoryStateBackup contains the saved memory and device state of the VM",
+		"persistentVolumeClaimName": "PersistentVolumeClaimName is the name of the pvc the memory state is saved to",
 	}
 }
 
@@ -139,9 +148,22 @@ func (VirtualMachineRestore) SwaggerDoc() map[string]string {
 
 func (VirtualMachineRestoreSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":        "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource",
-		"target":  "initially only VirtualMachine type supported",
-		"patches": "If the target for the restore does not exist, it will be created. Patches holds JSON patches that would be\napplied to the target manifest before it's created. Patches should fit the target's Kind.\n\nExample for a patch: {\"op\": \"replace\", \"path\": \"/metadata/name\", \"value\": \"new-vm-name\"}\n\n+optional\n+listType=atomic",
+		"":                  "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource",
+		"target":            "initially only VirtualMachine type supported",
+		"patches":           "If the target for the restore does not exist, it will be created. Patches holds JSON patches that would be\napplied to the target manifest before it's created. Patches should fit the target's Kind.\n\nExample for a patch: {\"op\": \"replace\", \"path\": \"/metadata/name\", \"value\": \"new-vm-name\"}\n\n+optional\n+listType=atomic",
+		"mode":              "Mode defines what is restored from the snapshot, defaults to Full\n+optional",
+		"includeVolumes":    "IncludeVolumes is the list of volumes to restore, all the volumes\nof the snapshot are restored if empty.\nVolumes that are not restored keep their current disks\n+optional\n+listType=set",
+		"excludeVolumes":    "ExcludeVolumes is the list of volumes not to restore.\nVolumes that are not restored keep their current disks\n+optional\n+listType=set",
+		"newVirtualMachine": "NewVirtualMachine holds the options applied to the target when it does not\nexist and is created from the snapshot, so that it can run next to the source\n+optional",
+	}
+}
+
+func (NewVirtualMachineOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "NewVirtualMachineOptions are the options applied to a VirtualMachine created by a restore",
+		"newMacAddresses": "NewMacAddresses manually sets that target interfaces' mac addresses. The key is the interface name and the\nvalue is the new mac address. If this field is not specified, a new MAC address will\nbe generated automatically, as for any interface that is not included in this map.\n+optional",
+		"newSMBiosSerial": "NewSMBiosSerial manually sets that target's SMbios serial. If this field is not specified, a new serial will\nbe generated automatically.\n+optional",
+		"newVolumeNames":  "NewVolumeNames manually sets the names of the restored PVCs and DataVolumes. The key is the volume name\nand the value is the new name. Restored volumes that are not included in this map get a generated name.\n+optional",
 	}
 }
 
@@ -1768,6 +1768,39 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) RemoveInterface(arg0, arg1, arg
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveInterface", arg0, arg1, arg2)
 }
 
+func (_m *MockVirtualMachineInterface) Timeline(ctx context.Context, name string) (*v120.VirtualMachineTimeline, error) {
+	ret := _m.ctrl.Call(_m, "Timeline", ctx, name)
+	ret0, _ := ret[0].(*v120.VirtualMachineTimeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) Timeline(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Timeline", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInterface) InstancetypeDiff(ctx context.Context, name string) (*v120.VirtualMachineInstancetypeDiff, error) {
+	ret := _m.ctrl.Call(_m, "InstancetypeDiff", ctx, name)
+	ret0, _ := ret[0].(*v120.VirtualMachineInstancetypeDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) InstancetypeDiff(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "InstancetypeDiff", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInterface) InstancetypeUpgrade(ctx context.Context, name string, upgradeOptions *v120.InstancetypeUpgradeOptions) (*v120.VirtualMachineInstancetypeDiff, error) {
+	ret := _m.ctrl.Call(_m, "InstancetypeUpgrade", ctx, name, upgradeOptions)
+	ret0, _ := ret[0].(*v120.VirtualMachineInstancetypeDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) InstancetypeUpgrade(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "InstancetypeUpgrade", arg0, arg1, arg2)
+}
+
 // Mock of VirtualMachineInstanceMigrationInterface interface
 type MockVirtualMachineInstanceMigrationInterface struct {
 	ctrl     *gomock.Controller
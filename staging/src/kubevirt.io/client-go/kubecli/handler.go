@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -214,6 +215,18 @@ func (v *virtHandlerConn) Pod() (pod *v1.Pod, err error) {
 	return v.pod, err
 }
 
+// VirtHandlerResponseError is returned when virt-handler answers a request
+// with an unexpected status code, the body holds the error it reported
+type VirtHandlerResponseError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *VirtHandlerResponseError) Error() string {
+	return fmt.Sprintf("unexpected return code %d (%s)", e.StatusCode, e.Status)
+}
+
 func (v *virtHandlerConn) doRequest(req *http.Request) (response string, err error) {
 	resp, err := v.httpClient.Do(req)
 	if err != nil {
@@ -222,7 +235,12 @@ func (v *virtHandlerConn) doRequest(req *http.Request) (response string, err err
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return "", fmt.Errorf("unexpected return code %d (%s)", resp.StatusCode, resp.Status)
+		body, _ := io.ReadAll(resp.Body)
+		return "", &VirtHandlerResponseError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       strings.TrimSpace(string(body)),
+		}
 	}
 
 	responseBytes, err := io.ReadAll(resp.Body)
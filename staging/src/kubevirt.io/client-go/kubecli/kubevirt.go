@@ -303,6 +303,9 @@ type VirtualMachineInterface interface {
 	RemoveMemoryDump(ctx context.Context, name string) error
 	AddInterface(ctx context.Context, name string, addInterfaceOptions *v1.AddInterfaceOptions) error
 	RemoveInterface(ctx context.Context, name string, removeInterfaceOptions *v1.RemoveInterfaceOptions) error
+	Timeline(ctx context.Context, name string) (*v1.VirtualMachineTimeline, error)
+	InstancetypeDiff(ctx context.Context, name string) (*v1.VirtualMachineInstancetypeDiff, error)
+	InstancetypeUpgrade(ctx context.Context, name string, upgradeOptions *v1.InstancetypeUpgradeOptions) (*v1.VirtualMachineInstancetypeDiff, error)
 }
 
 type VirtualMachineInstanceMigrationInterface interface {
@@ -307,3 +307,42 @@ func (v *vm) RemoveInterface(ctx context.Context, name string, removeInterfaceOp
 
 	return v.restClient.Put().RequestURI(uri).Body(JSON).Do(ctx).Error()
 }
+
+// Timeline returns the chronologically ordered lifecycle of the VirtualMachine
+func (v *vm) Timeline(ctx context.Context, name string) (*v1.VirtualMachineTimeline, error) {
+	uri := fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion, v.namespace, name, "timeline")
+	timeline := &v1.VirtualMachineTimeline{}
+	err := v.restClient.Get().
+		AbsPath(uri).
+		Do(ctx).
+		Into(timeline)
+	return timeline, err
+}
+
+// InstancetypeDiff compares the instancetype and preference revisions of the VirtualMachine with the current objects
+func (v *vm) InstancetypeDiff(ctx context.Context, name string) (*v1.VirtualMachineInstancetypeDiff, error) {
+	uri := fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion, v.namespace, name, "instancetypediff")
+	diff := &v1.VirtualMachineInstancetypeDiff{}
+	err := v.restClient.Get().
+		AbsPath(uri).
+		Do(ctx).
+		Into(diff)
+	return diff, err
+}
+
+// InstancetypeUpgrade moves the VirtualMachine to the current revisions of its instancetype and preference
+// and returns the difference which was upgraded
+func (v *vm) InstancetypeUpgrade(ctx context.Context, name string, upgradeOptions *v1.InstancetypeUpgradeOptions) (*v1.VirtualMachineInstancetypeDiff, error) {
+	uri := fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion, v.namespace, name, "instancetypeupgrade")
+	optsJson, err := json.Marshal(upgradeOptions)
+	if err != nil {
+		return nil, err
+	}
+	diff := &v1.VirtualMachineInstancetypeDiff{}
+	err = v.restClient.Put().
+		AbsPath(uri).
+		Body(optsJson).
+		Do(ctx).
+		Into(diff)
+	return diff, err
+}
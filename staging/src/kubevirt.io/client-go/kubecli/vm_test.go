@@ -243,6 +243,70 @@ var _ = Describe("Kubevirt VirtualMachine Client", func() {
 		Entry("with proxied server URL", proxyPath),
 	)
 
+	DescribeTable("should fetch the timeline of a VirtualMachine", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		timeline := &v1.VirtualMachineTimeline{
+			Entries: []v1.VirtualMachineTimelineEntry{{Source: v1.VirtualMachineTimelineSourcePhase, Reason: "Running"}},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", path.Join(proxyPath, subVMPath, "timeline")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, timeline),
+		))
+		fetched, err := client.VirtualMachine(k8sv1.NamespaceDefault).Timeline(context.Background(), "testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetched.Entries).To(Equal(timeline.Entries))
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
+	DescribeTable("should fetch the instancetype diff of a VirtualMachine", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		diff := &v1.VirtualMachineInstancetypeDiff{
+			Instancetype: &v1.InstancetypeRevisionDiff{Name: "instancetype", UpToDate: true},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", path.Join(proxyPath, subVMPath, "instancetypediff")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, diff),
+		))
+		fetched, err := client.VirtualMachine(k8sv1.NamespaceDefault).InstancetypeDiff(context.Background(), "testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetched.Instancetype).To(Equal(diff.Instancetype))
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
+	DescribeTable("should upgrade the instancetype of a VirtualMachine", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		opts := &v1.InstancetypeUpgradeOptions{DryRun: []string{k8smetav1.DryRunAll}}
+		diff := &v1.VirtualMachineInstancetypeDiff{
+			Instancetype: &v1.InstancetypeRevisionDiff{Name: "instancetype", RevisionName: "revision"},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", path.Join(proxyPath, subVMPath, "instancetypeupgrade")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, diff),
+		))
+		upgraded, err := client.VirtualMachine(k8sv1.NamespaceDefault).InstancetypeUpgrade(context.Background(), "testvm", opts)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upgraded.Instancetype).To(Equal(diff.Instancetype))
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
 	AfterEach(func() {
 		server.Close()
 	})
@@ -2531,7 +2531,7 @@ spec:
 			if len(originalKv.Spec.WorkloadUpdateStrategy.WorkloadUpdateMethods) > 0 {
 				hasWorkloadUpdates = true
 			}
-			expectedPromRuleSpec := components.NewPrometheusRuleSpec(flags.KubeVirtInstallNamespace, hasWorkloadUpdates)
+			expectedPromRuleSpec := components.NewPrometheusRuleSpec(flags.KubeVirtInstallNamespace, hasWorkloadUpdates, originalKv.Spec.Alerting)
 			Expect(prometheusRule.Spec).To(Equal(*expectedPromRuleSpec))
 		})
 	})
@@ -49,6 +49,7 @@ func main() {
 	handler := domainstats.Handler(1)
 	RegisterFakeDomainCollector()
 	RegisterFakeVMCollector()
+	RegisterFakeVMExportCollector()
 	RegisterFakeMigrationsCollector()
 
 	req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
@@ -154,6 +155,11 @@ func getMetricsNotIncludeInEndpointByDefault() metricList {
 			description: "Indication for a VirtualMachine that its eviction strategy is set to Live Migration but is not migratable.",
 			mType:       "Gauge",
 		},
+		{
+			name:        "kubevirt_vmi_guest_agent_connected",
+			description: "Indication for a running VirtualMachineInstance that its guest agent is connected.",
+			mType:       "Gauge",
+		},
 	}
 
 	for _, rule := range components.GetRecordingRules("") {
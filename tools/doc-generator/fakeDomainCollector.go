@@ -72,7 +72,12 @@ func (fc fakeDomainCollector) Collect(ch chan<- prometheus.Metric) {
 			NodeName: "test",
 		},
 	}
-	ps.Report("test", &vmi, &domainstats.VirtualMachineInstanceStats{DomainStats: &out, FsStats: fs})
+	guestMetrics := &k6tv1.VirtualMachineInstanceGuestMetrics{
+		Load:      &k6tv1.VirtualMachineInstanceGuestLoad{},
+		CPUStats:  []k6tv1.VirtualMachineInstanceGuestCPUStats{{CPU: 0}},
+		DiskStats: []k6tv1.VirtualMachineInstanceGuestDiskStats{{Name: "vda"}},
+	}
+	ps.Report("test", &vmi, &domainstats.VirtualMachineInstanceStats{DomainStats: &out, FsStats: fs, GuestMetrics: guestMetrics})
 }
 
 type fakeDomainIdentifier struct {
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+
+	"kubevirt.io/kubevirt/pkg/monitoring/vmexportstats"
+)
+
+type fakeVMExportCollector struct {
+}
+
+func (fc fakeVMExportCollector) Describe(_ chan<- *prometheus.Desc) {
+}
+
+// Collect needs to report all metrics to see it in docs
+func (fc fakeVMExportCollector) Collect(ch chan<- prometheus.Metric) {
+	ps := vmexportstats.NewPrometheusScraper(ch)
+
+	vmExports := []*exportv1.VirtualMachineExport{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-export"},
+			Status: &exportv1.VirtualMachineExportStatus{
+				Phase: exportv1.Ready,
+				Volumes: []exportv1.VirtualMachineExportVolumeProgress{
+					{Name: "test-volume"},
+				},
+			},
+		},
+	}
+
+	ps.Report(vmExports)
+}
+
+func RegisterFakeVMExportCollector() {
+	prometheus.MustRegister(fakeVMExportCollector{})
+}